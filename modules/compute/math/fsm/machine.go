@@ -160,6 +160,19 @@ func (m *Machine) Reset() {
 	m.current = m.initial
 }
 
+// Restore moves the machine to the given state without firing a transition.
+// It lets callers rehydrate a machine from a persisted state ID.
+func (m *Machine) Restore(state string) error {
+	_, exists := m.states[state]
+	if !exists {
+		return ErrFSM(ErrStateNotFound)
+	}
+
+	m.current = state
+
+	return nil
+}
+
 // States returns all states sorted by ID.
 func (m *Machine) States() []State {
 	result := make([]State, 0, len(m.states))
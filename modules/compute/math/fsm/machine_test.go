@@ -619,6 +619,51 @@ func TestReset_idempotent(t *testing.T) {
 	}
 }
 
+func TestRestore(t *testing.T) {
+	t.Parallel()
+
+	m, err := newTrafficLight()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = m.Restore("yellow")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if m.Current() != "yellow" {
+		t.Fatalf("expected %q, got %q", "yellow", m.Current())
+	}
+
+	err = m.Send("next", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if m.Current() != "red" {
+		t.Fatalf("expected %q, got %q", "red", m.Current())
+	}
+}
+
+func TestRestore_unknown_state(t *testing.T) {
+	t.Parallel()
+
+	m, err := newTrafficLight()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = m.Restore("blue")
+	if !errors.Is(err, ErrStateNotFound) {
+		t.Fatalf("expected ErrStateNotFound, got %v", err)
+	}
+
+	if m.Current() != "red" {
+		t.Fatalf("expected %q unchanged, got %q", "red", m.Current())
+	}
+}
+
 func TestNewMachine_error_is_typed(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,107 @@
+package crypto
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+// signatureVersion prefixes the signing input so the layout can evolve
+// without old signatures verifying against a new one.
+const signatureVersion = "yarumo.messaging.crypto.v1"
+
+// headerAbsent and headerPresent mark in the associated data whether
+// a named header carries a value.
+const (
+	headerAbsent  byte = 0
+	headerPresent byte = 1
+)
+
+// headerFields maps the recognised messaging.Headers field names to
+// the string form bound as associated data. Names absent from this
+// table are treated as Custom map keys.
+var headerFields = map[string]func(h messaging.Headers) string{
+	"MessageID":     func(h messaging.Headers) string { return h.MessageID },
+	"CorrelationID": func(h messaging.Headers) string { return h.CorrelationID },
+	"CausationID":   func(h messaging.Headers) string { return h.CausationID },
+	"ReplyTo":       func(h messaging.Headers) string { return h.ReplyTo },
+	"Type":          func(h messaging.Headers) string { return h.Type },
+	"Source":        func(h messaging.Headers) string { return h.Source },
+	"ContentType":   func(h messaging.Headers) string { return h.ContentType },
+	"Priority":      func(h messaging.Headers) string { return strconv.Itoa(int(h.Priority)) },
+	"ExpirationTime": func(h messaging.Headers) string {
+		if h.ExpirationTime.IsZero() {
+			return ""
+		}
+
+		return h.ExpirationTime.UTC().Format(time.RFC3339Nano)
+	},
+}
+
+// associatedData returns the AEAD associated data for h: every named
+// header as a length-prefixed name, a presence byte and, when present,
+// its length-prefixed value, in order. Headers fields are always
+// present; a Custom key is absent when missing or nil, so removing it
+// is told apart from setting it to "". Custom values must be strings —
+// any other type is rejected with ErrHeaderInvalid rather than
+// formatted, since its %v form would change with the Go type a codec
+// decodes it into.
+func associatedData(h messaging.Headers, names []string) ([]byte, error) {
+	var out []byte
+
+	for _, name := range names {
+		out = appendField(out, name)
+
+		field, ok := headerFields[name]
+		if ok {
+			out = append(out, headerPresent)
+			out = appendField(out, field(h))
+
+			continue
+		}
+
+		raw, found := h.Custom[name]
+		if !found || raw == nil {
+			out = append(out, headerAbsent)
+
+			continue
+		}
+
+		value, ok := raw.(string)
+		if !ok {
+			return nil, fmt.Errorf("%w: %q is %T", ErrHeaderInvalid, name, raw)
+		}
+
+		out = append(out, headerPresent)
+		out = appendField(out, value)
+	}
+
+	return out, nil
+}
+
+// signingInput returns the bytes covered by Sealed.Signature: the
+// version, every Sealed field except the signature and the associated
+// data, each length-prefixed.
+func signingInput(sealed Sealed, aad []byte) []byte {
+	out := appendField(nil, signatureVersion)
+
+	for _, field := range []string{sealed.KeyID, sealed.Algorithm, sealed.SignerKeyID, sealed.SignerAlgorithm} {
+		out = appendField(out, field)
+	}
+
+	out = appendField(out, string(aad))
+	out = appendField(out, string(sealed.Ciphertext))
+
+	return out
+}
+
+// appendField appends field to out prefixed with its length as a
+// big-endian uint32, so no two field sequences share an encoding.
+func appendField(out []byte, field string) []byte {
+	out = binary.BigEndian.AppendUint32(out, uint32(len(field))) //nolint:gosec // header and payload sizes are far below 4 GiB
+
+	return append(out, field...)
+}
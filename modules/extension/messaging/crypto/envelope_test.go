@@ -0,0 +1,110 @@
+package crypto
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+func TestAssociatedData(t *testing.T) {
+	t.Parallel()
+
+	names := []string{"MessageID", "Priority", "ExpirationTime", "tenant"}
+	base := messaging.Headers{
+		MessageID:      "m-1",
+		Priority:       3,
+		ExpirationTime: time.Date(2026, 1, 2, 3, 4, 5, 0, time.FixedZone("COT", -5*3600)),
+		Custom:         map[string]any{"tenant": "acme", "ignored": 7},
+	}
+
+	aad := aadOf(t, base, names)
+
+	t.Run("unnamed headers and instant-equal times keep the AAD", func(t *testing.T) {
+		t.Parallel()
+
+		same := base
+		same.ExpirationTime = base.ExpirationTime.UTC()
+		same.Custom = map[string]any{"tenant": "acme"}
+		same.Timestamp = time.Now()
+
+		if !bytes.Equal(aad, aadOf(t, same, names)) {
+			t.Fatal("expected the AAD to be kept")
+		}
+	})
+
+	t.Run("changed headers change the AAD", func(t *testing.T) {
+		t.Parallel()
+
+		changes := []func(h *messaging.Headers){
+			func(h *messaging.Headers) { h.MessageID = "m-2" },
+			func(h *messaging.Headers) { h.Priority = 4 },
+			func(h *messaging.Headers) { h.ExpirationTime = time.Time{} },
+			func(h *messaging.Headers) { h.Custom = nil },
+			func(h *messaging.Headers) { h.Custom = map[string]any{"tenant": "other"} },
+		}
+
+		for i, change := range changes {
+			h := base
+			change(&h)
+
+			if bytes.Equal(aad, aadOf(t, h, names)) {
+				t.Fatalf("change %d: expected the AAD to differ", i)
+			}
+		}
+	})
+
+	t.Run("an absent Custom header differs from an empty one", func(t *testing.T) {
+		t.Parallel()
+
+		absent := aadOf(t, messaging.Headers{}, []string{"tenant"})
+		empty := aadOf(t, messaging.Headers{Custom: map[string]any{"tenant": ""}}, []string{"tenant"})
+		null := aadOf(t, messaging.Headers{Custom: map[string]any{"tenant": nil}}, []string{"tenant"})
+
+		if bytes.Equal(absent, empty) {
+			t.Fatal("expected absent and empty tenant to differ")
+		}
+
+		if !bytes.Equal(absent, null) {
+			t.Fatal("expected a nil tenant to count as absent")
+		}
+	})
+
+	t.Run("non-string Custom values are rejected", func(t *testing.T) {
+		t.Parallel()
+
+		for _, raw := range []any{7, float64(7), true, []string{"a"}} {
+			_, err := associatedData(messaging.Headers{Custom: map[string]any{"tenant": raw}}, []string{"tenant"})
+			if !errors.Is(err, ErrHeaderInvalid) {
+				t.Fatalf("%T: expected ErrHeaderInvalid, got %v", raw, err)
+			}
+		}
+	})
+}
+
+func TestSigningInput(t *testing.T) {
+	t.Parallel()
+
+	sealed := Sealed{KeyID: "k1", Algorithm: "AES_256_GCM", Ciphertext: []byte("ct"), SignerKeyID: "s1", SignerAlgorithm: "HMAC"}
+	input := signingInput(sealed, []byte("aad"))
+
+	shifted := sealed
+	shifted.KeyID, shifted.Algorithm = "k1A", "ES_256_GCM"
+
+	withSignature := sealed
+	withSignature.Signature = []byte("sig")
+
+	if bytes.Equal(input, signingInput(shifted, []byte("aad"))) {
+		t.Fatal("expected field boundaries to be part of the input")
+	}
+
+	if !bytes.Equal(input, signingInput(withSignature, []byte("aad"))) {
+		t.Fatal("expected the signature itself to be excluded")
+	}
+
+	if bytes.Equal(input, signingInput(sealed, []byte("other"))) {
+		t.Fatal("expected the associated data to be covered")
+	}
+}
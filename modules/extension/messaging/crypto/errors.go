@@ -0,0 +1,79 @@
+package crypto
+
+import (
+	"errors"
+	"fmt"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+	cerrs "github.com/guidomantilla/yarumo/core/common/errs"
+)
+
+// CryptoType is the error domain identifier for message protection
+// operations.
+const CryptoType = "crypto"
+
+var (
+	_ error = (*Error)(nil)
+)
+
+// Sentinel errors for message protection operations.
+var (
+	// ErrCryptoFailed is the top-level sentinel embedded in every
+	// crypto-domain Error returned by ErrCrypto.
+	ErrCryptoFailed = errors.New("message protection failed")
+	// ErrEncodeFailed indicates that the payload could not be
+	// serialized with the configured format.
+	ErrEncodeFailed = errors.New("payload encoding failed")
+	// ErrDecodeFailed indicates that the decrypted payload could not be
+	// deserialized into T.
+	ErrDecodeFailed = errors.New("payload decoding failed")
+	// ErrEncryptFailed indicates that the AEAD encryption failed.
+	ErrEncryptFailed = errors.New("payload encryption failed")
+	// ErrDecryptFailed indicates that the AEAD decryption failed: wrong
+	// key, tampered ciphertext or tampered associated headers.
+	ErrDecryptFailed = errors.New("payload decryption failed")
+	// ErrSignFailed indicates that the signing key failed to sign.
+	ErrSignFailed = errors.New("envelope signing failed")
+	// ErrSignatureMissing indicates an unsigned envelope reached an
+	// Opener that requires signatures.
+	ErrSignatureMissing = errors.New("envelope signature missing")
+	// ErrSignatureInvalid indicates that the envelope signature did not
+	// verify.
+	ErrSignatureInvalid = errors.New("envelope signature invalid")
+	// ErrKeyUnknown indicates that the envelope names an encryption or
+	// signing key the Opener does not hold, or an algorithm that does
+	// not match the key.
+	ErrKeyUnknown = errors.New("key unknown")
+	// ErrHeaderInvalid indicates that a Headers.Custom value named by
+	// WithAADHeaders is not a string, so it cannot be bound as
+	// associated data.
+	ErrHeaderInvalid = errors.New("associated header is not a string")
+	// ErrForwardFailed indicates that the destination Channel.Send
+	// returned a non-nil error.
+	ErrForwardFailed = errors.New("forward to destination failed")
+)
+
+// Error is the domain error type for message protection operations.
+type Error struct {
+	cerrs.TypedError
+}
+
+// Error returns the formatted error string including the type
+// classification.
+func (e *Error) Error() string {
+	cassert.NotNil(e, "error is nil")
+	cassert.NotNil(e.Err, "internal error is nil")
+
+	return fmt.Sprintf("crypto %s error: %s", e.Type, e.Err)
+}
+
+// ErrCrypto wraps the given causes into a domain Error joined with
+// ErrCryptoFailed.
+func ErrCrypto(causes ...error) error {
+	return &Error{
+		TypedError: cerrs.TypedError{
+			Type: CryptoType,
+			Err:  errors.Join(append(causes, ErrCryptoFailed)...),
+		},
+	}
+}
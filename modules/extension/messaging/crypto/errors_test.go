@@ -0,0 +1,41 @@
+package crypto
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestErrCrypto(t *testing.T) {
+	t.Parallel()
+
+	t.Run("wraps causes with ErrCryptoFailed and CryptoType tag", func(t *testing.T) {
+		t.Parallel()
+
+		cause := errors.New("message authentication failed")
+		err := ErrCrypto(ErrDecryptFailed, cause)
+
+		if !errors.Is(err, ErrCryptoFailed) {
+			t.Fatalf("expected wrap of ErrCryptoFailed, got %v", err)
+		}
+
+		if !errors.Is(err, ErrDecryptFailed) || !errors.Is(err, cause) {
+			t.Fatalf("expected wrap of causes, got %v", err)
+		}
+
+		var e *Error
+
+		ok := errors.As(err, &e)
+		if !ok {
+			t.Fatalf("expected *Error, got %T", err)
+		}
+
+		if e.Type != CryptoType {
+			t.Fatalf("Type = %q, want %q", e.Type, CryptoType)
+		}
+
+		if !strings.HasPrefix(err.Error(), "crypto crypto error: ") {
+			t.Fatalf("unexpected message %q", err.Error())
+		}
+	})
+}
@@ -0,0 +1,24 @@
+module github.com/guidomantilla/yarumo/extension/messaging/crypto/examples
+
+go 1.25.5
+
+replace (
+	github.com/guidomantilla/yarumo/core/common => ../../../../core/common
+	github.com/guidomantilla/yarumo/core/crypto => ../../../../core/crypto
+	github.com/guidomantilla/yarumo/extension/messaging/crypto => ..
+	github.com/guidomantilla/yarumo/messaging => ../../../../messaging
+)
+
+require (
+	github.com/guidomantilla/yarumo/core/common v0.0.0-00010101000000-000000000000
+	github.com/guidomantilla/yarumo/core/crypto v0.0.0-00010101000000-000000000000
+	github.com/guidomantilla/yarumo/extension/messaging/crypto v0.0.0-00010101000000-000000000000
+	github.com/guidomantilla/yarumo/messaging v0.0.0-00010101000000-000000000000
+)
+
+require (
+	github.com/google/go-cmp v0.7.0 // indirect
+	golang.org/x/crypto v0.48.0 // indirect
+	golang.org/x/sys v0.42.0 // indirect
+	golang.org/x/text v0.34.0 // indirect
+)
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/guidomantilla/yarumo/core/common/lifecycle"
+	"github.com/guidomantilla/yarumo/core/crypto/ciphers/aead"
+	"github.com/guidomantilla/yarumo/core/crypto/signers/hmacs"
+	"github.com/guidomantilla/yarumo/extension/messaging/crypto"
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+type customer struct {
+	ID    string `json:"id"`
+	Email string `json:"email"`
+}
+
+func main() {
+	ctx := context.Background()
+
+	// In production the secrets come from a secret store; both ends hold
+	// them under the same IDs.
+	secret, err := aead.AES_256_GCM.GenerateKey()
+	if err != nil {
+		fmt.Println("key generation failed:", err)
+		return
+	}
+
+	hmacSecret, err := hmacs.HMAC_with_SHA256.GenerateKey()
+	if err != nil {
+		fmt.Println("key generation failed:", err)
+		return
+	}
+
+	key := crypto.Key{ID: "pii-2026-10", Method: aead.AES_256_GCM, Secret: secret}
+	signing := crypto.NewHMACKey("sig-2026-10", hmacs.HMAC_with_SHA256, hmacSecret)
+
+	customers := messaging.NewPipelineChannel[customer]()
+	wire := messaging.NewPipelineChannel[crypto.Sealed]()
+	opened := messaging.NewPipelineChannel[customer]()
+	rejected := messaging.NewPipelineChannel[messaging.DeadLetter[crypto.Sealed]]()
+
+	_, err = wire.Subscribe(func(_ context.Context, msg messaging.Message[crypto.Sealed]) error {
+		fmt.Printf("crypto: on the wire kid=%s sig=%s %d bytes of ciphertext\n", msg.Payload.KeyID, msg.Payload.SignerKeyID, len(msg.Payload.Ciphertext))
+		return nil
+	})
+	if err != nil {
+		fmt.Println("subscribe failed:", err)
+		return
+	}
+
+	_, err = opened.Subscribe(func(_ context.Context, msg messaging.Message[customer]) error {
+		fmt.Printf("crypto: opened %+v\n", msg.Payload)
+		return nil
+	})
+	if err != nil {
+		fmt.Println("subscribe failed:", err)
+		return
+	}
+
+	_, err = rejected.Subscribe(func(_ context.Context, msg messaging.Message[messaging.DeadLetter[crypto.Sealed]]) error {
+		fmt.Println("crypto: rejected, signature invalid:", errors.Is(msg.Payload.LastError, crypto.ErrSignatureInvalid))
+		return nil
+	})
+	if err != nil {
+		fmt.Println("subscribe failed:", err)
+		return
+	}
+
+	sealer := crypto.NewSealer("customers-seal", customers, wire, key, crypto.WithSigningKey(signing))
+	opener := crypto.NewOpener("customers-open", wire, opened, []crypto.Key{key},
+		crypto.WithVerificationKeys(signing),
+		crypto.WithDLQChannel(rejected),
+		crypto.WithErrorHandler(messaging.SilentErrorHandler),
+	)
+
+	// Start subscribes synchronously, so the endpoints are wired before
+	// the first Send below.
+	for _, component := range []lifecycle.Component{sealer, opener} {
+		err = component.Start(ctx)
+		if err != nil {
+			fmt.Println("start failed:", err)
+			return
+		}
+		defer func() { _ = component.Stop(ctx) }()
+	}
+
+	msg := messaging.NewMessage(customer{ID: "c-1", Email: "ana@example.com"}, nil)
+	msg.Headers.MessageID = "customer-registered-c-1"
+	msg.Headers.Type = "customer.registered"
+
+	err = customers.Send(ctx, msg)
+	if err != nil {
+		fmt.Println("send failed:", err)
+		return
+	}
+
+	// A forged envelope: right key ID, wrong signature.
+	forged := messaging.Message[crypto.Sealed]{Payload: crypto.Sealed{
+		KeyID: key.ID, Algorithm: key.Method.Name(), Ciphertext: []byte("forged"),
+		SignerKeyID: signing.ID, SignerAlgorithm: signing.Algorithm, Signature: []byte("forged"),
+	}}
+
+	err = wire.Send(ctx, forged)
+	if err != nil {
+		fmt.Println("send failed:", err)
+		return
+	}
+}
@@ -0,0 +1,106 @@
+package crypto
+
+import (
+	"context"
+	"crypto/ed25519"
+	"sync"
+	"testing"
+
+	"github.com/guidomantilla/yarumo/core/crypto/ciphers/aead"
+	ced25519 "github.com/guidomantilla/yarumo/core/crypto/signers/ed25519"
+	"github.com/guidomantilla/yarumo/core/crypto/signers/hmacs"
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+// order is the payload used across the tests.
+type order struct {
+	ID     string  `json:"id"`
+	Amount float64 `json:"amount"`
+}
+
+// newKey returns a fresh AES-256-GCM Key named id.
+func newKey(t *testing.T, id string) Key {
+	t.Helper()
+
+	secret, err := aead.AES_256_GCM.GenerateKey()
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+
+	return Key{ID: id, Method: aead.AES_256_GCM, Secret: secret}
+}
+
+// aadOf returns the associated data of h for names, failing the test
+// when the headers cannot be bound.
+func aadOf(t *testing.T, h messaging.Headers, names []string) []byte {
+	t.Helper()
+
+	aad, err := associatedData(h, names)
+	if err != nil {
+		t.Fatalf("associated data: %v", err)
+	}
+
+	return aad
+}
+
+// newHMACKey returns a fresh HMAC-SHA256 SigningKey named id.
+func newHMACKey(t *testing.T, id string) SigningKey {
+	t.Helper()
+
+	secret, err := hmacs.HMAC_with_SHA256.GenerateKey()
+	if err != nil {
+		t.Fatalf("generate hmac key: %v", err)
+	}
+
+	return NewHMACKey(id, hmacs.HMAC_with_SHA256, secret)
+}
+
+// newEd25519Key returns a fresh Ed25519 private key.
+func newEd25519Key(t *testing.T) ed25519.PrivateKey {
+	t.Helper()
+
+	key, err := ced25519.Ed25519.GenerateKey()
+	if err != nil {
+		t.Fatalf("generate ed25519 key: %v", err)
+	}
+
+	return key
+}
+
+// start starts component and stops it when the test ends.
+func start(t *testing.T, component interface {
+	Start(ctx context.Context) error
+	Stop(ctx context.Context) error
+}) {
+	t.Helper()
+
+	err := component.Start(context.Background())
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+
+	t.Cleanup(func() { _ = component.Stop(context.Background()) })
+}
+
+// captureErrors returns an ErrorHandler recording every error and a
+// function returning the errors recorded so far.
+func captureErrors() (messaging.ErrorHandler, func() []error) {
+	var (
+		mu   sync.Mutex
+		errs []error
+	)
+
+	hook := func(_ context.Context, _ any, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		errs = append(errs, err)
+	}
+
+	return hook, func() []error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return append([]error(nil), errs...)
+	}
+}
@@ -0,0 +1,67 @@
+package crypto
+
+import (
+	"crypto/ed25519"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+	ctypes "github.com/guidomantilla/yarumo/core/common/types"
+	ced25519 "github.com/guidomantilla/yarumo/core/crypto/signers/ed25519"
+	"github.com/guidomantilla/yarumo/core/crypto/signers/hmacs"
+)
+
+// NewHMACKey returns a SigningKey that signs and verifies with the
+// HMAC method and the shared secret, e.g. hmacs.HMAC_with_SHA256. Both
+// ends hold the same key.
+func NewHMACKey(id string, method *hmacs.Method, secret ctypes.Bytes) SigningKey {
+	cassert.NotEmpty(id, "key id is empty")
+	cassert.NotNil(method, "hmac method is nil")
+
+	return SigningKey{
+		ID:        id,
+		Algorithm: method.Name(),
+		Sign: func(data ctypes.Bytes) (ctypes.Bytes, error) {
+			return method.Digest(secret, data)
+		},
+		Verify: func(signature ctypes.Bytes, data ctypes.Bytes) (bool, error) {
+			return method.Validate(secret, signature, data)
+		},
+	}
+}
+
+// NewEd25519Key returns a SigningKey that signs with the private key
+// and verifies with its public half. The Sealer holds this key; the
+// Opener only needs NewEd25519PublicKey.
+func NewEd25519Key(id string, method *ced25519.Method, key ed25519.PrivateKey) SigningKey {
+	cassert.NotEmpty(id, "key id is empty")
+	cassert.NotNil(method, "ed25519 method is nil")
+
+	var public ed25519.PublicKey
+
+	// A malformed key is reported by Sign and Verify instead of making
+	// Public panic here.
+	if len(key) == ed25519.PrivateKeySize {
+		public, _ = key.Public().(ed25519.PublicKey)
+	}
+
+	signing := NewEd25519PublicKey(id, method, public)
+	signing.Sign = func(data ctypes.Bytes) (ctypes.Bytes, error) {
+		return method.Sign(&key, data)
+	}
+
+	return signing
+}
+
+// NewEd25519PublicKey returns a verification-only SigningKey for the
+// Opener.
+func NewEd25519PublicKey(id string, method *ced25519.Method, key ed25519.PublicKey) SigningKey {
+	cassert.NotEmpty(id, "key id is empty")
+	cassert.NotNil(method, "ed25519 method is nil")
+
+	return SigningKey{
+		ID:        id,
+		Algorithm: method.Name(),
+		Verify: func(signature ctypes.Bytes, data ctypes.Bytes) (bool, error) {
+			return method.Verify(&key, signature, data)
+		},
+	}
+}
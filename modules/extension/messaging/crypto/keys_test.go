@@ -0,0 +1,85 @@
+package crypto
+
+import (
+	"crypto/ed25519"
+	"testing"
+
+	ced25519 "github.com/guidomantilla/yarumo/core/crypto/signers/ed25519"
+	"github.com/guidomantilla/yarumo/core/crypto/signers/hmacs"
+)
+
+func TestNewHMACKey(t *testing.T) {
+	t.Parallel()
+
+	key := newHMACKey(t, "s1")
+
+	if key.ID != "s1" || key.Algorithm != hmacs.HMAC_with_SHA256.Name() {
+		t.Fatalf("unexpected key %+v", key)
+	}
+
+	signature, err := key.Sign([]byte("data"))
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+
+	valid, err := key.Verify(signature, []byte("data"))
+	if err != nil || !valid {
+		t.Fatalf("expected a valid signature, got %v, %v", valid, err)
+	}
+
+	valid, _ = newHMACKey(t, "s1").Verify(signature, []byte("data"))
+	if valid {
+		t.Fatal("expected another secret to reject the signature")
+	}
+}
+
+func TestNewEd25519Key(t *testing.T) {
+	t.Parallel()
+
+	t.Run("signs with the private key and verifies with the public one", func(t *testing.T) {
+		t.Parallel()
+
+		private := newEd25519Key(t)
+		public, _ := private.Public().(ed25519.PublicKey)
+
+		signer := NewEd25519Key("e1", ced25519.Ed25519, private)
+		verifier := NewEd25519PublicKey("e1", ced25519.Ed25519, public)
+
+		if verifier.Sign != nil || verifier.Algorithm != ced25519.Ed25519.Name() {
+			t.Fatalf("expected a verification-only key, got %+v", verifier)
+		}
+
+		signature, err := signer.Sign([]byte("data"))
+		if err != nil {
+			t.Fatalf("sign: %v", err)
+		}
+
+		for _, key := range []SigningKey{signer, verifier} {
+			valid, err := key.Verify(signature, []byte("data"))
+			if err != nil || !valid {
+				t.Fatalf("expected a valid signature, got %v, %v", valid, err)
+			}
+		}
+
+		valid, _ := verifier.Verify(signature, []byte("tampered"))
+		if valid {
+			t.Fatal("expected tampered data to be rejected")
+		}
+	})
+
+	t.Run("reports a malformed private key instead of panicking", func(t *testing.T) {
+		t.Parallel()
+
+		key := NewEd25519Key("e1", ced25519.Ed25519, ed25519.PrivateKey("short"))
+
+		_, err := key.Sign([]byte("data"))
+		if err == nil {
+			t.Fatal("expected a signing error")
+		}
+
+		_, err = key.Verify(make([]byte, ed25519.SignatureSize), []byte("data"))
+		if err == nil {
+			t.Fatal("expected a verification error")
+		}
+	})
+}
@@ -0,0 +1,293 @@
+package crypto
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+	"github.com/guidomantilla/yarumo/core/common/lifecycle"
+	"github.com/guidomantilla/yarumo/messaging"
+	"github.com/guidomantilla/yarumo/messaging/codec"
+)
+
+// opener is the Opener implementation. It owns a single subscription
+// on the source channel (registered in Start, cancelled in Stop) and
+// forwards the original Message[T] for every Message[Sealed] that
+// verifies and decrypts.
+type opener[T any] struct {
+	name              string
+	src               messaging.Channel[Sealed]
+	dst               messaging.Channel[T]
+	keys              map[string]Key
+	verificationKeys  map[string]SigningKey
+	requireSignatures bool
+	format            codec.Format
+	aadHeaders        []string
+	dlq               messaging.Channel[messaging.DeadLetter[Sealed]]
+	errorHandler      messaging.ErrorHandler
+	clock             messaging.Clock
+	stats             messaging.StatsRecorder
+
+	done      chan struct{}
+	startOnce sync.Once
+	stopOnce  sync.Once
+	doneOnce  sync.Once
+
+	mu     sync.Mutex
+	cancel messaging.Cancel
+}
+
+// NewOpener constructs an Opener that subscribes to src, verifies and
+// decrypts every Sealed envelope with the key it names and forwards the
+// deserialized payload to dst with the headers unchanged. keys lists
+// every encryption key accepted, current and previous, so Sealers can
+// rotate without a coordinated switch. The endpoint is not running on
+// return; call lifecycle.Build (or Start directly) to register the
+// subscription.
+//
+// name is used in lifecycle logs and must be non-empty. src, dst and
+// at least one key are mandatory; keys without an ID or a Method are
+// skipped, and a later key replaces an earlier one with the same ID.
+//
+// Options:
+//   - WithFormat: payload format (default JSON).
+//   - WithAADHeaders: headers bound as associated data.
+//   - WithVerificationKeys: accepted signing keys; makes signatures mandatory.
+//   - WithDLQChannel: Channel[DeadLetter[Sealed]] for envelopes that fail.
+//   - WithErrorHandler: failure hook (default messaging.DefaultErrorHandler).
+//   - WithClock: clock stamping dead letters (default messaging.SystemClock).
+func NewOpener[T any](name string, src messaging.Channel[Sealed], dst messaging.Channel[T], keys []Key, opts ...Option) Opener[T] {
+	cassert.NotEmpty(name, "name is empty")
+	cassert.NotNil(src, "source channel is nil")
+	cassert.NotNil(dst, "destination channel is nil")
+	cassert.NotEmpty(keys, "keys are empty")
+
+	options := NewOptions(opts...)
+
+	var dlq messaging.Channel[messaging.DeadLetter[Sealed]]
+
+	if options.dlq != nil {
+		typed, ok := options.dlq.(messaging.Channel[messaging.DeadLetter[Sealed]])
+		cassert.True(ok, "WithDLQChannel type parameter does not match Sealed")
+
+		dlq = typed
+	}
+
+	byID := make(map[string]Key, len(keys))
+
+	for _, key := range keys {
+		if key.ID != "" && key.Method != nil {
+			byID[key.ID] = key
+		}
+	}
+
+	if options.requireSignatures {
+		cassert.NotEmpty(options.verificationKeys, "verification keys are empty")
+	}
+
+	verificationKeys := make(map[string]SigningKey, len(options.verificationKeys))
+
+	for _, key := range options.verificationKeys {
+		verificationKeys[key.ID] = key
+	}
+
+	o := &opener[T]{
+		name:              name,
+		src:               src,
+		dst:               dst,
+		keys:              byID,
+		verificationKeys:  verificationKeys,
+		requireSignatures: options.requireSignatures,
+		format:            options.format,
+		aadHeaders:        options.aadHeaders,
+		dlq:               dlq,
+		errorHandler:      options.errorHandler,
+		clock:             options.clock,
+		done:              make(chan struct{}),
+	}
+
+	o.stats.UseClock(options.clock)
+
+	return o
+}
+
+// Name returns the endpoint's identity used in lifecycle logs.
+func (o *opener[T]) Name() string {
+	cassert.NotNil(o, "opener is nil")
+
+	return o.name
+}
+
+// Start registers the opening handler as a subscriber on the source
+// channel. Start is idempotent — a second invocation returns nil
+// without re-subscribing.
+func (o *opener[T]) Start(_ context.Context) error {
+	cassert.NotNil(o, "opener is nil")
+
+	var startErr error
+
+	o.startOnce.Do(func() {
+		cancel, err := o.src.Subscribe(o.handle)
+		if err != nil {
+			startErr = lifecycle.ErrStart(err)
+
+			return
+		}
+
+		o.mu.Lock()
+		o.cancel = cancel
+		o.mu.Unlock()
+	})
+
+	return startErr
+}
+
+// Stop cancels the source-channel subscription and closes Done. Stop
+// is idempotent. It returns lifecycle.ErrShutdown wrapping
+// lifecycle.ErrShutdownTimeout when ctx is already expired on entry.
+func (o *opener[T]) Stop(ctx context.Context) error {
+	cassert.NotNil(o, "opener is nil")
+
+	o.stopOnce.Do(func() {
+		o.mu.Lock()
+		cancel := o.cancel
+		o.cancel = nil
+		o.mu.Unlock()
+
+		if cancel != nil {
+			cancel()
+		}
+
+		o.doneOnce.Do(func() { close(o.done) })
+	})
+
+	select {
+	case <-ctx.Done():
+		return lifecycle.ErrShutdown(lifecycle.ErrShutdownTimeout, ctx.Err())
+	default:
+		return nil
+	}
+}
+
+// Done returns the channel that is closed after Stop has been called.
+func (o *opener[T]) Done() <-chan struct{} {
+	cassert.NotNil(o, "opener is nil")
+
+	return o.done
+}
+
+// Stats returns a snapshot of the Opener runtime statistics.
+// Subscribers is 1 while the source subscription is active.
+func (o *opener[T]) Stats() messaging.Stats {
+	cassert.NotNil(o, "opener is nil")
+
+	stats := o.stats.Snapshot()
+
+	o.mu.Lock()
+	if o.cancel != nil {
+		stats.Subscribers = 1
+	}
+	o.mu.Unlock()
+
+	return stats
+}
+
+// handle is the Handler[Sealed] subscribed on the source channel.
+// Failures are dead-lettered and reported through the ErrorHandler;
+// the function itself always returns nil.
+func (o *opener[T]) handle(ctx context.Context, msg messaging.Message[Sealed]) error {
+	end := o.stats.Begin()
+	defer end(nil)
+
+	err := o.open(ctx, msg)
+	if err != nil {
+		o.stats.RecordFailure()
+		o.deadLetter(ctx, msg, err)
+
+		if o.errorHandler != nil {
+			o.errorHandler(ctx, msg, err)
+		}
+
+		return nil
+	}
+
+	o.stats.RecordSent()
+
+	return nil
+}
+
+// open verifies and decrypts msg and forwards the result.
+func (o *opener[T]) open(ctx context.Context, msg messaging.Message[Sealed]) error {
+	sealed := msg.Payload
+	aad, err := associatedData(msg.Headers, o.aadHeaders)
+	if err != nil {
+		return ErrCrypto(err)
+	}
+
+	err = o.verify(sealed, aad)
+	if err != nil {
+		return err
+	}
+
+	key, ok := o.keys[sealed.KeyID]
+	if !ok || key.Method.Name() != sealed.Algorithm {
+		return ErrCrypto(fmt.Errorf("%w: encryption key %q (%s)", ErrKeyUnknown, sealed.KeyID, sealed.Algorithm))
+	}
+
+	plain, err := key.Method.Decrypt(key.Secret, sealed.Ciphertext, aad)
+	if err != nil {
+		return ErrCrypto(ErrDecryptFailed, err)
+	}
+
+	var payload T
+
+	err = o.format.Unmarshal(plain, &payload)
+	if err != nil {
+		return ErrCrypto(ErrDecodeFailed, err)
+	}
+
+	err = o.dst.Send(ctx, messaging.Message[T]{Payload: payload, Headers: msg.Headers})
+	if err != nil {
+		return ErrCrypto(ErrForwardFailed, err)
+	}
+
+	return nil
+}
+
+// verify checks the envelope signature when WithVerificationKeys was
+// configured. Signatures stay mandatory even if every key passed to it
+// was unusable: the lookup below then rejects every envelope.
+func (o *opener[T]) verify(sealed Sealed, aad []byte) error {
+	if !o.requireSignatures {
+		return nil
+	}
+
+	if len(sealed.Signature) == 0 {
+		return ErrCrypto(ErrSignatureMissing)
+	}
+
+	key, ok := o.verificationKeys[sealed.SignerKeyID]
+	if !ok || key.Algorithm != sealed.SignerAlgorithm {
+		return ErrCrypto(fmt.Errorf("%w: signing key %q (%s)", ErrKeyUnknown, sealed.SignerKeyID, sealed.SignerAlgorithm))
+	}
+
+	valid, err := key.Verify(sealed.Signature, signingInput(sealed, aad))
+	if err != nil {
+		return ErrCrypto(ErrSignatureInvalid, err)
+	}
+
+	if !valid {
+		return ErrCrypto(ErrSignatureInvalid)
+	}
+
+	return nil
+}
+
+// deadLetter publishes msg to the DLQ best-effort through
+// messaging.PublishDeadLetter, stamped with the endpoint clock.
+func (o *opener[T]) deadLetter(ctx context.Context, msg messaging.Message[Sealed], cause error) {
+	if messaging.PublishDeadLetter(ctx, o.dlq, msg, cause, o.clock.Now()) {
+		o.stats.RecordDeadLetter()
+	}
+}
@@ -0,0 +1,446 @@
+package crypto
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/guidomantilla/yarumo/core/common/lifecycle"
+	cuids "github.com/guidomantilla/yarumo/core/common/uids"
+	ced25519 "github.com/guidomantilla/yarumo/core/crypto/signers/ed25519"
+	"github.com/guidomantilla/yarumo/messaging"
+	"github.com/guidomantilla/yarumo/messaging/messagingtest"
+	"github.com/guidomantilla/yarumo/messaging/stores"
+)
+
+// pair wires src → Sealer → wire → Opener → dst and returns the three
+// channels plus the Opener's dead letters and reported errors.
+type pair struct {
+	src    messaging.Channel[order]
+	wire   *messagingtest.RecordingChannel[Sealed]
+	dst    *messagingtest.RecordingChannel[order]
+	dlq    *messagingtest.RecordingChannel[messaging.DeadLetter[Sealed]]
+	errs   func() []error
+	opener Opener[order]
+}
+
+func newPair(t *testing.T, sealKey Key, openKeys []Key, sealOpts []Option, openOpts ...Option) *pair {
+	t.Helper()
+
+	hook, errs := captureErrors()
+
+	p := &pair{
+		src:  messaging.NewPipelineChannel[order](),
+		wire: messagingtest.NewRecordingChannel[Sealed](),
+		dst:  messagingtest.NewRecordingChannel[order](),
+		dlq:  messagingtest.NewRecordingChannel[messaging.DeadLetter[Sealed]](),
+		errs: errs,
+	}
+
+	start(t, NewSealer("seal", p.src, p.wire, sealKey, sealOpts...))
+
+	p.opener = NewOpener("open", p.wire, p.dst, openKeys, append(openOpts, WithDLQChannel(p.dlq), WithErrorHandler(hook))...)
+	start(t, p.opener)
+
+	return p
+}
+
+// send publishes one order and fails t when Send errors.
+func (p *pair) send(t *testing.T, headers messaging.Headers) {
+	t.Helper()
+
+	err := p.src.Send(context.Background(), messaging.Message[order]{Payload: order{ID: "o-1", Amount: 12.5}, Headers: headers})
+	if err != nil {
+		t.Fatalf("send: %v", err)
+	}
+}
+
+// assertRejected checks that nothing was forwarded and that exactly one
+// dead letter carrying target was published.
+func (p *pair) assertRejected(t *testing.T, target error) {
+	t.Helper()
+
+	if p.dst.Len() != 0 {
+		t.Fatalf("expected nothing to be forwarded, got %+v", p.dst.Payloads())
+	}
+
+	letters := p.dlq.Payloads()
+	if len(letters) != 1 || !errors.Is(letters[0].LastError, target) || !errors.Is(letters[0].LastError, ErrCryptoFailed) {
+		t.Fatalf("expected one dead letter with %v, got %+v", target, letters)
+	}
+
+	if got := p.errs(); len(got) != 1 || !errors.Is(got[0], target) {
+		t.Fatalf("expected one reported %v, got %v", target, got)
+	}
+
+	stats := p.opener.Stats()
+	if stats.Failed != 1 || stats.DeadLettered != 1 || stats.Sent != 0 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
+
+// tamper rewrites every envelope crossing the wire with fn.
+func tamper(wire messaging.Channel[Sealed], fn func(msg *messaging.Message[Sealed])) messaging.Channel[Sealed] {
+	tampered := messaging.NewPipelineChannel[Sealed]()
+
+	_, _ = wire.Subscribe(func(ctx context.Context, msg messaging.Message[Sealed]) error {
+		fn(&msg)
+
+		return tampered.Send(ctx, msg)
+	})
+
+	return tampered
+}
+
+func TestNewOpener(t *testing.T) {
+	t.Parallel()
+
+	t.Run("round-trips payloads and headers", func(t *testing.T) {
+		t.Parallel()
+
+		key := newKey(t, "k1")
+		signing := newHMACKey(t, "s1")
+
+		p := newPair(t, key, []Key{key}, []Option{WithSigningKey(signing)}, WithVerificationKeys(signing))
+		p.send(t, messaging.Headers{MessageID: "m-1", Type: "order.created"})
+
+		msgs := p.dst.Messages()
+		if len(msgs) != 1 || msgs[0].Payload != (order{ID: "o-1", Amount: 12.5}) || msgs[0].Headers.MessageID != "m-1" {
+			t.Fatalf("unexpected output %+v", msgs)
+		}
+
+		stats := p.opener.Stats()
+		if stats.Sent != 1 || stats.Failed != 0 || stats.Subscribers != 1 {
+			t.Fatalf("unexpected stats %+v", stats)
+		}
+	})
+
+	t.Run("verifies Ed25519 signatures with the public key", func(t *testing.T) {
+		t.Parallel()
+
+		key := newKey(t, "k1")
+		private := newEd25519Key(t)
+		public, _ := private.Public().(ed25519.PublicKey)
+
+		p := newPair(t, key, []Key{key},
+			[]Option{WithSigningKey(NewEd25519Key("e1", ced25519.Ed25519, private))},
+			WithVerificationKeys(NewEd25519PublicKey("e1", ced25519.Ed25519, public)),
+		)
+		p.send(t, messaging.Headers{})
+
+		if p.dst.Len() != 1 {
+			t.Fatalf("expected the message to be forwarded, errors %v", p.errs())
+		}
+	})
+
+	t.Run("decrypts with any accepted key during rotation", func(t *testing.T) {
+		t.Parallel()
+
+		previous, current := newKey(t, "k1"), newKey(t, "k2")
+		hook, errs := captureErrors()
+
+		wire := messagingtest.NewRecordingChannel[Sealed]()
+		dst := messagingtest.NewRecordingChannel[order]()
+		start(t, NewOpener("open", wire, dst, []Key{previous, current}, WithErrorHandler(hook)))
+
+		for _, key := range []Key{previous, current} {
+			src := messaging.NewPipelineChannel[order]()
+			start(t, NewSealer("seal-"+key.ID, src, wire, key))
+
+			_ = src.Send(context.Background(), messaging.Message[order]{Payload: order{ID: key.ID}})
+		}
+
+		payloads := dst.Payloads()
+		if len(payloads) != 2 || payloads[0].ID != "k1" || payloads[1].ID != "k2" || len(errs()) != 0 {
+			t.Fatalf("expected both generations to open, got %+v, %v", payloads, errs())
+		}
+	})
+
+	t.Run("rejects envelopes sealed with an unknown key", func(t *testing.T) {
+		t.Parallel()
+
+		instant := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+		p := newPair(t, newKey(t, "k9"), []Key{newKey(t, "k1")}, nil, WithClock(messagingtest.NewFakeClock(instant)))
+		p.send(t, messaging.Headers{})
+		p.assertRejected(t, ErrKeyUnknown)
+
+		if failedAt := p.dlq.Payloads()[0].FailedAt; !failedAt.Equal(instant) {
+			t.Fatalf("expected FailedAt %v from the clock, got %v", instant, failedAt)
+		}
+	})
+
+	t.Run("rejects envelopes whose key secret does not match", func(t *testing.T) {
+		t.Parallel()
+
+		p := newPair(t, newKey(t, "k1"), []Key{newKey(t, "k1")}, nil)
+		p.send(t, messaging.Headers{})
+		p.assertRejected(t, ErrDecryptFailed)
+	})
+
+	t.Run("rejects tampered associated headers", func(t *testing.T) {
+		t.Parallel()
+
+		key := newKey(t, "k1")
+		hook, errs := captureErrors()
+
+		src := messaging.NewPipelineChannel[order]()
+		wire := messagingtest.NewRecordingChannel[Sealed]()
+		dst := messagingtest.NewRecordingChannel[order]()
+
+		start(t, NewSealer("seal", src, wire, key, WithAADHeaders("Type", "tenant")))
+		start(t, NewOpener("open", tamper(wire, func(msg *messaging.Message[Sealed]) {
+			msg.Headers.Custom = map[string]any{"tenant": "other"}
+		}), dst, []Key{key}, WithAADHeaders("Type", "tenant"), WithErrorHandler(hook)))
+
+		_ = src.Send(context.Background(), messaging.Message[order]{Headers: messaging.Headers{Type: "t", Custom: map[string]any{"tenant": "acme"}}})
+
+		if dst.Len() != 0 {
+			t.Fatal("expected nothing to be forwarded")
+		}
+
+		if got := errs(); len(got) != 1 || !errors.Is(got[0], ErrDecryptFailed) {
+			t.Fatalf("expected ErrDecryptFailed, got %v", got)
+		}
+	})
+
+	t.Run("rejects an associated header emptied in transit", func(t *testing.T) {
+		t.Parallel()
+
+		key := newKey(t, "k1")
+		hook, errs := captureErrors()
+
+		src := messaging.NewPipelineChannel[order]()
+		wire := messagingtest.NewRecordingChannel[Sealed]()
+		dst := messagingtest.NewRecordingChannel[order]()
+
+		start(t, NewSealer("seal", src, wire, key, WithAADHeaders("tenant")))
+		start(t, NewOpener("open", tamper(wire, func(msg *messaging.Message[Sealed]) {
+			msg.Headers.Custom = map[string]any{"tenant": ""}
+		}), dst, []Key{key}, WithAADHeaders("tenant"), WithErrorHandler(hook)))
+
+		_ = src.Send(context.Background(), messaging.Message[order]{})
+
+		if dst.Len() != 0 {
+			t.Fatal("expected nothing to be forwarded")
+		}
+
+		if got := errs(); len(got) != 1 || !errors.Is(got[0], ErrDecryptFailed) {
+			t.Fatalf("expected ErrDecryptFailed, got %v", got)
+		}
+	})
+
+	t.Run("rejects unsigned envelopes when verification keys are set", func(t *testing.T) {
+		t.Parallel()
+
+		key := newKey(t, "k1")
+
+		p := newPair(t, key, []Key{key}, nil, WithVerificationKeys(newHMACKey(t, "s1")))
+		p.send(t, messaging.Headers{})
+		p.assertRejected(t, ErrSignatureMissing)
+	})
+
+	t.Run("rejects every envelope when no verification key is usable", func(t *testing.T) {
+		t.Parallel()
+
+		key := newKey(t, "k1")
+		signing := newHMACKey(t, "s1")
+
+		p := newPair(t, key, []Key{key}, []Option{WithSigningKey(signing)}, WithVerificationKeys(SigningKey{Verify: signing.Verify}))
+		p.send(t, messaging.Headers{})
+		p.assertRejected(t, ErrKeyUnknown)
+
+		unsigned := newPair(t, key, []Key{key}, nil, WithVerificationKeys(SigningKey{ID: "s1"}))
+		unsigned.send(t, messaging.Headers{})
+		unsigned.assertRejected(t, ErrSignatureMissing)
+	})
+
+	t.Run("rejects signatures from unknown or forged keys", func(t *testing.T) {
+		t.Parallel()
+
+		key := newKey(t, "k1")
+
+		unknown := newPair(t, key, []Key{key}, []Option{WithSigningKey(newHMACKey(t, "s9"))}, WithVerificationKeys(newHMACKey(t, "s1")))
+		unknown.send(t, messaging.Headers{})
+		unknown.assertRejected(t, ErrKeyUnknown)
+
+		forged := newPair(t, key, []Key{key}, []Option{WithSigningKey(newHMACKey(t, "s1"))}, WithVerificationKeys(newHMACKey(t, "s1")))
+		forged.send(t, messaging.Headers{})
+		forged.assertRejected(t, ErrSignatureInvalid)
+	})
+
+	t.Run("rejects a swapped key id even when the ciphertext would open", func(t *testing.T) {
+		t.Parallel()
+
+		k1, k2 := newKey(t, "k1"), newKey(t, "k2")
+		k2.Secret = k1.Secret
+		signing := newHMACKey(t, "s1")
+		hook, errs := captureErrors()
+
+		src := messaging.NewPipelineChannel[order]()
+		wire := messagingtest.NewRecordingChannel[Sealed]()
+		dst := messagingtest.NewRecordingChannel[order]()
+
+		start(t, NewSealer("seal", src, wire, k1, WithSigningKey(signing)))
+		start(t, NewOpener("open", tamper(wire, func(msg *messaging.Message[Sealed]) {
+			msg.Payload.KeyID = "k2"
+		}), dst, []Key{k1, k2}, WithVerificationKeys(signing), WithErrorHandler(hook)))
+
+		_ = src.Send(context.Background(), messaging.Message[order]{})
+
+		if got := errs(); dst.Len() != 0 || len(got) != 1 || !errors.Is(got[0], ErrSignatureInvalid) {
+			t.Fatalf("expected ErrSignatureInvalid, got %v", got)
+		}
+	})
+
+	t.Run("reports payloads that do not decode into T", func(t *testing.T) {
+		t.Parallel()
+
+		key := newKey(t, "k1")
+		hook, errs := captureErrors()
+
+		src := messaging.NewPipelineChannel[string]()
+		wire := messagingtest.NewRecordingChannel[Sealed]()
+		dst := messagingtest.NewRecordingChannel[order]()
+
+		start(t, NewSealer("seal", src, wire, key))
+		start(t, NewOpener("open", wire, dst, []Key{key}, WithErrorHandler(hook)))
+
+		_ = src.Send(context.Background(), messaging.Message[string]{Payload: "not an order"})
+
+		if got := errs(); dst.Len() != 0 || len(got) != 1 || !errors.Is(got[0], ErrDecodeFailed) {
+			t.Fatalf("expected ErrDecodeFailed, got %v", got)
+		}
+	})
+
+	t.Run("reports forward failures", func(t *testing.T) {
+		t.Parallel()
+
+		key := newKey(t, "k1")
+		hook, errs := captureErrors()
+
+		src := messaging.NewPipelineChannel[order]()
+		wire := messagingtest.NewRecordingChannel[Sealed]()
+		dst := messaging.NewPipelineChannel[order]()
+		_, _ = dst.Subscribe(func(_ context.Context, _ messaging.Message[order]) error { return errors.New("downstream down") })
+
+		start(t, NewSealer("seal", src, wire, key))
+		start(t, NewOpener("open", wire, dst, []Key{key}, WithErrorHandler(hook)))
+
+		_ = src.Send(context.Background(), messaging.Message[order]{})
+
+		if got := errs(); len(got) != 1 || !errors.Is(got[0], ErrForwardFailed) {
+			t.Fatalf("expected ErrForwardFailed, got %v", got)
+		}
+	})
+
+	t.Run("opens envelopes replayed from the dead letter store", func(t *testing.T) {
+		t.Parallel()
+
+		key := newKey(t, "k1")
+		signing := newHMACKey(t, "s1")
+		hook, errs := captureErrors()
+
+		src := messaging.NewPipelineChannel[order]()
+		wire := messaging.NewPipelineChannel[Sealed]()
+		received := messagingtest.NewRecordingChannel[order]()
+		dlq := messaging.NewPipelineChannel[messaging.DeadLetter[Sealed]]()
+		store := stores.NewInMemoryDeadLetterStore[Sealed]()
+		registry := messaging.NewChannelRegistry()
+
+		err := registry.Register("sealed", wire)
+		if err != nil {
+			t.Fatalf("register: %v", err)
+		}
+
+		_, _ = dlq.Subscribe(stores.RecordDeadLetters(store, "sealed"))
+
+		var down atomic.Bool
+
+		down.Store(true)
+
+		dst := messaging.NewPipelineChannel[order]()
+		_, _ = dst.Subscribe(func(ctx context.Context, msg messaging.Message[order]) error {
+			if down.Load() {
+				return errors.New("downstream down")
+			}
+
+			return received.Send(ctx, msg)
+		})
+
+		start(t, NewSealer("seal", src, wire, key, WithSigningKey(signing)))
+		start(t, NewOpener("open", wire, dst, []Key{key}, WithVerificationKeys(signing), WithDLQChannel(dlq), WithErrorHandler(hook)))
+
+		headers := messaging.Headers{MessageID: "m-1", CorrelationID: "c-1", Type: "order.created", Source: "checkout"}
+		_ = src.Send(context.Background(), messaging.Message[order]{Payload: order{ID: "o-1"}, Headers: headers})
+
+		entries, _ := store.List(context.Background(), stores.DeadLetterFilter{Channel: "sealed"})
+		if len(entries) != 1 || !errors.Is(entries[0].Letter.LastError, ErrForwardFailed) {
+			t.Fatalf("expected one forward failure in the store, got %+v", entries)
+		}
+
+		down.Store(false)
+
+		uid := cuids.NewUID("fixed", func() (string, error) { return "m-2", nil })
+
+		_, err = stores.ReplayDeadLetter(context.Background(), store, registry, uid, entries[0].ID)
+		if err != nil {
+			t.Fatalf("replay: %v", err)
+		}
+
+		msgs := received.Messages()
+		if len(msgs) != 1 || msgs[0].Payload.ID != "o-1" || msgs[0].Headers.MessageID != "m-2" || msgs[0].Headers.CausationID != "m-1" {
+			t.Fatalf("expected the replayed message to open, got %+v, errors %v", msgs, errs())
+		}
+	})
+}
+
+func TestOpener_Lifecycle(t *testing.T) {
+	t.Parallel()
+
+	wire := messagingtest.NewRecordingChannel[Sealed]()
+	opener := NewOpener("open", wire, messagingtest.NewRecordingChannel[order](), []Key{newKey(t, "k1")})
+
+	if opener.Name() != "open" {
+		t.Fatalf("unexpected name %q", opener.Name())
+	}
+
+	for range 2 {
+		err := opener.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+	}
+
+	if got := wire.Stats().Subscribers; got != 1 {
+		t.Fatalf("expected 1 subscriber, got %d", got)
+	}
+
+	for range 2 {
+		err := opener.Stop(context.Background())
+		if err != nil {
+			t.Fatalf("stop: %v", err)
+		}
+	}
+
+	select {
+	case <-opener.Done():
+	default:
+		t.Fatal("expected Done to be closed")
+	}
+
+	if got := opener.Stats().Subscribers; got != 0 {
+		t.Fatalf("expected no subscribers after stop, got %d", got)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := opener.Stop(ctx)
+	if !errors.Is(err, lifecycle.ErrShutdownTimeout) {
+		t.Fatalf("expected ErrShutdownTimeout, got %v", err)
+	}
+}
@@ -0,0 +1,149 @@
+package crypto
+
+import (
+	"github.com/guidomantilla/yarumo/messaging"
+	"github.com/guidomantilla/yarumo/messaging/codec"
+)
+
+// Option is a functional option for configuring Options. The Sealer
+// and the Opener share one Option type; each option documents the side
+// it applies to and is ignored by the other.
+type Option func(opts *Options)
+
+// Options holds the configuration for the Sealer and the Opener.
+//
+// dlq is stored as any because the Channel[DeadLetter[X]] it holds is
+// parameterized by the endpoint's input type, which Options itself is
+// not. The constructors type-assert it at build time and report a
+// mismatch via cassert.
+type Options struct {
+	format            codec.Format
+	aadHeaders        []string
+	signingKey        *SigningKey
+	verificationKeys  []SigningKey
+	requireSignatures bool
+	dlq               any
+	errorHandler      messaging.ErrorHandler
+	clock             messaging.Clock
+}
+
+// defaultAADHeaders are the headers bound to the ciphertext when
+// WithAADHeaders is not configured. MessageID is left out on purpose:
+// stores.ReplayDeadLetter gives every replayed message a new one.
+var defaultAADHeaders = []string{"CorrelationID", "Type", "Source"}
+
+// NewOptions creates a new Options with sensible defaults and applies
+// the given options. Defaults: JSON format, associated headers
+// CorrelationID, Type and Source, no signing or verification keys, no
+// DLQ, messaging.DefaultErrorHandler and messaging.SystemClock.
+func NewOptions(opts ...Option) *Options {
+	options := &Options{
+		format:       codec.NewJSONFormat(),
+		aadHeaders:   defaultAADHeaders,
+		errorHandler: messaging.DefaultErrorHandler,
+		clock:        messaging.SystemClock(),
+	}
+
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	return options
+}
+
+// WithFormat sets the codec.Format the Sealer serializes payloads with
+// and the Opener deserializes them with. Both ends must agree. Nil
+// values are ignored.
+func WithFormat(format codec.Format) Option {
+	return func(opts *Options) {
+		if format != nil {
+			opts.format = format
+		}
+	}
+}
+
+// WithAADHeaders replaces the headers bound to the ciphertext as
+// associated data (see the package documentation). Both ends must use
+// the same list, in the same order. Headers rewritten between the two
+// ends, such as MessageID and CausationID on a dead-letter replay, must
+// not be listed. Empty names are skipped; an empty list keeps the
+// current one.
+func WithAADHeaders(names ...string) Option {
+	return func(opts *Options) {
+		var kept []string
+
+		for _, name := range names {
+			if name != "" {
+				kept = append(kept, name)
+			}
+		}
+
+		if len(kept) > 0 {
+			opts.aadHeaders = kept
+		}
+	}
+}
+
+// WithSigningKey makes the Sealer sign every envelope with key. Keys
+// without an ID or a SignFn are ignored. The Opener ignores this
+// option.
+func WithSigningKey(key SigningKey) Option {
+	return func(opts *Options) {
+		if key.ID != "" && key.Sign != nil {
+			opts.signingKey = &key
+		}
+	}
+}
+
+// WithVerificationKeys adds keys the Opener accepts signatures from
+// and makes signatures mandatory. Calls accumulate; keys without an ID
+// or a VerifyFn are ignored, but signatures stay mandatory even when
+// none is left, so every envelope is then rejected. The Sealer ignores
+// this option.
+func WithVerificationKeys(keys ...SigningKey) Option {
+	return func(opts *Options) {
+		opts.requireSignatures = true
+
+		for _, key := range keys {
+			if key.ID != "" && key.Verify != nil {
+				opts.verificationKeys = append(opts.verificationKeys, key)
+			}
+		}
+	}
+}
+
+// WithDLQChannel installs the Dead Letter Channel failed messages are
+// published to. The publication is best-effort: failures of the DLQ
+// Send itself are swallowed, and the ErrorHandler fires independently.
+// The Sealer expects a Channel[DeadLetter[T]] and the Opener a
+// Channel[DeadLetter[Sealed]]; mismatches are caught with cassert at
+// build time. Nil values are ignored.
+func WithDLQChannel[T any](dlq messaging.Channel[messaging.DeadLetter[T]]) Option {
+	return func(opts *Options) {
+		if dlq != nil {
+			opts.dlq = dlq
+		}
+	}
+}
+
+// WithErrorHandler installs the hook fired once per failed message.
+// The default is messaging.DefaultErrorHandler; pass
+// messaging.SilentErrorHandler to opt out. Nil values are ignored.
+func WithErrorHandler(handler messaging.ErrorHandler) Option {
+	return func(opts *Options) {
+		if handler != nil {
+			opts.errorHandler = handler
+		}
+	}
+}
+
+// WithClock sets the clock both endpoints read to stamp
+// DeadLetter.FailedAt and the stats failure timestamps. Nil values are
+// ignored (the system clock is preserved).
+func WithClock(clock messaging.Clock) Option {
+	return func(opts *Options) {
+		if clock != nil {
+			opts.clock = clock
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package crypto
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/guidomantilla/yarumo/messaging"
+	"github.com/guidomantilla/yarumo/messaging/codec"
+	"github.com/guidomantilla/yarumo/messaging/messagingtest"
+)
+
+func TestNewOptions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("applies defaults", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions()
+
+		if opts.format == nil || opts.format.ContentType() != codec.NewJSONFormat().ContentType() {
+			t.Fatalf("expected the JSON format, got %v", opts.format)
+		}
+
+		if !slices.Equal(opts.aadHeaders, []string{"CorrelationID", "Type", "Source"}) {
+			t.Fatalf("unexpected default AAD headers %v", opts.aadHeaders)
+		}
+
+		if opts.signingKey != nil || opts.verificationKeys != nil || opts.requireSignatures || opts.dlq != nil || opts.errorHandler == nil || opts.clock == nil {
+			t.Fatalf("unexpected defaults %+v", opts)
+		}
+	})
+
+	t.Run("applies options", func(t *testing.T) {
+		t.Parallel()
+
+		cbor := codec.NewCBORFormat()
+		signing := newHMACKey(t, "s1")
+		dlq := messagingtest.NewRecordingChannel[messaging.DeadLetter[Sealed]]()
+		clock := messagingtest.NewFakeClock(time.Time{})
+
+		opts := NewOptions(
+			WithFormat(cbor),
+			WithAADHeaders("", "Type", "tenant"),
+			WithSigningKey(signing),
+			WithVerificationKeys(signing),
+			WithVerificationKeys(newHMACKey(t, "s0")),
+			WithDLQChannel(dlq),
+			WithErrorHandler(messaging.SilentErrorHandler),
+			WithClock(clock),
+		)
+
+		if opts.format != cbor || !slices.Equal(opts.aadHeaders, []string{"Type", "tenant"}) {
+			t.Fatalf("unexpected format or headers %+v", opts)
+		}
+
+		if opts.signingKey == nil || opts.signingKey.ID != "s1" || len(opts.verificationKeys) != 2 || !opts.requireSignatures {
+			t.Fatalf("unexpected keys %+v", opts)
+		}
+
+		if opts.dlq == nil {
+			t.Fatal("expected the DLQ to be installed")
+		}
+
+		if opts.clock != clock {
+			t.Fatal("expected the custom clock")
+		}
+	})
+
+	t.Run("ignores empty values", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(
+			WithFormat(nil),
+			WithAADHeaders(""),
+			WithSigningKey(SigningKey{ID: "no-sign-fn"}),
+			WithVerificationKeys(SigningKey{Verify: newHMACKey(t, "x").Verify}),
+			WithDLQChannel[Sealed](nil),
+			WithErrorHandler(nil),
+			WithClock(nil),
+		)
+
+		if opts.clock == nil {
+			t.Fatal("expected the system clock to be preserved")
+		}
+
+		if opts.format == nil || len(opts.aadHeaders) != 3 || opts.signingKey != nil || opts.verificationKeys != nil || opts.dlq != nil || opts.errorHandler == nil {
+			t.Fatalf("expected defaults to be preserved, got %+v", opts)
+		}
+
+		if !opts.requireSignatures {
+			t.Fatal("expected signatures to stay mandatory without usable verification keys")
+		}
+	})
+}
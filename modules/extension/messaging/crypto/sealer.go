@@ -0,0 +1,244 @@
+package crypto
+
+import (
+	"context"
+	"sync"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+	"github.com/guidomantilla/yarumo/core/common/lifecycle"
+	"github.com/guidomantilla/yarumo/messaging"
+	"github.com/guidomantilla/yarumo/messaging/codec"
+)
+
+// sealer is the Sealer implementation. It owns a single subscription
+// on the source channel (registered in Start, cancelled in Stop) and
+// forwards a Message[Sealed] for every received Message[T].
+type sealer[T any] struct {
+	name         string
+	src          messaging.Channel[T]
+	dst          messaging.Channel[Sealed]
+	key          Key
+	signingKey   *SigningKey
+	format       codec.Format
+	aadHeaders   []string
+	dlq          messaging.Channel[messaging.DeadLetter[T]]
+	errorHandler messaging.ErrorHandler
+	clock        messaging.Clock
+	stats        messaging.StatsRecorder
+
+	done      chan struct{}
+	startOnce sync.Once
+	stopOnce  sync.Once
+	doneOnce  sync.Once
+
+	mu     sync.Mutex
+	cancel messaging.Cancel
+}
+
+// NewSealer constructs a Sealer that subscribes to src and forwards to
+// dst every payload serialized with the configured format, encrypted
+// with key and, when WithSigningKey is given, signed. Headers are
+// forwarded unchanged. The endpoint is not running on return; call
+// lifecycle.Build (or Start directly) to register the subscription.
+//
+// name is used in lifecycle logs and must be non-empty. src and dst
+// are mandatory; key must carry an ID, a Method and a Secret.
+//
+// Options:
+//   - WithFormat: payload format (default JSON).
+//   - WithAADHeaders: headers bound as associated data.
+//   - WithSigningKey: signs every envelope.
+//   - WithDLQChannel: Channel[DeadLetter[T]] for messages that cannot be sealed.
+//   - WithErrorHandler: failure hook (default messaging.DefaultErrorHandler).
+//   - WithClock: clock stamping dead letters (default messaging.SystemClock).
+func NewSealer[T any](name string, src messaging.Channel[T], dst messaging.Channel[Sealed], key Key, opts ...Option) Sealer[T] {
+	cassert.NotEmpty(name, "name is empty")
+	cassert.NotNil(src, "source channel is nil")
+	cassert.NotNil(dst, "destination channel is nil")
+	cassert.NotEmpty(key.ID, "key id is empty")
+	cassert.NotNil(key.Method, "key method is nil")
+	cassert.NotEmpty(key.Secret, "key secret is empty")
+
+	options := NewOptions(opts...)
+
+	var dlq messaging.Channel[messaging.DeadLetter[T]]
+
+	if options.dlq != nil {
+		typed, ok := options.dlq.(messaging.Channel[messaging.DeadLetter[T]])
+		cassert.True(ok, "WithDLQChannel type parameter does not match sealer type T")
+
+		dlq = typed
+	}
+
+	s := &sealer[T]{
+		name:         name,
+		src:          src,
+		dst:          dst,
+		key:          key,
+		signingKey:   options.signingKey,
+		format:       options.format,
+		aadHeaders:   options.aadHeaders,
+		dlq:          dlq,
+		errorHandler: options.errorHandler,
+		clock:        options.clock,
+		done:         make(chan struct{}),
+	}
+
+	s.stats.UseClock(options.clock)
+
+	return s
+}
+
+// Name returns the endpoint's identity used in lifecycle logs.
+func (s *sealer[T]) Name() string {
+	cassert.NotNil(s, "sealer is nil")
+
+	return s.name
+}
+
+// Start registers the sealing handler as a subscriber on the source
+// channel. Start is idempotent — a second invocation returns nil
+// without re-subscribing.
+func (s *sealer[T]) Start(_ context.Context) error {
+	cassert.NotNil(s, "sealer is nil")
+
+	var startErr error
+
+	s.startOnce.Do(func() {
+		cancel, err := s.src.Subscribe(s.handle)
+		if err != nil {
+			startErr = lifecycle.ErrStart(err)
+
+			return
+		}
+
+		s.mu.Lock()
+		s.cancel = cancel
+		s.mu.Unlock()
+	})
+
+	return startErr
+}
+
+// Stop cancels the source-channel subscription and closes Done. Stop
+// is idempotent. It returns lifecycle.ErrShutdown wrapping
+// lifecycle.ErrShutdownTimeout when ctx is already expired on entry.
+func (s *sealer[T]) Stop(ctx context.Context) error {
+	cassert.NotNil(s, "sealer is nil")
+
+	s.stopOnce.Do(func() {
+		s.mu.Lock()
+		cancel := s.cancel
+		s.cancel = nil
+		s.mu.Unlock()
+
+		if cancel != nil {
+			cancel()
+		}
+
+		s.doneOnce.Do(func() { close(s.done) })
+	})
+
+	select {
+	case <-ctx.Done():
+		return lifecycle.ErrShutdown(lifecycle.ErrShutdownTimeout, ctx.Err())
+	default:
+		return nil
+	}
+}
+
+// Done returns the channel that is closed after Stop has been called.
+func (s *sealer[T]) Done() <-chan struct{} {
+	cassert.NotNil(s, "sealer is nil")
+
+	return s.done
+}
+
+// Stats returns a snapshot of the Sealer runtime statistics.
+// Subscribers is 1 while the source subscription is active.
+func (s *sealer[T]) Stats() messaging.Stats {
+	cassert.NotNil(s, "sealer is nil")
+
+	stats := s.stats.Snapshot()
+
+	s.mu.Lock()
+	if s.cancel != nil {
+		stats.Subscribers = 1
+	}
+	s.mu.Unlock()
+
+	return stats
+}
+
+// handle is the Handler[T] subscribed on the source channel. Failures
+// are dead-lettered and reported through the ErrorHandler; the
+// function itself always returns nil.
+func (s *sealer[T]) handle(ctx context.Context, msg messaging.Message[T]) error {
+	end := s.stats.Begin()
+	defer end(nil)
+
+	err := s.seal(ctx, msg)
+	if err != nil {
+		s.stats.RecordFailure()
+		s.deadLetter(ctx, msg, err)
+
+		if s.errorHandler != nil {
+			s.errorHandler(ctx, msg, err)
+		}
+
+		return nil
+	}
+
+	s.stats.RecordSent()
+
+	return nil
+}
+
+// seal encrypts and signs msg and forwards the result.
+func (s *sealer[T]) seal(ctx context.Context, msg messaging.Message[T]) error {
+	plain, err := s.format.Marshal(msg.Payload)
+	if err != nil {
+		return ErrCrypto(ErrEncodeFailed, err)
+	}
+
+	aad, err := associatedData(msg.Headers, s.aadHeaders)
+	if err != nil {
+		return ErrCrypto(err)
+	}
+
+	ciphertext, err := s.key.Method.Encrypt(s.key.Secret, plain, aad)
+	if err != nil {
+		return ErrCrypto(ErrEncryptFailed, err)
+	}
+
+	sealed := Sealed{
+		KeyID:      s.key.ID,
+		Algorithm:  s.key.Method.Name(),
+		Ciphertext: ciphertext,
+	}
+
+	if s.signingKey != nil {
+		sealed.SignerKeyID = s.signingKey.ID
+		sealed.SignerAlgorithm = s.signingKey.Algorithm
+
+		sealed.Signature, err = s.signingKey.Sign(signingInput(sealed, aad))
+		if err != nil {
+			return ErrCrypto(ErrSignFailed, err)
+		}
+	}
+
+	err = s.dst.Send(ctx, messaging.Message[Sealed]{Payload: sealed, Headers: msg.Headers})
+	if err != nil {
+		return ErrCrypto(ErrForwardFailed, err)
+	}
+
+	return nil
+}
+
+// deadLetter publishes msg to the DLQ best-effort through
+// messaging.PublishDeadLetter, stamped with the endpoint clock.
+func (s *sealer[T]) deadLetter(ctx context.Context, msg messaging.Message[T], cause error) {
+	if messaging.PublishDeadLetter(ctx, s.dlq, msg, cause, s.clock.Now()) {
+		s.stats.RecordDeadLetter()
+	}
+}
@@ -0,0 +1,241 @@
+package crypto
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/guidomantilla/yarumo/core/common/lifecycle"
+	"github.com/guidomantilla/yarumo/messaging"
+	"github.com/guidomantilla/yarumo/messaging/codec"
+	"github.com/guidomantilla/yarumo/messaging/messagingtest"
+)
+
+func TestNewSealer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("forwards encrypted payloads with the original headers", func(t *testing.T) {
+		t.Parallel()
+
+		key := newKey(t, "k1")
+		src := messaging.NewPipelineChannel[order]()
+		dst := messagingtest.NewRecordingChannel[Sealed]()
+
+		sealer := NewSealer("orders-seal", src, dst, key)
+		start(t, sealer)
+
+		headers := messaging.Headers{MessageID: "m-1", Type: "order.created", Source: "checkout"}
+
+		err := src.Send(context.Background(), messaging.Message[order]{Payload: order{ID: "o-1", Amount: 10}, Headers: headers})
+		if err != nil {
+			t.Fatalf("send: %v", err)
+		}
+
+		msgs := dst.Messages()
+		if len(msgs) != 1 {
+			t.Fatalf("expected one sealed message, got %d", len(msgs))
+		}
+
+		sealed := msgs[0].Payload
+		if sealed.KeyID != "k1" || sealed.Algorithm != key.Method.Name() || sealed.Signature != nil || sealed.SignerKeyID != "" {
+			t.Fatalf("unexpected envelope %+v", sealed)
+		}
+
+		if msgs[0].Headers.MessageID != "m-1" || msgs[0].Headers.Source != "checkout" {
+			t.Fatalf("expected the original headers, got %+v", msgs[0].Headers)
+		}
+
+		plain, err := key.Method.Decrypt(key.Secret, sealed.Ciphertext, aadOf(t, headers, defaultAADHeaders))
+		if err != nil || string(plain) != `{"id":"o-1","amount":10}` {
+			t.Fatalf("expected the JSON payload, got %q, %v", plain, err)
+		}
+
+		stats := sealer.Stats()
+		if stats.Sent != 1 || stats.Failed != 0 || stats.Subscribers != 1 {
+			t.Fatalf("unexpected stats %+v", stats)
+		}
+	})
+
+	t.Run("signs envelopes with the signing key", func(t *testing.T) {
+		t.Parallel()
+
+		signing := newHMACKey(t, "s1")
+		src := messaging.NewPipelineChannel[order]()
+		dst := messagingtest.NewRecordingChannel[Sealed]()
+
+		start(t, NewSealer("orders-seal", src, dst, newKey(t, "k1"), WithSigningKey(signing)))
+
+		err := src.Send(context.Background(), messaging.Message[order]{Payload: order{ID: "o-1"}})
+		if err != nil {
+			t.Fatalf("send: %v", err)
+		}
+
+		msg := dst.Messages()[0]
+
+		sealed := msg.Payload
+		if sealed.SignerKeyID != "s1" || sealed.SignerAlgorithm != signing.Algorithm {
+			t.Fatalf("unexpected signer fields %+v", sealed)
+		}
+
+		valid, err := signing.Verify(sealed.Signature, signingInput(sealed, aadOf(t, msg.Headers, defaultAADHeaders)))
+		if err != nil || !valid {
+			t.Fatalf("expected a valid signature, got %v, %v", valid, err)
+		}
+	})
+
+	t.Run("dead-letters messages that cannot be sealed", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[any]()
+		dst := messagingtest.NewRecordingChannel[Sealed]()
+		dlq := messagingtest.NewRecordingChannel[messaging.DeadLetter[any]]()
+		hook, errs := captureErrors()
+		instant := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+		sealer := NewSealer("seal", src, dst, newKey(t, "k1"),
+			WithDLQChannel(dlq),
+			WithErrorHandler(hook),
+			WithClock(messagingtest.NewFakeClock(instant)),
+		)
+		start(t, sealer)
+
+		err := src.Send(context.Background(), messaging.Message[any]{Payload: make(chan int)})
+		if err != nil {
+			t.Fatalf("expected failures to stay off the Send path, got %v", err)
+		}
+
+		if dst.Len() != 0 {
+			t.Fatal("expected nothing to be forwarded")
+		}
+
+		letters := dlq.Payloads()
+		if len(letters) != 1 || !errors.Is(letters[0].LastError, ErrEncodeFailed) || !letters[0].FailedAt.Equal(instant) {
+			t.Fatalf("expected one ErrEncodeFailed dead letter, got %+v", letters)
+		}
+
+		if got := errs(); len(got) != 1 || !errors.Is(got[0], ErrCryptoFailed) {
+			t.Fatalf("expected one reported failure, got %v", got)
+		}
+
+		stats := sealer.Stats()
+		if stats.Failed != 1 || stats.DeadLettered != 1 || stats.Sent != 0 {
+			t.Fatalf("unexpected stats %+v", stats)
+		}
+	})
+
+	t.Run("reports encryption and forward failures", func(t *testing.T) {
+		t.Parallel()
+
+		bad := newKey(t, "k1")
+		bad.Secret = bad.Secret[:5]
+
+		src := messaging.NewPipelineChannel[order]()
+		hook, errs := captureErrors()
+
+		start(t, NewSealer("seal", src, messagingtest.NewRecordingChannel[Sealed](), bad, WithErrorHandler(hook)))
+
+		failing := messaging.NewPipelineChannel[Sealed]()
+		_, _ = failing.Subscribe(func(_ context.Context, _ messaging.Message[Sealed]) error { return errors.New("broker down") })
+
+		other := messaging.NewPipelineChannel[order]()
+		start(t, NewSealer("seal", other, failing, newKey(t, "k1"), WithErrorHandler(hook)))
+
+		_ = src.Send(context.Background(), messaging.Message[order]{})
+		_ = other.Send(context.Background(), messaging.Message[order]{})
+
+		got := errs()
+		if len(got) != 2 || !errors.Is(got[0], ErrEncryptFailed) || !errors.Is(got[1], ErrForwardFailed) {
+			t.Fatalf("expected ErrEncryptFailed then ErrForwardFailed, got %v", got)
+		}
+	})
+
+	t.Run("rejects non-string associated Custom headers", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[order]()
+		dst := messagingtest.NewRecordingChannel[Sealed]()
+		hook, errs := captureErrors()
+
+		start(t, NewSealer("seal", src, dst, newKey(t, "k1"), WithAADHeaders("tenant"), WithErrorHandler(hook)))
+
+		_ = src.Send(context.Background(), messaging.Message[order]{Headers: messaging.Headers{Custom: map[string]any{"tenant": 7}}})
+
+		if dst.Len() != 0 {
+			t.Fatal("expected nothing to be forwarded")
+		}
+
+		if got := errs(); len(got) != 1 || !errors.Is(got[0], ErrHeaderInvalid) {
+			t.Fatalf("expected ErrHeaderInvalid, got %v", got)
+		}
+	})
+
+	t.Run("honours WithFormat", func(t *testing.T) {
+		t.Parallel()
+
+		key := newKey(t, "k1")
+		src := messaging.NewPipelineChannel[order]()
+		dst := messagingtest.NewRecordingChannel[Sealed]()
+
+		start(t, NewSealer("seal", src, dst, key, WithFormat(codec.NewCBORFormat())))
+
+		_ = src.Send(context.Background(), messaging.Message[order]{Payload: order{ID: "o-1"}})
+
+		plain, err := key.Method.Decrypt(key.Secret, dst.Payloads()[0].Ciphertext, aadOf(t, messaging.Headers{}, defaultAADHeaders))
+		if err != nil {
+			t.Fatalf("decrypt: %v", err)
+		}
+
+		var decoded order
+
+		err = codec.NewCBORFormat().Unmarshal(plain, &decoded)
+		if err != nil || decoded.ID != "o-1" {
+			t.Fatalf("expected a CBOR payload, got %+v, %v", decoded, err)
+		}
+	})
+}
+
+func TestSealer_Lifecycle(t *testing.T) {
+	t.Parallel()
+
+	src := messagingtest.NewRecordingChannel[order]()
+	sealer := NewSealer("seal", src, messagingtest.NewRecordingChannel[Sealed](), newKey(t, "k1"))
+
+	if sealer.Name() != "seal" {
+		t.Fatalf("unexpected name %q", sealer.Name())
+	}
+
+	for range 2 {
+		err := sealer.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+	}
+
+	if got := src.Stats().Subscribers; got != 1 {
+		t.Fatalf("expected 1 subscriber, got %d", got)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := sealer.Stop(ctx)
+	if !errors.Is(err, lifecycle.ErrShutdownTimeout) {
+		t.Fatalf("expected ErrShutdownTimeout, got %v", err)
+	}
+
+	err = sealer.Stop(context.Background())
+	if err != nil {
+		t.Fatalf("stop: %v", err)
+	}
+
+	select {
+	case <-sealer.Done():
+	default:
+		t.Fatal("expected Done to be closed")
+	}
+
+	if got := src.Stats().Subscribers; got != 0 {
+		t.Fatalf("expected no subscribers after stop, got %d", got)
+	}
+}
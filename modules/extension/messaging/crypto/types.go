@@ -0,0 +1,135 @@
+// Package crypto protects messaging payloads end to end with the
+// primitives of core/crypto, independently of the transport that
+// carries them.
+//
+// The package ships a transformer pair:
+//
+//   - Sealer[T] subscribes to a Channel[T], serializes each payload with
+//     a codec.Format, encrypts it with an AEAD cipher
+//     (core/crypto/ciphers/aead) and forwards a Message[Sealed] with the
+//     original headers. When a signing key is configured the Sealed
+//     envelope is also signed (core/crypto/signers/hmacs or ed25519).
+//   - Opener[T] subscribes to a Channel[Sealed], verifies the signature,
+//     decrypts and deserializes the payload and forwards the original
+//     Message[T].
+//
+// # Associated data
+//
+// The headers named by WithAADHeaders (default CorrelationID, Type and
+// Source) are bound to the ciphertext as AEAD associated data: they
+// travel in clear text so brokers and routers can read them, but
+// changing any of them in transit makes decryption fail. Names are
+// Headers field names or Headers.Custom keys. Custom values must be
+// strings (a message with any other type fails with ErrHeaderInvalid),
+// and a missing Custom key is bound differently from an empty one. Both
+// ends must be configured with the same list.
+//
+// Headers that are legitimately rewritten between the Sealer and the
+// Opener must not be bound. stores.ReplayDeadLetter assigns a new
+// MessageID and moves the old one to CausationID, so binding either
+// would make a replayed envelope fail decryption forever; that is why
+// MessageID is not in the default list.
+//
+// # Keys and rotation
+//
+// Every Sealed envelope names the key it was encrypted with (KeyID) and
+// the key it was signed with (SignerKeyID). The Sealer encrypts and
+// signs with one key each; the Opener is given every key it accepts
+// and picks the right one by ID. Rotation is therefore: add the new
+// key to the Opener, switch the Sealer to it, and drop the old key from
+// the Opener once in-flight messages have drained.
+//
+// An Opener configured with WithVerificationKeys rejects unsigned
+// envelopes, even when none of the keys passed is usable (it then
+// rejects every envelope); without that option it only decrypts.
+//
+// # Error path
+//
+// Both handlers always return nil to the source channel. A message
+// that cannot be sealed, or a Sealed envelope that fails verification,
+// decryption or decoding, is published as a messaging.DeadLetter to
+// the WithDLQChannel channel and reported through WithErrorHandler,
+// with an ErrCrypto error naming the failed step. Nothing is forwarded
+// for such messages.
+package crypto
+
+import (
+	"github.com/guidomantilla/yarumo/core/common/lifecycle"
+	ctypes "github.com/guidomantilla/yarumo/core/common/types"
+	"github.com/guidomantilla/yarumo/core/crypto/ciphers/aead"
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+var (
+	_ Sealer[any] = (*sealer[any])(nil)
+	_ Opener[any] = (*opener[any])(nil)
+
+	_ ErrCryptoFn = ErrCrypto
+)
+
+// Sealed is the protected payload forwarded by a Sealer. It carries
+// everything an Opener needs besides the keys themselves.
+type Sealed struct {
+	// KeyID identifies the encryption key.
+	KeyID string `json:"kid"`
+	// Algorithm is the name of the aead.Method used to encrypt.
+	Algorithm string `json:"alg"`
+	// Ciphertext is the encrypted, serialized payload (nonce included).
+	Ciphertext []byte `json:"ct"`
+	// SignerKeyID identifies the signing key. Empty when unsigned.
+	SignerKeyID string `json:"skid,omitempty"`
+	// SignerAlgorithm is the name of the signing algorithm.
+	SignerAlgorithm string `json:"salg,omitempty"`
+	// Signature covers the fields above and the associated data.
+	Signature []byte `json:"sig,omitempty"`
+}
+
+// Key is a symmetric encryption key identified by ID.
+type Key struct {
+	// ID names the key in Sealed.KeyID. It must be unique among the
+	// keys an Opener accepts.
+	ID string
+	// Method is the AEAD algorithm, e.g. aead.AES_256_GCM.
+	Method *aead.Method
+	// Secret is the raw key material; its length must match Method.
+	Secret ctypes.Bytes
+}
+
+// SigningKey is a signing key identified by ID. Build it with
+// NewHMACKey, NewEd25519Key or NewEd25519PublicKey. A key without a
+// SignFn can only verify.
+type SigningKey struct {
+	// ID names the key in Sealed.SignerKeyID.
+	ID string
+	// Algorithm names the signing algorithm in Sealed.SignerAlgorithm.
+	Algorithm string
+	// Sign signs data; nil for verification-only keys.
+	Sign SignFn
+	// Verify checks a signature produced by Sign.
+	Verify VerifyFn
+}
+
+// Sealer is the encrypting side of the pair. It embeds
+// lifecycle.Component so callers wire it up with lifecycle.Build, and
+// messaging.Inspectable to expose its runtime statistics.
+type Sealer[T any] interface {
+	lifecycle.Component
+	messaging.Inspectable
+}
+
+// Opener is the decrypting side of the pair. It embeds
+// lifecycle.Component so callers wire it up with lifecycle.Build, and
+// messaging.Inspectable to expose its runtime statistics.
+type Opener[T any] interface {
+	lifecycle.Component
+	messaging.Inspectable
+}
+
+// SignFn signs data.
+type SignFn func(data ctypes.Bytes) (ctypes.Bytes, error)
+
+// VerifyFn reports whether signature is valid for data.
+type VerifyFn func(signature ctypes.Bytes, data ctypes.Bytes) (bool, error)
+
+// ErrCryptoFn is the function type for ErrCrypto.
+type ErrCryptoFn func(causes ...error) error
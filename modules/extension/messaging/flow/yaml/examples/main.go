@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/guidomantilla/yarumo/core/common/lifecycle"
+	"github.com/guidomantilla/yarumo/extension/messaging/flow/yaml"
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+// definition splits an order into its lines, keeps the large ones and
+// routes them by region. Ops can change thresholds and routes here
+// without touching the Go code below.
+const definition = `
+name: orders
+channels:
+  - name: orders
+  - name: lines
+    type: queue
+    bufferSize: 64
+    workers: 2
+  - name: large
+  - name: eu
+  - name: other
+nodes:
+  - name: split
+    type: splitter
+    from: orders
+    to: lines
+    expression: payload.lines
+  - name: large-only
+    type: filter
+    from: lines
+    to: large
+    expression: payload.amount > 100
+  - name: by-region
+    type: router
+    from: large
+    expression: payload.region
+    routes:
+      eu: eu
+    default: other
+`
+
+func main() {
+	ctx := context.Background()
+
+	f, err := yaml.Load([]byte(definition))
+	if err != nil {
+		fmt.Println("load failed:", err)
+		return
+	}
+
+	delivered := make(chan string, 4)
+
+	for _, name := range []string{"eu", "other"} {
+		channel, err := f.Channel(name)
+		if err != nil {
+			fmt.Println("channel lookup failed:", err)
+			return
+		}
+
+		_, err = channel.Subscribe(func(_ context.Context, msg messaging.Message[any]) error {
+			delivered <- fmt.Sprintf("%s <- %v", name, msg.Payload)
+			return nil
+		})
+		if err != nil {
+			fmt.Println("subscribe failed:", err)
+			return
+		}
+	}
+
+	closeFn, err := lifecycle.Build(ctx, f, make(chan error, 1))
+	if err != nil {
+		fmt.Println("lifecycle.Build:", err)
+		return
+	}
+	defer closeFn(ctx, 5*time.Second)
+
+	// lifecycle.Build starts the flow asynchronously; give it a moment.
+	time.Sleep(50 * time.Millisecond)
+
+	orders, err := f.Channel("orders")
+	if err != nil {
+		fmt.Println("channel lookup failed:", err)
+		return
+	}
+
+	err = orders.Send(ctx, messaging.Message[any]{Payload: map[string]any{
+		"lines": []any{
+			map[string]any{"sku": "a", "amount": 40.0, "region": "eu"},
+			map[string]any{"sku": "b", "amount": 250.0, "region": "eu"},
+			map[string]any{"sku": "c", "amount": 180.0, "region": "us"},
+		},
+	}})
+	if err != nil {
+		fmt.Println("send failed:", err)
+		return
+	}
+
+	for range 2 {
+		select {
+		case line := <-delivered:
+			fmt.Println("flow:", line)
+		case <-time.After(2 * time.Second):
+			fmt.Println("flow: line not delivered in time")
+			return
+		}
+	}
+}
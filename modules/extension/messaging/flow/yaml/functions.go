@@ -0,0 +1,35 @@
+package yaml
+
+import (
+	"encoding/json"
+
+	goyaml "go.yaml.in/yaml/v3"
+
+	"github.com/guidomantilla/yarumo/messaging/flow"
+)
+
+// Decode decodes the YAML document in data into v with the semantics of
+// flow.DecodeJSON: the document is converted to JSON first, so field
+// names follow the json tags and unknown fields are rejected.
+func Decode(data []byte, v any) error {
+	var document any
+
+	err := goyaml.Unmarshal(data, &document)
+	if err != nil {
+		return err
+	}
+
+	converted, err := json.Marshal(document)
+	if err != nil {
+		return err
+	}
+
+	return flow.DecodeJSON(converted, v)
+}
+
+// Load decodes a YAML flow definition from data and builds it with
+// flow.NewFlow. It is flow.Load with Decode installed via
+// flow.WithDecoder; opts may carry any other flow.Option.
+func Load(data []byte, opts ...flow.Option) (flow.Flow, error) {
+	return flow.Load(data, append(opts, flow.WithDecoder(Decode))...)
+}
@@ -0,0 +1,154 @@
+package yaml
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/guidomantilla/yarumo/messaging"
+	"github.com/guidomantilla/yarumo/messaging/flow"
+)
+
+const definition = `
+name: orders
+channels:
+  - name: incoming
+  - name: large
+    type: topic
+    bufferSize: 8
+    drainTimeout: 500ms
+nodes:
+  - name: large-only
+    type: filter
+    from: incoming
+    to: large
+    expression: payload.amount > 100
+`
+
+func TestDecode(t *testing.T) {
+	t.Parallel()
+
+	t.Run("decodes with the JSON field names", func(t *testing.T) {
+		t.Parallel()
+
+		var def flow.Definition
+
+		err := Decode([]byte(definition), &def)
+		if err != nil {
+			t.Fatalf("Decode: %v", err)
+		}
+
+		if def.Name != "orders" || len(def.Channels) != 2 || len(def.Nodes) != 1 {
+			t.Fatalf("unexpected definition %+v", def)
+		}
+
+		large := def.Channels[1]
+		if large.Type != flow.ChannelTopic || large.BufferSize != 8 || time.Duration(large.DrainTimeout) != 500*time.Millisecond {
+			t.Fatalf("unexpected channel %+v", large)
+		}
+
+		if def.Nodes[0].Expression != "payload.amount > 100" {
+			t.Fatalf("unexpected expression %q", def.Nodes[0].Expression)
+		}
+	})
+
+	t.Run("rejects malformed YAML", func(t *testing.T) {
+		t.Parallel()
+
+		var def flow.Definition
+
+		err := Decode([]byte("name: [unclosed"), &def)
+		if err == nil {
+			t.Fatal("expected error")
+		}
+	})
+
+	t.Run("rejects unknown fields", func(t *testing.T) {
+		t.Parallel()
+
+		var def flow.Definition
+
+		err := Decode([]byte("name: orders\nchanels: []\n"), &def)
+		if err == nil {
+			t.Fatal("expected error for misspelled field")
+		}
+	})
+
+	t.Run("rejects non-string keys", func(t *testing.T) {
+		t.Parallel()
+
+		var def flow.Definition
+
+		err := Decode([]byte("? [a, b]\n: c\n"), &def)
+		if err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	t.Run("builds a running flow", func(t *testing.T) {
+		t.Parallel()
+
+		f, err := Load([]byte(definition))
+		if err != nil {
+			t.Fatalf("Load: %v", err)
+		}
+
+		err = f.Start(context.Background())
+		if err != nil {
+			t.Fatalf("Start: %v", err)
+		}
+
+		t.Cleanup(func() { _ = f.Stop(context.Background()) })
+
+		large, err := f.Channel("large")
+		if err != nil {
+			t.Fatalf("Channel: %v", err)
+		}
+
+		received := make(chan any, 2)
+
+		_, err = large.Subscribe(func(_ context.Context, msg messaging.Message[any]) error {
+			received <- msg.Payload
+
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Subscribe: %v", err)
+		}
+
+		incoming, err := f.Channel("incoming")
+		if err != nil {
+			t.Fatalf("Channel: %v", err)
+		}
+
+		for _, amount := range []float64{50, 150} {
+			err = incoming.Send(context.Background(), messaging.Message[any]{Payload: map[string]any{"amount": amount}})
+			if err != nil {
+				t.Fatalf("Send: %v", err)
+			}
+		}
+
+		select {
+		case payload := <-received:
+			if payload.(map[string]any)["amount"] != 150.0 {
+				t.Fatalf("unexpected payload %v", payload)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("message not delivered")
+		}
+	})
+
+	t.Run("decoding failures return flow.ErrDecode", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Load([]byte("name: [unclosed"))
+		if !errors.Is(err, flow.ErrDecode) {
+			t.Fatalf("expected flow.ErrDecode, got %v", err)
+		}
+	})
+}
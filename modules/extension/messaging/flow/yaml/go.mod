@@ -0,0 +1,19 @@
+module github.com/guidomantilla/yarumo/extension/messaging/flow/yaml
+
+go 1.25.5
+
+replace (
+	github.com/guidomantilla/yarumo/core/common => ../../../../core/common
+	github.com/guidomantilla/yarumo/messaging => ../../../../messaging
+)
+
+require (
+	github.com/guidomantilla/yarumo/messaging v0.0.0-00010101000000-000000000000
+	go.yaml.in/yaml/v3 v3.0.5
+)
+
+require (
+	github.com/google/go-cmp v0.7.0 // indirect
+	github.com/guidomantilla/yarumo/core/common v0.0.0-00010101000000-000000000000 // indirect
+	golang.org/x/text v0.34.0 // indirect
+)
@@ -0,0 +1,19 @@
+// Package yaml lets messaging/flow load YAML flow definitions. It ships
+// Decode, a flow.DecodeFn for flow.WithDecoder, and Load, the YAML
+// counterpart of flow.Load.
+//
+// Decode reads the YAML document into generic values and re-encodes it
+// as JSON before handing it to flow.DecodeJSON, so YAML definitions use
+// exactly the JSON field names, duration strings and unknown-field
+// checks of JSON ones. Keeping YAML in its own module keeps the YAML
+// library out of the module graph of every modules/messaging consumer.
+package yaml
+
+import (
+	"github.com/guidomantilla/yarumo/messaging/flow"
+)
+
+var (
+	_ flow.DecodeFn = Decode
+	_ flow.LoadFn   = Load
+)
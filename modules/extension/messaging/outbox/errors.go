@@ -0,0 +1,72 @@
+package outbox
+
+import (
+	"errors"
+	"fmt"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+	cerrs "github.com/guidomantilla/yarumo/core/common/errs"
+)
+
+// OutboxType is the error domain identifier for outbox operations.
+const OutboxType = "outbox"
+
+var (
+	_ error = (*Error)(nil)
+)
+
+// Sentinel errors for outbox operations.
+var (
+	// ErrOutboxFailed is the top-level sentinel embedded in every
+	// outbox-domain Error returned by ErrOutbox.
+	ErrOutboxFailed = errors.New("outbox failed")
+	// ErrTxNil indicates that Writer.Write was called with a nil
+	// transaction.
+	ErrTxNil = errors.New("transaction is nil")
+	// ErrMessageIDEmpty indicates that Writer.Write was called with a
+	// message whose Headers.MessageID is empty; the id is the relay's
+	// dedup key.
+	ErrMessageIDEmpty = errors.New("message id is empty")
+	// ErrEncode indicates that a message could not be encoded before
+	// being inserted into the outbox.
+	ErrEncode = errors.New("message encoding failed")
+	// ErrDecode indicates that an outbox row could not be decoded back
+	// into a message. The row is parked (failed_at is set) so it does
+	// not block the rows behind it and is not relayed again.
+	ErrDecode = errors.New("message decoding failed")
+	// ErrQuery indicates that a database statement issued by the writer
+	// or the relay failed.
+	ErrQuery = errors.New("outbox query failed")
+	// ErrPublish indicates that the destination channel rejected a
+	// relayed message. The row stays pending and is retried on the next
+	// poll.
+	ErrPublish = errors.New("outbox publish failed")
+	// ErrMarkSent indicates that a published row could not be marked
+	// sent. The row will be published again on the next poll.
+	ErrMarkSent = errors.New("outbox mark sent failed")
+)
+
+// Error is the domain error type for outbox operations.
+type Error struct {
+	cerrs.TypedError
+}
+
+// Error returns the formatted error string including the type
+// classification.
+func (e *Error) Error() string {
+	cassert.NotNil(e, "error is nil")
+	cassert.NotNil(e.Err, "internal error is nil")
+
+	return fmt.Sprintf("outbox %s error: %s", e.Type, e.Err)
+}
+
+// ErrOutbox wraps the given causes into a domain Error joined with
+// ErrOutboxFailed.
+func ErrOutbox(causes ...error) error {
+	return &Error{
+		TypedError: cerrs.TypedError{
+			Type: OutboxType,
+			Err:  errors.Join(append(causes, ErrOutboxFailed)...),
+		},
+	}
+}
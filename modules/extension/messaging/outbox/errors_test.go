@@ -0,0 +1,41 @@
+package outbox
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestErrOutbox(t *testing.T) {
+	t.Parallel()
+
+	t.Run("wraps causes with ErrOutboxFailed and OutboxType tag", func(t *testing.T) {
+		t.Parallel()
+
+		cause := errors.New("disk full")
+		err := ErrOutbox(ErrQuery, cause)
+
+		if !errors.Is(err, ErrOutboxFailed) {
+			t.Fatalf("expected wrap of ErrOutboxFailed, got %v", err)
+		}
+
+		if !errors.Is(err, ErrQuery) || !errors.Is(err, cause) {
+			t.Fatalf("expected wrap of causes, got %v", err)
+		}
+
+		var e *Error
+
+		ok := errors.As(err, &e)
+		if !ok {
+			t.Fatalf("expected *Error, got %T", err)
+		}
+
+		if e.Type != OutboxType {
+			t.Fatalf("Type = %q, want %q", e.Type, OutboxType)
+		}
+
+		if !strings.HasPrefix(err.Error(), "outbox outbox error: ") {
+			t.Fatalf("unexpected message %q", err.Error())
+		}
+	})
+}
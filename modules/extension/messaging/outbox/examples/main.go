@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	_ "modernc.org/sqlite"
+
+	"github.com/guidomantilla/yarumo/core/common/lifecycle"
+	"github.com/guidomantilla/yarumo/extension/messaging/outbox"
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+const schema = `
+CREATE TABLE orders (id TEXT PRIMARY KEY, amount REAL NOT NULL);
+CREATE TABLE messaging_outbox (
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    message_id  TEXT    NOT NULL,
+    message     TEXT    NOT NULL,
+    created_at  INTEGER NOT NULL,
+    sent_at     INTEGER NULL,
+    failed_at   INTEGER NULL
+);`
+
+type orderCreated struct {
+	ID     string  `json:"id"`
+	Amount float64 `json:"amount"`
+}
+
+func main() {
+	ctx := context.Background()
+
+	db, err := sql.Open("sqlite", "file:outbox-example?mode=memory&cache=shared")
+	if err != nil {
+		fmt.Println("open failed:", err)
+		return
+	}
+	defer db.Close()
+
+	_, err = db.ExecContext(ctx, schema)
+	if err != nil {
+		fmt.Println("schema failed:", err)
+		return
+	}
+
+	events := messaging.NewPipelineChannel[orderCreated]()
+	delivered := make(chan orderCreated, 1)
+
+	_, err = events.Subscribe(func(_ context.Context, msg messaging.Message[orderCreated]) error {
+		delivered <- msg.Payload
+		return nil
+	})
+	if err != nil {
+		fmt.Println("subscribe failed:", err)
+		return
+	}
+
+	writer := outbox.NewWriter[orderCreated]()
+	relay := outbox.NewRelay[orderCreated]("orders-outbox", db, events, outbox.WithPollInterval(50*time.Millisecond))
+
+	errCh := make(chan error, 1)
+	closeFn, err := lifecycle.Build(ctx, relay, errCh)
+	if err != nil {
+		fmt.Println("lifecycle.Build:", err)
+		return
+	}
+	defer closeFn(ctx, 5*time.Second)
+
+	// The order row and its event commit (or roll back) together.
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		fmt.Println("begin failed:", err)
+		return
+	}
+
+	_, err = tx.ExecContext(ctx, "INSERT INTO orders (id, amount) VALUES (?, ?)", "o-1", 99.5)
+	if err != nil {
+		_ = tx.Rollback()
+		fmt.Println("insert failed:", err)
+		return
+	}
+
+	msg := messaging.NewMessage(orderCreated{ID: "o-1", Amount: 99.5}, nil)
+	msg.Headers.MessageID = "order-created-o-1"
+
+	err = writer.Write(ctx, tx, msg)
+	if err != nil {
+		_ = tx.Rollback()
+		fmt.Println("outbox write failed:", err)
+		return
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		fmt.Println("commit failed:", err)
+		return
+	}
+
+	select {
+	case event := <-delivered:
+		fmt.Printf("outbox: relayed %+v\n", event)
+	case <-time.After(2 * time.Second):
+		fmt.Println("outbox: event not relayed in time")
+	}
+}
@@ -0,0 +1,28 @@
+module github.com/guidomantilla/yarumo/extension/messaging/outbox
+
+go 1.25.5
+
+replace (
+	github.com/guidomantilla/yarumo/core/common => ../../../core/common
+	github.com/guidomantilla/yarumo/messaging => ../../../messaging
+)
+
+require (
+	github.com/guidomantilla/yarumo/core/common v0.0.0-00010101000000-000000000000
+	github.com/guidomantilla/yarumo/messaging v0.0.0-00010101000000-000000000000
+	modernc.org/sqlite v1.55.0
+)
+
+require (
+	github.com/dustin/go-humanize v1.0.1 // indirect
+	github.com/google/go-cmp v0.7.0 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/mattn/go-isatty v0.0.24 // indirect
+	github.com/ncruces/go-strftime v1.0.0 // indirect
+	github.com/remyoudompheng/bigfft v0.0.0-20230129092748-24d4a6f8daec // indirect
+	golang.org/x/sys v0.47.0 // indirect
+	golang.org/x/text v0.34.0 // indirect
+	modernc.org/libc v1.74.1 // indirect
+	modernc.org/mathutil v1.7.1 // indirect
+	modernc.org/memory v1.12.1 // indirect
+)
@@ -0,0 +1,147 @@
+package outbox
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+// Defaults applied by NewOptions.
+const (
+	defaultTable        = "messaging_outbox"
+	defaultPollInterval = time.Second
+	defaultBatchSize    = 100
+)
+
+// tablePattern restricts WithTable to plain (optionally schema-
+// qualified) SQL identifiers, since the name is interpolated into the
+// generated statements.
+var tablePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// Placeholder selects the bind-parameter syntax of the generated SQL.
+type Placeholder int
+
+const (
+	// PlaceholderQuestion renders parameters as "?" (SQLite, MySQL,
+	// MariaDB). The default.
+	PlaceholderQuestion Placeholder = iota
+	// PlaceholderDollar renders parameters as "$1", "$2", ...
+	// (PostgreSQL).
+	PlaceholderDollar
+)
+
+// Option is a functional option for configuring outbox Options. The
+// writer and the relay share the same Options (table and placeholder
+// must agree); options that only affect the relay are ignored by the
+// writer. Option is non-generic because no option carries a T-typed
+// value.
+type Option func(opts *Options)
+
+// Options holds the configuration for a Writer and a Relay.
+type Options struct {
+	table        string
+	placeholder  Placeholder
+	pollInterval time.Duration
+	batchSize    int
+	errorHandler messaging.ErrorHandler
+	dropHandler  DropHandler
+	clock        messaging.Clock
+}
+
+// NewOptions creates a new Options with sensible defaults and applies
+// the given options. Defaults: table "messaging_outbox",
+// PlaceholderQuestion, pollInterval 1s, batchSize 100, ErrorHandler
+// logs via common/log, DropHandler nil (duplicate skips are silent),
+// messaging.SystemClock.
+func NewOptions(opts ...Option) *Options {
+	options := &Options{
+		table:        defaultTable,
+		placeholder:  PlaceholderQuestion,
+		pollInterval: defaultPollInterval,
+		batchSize:    defaultBatchSize,
+		errorHandler: messaging.DefaultErrorHandler,
+		clock:        messaging.SystemClock(),
+	}
+
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	return options
+}
+
+// WithTable sets the outbox table name. Only plain identifiers,
+// optionally schema-qualified ("events.outbox"), are accepted; other
+// values are ignored (the previously configured name is preserved).
+func WithTable(name string) Option {
+	return func(opts *Options) {
+		if tablePattern.MatchString(name) {
+			opts.table = name
+		}
+	}
+}
+
+// WithPlaceholder selects the bind-parameter syntax for the target
+// database. Values outside the defined range are ignored.
+func WithPlaceholder(p Placeholder) Option {
+	return func(opts *Options) {
+		if p >= PlaceholderQuestion && p <= PlaceholderDollar {
+			opts.placeholder = p
+		}
+	}
+}
+
+// WithPollInterval sets how long the relay waits between polls once the
+// table has been drained. Non-positive values are ignored.
+func WithPollInterval(d time.Duration) Option {
+	return func(opts *Options) {
+		if d > 0 {
+			opts.pollInterval = d
+		}
+	}
+}
+
+// WithBatchSize sets the maximum number of pending rows the relay
+// fetches per query. Non-positive values are ignored.
+func WithBatchSize(n int) Option {
+	return func(opts *Options) {
+		if n > 0 {
+			opts.batchSize = n
+		}
+	}
+}
+
+// WithErrorHandler installs an observability hook fired once per relay
+// failure (query, decode, publish or mark-sent error). The default is
+// messaging.DefaultErrorHandler, which logs via common/log. Pass
+// messaging.SilentErrorHandler to opt out. Nil values are ignored.
+func WithErrorHandler(handler messaging.ErrorHandler) Option {
+	return func(opts *Options) {
+		if handler != nil {
+			opts.errorHandler = handler
+		}
+	}
+}
+
+// WithDropHandler installs an observability hook fired once per row the
+// relay skipped as a duplicate. The default is nil (silent). Nil values
+// are ignored.
+func WithDropHandler(handler DropHandler) Option {
+	return func(opts *Options) {
+		if handler != nil {
+			opts.dropHandler = handler
+		}
+	}
+}
+
+// WithClock sets the clock the writer reads to stamp created_at and
+// the relay to stamp sent_at, failed_at and the stats failure
+// timestamps. Nil values are ignored (the system clock is preserved).
+func WithClock(clock messaging.Clock) Option {
+	return func(opts *Options) {
+		if clock != nil {
+			opts.clock = clock
+		}
+	}
+}
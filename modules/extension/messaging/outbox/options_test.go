@@ -0,0 +1,190 @@
+package outbox
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/guidomantilla/yarumo/messaging"
+	"github.com/guidomantilla/yarumo/messaging/messagingtest"
+)
+
+func TestNewOptions(t *testing.T) {
+	t.Parallel()
+
+	opts := NewOptions()
+
+	if opts.table != defaultTable {
+		t.Fatalf("expected table %q, got %q", defaultTable, opts.table)
+	}
+
+	if opts.placeholder != PlaceholderQuestion {
+		t.Fatalf("expected PlaceholderQuestion, got %v", opts.placeholder)
+	}
+
+	if opts.pollInterval != defaultPollInterval {
+		t.Fatalf("expected pollInterval %v, got %v", defaultPollInterval, opts.pollInterval)
+	}
+
+	if opts.batchSize != defaultBatchSize {
+		t.Fatalf("expected batchSize %d, got %d", defaultBatchSize, opts.batchSize)
+	}
+
+	if opts.errorHandler == nil {
+		t.Fatal("expected default error handler")
+	}
+
+	if opts.dropHandler != nil {
+		t.Fatal("expected nil drop handler")
+	}
+
+	if opts.clock == nil {
+		t.Fatal("expected the system clock")
+	}
+}
+
+func TestWithClock(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid value applied", func(t *testing.T) {
+		t.Parallel()
+
+		clock := messagingtest.NewFakeClock(time.Time{})
+
+		opts := NewOptions(WithClock(clock))
+		if opts.clock != clock {
+			t.Fatal("expected the custom clock")
+		}
+	})
+
+	t.Run("nil ignored", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithClock(nil))
+		if opts.clock == nil {
+			t.Fatal("expected the system clock to be preserved")
+		}
+	})
+}
+
+func TestWithTable(t *testing.T) {
+	t.Parallel()
+
+	t.Run("plain and qualified identifiers applied", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithTable("events"))
+		if opts.table != "events" {
+			t.Fatalf("expected events, got %q", opts.table)
+		}
+
+		opts = NewOptions(WithTable("app.events_outbox"))
+		if opts.table != "app.events_outbox" {
+			t.Fatalf("expected app.events_outbox, got %q", opts.table)
+		}
+	})
+
+	t.Run("unsafe names ignored", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithTable(""), WithTable("x; DROP TABLE y"), WithTable("1abc"))
+		if opts.table != defaultTable {
+			t.Fatalf("expected default table, got %q", opts.table)
+		}
+	})
+}
+
+func TestWithPlaceholder(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid value applied", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithPlaceholder(PlaceholderDollar))
+		if opts.placeholder != PlaceholderDollar {
+			t.Fatalf("expected PlaceholderDollar, got %v", opts.placeholder)
+		}
+	})
+
+	t.Run("out of range ignored", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithPlaceholder(PlaceholderDollar), WithPlaceholder(Placeholder(9)), WithPlaceholder(Placeholder(-1)))
+		if opts.placeholder != PlaceholderDollar {
+			t.Fatalf("expected PlaceholderDollar preserved, got %v", opts.placeholder)
+		}
+	})
+}
+
+func TestWithPollInterval(t *testing.T) {
+	t.Parallel()
+
+	opts := NewOptions(WithPollInterval(time.Minute), WithPollInterval(0), WithPollInterval(-time.Second))
+	if opts.pollInterval != time.Minute {
+		t.Fatalf("expected 1m, got %v", opts.pollInterval)
+	}
+}
+
+func TestWithBatchSize(t *testing.T) {
+	t.Parallel()
+
+	opts := NewOptions(WithBatchSize(7), WithBatchSize(0), WithBatchSize(-1))
+	if opts.batchSize != 7 {
+		t.Fatalf("expected 7, got %d", opts.batchSize)
+	}
+}
+
+func TestWithErrorHandler(t *testing.T) {
+	t.Parallel()
+
+	called := false
+	handler := func(context.Context, any, error) { called = true }
+
+	opts := NewOptions(WithErrorHandler(handler), WithErrorHandler(nil))
+	opts.errorHandler(context.Background(), nil, nil)
+
+	if !called {
+		t.Fatal("expected custom handler to be installed")
+	}
+
+	opts = NewOptions(WithErrorHandler(messaging.SilentErrorHandler))
+	if opts.errorHandler == nil {
+		t.Fatal("expected silent handler to be installed")
+	}
+}
+
+func TestWithDropHandler(t *testing.T) {
+	t.Parallel()
+
+	called := false
+	handler := func(context.Context, any, DropReason) { called = true }
+
+	opts := NewOptions(WithDropHandler(handler), WithDropHandler(nil))
+	opts.dropHandler(context.Background(), nil, DropReasonDuplicate)
+
+	if !called {
+		t.Fatal("expected custom drop handler to be installed")
+	}
+}
+
+func TestNewStatements(t *testing.T) {
+	t.Parallel()
+
+	t.Run("question placeholders", func(t *testing.T) {
+		t.Parallel()
+
+		stmts := newStatements("outbox", PlaceholderQuestion)
+		if stmts.markSent != "UPDATE outbox SET sent_at = ? WHERE id = ?" {
+			t.Fatalf("unexpected markSent %q", stmts.markSent)
+		}
+	})
+
+	t.Run("dollar placeholders", func(t *testing.T) {
+		t.Parallel()
+
+		stmts := newStatements("outbox", PlaceholderDollar)
+		if stmts.insert != "INSERT INTO outbox (message_id, message, created_at) VALUES ($1, $2, $3)" {
+			t.Fatalf("unexpected insert %q", stmts.insert)
+		}
+	})
+}
@@ -0,0 +1,363 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+	"github.com/guidomantilla/yarumo/core/common/lifecycle"
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+// relay is the Relay implementation. A single goroutine polls the
+// outbox table for pending rows in id order, publishes each one to dst
+// and marks it sent. It keeps fetching batches back-to-back while full
+// batches come back and sleeps pollInterval once the table is drained
+// or a failure stopped the batch.
+type relay[T any] struct {
+	name         string
+	db           *sql.DB
+	dst          messaging.Channel[T]
+	stmts        statements
+	pollInterval time.Duration
+	batchSize    int
+	errorHandler messaging.ErrorHandler
+	dropHandler  DropHandler
+	clock        messaging.Clock
+	stats        messaging.StatsRecorder
+
+	mu           sync.Mutex
+	started      bool
+	workerCancel context.CancelFunc
+
+	done      chan struct{}
+	startOnce sync.Once
+	stopOnce  sync.Once
+	doneOnce  sync.Once
+}
+
+// pendingRow is one outbox row fetched by the relay.
+type pendingRow struct {
+	id        int64
+	messageID string
+	data      string
+}
+
+// NewRelay constructs a Relay that publishes the rows of the outbox
+// table in db to dst. name is used in lifecycle logs and must be
+// non-empty; db and dst are mandatory. The relay is not running on
+// return; call lifecycle.Build (or Start directly) to spawn the polling
+// goroutine.
+//
+// Optional behaviors:
+//
+//   - WithTable / WithPlaceholder must match the Writer's options.
+//   - WithPollInterval sets the idle wait between polls (default 1s).
+//   - WithBatchSize caps the rows fetched per query (default 100).
+//   - WithErrorHandler observes query, decode, publish and mark-sent
+//     failures.
+//   - WithDropHandler observes rows skipped as duplicates.
+//   - WithClock sets the clock stamping sent_at and failed_at.
+func NewRelay[T any](name string, db *sql.DB, dst messaging.Channel[T], opts ...Option) Relay {
+	cassert.NotEmpty(name, "name is empty")
+	cassert.NotNil(db, "db is nil")
+	cassert.NotNil(dst, "destination channel is nil")
+
+	options := NewOptions(opts...)
+
+	r := &relay[T]{
+		name:         name,
+		db:           db,
+		dst:          dst,
+		stmts:        newStatements(options.table, options.placeholder),
+		pollInterval: options.pollInterval,
+		batchSize:    options.batchSize,
+		errorHandler: options.errorHandler,
+		dropHandler:  options.dropHandler,
+		clock:        options.clock,
+		done:         make(chan struct{}),
+	}
+
+	r.stats.UseClock(options.clock)
+
+	return r
+}
+
+// Name returns the relay's identity used in lifecycle logs.
+func (r *relay[T]) Name() string {
+	cassert.NotNil(r, "outbox relay is nil")
+
+	return r.name
+}
+
+// Start spawns the polling goroutine and returns immediately. The first
+// poll happens right away. Start is idempotent, and a no-op after Stop.
+func (r *relay[T]) Start(ctx context.Context) error {
+	cassert.NotNil(r, "outbox relay is nil")
+
+	r.startOnce.Do(func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		workerCtx, workerCancel := context.WithCancel(ctx)
+		r.workerCancel = workerCancel
+		r.started = true
+
+		go func() {
+			defer r.doneOnce.Do(func() { close(r.done) })
+
+			r.run(workerCtx)
+		}()
+	})
+
+	return nil
+}
+
+// Stop cancels the polling goroutine and waits, bounded by ctx, for the
+// in-flight batch to finish. Rows published but not yet marked sent
+// are republished by the next relay run. Stop is idempotent.
+func (r *relay[T]) Stop(ctx context.Context) error {
+	cassert.NotNil(r, "outbox relay is nil")
+
+	r.stopOnce.Do(func() {
+		// Consume startOnce so a late Start is a no-op.
+		r.startOnce.Do(func() {})
+
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		if !r.started {
+			r.doneOnce.Do(func() { close(r.done) })
+
+			return
+		}
+
+		r.workerCancel()
+	})
+
+	select {
+	case <-r.done:
+		return nil
+	case <-ctx.Done():
+		return lifecycle.ErrShutdown(lifecycle.ErrShutdownTimeout, ctx.Err())
+	}
+}
+
+// Done returns the channel that is closed after the polling goroutine
+// has exited.
+func (r *relay[T]) Done() <-chan struct{} {
+	cassert.NotNil(r, "outbox relay is nil")
+
+	return r.done
+}
+
+// Stats returns a snapshot of the relay runtime statistics. Delivered
+// counts the rows taken from the table and Sent the messages published
+// to dst. Failed counts the rows left pending by a query, publish or
+// mark-sent failure, and the undecodable ones. Dropped counts the
+// duplicates marked sent without publishing and the parked rows.
+// Subscribers is always zero because the relay polls.
+func (r *relay[T]) Stats() messaging.Stats {
+	cassert.NotNil(r, "outbox relay is nil")
+
+	return r.stats.Snapshot()
+}
+
+// run is the polling loop: relay batches back-to-back while they come
+// back full, otherwise wait pollInterval (or until ctx is cancelled).
+func (r *relay[T]) run(ctx context.Context) {
+	for {
+		full := r.relayBatch(ctx)
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		if full {
+			continue
+		}
+
+		timer := time.NewTimer(r.pollInterval)
+
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+
+			return
+		}
+	}
+}
+
+// relayBatch fetches up to batchSize pending rows and relays them in id
+// order, stopping at the first row that cannot be relayed. It returns
+// true when the whole batch was relayed and was full (more rows may be
+// waiting).
+func (r *relay[T]) relayBatch(ctx context.Context) bool {
+	rows, err := r.fetch(ctx)
+	if err != nil {
+		r.report(ctx, nil, err)
+
+		return false
+	}
+
+	seen := make(map[string]struct{}, len(rows))
+
+	for _, row := range rows {
+		if ctx.Err() != nil {
+			return false
+		}
+
+		end := r.stats.Begin()
+		ok := r.relayRow(ctx, row, seen)
+		end(nil)
+
+		if !ok {
+			return false
+		}
+	}
+
+	return len(rows) == r.batchSize
+}
+
+// fetch reads the next batch of pending rows. The result set is fully
+// consumed and closed before any row is relayed so the relay never
+// holds a cursor open across the publish and update statements.
+func (r *relay[T]) fetch(ctx context.Context) ([]pendingRow, error) {
+	rows, err := r.db.QueryContext(ctx, r.stmts.pending, r.batchSize)
+	if err != nil {
+		return nil, ErrOutbox(ErrQuery, err)
+	}
+	defer rows.Close()
+
+	var pending []pendingRow
+
+	for rows.Next() {
+		var row pendingRow
+
+		err = rows.Scan(&row.id, &row.messageID, &row.data)
+		if err != nil {
+			return nil, ErrOutbox(ErrQuery, err)
+		}
+
+		pending = append(pending, row)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, ErrOutbox(ErrQuery, err)
+	}
+
+	return pending, nil
+}
+
+// relayRow publishes one row and marks it sent, marks it sent without
+// publishing it when it is a duplicate, or parks it when it cannot be
+// decoded. It returns false when the row is left pending, which stops
+// the batch to preserve ordering. Each row counts at most one failure
+// in the stats.
+func (r *relay[T]) relayRow(ctx context.Context, row pendingRow, seen map[string]struct{}) bool {
+	var msg messaging.Message[T]
+
+	err := json.Unmarshal([]byte(row.data), &msg)
+	if err != nil {
+		r.stats.RecordFailure()
+		r.report(ctx, row.data, ErrOutbox(ErrDecode, fmt.Errorf("row %d: %w", row.id, err)))
+
+		return r.park(ctx, row)
+	}
+
+	duplicate, err := r.isDuplicate(ctx, row, seen)
+	if err != nil {
+		r.stats.RecordFailure()
+		r.report(ctx, msg, err)
+
+		return false
+	}
+
+	if duplicate {
+		if r.dropHandler != nil {
+			r.dropHandler(ctx, msg, DropReasonDuplicate)
+		}
+
+		ok := r.markSent(ctx, row, msg)
+		if ok {
+			r.stats.RecordDrop()
+		}
+
+		return ok
+	}
+
+	err = r.dst.Send(ctx, msg)
+	if err != nil {
+		r.stats.RecordFailure()
+		r.report(ctx, msg, ErrOutbox(ErrPublish, err))
+
+		return false
+	}
+
+	r.stats.RecordSent()
+
+	seen[row.messageID] = struct{}{}
+
+	return r.markSent(ctx, row, msg)
+}
+
+// isDuplicate reports whether row's message_id was already published,
+// either earlier in this batch or by a row already marked sent.
+func (r *relay[T]) isDuplicate(ctx context.Context, row pendingRow, seen map[string]struct{}) (bool, error) {
+	_, ok := seen[row.messageID]
+	if ok {
+		return true, nil
+	}
+
+	var count int
+
+	err := r.db.QueryRowContext(ctx, r.stmts.published, row.messageID).Scan(&count)
+	if err != nil {
+		return false, ErrOutbox(ErrQuery, err)
+	}
+
+	return count > 0, nil
+}
+
+// markSent stamps row as sent. A failure is reported and stops the
+// batch; the row will be relayed again on the next poll.
+func (r *relay[T]) markSent(ctx context.Context, row pendingRow, msg any) bool {
+	_, err := r.db.ExecContext(ctx, r.stmts.markSent, r.clock.Now().UnixNano(), row.id)
+	if err != nil {
+		r.stats.RecordFailure()
+		r.report(ctx, msg, ErrOutbox(ErrMarkSent, err))
+
+		return false
+	}
+
+	return true
+}
+
+// park stamps row as failed so it is no longer fetched. A failure is
+// reported and stops the batch; the row will be decoded again on the
+// next poll.
+func (r *relay[T]) park(ctx context.Context, row pendingRow) bool {
+	_, err := r.db.ExecContext(ctx, r.stmts.park, r.clock.Now().UnixNano(), row.id)
+	if err != nil {
+		r.report(ctx, row.data, ErrOutbox(ErrDecode, ErrQuery, err))
+
+		return false
+	}
+
+	r.stats.RecordDrop()
+
+	return true
+}
+
+// report forwards err to the configured ErrorHandler, if any.
+func (r *relay[T]) report(ctx context.Context, msg any, err error) {
+	if r.errorHandler != nil {
+		r.errorHandler(ctx, msg, err)
+	}
+}
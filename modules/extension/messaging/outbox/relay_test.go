@@ -0,0 +1,539 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	lctests "github.com/guidomantilla/yarumo/core/common/lifecycle/tests"
+	"github.com/guidomantilla/yarumo/messaging"
+	"github.com/guidomantilla/yarumo/messaging/messagingtest"
+)
+
+// captureErrors returns a thread-safe ErrorHandler that appends every
+// reported error, and a getter that returns a defensive copy.
+func captureErrors() (messaging.ErrorHandler, func() []error) {
+	var mu sync.Mutex
+
+	captured := []error{}
+
+	handler := func(_ context.Context, _ any, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		captured = append(captured, err)
+	}
+
+	get := func() []error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		out := make([]error, len(captured))
+		copy(out, captured)
+
+		return out
+	}
+
+	return handler, get
+}
+
+// recorder is a destination channel that records every published
+// message name in order; fail, when set, decides whether a publish is
+// rejected.
+type recorder struct {
+	mu    sync.Mutex
+	names []string
+	fail  func(name string) bool
+}
+
+func (r *recorder) channel(t *testing.T) messaging.Channel[event] {
+	t.Helper()
+
+	ch := messaging.NewPipelineChannel[event]()
+
+	_, err := ch.Subscribe(func(_ context.Context, msg messaging.Message[event]) error {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		if r.fail != nil && r.fail(msg.Payload.Name) {
+			return errors.New("rejected")
+		}
+
+		r.names = append(r.names, msg.Payload.Name)
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Subscribe returned %v", err)
+	}
+
+	return ch
+}
+
+func (r *recorder) published() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	out := make([]string, len(r.names))
+	copy(out, r.names)
+
+	return out
+}
+
+// startRelay starts relay and stops it on cleanup.
+func startRelay(t *testing.T, relay Relay) {
+	t.Helper()
+
+	err := relay.Start(context.Background())
+	if err != nil {
+		t.Fatalf("Start returned %v", err)
+	}
+
+	t.Cleanup(func() { _ = relay.Stop(context.Background()) })
+}
+
+// waitFor polls cond until it returns true or 2s elapses.
+func waitFor(cond func() bool) bool {
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return true
+		}
+
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	return cond()
+}
+
+func equalNames(got []string, want ...string) bool {
+	if len(got) != len(want) {
+		return false
+	}
+
+	for i := range got {
+		if got[i] != want[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func TestNewRelay(t *testing.T) {
+	t.Parallel()
+
+	db := newTestDB(t)
+	relay := NewRelay[event]("orders", db, messaging.NewNullChannel[event]())
+
+	if relay.Name() != "orders" {
+		t.Fatalf("expected name orders, got %q", relay.Name())
+	}
+}
+
+func TestRelay_PublishesInOrderAndMarksSent(t *testing.T) {
+	t.Parallel()
+
+	db := newTestDB(t)
+	rec := &recorder{}
+
+	writeCommitted(t, db, NewWriter[event](),
+		newTestMessage("m-1", "a"),
+		newTestMessage("m-2", "b"),
+		newTestMessage("m-3", "c"),
+	)
+
+	relay := NewRelay[event]("relay", db, rec.channel(t), WithPollInterval(10*time.Millisecond))
+	startRelay(t, relay)
+
+	ok := waitFor(func() bool { return countRows(t, db, "sent_at IS NOT NULL") == 3 })
+	if !ok {
+		t.Fatalf("expected every row marked sent, published %v", rec.published())
+	}
+
+	if !equalNames(rec.published(), "a", "b", "c") {
+		t.Fatalf("expected in-order publication [a b c], got %v", rec.published())
+	}
+
+	// Rows committed after the first poll are picked up too.
+	writeCommitted(t, db, NewWriter[event](), newTestMessage("m-4", "d"))
+
+	ok = waitFor(func() bool { return len(rec.published()) == 4 })
+	if !ok || !equalNames(rec.published(), "a", "b", "c", "d") {
+		t.Fatalf("expected late row relayed, got %v", rec.published())
+	}
+
+	ok = waitFor(func() bool { return relay.Stats().InFlight == 0 })
+
+	stats := relay.Stats()
+	if !ok || stats.Delivered != 4 || stats.Sent != 4 || stats.Failed != 0 || stats.Dropped != 0 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
+
+func TestRelay_FullBatchesAreRelayedBackToBack(t *testing.T) {
+	t.Parallel()
+
+	db := newTestDB(t)
+	rec := &recorder{}
+
+	writeCommitted(t, db, NewWriter[event](),
+		newTestMessage("m-1", "a"),
+		newTestMessage("m-2", "b"),
+		newTestMessage("m-3", "c"),
+		newTestMessage("m-4", "d"),
+		newTestMessage("m-5", "e"),
+	)
+
+	startRelay(t, NewRelay[event]("relay", db, rec.channel(t), WithBatchSize(2), WithPollInterval(time.Hour)))
+
+	ok := waitFor(func() bool { return len(rec.published()) == 5 })
+	if !ok {
+		t.Fatalf("expected all rows relayed without waiting the poll interval, got %v", rec.published())
+	}
+}
+
+func TestRelay_Deduplicates(t *testing.T) {
+	t.Parallel()
+
+	db := newTestDB(t)
+	rec := &recorder{}
+
+	var (
+		mu    sync.Mutex
+		drops []DropReason
+	)
+
+	dropHandler := func(_ context.Context, _ any, reason DropReason) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		drops = append(drops, reason)
+	}
+
+	writeCommitted(t, db, NewWriter[event](),
+		newTestMessage("m-1", "a"),
+		newTestMessage("m-1", "a-again"),
+		newTestMessage("m-2", "b"),
+	)
+
+	relay := NewRelay[event]("relay", db, rec.channel(t),
+		WithPollInterval(10*time.Millisecond),
+		WithDropHandler(dropHandler),
+	)
+	startRelay(t, relay)
+
+	ok := waitFor(func() bool { return countRows(t, db, "sent_at IS NOT NULL") == 3 })
+	if !ok {
+		t.Fatal("expected every row marked sent")
+	}
+
+	// A duplicate committed after the original was sent is skipped as
+	// well.
+	writeCommitted(t, db, NewWriter[event](), newTestMessage("m-2", "b-again"))
+
+	ok = waitFor(func() bool { return countRows(t, db, "sent_at IS NOT NULL") == 4 })
+	if !ok {
+		t.Fatal("expected late duplicate marked sent")
+	}
+
+	if !equalNames(rec.published(), "a", "b") {
+		t.Fatalf("expected duplicates skipped, got %v", rec.published())
+	}
+
+	ok = waitFor(func() bool { return relay.Stats().Dropped == 2 })
+
+	stats := relay.Stats()
+	if !ok || stats.Delivered != 4 || stats.Sent != 2 || stats.Dropped != 2 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if len(drops) != 2 || drops[0] != DropReasonDuplicate || drops[1] != DropReasonDuplicate {
+		t.Fatalf("expected two duplicate drops, got %v", drops)
+	}
+}
+
+func TestRelay_PublishFailureStopsBatchAndRetries(t *testing.T) {
+	t.Parallel()
+
+	db := newTestDB(t)
+	errorHandler, errs := captureErrors()
+
+	var (
+		mu       sync.Mutex
+		rejected bool
+	)
+
+	rec := &recorder{fail: func(name string) bool {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if name == "b" && !rejected {
+			rejected = true
+
+			return true
+		}
+
+		return false
+	}}
+
+	writeCommitted(t, db, NewWriter[event](),
+		newTestMessage("m-1", "a"),
+		newTestMessage("m-2", "b"),
+		newTestMessage("m-3", "c"),
+	)
+
+	relay := NewRelay[event]("relay", db, rec.channel(t),
+		WithPollInterval(10*time.Millisecond),
+		WithErrorHandler(errorHandler),
+	)
+	startRelay(t, relay)
+
+	ok := waitFor(func() bool { return len(rec.published()) == 3 })
+	if !ok {
+		t.Fatalf("expected retry to publish every row, got %v", rec.published())
+	}
+
+	if !equalNames(rec.published(), "a", "b", "c") {
+		t.Fatalf("expected order preserved across the retry, got %v", rec.published())
+	}
+
+	captured := errs()
+	if len(captured) != 1 || !errors.Is(captured[0], ErrPublish) {
+		t.Fatalf("expected one ErrPublish, got %v", captured)
+	}
+
+	ok = waitFor(func() bool { return countRows(t, db, "sent_at IS NOT NULL") == 3 })
+
+	stats := relay.Stats()
+	if !ok || stats.Delivered != 4 || stats.Sent != 3 || stats.Failed != 1 || stats.LastErrorTime.IsZero() {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
+
+func TestRelay_UndecodableRowIsParked(t *testing.T) {
+	t.Parallel()
+
+	db := newTestDB(t)
+	rec := &recorder{}
+	errorHandler, errs := captureErrors()
+
+	_, err := db.ExecContext(context.Background(),
+		"INSERT INTO messaging_outbox (message_id, message, created_at) VALUES ('bad', 'not json', 0)")
+	if err != nil {
+		t.Fatalf("insert returned %v", err)
+	}
+
+	writeCommitted(t, db, NewWriter[event](), newTestMessage("m-1", "a"))
+
+	relay := NewRelay[event]("relay", db, rec.channel(t),
+		WithPollInterval(10*time.Millisecond),
+		WithErrorHandler(errorHandler),
+	)
+	startRelay(t, relay)
+
+	ok := waitFor(func() bool { return countRows(t, db, "sent_at IS NOT NULL") == 1 })
+	if !ok {
+		t.Fatal("expected the valid row marked sent")
+	}
+
+	if !equalNames(rec.published(), "a") {
+		t.Fatalf("expected only the valid row published, got %v", rec.published())
+	}
+
+	if countRows(t, db, "message_id = 'bad' AND failed_at IS NOT NULL AND sent_at IS NULL") != 1 {
+		t.Fatal("expected the undecodable row parked, not marked sent")
+	}
+
+	// A parked row is not fetched again: a few more polls report
+	// nothing new.
+	time.Sleep(50 * time.Millisecond)
+
+	captured := errs()
+	if len(captured) != 1 || !errors.Is(captured[0], ErrDecode) {
+		t.Fatalf("expected one ErrDecode, got %v", captured)
+	}
+
+	stats := relay.Stats()
+	if stats.Delivered != 2 || stats.Sent != 1 || stats.Failed != 1 || stats.Dropped != 1 {
+		t.Fatalf("expected the parked row failed and dropped, got %+v", stats)
+	}
+}
+
+func TestRelay_ClockStampsSentAndFailed(t *testing.T) {
+	t.Parallel()
+
+	instant := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	db := newTestDB(t)
+	rec := &recorder{}
+
+	_, err := db.ExecContext(context.Background(),
+		"INSERT INTO messaging_outbox (message_id, message, created_at) VALUES ('bad', 'not json', 0)")
+	if err != nil {
+		t.Fatalf("insert returned %v", err)
+	}
+
+	writeCommitted(t, db, NewWriter[event](), newTestMessage("m-1", "a"))
+
+	startRelay(t, NewRelay[event]("relay", db, rec.channel(t),
+		WithPollInterval(10*time.Millisecond),
+		WithErrorHandler(messaging.SilentErrorHandler),
+		WithClock(messagingtest.NewFakeClock(instant)),
+	))
+
+	ok := waitFor(func() bool { return countRows(t, db, "sent_at IS NOT NULL") == 1 })
+	if !ok {
+		t.Fatal("expected the valid row marked sent")
+	}
+
+	stamp := instant.UnixNano()
+
+	if countRows(t, db, fmt.Sprintf("message_id = 'm-1' AND sent_at = %d", stamp)) != 1 {
+		t.Fatalf("expected sent_at %d", stamp)
+	}
+
+	if countRows(t, db, fmt.Sprintf("message_id = 'bad' AND failed_at = %d", stamp)) != 1 {
+		t.Fatalf("expected failed_at %d", stamp)
+	}
+}
+
+func TestRelay_QueryFailureIsReported(t *testing.T) {
+	t.Parallel()
+
+	db := newTestDB(t)
+	errorHandler, errs := captureErrors()
+
+	startRelay(t, NewRelay[event]("relay", db, messaging.NewNullChannel[event](),
+		WithTable("missing"),
+		WithPollInterval(10*time.Millisecond),
+		WithErrorHandler(errorHandler),
+	))
+
+	ok := waitFor(func() bool { return len(errs()) > 0 })
+	if !ok {
+		t.Fatal("expected query failure to be reported")
+	}
+
+	if !errors.Is(errs()[0], ErrQuery) {
+		t.Fatalf("expected ErrQuery, got %v", errs()[0])
+	}
+}
+
+func TestRelay_MarkSentFailureIsReported(t *testing.T) {
+	t.Parallel()
+
+	db := newTestDB(t)
+	rec := &recorder{}
+	errorHandler, errs := captureErrors()
+
+	_, err := db.ExecContext(context.Background(), `CREATE TRIGGER reject_mark BEFORE UPDATE ON messaging_outbox
+BEGIN SELECT RAISE(ABORT, 'read only'); END`)
+	if err != nil {
+		t.Fatalf("create trigger returned %v", err)
+	}
+
+	writeCommitted(t, db, NewWriter[event](), newTestMessage("m-1", "a"))
+
+	startRelay(t, NewRelay[event]("relay", db, rec.channel(t),
+		WithPollInterval(10*time.Millisecond),
+		WithErrorHandler(errorHandler),
+	))
+
+	ok := waitFor(func() bool { return len(errs()) > 0 })
+	if !ok {
+		t.Fatal("expected mark-sent failure to be reported")
+	}
+
+	if !errors.Is(errs()[0], ErrMarkSent) {
+		t.Fatalf("expected ErrMarkSent, got %v", errs()[0])
+	}
+
+	if countRows(t, db, "sent_at IS NULL") != 1 {
+		t.Fatal("expected the row to stay pending")
+	}
+}
+
+func TestRelay_Lifecycle(t *testing.T) {
+	t.Parallel()
+
+	t.Run("stop before start closes done", func(t *testing.T) {
+		t.Parallel()
+
+		relay := NewRelay[event]("relay", newTestDB(t), messaging.NewNullChannel[event]())
+
+		err := relay.Stop(context.Background())
+		if err != nil {
+			t.Fatalf("Stop returned %v", err)
+		}
+
+		select {
+		case <-relay.Done():
+		default:
+			t.Fatal("expected Done to be closed")
+		}
+
+		err = relay.Start(context.Background())
+		if err != nil {
+			t.Fatalf("late Start returned %v", err)
+		}
+	})
+
+	t.Run("stop is idempotent", func(t *testing.T) {
+		t.Parallel()
+
+		relay := NewRelay[event]("relay", newTestDB(t), messaging.NewNullChannel[event]())
+
+		err := relay.Start(context.Background())
+		if err != nil {
+			t.Fatalf("Start returned %v", err)
+		}
+
+		lctests.AssertIdempotentStop(t, relay)
+	})
+
+	t.Run("stop honours ctx deadline", func(t *testing.T) {
+		t.Parallel()
+
+		block := make(chan struct{})
+		dst := messaging.NewPipelineChannel[event]()
+
+		_, err := dst.Subscribe(func(context.Context, messaging.Message[event]) error {
+			<-block
+
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Subscribe returned %v", err)
+		}
+
+		db := newTestDB(t)
+		writeCommitted(t, db, NewWriter[event](), newTestMessage("m-1", "a"))
+
+		relay := NewRelay[event]("relay", db, dst)
+
+		err = relay.Start(context.Background())
+		if err != nil {
+			t.Fatalf("Start returned %v", err)
+		}
+
+		time.Sleep(20 * time.Millisecond)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		err = relay.Stop(ctx)
+		if err == nil {
+			t.Fatal("expected shutdown timeout while the publish is blocked")
+		}
+
+		close(block)
+		<-relay.Done()
+	})
+}
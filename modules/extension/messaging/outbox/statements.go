@@ -0,0 +1,42 @@
+package outbox
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// statements holds the SQL text shared by the writer and the relay,
+// rendered once at construction for the configured table and
+// placeholder style.
+type statements struct {
+	insert    string
+	pending   string
+	published string
+	markSent  string
+	park      string
+}
+
+// newStatements renders the outbox statements for table using the given
+// placeholder style.
+func newStatements(table string, placeholder Placeholder) statements {
+	bind := func(n int) string {
+		if placeholder == PlaceholderDollar {
+			return "$" + strconv.Itoa(n)
+		}
+
+		return "?"
+	}
+
+	return statements{
+		insert: fmt.Sprintf("INSERT INTO %s (message_id, message, created_at) VALUES (%s, %s, %s)",
+			table, bind(1), bind(2), bind(3)),
+		pending: fmt.Sprintf("SELECT id, message_id, message FROM %s WHERE sent_at IS NULL AND failed_at IS NULL ORDER BY id LIMIT %s",
+			table, bind(1)),
+		published: fmt.Sprintf("SELECT COUNT(1) FROM %s WHERE message_id = %s AND sent_at IS NOT NULL",
+			table, bind(1)),
+		markSent: fmt.Sprintf("UPDATE %s SET sent_at = %s WHERE id = %s",
+			table, bind(1), bind(2)),
+		park: fmt.Sprintf("UPDATE %s SET failed_at = %s WHERE id = %s",
+			table, bind(1), bind(2)),
+	}
+}
@@ -0,0 +1,140 @@
+// Package outbox implements the Transactional Outbox pattern for
+// common/messaging over database/sql.
+//
+// A service that must both change its own database and publish an
+// event cannot do the two atomically against different systems. The
+// outbox splits the problem: the event is written into an outbox table
+// inside the SAME *sql.Tx as the business change (Writer), and a
+// background Relay polls the table and publishes the committed rows to
+// a messaging.Channel[T]. Either both the business change and the
+// event commit, or neither does.
+//
+// # Schema
+//
+// The package owns no migrations; create the table with the dialect of
+// your database. The required columns (names fixed, table name
+// configurable via WithTable, default "messaging_outbox") are:
+//
+//	CREATE TABLE messaging_outbox (
+//	    id          INTEGER PRIMARY KEY AUTOINCREMENT, -- BIGSERIAL, BIGINT AUTO_INCREMENT, ...
+//	    message_id  VARCHAR(255) NOT NULL,
+//	    message     TEXT         NOT NULL,
+//	    created_at  BIGINT       NOT NULL,
+//	    sent_at     BIGINT       NULL,
+//	    failed_at   BIGINT       NULL
+//	);
+//	CREATE INDEX messaging_outbox_pending    ON messaging_outbox (sent_at, failed_at, id);
+//	CREATE INDEX messaging_outbox_message_id ON messaging_outbox (message_id);
+//
+// id is assigned by the database (auto-increment / identity /
+// sequence) and the relay publishes in id order. message holds the
+// JSON encoding of the whole messaging.Message[T] (payload and
+// headers); created_at, sent_at and failed_at are Unix nanoseconds.
+// Queries use LIMIT and the placeholder style selected with
+// WithPlaceholder (PlaceholderQuestion for SQLite and MySQL,
+// PlaceholderDollar for PostgreSQL).
+//
+// # Delivery guarantee
+//
+// At-least-once. Every committed row is eventually published: the
+// relay publishes the pending rows it can see in id order and marks
+// each one sent only after dst.Send returned nil. A failed Send stops
+// the batch (later rows are not published ahead of it) and the row is
+// retried on the next poll. A crash between Send and the mark
+// republishes the row — consumers should be idempotent (see
+// patterns/endpoints/idempotent).
+//
+// Order follows id, and ids are handed out when rows are inserted, not
+// when their transactions commit. With concurrent writers a row with
+// a lower id can commit after the relay already published a higher
+// one; it is still published on the next poll, but after it. Rows
+// written by one transaction, or by transactions that are serialized
+// (for example by locking the aggregate row they belong to), are
+// published in the order they were written. Consumers that need a
+// total order across concurrent writers must reorder on their side
+// (see patterns/routers/resequencer).
+//
+// A row that cannot be decoded is parked: failed_at is set, the relay
+// reports ErrDecode to the ErrorHandler and moves on, and the row is
+// never picked up again. Inspect parked rows with
+// "WHERE failed_at IS NOT NULL" and clear failed_at to retry them.
+//
+// # Deduplication
+//
+// Headers.MessageID is the dedup key and Writer rejects messages
+// without one. Before publishing a row the relay checks whether a row
+// with the same message_id was already sent (or appeared earlier in the
+// same batch); duplicates are marked sent without publishing and
+// reported to the optional DropHandler. A UNIQUE constraint on
+// message_id may be added to reject duplicates at write time instead.
+//
+// # Concurrency
+//
+// Run a single Relay per outbox table. Several relays polling the same
+// table would publish the same rows concurrently; use leader election
+// when the process is replicated.
+//
+// # Lifecycle
+//
+// Relay implements common/lifecycle.Component (worker-style): Start
+// spawns the polling goroutine and returns; Stop cancels it and waits
+// (bounded by ctx) for the in-flight batch to finish. Writer is a
+// passive value with no lifecycle.
+package outbox
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/guidomantilla/yarumo/core/common/lifecycle"
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+var (
+	_ Writer[any] = (*writer[any])(nil)
+	_ Relay       = (*relay[any])(nil)
+
+	_ ErrOutboxFn = ErrOutbox
+)
+
+// Writer stores messages in the outbox table as part of a caller-owned
+// transaction.
+type Writer[T any] interface {
+	// Write inserts msg into the outbox using tx. The row becomes
+	// visible to the Relay only when the caller commits tx, and
+	// disappears with it on rollback. Returns ErrOutbox(ErrTxNil) on a
+	// nil tx, ErrOutbox(ErrMessageIDEmpty) when Headers.MessageID is
+	// empty, ErrOutbox(ErrEncode, ...) when msg cannot be encoded and
+	// ErrOutbox(ErrQuery, ...) when the insert fails.
+	Write(ctx context.Context, tx *sql.Tx, msg messaging.Message[T]) error
+}
+
+// Relay is the public interface for the outbox relay. It embeds
+// lifecycle.Component so callers wire it up with lifecycle.Build, and
+// messaging.Inspectable to expose its runtime statistics. The interface
+// exists (rather than returning lifecycle.Component directly) so the
+// API surface preserves "this is a Relay" semantics and the type stays
+// open to relay-specific methods without breaking callers.
+type Relay interface {
+	lifecycle.Component
+	messaging.Inspectable
+}
+
+// DropReason classifies why the relay marked a row sent without
+// publishing it.
+type DropReason string
+
+const (
+	// DropReasonDuplicate indicates a row whose message_id was already
+	// published (by an earlier row or earlier in the same batch).
+	DropReasonDuplicate DropReason = "duplicate"
+)
+
+// DropHandler is the optional observability hook invoked once per row
+// the relay skipped on purpose. msg is the decoded messaging.Message[T]
+// (type-erased); reason classifies the drop. The hook runs on the relay
+// goroutine and must not block.
+type DropHandler func(ctx context.Context, msg any, reason DropReason)
+
+// ErrOutboxFn is the function type for ErrOutbox.
+type ErrOutboxFn func(causes ...error) error
@@ -0,0 +1,56 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+// writer is the Writer implementation: a stateless INSERT into the
+// outbox table through the caller's transaction.
+type writer[T any] struct {
+	stmts statements
+	clock messaging.Clock
+}
+
+// NewWriter constructs a Writer[T] for the outbox table selected by
+// WithTable, rendering SQL with the style selected by WithPlaceholder,
+// and stamps created_at with the clock of WithClock. Relay-only options
+// are ignored. The same options must be passed to
+// NewRelay so both sides agree on table and dialect.
+func NewWriter[T any](opts ...Option) Writer[T] {
+	options := NewOptions(opts...)
+
+	return &writer[T]{
+		stmts: newStatements(options.table, options.placeholder),
+		clock: options.clock,
+	}
+}
+
+// Write inserts msg into the outbox as part of tx. See Writer.Write.
+func (w *writer[T]) Write(ctx context.Context, tx *sql.Tx, msg messaging.Message[T]) error {
+	cassert.NotNil(w, "outbox writer is nil")
+
+	if tx == nil {
+		return ErrOutbox(ErrTxNil)
+	}
+
+	if msg.Headers.MessageID == "" {
+		return ErrOutbox(ErrMessageIDEmpty)
+	}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return ErrOutbox(ErrEncode, err)
+	}
+
+	_, err = tx.ExecContext(ctx, w.stmts.insert, msg.Headers.MessageID, string(data), w.clock.Now().UnixNano())
+	if err != nil {
+		return ErrOutbox(ErrQuery, err)
+	}
+
+	return nil
+}
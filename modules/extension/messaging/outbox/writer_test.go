@@ -0,0 +1,237 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	_ "modernc.org/sqlite"
+
+	"github.com/guidomantilla/yarumo/messaging"
+	"github.com/guidomantilla/yarumo/messaging/messagingtest"
+)
+
+const testSchema = `CREATE TABLE messaging_outbox (
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    message_id  TEXT    NOT NULL,
+    message     TEXT    NOT NULL,
+    created_at  INTEGER NOT NULL,
+    sent_at     INTEGER NULL,
+    failed_at   INTEGER NULL
+)`
+
+type event struct {
+	Name string `json:"name"`
+}
+
+// newTestDB opens a file-backed SQLite database in a per-test temp dir
+// with the default outbox table created. A single connection avoids
+// SQLITE_BUSY between the relay and the test's own writes.
+func newTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "outbox.db"))
+	if err != nil {
+		t.Fatalf("sql.Open returned %v", err)
+	}
+
+	db.SetMaxOpenConns(1)
+
+	t.Cleanup(func() { _ = db.Close() })
+
+	_, err = db.ExecContext(context.Background(), testSchema)
+	if err != nil {
+		t.Fatalf("create schema returned %v", err)
+	}
+
+	return db
+}
+
+// newTestMessage builds a message with the given dedup id.
+func newTestMessage(id string, name string) messaging.Message[event] {
+	msg := messaging.NewMessage(event{Name: name}, nil)
+	msg.Headers.MessageID = id
+
+	return msg
+}
+
+// writeCommitted writes msgs through w in a single committed tx.
+func writeCommitted(t *testing.T, db *sql.DB, w Writer[event], msgs ...messaging.Message[event]) {
+	t.Helper()
+
+	tx, err := db.BeginTx(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("BeginTx returned %v", err)
+	}
+
+	for _, msg := range msgs {
+		err = w.Write(context.Background(), tx, msg)
+		if err != nil {
+			_ = tx.Rollback()
+			t.Fatalf("Write returned %v", err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		t.Fatalf("Commit returned %v", err)
+	}
+}
+
+// countRows returns the number of outbox rows matching where.
+func countRows(t *testing.T, db *sql.DB, where string) int {
+	t.Helper()
+
+	var count int
+
+	err := db.QueryRowContext(context.Background(), "SELECT COUNT(1) FROM messaging_outbox WHERE "+where).Scan(&count)
+	if err != nil {
+		t.Fatalf("count returned %v", err)
+	}
+
+	return count
+}
+
+func TestWriter_Write(t *testing.T) {
+	t.Parallel()
+
+	t.Run("stores message in committed tx", func(t *testing.T) {
+		t.Parallel()
+
+		db := newTestDB(t)
+		w := NewWriter[event]()
+
+		writeCommitted(t, db, w, newTestMessage("m-1", "created"))
+
+		var (
+			messageID string
+			data      string
+		)
+
+		err := db.QueryRowContext(context.Background(), "SELECT message_id, message FROM messaging_outbox").Scan(&messageID, &data)
+		if err != nil {
+			t.Fatalf("select returned %v", err)
+		}
+
+		if messageID != "m-1" {
+			t.Fatalf("expected message_id m-1, got %q", messageID)
+		}
+
+		var stored messaging.Message[event]
+
+		err = json.Unmarshal([]byte(data), &stored)
+		if err != nil {
+			t.Fatalf("stored message is not JSON: %v", err)
+		}
+
+		if stored.Payload.Name != "created" || stored.Headers.MessageID != "m-1" {
+			t.Fatalf("unexpected stored message %+v", stored)
+		}
+	})
+
+	t.Run("stamps created_at with the clock", func(t *testing.T) {
+		t.Parallel()
+
+		instant := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+		db := newTestDB(t)
+		w := NewWriter[event](WithClock(messagingtest.NewFakeClock(instant)))
+
+		writeCommitted(t, db, w, newTestMessage("m-1", "created"))
+
+		if countRows(t, db, fmt.Sprintf("created_at = %d", instant.UnixNano())) != 1 {
+			t.Fatalf("expected created_at %d", instant.UnixNano())
+		}
+	})
+
+	t.Run("rollback discards the row", func(t *testing.T) {
+		t.Parallel()
+
+		db := newTestDB(t)
+		w := NewWriter[event]()
+
+		tx, err := db.BeginTx(context.Background(), nil)
+		if err != nil {
+			t.Fatalf("BeginTx returned %v", err)
+		}
+
+		err = w.Write(context.Background(), tx, newTestMessage("m-1", "created"))
+		if err != nil {
+			t.Fatalf("Write returned %v", err)
+		}
+
+		_ = tx.Rollback()
+
+		if countRows(t, db, "1 = 1") != 0 {
+			t.Fatal("expected no rows after rollback")
+		}
+	})
+
+	t.Run("nil tx", func(t *testing.T) {
+		t.Parallel()
+
+		err := NewWriter[event]().Write(context.Background(), nil, newTestMessage("m-1", "x"))
+		if !errors.Is(err, ErrTxNil) {
+			t.Fatalf("expected ErrTxNil, got %v", err)
+		}
+	})
+
+	t.Run("empty message id", func(t *testing.T) {
+		t.Parallel()
+
+		db := newTestDB(t)
+
+		tx, err := db.BeginTx(context.Background(), nil)
+		if err != nil {
+			t.Fatalf("BeginTx returned %v", err)
+		}
+		defer tx.Rollback()
+
+		err = NewWriter[event]().Write(context.Background(), tx, newTestMessage("", "x"))
+		if !errors.Is(err, ErrMessageIDEmpty) {
+			t.Fatalf("expected ErrMessageIDEmpty, got %v", err)
+		}
+	})
+
+	t.Run("unencodable payload", func(t *testing.T) {
+		t.Parallel()
+
+		db := newTestDB(t)
+
+		tx, err := db.BeginTx(context.Background(), nil)
+		if err != nil {
+			t.Fatalf("BeginTx returned %v", err)
+		}
+		defer tx.Rollback()
+
+		msg := messaging.NewMessage[func()](func() {}, nil)
+		msg.Headers.MessageID = "m-1"
+
+		err = NewWriter[func()]().Write(context.Background(), tx, msg)
+		if !errors.Is(err, ErrEncode) {
+			t.Fatalf("expected ErrEncode, got %v", err)
+		}
+	})
+
+	t.Run("insert failure", func(t *testing.T) {
+		t.Parallel()
+
+		db := newTestDB(t)
+
+		tx, err := db.BeginTx(context.Background(), nil)
+		if err != nil {
+			t.Fatalf("BeginTx returned %v", err)
+		}
+		defer tx.Rollback()
+
+		err = NewWriter[event](WithTable("missing")).Write(context.Background(), tx, newTestMessage("m-1", "x"))
+		if !errors.Is(err, ErrQuery) {
+			t.Fatalf("expected ErrQuery, got %v", err)
+		}
+	})
+}
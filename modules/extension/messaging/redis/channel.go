@@ -0,0 +1,334 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+	"github.com/guidomantilla/yarumo/core/common/lifecycle"
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+// channel is the push-based Channel implementation. A single consumer
+// goroutine reads batches for its consumer of the group and dispatches
+// every entry to all subscribed handlers, acknowledging it once they
+// all succeeded.
+type channel[T any] struct {
+	name         string
+	stream       *stream[T]
+	batchSize    int64
+	drainTimeout time.Duration
+
+	mu           sync.RWMutex
+	handlers     map[uint64]messaging.Handler[T]
+	nextID       uint64
+	started      bool
+	workerCancel context.CancelFunc
+	closed       atomic.Bool
+
+	done      chan struct{}
+	startOnce sync.Once
+	stopOnce  sync.Once
+	doneOnce  sync.Once
+}
+
+// NewChannel constructs a Redis Streams backed Channel named name. The
+// channel also implements lifecycle.Component (worker-style): Send
+// works right away, handlers only receive entries between Start and
+// Stop. name must be non-empty.
+//
+// Optional behaviors:
+//
+//   - WithClient / WithAddr / WithPassword / WithDB select the Redis
+//     server.
+//   - WithStream / WithGroup / WithConsumer override the stream key,
+//     group and consumer names.
+//   - WithCodec replaces the JSON envelope codec.
+//   - WithMaxLen trims the stream approximately on every XADD.
+//   - WithBatchSize / WithBlockTimeout tune XREADGROUP.
+//   - WithVisibilityTimeout / WithMaxDeliveries / WithDLQChannel
+//     control redelivery of failed entries.
+//   - WithDrainTimeout bounds Stop.
+//   - WithErrorHandler observes consumer-side failures.
+//   - WithInterceptors installs messaging.ChannelInterceptor hooks.
+//   - WithClock sets the clock for expirations, dead letters and stats.
+func NewChannel[T any](name string, opts ...Option) messaging.Channel[T] {
+	cassert.NotEmpty(name, "name is empty")
+
+	options := NewOptions(opts...)
+
+	return &channel[T]{
+		name:         name,
+		stream:       newStream[T](name, options),
+		batchSize:    options.batchSize,
+		drainTimeout: options.drainTimeout,
+		handlers:     make(map[uint64]messaging.Handler[T]),
+		done:         make(chan struct{}),
+	}
+}
+
+// Name returns the channel's identity used in lifecycle logs.
+func (c *channel[T]) Name() string {
+	cassert.NotNil(c, "redis channel is nil")
+
+	return c.name
+}
+
+// Start creates the consumer group (and the stream when missing) and
+// spawns the consumer goroutine. A group creation failure is returned
+// as lifecycle.ErrStart and leaves the channel startable again. Start
+// is idempotent once it succeeded, and a no-op after Stop.
+func (c *channel[T]) Start(ctx context.Context) error {
+	cassert.NotNil(c, "redis channel is nil")
+
+	if c.closed.Load() {
+		return nil
+	}
+
+	err := c.stream.ensureGroup(ctx)
+	if err != nil {
+		return lifecycle.ErrStart(err)
+	}
+
+	c.startOnce.Do(func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		if c.closed.Load() {
+			return
+		}
+
+		workerCtx, workerCancel := context.WithCancel(context.WithoutCancel(ctx))
+		c.workerCancel = workerCancel
+		c.started = true
+
+		go func() {
+			defer c.doneOnce.Do(func() { close(c.done) })
+			defer c.stream.close()
+
+			c.run(workerCtx)
+		}()
+	})
+
+	return nil
+}
+
+// Stop cancels the consumer goroutine and waits, bounded by the drain
+// timeout and ctx, for the in-flight batch to finish. Entries read but
+// not acknowledged stay pending and are claimed back by another
+// consumer after the visibility timeout. Stop is idempotent.
+func (c *channel[T]) Stop(ctx context.Context) error {
+	cassert.NotNil(c, "redis channel is nil")
+
+	c.stopOnce.Do(func() {
+		c.closed.Store(true)
+
+		// Consume startOnce so a late Start is a no-op.
+		c.startOnce.Do(func() {})
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		if !c.started {
+			c.stream.close()
+			c.doneOnce.Do(func() { close(c.done) })
+
+			return
+		}
+
+		c.workerCancel()
+	})
+
+	waitCtx, cancel := context.WithTimeout(ctx, c.drainTimeout)
+	defer cancel()
+
+	select {
+	case <-c.done:
+		return nil
+	case <-waitCtx.Done():
+		return lifecycle.ErrShutdown(lifecycle.ErrShutdownTimeout, waitCtx.Err())
+	}
+}
+
+// Done returns the channel that is closed after the consumer goroutine
+// has exited.
+func (c *channel[T]) Done() <-chan struct{} {
+	cassert.NotNil(c, "redis channel is nil")
+
+	return c.done
+}
+
+// Stats returns a snapshot of the channel's runtime statistics. Sent
+// counts the entries appended by this instance and Delivered the
+// handler invocations of its consumer; Dropped counts expired entries
+// and DeadLettered the entries handed to the DLQ. Buffer fields are
+// zero because the backlog lives in Redis.
+func (c *channel[T]) Stats() messaging.Stats {
+	cassert.NotNil(c, "redis channel is nil")
+
+	stats := c.stream.stats.Snapshot()
+
+	c.mu.RLock()
+	stats.Subscribers = len(c.handlers)
+	c.mu.RUnlock()
+
+	return stats
+}
+
+// Send runs the interceptor chain and appends msg to the stream. It
+// returns ErrSend(ErrClosed) after Stop, ErrSend(ErrContextNil) for a
+// nil ctx, ErrSend(ErrIntercepted) on a PreSend veto and ErrSend
+// wrapping ErrRedis on encoding or XADD failures.
+func (c *channel[T]) Send(ctx context.Context, msg messaging.Message[T]) error {
+	cassert.NotNil(c, "redis channel is nil")
+
+	return messaging.InterceptSend(ctx, c.stream.interceptors, msg, c.send)
+}
+
+// send is Send without the interceptor chain.
+func (c *channel[T]) send(ctx context.Context, msg messaging.Message[T]) error {
+	if ctx == nil {
+		return messaging.ErrSend(messaging.ErrContextNil)
+	}
+
+	if c.closed.Load() {
+		return messaging.ErrSend(messaging.ErrClosed)
+	}
+
+	return c.stream.send(ctx, msg)
+}
+
+// Subscribe registers handler, wrapped with the PreHandle and
+// AfterHandle hooks; every entry consumed by this channel is
+// dispatched to every registered handler. The returned Cancel
+// unregisters it. Returns ErrSubscribe(ErrHandlerNil) for a nil
+// handler and ErrSubscribe(ErrClosed) after Stop.
+func (c *channel[T]) Subscribe(handler messaging.Handler[T]) (messaging.Cancel, error) {
+	cassert.NotNil(c, "redis channel is nil")
+
+	if handler == nil {
+		return nil, messaging.ErrSubscribe(messaging.ErrHandlerNil)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed.Load() {
+		return nil, messaging.ErrSubscribe(messaging.ErrClosed)
+	}
+
+	c.nextID++
+	id := c.nextID
+	c.handlers[id] = messaging.InterceptHandler(c.stream.interceptors, handler)
+
+	var once sync.Once
+
+	cancel := func() {
+		once.Do(func() {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+
+			delete(c.handlers, id)
+		})
+	}
+
+	return cancel, nil
+}
+
+// run is the consumer loop. It does not read while no handler is
+// subscribed so entries stay available to the rest of the group.
+func (c *channel[T]) run(ctx context.Context) {
+	for ctx.Err() == nil {
+		handlers := c.snapshot()
+		if len(handlers) == 0 {
+			c.idle(ctx)
+
+			continue
+		}
+
+		deliveries, err := c.stream.fetch(ctx, c.batchSize)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+
+			c.stream.report(ctx, nil, err)
+			c.idle(ctx)
+
+			continue
+		}
+
+		// The batch already read is finished even when Stop cancels
+		// ctx meanwhile; Stop bounds the wait with the drain timeout.
+		dispatchCtx := context.WithoutCancel(ctx)
+
+		for _, d := range deliveries {
+			c.dispatch(dispatchCtx, d, handlers)
+		}
+	}
+}
+
+// dispatch hands d to every handler and acknowledges it when all of
+// them returned nil. Otherwise the joined errors are reported and the
+// entry stays pending for redelivery.
+func (c *channel[T]) dispatch(ctx context.Context, d delivery[T], handlers []messaging.Handler[T]) {
+	var errs []error
+
+	for _, handler := range handlers {
+		end := c.stream.stats.Begin()
+		err := invoke(ctx, d.msg, handler)
+		end(err)
+
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		c.stream.report(ctx, d.msg, errors.Join(errs...))
+
+		return
+	}
+
+	c.stream.ack(ctx, d.id)
+}
+
+// snapshot returns the handlers registered right now.
+func (c *channel[T]) snapshot() []messaging.Handler[T] {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return slices.Collect(maps.Values(c.handlers))
+}
+
+// idle waits blockTimeout or until ctx is cancelled.
+func (c *channel[T]) idle(ctx context.Context) {
+	timer := time.NewTimer(c.stream.blockTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+}
+
+// invoke runs one handler with panic recovery. A panic is returned as
+// an error wrapping messaging.ErrHandlerPanic.
+func invoke[T any](ctx context.Context, msg messaging.Message[T], handler messaging.Handler[T]) (err error) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+
+		err = fmt.Errorf("%w: %v", messaging.ErrHandlerPanic, r)
+	}()
+
+	return handler(ctx, msg)
+}
@@ -0,0 +1,737 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+
+	"github.com/guidomantilla/yarumo/core/common/lifecycle"
+	"github.com/guidomantilla/yarumo/messaging"
+	"github.com/guidomantilla/yarumo/messaging/messagingtest"
+)
+
+const (
+	testBlock      = 20 * time.Millisecond
+	testVisibility = 50 * time.Millisecond
+	testWait       = 3 * time.Second
+)
+
+// testOptions points a channel at mr with timeouts short enough for
+// tests.
+func testOptions(mr *miniredis.Miniredis, opts ...Option) []Option {
+	return append([]Option{
+		WithAddr(mr.Addr()),
+		WithBlockTimeout(testBlock),
+		WithVisibilityTimeout(testVisibility),
+	}, opts...)
+}
+
+// startChannel builds and starts a channel, stopping it on cleanup.
+func startChannel[T any](t *testing.T, name string, opts ...Option) *channel[T] {
+	t.Helper()
+
+	c, _ := NewChannel[T](name, opts...).(*channel[T])
+
+	err := c.Start(context.Background())
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	t.Cleanup(func() { _ = c.Stop(context.Background()) })
+
+	return c
+}
+
+// errorRecorder is a concurrency-safe ErrorHandler capturing errors.
+type errorRecorder struct {
+	mu   sync.Mutex
+	errs []error
+}
+
+func (r *errorRecorder) handle(_ context.Context, _ any, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.errs = append(r.errs, err)
+}
+
+func (r *errorRecorder) has(target error) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, err := range r.errs {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// waitFor polls cond until it holds or testWait elapses.
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(testWait)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return
+		}
+
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	t.Fatal("condition not met in time")
+}
+
+func TestNewChannel(t *testing.T) {
+	t.Parallel()
+
+	t.Run("defaults stream and group to the name", func(t *testing.T) {
+		t.Parallel()
+
+		c, _ := NewChannel[int]("orders").(*channel[int])
+
+		if c.Name() != "orders" {
+			t.Fatalf("expected name orders, got %q", c.Name())
+		}
+
+		if c.stream.key != "orders" || c.stream.group != "orders" {
+			t.Fatalf("unexpected stream %q group %q", c.stream.key, c.stream.group)
+		}
+
+		if c.stream.consumer == "" {
+			t.Fatal("expected default consumer name")
+		}
+
+		if !c.stream.ownClient {
+			t.Fatal("expected the channel to own its client")
+		}
+	})
+
+	t.Run("explicit names applied", func(t *testing.T) {
+		t.Parallel()
+
+		c, _ := NewChannel[int]("orders",
+			WithStream("events"), WithGroup("billing"), WithConsumer("w1"),
+		).(*channel[int])
+
+		if c.stream.key != "events" || c.stream.group != "billing" || c.stream.consumer != "w1" {
+			t.Fatalf("unexpected names %q %q %q", c.stream.key, c.stream.group, c.stream.consumer)
+		}
+	})
+}
+
+func TestChannel_SendAndConsume(t *testing.T) {
+	t.Parallel()
+
+	mr := miniredis.RunT(t)
+	c := startChannel[string](t, "greetings", testOptions(mr)...)
+
+	got := make(chan messaging.Message[string], 1)
+
+	_, err := c.Subscribe(func(_ context.Context, msg messaging.Message[string]) error {
+		got <- msg
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	msg := messaging.NewMessage("hello", nil)
+	msg.Headers.MessageID = "m-1"
+	msg.Headers.Type = "greeting"
+
+	err = c.Send(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	select {
+	case received := <-got:
+		if received.Payload != "hello" || received.Headers.MessageID != "m-1" || received.Headers.Type != "greeting" {
+			t.Fatalf("unexpected message %+v", received)
+		}
+
+		if received.Headers.DeliveryCount != 1 {
+			t.Fatalf("expected DeliveryCount 1, got %d", received.Headers.DeliveryCount)
+		}
+	case <-time.After(testWait):
+		t.Fatal("message not consumed")
+	}
+
+	waitFor(t, func() bool {
+		pending, _ := c.stream.client.XPending(context.Background(), "greetings", "greetings").Result()
+		return pending != nil && pending.Count == 0
+	})
+}
+
+func TestChannel_Interceptors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("send and handle hooks run", func(t *testing.T) {
+		t.Parallel()
+
+		var hooks atomic.Int32
+
+		interceptor := messaging.InterceptorFuncs[string]{
+			PreSendFn: func(_ context.Context, msg messaging.Message[string]) (messaging.Message[string], error) {
+				hooks.Add(1)
+				msg.Headers.Source = "intercepted"
+
+				return msg, nil
+			},
+			PostSendFn: func(_ context.Context, _ messaging.Message[string], _ error) { hooks.Add(1) },
+			PreHandleFn: func(_ context.Context, msg messaging.Message[string]) (messaging.Message[string], error) {
+				hooks.Add(1)
+
+				return msg, nil
+			},
+			AfterHandleFn: func(_ context.Context, _ messaging.Message[string], _ error) { hooks.Add(1) },
+		}
+
+		mr := miniredis.RunT(t)
+		c := startChannel[string](t, "greetings", testOptions(mr, WithInterceptors[string](interceptor))...)
+
+		got := make(chan messaging.Message[string], 1)
+
+		_, err := c.Subscribe(func(_ context.Context, msg messaging.Message[string]) error {
+			got <- msg
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Subscribe: %v", err)
+		}
+
+		err = c.Send(context.Background(), messaging.NewMessage("hello", nil))
+		if err != nil {
+			t.Fatalf("Send: %v", err)
+		}
+
+		select {
+		case received := <-got:
+			if received.Headers.Source != "intercepted" {
+				t.Fatalf("expected the PreSend rewrite to travel, got %+v", received.Headers)
+			}
+		case <-time.After(testWait):
+			t.Fatal("message not consumed")
+		}
+
+		waitFor(t, func() bool { return hooks.Load() == 4 })
+	})
+
+	t.Run("PreSend veto skips XADD", func(t *testing.T) {
+		t.Parallel()
+
+		veto := errors.New("no source")
+		interceptor := messaging.InterceptorFuncs[string]{
+			PreSendFn: func(_ context.Context, msg messaging.Message[string]) (messaging.Message[string], error) {
+				return msg, veto
+			},
+		}
+
+		mr := miniredis.RunT(t)
+		c, _ := NewChannel[string]("greetings", testOptions(mr, WithInterceptors[string](interceptor))...).(*channel[string])
+		t.Cleanup(func() { _ = c.Stop(context.Background()) })
+
+		err := c.Send(context.Background(), messaging.NewMessage("hello", nil))
+		if !errors.Is(err, messaging.ErrIntercepted) || !errors.Is(err, veto) {
+			t.Fatalf("expected ErrIntercepted wrapping the veto, got %v", err)
+		}
+
+		if mr.Exists("greetings") {
+			t.Fatal("expected nothing appended to the stream")
+		}
+	})
+}
+
+func TestChannel_FailedHandlerIsRedelivered(t *testing.T) {
+	t.Parallel()
+
+	mr := miniredis.RunT(t)
+	recorder := &errorRecorder{}
+	c := startChannel[int](t, "jobs", testOptions(mr, WithErrorHandler(recorder.handle))...)
+
+	boom := errors.New("boom")
+
+	var calls atomic.Int32
+
+	got := make(chan int, 1)
+
+	_, _ = c.Subscribe(func(_ context.Context, msg messaging.Message[int]) error {
+		if calls.Add(1) == 1 {
+			return boom
+		}
+
+		got <- msg.Headers.DeliveryCount
+
+		return nil
+	})
+
+	_ = c.Send(context.Background(), messaging.NewMessage(7, nil))
+
+	select {
+	case count := <-got:
+		if count != 2 {
+			t.Fatalf("expected DeliveryCount 2, got %d", count)
+		}
+	case <-time.After(testWait):
+		t.Fatal("message not redelivered")
+	}
+
+	if !recorder.has(boom) {
+		t.Fatal("expected handler error to be reported")
+	}
+}
+
+func TestChannel_MaxDeliveriesDeadLetters(t *testing.T) {
+	t.Parallel()
+
+	mr := miniredis.RunT(t)
+	recorder := &errorRecorder{}
+	dlq := messaging.NewPipelineChannel[messaging.DeadLetter[int]]()
+	letters := make(chan messaging.DeadLetter[int], 1)
+
+	_, _ = dlq.Subscribe(func(_ context.Context, msg messaging.Message[messaging.DeadLetter[int]]) error {
+		letters <- msg.Payload
+		return nil
+	})
+
+	c := startChannel[int](t, "jobs", testOptions(mr,
+		WithMaxDeliveries(2),
+		WithDLQChannel(dlq),
+		WithErrorHandler(recorder.handle),
+	)...)
+
+	var calls atomic.Int32
+
+	_, _ = c.Subscribe(func(_ context.Context, _ messaging.Message[int]) error {
+		calls.Add(1)
+		return errors.New("always")
+	})
+
+	_ = c.Send(context.Background(), messaging.NewMessage(9, nil))
+
+	select {
+	case letter := <-letters:
+		if letter.Original.Payload != 9 || !errors.Is(letter.LastError, ErrMaxDeliveries) {
+			t.Fatalf("unexpected dead letter %+v", letter)
+		}
+
+		if letter.Original.Headers.DeliveryCount != 3 {
+			t.Fatalf("expected DeliveryCount 3, got %d", letter.Original.Headers.DeliveryCount)
+		}
+	case <-time.After(testWait):
+		t.Fatal("message not dead-lettered")
+	}
+
+	if calls.Load() != 2 {
+		t.Fatalf("expected 2 handler calls, got %d", calls.Load())
+	}
+
+	if !recorder.has(ErrMaxDeliveries) {
+		t.Fatal("expected ErrMaxDeliveries to be reported")
+	}
+
+	waitFor(t, func() bool { return c.Stats().DeadLettered == 1 })
+
+	stats := c.Stats()
+	if stats.Sent != 1 || stats.Delivered != 2 || stats.Failed != 2 || stats.Subscribers != 1 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
+
+func TestChannel_ExpiredEntryIsDropped(t *testing.T) {
+	t.Parallel()
+
+	mr := miniredis.RunT(t)
+	recorder := &errorRecorder{}
+	c := startChannel[int](t, "jobs", testOptions(mr, WithErrorHandler(recorder.handle))...)
+
+	got := make(chan int, 2)
+
+	_, _ = c.Subscribe(func(_ context.Context, msg messaging.Message[int]) error {
+		got <- msg.Payload
+		return nil
+	})
+
+	expired := messaging.NewMessage(1, nil)
+	expired.Headers.ExpirationTime = time.Now().Add(-time.Minute)
+
+	_ = c.Send(context.Background(), expired)
+	_ = c.Send(context.Background(), messaging.NewMessage(2, nil))
+
+	select {
+	case payload := <-got:
+		if payload != 2 {
+			t.Fatalf("expected only the live message, got %d", payload)
+		}
+	case <-time.After(testWait):
+		t.Fatal("live message not consumed")
+	}
+
+	if !recorder.has(messaging.ErrExpired) || !recorder.has(messaging.ErrDropped) {
+		t.Fatal("expected ErrExpired and ErrDropped to be reported")
+	}
+
+	waitFor(t, func() bool { return c.Stats().Delivered == 1 })
+
+	stats := c.Stats()
+	if stats.Sent != 2 || stats.Dropped != 1 || stats.Failed != 0 || stats.DeadLettered != 0 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
+
+func TestChannel_ClockDrivesExpiryAndDeadLetters(t *testing.T) {
+	t.Parallel()
+
+	instant := time.Date(2040, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	mr := miniredis.RunT(t)
+	dlq := messagingtest.NewRecordingChannel[messaging.DeadLetter[int]]()
+	c := startChannel[int](t, "jobs", testOptions(mr,
+		WithClock(messagingtest.NewFakeClock(instant)),
+		WithDLQChannel(dlq),
+		WithErrorHandler(messaging.SilentErrorHandler),
+	)...)
+
+	_, _ = c.Subscribe(func(_ context.Context, _ messaging.Message[int]) error {
+		t.Error("handler must not be called")
+		return nil
+	})
+
+	// Still live on the system clock, expired on the channel's clock.
+	msg := messaging.NewMessage(1, nil)
+	msg.Headers.ExpirationTime = instant.Add(-time.Second)
+
+	_ = c.Send(context.Background(), msg)
+
+	letters := dlq.WaitFor(t, 1)
+	if !letters[0].Payload.FailedAt.Equal(instant) || !errors.Is(letters[0].Payload.LastError, messaging.ErrExpired) {
+		t.Fatalf("expected an expired dead letter stamped %v, got %+v", instant, letters[0].Payload)
+	}
+}
+
+func TestChannel_UndecodableEntryIsAckedAndReported(t *testing.T) {
+	t.Parallel()
+
+	mr := miniredis.RunT(t)
+	recorder := &errorRecorder{}
+	c := startChannel[int](t, "jobs", testOptions(mr, WithErrorHandler(recorder.handle))...)
+
+	_, _ = c.Subscribe(func(_ context.Context, _ messaging.Message[int]) error {
+		t.Error("handler must not be called")
+		return nil
+	})
+
+	_, err := mr.XAdd("jobs", "*", []string{fieldMessage, "not json"})
+	if err != nil {
+		t.Fatalf("XAdd: %v", err)
+	}
+
+	waitFor(t, func() bool { return recorder.has(ErrDecode) })
+
+	stats := c.Stats()
+	if stats.Delivered != 1 || stats.Failed != 1 || stats.LastErrorTime.IsZero() {
+		t.Fatalf("expected the entry counted as a failed delivery, got %+v", stats)
+	}
+
+	waitFor(t, func() bool {
+		pending, _ := c.stream.client.XPending(context.Background(), "jobs", "jobs").Result()
+		return pending != nil && pending.Count == 0
+	})
+}
+
+func TestChannel_HandlerPanicIsReported(t *testing.T) {
+	t.Parallel()
+
+	mr := miniredis.RunT(t)
+	recorder := &errorRecorder{}
+	c := startChannel[int](t, "jobs", testOptions(mr, WithErrorHandler(recorder.handle))...)
+
+	_, _ = c.Subscribe(func(_ context.Context, _ messaging.Message[int]) error {
+		panic("kaboom")
+	})
+
+	_ = c.Send(context.Background(), messaging.NewMessage(1, nil))
+
+	waitFor(t, func() bool { return recorder.has(messaging.ErrHandlerPanic) })
+}
+
+func TestChannel_ConsumerGroups(t *testing.T) {
+	t.Parallel()
+
+	t.Run("consumers of one group share the entries", func(t *testing.T) {
+		t.Parallel()
+
+		mr := miniredis.RunT(t)
+
+		var total atomic.Int32
+
+		seen := sync.Map{}
+		handler := func(_ context.Context, msg messaging.Message[int]) error {
+			_, dup := seen.LoadOrStore(msg.Payload, struct{}{})
+			if dup {
+				t.Errorf("payload %d delivered twice", msg.Payload)
+			}
+
+			total.Add(1)
+
+			return nil
+		}
+
+		first := startChannel[int](t, "jobs", testOptions(mr, WithConsumer("w1"), WithBatchSize(1))...)
+		second := startChannel[int](t, "jobs", testOptions(mr, WithConsumer("w2"), WithBatchSize(1))...)
+
+		_, _ = first.Subscribe(handler)
+		_, _ = second.Subscribe(handler)
+
+		for i := range 20 {
+			_ = first.Send(context.Background(), messaging.NewMessage(i, nil))
+		}
+
+		waitFor(t, func() bool { return total.Load() == 20 })
+	})
+
+	t.Run("separate groups each see every entry", func(t *testing.T) {
+		t.Parallel()
+
+		mr := miniredis.RunT(t)
+
+		var billing, audit atomic.Int32
+
+		first := startChannel[int](t, "jobs", testOptions(mr, WithGroup("billing"))...)
+		second := startChannel[int](t, "jobs", testOptions(mr, WithGroup("audit"))...)
+
+		_, _ = first.Subscribe(func(_ context.Context, _ messaging.Message[int]) error {
+			billing.Add(1)
+			return nil
+		})
+		_, _ = second.Subscribe(func(_ context.Context, _ messaging.Message[int]) error {
+			audit.Add(1)
+			return nil
+		})
+
+		for i := range 5 {
+			_ = first.Send(context.Background(), messaging.NewMessage(i, nil))
+		}
+
+		waitFor(t, func() bool { return billing.Load() == 5 && audit.Load() == 5 })
+	})
+}
+
+func TestChannel_Send(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil ctx rejected", func(t *testing.T) {
+		t.Parallel()
+
+		mr := miniredis.RunT(t)
+		c := NewChannel[int]("jobs", testOptions(mr)...)
+
+		err := c.Send(nil, messaging.NewMessage(1, nil)) //nolint:staticcheck
+		if !errors.Is(err, messaging.ErrContextNil) || !errors.Is(err, messaging.ErrSendFailed) {
+			t.Fatalf("expected ErrSend(ErrContextNil), got %v", err)
+		}
+	})
+
+	t.Run("redis failure wrapped", func(t *testing.T) {
+		t.Parallel()
+
+		mr := miniredis.RunT(t)
+		c := NewChannel[int]("jobs", testOptions(mr)...)
+
+		mr.SetError("server down")
+
+		err := c.Send(context.Background(), messaging.NewMessage(1, nil))
+		if !errors.Is(err, ErrCommand) || !errors.Is(err, messaging.ErrSendFailed) {
+			t.Fatalf("expected ErrSend(ErrRedis(ErrCommand)), got %v", err)
+		}
+	})
+
+	t.Run("stream trimmed with max len", func(t *testing.T) {
+		t.Parallel()
+
+		mr := miniredis.RunT(t)
+		c, _ := NewChannel[int]("jobs", testOptions(mr, WithMaxLen(3))...).(*channel[int])
+
+		for i := range 10 {
+			_ = c.Send(context.Background(), messaging.NewMessage(i, nil))
+		}
+
+		length, _ := c.stream.client.XLen(context.Background(), "jobs").Result()
+		if length > 3 {
+			t.Fatalf("expected at most 3 entries, got %d", length)
+		}
+	})
+
+	t.Run("closed after stop", func(t *testing.T) {
+		t.Parallel()
+
+		mr := miniredis.RunT(t)
+		c, _ := NewChannel[int]("jobs", testOptions(mr)...).(*channel[int])
+
+		_ = c.Stop(context.Background())
+
+		err := c.Send(context.Background(), messaging.NewMessage(1, nil))
+		if !errors.Is(err, messaging.ErrClosed) {
+			t.Fatalf("expected ErrClosed, got %v", err)
+		}
+	})
+}
+
+func TestChannel_Subscribe(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil handler rejected", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewChannel[int]("jobs")
+
+		_, err := c.Subscribe(nil)
+		if !errors.Is(err, messaging.ErrHandlerNil) {
+			t.Fatalf("expected ErrHandlerNil, got %v", err)
+		}
+	})
+
+	t.Run("cancel unregisters", func(t *testing.T) {
+		t.Parallel()
+
+		c, _ := NewChannel[int]("jobs").(*channel[int])
+
+		cancel, _ := c.Subscribe(func(_ context.Context, _ messaging.Message[int]) error { return nil })
+		cancel()
+		cancel()
+
+		if len(c.snapshot()) != 0 {
+			t.Fatal("expected no handlers after cancel")
+		}
+	})
+
+	t.Run("closed after stop", func(t *testing.T) {
+		t.Parallel()
+
+		c, _ := NewChannel[int]("jobs").(*channel[int])
+		_ = c.Stop(context.Background())
+
+		_, err := c.Subscribe(func(_ context.Context, _ messaging.Message[int]) error { return nil })
+		if !errors.Is(err, messaging.ErrClosed) {
+			t.Fatalf("expected ErrClosed, got %v", err)
+		}
+	})
+}
+
+func TestChannel_Lifecycle(t *testing.T) {
+	t.Parallel()
+
+	t.Run("start stop and done", func(t *testing.T) {
+		t.Parallel()
+
+		mr := miniredis.RunT(t)
+		c, _ := NewChannel[int]("jobs", testOptions(mr)...).(*channel[int])
+
+		err := c.Start(context.Background())
+		if err != nil {
+			t.Fatalf("Start: %v", err)
+		}
+
+		err = c.Start(context.Background())
+		if err != nil {
+			t.Fatalf("second Start: %v", err)
+		}
+
+		err = c.Stop(context.Background())
+		if err != nil {
+			t.Fatalf("Stop: %v", err)
+		}
+
+		select {
+		case <-c.Done():
+		default:
+			t.Fatal("expected Done to be closed")
+		}
+
+		err = c.Stop(context.Background())
+		if err != nil {
+			t.Fatalf("second Stop: %v", err)
+		}
+	})
+
+	t.Run("stop before start closes done", func(t *testing.T) {
+		t.Parallel()
+
+		c, _ := NewChannel[int]("jobs").(*channel[int])
+
+		_ = c.Stop(context.Background())
+
+		select {
+		case <-c.Done():
+		default:
+			t.Fatal("expected Done to be closed")
+		}
+
+		err := c.Start(context.Background())
+		if err != nil {
+			t.Fatalf("Start after Stop: %v", err)
+		}
+	})
+
+	t.Run("group creation failure fails start", func(t *testing.T) {
+		t.Parallel()
+
+		mr := miniredis.RunT(t)
+		c, _ := NewChannel[int]("jobs", testOptions(mr)...).(*channel[int])
+		defer func() { _ = c.Stop(context.Background()) }()
+
+		mr.SetError("server down")
+
+		err := c.Start(context.Background())
+		if !errors.Is(err, lifecycle.ErrStartFailed) || !errors.Is(err, ErrCommand) {
+			t.Fatalf("expected ErrStart(ErrRedis(ErrCommand)), got %v", err)
+		}
+
+		mr.SetError("")
+
+		err = c.Start(context.Background())
+		if err != nil {
+			t.Fatalf("retried Start: %v", err)
+		}
+	})
+
+	t.Run("existing group is reused", func(t *testing.T) {
+		t.Parallel()
+
+		mr := miniredis.RunT(t)
+		startChannel[int](t, "jobs", testOptions(mr)...)
+
+		second, _ := NewChannel[int]("jobs", testOptions(mr)...).(*channel[int])
+		defer func() { _ = second.Stop(context.Background()) }()
+
+		err := second.Start(context.Background())
+		if err != nil {
+			t.Fatalf("Start: %v", err)
+		}
+	})
+
+	t.Run("shared client left open", func(t *testing.T) {
+		t.Parallel()
+
+		mr := miniredis.RunT(t)
+		owner, _ := NewChannel[int]("jobs", testOptions(mr)...).(*channel[int])
+		defer func() { _ = owner.Stop(context.Background()) }()
+
+		c, _ := NewChannel[int]("jobs", WithClient(owner.stream.client)).(*channel[int])
+		_ = c.Start(context.Background())
+		_ = c.Stop(context.Background())
+
+		err := owner.stream.client.Ping(context.Background()).Err()
+		if err != nil {
+			t.Fatalf("expected shared client to stay open, got %v", err)
+		}
+	})
+}
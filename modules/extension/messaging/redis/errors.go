@@ -0,0 +1,63 @@
+package redis
+
+import (
+	"errors"
+	"fmt"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+	cerrs "github.com/guidomantilla/yarumo/core/common/errs"
+)
+
+// RedisType is the error domain identifier for Redis Streams channel
+// operations.
+const RedisType = "messaging-redis"
+
+var (
+	_ error = (*Error)(nil)
+)
+
+// Sentinel errors for Redis Streams channel operations.
+var (
+	// ErrRedisFailed is the top-level sentinel embedded in every
+	// domain Error returned by ErrRedis.
+	ErrRedisFailed = errors.New("redis stream channel failed")
+	// ErrCommand indicates that a Redis command (XADD, XREADGROUP,
+	// XACK, XPENDING, XCLAIM, XGROUP) failed.
+	ErrCommand = errors.New("redis command failed")
+	// ErrEncode indicates that a message could not be encoded with the
+	// configured codec before XADD.
+	ErrEncode = errors.New("message encoding failed")
+	// ErrDecode indicates that a stream entry could not be decoded
+	// into a message. The entry is acknowledged so it does not block
+	// the group.
+	ErrDecode = errors.New("message decoding failed")
+	// ErrMaxDeliveries indicates that a pending entry was delivered
+	// more times than WithMaxDeliveries allows. The entry is
+	// acknowledged and dead-lettered.
+	ErrMaxDeliveries = errors.New("max deliveries exceeded")
+)
+
+// Error is the domain error type for Redis Streams channel operations.
+type Error struct {
+	cerrs.TypedError
+}
+
+// Error returns the formatted error string including the type
+// classification.
+func (e *Error) Error() string {
+	cassert.NotNil(e, "error is nil")
+	cassert.NotNil(e.Err, "internal error is nil")
+
+	return fmt.Sprintf("redis %s error: %s", e.Type, e.Err)
+}
+
+// ErrRedis wraps the given causes into a domain Error joined with
+// ErrRedisFailed.
+func ErrRedis(causes ...error) error {
+	return &Error{
+		TypedError: cerrs.TypedError{
+			Type: RedisType,
+			Err:  errors.Join(append(causes, ErrRedisFailed)...),
+		},
+	}
+}
@@ -0,0 +1,41 @@
+package redis
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestErrRedis(t *testing.T) {
+	t.Parallel()
+
+	t.Run("wraps causes with ErrRedisFailed and RedisType tag", func(t *testing.T) {
+		t.Parallel()
+
+		cause := errors.New("connection refused")
+		err := ErrRedis(ErrCommand, cause)
+
+		if !errors.Is(err, ErrRedisFailed) {
+			t.Fatalf("expected wrap of ErrRedisFailed, got %v", err)
+		}
+
+		if !errors.Is(err, ErrCommand) || !errors.Is(err, cause) {
+			t.Fatalf("expected wrap of causes, got %v", err)
+		}
+
+		var e *Error
+
+		ok := errors.As(err, &e)
+		if !ok {
+			t.Fatalf("expected *Error, got %T", err)
+		}
+
+		if e.Type != RedisType {
+			t.Fatalf("Type = %q, want %q", e.Type, RedisType)
+		}
+
+		if !strings.HasPrefix(err.Error(), "redis messaging-redis error: ") {
+			t.Fatalf("unexpected message %q", err.Error())
+		}
+	})
+}
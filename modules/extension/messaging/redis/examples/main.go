@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+
+	"github.com/guidomantilla/yarumo/core/common/lifecycle"
+	"github.com/guidomantilla/yarumo/extension/messaging/redis"
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+type orderCreated struct {
+	ID     string  `json:"id"`
+	Amount float64 `json:"amount"`
+}
+
+func main() {
+	server, err := miniredis.Run()
+	if err != nil {
+		fmt.Println("failed to start miniredis:", err)
+		return
+	}
+	defer server.Close()
+
+	ctx := context.Background()
+
+	orders := redis.NewChannel[orderCreated]("orders",
+		redis.WithAddr(server.Addr()),
+		redis.WithGroup("billing"),
+		redis.WithBlockTimeout(100*time.Millisecond),
+	)
+
+	delivered := make(chan messaging.Message[orderCreated], 1)
+
+	_, err = orders.Subscribe(func(_ context.Context, msg messaging.Message[orderCreated]) error {
+		delivered <- msg
+		return nil
+	})
+	if err != nil {
+		fmt.Println("subscribe failed:", err)
+		return
+	}
+
+	errCh := make(chan error, 1)
+	closeFn, err := lifecycle.Build(ctx, orders.(lifecycle.Component), errCh)
+	if err != nil {
+		fmt.Println("lifecycle.Build:", err)
+		return
+	}
+	defer closeFn(ctx, 5*time.Second)
+
+	err = orders.Send(ctx, messaging.NewMessage(orderCreated{ID: "o-1", Amount: 99.5}, nil))
+	if err != nil {
+		fmt.Println("send failed:", err)
+		return
+	}
+
+	select {
+	case msg := <-delivered:
+		fmt.Printf("redis: consumed %+v (delivery %d)\n", msg.Payload, msg.Headers.DeliveryCount)
+	case <-time.After(2 * time.Second):
+		fmt.Println("redis: message not consumed in time")
+	}
+
+	// A pollable channel on the same stream with its own group sees
+	// every entry too.
+	audit := redis.NewPollableChannel[orderCreated]("orders",
+		redis.WithAddr(server.Addr()),
+		redis.WithGroup("audit"),
+		redis.WithBlockTimeout(100*time.Millisecond),
+	)
+	defer audit.Close()
+
+	receiveCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	msg, err := audit.Receive(receiveCtx)
+	if err != nil {
+		fmt.Println("receive failed:", err)
+		return
+	}
+
+	fmt.Printf("redis: audited %+v\n", msg.Payload)
+}
@@ -0,0 +1,159 @@
+package redis
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+// lease is the Lease of a pending stream entry. mu guards deadline and
+// settled; settled flips once Ack or Nack succeeded.
+type lease[T any] struct {
+	stream *stream[T]
+	id     string
+	msg    messaging.Message[T]
+
+	mu       sync.Mutex
+	deadline time.Time
+	settled  bool
+}
+
+// Message returns the leased message.
+func (l *lease[T]) Message() messaging.Message[T] {
+	cassert.NotNil(l, "lease is nil")
+
+	return l.msg
+}
+
+// Deadline returns the instant the entry becomes claimable by other
+// consumers.
+func (l *lease[T]) Deadline() time.Time {
+	cassert.NotNil(l, "lease is nil")
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.deadline
+}
+
+// Ack acknowledges the entry (XACK). It returns
+// messaging.ErrLease(messaging.ErrLeaseLost) when the lease was
+// already settled or its deadline has passed — the entry may then have
+// been claimed by another consumer — and messaging.ErrLease wrapping
+// ErrRedis on an XACK failure, leaving the lease held.
+func (l *lease[T]) Ack(ctx context.Context) error {
+	cassert.NotNil(l, "lease is nil")
+
+	if ctx == nil {
+		return messaging.ErrLease(messaging.ErrContextNil)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.held() {
+		return messaging.ErrLease(messaging.ErrLeaseLost)
+	}
+
+	err := l.stream.client.XAck(ctx, l.stream.key, l.stream.group, l.id).Err()
+	if err != nil {
+		return messaging.ErrLease(ErrRedis(ErrCommand, err))
+	}
+
+	l.settled = true
+
+	return nil
+}
+
+// Nack settles the lease and leaves the entry pending with an idle
+// time that makes it claimable after requeueDelay, capped at the
+// visibility timeout, and counts a failure in the channel stats. It
+// fails like Ack.
+func (l *lease[T]) Nack(ctx context.Context, requeueDelay time.Duration) error {
+	cassert.NotNil(l, "lease is nil")
+
+	if ctx == nil {
+		return messaging.ErrLease(messaging.ErrContextNil)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.held() {
+		return messaging.ErrLease(messaging.ErrLeaseLost)
+	}
+
+	err := l.rearm(ctx, max(requeueDelay, 0))
+	if err != nil {
+		return err
+	}
+
+	l.settled = true
+	l.stream.stats.RecordFailure()
+
+	return nil
+}
+
+// Extend keeps the entry unclaimable for extension from now, capped at
+// the visibility timeout. It fails like Ack and returns
+// messaging.ErrLease(messaging.ErrLeaseExtension) when extension is
+// not positive.
+func (l *lease[T]) Extend(ctx context.Context, extension time.Duration) error {
+	cassert.NotNil(l, "lease is nil")
+
+	if ctx == nil {
+		return messaging.ErrLease(messaging.ErrContextNil)
+	}
+
+	if extension <= 0 {
+		return messaging.ErrLease(messaging.ErrLeaseExtension)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.held() {
+		return messaging.ErrLease(messaging.ErrLeaseLost)
+	}
+
+	extension = min(extension, l.stream.visibilityTimeout)
+
+	err := l.rearm(ctx, extension)
+	if err != nil {
+		return err
+	}
+
+	l.deadline = l.stream.clock.Now().Add(extension)
+
+	return nil
+}
+
+// rearm sets the entry's idle time so it becomes claimable after
+// remaining (XCLAIM IDLE), pinning the delivery counter to the
+// deliveries so far (RETRYCOUNT) so that only real deliveries count
+// toward WithMaxDeliveries. An entry no longer pending returns
+// ErrLeaseLost. Callers hold mu.
+func (l *lease[T]) rearm(ctx context.Context, remaining time.Duration) error {
+	idle := max(l.stream.visibilityTimeout-remaining, 0)
+
+	ids, err := l.stream.client.Do(ctx, "XCLAIM", l.stream.key, l.stream.group, l.stream.consumer,
+		0, l.id, "IDLE", idle.Milliseconds(), "RETRYCOUNT", l.msg.Headers.DeliveryCount, "JUSTID").StringSlice()
+	if err != nil {
+		return messaging.ErrLease(ErrRedis(ErrCommand, err))
+	}
+
+	if len(ids) == 0 {
+		return messaging.ErrLease(messaging.ErrLeaseLost)
+	}
+
+	return nil
+}
+
+// held reports whether the lease is unsettled and within its deadline.
+// Callers hold mu.
+func (l *lease[T]) held() bool {
+	return !l.settled && l.stream.clock.Now().Before(l.deadline)
+}
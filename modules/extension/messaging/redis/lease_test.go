@@ -0,0 +1,202 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+// leased sends 42 to a fresh pollable channel and leases it back.
+func leased(t *testing.T, mr *miniredis.Miniredis, opts ...Option) (*pollable[int], messaging.Lease[int]) {
+	t.Helper()
+
+	c, _ := NewPollableChannel[int]("jobs", testOptions(mr, opts...)...).(*pollable[int])
+	t.Cleanup(func() { _ = c.Close() })
+
+	ctx, cancel := context.WithTimeout(context.Background(), testWait)
+	defer cancel()
+
+	_ = c.Send(ctx, messaging.NewMessage(42, nil))
+
+	l, err := c.ReceiveWithLease(ctx)
+	if err != nil {
+		t.Fatalf("ReceiveWithLease: %v", err)
+	}
+
+	return c, l
+}
+
+func TestLease_Ack(t *testing.T) {
+	t.Parallel()
+
+	mr := miniredis.RunT(t)
+	c, l := leased(t, mr)
+
+	ctx := context.Background()
+
+	if l.Message().Payload != 42 || l.Message().Headers.DeliveryCount != 1 {
+		t.Fatalf("unexpected leased message %+v", l.Message())
+	}
+
+	pending, _ := c.stream.client.XPending(ctx, "jobs", "jobs").Result()
+	if pending.Count != 1 {
+		t.Fatalf("expected the leased entry pending, got %d", pending.Count)
+	}
+
+	err := l.Ack(ctx)
+	if err != nil {
+		t.Fatalf("Ack: %v", err)
+	}
+
+	pending, _ = c.stream.client.XPending(ctx, "jobs", "jobs").Result()
+	if pending.Count != 0 {
+		t.Fatalf("expected no pending entries after Ack, got %d", pending.Count)
+	}
+
+	err = l.Ack(ctx)
+	if !errors.Is(err, messaging.ErrLeaseLost) || !errors.Is(err, messaging.ErrLeaseFailed) {
+		t.Fatalf("second Ack: expected ErrLeaseLost, got %v", err)
+	}
+}
+
+func TestLease_Nack(t *testing.T) {
+	t.Parallel()
+
+	mr := miniredis.RunT(t)
+	c, l := leased(t, mr, WithVisibilityTimeout(time.Second))
+
+	ctx, cancel := context.WithTimeout(context.Background(), testWait)
+	defer cancel()
+
+	err := l.Nack(ctx, 0)
+	if err != nil {
+		t.Fatalf("Nack: %v", err)
+	}
+
+	again, err := c.ReceiveWithLease(ctx)
+	if err != nil {
+		t.Fatalf("ReceiveWithLease: %v", err)
+	}
+
+	if again.Message().Payload != 42 || again.Message().Headers.DeliveryCount != 2 {
+		t.Fatalf("expected the nacked entry with DeliveryCount 2, got %+v", again.Message())
+	}
+
+	stats := c.Stats()
+	if stats.Delivered != 2 || stats.Failed != 1 {
+		t.Fatalf("expected two deliveries and one failure, got %+v", stats)
+	}
+
+	err = l.Extend(ctx, time.Second)
+	if !errors.Is(err, messaging.ErrLeaseLost) {
+		t.Fatalf("Extend after Nack: expected ErrLeaseLost, got %v", err)
+	}
+}
+
+func TestLease_Extend(t *testing.T) {
+	t.Parallel()
+
+	mr := miniredis.RunT(t)
+	c, l := leased(t, mr, WithVisibilityTimeout(300*time.Millisecond))
+
+	ctx := context.Background()
+
+	err := l.Extend(ctx, -time.Second)
+	if !errors.Is(err, messaging.ErrLeaseExtension) {
+		t.Fatalf("expected ErrLeaseExtension, got %v", err)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+
+	err = l.Extend(ctx, time.Minute)
+	if err != nil {
+		t.Fatalf("Extend: %v", err)
+	}
+
+	if time.Until(l.Deadline()) > 300*time.Millisecond {
+		t.Fatalf("expected the extension capped at the visibility timeout, deadline %v", l.Deadline())
+	}
+
+	// Past the original deadline, the extended lease still holds the
+	// entry.
+	time.Sleep(150 * time.Millisecond)
+
+	waitCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+
+	_, err = c.ReceiveWithLease(waitCtx)
+	if !errors.Is(err, messaging.ErrTimeout) {
+		t.Fatalf("expected the extended entry to stay unclaimable, got %v", err)
+	}
+
+	err = l.Ack(ctx)
+	if err != nil {
+		t.Fatalf("Ack: %v", err)
+	}
+}
+
+func TestLease_Expiry(t *testing.T) {
+	t.Parallel()
+
+	mr := miniredis.RunT(t)
+	recorder := &errorRecorder{}
+
+	dlq := messaging.NewPipelineChannel[messaging.DeadLetter[int]]()
+	letters := make(chan messaging.DeadLetter[int], 1)
+
+	_, _ = dlq.Subscribe(func(_ context.Context, msg messaging.Message[messaging.DeadLetter[int]]) error {
+		letters <- msg.Payload
+
+		return nil
+	})
+
+	c, l := leased(t, mr, WithMaxDeliveries(2), WithErrorHandler(recorder.handle), WithDLQChannel(dlq))
+
+	ctx, cancel := context.WithTimeout(context.Background(), testWait)
+	defer cancel()
+
+	second, err := c.ReceiveWithLease(ctx)
+	if err != nil {
+		t.Fatalf("ReceiveWithLease: %v", err)
+	}
+
+	if second.Message().Headers.DeliveryCount != 2 {
+		t.Fatalf("expected the expired lease claimed back with DeliveryCount 2, got %+v", second.Message())
+	}
+
+	err = l.Ack(ctx)
+	if !errors.Is(err, messaging.ErrLeaseLost) {
+		t.Fatalf("Ack of expired lease: expected ErrLeaseLost, got %v", err)
+	}
+
+	waitCtx, waitCancel := context.WithTimeout(ctx, 4*testVisibility)
+	defer waitCancel()
+
+	_, err = c.ReceiveWithLease(waitCtx)
+	if !errors.Is(err, messaging.ErrTimeout) {
+		t.Fatalf("expected the entry dead-lettered past max deliveries, got %v", err)
+	}
+
+	if !recorder.has(ErrMaxDeliveries) || len(letters) != 1 {
+		t.Fatalf("expected one dead letter with ErrMaxDeliveries, got %d", len(letters))
+	}
+}
+
+func TestLease_NilContext(t *testing.T) {
+	t.Parallel()
+
+	mr := miniredis.RunT(t)
+	_, l := leased(t, mr)
+
+	//nolint:staticcheck // exercising the nil guard
+	for _, err := range []error{l.Ack(nil), l.Nack(nil, 0), l.Extend(nil, time.Second)} {
+		if !errors.Is(err, messaging.ErrContextNil) {
+			t.Fatalf("expected ErrContextNil, got %v", err)
+		}
+	}
+}
@@ -0,0 +1,284 @@
+package redis
+
+import (
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/guidomantilla/yarumo/messaging"
+	"github.com/guidomantilla/yarumo/messaging/codec"
+)
+
+// Defaults applied by NewOptions.
+const (
+	defaultBatchSize         = 10
+	defaultBlockTimeout      = time.Second
+	defaultVisibilityTimeout = 30 * time.Second
+	defaultDrainTimeout      = 5 * time.Second
+)
+
+// Option is a functional option for configuring Options. Option is
+// non-generic; the T-typed options (WithCodec, WithDLQChannel,
+// WithInterceptors) store
+// their value type-erased and the constructor checks it against the
+// channel's T.
+type Option func(opts *Options)
+
+// Options holds the configuration for a Redis Streams channel.
+type Options struct {
+	client   goredis.UniversalClient
+	addr     string
+	password string
+	db       int
+
+	stream   string
+	group    string
+	consumer string
+	codec    any
+	maxLen   int64
+
+	batchSize         int64
+	blockTimeout      time.Duration
+	visibilityTimeout time.Duration
+	maxDeliveries     int
+	drainTimeout      time.Duration
+
+	errorHandler messaging.ErrorHandler
+	dlq          any
+	interceptors []any
+	clock        messaging.Clock
+}
+
+// NewOptions creates a new Options with sensible defaults and applies
+// the given options. Defaults: a client of its own on localhost:6379
+// DB 0, stream and group named after the channel, consumer
+// "<host>-<pid>", JSON codec, no MAXLEN trimming, batchSize 10,
+// blockTimeout 1s, visibilityTimeout 30s, unlimited deliveries,
+// drainTimeout 5s, ErrorHandler logs via common/log, no DLQ, no
+// interceptors, messaging.SystemClock.
+func NewOptions(opts ...Option) *Options {
+	options := &Options{
+		batchSize:         defaultBatchSize,
+		blockTimeout:      defaultBlockTimeout,
+		visibilityTimeout: defaultVisibilityTimeout,
+		drainTimeout:      defaultDrainTimeout,
+		errorHandler:      messaging.DefaultErrorHandler,
+		clock:             messaging.SystemClock(),
+	}
+
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	return options
+}
+
+// WithClient makes the channel use client instead of building its
+// own. A shared client is not closed by Stop or Close; WithAddr,
+// WithPassword and WithDB are ignored. Nil values are ignored.
+func WithClient(client goredis.UniversalClient) Option {
+	return func(opts *Options) {
+		if client != nil {
+			opts.client = client
+		}
+	}
+}
+
+// WithAddr sets the redis server address of the channel's own client.
+// Empty values are ignored; go-redis then defaults Addr to
+// "localhost:6379" at client init.
+func WithAddr(addr string) Option {
+	return func(opts *Options) {
+		if addr != "" {
+			opts.addr = addr
+		}
+	}
+}
+
+// WithPassword sets the redis auth password of the channel's own
+// client. Empty values are ignored.
+func WithPassword(password string) Option {
+	return func(opts *Options) {
+		if password != "" {
+			opts.password = password
+		}
+	}
+}
+
+// WithDB sets the redis logical DB index of the channel's own client.
+// Negative values are ignored.
+func WithDB(db int) Option {
+	return func(opts *Options) {
+		if db >= 0 {
+			opts.db = db
+		}
+	}
+}
+
+// WithStream sets the stream key. Defaults to the channel name. Empty
+// values are ignored.
+func WithStream(key string) Option {
+	return func(opts *Options) {
+		if key != "" {
+			opts.stream = key
+		}
+	}
+}
+
+// WithGroup sets the consumer group. Defaults to the channel name.
+// Channels sharing a group compete for entries; separate groups each
+// receive every entry. Empty values are ignored.
+func WithGroup(group string) Option {
+	return func(opts *Options) {
+		if group != "" {
+			opts.group = group
+		}
+	}
+}
+
+// WithConsumer sets the consumer name inside the group. Defaults to
+// "<host>-<pid>"; give each channel instance of a process its own
+// name when several of them share a group. Empty values are ignored.
+func WithConsumer(name string) Option {
+	return func(opts *Options) {
+		if name != "" {
+			opts.consumer = name
+		}
+	}
+}
+
+// WithCodec sets the codec that maps a messaging.Message[T] (payload
+// and headers) to the "message" field of a stream entry. Defaults to
+// codec.NewJSONCodec[T]. Type parameter T must match the channel's T;
+// mismatches are caught with cassert at construction. Nil values are
+// ignored.
+func WithCodec[T any](c codec.Codec[T]) Option {
+	return func(opts *Options) {
+		if c != nil {
+			opts.codec = c
+		}
+	}
+}
+
+// WithMaxLen caps the stream length with approximate trimming
+// (XADD MAXLEN ~ n). Entries trimmed before being read are lost.
+// Defaults to no trimming. Non-positive values are ignored.
+func WithMaxLen(n int64) Option {
+	return func(opts *Options) {
+		if n > 0 {
+			opts.maxLen = n
+		}
+	}
+}
+
+// WithBatchSize sets how many entries the consumer loop reads or
+// claims per round trip. Non-positive values are ignored.
+func WithBatchSize(n int) Option {
+	return func(opts *Options) {
+		if n > 0 {
+			opts.batchSize = int64(n)
+		}
+	}
+}
+
+// WithBlockTimeout sets how long one XREADGROUP blocks waiting for new
+// entries. It also bounds how quickly the consumer loop notices Stop
+// and how often it looks for stale pending entries. Non-positive
+// values are ignored.
+func WithBlockTimeout(timeout time.Duration) Option {
+	return func(opts *Options) {
+		if timeout > 0 {
+			opts.blockTimeout = timeout
+		}
+	}
+}
+
+// WithVisibilityTimeout sets how long an entry may stay pending
+// (delivered but not acknowledged) before another consumer claims it
+// back. Keep it above the slowest handler. Non-positive values are
+// ignored.
+func WithVisibilityTimeout(timeout time.Duration) Option {
+	return func(opts *Options) {
+		if timeout > 0 {
+			opts.visibilityTimeout = timeout
+		}
+	}
+}
+
+// WithMaxDeliveries sets how many times an entry may be delivered
+// before it is acknowledged and dead-lettered with ErrMaxDeliveries.
+// Defaults to unlimited. Non-positive values are ignored.
+func WithMaxDeliveries(n int) Option {
+	return func(opts *Options) {
+		if n > 0 {
+			opts.maxDeliveries = n
+		}
+	}
+}
+
+// WithDrainTimeout sets the maximum time Stop waits for the consumer
+// loop to finish its in-flight batch. Non-positive values are ignored.
+func WithDrainTimeout(timeout time.Duration) Option {
+	return func(opts *Options) {
+		if timeout > 0 {
+			opts.drainTimeout = timeout
+		}
+	}
+}
+
+// WithErrorHandler installs the observability hook fired for handler
+// failures, undecodable entries, dropped entries and Redis errors of
+// the consumer side. Defaults to messaging.DefaultErrorHandler. Nil
+// values are ignored.
+func WithErrorHandler(handler messaging.ErrorHandler) Option {
+	return func(opts *Options) {
+		if handler != nil {
+			opts.errorHandler = handler
+		}
+	}
+}
+
+// WithDLQChannel installs the channel that receives a
+// messaging.DeadLetter[T] for every entry given up on: past
+// WithMaxDeliveries (LastError ErrMaxDeliveries) or past its
+// Headers.ExpirationTime (LastError messaging.ErrExpired). Publishing
+// is best-effort. Type parameter T must match the channel's T;
+// mismatches are caught with cassert at construction. Nil values are
+// ignored.
+func WithDLQChannel[T any](dlq messaging.Channel[messaging.DeadLetter[T]]) Option {
+	return func(opts *Options) {
+		if dlq != nil {
+			opts.dlq = dlq
+		}
+	}
+}
+
+// WithInterceptors appends interceptors to the channel's interceptor
+// chain; repeated calls accumulate in order. Send runs PreSend and
+// PostSend as the in-memory channels do, and NewChannel runs PreHandle
+// and AfterHandle around each handler invocation, redeliveries
+// included; PollableChannel has no handlers, so it only runs the send
+// hooks. Type parameter T must match the channel's T; mismatches are
+// caught with cassert at construction. Nil interceptors are ignored.
+func WithInterceptors[T any](interceptors ...messaging.ChannelInterceptor[T]) Option {
+	return func(opts *Options) {
+		for _, interceptor := range interceptors {
+			if interceptor != nil {
+				opts.interceptors = append(opts.interceptors, interceptor)
+			}
+		}
+	}
+}
+
+// WithClock sets the clock the channel reads to check
+// Headers.ExpirationTime, stamp DeadLetter.FailedAt and the stats
+// failure timestamps, and compute lease deadlines. Redis measures the
+// idle time of pending entries on its own clock. Nil values are
+// ignored (the system clock is preserved).
+func WithClock(clock messaging.Clock) Option {
+	return func(opts *Options) {
+		if clock != nil {
+			opts.clock = clock
+		}
+	}
+}
@@ -0,0 +1,325 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/guidomantilla/yarumo/messaging"
+	"github.com/guidomantilla/yarumo/messaging/codec"
+	"github.com/guidomantilla/yarumo/messaging/messagingtest"
+)
+
+func TestNewOptions(t *testing.T) {
+	t.Parallel()
+
+	opts := NewOptions()
+
+	if opts.client != nil {
+		t.Fatal("expected nil client")
+	}
+
+	if opts.addr != "" || opts.password != "" || opts.db != 0 {
+		t.Fatalf("expected zero connection settings, got %q %q %d", opts.addr, opts.password, opts.db)
+	}
+
+	if opts.stream != "" || opts.group != "" || opts.consumer != "" {
+		t.Fatalf("expected empty names, got %q %q %q", opts.stream, opts.group, opts.consumer)
+	}
+
+	if opts.codec != nil {
+		t.Fatal("expected nil codec")
+	}
+
+	if opts.maxLen != 0 {
+		t.Fatalf("expected maxLen 0, got %d", opts.maxLen)
+	}
+
+	if opts.batchSize != defaultBatchSize {
+		t.Fatalf("expected batchSize %d, got %d", defaultBatchSize, opts.batchSize)
+	}
+
+	if opts.blockTimeout != defaultBlockTimeout {
+		t.Fatalf("expected blockTimeout %v, got %v", defaultBlockTimeout, opts.blockTimeout)
+	}
+
+	if opts.visibilityTimeout != defaultVisibilityTimeout {
+		t.Fatalf("expected visibilityTimeout %v, got %v", defaultVisibilityTimeout, opts.visibilityTimeout)
+	}
+
+	if opts.maxDeliveries != 0 {
+		t.Fatalf("expected maxDeliveries 0, got %d", opts.maxDeliveries)
+	}
+
+	if opts.drainTimeout != defaultDrainTimeout {
+		t.Fatalf("expected drainTimeout %v, got %v", defaultDrainTimeout, opts.drainTimeout)
+	}
+
+	if opts.errorHandler == nil {
+		t.Fatal("expected default error handler")
+	}
+
+	if opts.dlq != nil {
+		t.Fatal("expected nil dlq")
+	}
+
+	if opts.clock == nil {
+		t.Fatal("expected the system clock")
+	}
+}
+
+func TestWithClock(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid value applied", func(t *testing.T) {
+		t.Parallel()
+
+		clock := messagingtest.NewFakeClock(time.Time{})
+
+		opts := NewOptions(WithClock(clock))
+		if opts.clock != clock {
+			t.Fatal("expected the custom clock")
+		}
+	})
+
+	t.Run("nil ignored", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithClock(nil))
+		if opts.clock == nil {
+			t.Fatal("expected the system clock to be preserved")
+		}
+	})
+}
+
+func TestWithClient(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid value applied", func(t *testing.T) {
+		t.Parallel()
+
+		client := goredis.NewClient(&goredis.Options{})
+		defer client.Close()
+
+		opts := NewOptions(WithClient(client))
+		if opts.client != client {
+			t.Fatal("expected client to be set")
+		}
+	})
+
+	t.Run("nil ignored", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithClient(nil))
+		if opts.client != nil {
+			t.Fatal("expected nil client")
+		}
+	})
+}
+
+func TestWithConnectionSettings(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid values applied", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithAddr("redis:6380"), WithPassword("secret"), WithDB(3))
+		if opts.addr != "redis:6380" || opts.password != "secret" || opts.db != 3 {
+			t.Fatalf("unexpected settings %q %q %d", opts.addr, opts.password, opts.db)
+		}
+	})
+
+	t.Run("invalid values ignored", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithAddr(""), WithPassword(""), WithDB(-1))
+		if opts.addr != "" || opts.password != "" || opts.db != 0 {
+			t.Fatalf("unexpected settings %q %q %d", opts.addr, opts.password, opts.db)
+		}
+	})
+}
+
+func TestWithNames(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid values applied", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithStream("events"), WithGroup("billing"), WithConsumer("worker-1"))
+		if opts.stream != "events" || opts.group != "billing" || opts.consumer != "worker-1" {
+			t.Fatalf("unexpected names %q %q %q", opts.stream, opts.group, opts.consumer)
+		}
+	})
+
+	t.Run("empty values ignored", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithStream(""), WithGroup(""), WithConsumer(""))
+		if opts.stream != "" || opts.group != "" || opts.consumer != "" {
+			t.Fatalf("unexpected names %q %q %q", opts.stream, opts.group, opts.consumer)
+		}
+	})
+}
+
+func TestWithCodec(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid value applied", func(t *testing.T) {
+		t.Parallel()
+
+		c := codec.NewJSONCodec[int]()
+
+		opts := NewOptions(WithCodec(c))
+		if opts.codec == nil {
+			t.Fatal("expected codec to be set")
+		}
+	})
+
+	t.Run("nil ignored", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithCodec[int](nil))
+		if opts.codec != nil {
+			t.Fatal("expected nil codec")
+		}
+	})
+}
+
+func TestWithLimits(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid values applied", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithMaxLen(1000), WithBatchSize(5), WithMaxDeliveries(3))
+		if opts.maxLen != 1000 || opts.batchSize != 5 || opts.maxDeliveries != 3 {
+			t.Fatalf("unexpected limits %d %d %d", opts.maxLen, opts.batchSize, opts.maxDeliveries)
+		}
+	})
+
+	t.Run("non-positive values ignored", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithMaxLen(0), WithBatchSize(-1), WithMaxDeliveries(0))
+		if opts.maxLen != 0 || opts.batchSize != defaultBatchSize || opts.maxDeliveries != 0 {
+			t.Fatalf("unexpected limits %d %d %d", opts.maxLen, opts.batchSize, opts.maxDeliveries)
+		}
+	})
+}
+
+func TestWithTimeouts(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid values applied", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(
+			WithBlockTimeout(10*time.Millisecond),
+			WithVisibilityTimeout(20*time.Millisecond),
+			WithDrainTimeout(30*time.Millisecond),
+		)
+
+		if opts.blockTimeout != 10*time.Millisecond {
+			t.Fatalf("expected blockTimeout 10ms, got %v", opts.blockTimeout)
+		}
+
+		if opts.visibilityTimeout != 20*time.Millisecond {
+			t.Fatalf("expected visibilityTimeout 20ms, got %v", opts.visibilityTimeout)
+		}
+
+		if opts.drainTimeout != 30*time.Millisecond {
+			t.Fatalf("expected drainTimeout 30ms, got %v", opts.drainTimeout)
+		}
+	})
+
+	t.Run("non-positive values ignored", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithBlockTimeout(0), WithVisibilityTimeout(-1), WithDrainTimeout(0))
+		if opts.blockTimeout != defaultBlockTimeout ||
+			opts.visibilityTimeout != defaultVisibilityTimeout ||
+			opts.drainTimeout != defaultDrainTimeout {
+			t.Fatalf("unexpected timeouts %v %v %v", opts.blockTimeout, opts.visibilityTimeout, opts.drainTimeout)
+		}
+	})
+}
+
+func TestWithErrorHandler(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid value applied", func(t *testing.T) {
+		t.Parallel()
+
+		called := false
+		opts := NewOptions(WithErrorHandler(func(_ context.Context, _ any, _ error) { called = true }))
+		opts.errorHandler(context.Background(), nil, nil)
+
+		if !called {
+			t.Fatal("expected custom error handler")
+		}
+	})
+
+	t.Run("nil ignored", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithErrorHandler(nil))
+		if opts.errorHandler == nil {
+			t.Fatal("expected default error handler")
+		}
+	})
+}
+
+func TestWithDLQChannel(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid value applied", func(t *testing.T) {
+		t.Parallel()
+
+		dlq := messaging.NewPipelineChannel[messaging.DeadLetter[int]]()
+
+		opts := NewOptions(WithDLQChannel(dlq))
+		if opts.dlq == nil {
+			t.Fatal("expected dlq to be set")
+		}
+	})
+
+	t.Run("nil ignored", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithDLQChannel[int](nil))
+		if opts.dlq != nil {
+			t.Fatal("expected nil dlq")
+		}
+	})
+}
+
+func TestWithInterceptors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("calls accumulate", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(
+			WithInterceptors[int](messaging.InterceptorFuncs[int]{}),
+			WithInterceptors[int](messaging.InterceptorFuncs[int]{}),
+		)
+		if len(opts.interceptors) != 2 {
+			t.Fatalf("expected 2 interceptors, got %d", len(opts.interceptors))
+		}
+
+		if len(extractInterceptors[int](opts.interceptors)) != 2 {
+			t.Fatal("expected 2 typed interceptors")
+		}
+	})
+
+	t.Run("nil ignored", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithInterceptors[int](nil))
+		if opts.interceptors != nil || extractInterceptors[int](opts.interceptors) != nil {
+			t.Fatal("expected no interceptors")
+		}
+	})
+}
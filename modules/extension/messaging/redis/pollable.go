@@ -0,0 +1,182 @@
+package redis
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+// pollable is the PollableChannel implementation. Each Receive fetches
+// one entry for its consumer of the group and acknowledges it before
+// returning; ReceiveWithLease leaves the acknowledgement to the lease.
+type pollable[T any] struct {
+	stream *stream[T]
+
+	closed    atomic.Bool
+	closeOnce sync.Once
+}
+
+// NewPollableChannel constructs a Redis Streams backed PollableChannel
+// named name. It accepts the same options as NewChannel; WithBatchSize
+// and WithDrainTimeout do not apply, and WithInterceptors only runs the
+// send hooks. name must be non-empty.
+func NewPollableChannel[T any](name string, opts ...Option) messaging.PollableChannel[T] {
+	cassert.NotEmpty(name, "name is empty")
+
+	options := NewOptions(opts...)
+
+	return &pollable[T]{
+		stream: newStream[T](name, options),
+	}
+}
+
+// Send runs the interceptor send hooks and appends msg to the stream.
+// It returns ErrSend(ErrClosed) after Close, ErrSend(ErrContextNil)
+// for a nil ctx, ErrSend(ErrIntercepted) on a PreSend veto and ErrSend
+// wrapping ErrRedis on encoding or XADD failures.
+func (c *pollable[T]) Send(ctx context.Context, msg messaging.Message[T]) error {
+	cassert.NotNil(c, "redis pollable channel is nil")
+
+	return messaging.InterceptSend(ctx, c.stream.interceptors, msg, c.send)
+}
+
+// send is Send without the interceptor chain.
+func (c *pollable[T]) send(ctx context.Context, msg messaging.Message[T]) error {
+	if ctx == nil {
+		return messaging.ErrSend(messaging.ErrContextNil)
+	}
+
+	if c.closed.Load() {
+		return messaging.ErrSend(messaging.ErrClosed)
+	}
+
+	return c.stream.send(ctx, msg)
+}
+
+// Receive blocks until an entry is available for this consumer, ctx
+// expires or the channel is closed. Stale pending entries of the group
+// are claimed back before new ones are read. The entry is acknowledged
+// before being returned; an XACK failure is reported through the
+// ErrorHandler and the message is returned anyway. On ctx cancellation
+// Receive returns ErrReceive(ErrTimeout, ctx.Err); after Close it
+// returns ErrReceive(ErrChannelClosed); a Redis failure is returned as
+// ErrReceive wrapping ErrRedis.
+func (c *pollable[T]) Receive(ctx context.Context) (messaging.Message[T], error) {
+	cassert.NotNil(c, "redis pollable channel is nil")
+
+	if ctx == nil {
+		return messaging.Message[T]{}, messaging.ErrReceive(messaging.ErrContextNil)
+	}
+
+	d, err := c.next(ctx)
+	if err != nil {
+		return messaging.Message[T]{}, err
+	}
+
+	c.stream.ack(context.WithoutCancel(ctx), d.id)
+	c.stream.stats.Begin()(nil)
+
+	return d.msg, nil
+}
+
+// ReceiveWithLease fetches an entry like Receive but leaves it pending
+// under a lease of WithVisibilityTimeout instead of acknowledging it.
+// Ack acknowledges it (XACK); Nack and Extend re-arm its idle time
+// (XCLAIM IDLE) so that any consumer of the group claims it back once
+// the requeue delay or the extension has elapsed. Both are capped at
+// the visibility timeout, the longest an entry can stay unclaimable.
+// A lease nobody settles is claimed back after the visibility timeout,
+// and WithMaxDeliveries dead-letters it on the claim past the limit.
+// It fails like Receive.
+func (c *pollable[T]) ReceiveWithLease(ctx context.Context) (messaging.Lease[T], error) {
+	cassert.NotNil(c, "redis pollable channel is nil")
+
+	if ctx == nil {
+		return nil, messaging.ErrReceive(messaging.ErrContextNil)
+	}
+
+	d, err := c.next(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c.stream.stats.Begin()(nil)
+
+	return &lease[T]{
+		stream:   c.stream,
+		id:       d.id,
+		msg:      d.msg,
+		deadline: c.stream.clock.Now().Add(c.stream.visibilityTimeout),
+	}, nil
+}
+
+// next fetches the next entry for this consumer, waiting until one is
+// available, ctx expires or the channel is closed.
+func (c *pollable[T]) next(ctx context.Context) (delivery[T], error) {
+	for {
+		if c.closed.Load() {
+			return delivery[T]{}, messaging.ErrReceive(messaging.ErrChannelClosed)
+		}
+
+		if ctx.Err() != nil {
+			return delivery[T]{}, messaging.ErrReceive(messaging.ErrTimeout, ctx.Err())
+		}
+
+		err := c.stream.ensureGroup(ctx)
+		if err != nil {
+			return delivery[T]{}, c.receiveError(ctx, err)
+		}
+
+		deliveries, err := c.stream.fetch(ctx, 1)
+		if err != nil {
+			return delivery[T]{}, c.receiveError(ctx, err)
+		}
+
+		if len(deliveries) > 0 {
+			return deliveries[0], nil
+		}
+	}
+}
+
+// Stats returns a snapshot of the channel's runtime statistics.
+// Delivered counts the entries returned by Receive and
+// ReceiveWithLease, and Failed the undecodable entries and the nacked
+// leases; a lease is handed off, so InFlight stays zero. Buffer fields
+// and Subscribers are zero.
+func (c *pollable[T]) Stats() messaging.Stats {
+	cassert.NotNil(c, "redis pollable channel is nil")
+
+	return c.stream.stats.Snapshot()
+}
+
+// Close marks the channel as closed and releases the Redis client when
+// the channel created it. Entries still in the stream stay there for
+// the group. Close is idempotent.
+func (c *pollable[T]) Close() error {
+	cassert.NotNil(c, "redis pollable channel is nil")
+
+	c.closeOnce.Do(func() {
+		c.closed.Store(true)
+		c.stream.close()
+	})
+
+	return nil
+}
+
+// receiveError maps a fetch failure to the Receive error contract:
+// ctx cancellation and Close take precedence over the Redis error they
+// usually cause.
+func (c *pollable[T]) receiveError(ctx context.Context, err error) error {
+	if c.closed.Load() {
+		return messaging.ErrReceive(messaging.ErrChannelClosed)
+	}
+
+	if ctx.Err() != nil {
+		return messaging.ErrReceive(messaging.ErrTimeout, ctx.Err())
+	}
+
+	return messaging.ErrReceive(err)
+}
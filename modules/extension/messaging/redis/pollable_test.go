@@ -0,0 +1,230 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+func TestNewPollableChannel(t *testing.T) {
+	t.Parallel()
+
+	c, _ := NewPollableChannel[int]("jobs", WithGroup("billing")).(*pollable[int])
+	defer func() { _ = c.Close() }()
+
+	if c.stream.key != "jobs" || c.stream.group != "billing" {
+		t.Fatalf("unexpected stream %q group %q", c.stream.key, c.stream.group)
+	}
+}
+
+func TestPollable_SendAndReceive(t *testing.T) {
+	t.Parallel()
+
+	mr := miniredis.RunT(t)
+	c, _ := NewPollableChannel[string]("jobs", testOptions(mr)...).(*pollable[string])
+	defer func() { _ = c.Close() }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), testWait)
+	defer cancel()
+
+	// The group is created lazily by the first Receive; entries sent
+	// before it are still delivered because the group starts at 0.
+	_ = c.Send(ctx, messaging.NewMessage("a", nil))
+	_ = c.Send(ctx, messaging.NewMessage("b", nil))
+
+	for _, want := range []string{"a", "b"} {
+		msg, err := c.Receive(ctx)
+		if err != nil {
+			t.Fatalf("Receive: %v", err)
+		}
+
+		if msg.Payload != want || msg.Headers.DeliveryCount != 1 {
+			t.Fatalf("expected %q with DeliveryCount 1, got %+v", want, msg)
+		}
+	}
+
+	pending, _ := c.stream.client.XPending(ctx, "jobs", "jobs").Result()
+	if pending.Count != 0 {
+		t.Fatalf("expected no pending entries, got %d", pending.Count)
+	}
+
+	stats := c.Stats()
+	if stats.Sent != 2 || stats.Delivered != 2 || stats.InFlight != 0 || stats.Failed != 0 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
+
+func TestPollable_Interceptors(t *testing.T) {
+	t.Parallel()
+
+	interceptor := messaging.InterceptorFuncs[string]{
+		PreSendFn: func(_ context.Context, msg messaging.Message[string]) (messaging.Message[string], error) {
+			msg.Headers.Source = "intercepted"
+
+			return msg, nil
+		},
+	}
+
+	mr := miniredis.RunT(t)
+	c, _ := NewPollableChannel[string]("jobs", testOptions(mr, WithInterceptors[string](interceptor))...).(*pollable[string])
+	defer func() { _ = c.Close() }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), testWait)
+	defer cancel()
+
+	err := c.Send(ctx, messaging.NewMessage("a", nil))
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	msg, err := c.Receive(ctx)
+	if err != nil {
+		t.Fatalf("Receive: %v", err)
+	}
+
+	if msg.Headers.Source != "intercepted" {
+		t.Fatalf("expected the PreSend rewrite to travel, got %+v", msg.Headers)
+	}
+}
+
+func TestPollable_ClaimsStaleEntries(t *testing.T) {
+	t.Parallel()
+
+	mr := miniredis.RunT(t)
+	crashed, _ := NewPollableChannel[int]("jobs", testOptions(mr, WithConsumer("crashed"))...).(*pollable[int])
+	defer func() { _ = crashed.Close() }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), testWait)
+	defer cancel()
+
+	_ = crashed.Send(ctx, messaging.NewMessage(42, nil))
+
+	// Read without acknowledging, as a consumer crashing mid-flight.
+	err := crashed.stream.ensureGroup(ctx)
+	if err != nil {
+		t.Fatalf("ensureGroup: %v", err)
+	}
+
+	deliveries, err := crashed.stream.readNew(ctx, 1)
+	if err != nil || len(deliveries) != 1 {
+		t.Fatalf("readNew: %v %d", err, len(deliveries))
+	}
+
+	survivor := NewPollableChannel[int]("jobs", testOptions(mr, WithConsumer("survivor"))...)
+	defer func() { _ = survivor.Close() }()
+
+	msg, err := survivor.Receive(ctx)
+	if err != nil {
+		t.Fatalf("Receive: %v", err)
+	}
+
+	if msg.Payload != 42 || msg.Headers.DeliveryCount != 2 {
+		t.Fatalf("expected claimed entry with DeliveryCount 2, got %+v", msg)
+	}
+}
+
+func TestPollable_Receive(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil ctx rejected", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewPollableChannel[int]("jobs")
+		defer func() { _ = c.Close() }()
+
+		_, err := c.Receive(nil) //nolint:staticcheck
+		if !errors.Is(err, messaging.ErrContextNil) {
+			t.Fatalf("expected ErrContextNil, got %v", err)
+		}
+	})
+
+	t.Run("ctx expiry returns timeout", func(t *testing.T) {
+		t.Parallel()
+
+		mr := miniredis.RunT(t)
+		c := NewPollableChannel[int]("jobs", testOptions(mr)...)
+		defer func() { _ = c.Close() }()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		_, err := c.Receive(ctx)
+		if !errors.Is(err, messaging.ErrTimeout) || !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected ErrReceive(ErrTimeout), got %v", err)
+		}
+	})
+
+	t.Run("redis failure wrapped", func(t *testing.T) {
+		t.Parallel()
+
+		mr := miniredis.RunT(t)
+		c := NewPollableChannel[int]("jobs", testOptions(mr)...)
+		defer func() { _ = c.Close() }()
+
+		mr.SetError("server down")
+
+		_, err := c.Receive(context.Background())
+		if !errors.Is(err, ErrCommand) || !errors.Is(err, messaging.ErrReceiveFailed) {
+			t.Fatalf("expected ErrReceive(ErrRedis(ErrCommand)), got %v", err)
+		}
+	})
+
+	t.Run("expired entries skipped", func(t *testing.T) {
+		t.Parallel()
+
+		mr := miniredis.RunT(t)
+		recorder := &errorRecorder{}
+		c := NewPollableChannel[int]("jobs", testOptions(mr, WithErrorHandler(recorder.handle))...)
+		defer func() { _ = c.Close() }()
+
+		ctx, cancel := context.WithTimeout(context.Background(), testWait)
+		defer cancel()
+
+		expired := messaging.NewMessage(1, nil)
+		expired.Headers.ExpirationTime = time.Now().Add(-time.Minute)
+
+		_ = c.Send(ctx, expired)
+		_ = c.Send(ctx, messaging.NewMessage(2, nil))
+
+		msg, err := c.Receive(ctx)
+		if err != nil || msg.Payload != 2 {
+			t.Fatalf("expected live message, got %+v %v", msg, err)
+		}
+
+		if !recorder.has(messaging.ErrExpired) {
+			t.Fatal("expected ErrExpired to be reported")
+		}
+	})
+}
+
+func TestPollable_Close(t *testing.T) {
+	t.Parallel()
+
+	mr := miniredis.RunT(t)
+	c := NewPollableChannel[int]("jobs", testOptions(mr)...)
+
+	err := c.Close()
+	if err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	err = c.Close()
+	if err != nil {
+		t.Fatalf("second Close: %v", err)
+	}
+
+	err = c.Send(context.Background(), messaging.NewMessage(1, nil))
+	if !errors.Is(err, messaging.ErrClosed) {
+		t.Fatalf("expected ErrClosed, got %v", err)
+	}
+
+	_, err = c.Receive(context.Background())
+	if !errors.Is(err, messaging.ErrChannelClosed) {
+		t.Fatalf("expected ErrChannelClosed, got %v", err)
+	}
+}
@@ -0,0 +1,387 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+	"github.com/guidomantilla/yarumo/messaging"
+	"github.com/guidomantilla/yarumo/messaging/codec"
+)
+
+// Stream entry field names.
+const (
+	fieldMessage     = "message"
+	fieldContentType = "content-type"
+)
+
+// stream is the Redis Streams plumbing shared by channel and pollable:
+// appending, consumer-group bootstrap, reading new entries, claiming
+// stale pending ones and acknowledging them.
+type stream[T any] struct {
+	client    goredis.UniversalClient
+	ownClient bool
+
+	key               string
+	group             string
+	consumer          string
+	codec             codec.Codec[T]
+	maxLen            int64
+	blockTimeout      time.Duration
+	visibilityTimeout time.Duration
+	maxDeliveries     int
+
+	errorHandler messaging.ErrorHandler
+	dlq          messaging.Channel[messaging.DeadLetter[T]]
+	interceptors []messaging.ChannelInterceptor[T]
+	clock        messaging.Clock
+	stats        messaging.StatsRecorder
+
+	groupMu    sync.Mutex
+	groupReady bool
+}
+
+// delivery is one decoded stream entry handed to a consumer.
+type delivery[T any] struct {
+	id  string
+	msg messaging.Message[T]
+}
+
+// newStream builds the shared plumbing for the channel called name.
+func newStream[T any](name string, options *Options) *stream[T] {
+	s := &stream[T]{
+		client:            options.client,
+		key:               name,
+		group:             name,
+		consumer:          options.consumer,
+		codec:             extractCodec[T](options.codec),
+		maxLen:            options.maxLen,
+		blockTimeout:      options.blockTimeout,
+		visibilityTimeout: options.visibilityTimeout,
+		maxDeliveries:     options.maxDeliveries,
+		errorHandler:      options.errorHandler,
+		dlq:               extractDLQ[T](options.dlq),
+		interceptors:      extractInterceptors[T](options.interceptors),
+		clock:             options.clock,
+	}
+
+	s.stats.UseClock(options.clock)
+
+	if s.client == nil {
+		s.client = goredis.NewClient(&goredis.Options{
+			Addr:     options.addr,
+			Password: options.password,
+			DB:       options.db,
+		})
+		s.ownClient = true
+	}
+
+	if options.stream != "" {
+		s.key = options.stream
+	}
+
+	if options.group != "" {
+		s.group = options.group
+	}
+
+	if s.consumer == "" {
+		s.consumer = defaultConsumer()
+	}
+
+	return s
+}
+
+// send encodes msg and appends it to the stream.
+func (s *stream[T]) send(ctx context.Context, msg messaging.Message[T]) error {
+	data, err := s.codec.Encode(msg)
+	if err != nil {
+		return messaging.ErrSend(ErrRedis(ErrEncode, err))
+	}
+
+	err = s.client.XAdd(ctx, &goredis.XAddArgs{
+		Stream: s.key,
+		MaxLen: s.maxLen,
+		Approx: s.maxLen > 0,
+		Values: map[string]any{
+			fieldMessage:     data,
+			fieldContentType: s.codec.ContentType(),
+		},
+	}).Err()
+	if err != nil {
+		return messaging.ErrSend(ErrRedis(ErrCommand, err))
+	}
+
+	s.stats.RecordSent()
+
+	return nil
+}
+
+// ensureGroup creates the consumer group (and the stream) once. An
+// existing group is reused. A failed attempt is retried on the next
+// call.
+func (s *stream[T]) ensureGroup(ctx context.Context) error {
+	s.groupMu.Lock()
+	defer s.groupMu.Unlock()
+
+	if s.groupReady {
+		return nil
+	}
+
+	err := s.client.XGroupCreateMkStream(ctx, s.key, s.group, "0").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return ErrRedis(ErrCommand, err)
+	}
+
+	s.groupReady = true
+
+	return nil
+}
+
+// fetch returns up to count entries for this consumer: stale pending
+// entries claimed back first, new entries otherwise (blocking up to
+// blockTimeout). Entries given up on are handled here and not
+// returned.
+func (s *stream[T]) fetch(ctx context.Context, count int64) ([]delivery[T], error) {
+	claimed, err := s.claimStale(ctx, count)
+	if err != nil || len(claimed) > 0 {
+		return claimed, err
+	}
+
+	return s.readNew(ctx, count)
+}
+
+// readNew reads entries never delivered to the group (XREADGROUP >).
+func (s *stream[T]) readNew(ctx context.Context, count int64) ([]delivery[T], error) {
+	streams, err := s.client.XReadGroup(ctx, &goredis.XReadGroupArgs{
+		Group:    s.group,
+		Consumer: s.consumer,
+		Streams:  []string{s.key, ">"},
+		Count:    count,
+		Block:    s.blockTimeout,
+	}).Result()
+	if errors.Is(err, goredis.Nil) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, ErrRedis(ErrCommand, err)
+	}
+
+	var out []delivery[T]
+
+	for _, entries := range streams {
+		for _, entry := range entries.Messages {
+			d, ok := s.accept(ctx, entry, 1)
+			if ok {
+				out = append(out, d)
+			}
+		}
+	}
+
+	return out, nil
+}
+
+// claimStale claims entries that stayed pending longer than the
+// visibility timeout (XPENDING IDLE + XCLAIM), whichever consumer
+// held them.
+func (s *stream[T]) claimStale(ctx context.Context, count int64) ([]delivery[T], error) {
+	pending, err := s.client.XPendingExt(ctx, &goredis.XPendingExtArgs{
+		Stream: s.key,
+		Group:  s.group,
+		Idle:   s.visibilityTimeout,
+		Start:  "-",
+		End:    "+",
+		Count:  count,
+	}).Result()
+	if err != nil {
+		return nil, ErrRedis(ErrCommand, err)
+	}
+
+	if len(pending) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]string, 0, len(pending))
+	deliveries := make(map[string]int, len(pending))
+
+	for _, p := range pending {
+		ids = append(ids, p.ID)
+		deliveries[p.ID] = int(p.RetryCount) + 1
+	}
+
+	entries, err := s.client.XClaim(ctx, &goredis.XClaimArgs{
+		Stream:   s.key,
+		Group:    s.group,
+		Consumer: s.consumer,
+		MinIdle:  s.visibilityTimeout,
+		Messages: ids,
+	}).Result()
+	if err != nil {
+		return nil, ErrRedis(ErrCommand, err)
+	}
+
+	var out []delivery[T]
+
+	for _, entry := range entries {
+		d, ok := s.accept(ctx, entry, deliveries[entry.ID])
+		if ok {
+			out = append(out, d)
+		}
+	}
+
+	return out, nil
+}
+
+// accept decodes entry and stamps its delivery count. Entries that
+// cannot be decoded, are past their Headers.ExpirationTime or exceed
+// WithMaxDeliveries are acknowledged, reported and rejected; an
+// undecodable entry counts as a failed delivery in the stats.
+func (s *stream[T]) accept(ctx context.Context, entry goredis.XMessage, deliveryCount int) (delivery[T], bool) {
+	msg, err := s.decode(entry)
+	if err != nil {
+		s.stats.Begin()(err)
+		s.report(ctx, nil, err)
+		s.ack(ctx, entry.ID)
+
+		return delivery[T]{}, false
+	}
+
+	msg.Headers.DeliveryCount = deliveryCount
+
+	if messaging.IsExpired(msg, s.clock.Now()) {
+		s.giveUp(ctx, entry.ID, msg, messaging.ErrExpired, errors.Join(messaging.ErrExpired, messaging.ErrDropped))
+
+		return delivery[T]{}, false
+	}
+
+	if s.maxDeliveries > 0 && deliveryCount > s.maxDeliveries {
+		s.giveUp(ctx, entry.ID, msg, ErrMaxDeliveries, ErrRedis(ErrMaxDeliveries))
+
+		return delivery[T]{}, false
+	}
+
+	return delivery[T]{id: entry.ID, msg: msg}, true
+}
+
+// decode rebuilds the message carried by entry.
+func (s *stream[T]) decode(entry goredis.XMessage) (messaging.Message[T], error) {
+	raw, ok := entry.Values[fieldMessage].(string)
+	if !ok {
+		return messaging.Message[T]{}, ErrRedis(ErrDecode, fmt.Errorf("entry %s has no %q field", entry.ID, fieldMessage))
+	}
+
+	msg, err := s.codec.Decode([]byte(raw))
+	if err != nil {
+		return messaging.Message[T]{}, ErrRedis(ErrDecode, fmt.Errorf("entry %s", entry.ID), err)
+	}
+
+	return msg, nil
+}
+
+// ack acknowledges id, reporting a failure through the ErrorHandler:
+// the entry then stays pending and is claimed back later.
+func (s *stream[T]) ack(ctx context.Context, id string) {
+	err := s.client.XAck(ctx, s.key, s.group, id).Err()
+	if err != nil {
+		s.report(ctx, nil, ErrRedis(ErrCommand, err))
+	}
+}
+
+// giveUp acknowledges an entry that will not be dispatched, reports
+// it with reason and publishes it to the DLQ with lastError. Reasons
+// wrapping messaging.ErrDropped count as drops in the stats.
+func (s *stream[T]) giveUp(ctx context.Context, id string, msg messaging.Message[T], lastError error, reason error) {
+	s.ack(ctx, id)
+
+	if errors.Is(reason, messaging.ErrDropped) {
+		s.stats.RecordDrop()
+	}
+
+	s.report(ctx, msg, reason)
+
+	if messaging.PublishDeadLetter(ctx, s.dlq, msg, lastError, s.clock.Now()) {
+		s.stats.RecordDeadLetter()
+	}
+}
+
+// report forwards err to the ErrorHandler when one is installed.
+func (s *stream[T]) report(ctx context.Context, msg any, err error) {
+	if s.errorHandler != nil {
+		s.errorHandler(ctx, msg, err)
+	}
+}
+
+// close releases the client when the stream created it.
+func (s *stream[T]) close() {
+	if s.ownClient {
+		_ = s.client.Close()
+	}
+}
+
+// defaultConsumer returns "<host>-<pid>", the consumer name used when
+// WithConsumer is not set.
+func defaultConsumer() string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "consumer"
+	}
+
+	return fmt.Sprintf("%s-%d", host, os.Getpid())
+}
+
+// extractCodec converts the type-erased Options.codec into a
+// codec.Codec[T], defaulting to JSON. Panics via cassert when the T of
+// WithCodec does not match the channel's T.
+func extractCodec[T any](raw any) codec.Codec[T] {
+	if raw == nil {
+		return codec.NewJSONCodec[T]()
+	}
+
+	typed, ok := raw.(codec.Codec[T])
+	cassert.True(ok, "WithCodec type parameter does not match channel type T")
+
+	return typed
+}
+
+// extractDLQ converts the type-erased Options.dlq into a
+// messaging.Channel[messaging.DeadLetter[T]]. Returns nil when no DLQ
+// is configured; panics via cassert when the T of WithDLQChannel does
+// not match the channel's T.
+func extractDLQ[T any](raw any) messaging.Channel[messaging.DeadLetter[T]] {
+	if raw == nil {
+		return nil
+	}
+
+	typed, ok := raw.(messaging.Channel[messaging.DeadLetter[T]])
+	cassert.True(ok, "WithDLQChannel type parameter does not match channel type T")
+
+	return typed
+}
+
+// extractInterceptors converts the type-erased Options.interceptors
+// entries into messaging.ChannelInterceptor[T] values. Returns nil when
+// none is configured; panics via cassert when the T of WithInterceptors
+// does not match the channel's T.
+func extractInterceptors[T any](raw []any) []messaging.ChannelInterceptor[T] {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	interceptors := make([]messaging.ChannelInterceptor[T], 0, len(raw))
+
+	for _, entry := range raw {
+		typed, ok := entry.(messaging.ChannelInterceptor[T])
+		cassert.True(ok, "WithInterceptors type parameter does not match channel type T")
+
+		interceptors = append(interceptors, typed)
+	}
+
+	return interceptors
+}
@@ -0,0 +1,100 @@
+// Package redis provides messaging channels backed by Redis Streams,
+// for cross-process messaging on infrastructure the workspace already
+// depends on (see extension/common/cache/redis).
+//
+// Two shapes are offered over the same stream layout:
+//
+//   - NewChannel returns a push-based messaging.Channel[T] that also
+//     implements lifecycle.Component: Send appends with XADD; Start
+//     creates the consumer group and spawns a consumer loop that reads
+//     with XREADGROUP and dispatches every entry to the handlers
+//     registered with Subscribe.
+//   - NewPollableChannel returns a messaging.PollableChannel[T]: Send
+//     appends with XADD and Receive (or ReceiveWithLease) pulls the
+//     next entry of the group with XREADGROUP.
+//
+// # Stream layout
+//
+// Each entry carries two fields: "message", the whole
+// messaging.Message[T] envelope (payload and every Headers field)
+// encoded with the configured messaging/codec.Codec[T] (WithCodec,
+// JSON by default), and "content-type", the codec's content type. The
+// stream key and the group name default to the channel name (see
+// WithStream and WithGroup).
+//
+// # Consumer groups
+//
+// Every channel instance reads as one consumer of its group (see
+// WithConsumer; the default is "<host>-<pid>"). Instances sharing a
+// group compete for entries — each entry is handed to one consumer —
+// while separate groups each see every entry. Inside one Channel
+// instance an entry is dispatched to every subscribed handler.
+//
+// # Delivery guarantee
+//
+// Channel is at-least-once: an entry is acknowledged (XACK) only after
+// every handler returned nil. A failed handler leaves it pending; once
+// it has been idle for the visibility timeout (WithVisibilityTimeout)
+// any consumer of the group claims it back (XPENDING + XCLAIM) and
+// dispatches it again with Headers.DeliveryCount set to the number of
+// deliveries. With WithMaxDeliveries, an entry past the limit is
+// acknowledged and handed to the DLQ (WithDLQChannel) with
+// ErrMaxDeliveries instead. Entries past their Headers.ExpirationTime
+// are acknowledged and dropped the same way, with messaging.ErrExpired.
+//
+// PollableChannel.Receive acknowledges the entry before returning it:
+// once Receive returned, the message is the caller's responsibility.
+// Entries whose consumer crashed between read and acknowledgement are
+// claimed back by the next Receive after the visibility timeout.
+// ReceiveWithLease leaves the entry pending instead: the lease's Ack
+// acknowledges it, while Nack and Extend rewrite its idle time, so a
+// Redis lease can be held or deferred for at most the visibility
+// timeout at a time.
+//
+// # Error handling
+//
+// Send returns messaging.ErrSend wrapping ErrRedis(ErrEncode, ...) or
+// ErrRedis(ErrCommand, ...), and messaging.ErrSend wrapping
+// messaging.ErrIntercepted when a PreSend hook of WithInterceptors
+// vetoes it. Failures on the consumer side (handler errors,
+// undecodable entries, Redis errors of the loop) are reported through
+// the ErrorHandler (WithErrorHandler, defaulting to
+// messaging.DefaultErrorHandler). Undecodable entries cannot be
+// rebuilt as a messaging.DeadLetter[T]; they are acknowledged and only
+// reported, with ErrDecode.
+//
+// # Statistics
+//
+// Both channels implement messaging.Inspectable. The counters are
+// local to the instance, not to the stream: Sent counts what this
+// instance appended, Delivered what its consumer handed out. Other
+// processes sharing the stream keep their own counters.
+//
+// # Lifecycle
+//
+// Channel is worker-style: Start creates the group (MKSTREAM, an
+// existing group is reused) and spawns the consumer loop; Stop cancels
+// it, waits for the in-flight batch bounded by the drain timeout and
+// closes the Redis client when the channel created it (WithClient
+// leaves a shared client open). PollableChannel has no Start: the
+// group is created on first Receive and Close releases the client.
+package redis
+
+import (
+	"github.com/guidomantilla/yarumo/core/common/lifecycle"
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+var (
+	_ messaging.Channel[any]         = (*channel[any])(nil)
+	_ lifecycle.Component            = (*channel[any])(nil)
+	_ messaging.Inspectable          = (*channel[any])(nil)
+	_ messaging.PollableChannel[any] = (*pollable[any])(nil)
+	_ messaging.Inspectable          = (*pollable[any])(nil)
+	_ messaging.Lease[any]           = (*lease[any])(nil)
+
+	_ ErrRedisFn = ErrRedis
+)
+
+// ErrRedisFn is the function type for ErrRedis.
+type ErrRedisFn func(causes ...error) error
@@ -0,0 +1,79 @@
+package redis
+
+import (
+	"sync"
+	"sync/atomic"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/guidomantilla/yarumo/messaging/stores"
+)
+
+// conn is the Redis plumbing shared by messageStore and metadataStore:
+// the client, whether the store owns it, the key prefix and the closed
+// flag.
+type conn struct {
+	client    goredis.UniversalClient
+	ownClient bool
+	prefix    string
+
+	closed    atomic.Bool
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// newConn builds the shared plumbing from options: WithClient wins,
+// otherwise the store builds (and owns) a client from WithAddr,
+// WithPassword and WithDB.
+func newConn(options *Options) *conn {
+	c := &conn{
+		client: options.client,
+		prefix: options.keyPrefix,
+	}
+
+	if c.client == nil {
+		c.client = goredis.NewClient(&goredis.Options{
+			Addr:     options.addr,
+			Password: options.password,
+			DB:       options.db,
+		})
+		c.ownClient = true
+	}
+
+	return c
+}
+
+// key returns the Redis key of the store key k.
+func (c *conn) key(k string) string {
+	return c.prefix + k
+}
+
+// check returns stores.ErrStore(stores.ErrStoreClosed) once Close has
+// been called.
+func (c *conn) check() error {
+	if c.closed.Load() {
+		return stores.ErrStore(stores.ErrStoreClosed)
+	}
+
+	return nil
+}
+
+// close marks the store as closed and releases the client when the
+// store owns it. Only the first call closes; later calls return the
+// same result.
+func (c *conn) close() error {
+	c.closeOnce.Do(func() {
+		c.closed.Store(true)
+
+		if !c.ownClient {
+			return
+		}
+
+		err := c.client.Close()
+		if err != nil {
+			c.closeErr = stores.ErrStore(ErrRedis(ErrCommand, err))
+		}
+	})
+
+	return c.closeErr
+}
@@ -0,0 +1,57 @@
+package redis
+
+import (
+	"errors"
+	"fmt"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+	cerrs "github.com/guidomantilla/yarumo/core/common/errs"
+)
+
+// RedisType is the error domain identifier for Redis store operations.
+const RedisType = "messaging-store-redis"
+
+var (
+	_ error = (*Error)(nil)
+)
+
+// Sentinel errors for Redis store operations.
+var (
+	// ErrRedisFailed is the top-level sentinel embedded in every
+	// domain Error returned by ErrRedis.
+	ErrRedisFailed = errors.New("redis store failed")
+	// ErrCommand indicates that a Redis command (SET, GET, DEL,
+	// EXISTS) failed.
+	ErrCommand = errors.New("redis command failed")
+	// ErrEncode indicates that a message could not be encoded with the
+	// configured codec before SET.
+	ErrEncode = errors.New("message encoding failed")
+	// ErrDecode indicates that a stored value could not be decoded
+	// into a message.
+	ErrDecode = errors.New("message decoding failed")
+)
+
+// Error is the domain error type for Redis store operations.
+type Error struct {
+	cerrs.TypedError
+}
+
+// Error returns the formatted error string including the type
+// classification.
+func (e *Error) Error() string {
+	cassert.NotNil(e, "error is nil")
+	cassert.NotNil(e.Err, "internal error is nil")
+
+	return fmt.Sprintf("redis %s error: %s", e.Type, e.Err)
+}
+
+// ErrRedis wraps the given causes into a domain Error joined with
+// ErrRedisFailed.
+func ErrRedis(causes ...error) error {
+	return &Error{
+		TypedError: cerrs.TypedError{
+			Type: RedisType,
+			Err:  errors.Join(append(causes, ErrRedisFailed)...),
+		},
+	}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+
+	"github.com/guidomantilla/yarumo/extension/messaging/stores/redis"
+	"github.com/guidomantilla/yarumo/messaging"
+	"github.com/guidomantilla/yarumo/messaging/patterns/endpoints/idempotent"
+)
+
+type paymentReceived struct {
+	ID     string  `json:"id"`
+	Amount float64 `json:"amount"`
+}
+
+func main() {
+	server, err := miniredis.Run()
+	if err != nil {
+		fmt.Println("failed to start miniredis:", err)
+		return
+	}
+	defer server.Close()
+
+	ctx := context.Background()
+
+	// Claim check: park a large message in Redis and keep only its key.
+	claims := redis.NewMessageStore[paymentReceived](
+		redis.WithAddr(server.Addr()),
+		redis.WithKeyPrefix("claims:"),
+		redis.WithTTL(time.Hour),
+	)
+	defer claims.Close()
+
+	payment := messaging.NewMessage(paymentReceived{ID: "p-1", Amount: 42.5}, nil)
+	payment.Headers.MessageID = "p-1"
+
+	err = claims.Put(ctx, "p-1", payment)
+	if err != nil {
+		fmt.Println("put failed:", err)
+		return
+	}
+
+	claimed, err := claims.Get(ctx, "p-1")
+	if err != nil {
+		fmt.Println("get failed:", err)
+		return
+	}
+
+	fmt.Printf("redis: claimed %+v\n", claimed.Payload)
+
+	// Idempotent receiver: the metadata store is shared by every
+	// replica, and AddIfAbsent makes the duplicate check atomic.
+	dedup := redis.NewMetadataStore(
+		redis.WithAddr(server.Addr()),
+		redis.WithKeyPrefix("dedup:"),
+	)
+	defer dedup.Close()
+
+	src := messaging.NewPipelineChannel[paymentReceived]()
+	dst := messaging.NewPipelineChannel[paymentReceived]()
+
+	processed := 0
+
+	_, err = dst.Subscribe(func(_ context.Context, msg messaging.Message[paymentReceived]) error {
+		processed++
+		fmt.Printf("redis: processed %+v\n", msg.Payload)
+
+		return nil
+	})
+	if err != nil {
+		fmt.Println("subscribe failed:", err)
+		return
+	}
+
+	receiver := idempotent.NewIdempotent("payments", src, dst, dedup,
+		idempotent.WithTTL[paymentReceived](time.Hour),
+	)
+
+	// Start directly (rather than lifecycle.Build) so the subscription
+	// is in place before the first Send.
+	err = receiver.Start(ctx)
+	if err != nil {
+		fmt.Println("start failed:", err)
+		return
+	}
+	defer receiver.Stop(ctx)
+
+	for range 2 {
+		err = src.Send(ctx, payment)
+		if err != nil {
+			fmt.Println("send failed:", err)
+			return
+		}
+	}
+
+	fmt.Printf("redis: sent twice, processed %d time(s)\n", processed)
+}
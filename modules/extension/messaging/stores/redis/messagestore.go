@@ -0,0 +1,121 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+	cpointer "github.com/guidomantilla/yarumo/core/common/pointer"
+	"github.com/guidomantilla/yarumo/messaging"
+	"github.com/guidomantilla/yarumo/messaging/codec"
+	"github.com/guidomantilla/yarumo/messaging/stores"
+)
+
+// messageStore is the Redis MessageStore implementation. Each message
+// is one string key holding the codec-encoded envelope.
+type messageStore[T any] struct {
+	*conn
+
+	codec codec.Codec[T]
+	ttl   time.Duration
+}
+
+// NewMessageStore constructs a Redis-backed MessageStore[T]. The store
+// is ready to use immediately; call Close to release its client.
+//
+// Optional behaviors:
+//
+//   - WithClient / WithAddr / WithPassword / WithDB select the Redis
+//     server.
+//   - WithKeyPrefix namespaces the keys.
+//   - WithCodec replaces the JSON envelope codec.
+//   - WithTTL expires stored messages.
+func NewMessageStore[T any](opts ...Option) MessageStore[T] {
+	options := NewOptions(opts...)
+
+	return &messageStore[T]{
+		conn:  newConn(options),
+		codec: extractCodec[T](options.codec),
+		ttl:   options.ttl,
+	}
+}
+
+// Put encodes msg and stores it under key with SET, overwriting any
+// previous value (and resetting its TTL).
+func (s *messageStore[T]) Put(ctx context.Context, key string, msg messaging.Message[T]) error {
+	cassert.NotNil(s, "redis message store is nil")
+
+	err := s.check()
+	if err != nil {
+		return err
+	}
+
+	data, err := s.codec.Encode(msg)
+	if err != nil {
+		return stores.ErrStore(ErrRedis(ErrEncode, err))
+	}
+
+	err = s.client.Set(ctx, s.key(key), data, s.ttl).Err()
+	if err != nil {
+		return stores.ErrStore(ErrRedis(ErrCommand, err))
+	}
+
+	return nil
+}
+
+// Get reads the message stored under key with GET and decodes it. It
+// returns stores.ErrNotFound when the key does not exist (or has
+// expired).
+func (s *messageStore[T]) Get(ctx context.Context, key string) (messaging.Message[T], error) {
+	cassert.NotNil(s, "redis message store is nil")
+
+	err := s.check()
+	if err != nil {
+		return cpointer.Zero[messaging.Message[T]](), err
+	}
+
+	data, err := s.client.Get(ctx, s.key(key)).Bytes()
+	if errors.Is(err, goredis.Nil) {
+		return cpointer.Zero[messaging.Message[T]](), stores.ErrNotFound()
+	}
+
+	if err != nil {
+		return cpointer.Zero[messaging.Message[T]](), stores.ErrStore(ErrRedis(ErrCommand, err))
+	}
+
+	msg, err := s.codec.Decode(data)
+	if err != nil {
+		return cpointer.Zero[messaging.Message[T]](), stores.ErrStore(ErrRedis(ErrDecode, err))
+	}
+
+	return msg, nil
+}
+
+// Delete removes the message stored under key with DEL. Delete is
+// idempotent: a missing key is not an error.
+func (s *messageStore[T]) Delete(ctx context.Context, key string) error {
+	cassert.NotNil(s, "redis message store is nil")
+
+	err := s.check()
+	if err != nil {
+		return err
+	}
+
+	err = s.client.Del(ctx, s.key(key)).Err()
+	if err != nil {
+		return stores.ErrStore(ErrRedis(ErrCommand, err))
+	}
+
+	return nil
+}
+
+// Close marks the store as closed and releases the Redis client when
+// the store created it. Close is idempotent.
+func (s *messageStore[T]) Close() error {
+	cassert.NotNil(s, "redis message store is nil")
+
+	return s.close()
+}
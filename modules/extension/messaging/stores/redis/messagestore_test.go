@@ -0,0 +1,265 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/guidomantilla/yarumo/messaging"
+	"github.com/guidomantilla/yarumo/messaging/stores"
+)
+
+type claim struct {
+	ID     string  `json:"id"`
+	Amount float64 `json:"amount"`
+}
+
+// newTestMessageStore builds a MessageStore on mr, closing it on
+// cleanup.
+func newTestMessageStore[T any](t *testing.T, mr *miniredis.Miniredis, opts ...Option) MessageStore[T] {
+	t.Helper()
+
+	s := NewMessageStore[T](append([]Option{WithAddr(mr.Addr())}, opts...)...)
+
+	t.Cleanup(func() { _ = s.Close() })
+
+	return s
+}
+
+func TestMessageStore_PutGet(t *testing.T) {
+	t.Parallel()
+
+	t.Run("round-trips payload and headers", func(t *testing.T) {
+		t.Parallel()
+
+		mr := miniredis.RunT(t)
+		s := newTestMessageStore[claim](t, mr)
+
+		in := messaging.Message[claim]{
+			Payload: claim{ID: "c-1", Amount: 12.5},
+			Headers: messaging.Headers{
+				MessageID:     "m-1",
+				CorrelationID: "corr-1",
+				Custom:        map[string]any{"tenant": "acme"},
+			},
+		}
+
+		err := s.Put(context.Background(), "c-1", in)
+		if err != nil {
+			t.Fatalf("Put: %v", err)
+		}
+
+		out, err := s.Get(context.Background(), "c-1")
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+
+		if out.Payload != in.Payload {
+			t.Fatalf("payload = %+v, want %+v", out.Payload, in.Payload)
+		}
+
+		if out.Headers.MessageID != "m-1" || out.Headers.CorrelationID != "corr-1" || out.Headers.Custom["tenant"] != "acme" {
+			t.Fatalf("unexpected headers %+v", out.Headers)
+		}
+	})
+
+	t.Run("Put overwrites", func(t *testing.T) {
+		t.Parallel()
+
+		mr := miniredis.RunT(t)
+		s := newTestMessageStore[int](t, mr)
+
+		for _, payload := range []int{1, 2} {
+			err := s.Put(context.Background(), "k", messaging.Message[int]{Payload: payload})
+			if err != nil {
+				t.Fatalf("Put: %v", err)
+			}
+		}
+
+		out, err := s.Get(context.Background(), "k")
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+
+		if out.Payload != 2 {
+			t.Fatalf("payload = %d, want 2", out.Payload)
+		}
+	})
+
+	t.Run("missing key returns ErrStoreNotFound", func(t *testing.T) {
+		t.Parallel()
+
+		mr := miniredis.RunT(t)
+		s := newTestMessageStore[int](t, mr)
+
+		_, err := s.Get(context.Background(), "missing")
+		if !errors.Is(err, stores.ErrStoreNotFound) {
+			t.Fatalf("expected ErrStoreNotFound, got %v", err)
+		}
+	})
+
+	t.Run("key prefix namespaces the Redis key", func(t *testing.T) {
+		t.Parallel()
+
+		mr := miniredis.RunT(t)
+		s := newTestMessageStore[int](t, mr, WithKeyPrefix("claims:"))
+
+		err := s.Put(context.Background(), "k", messaging.Message[int]{Payload: 1})
+		if err != nil {
+			t.Fatalf("Put: %v", err)
+		}
+
+		if !mr.Exists("claims:k") {
+			t.Fatalf("expected key claims:k, got keys %v", mr.Keys())
+		}
+	})
+
+	t.Run("WithTTL expires stored messages", func(t *testing.T) {
+		t.Parallel()
+
+		mr := miniredis.RunT(t)
+		s := newTestMessageStore[int](t, mr, WithTTL(time.Minute))
+
+		err := s.Put(context.Background(), "k", messaging.Message[int]{Payload: 1})
+		if err != nil {
+			t.Fatalf("Put: %v", err)
+		}
+
+		if got := mr.TTL("k"); got != time.Minute {
+			t.Fatalf("TTL = %v, want 1m", got)
+		}
+
+		mr.FastForward(2 * time.Minute)
+
+		_, err = s.Get(context.Background(), "k")
+		if !errors.Is(err, stores.ErrStoreNotFound) {
+			t.Fatalf("expected ErrStoreNotFound after expiry, got %v", err)
+		}
+	})
+
+	t.Run("undecodable value returns ErrDecode", func(t *testing.T) {
+		t.Parallel()
+
+		mr := miniredis.RunT(t)
+		s := newTestMessageStore[int](t, mr)
+
+		err := mr.Set("k", "not json")
+		if err != nil {
+			t.Fatalf("seed: %v", err)
+		}
+
+		_, err = s.Get(context.Background(), "k")
+		if !errors.Is(err, ErrDecode) || !errors.Is(err, stores.ErrStoreFailed) {
+			t.Fatalf("expected ErrDecode wrapped in ErrStoreFailed, got %v", err)
+		}
+	})
+}
+
+func TestMessageStore_Delete(t *testing.T) {
+	t.Parallel()
+
+	mr := miniredis.RunT(t)
+	s := newTestMessageStore[int](t, mr)
+
+	err := s.Put(context.Background(), "k", messaging.Message[int]{Payload: 1})
+	if err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	for range 2 {
+		err = s.Delete(context.Background(), "k")
+		if err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+	}
+
+	_, err = s.Get(context.Background(), "k")
+	if !errors.Is(err, stores.ErrStoreNotFound) {
+		t.Fatalf("expected ErrStoreNotFound after Delete, got %v", err)
+	}
+}
+
+func TestMessageStore_Errors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Redis failures return ErrCommand", func(t *testing.T) {
+		t.Parallel()
+
+		mr := miniredis.RunT(t)
+		s := newTestMessageStore[int](t, mr)
+
+		mr.SetError("boom")
+
+		err := s.Put(context.Background(), "k", messaging.Message[int]{Payload: 1})
+		if !errors.Is(err, ErrCommand) || !errors.Is(err, stores.ErrStoreFailed) {
+			t.Fatalf("Put: expected ErrCommand wrapped in ErrStoreFailed, got %v", err)
+		}
+
+		_, err = s.Get(context.Background(), "k")
+		if !errors.Is(err, ErrCommand) {
+			t.Fatalf("Get: expected ErrCommand, got %v", err)
+		}
+
+		err = s.Delete(context.Background(), "k")
+		if !errors.Is(err, ErrCommand) {
+			t.Fatalf("Delete: expected ErrCommand, got %v", err)
+		}
+	})
+
+	t.Run("calls after Close return ErrStoreClosed", func(t *testing.T) {
+		t.Parallel()
+
+		mr := miniredis.RunT(t)
+		s := NewMessageStore[int](WithAddr(mr.Addr()))
+
+		err := s.Close()
+		if err != nil {
+			t.Fatalf("Close: %v", err)
+		}
+
+		err = s.Close()
+		if err != nil {
+			t.Fatalf("second Close: %v", err)
+		}
+
+		err = s.Put(context.Background(), "k", messaging.Message[int]{Payload: 1})
+		if !errors.Is(err, stores.ErrStoreClosed) {
+			t.Fatalf("Put: expected ErrStoreClosed, got %v", err)
+		}
+
+		_, err = s.Get(context.Background(), "k")
+		if !errors.Is(err, stores.ErrStoreClosed) {
+			t.Fatalf("Get: expected ErrStoreClosed, got %v", err)
+		}
+
+		err = s.Delete(context.Background(), "k")
+		if !errors.Is(err, stores.ErrStoreClosed) {
+			t.Fatalf("Delete: expected ErrStoreClosed, got %v", err)
+		}
+	})
+
+	t.Run("Close leaves a shared client open", func(t *testing.T) {
+		t.Parallel()
+
+		mr := miniredis.RunT(t)
+
+		client := goredis.NewClient(&goredis.Options{Addr: mr.Addr()})
+		t.Cleanup(func() { _ = client.Close() })
+
+		s := NewMessageStore[int](WithClient(client))
+
+		err := s.Close()
+		if err != nil {
+			t.Fatalf("Close: %v", err)
+		}
+
+		err = client.Ping(context.Background()).Err()
+		if err != nil {
+			t.Fatalf("shared client closed: %v", err)
+		}
+	})
+}
@@ -0,0 +1,110 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+	"github.com/guidomantilla/yarumo/messaging/stores"
+)
+
+// presentValue is the value stored under every metadata key; only the
+// key's existence and TTL carry information.
+const presentValue = "1"
+
+// metadataStore is the Redis MetadataStore implementation. Each
+// recorded key is one string key whose Redis TTL is the dedup window.
+type metadataStore struct {
+	*conn
+}
+
+// NewMetadataStore constructs a Redis-backed MetadataStore. The store
+// is ready to use immediately; call Close to release its client. It
+// implements stores.AtomicMetadataStore.
+//
+// Optional behaviors:
+//
+//   - WithClient / WithAddr / WithPassword / WithDB select the Redis
+//     server.
+//   - WithKeyPrefix namespaces the keys.
+func NewMetadataStore(opts ...Option) MetadataStore {
+	options := NewOptions(opts...)
+
+	return &metadataStore{
+		conn: newConn(options),
+	}
+}
+
+// Has reports whether key is present with EXISTS. Expired keys are
+// evicted by Redis and reported as absent.
+func (s *metadataStore) Has(ctx context.Context, key string) (bool, error) {
+	cassert.NotNil(s, "redis metadata store is nil")
+
+	err := s.check()
+	if err != nil {
+		return false, err
+	}
+
+	n, err := s.client.Exists(ctx, s.key(key)).Result()
+	if err != nil {
+		return false, stores.ErrStore(ErrRedis(ErrCommand, err))
+	}
+
+	return n > 0, nil
+}
+
+// Add records key with the given TTL using SET PX. An existing key has
+// its TTL refreshed (last writer wins). It returns
+// stores.ErrStore(stores.ErrInvalidTTL) when ttl is non-positive.
+func (s *metadataStore) Add(ctx context.Context, key string, ttl time.Duration) error {
+	cassert.NotNil(s, "redis metadata store is nil")
+
+	if ttl <= 0 {
+		return stores.ErrStore(stores.ErrInvalidTTL)
+	}
+
+	err := s.check()
+	if err != nil {
+		return err
+	}
+
+	err = s.client.Set(ctx, s.key(key), presentValue, ttl).Err()
+	if err != nil {
+		return stores.ErrStore(ErrRedis(ErrCommand, err))
+	}
+
+	return nil
+}
+
+// AddIfAbsent records key with the given TTL using SET NX PX and
+// reports whether it did. The check and the write are one Redis
+// command, so concurrent callers on any number of replicas record a
+// key exactly once. A present key keeps its TTL. It returns
+// stores.ErrStore(stores.ErrInvalidTTL) when ttl is non-positive.
+func (s *metadataStore) AddIfAbsent(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	cassert.NotNil(s, "redis metadata store is nil")
+
+	if ttl <= 0 {
+		return false, stores.ErrStore(stores.ErrInvalidTTL)
+	}
+
+	err := s.check()
+	if err != nil {
+		return false, err
+	}
+
+	added, err := s.client.SetNX(ctx, s.key(key), presentValue, ttl).Result()
+	if err != nil {
+		return false, stores.ErrStore(ErrRedis(ErrCommand, err))
+	}
+
+	return added, nil
+}
+
+// Close marks the store as closed and releases the Redis client when
+// the store created it. Close is idempotent.
+func (s *metadataStore) Close() error {
+	cassert.NotNil(s, "redis metadata store is nil")
+
+	return s.close()
+}
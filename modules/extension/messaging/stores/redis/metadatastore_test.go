@@ -0,0 +1,225 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+
+	"github.com/guidomantilla/yarumo/messaging/stores"
+)
+
+// newTestMetadataStore builds a MetadataStore on mr, closing it on
+// cleanup.
+func newTestMetadataStore(t *testing.T, mr *miniredis.Miniredis, opts ...Option) MetadataStore {
+	t.Helper()
+
+	s := NewMetadataStore(append([]Option{WithAddr(mr.Addr())}, opts...)...)
+
+	t.Cleanup(func() { _ = s.Close() })
+
+	return s
+}
+
+func TestMetadataStore_AddHas(t *testing.T) {
+	t.Parallel()
+
+	t.Run("records a key that Has reports as present", func(t *testing.T) {
+		t.Parallel()
+
+		mr := miniredis.RunT(t)
+		s := newTestMetadataStore(t, mr, WithKeyPrefix("dedup:"))
+
+		ok, err := s.Has(context.Background(), "k")
+		if err != nil || ok {
+			t.Fatalf("Has before Add = %v, %v; want false, nil", ok, err)
+		}
+
+		err = s.Add(context.Background(), "k", time.Minute)
+		if err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+
+		ok, err = s.Has(context.Background(), "k")
+		if err != nil || !ok {
+			t.Fatalf("Has after Add = %v, %v; want true, nil", ok, err)
+		}
+
+		if got := mr.TTL("dedup:k"); got != time.Minute {
+			t.Fatalf("TTL = %v, want 1m", got)
+		}
+	})
+
+	t.Run("Add refreshes the TTL", func(t *testing.T) {
+		t.Parallel()
+
+		mr := miniredis.RunT(t)
+		s := newTestMetadataStore(t, mr)
+
+		err := s.Add(context.Background(), "k", time.Minute)
+		if err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+
+		err = s.Add(context.Background(), "k", time.Hour)
+		if err != nil {
+			t.Fatalf("refresh Add: %v", err)
+		}
+
+		if got := mr.TTL("k"); got != time.Hour {
+			t.Fatalf("TTL = %v, want 1h", got)
+		}
+	})
+
+	t.Run("expired key is reported absent", func(t *testing.T) {
+		t.Parallel()
+
+		mr := miniredis.RunT(t)
+		s := newTestMetadataStore(t, mr)
+
+		err := s.Add(context.Background(), "k", time.Minute)
+		if err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+
+		mr.FastForward(2 * time.Minute)
+
+		ok, err := s.Has(context.Background(), "k")
+		if err != nil || ok {
+			t.Fatalf("Has after expiry = %v, %v; want false, nil", ok, err)
+		}
+	})
+
+	t.Run("non-positive TTL returns ErrInvalidTTL", func(t *testing.T) {
+		t.Parallel()
+
+		mr := miniredis.RunT(t)
+		s := newTestMetadataStore(t, mr)
+
+		err := s.Add(context.Background(), "k", 0)
+		if !errors.Is(err, stores.ErrInvalidTTL) {
+			t.Fatalf("Add: expected ErrInvalidTTL, got %v", err)
+		}
+
+		_, err = s.AddIfAbsent(context.Background(), "k", -time.Second)
+		if !errors.Is(err, stores.ErrInvalidTTL) {
+			t.Fatalf("AddIfAbsent: expected ErrInvalidTTL, got %v", err)
+		}
+	})
+}
+
+func TestMetadataStore_AddIfAbsent(t *testing.T) {
+	t.Parallel()
+
+	t.Run("records an absent key exactly once and keeps its TTL", func(t *testing.T) {
+		t.Parallel()
+
+		mr := miniredis.RunT(t)
+		s := newTestMetadataStore(t, mr)
+
+		added, err := s.AddIfAbsent(context.Background(), "k", time.Minute)
+		if err != nil || !added {
+			t.Fatalf("first AddIfAbsent = %v, %v; want true, nil", added, err)
+		}
+
+		added, err = s.AddIfAbsent(context.Background(), "k", time.Hour)
+		if err != nil || added {
+			t.Fatalf("second AddIfAbsent = %v, %v; want false, nil", added, err)
+		}
+
+		if got := mr.TTL("k"); got != time.Minute {
+			t.Fatalf("TTL = %v, want 1m (unchanged)", got)
+		}
+	})
+
+	t.Run("concurrent stores record a key once", func(t *testing.T) {
+		t.Parallel()
+
+		mr := miniredis.RunT(t)
+
+		var (
+			wg    sync.WaitGroup
+			added atomic.Int32
+		)
+
+		for range 8 {
+			s := newTestMetadataStore(t, mr)
+
+			wg.Go(func() {
+				ok, err := s.AddIfAbsent(context.Background(), "k", time.Minute)
+				if err != nil {
+					t.Errorf("AddIfAbsent: %v", err)
+				}
+
+				if ok {
+					added.Add(1)
+				}
+			})
+		}
+
+		wg.Wait()
+
+		if got := added.Load(); got != 1 {
+			t.Fatalf("added = %d, want 1", got)
+		}
+	})
+}
+
+func TestMetadataStore_Errors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Redis failures return ErrCommand", func(t *testing.T) {
+		t.Parallel()
+
+		mr := miniredis.RunT(t)
+		s := newTestMetadataStore(t, mr)
+
+		mr.SetError("boom")
+
+		_, err := s.Has(context.Background(), "k")
+		if !errors.Is(err, ErrCommand) || !errors.Is(err, stores.ErrStoreFailed) {
+			t.Fatalf("Has: expected ErrCommand wrapped in ErrStoreFailed, got %v", err)
+		}
+
+		err = s.Add(context.Background(), "k", time.Minute)
+		if !errors.Is(err, ErrCommand) {
+			t.Fatalf("Add: expected ErrCommand, got %v", err)
+		}
+
+		_, err = s.AddIfAbsent(context.Background(), "k", time.Minute)
+		if !errors.Is(err, ErrCommand) {
+			t.Fatalf("AddIfAbsent: expected ErrCommand, got %v", err)
+		}
+	})
+
+	t.Run("calls after Close return ErrStoreClosed", func(t *testing.T) {
+		t.Parallel()
+
+		mr := miniredis.RunT(t)
+		s := NewMetadataStore(WithAddr(mr.Addr()))
+
+		err := s.Close()
+		if err != nil {
+			t.Fatalf("Close: %v", err)
+		}
+
+		_, err = s.Has(context.Background(), "k")
+		if !errors.Is(err, stores.ErrStoreClosed) {
+			t.Fatalf("Has: expected ErrStoreClosed, got %v", err)
+		}
+
+		err = s.Add(context.Background(), "k", time.Minute)
+		if !errors.Is(err, stores.ErrStoreClosed) {
+			t.Fatalf("Add: expected ErrStoreClosed, got %v", err)
+		}
+
+		_, err = s.AddIfAbsent(context.Background(), "k", time.Minute)
+		if !errors.Is(err, stores.ErrStoreClosed) {
+			t.Fatalf("AddIfAbsent: expected ErrStoreClosed, got %v", err)
+		}
+	})
+}
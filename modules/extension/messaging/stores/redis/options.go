@@ -0,0 +1,131 @@
+package redis
+
+import (
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+	"github.com/guidomantilla/yarumo/messaging/codec"
+)
+
+// Option is a functional option for configuring Options. Option is
+// non-generic so both stores share it; WithCodec stores its value
+// type-erased and NewMessageStore checks it against the store's T.
+type Option func(opts *Options)
+
+// Options holds the configuration for a Redis store.
+type Options struct {
+	client   goredis.UniversalClient
+	addr     string
+	password string
+	db       int
+
+	keyPrefix string
+	codec     any
+	ttl       time.Duration
+}
+
+// NewOptions creates a new Options with sensible defaults and applies
+// the given options. Defaults: a client of its own on localhost:6379
+// DB 0, no key prefix, JSON codec, messages stored without TTL.
+func NewOptions(opts ...Option) *Options {
+	options := &Options{}
+
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	return options
+}
+
+// WithClient makes the store use client instead of building its own.
+// A shared client is not closed by Close; WithAddr, WithPassword and
+// WithDB are ignored. Nil values are ignored.
+func WithClient(client goredis.UniversalClient) Option {
+	return func(opts *Options) {
+		if client != nil {
+			opts.client = client
+		}
+	}
+}
+
+// WithAddr sets the redis server address of the store's own client.
+// Empty values are ignored; go-redis then defaults Addr to
+// "localhost:6379" at client init.
+func WithAddr(addr string) Option {
+	return func(opts *Options) {
+		if addr != "" {
+			opts.addr = addr
+		}
+	}
+}
+
+// WithPassword sets the redis auth password of the store's own
+// client. Empty values are ignored.
+func WithPassword(password string) Option {
+	return func(opts *Options) {
+		if password != "" {
+			opts.password = password
+		}
+	}
+}
+
+// WithDB sets the redis logical DB index of the store's own client.
+// Negative values are ignored.
+func WithDB(db int) Option {
+	return func(opts *Options) {
+		if db >= 0 {
+			opts.db = db
+		}
+	}
+}
+
+// WithKeyPrefix prepends prefix to every key the store reads or
+// writes. Empty values are ignored.
+func WithKeyPrefix(prefix string) Option {
+	return func(opts *Options) {
+		if prefix != "" {
+			opts.keyPrefix = prefix
+		}
+	}
+}
+
+// WithCodec sets the codec that maps a messaging.Message[T] (payload
+// and headers) to the stored value. Only the MessageStore uses it.
+// Defaults to codec.NewJSONCodec[T]. Type parameter T must match the
+// store's T; mismatches are caught with cassert at construction. Nil
+// values are ignored.
+func WithCodec[T any](c codec.Codec[T]) Option {
+	return func(opts *Options) {
+		if c != nil {
+			opts.codec = c
+		}
+	}
+}
+
+// WithTTL makes the MessageStore expire every stored message ttl after
+// its Put, so that claims nobody retrieves do not accumulate. Defaults
+// to no expiry. The MetadataStore ignores it: its TTL is given on
+// every Add. Non-positive values are ignored.
+func WithTTL(ttl time.Duration) Option {
+	return func(opts *Options) {
+		if ttl > 0 {
+			opts.ttl = ttl
+		}
+	}
+}
+
+// extractCodec converts the type-erased Options.codec into a
+// codec.Codec[T], defaulting to JSON. It panics via cassert when the T
+// of WithCodec does not match the store's T.
+func extractCodec[T any](raw any) codec.Codec[T] {
+	if raw == nil {
+		return codec.NewJSONCodec[T]()
+	}
+
+	typed, ok := raw.(codec.Codec[T])
+	cassert.True(ok, "WithCodec type parameter does not match store type T")
+
+	return typed
+}
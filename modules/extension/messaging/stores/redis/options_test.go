@@ -0,0 +1,149 @@
+package redis
+
+import (
+	"testing"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/guidomantilla/yarumo/messaging/codec"
+)
+
+func TestNewOptions(t *testing.T) {
+	t.Parallel()
+
+	opts := NewOptions()
+
+	if opts.client != nil {
+		t.Fatal("expected nil client")
+	}
+
+	if opts.addr != "" || opts.password != "" || opts.db != 0 {
+		t.Fatalf("expected zero connection settings, got %q %q %d", opts.addr, opts.password, opts.db)
+	}
+
+	if opts.keyPrefix != "" {
+		t.Fatalf("expected empty key prefix, got %q", opts.keyPrefix)
+	}
+
+	if opts.codec != nil {
+		t.Fatal("expected nil codec")
+	}
+
+	if opts.ttl != 0 {
+		t.Fatalf("expected ttl 0, got %v", opts.ttl)
+	}
+}
+
+func TestWithClient(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid value applied", func(t *testing.T) {
+		t.Parallel()
+
+		client := goredis.NewClient(&goredis.Options{})
+		defer client.Close()
+
+		opts := NewOptions(WithClient(client))
+		if opts.client != client {
+			t.Fatal("expected client to be set")
+		}
+	})
+
+	t.Run("nil ignored", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithClient(nil))
+		if opts.client != nil {
+			t.Fatal("expected nil client")
+		}
+	})
+}
+
+func TestWithConnectionSettings(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid values applied", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithAddr("redis:6380"), WithPassword("secret"), WithDB(3))
+		if opts.addr != "redis:6380" || opts.password != "secret" || opts.db != 3 {
+			t.Fatalf("unexpected settings %q %q %d", opts.addr, opts.password, opts.db)
+		}
+	})
+
+	t.Run("invalid values ignored", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithAddr(""), WithPassword(""), WithDB(-1))
+		if opts.addr != "" || opts.password != "" || opts.db != 0 {
+			t.Fatalf("unexpected settings %q %q %d", opts.addr, opts.password, opts.db)
+		}
+	})
+}
+
+func TestWithKeyPrefix(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid value applied", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithKeyPrefix("dedup:"))
+		if opts.keyPrefix != "dedup:" {
+			t.Fatalf("expected prefix %q, got %q", "dedup:", opts.keyPrefix)
+		}
+	})
+
+	t.Run("empty ignored", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithKeyPrefix("dedup:"), WithKeyPrefix(""))
+		if opts.keyPrefix != "dedup:" {
+			t.Fatalf("expected prefix %q, got %q", "dedup:", opts.keyPrefix)
+		}
+	})
+}
+
+func TestWithCodec(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid value applied", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithCodec(codec.NewJSONCodec[int]()))
+		if opts.codec == nil {
+			t.Fatal("expected codec to be set")
+		}
+	})
+
+	t.Run("nil ignored", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithCodec[int](nil))
+		if opts.codec != nil {
+			t.Fatal("expected nil codec")
+		}
+	})
+}
+
+func TestWithTTL(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid value applied", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithTTL(time.Minute))
+		if opts.ttl != time.Minute {
+			t.Fatalf("expected ttl 1m, got %v", opts.ttl)
+		}
+	})
+
+	t.Run("non-positive ignored", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithTTL(0), WithTTL(-time.Second))
+		if opts.ttl != 0 {
+			t.Fatalf("expected ttl 0, got %v", opts.ttl)
+		}
+	})
+}
@@ -0,0 +1,72 @@
+// Package redis provides Redis-backed implementations of the
+// messaging/stores contracts, so that the patterns built on them
+// (Idempotent Receiver, Claim Check, Saga) share their state across
+// every replica of a service instead of keeping it per process.
+//
+// Two stores are offered:
+//
+//   - NewMessageStore returns a MessageStore[T]: Put encodes the whole
+//     messaging.Message[T] envelope with the configured
+//     messaging/codec.Codec[T] (WithCodec, JSON by default) and stores
+//     it with SET (optionally with a TTL, see WithTTL); Get reads it
+//     back with GET and Delete removes it with DEL.
+//   - NewMetadataStore returns a MetadataStore: Add records a key with
+//     SET PX, Has checks it with EXISTS, and AddIfAbsent uses SET NX PX
+//     so that checking and recording a key is a single atomic command
+//     — two replicas racing on the same key cannot both record it. It
+//     implements stores.AtomicMetadataStore, which the Idempotent
+//     Receiver detects and uses instead of Has + Add.
+//
+// # Key layout
+//
+// Keys are stored verbatim, prefixed with WithKeyPrefix when set. Give
+// each store sharing a Redis database its own prefix ("dedup:",
+// "claims:") so their keys cannot collide.
+//
+// # Error handling
+//
+// Errors follow the stores contract: Get on a missing key returns
+// stores.ErrNotFound, a non-positive TTL returns
+// stores.ErrStore(stores.ErrInvalidTTL) and any call after Close
+// returns stores.ErrStore(stores.ErrStoreClosed). Redis, encoding and
+// decoding failures return stores.ErrStore wrapping ErrRedis(ErrCommand,
+// ...), ErrRedis(ErrEncode, ...) or ErrRedis(ErrDecode, ...).
+//
+// # Lifecycle
+//
+// Neither store runs goroutines: expiry is delegated to Redis. Close
+// releases the Redis client when the store created it (WithClient
+// leaves a shared client open).
+package redis
+
+import (
+	"github.com/guidomantilla/yarumo/messaging/stores"
+)
+
+var (
+	_ MessageStore[any] = (*messageStore[any])(nil)
+	_ MetadataStore     = (*metadataStore)(nil)
+
+	_ ErrRedisFn = ErrRedis
+)
+
+// MessageStore is a stores.MessageStore[T] backed by Redis. Close
+// releases the Redis client when the store owns it.
+type MessageStore[T any] interface {
+	stores.MessageStore[T]
+	// Close marks the store as closed and releases the Redis client
+	// when the store created it. Close is idempotent.
+	Close() error
+}
+
+// MetadataStore is a stores.AtomicMetadataStore backed by Redis.
+// Close releases the Redis client when the store owns it.
+type MetadataStore interface {
+	stores.AtomicMetadataStore
+	// Close marks the store as closed and releases the Redis client
+	// when the store created it. Close is idempotent.
+	Close() error
+}
+
+// ErrRedisFn is the function type for ErrRedis.
+type ErrRedisFn func(causes ...error) error
@@ -0,0 +1,39 @@
+package otelmessaging
+
+// defaultScopeName is the instrumentation scope name used by every
+// decorator when the caller does not override it via WithName.
+const defaultScopeName = "github.com/guidomantilla/yarumo/extension/telemetry/otel/messaging"
+
+// defaultSystem is the messaging.system attribute value used when the
+// caller does not override it via WithSystem.
+const defaultSystem = "yarumo"
+
+// Attribute keys from the OTel messaging semantic conventions, plus
+// the yarumo-specific drop labels.
+const (
+	attrSystem         = "messaging.system"
+	attrDestination    = "messaging.destination.name"
+	attrOperationName  = "messaging.operation.name"
+	attrOperationType  = "messaging.operation.type"
+	attrMessageID      = "messaging.message.id"
+	attrConversationID = "messaging.message.conversation_id"
+	attrDeliveryCount  = "messaging.message.delivery_count"
+	attrErrorType      = "error.type"
+	attrDropReason     = "messaging.drop.reason"
+	attrOverflowPolicy = "messaging.overflow.policy"
+	attrConsumerGroup  = "messaging.consumer.group.name"
+)
+
+// Operation names and types.
+const (
+	operationSend    = "send"
+	operationProcess = "process"
+)
+
+// Drop reasons recorded on messaging.client.dropped.messages.
+const (
+	dropReasonRejected = "rejected"
+	dropReasonOverflow = "overflow"
+	dropReasonExpired  = "expired"
+	dropReasonOther    = "other"
+)
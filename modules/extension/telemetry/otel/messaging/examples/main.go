@@ -0,0 +1,110 @@
+// Demo that exercises the public API of the telemetry/otel/messaging
+// channel decorators:
+//
+//  1. NewTracingChannel + NewMetricsChannel stacked on a TopicChannel:
+//     the producer span of Send becomes the parent of the consumer
+//     span of the handler, through the trace context injected into
+//     Headers.Custom.
+//  2. The recorded metrics are flushed from a manual reader and the
+//     data points printed.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/guidomantilla/yarumo/core/common/lifecycle"
+	otelmessaging "github.com/guidomantilla/yarumo/extension/telemetry/otel/messaging"
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+type orderCreated struct {
+	ID string
+}
+
+func main() {
+	err := run()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	ctx := context.Background()
+
+	recorder := tracetest.NewSpanRecorder()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	reader := metric.NewManualReader()
+	meterProvider := metric.NewMeterProvider(metric.WithReader(reader))
+
+	opts := []otelmessaging.Option{
+		otelmessaging.WithTracerProvider(tracerProvider),
+		otelmessaging.WithMeterProvider(meterProvider),
+		otelmessaging.WithPropagator(propagation.TraceContext{}),
+	}
+
+	topic := messaging.NewTopicChannel[orderCreated]("orders")
+	orders := otelmessaging.NewTracingChannel("orders", otelmessaging.NewMetricsChannel("orders", topic, opts...), opts...)
+
+	handled := make(chan struct{})
+
+	_, err := orders.Subscribe(func(_ context.Context, msg messaging.Message[orderCreated]) error {
+		fmt.Printf("handler: %s (traceparent %v)\n", msg.Payload.ID, msg.Headers.Custom["traceparent"])
+		close(handled)
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	errCh := make(chan error, 1)
+	closeFn, err := lifecycle.Build(ctx, topic.(lifecycle.Component), errCh)
+	if err != nil {
+		return err
+	}
+	defer closeFn(ctx, 5*time.Second)
+
+	err = orders.Send(ctx, messaging.NewMessage(orderCreated{ID: "o-1"}, nil))
+	if err != nil {
+		return err
+	}
+
+	select {
+	case <-handled:
+	case <-time.After(2 * time.Second):
+		return fmt.Errorf("message not handled in time")
+	}
+
+	time.Sleep(10 * time.Millisecond)
+
+	fmt.Println("=== Spans ===")
+	for _, span := range recorder.Ended() {
+		fmt.Printf("%-15s kind=%-8s trace=%s parent=%s\n", span.Name(), span.SpanKind(), span.SpanContext().TraceID(), span.Parent().SpanID())
+	}
+
+	var rm metricdata.ResourceMetrics
+
+	err = reader.Collect(ctx, &rm)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("=== Metrics ===")
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			fmt.Printf("%s (%s)\n", m.Name, m.Unit)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,338 @@
+package otelmessaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+// metricsChannel wraps a base Channel and records send and process
+// metrics. Every record carries messaging.system and
+// messaging.destination.name; failures add error.type. Process records
+// carry consumerAttrs, which add messaging.consumer.group.name when the
+// channel is a pattern source.
+type metricsChannel[T any] struct {
+	base          messaging.Channel[T]
+	attrs         []attribute.KeyValue
+	consumerAttrs []attribute.KeyValue
+
+	sent            metric.Int64Counter
+	sendDuration    metric.Float64Histogram
+	consumed        metric.Int64Counter
+	processDuration metric.Float64Histogram
+	dispatchLatency metric.Float64Histogram
+	panics          metric.Int64Counter
+	dropped         metric.Int64Counter
+}
+
+// NewMetricsChannel wraps base with a Channel that records, for every
+// Send and every handler subscribed through it:
+//
+//   - messaging.client.sent.messages (counter) and
+//     messaging.client.operation.duration (histogram, s) per Send.
+//   - messaging.client.dropped.messages (counter) with
+//     messaging.drop.reason=rejected when Send fails with
+//     messaging.ErrBufferFull (OverflowReject).
+//   - messaging.client.consumed.messages (counter) and
+//     messaging.process.duration (histogram, s) per handler call.
+//   - messaging.process.dispatch_latency (histogram, s): time between
+//     Headers.Timestamp and the handler start, for messages that carry
+//     a Timestamp.
+//   - messaging.process.panics (counter) per handler panic; the panic
+//     is re-raised for the channel to recover.
+//   - messaging.queue.depth (observable gauge) when WithDepthFn is set.
+//
+// destination must be non-empty. Only the meterProvider + name +
+// system + depthFn fields of the shared Options are consulted.
+// Instrument construction failures are reported through otel.Handle
+// (the global OTel ErrorHandler, which logs them by default) and the
+// failed instrument is replaced by a no-op: the channel keeps working
+// without recording it.
+func NewMetricsChannel[T any](destination string, base messaging.Channel[T], opts ...Option) messaging.Channel[T] {
+	cassert.NotEmpty(destination, "destination is empty")
+	cassert.NotNil(base, "base channel is nil")
+
+	options := NewOptions(opts...)
+	meter := options.meterProvider.Meter(options.name)
+
+	c := &metricsChannel[T]{
+		base: base,
+		attrs: []attribute.KeyValue{
+			attribute.String(attrSystem, options.system),
+			attribute.String(attrDestination, destination),
+		},
+	}
+
+	c.consumerAttrs = c.attrs
+	if options.consumerGroup != "" {
+		c.consumerAttrs = c.with(attribute.String(attrConsumerGroup, options.consumerGroup))
+	}
+
+	c.sent = newInt64Counter(meter, "messaging.client.sent.messages", "Number of messages producers attempted to send.", "{message}")
+	c.sendDuration = newFloat64Histogram(meter, "messaging.client.operation.duration", "Duration of send operations.", "s")
+	c.consumed = newInt64Counter(meter, "messaging.client.consumed.messages", "Number of messages delivered to handlers.", "{message}")
+	c.processDuration = newFloat64Histogram(meter, "messaging.process.duration", "Duration of handler invocations.", "s")
+	c.dispatchLatency = newFloat64Histogram(meter, "messaging.process.dispatch_latency", "Time between message creation and handler dispatch.", "s")
+	c.panics = newInt64Counter(meter, "messaging.process.panics", "Number of handler invocations that panicked.", "{panic}")
+	c.dropped = newDroppedCounter(meter)
+
+	if options.depthFn != nil {
+		depthFn := options.depthFn
+		depthAttrs := metric.WithAttributes(c.attrs...)
+
+		_, err := meter.Int64ObservableGauge(
+			"messaging.queue.depth",
+			metric.WithDescription("Number of messages buffered by the channel."),
+			metric.WithUnit("{message}"),
+			metric.WithInt64Callback(func(_ context.Context, observer metric.Int64Observer) error {
+				observer.Observe(int64(depthFn()), depthAttrs)
+
+				return nil
+			}),
+		)
+		if err != nil {
+			reportInstrumentError("messaging.queue.depth", err)
+		}
+	}
+
+	return c
+}
+
+// Send delegates to base and records the send counter and duration.
+// A nil ctx is passed straight to base so it can reject it.
+func (c *metricsChannel[T]) Send(ctx context.Context, msg messaging.Message[T]) error {
+	cassert.NotNil(c, "metrics channel is nil")
+
+	if ctx == nil {
+		return c.base.Send(ctx, msg)
+	}
+
+	start := time.Now()
+	err := c.base.Send(ctx, msg)
+	elapsed := time.Since(start).Seconds()
+
+	attrs := c.with(attribute.String(attrOperationName, operationSend))
+	if err != nil {
+		attrs = append(attrs, attribute.String(attrErrorType, errorType(err)))
+	}
+
+	c.sent.Add(ctx, 1, metric.WithAttributes(attrs...))
+	c.sendDuration.Record(ctx, elapsed, metric.WithAttributes(attrs...))
+
+	if errors.Is(err, messaging.ErrBufferFull) {
+		c.dropped.Add(ctx, 1, metric.WithAttributes(c.with(
+			attribute.String(attrDropReason, dropReasonRejected),
+			attribute.String(attrOverflowPolicy, overflowPolicyName(messaging.OverflowReject)),
+		)...))
+	}
+
+	return err
+}
+
+// Subscribe registers handler on base wrapped with process metrics.
+func (c *metricsChannel[T]) Subscribe(handler messaging.Handler[T]) (messaging.Cancel, error) {
+	cassert.NotNil(c, "metrics channel is nil")
+
+	if handler == nil {
+		return c.base.Subscribe(nil)
+	}
+
+	return c.base.Subscribe(c.wrap(handler))
+}
+
+// wrap returns handler decorated with process metrics.
+func (c *metricsChannel[T]) wrap(handler messaging.Handler[T]) messaging.Handler[T] {
+	return func(ctx context.Context, msg messaging.Message[T]) error {
+		start := time.Now()
+
+		if !msg.Headers.Timestamp.IsZero() {
+			c.dispatchLatency.Record(ctx, start.Sub(msg.Headers.Timestamp).Seconds(), metric.WithAttributes(c.consumerAttrs...))
+		}
+
+		defer func() {
+			r := recover()
+			if r == nil {
+				return
+			}
+
+			c.panics.Add(ctx, 1, metric.WithAttributes(c.consumerAttrs...))
+
+			panic(r)
+		}()
+
+		err := handler(ctx, msg)
+		elapsed := time.Since(start).Seconds()
+
+		attrs := make([]attribute.KeyValue, 0, len(c.consumerAttrs)+2)
+		attrs = append(attrs, c.consumerAttrs...)
+		attrs = append(attrs, attribute.String(attrOperationName, operationProcess))
+		if err != nil {
+			attrs = append(attrs, attribute.String(attrErrorType, errorType(err)))
+		}
+
+		c.consumed.Add(ctx, 1, metric.WithAttributes(attrs...))
+		c.processDuration.Record(ctx, elapsed, metric.WithAttributes(attrs...))
+
+		return err
+	}
+}
+
+// with returns the base attributes followed by extra, in a fresh slice.
+func (c *metricsChannel[T]) with(extra ...attribute.KeyValue) []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, 0, len(c.attrs)+len(extra)+1)
+	attrs = append(attrs, c.attrs...)
+
+	return append(attrs, extra...)
+}
+
+// NewMetricsErrorHandler wraps next with an ErrorHandler that counts
+// the messages a channel drops on messaging.client.dropped.messages,
+// labelled with messaging.drop.reason (overflow, expired or other) and,
+// for overflow, messaging.overflow.policy from WithOverflowPolicy.
+// Every error is then forwarded to next (messaging.DefaultErrorHandler
+// when nil). Install it with messaging.WithErrorHandler (and
+// messaging.WithExpiredHandler when a separate expired hook is used).
+// destination must be non-empty.
+func NewMetricsErrorHandler(destination string, next messaging.ErrorHandler, opts ...Option) messaging.ErrorHandler {
+	cassert.NotEmpty(destination, "destination is empty")
+
+	if next == nil {
+		next = messaging.DefaultErrorHandler
+	}
+
+	options := NewOptions(opts...)
+	dropped := newDroppedCounter(options.meterProvider.Meter(options.name))
+	policy := options.overflowPolicy
+
+	base := []attribute.KeyValue{
+		attribute.String(attrSystem, options.system),
+		attribute.String(attrDestination, destination),
+	}
+
+	return func(ctx context.Context, msg any, err error) {
+		if errors.Is(err, messaging.ErrDropped) {
+			attrs := append([]attribute.KeyValue{}, base...)
+
+			switch {
+			case errors.Is(err, messaging.ErrOverflow):
+				attrs = append(attrs, attribute.String(attrDropReason, dropReasonOverflow))
+				if policy != "" {
+					attrs = append(attrs, attribute.String(attrOverflowPolicy, policy))
+				}
+			case errors.Is(err, messaging.ErrExpired):
+				attrs = append(attrs, attribute.String(attrDropReason, dropReasonExpired))
+			default:
+				attrs = append(attrs, attribute.String(attrDropReason, dropReasonOther))
+			}
+
+			dropped.Add(ctx, 1, metric.WithAttributes(attrs...))
+		}
+
+		next(ctx, msg, err)
+	}
+}
+
+// deadLetterChannel wraps a DLQ channel and counts publications.
+type deadLetterChannel[T any] struct {
+	base      messaging.Channel[messaging.DeadLetter[T]]
+	attrs     []attribute.KeyValue
+	published metric.Int64Counter
+}
+
+// NewMetricsDeadLetterChannel wraps a DLQ channel with a counter of
+// successful publications (messaging.dlq.published), labelled with
+// error.type from DeadLetter.LastError. Pass the result to
+// messaging.WithDLQChannel. destination names the DLQ and must be
+// non-empty.
+func NewMetricsDeadLetterChannel[T any](destination string, base messaging.Channel[messaging.DeadLetter[T]], opts ...Option) messaging.Channel[messaging.DeadLetter[T]] {
+	cassert.NotEmpty(destination, "destination is empty")
+	cassert.NotNil(base, "base channel is nil")
+
+	options := NewOptions(opts...)
+	meter := options.meterProvider.Meter(options.name)
+
+	published := newInt64Counter(meter, "messaging.dlq.published", "Number of dead letters published to the DLQ.", "{message}")
+
+	return &deadLetterChannel[T]{
+		base: base,
+		attrs: []attribute.KeyValue{
+			attribute.String(attrSystem, options.system),
+			attribute.String(attrDestination, destination),
+		},
+		published: published,
+	}
+}
+
+// Send delegates to base and counts the dead letter when accepted.
+func (c *deadLetterChannel[T]) Send(ctx context.Context, msg messaging.Message[messaging.DeadLetter[T]]) error {
+	cassert.NotNil(c, "dead letter channel is nil")
+
+	err := c.base.Send(ctx, msg)
+	if err != nil || ctx == nil {
+		return err
+	}
+
+	attrs := append([]attribute.KeyValue{}, c.attrs...)
+	if msg.Payload.LastError != nil {
+		attrs = append(attrs, attribute.String(attrErrorType, errorType(msg.Payload.LastError)))
+	}
+
+	c.published.Add(ctx, 1, metric.WithAttributes(attrs...))
+
+	return nil
+}
+
+// Subscribe delegates to base.
+func (c *deadLetterChannel[T]) Subscribe(handler messaging.Handler[messaging.DeadLetter[T]]) (messaging.Cancel, error) {
+	cassert.NotNil(c, "dead letter channel is nil")
+
+	return c.base.Subscribe(handler)
+}
+
+// newDroppedCounter builds the messaging.client.dropped.messages
+// counter shared by NewMetricsChannel and NewMetricsErrorHandler.
+func newDroppedCounter(meter metric.Meter) metric.Int64Counter {
+	return newInt64Counter(meter, "messaging.client.dropped.messages", "Number of messages dropped by the channel.", "{message}")
+}
+
+// newInt64Counter builds the named counter on meter, falling back to a
+// no-op counter reported through reportInstrumentError on failure.
+func newInt64Counter(meter metric.Meter, name, description, unit string) metric.Int64Counter {
+	counter, err := meter.Int64Counter(name, metric.WithDescription(description), metric.WithUnit(unit))
+	if err != nil {
+		reportInstrumentError(name, err)
+
+		return noop.Int64Counter{}
+	}
+
+	return counter
+}
+
+// newFloat64Histogram builds the named histogram on meter, falling back
+// to a no-op histogram reported through reportInstrumentError on
+// failure.
+func newFloat64Histogram(meter metric.Meter, name, description, unit string) metric.Float64Histogram {
+	histogram, err := meter.Float64Histogram(name, metric.WithDescription(description), metric.WithUnit(unit))
+	if err != nil {
+		reportInstrumentError(name, err)
+
+		return noop.Float64Histogram{}
+	}
+
+	return histogram
+}
+
+// reportInstrumentError hands an instrument construction failure to
+// the global OTel ErrorHandler.
+func reportInstrumentError(name string, err error) {
+	otel.Handle(fmt.Errorf("otelmessaging: create instrument %s: %w", name, err))
+}
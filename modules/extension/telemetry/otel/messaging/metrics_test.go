@@ -0,0 +1,439 @@
+package otelmessaging
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+// collectMetrics returns the freshly-collected ResourceMetrics from reader.
+func collectMetrics(t *testing.T, reader *metric.ManualReader) metricdata.ResourceMetrics {
+	t.Helper()
+
+	var rm metricdata.ResourceMetrics
+	err := reader.Collect(context.Background(), &rm)
+	if err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+
+	return rm
+}
+
+// findMetric scans the ResourceMetrics for a metric matching name.
+// Returns nil when not found so tests can assert presence explicitly.
+func findMetric(rm metricdata.ResourceMetrics, name string) *metricdata.Metrics {
+	for _, sm := range rm.ScopeMetrics {
+		for i := range sm.Metrics {
+			if sm.Metrics[i].Name == name {
+				return &sm.Metrics[i]
+			}
+		}
+	}
+	return nil
+}
+
+// sumOf returns the total of an Int64 sum metric, optionally filtered
+// to data points carrying key=value (empty key matches every point).
+func sumOf(t *testing.T, rm metricdata.ResourceMetrics, name, key, value string) int64 {
+	t.Helper()
+
+	m := findMetric(rm, name)
+	if m == nil {
+		return 0
+	}
+
+	sum, ok := m.Data.(metricdata.Sum[int64])
+	if !ok {
+		t.Fatalf("expected %s to be Sum[int64], got %T", name, m.Data)
+	}
+
+	var total int64
+
+	for _, dp := range sum.DataPoints {
+		if key != "" {
+			v, found := dp.Attributes.Value(attribute.Key(key))
+			if !found || v.Emit() != value {
+				continue
+			}
+		}
+
+		total += dp.Value
+	}
+
+	return total
+}
+
+// histogramCount returns the number of recordings of a Float64
+// histogram metric.
+func histogramCount(t *testing.T, rm metricdata.ResourceMetrics, name string) uint64 {
+	t.Helper()
+
+	m := findMetric(rm, name)
+	if m == nil {
+		return 0
+	}
+
+	hist, ok := m.Data.(metricdata.Histogram[float64])
+	if !ok {
+		t.Fatalf("expected %s to be Histogram[float64], got %T", name, m.Data)
+	}
+
+	var count uint64
+	for _, dp := range hist.DataPoints {
+		count += dp.Count
+	}
+
+	return count
+}
+
+func TestNewMetricsChannel(t *testing.T) {
+	t.Parallel()
+
+	ch := NewMetricsChannel("orders", messaging.NewPipelineChannel[int]())
+	if ch == nil {
+		t.Fatal("expected non-nil channel")
+	}
+}
+
+var errInstrument = errors.New("instrument rejected")
+
+// failingMeterProvider hands out meters whose instrument constructors
+// all fail with errInstrument.
+type failingMeterProvider struct{ noop.MeterProvider }
+
+func (failingMeterProvider) Meter(string, ...otelmetric.MeterOption) otelmetric.Meter {
+	return failingMeter{}
+}
+
+type failingMeter struct{ noop.Meter }
+
+func (failingMeter) Int64Counter(string, ...otelmetric.Int64CounterOption) (otelmetric.Int64Counter, error) {
+	return nil, errInstrument
+}
+
+func (failingMeter) Float64Histogram(string, ...otelmetric.Float64HistogramOption) (otelmetric.Float64Histogram, error) {
+	return nil, errInstrument
+}
+
+func (failingMeter) Int64ObservableCounter(string, ...otelmetric.Int64ObservableCounterOption) (otelmetric.Int64ObservableCounter, error) {
+	return nil, errInstrument
+}
+
+func (failingMeter) Int64ObservableGauge(string, ...otelmetric.Int64ObservableGaugeOption) (otelmetric.Int64ObservableGauge, error) {
+	return nil, errInstrument
+}
+
+// installErrorHandler swaps the global OTel ErrorHandler for a
+// recorder and returns a snapshot func. Tests using this helper cannot
+// run in parallel because the global is shared.
+func installErrorHandler(t *testing.T) func() []error {
+	t.Helper()
+
+	var (
+		mu   sync.Mutex
+		errs []error
+	)
+
+	prev := otel.GetErrorHandler()
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		errs = append(errs, err)
+	}))
+	t.Cleanup(func() { otel.SetErrorHandler(prev) })
+
+	return func() []error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return append([]error(nil), errs...)
+	}
+}
+
+func TestMetrics_InstrumentErrors(t *testing.T) { //nolint:paralleltest // swaps the global OTel ErrorHandler
+	errs := installErrorHandler(t)
+	provider := failingMeterProvider{}
+
+	ch := NewMetricsChannel("orders", messaging.NewPipelineChannel[int](),
+		WithMeterProvider(provider),
+		WithDepthFn(func() int { return 0 }),
+	)
+	handler := NewMetricsErrorHandler("orders", messaging.SilentErrorHandler, WithMeterProvider(provider))
+	dlq := NewMetricsDeadLetterChannel("orders-dlq", messaging.NewPipelineChannel[messaging.DeadLetter[int]](), WithMeterProvider(provider))
+
+	// 7 channel instruments + the depth gauge, the error handler's and
+	// the DLQ's counters.
+	got := errs()
+	if len(got) != 10 {
+		t.Fatalf("expected 10 reported instrument errors, got %d: %v", len(got), got)
+	}
+
+	for _, err := range got {
+		if !errors.Is(err, errInstrument) {
+			t.Fatalf("expected errInstrument, got %v", err)
+		}
+	}
+
+	_, err := ch.Subscribe(func(_ context.Context, _ messaging.Message[int]) error { return nil })
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	err = ch.Send(context.Background(), messaging.NewMessage(1, nil))
+	if err != nil {
+		t.Fatalf("expected the channel to keep working, got %v", err)
+	}
+
+	handler(context.Background(), nil, messaging.ErrDropped)
+
+	err = dlq.Send(context.Background(), messaging.NewMessage(messaging.DeadLetter[int]{}, nil))
+	if err != nil {
+		t.Fatalf("expected the DLQ to keep working, got %v", err)
+	}
+}
+
+func TestMetricsChannel_SendAndProcess(t *testing.T) {
+	t.Parallel()
+
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+
+	ch := NewMetricsChannel("orders", messaging.NewPipelineChannel[int](), WithMeterProvider(provider))
+
+	boom := errors.New("boom")
+
+	_, _ = ch.Subscribe(func(_ context.Context, msg messaging.Message[int]) error {
+		if msg.Payload < 0 {
+			return boom
+		}
+
+		return nil
+	})
+
+	_ = ch.Send(context.Background(), messaging.NewMessage(1, nil))
+	sendErr := ch.Send(context.Background(), messaging.NewMessage(-1, nil))
+
+	rm := collectMetrics(t, reader)
+
+	got := sumOf(t, rm, "messaging.client.sent.messages", "", "")
+	if got != 2 {
+		t.Fatalf("expected 2 sent, got %d", got)
+	}
+
+	got = sumOf(t, rm, "messaging.client.sent.messages", attrErrorType, errorType(sendErr))
+	if got != 1 {
+		t.Fatalf("expected 1 failed send, got %d", got)
+	}
+
+	got = sumOf(t, rm, "messaging.client.consumed.messages", attrDestination, "orders")
+	if got != 2 {
+		t.Fatalf("expected 2 consumed, got %d", got)
+	}
+
+	for _, name := range []string{
+		"messaging.client.operation.duration",
+		"messaging.process.duration",
+		"messaging.process.dispatch_latency",
+	} {
+		count := histogramCount(t, rm, name)
+		if count != 2 {
+			t.Fatalf("expected 2 %s recordings, got %d", name, count)
+		}
+	}
+}
+
+func TestMetricsChannel_Panics(t *testing.T) {
+	t.Parallel()
+
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+
+	ch := NewMetricsChannel("orders", messaging.NewPipelineChannel[int](), WithMeterProvider(provider))
+
+	_, _ = ch.Subscribe(func(_ context.Context, _ messaging.Message[int]) error { panic("kaboom") })
+
+	err := ch.Send(context.Background(), messaging.NewMessage(1, nil))
+	if !errors.Is(err, messaging.ErrHandlerPanic) {
+		t.Fatalf("expected the channel to recover the re-raised panic, got %v", err)
+	}
+
+	rm := collectMetrics(t, reader)
+
+	got := sumOf(t, rm, "messaging.process.panics", "", "")
+	if got != 1 {
+		t.Fatalf("expected 1 panic, got %d", got)
+	}
+}
+
+func TestMetricsChannel_RejectedSendIsADrop(t *testing.T) {
+	t.Parallel()
+
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+
+	// An unstarted queue with a one-slot buffer rejects the second Send.
+	queue := messaging.NewQueueChannel[int]("orders", messaging.WithBufferSize(1))
+	ch := NewMetricsChannel("orders", queue, WithMeterProvider(provider))
+
+	_ = ch.Send(context.Background(), messaging.NewMessage(1, nil))
+
+	err := ch.Send(context.Background(), messaging.NewMessage(2, nil))
+	if !errors.Is(err, messaging.ErrBufferFull) {
+		t.Fatalf("expected ErrBufferFull, got %v", err)
+	}
+
+	rm := collectMetrics(t, reader)
+
+	got := sumOf(t, rm, "messaging.client.dropped.messages", attrOverflowPolicy, "reject")
+	if got != 1 {
+		t.Fatalf("expected 1 rejected drop, got %d", got)
+	}
+}
+
+func TestMetricsChannel_DepthGauge(t *testing.T) {
+	t.Parallel()
+
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+
+	_ = NewMetricsChannel("orders", messaging.NewPipelineChannel[int](),
+		WithMeterProvider(provider),
+		WithDepthFn(func() int { return 4 }),
+	)
+
+	rm := collectMetrics(t, reader)
+
+	m := findMetric(rm, "messaging.queue.depth")
+	if m == nil {
+		t.Fatal("expected messaging.queue.depth gauge")
+	}
+
+	gauge, ok := m.Data.(metricdata.Gauge[int64])
+	if !ok || len(gauge.DataPoints) != 1 || gauge.DataPoints[0].Value != 4 {
+		t.Fatalf("expected depth 4, got %+v", m.Data)
+	}
+}
+
+func TestNewMetricsErrorHandler(t *testing.T) {
+	t.Parallel()
+
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+
+	var forwarded int
+
+	hook := NewMetricsErrorHandler("orders",
+		func(_ context.Context, _ any, _ error) { forwarded++ },
+		WithMeterProvider(provider),
+		WithOverflowPolicy(messaging.OverflowDropOldest),
+	)
+
+	ctx := context.Background()
+	hook(ctx, nil, errors.Join(messaging.ErrOverflow, messaging.ErrDropped))
+	hook(ctx, nil, errors.Join(messaging.ErrExpired, messaging.ErrDropped))
+	hook(ctx, nil, messaging.ErrDropped)
+	hook(ctx, nil, errors.New("handler failed"))
+
+	if forwarded != 4 {
+		t.Fatalf("expected every error forwarded, got %d", forwarded)
+	}
+
+	rm := collectMetrics(t, reader)
+
+	got := sumOf(t, rm, "messaging.client.dropped.messages", "", "")
+	if got != 3 {
+		t.Fatalf("expected 3 drops, got %d", got)
+	}
+
+	got = sumOf(t, rm, "messaging.client.dropped.messages", attrOverflowPolicy, "drop_oldest")
+	if got != 1 {
+		t.Fatalf("expected 1 drop_oldest overflow, got %d", got)
+	}
+
+	for _, reason := range []string{dropReasonOverflow, dropReasonExpired, dropReasonOther} {
+		got = sumOf(t, rm, "messaging.client.dropped.messages", attrDropReason, reason)
+		if got != 1 {
+			t.Fatalf("expected 1 %s drop, got %d", reason, got)
+		}
+	}
+}
+
+func TestMetricsErrorHandler_WithQueueOverflow(t *testing.T) {
+	t.Parallel()
+
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+
+	hook := NewMetricsErrorHandler("orders", messaging.SilentErrorHandler,
+		WithMeterProvider(provider),
+		WithOverflowPolicy(messaging.OverflowDropNewest),
+	)
+
+	queue := messaging.NewQueueChannel[int]("orders",
+		messaging.WithBufferSize(1),
+		messaging.WithOverflowPolicy(messaging.OverflowDropNewest),
+		messaging.WithErrorHandler(hook),
+	)
+
+	for i := range 3 {
+		_ = queue.Send(context.Background(), messaging.NewMessage(i, nil))
+	}
+
+	rm := collectMetrics(t, reader)
+
+	got := sumOf(t, rm, "messaging.client.dropped.messages", attrOverflowPolicy, "drop_newest")
+	if got != 2 {
+		t.Fatalf("expected 2 drop_newest overflows, got %d", got)
+	}
+}
+
+func TestNewMetricsDeadLetterChannel(t *testing.T) {
+	t.Parallel()
+
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+
+	base := messaging.NewPipelineChannel[messaging.DeadLetter[int]]()
+	dlq := NewMetricsDeadLetterChannel("orders-dlq", base, WithMeterProvider(provider))
+
+	received := 0
+	_, _ = dlq.Subscribe(func(_ context.Context, _ messaging.Message[messaging.DeadLetter[int]]) error {
+		received++
+		return nil
+	})
+
+	letter := messaging.DeadLetter[int]{LastError: messaging.ErrExpired, FailedAt: time.Now()}
+
+	err := dlq.Send(context.Background(), messaging.NewMessage(letter, nil))
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	err = dlq.Send(nil, messaging.NewMessage(letter, nil)) //nolint:staticcheck
+	if !errors.Is(err, messaging.ErrContextNil) {
+		t.Fatalf("expected ErrContextNil, got %v", err)
+	}
+
+	if received != 1 {
+		t.Fatalf("expected 1 delivery, got %d", received)
+	}
+
+	rm := collectMetrics(t, reader)
+
+	got := sumOf(t, rm, "messaging.dlq.published", attrDestination, "orders-dlq")
+	if got != 1 {
+		t.Fatalf("expected 1 publication, got %d", got)
+	}
+}
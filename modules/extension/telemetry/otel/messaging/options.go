@@ -0,0 +1,159 @@
+package otelmessaging
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+// Option is a functional option for configuring Options. Every
+// constructor in this package accepts the same Option type; each reads
+// only the fields it cares about.
+type Option func(opts *Options)
+
+// Options holds the configuration shared by the decorators in this
+// package. The name field doubles as the instrumentation scope passed
+// to MeterProvider.Meter and TracerProvider.Tracer; system is the
+// messaging.system attribute. Fields a constructor does not care about
+// are ignored.
+type Options struct {
+	name   string
+	system string
+
+	meterProvider  metric.MeterProvider
+	depthFn        DepthFn
+	overflowPolicy string
+
+	tracerProvider trace.TracerProvider
+	propagator     propagation.TextMapPropagator
+
+	consumerGroup string
+}
+
+// NewOptions creates Options with safe defaults and applies the given
+// functional options. Defaults: the global meter / tracer / propagator
+// from OTel, this package's import path as instrumentation scope,
+// messaging.system "yarumo", no depth gauge, no overflow policy label.
+func NewOptions(opts ...Option) *Options {
+	options := &Options{
+		name:           defaultScopeName,
+		system:         defaultSystem,
+		meterProvider:  otel.GetMeterProvider(),
+		tracerProvider: otel.GetTracerProvider(),
+		propagator:     otel.GetTextMapPropagator(),
+	}
+
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	return options
+}
+
+// WithName overrides the instrumentation scope name passed to
+// MeterProvider.Meter and TracerProvider.Tracer. Empty values are
+// ignored, preserving the package-path default.
+func WithName(name string) Option {
+	return func(opts *Options) {
+		if name != "" {
+			opts.name = name
+		}
+	}
+}
+
+// WithSystem overrides the messaging.system attribute, e.g. "redis"
+// for a channel built by extension/messaging/redis. Empty values are
+// ignored, preserving "yarumo".
+func WithSystem(system string) Option {
+	return func(opts *Options) {
+		if system != "" {
+			opts.system = system
+		}
+	}
+}
+
+// WithMeterProvider overrides the meter provider used by the metrics
+// decorators. Nil values are ignored, preserving the global default.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return func(opts *Options) {
+		if provider != nil {
+			opts.meterProvider = provider
+		}
+	}
+}
+
+// WithDepthFn makes NewMetricsChannel register the
+// messaging.queue.depth observable gauge, sampled from fn on every
+// collection. Nil values are ignored (no gauge).
+func WithDepthFn(fn DepthFn) Option {
+	return func(opts *Options) {
+		if fn != nil {
+			opts.depthFn = fn
+		}
+	}
+}
+
+// WithOverflowPolicy labels the overflow drops counted by
+// NewMetricsErrorHandler with the policy the channel was built with
+// (messaging.overflow.policy). The hook cannot tell OverflowDropNewest
+// from OverflowDropOldest on its own. Unknown values are ignored.
+func WithOverflowPolicy(policy messaging.OverflowPolicy) Option {
+	return func(opts *Options) {
+		name := overflowPolicyName(policy)
+		if name != "" {
+			opts.overflowPolicy = name
+		}
+	}
+}
+
+// WithTracerProvider overrides the tracer provider used by the tracing
+// decorator. Nil values are ignored, preserving the global default.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(opts *Options) {
+		if provider != nil {
+			opts.tracerProvider = provider
+		}
+	}
+}
+
+// WithPropagator overrides the text-map propagator used to inject the
+// trace context into Headers.Custom and extract it back. Nil values
+// are ignored.
+func WithPropagator(propagator propagation.TextMapPropagator) Option {
+	return func(opts *Options) {
+		if propagator != nil {
+			opts.propagator = propagator
+		}
+	}
+}
+
+// withConsumerGroup labels the consumer spans and process metrics with
+// messaging.consumer.group.name. NewPatternSource sets it to the name
+// of the pattern subscribed to the decorated channel.
+func withConsumerGroup(group string) Option {
+	return func(opts *Options) {
+		if group != "" {
+			opts.consumerGroup = group
+		}
+	}
+}
+
+// overflowPolicyName returns the attribute value for policy, or empty
+// for an unknown value.
+func overflowPolicyName(policy messaging.OverflowPolicy) string {
+	switch policy {
+	case messaging.OverflowBlock:
+		return "block"
+	case messaging.OverflowDropNewest:
+		return "drop_newest"
+	case messaging.OverflowDropOldest:
+		return "drop_oldest"
+	case messaging.OverflowReject:
+		return "reject"
+	default:
+		return ""
+	}
+}
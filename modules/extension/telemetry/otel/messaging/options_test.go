@@ -0,0 +1,152 @@
+package otelmessaging
+
+import (
+	"testing"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+func TestNewOptions(t *testing.T) {
+	t.Parallel()
+
+	opts := NewOptions()
+
+	if opts.name != defaultScopeName {
+		t.Fatalf("expected scope %q, got %q", defaultScopeName, opts.name)
+	}
+
+	if opts.system != defaultSystem {
+		t.Fatalf("expected system %q, got %q", defaultSystem, opts.system)
+	}
+
+	if opts.meterProvider == nil || opts.tracerProvider == nil || opts.propagator == nil {
+		t.Fatal("expected global providers and propagator")
+	}
+
+	if opts.depthFn != nil {
+		t.Fatal("expected nil depthFn")
+	}
+
+	if opts.overflowPolicy != "" {
+		t.Fatalf("expected no overflow policy, got %q", opts.overflowPolicy)
+	}
+}
+
+func TestWithName(t *testing.T) {
+	t.Parallel()
+
+	opts := NewOptions(WithName("custom"), WithName(""))
+	if opts.name != "custom" {
+		t.Fatalf("expected custom, got %q", opts.name)
+	}
+}
+
+func TestWithSystem(t *testing.T) {
+	t.Parallel()
+
+	opts := NewOptions(WithSystem("redis"), WithSystem(""))
+	if opts.system != "redis" {
+		t.Fatalf("expected redis, got %q", opts.system)
+	}
+}
+
+func TestWithProviders(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid values applied", func(t *testing.T) {
+		t.Parallel()
+
+		meterProvider := metric.NewMeterProvider()
+		tracerProvider := trace.NewTracerProvider()
+		propagator := propagation.TraceContext{}
+
+		opts := NewOptions(
+			WithMeterProvider(meterProvider),
+			WithTracerProvider(tracerProvider),
+			WithPropagator(propagator),
+		)
+
+		if opts.meterProvider != meterProvider {
+			t.Fatal("expected meter provider to be set")
+		}
+
+		if opts.tracerProvider != tracerProvider {
+			t.Fatal("expected tracer provider to be set")
+		}
+
+		if opts.propagator != propagator {
+			t.Fatal("expected propagator to be set")
+		}
+	})
+
+	t.Run("nil ignored", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithMeterProvider(nil), WithTracerProvider(nil), WithPropagator(nil))
+		if opts.meterProvider == nil || opts.tracerProvider == nil || opts.propagator == nil {
+			t.Fatal("expected defaults to be preserved")
+		}
+	})
+}
+
+func TestWithDepthFn(t *testing.T) {
+	t.Parallel()
+
+	opts := NewOptions(WithDepthFn(func() int { return 3 }), WithDepthFn(nil))
+	if opts.depthFn == nil || opts.depthFn() != 3 {
+		t.Fatal("expected depthFn to be set")
+	}
+}
+
+func TestWithOverflowPolicy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("block", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithOverflowPolicy(messaging.OverflowBlock))
+		if opts.overflowPolicy != "block" {
+			t.Fatalf("expected block, got %q", opts.overflowPolicy)
+		}
+	})
+
+	t.Run("drop newest", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithOverflowPolicy(messaging.OverflowDropNewest))
+		if opts.overflowPolicy != "drop_newest" {
+			t.Fatalf("expected drop_newest, got %q", opts.overflowPolicy)
+		}
+	})
+
+	t.Run("drop oldest", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithOverflowPolicy(messaging.OverflowDropOldest))
+		if opts.overflowPolicy != "drop_oldest" {
+			t.Fatalf("expected drop_oldest, got %q", opts.overflowPolicy)
+		}
+	})
+
+	t.Run("reject", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithOverflowPolicy(messaging.OverflowReject))
+		if opts.overflowPolicy != "reject" {
+			t.Fatalf("expected reject, got %q", opts.overflowPolicy)
+		}
+	})
+
+	t.Run("unknown ignored", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithOverflowPolicy(messaging.OverflowPolicy(99)))
+		if opts.overflowPolicy != "" {
+			t.Fatalf("expected unknown policy ignored, got %q", opts.overflowPolicy)
+		}
+	})
+}
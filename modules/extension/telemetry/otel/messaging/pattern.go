@@ -0,0 +1,140 @@
+package otelmessaging
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+// NewPatternSource wraps src, the channel a messaging pattern
+// subscribes to, so that the pattern's handler is traced and measured:
+// every message the pattern takes from src runs inside a "process
+// <destination>" consumer span and is counted on the process metrics of
+// NewMetricsChannel, both labelled with messaging.consumer.group.name
+// set to pattern. Because the handler ctx carries the consumer span,
+// the sends the pattern makes on a channel decorated with
+// NewTracingChannel become its children.
+//
+// Pass the result as the pattern's source channel, e.g.
+// filter.NewFilter("paid", NewPatternSource("paid", "orders", orders),
+// dst, predicate). pattern and destination must be non-empty. The
+// decorators are stacked as NewTracingChannel over NewMetricsChannel
+// over src and read the same Options.
+func NewPatternSource[T any](pattern string, destination string, src messaging.Channel[T], opts ...Option) messaging.Channel[T] {
+	cassert.NotEmpty(pattern, "pattern is empty")
+	cassert.NotEmpty(destination, "destination is empty")
+	cassert.NotNil(src, "source channel is nil")
+
+	opts = append(opts[:len(opts):len(opts)], withConsumerGroup(pattern))
+
+	return NewTracingChannel(destination, NewMetricsChannel(destination, src, opts...), opts...)
+}
+
+// patternCounter maps an observable counter to the Stats field it
+// reports.
+type patternCounter struct {
+	counter metric.Int64ObservableCounter
+	value   func(stats messaging.Stats) uint64
+}
+
+// NewPatternMetrics exports the runtime statistics of a messaging
+// pattern (or any messaging.Inspectable) as observable instruments,
+// sampled from component.Stats() on every collection:
+//
+//   - messaging.pattern.delivered.messages (counter): messages taken
+//     from the source channel.
+//   - messaging.pattern.sent.messages (counter): messages forwarded.
+//   - messaging.pattern.failed.messages (counter): deliveries that
+//     failed.
+//   - messaging.pattern.dropped.messages (counter): messages discarded
+//     on purpose (filter rejections, expirations).
+//   - messaging.pattern.dead_lettered.messages (counter): dead letters
+//     published.
+//   - messaging.pattern.in_flight (gauge): deliveries being processed.
+//
+// Every observation carries messaging.system and
+// messaging.consumer.group.name set to pattern, which must be
+// non-empty. Only the meterProvider + name + system fields of the
+// shared Options are consulted. Instrument construction failures are
+// reported through otel.Handle and the failed instrument is skipped.
+// The returned Cancel unregisters the callback; call it when the
+// pattern is stopped.
+func NewPatternMetrics(pattern string, component messaging.Inspectable, opts ...Option) messaging.Cancel {
+	cassert.NotEmpty(pattern, "pattern is empty")
+	cassert.NotNil(component, "component is nil")
+
+	options := NewOptions(opts...)
+	meter := options.meterProvider.Meter(options.name)
+	attrs := metric.WithAttributes(
+		attribute.String(attrSystem, options.system),
+		attribute.String(attrConsumerGroup, pattern),
+	)
+
+	counters := make([]patternCounter, 0, 5)
+	instruments := make([]metric.Observable, 0, 6)
+
+	add := func(name, description string, value func(stats messaging.Stats) uint64) {
+		counter, err := meter.Int64ObservableCounter(name, metric.WithDescription(description), metric.WithUnit("{message}"))
+		if err != nil {
+			reportInstrumentError(name, err)
+
+			return
+		}
+
+		counters = append(counters, patternCounter{counter: counter, value: value})
+		instruments = append(instruments, counter)
+	}
+
+	add("messaging.pattern.delivered.messages", "Number of messages the pattern took from its source channel.",
+		func(stats messaging.Stats) uint64 { return stats.Delivered })
+	add("messaging.pattern.sent.messages", "Number of messages the pattern forwarded.",
+		func(stats messaging.Stats) uint64 { return stats.Sent })
+	add("messaging.pattern.failed.messages", "Number of deliveries the pattern failed to process.",
+		func(stats messaging.Stats) uint64 { return stats.Failed })
+	add("messaging.pattern.dropped.messages", "Number of messages the pattern discarded.",
+		func(stats messaging.Stats) uint64 { return stats.Dropped })
+	add("messaging.pattern.dead_lettered.messages", "Number of dead letters the pattern published.",
+		func(stats messaging.Stats) uint64 { return stats.DeadLettered })
+
+	inFlight, err := meter.Int64ObservableGauge("messaging.pattern.in_flight",
+		metric.WithDescription("Number of deliveries the pattern is processing."),
+		metric.WithUnit("{message}"),
+	)
+	if err != nil {
+		reportInstrumentError("messaging.pattern.in_flight", err)
+		inFlight = nil
+	} else {
+		instruments = append(instruments, inFlight)
+	}
+
+	if len(instruments) == 0 {
+		return func() {}
+	}
+
+	registration, err := meter.RegisterCallback(func(_ context.Context, observer metric.Observer) error {
+		stats := component.Stats()
+
+		for _, c := range counters {
+			observer.ObserveInt64(c.counter, int64(c.value(stats)), attrs) //nolint:gosec // cumulative counts stay far below MaxInt64
+		}
+
+		if inFlight != nil {
+			observer.ObserveInt64(inFlight, int64(stats.InFlight), attrs)
+		}
+
+		return nil
+	}, instruments...)
+	if err != nil {
+		reportInstrumentError("messaging.pattern callback", err)
+
+		return func() {}
+	}
+
+	return func() {
+		_ = registration.Unregister()
+	}
+}
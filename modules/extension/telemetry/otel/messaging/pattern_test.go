@@ -0,0 +1,188 @@
+package otelmessaging
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/guidomantilla/yarumo/messaging"
+	"github.com/guidomantilla/yarumo/messaging/patterns/routers/filter"
+)
+
+// positive keeps the positive payloads.
+func positive(_ context.Context, msg messaging.Message[int]) (bool, error) {
+	return msg.Payload > 0, nil
+}
+
+// startFilter builds a Message Filter from src to dst, starts it and
+// stops it on cleanup.
+func startFilter(t *testing.T, src, dst messaging.Channel[int]) filter.Filter[int] {
+	t.Helper()
+
+	f := filter.NewFilter("positive", src, dst, positive)
+
+	err := f.Start(context.Background())
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	t.Cleanup(func() { _ = f.Stop(context.Background()) })
+
+	return f
+}
+
+func TestNewPatternSource(t *testing.T) {
+	t.Parallel()
+
+	recorder := tracetest.NewSpanRecorder()
+	tracerProvider := trace.NewTracerProvider(trace.WithSpanProcessor(recorder))
+	reader := metric.NewManualReader()
+	meterProvider := metric.NewMeterProvider(metric.WithReader(reader))
+
+	opts := []Option{
+		WithTracerProvider(tracerProvider),
+		WithMeterProvider(meterProvider),
+		WithPropagator(propagation.TraceContext{}),
+	}
+
+	orders := messaging.NewPipelineChannel[int]()
+	paid := messaging.NewPipelineChannel[int]()
+
+	_, _ = paid.Subscribe(func(_ context.Context, _ messaging.Message[int]) error { return nil })
+
+	startFilter(t, NewPatternSource("positive", "orders", orders, opts...), NewTracingChannel("paid", paid, opts...))
+
+	err := orders.Send(context.Background(), messaging.NewMessage(1, nil))
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	process := findSpan(recorder, "process orders")
+	if process == nil {
+		t.Fatal("expected a process span for the pattern handler")
+	}
+
+	if got := findSpanAttr(process, attrConsumerGroup); got != "positive" {
+		t.Fatalf("expected consumer group positive, got %q", got)
+	}
+
+	send := findSpan(recorder, "send paid")
+	if send == nil {
+		t.Fatal("expected a send span for the forwarded message")
+	}
+
+	if send.Parent().SpanID() != process.SpanContext().SpanID() {
+		t.Fatal("expected the forward to be a child of the process span")
+	}
+
+	if got := findSpanAttr(send, attrConsumerGroup); got != "" {
+		t.Fatalf("expected no consumer group on the send span, got %q", got)
+	}
+
+	rm := collectMetrics(t, reader)
+
+	got := sumOf(t, rm, "messaging.client.consumed.messages", attrConsumerGroup, "positive")
+	if got != 1 {
+		t.Fatalf("expected 1 consumed message labelled with the pattern, got %d", got)
+	}
+}
+
+func TestNewPatternMetrics(t *testing.T) {
+	t.Parallel()
+
+	t.Run("reports the pattern stats", func(t *testing.T) {
+		t.Parallel()
+
+		reader := metric.NewManualReader()
+		provider := metric.NewMeterProvider(metric.WithReader(reader))
+
+		orders := messaging.NewPipelineChannel[int]()
+		paid := messaging.NewPipelineChannel[int]()
+		_, _ = paid.Subscribe(func(_ context.Context, _ messaging.Message[int]) error { return nil })
+
+		f := startFilter(t, orders, paid)
+
+		cancel := NewPatternMetrics("positive", f, WithMeterProvider(provider))
+		defer cancel()
+
+		_ = orders.Send(context.Background(), messaging.NewMessage(1, nil))
+		_ = orders.Send(context.Background(), messaging.NewMessage(-1, nil))
+		_ = orders.Send(context.Background(), messaging.NewMessage(2, nil))
+
+		rm := collectMetrics(t, reader)
+
+		got := sumOf(t, rm, "messaging.pattern.delivered.messages", attrConsumerGroup, "positive")
+		if got != 3 {
+			t.Fatalf("expected 3 delivered, got %d", got)
+		}
+
+		got = sumOf(t, rm, "messaging.pattern.sent.messages", attrConsumerGroup, "positive")
+		if got != 2 {
+			t.Fatalf("expected 2 sent, got %d", got)
+		}
+
+		got = sumOf(t, rm, "messaging.pattern.dropped.messages", attrConsumerGroup, "positive")
+		if got != 1 {
+			t.Fatalf("expected 1 dropped, got %d", got)
+		}
+
+		if findMetric(rm, "messaging.pattern.in_flight") == nil {
+			t.Fatal("expected the in-flight gauge")
+		}
+	})
+
+	t.Run("cancel unregisters the callback", func(t *testing.T) {
+		t.Parallel()
+
+		reader := metric.NewManualReader()
+		provider := metric.NewMeterProvider(metric.WithReader(reader))
+
+		calls := 0
+		component := inspectableFunc(func() messaging.Stats {
+			calls++
+
+			return messaging.Stats{Delivered: 1}
+		})
+
+		cancel := NewPatternMetrics("positive", component, WithMeterProvider(provider))
+
+		_ = collectMetrics(t, reader)
+		cancel()
+		_ = collectMetrics(t, reader)
+
+		if calls != 1 {
+			t.Fatalf("expected Stats sampled once before cancel, got %d", calls)
+		}
+	})
+}
+
+func TestPatternMetrics_InstrumentErrors(t *testing.T) { //nolint:paralleltest // swaps the global OTel ErrorHandler
+	errs := installErrorHandler(t)
+
+	cancel := NewPatternMetrics("positive", inspectableFunc(func() messaging.Stats { return messaging.Stats{} }),
+		WithMeterProvider(failingMeterProvider{}))
+	cancel()
+
+	// 5 counters and the in-flight gauge.
+	got := errs()
+	if len(got) != 6 {
+		t.Fatalf("expected 6 reported instrument errors, got %d: %v", len(got), got)
+	}
+
+	for _, err := range got {
+		if !errors.Is(err, errInstrument) {
+			t.Fatalf("expected errInstrument, got %v", err)
+		}
+	}
+}
+
+// inspectableFunc adapts a func to messaging.Inspectable.
+type inspectableFunc func() messaging.Stats
+
+func (f inspectableFunc) Stats() messaging.Stats {
+	return f()
+}
@@ -0,0 +1,81 @@
+package otelmessaging
+
+import (
+	"context"
+	"maps"
+
+	"go.opentelemetry.io/otel/propagation"
+
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+var _ propagation.TextMapCarrier = HeadersCarrier(nil)
+
+// HeadersCarrier adapts a Headers.Custom map to propagation.
+// TextMapCarrier. Only string values are visible to Get and Keys, so
+// entries that survived a codec round trip (always strings for the
+// propagation fields) are read back transparently.
+type HeadersCarrier map[string]any
+
+// Get returns the string value stored under key, or empty.
+func (c HeadersCarrier) Get(key string) string {
+	value, ok := c[key].(string)
+	if !ok {
+		return ""
+	}
+
+	return value
+}
+
+// Set stores value under key.
+func (c HeadersCarrier) Set(key string, value string) {
+	c[key] = value
+}
+
+// Keys lists the keys holding string values.
+func (c HeadersCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+
+	for key, value := range c {
+		_, ok := value.(string)
+		if ok {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
+// InjectHeaders returns headers with the trace context of ctx injected
+// into Custom by the configured propagator (WithPropagator, the global
+// one by default). Custom is copied, never mutated in place, so the
+// caller's map stays untouched; it remains nil when there was nothing
+// to inject.
+func InjectHeaders(ctx context.Context, headers messaging.Headers, opts ...Option) messaging.Headers {
+	options := NewOptions(opts...)
+
+	return injectHeaders(ctx, options.propagator, headers)
+}
+
+// ExtractHeaders returns ctx enriched with the trace context carried
+// in headers.Custom by the configured propagator (WithPropagator, the
+// global one by default).
+func ExtractHeaders(ctx context.Context, headers messaging.Headers, opts ...Option) context.Context {
+	options := NewOptions(opts...)
+
+	return options.propagator.Extract(ctx, HeadersCarrier(headers.Custom))
+}
+
+// injectHeaders is the body of InjectHeaders for a resolved propagator.
+func injectHeaders(ctx context.Context, propagator propagation.TextMapPropagator, headers messaging.Headers) messaging.Headers {
+	custom := make(map[string]any, len(headers.Custom)+len(propagator.Fields()))
+	maps.Copy(custom, headers.Custom)
+
+	propagator.Inject(ctx, HeadersCarrier(custom))
+
+	if len(custom) > 0 {
+		headers.Custom = custom
+	}
+
+	return headers
+}
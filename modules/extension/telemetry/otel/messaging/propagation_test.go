@@ -0,0 +1,80 @@
+package otelmessaging
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/trace"
+	otrace "go.opentelemetry.io/otel/trace"
+
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+func TestHeadersCarrier(t *testing.T) {
+	t.Parallel()
+
+	carrier := HeadersCarrier{"number": 7}
+	carrier.Set("traceparent", "value")
+
+	if carrier.Get("traceparent") != "value" {
+		t.Fatalf("expected value, got %q", carrier.Get("traceparent"))
+	}
+
+	if carrier.Get("number") != "" || carrier.Get("missing") != "" {
+		t.Fatal("expected non-string and missing keys to read empty")
+	}
+
+	if !slices.Equal(carrier.Keys(), []string{"traceparent"}) {
+		t.Fatalf("expected only string keys, got %v", carrier.Keys())
+	}
+}
+
+func TestInjectAndExtractHeaders(t *testing.T) {
+	t.Parallel()
+
+	provider := trace.NewTracerProvider()
+	propagator := WithPropagator(propagation.TraceContext{})
+
+	t.Run("round trip through Custom", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, span := provider.Tracer("test").Start(context.Background(), "producer")
+		defer span.End()
+
+		original := map[string]any{"tenant": "acme"}
+		headers := InjectHeaders(ctx, messaging.Headers{Custom: original}, propagator)
+
+		_, ok := headers.Custom["traceparent"]
+		if !ok {
+			t.Fatalf("expected traceparent in Custom, got %v", headers.Custom)
+		}
+
+		if headers.Custom["tenant"] != "acme" {
+			t.Fatal("expected existing Custom entries preserved")
+		}
+
+		if len(original) != 1 {
+			t.Fatalf("expected caller map untouched, got %v", original)
+		}
+
+		extracted := otrace.SpanContextFromContext(ExtractHeaders(context.Background(), headers, propagator))
+		if extracted.TraceID() != span.SpanContext().TraceID() || extracted.SpanID() != span.SpanContext().SpanID() {
+			t.Fatalf("expected extracted span context %v, got %v", span.SpanContext(), extracted)
+		}
+
+		if !extracted.IsRemote() {
+			t.Fatal("expected extracted span context to be remote")
+		}
+	})
+
+	t.Run("nothing to inject keeps Custom nil", func(t *testing.T) {
+		t.Parallel()
+
+		headers := InjectHeaders(context.Background(), messaging.Headers{}, propagator)
+		if headers.Custom != nil {
+			t.Fatalf("expected nil Custom, got %v", headers.Custom)
+		}
+	})
+}
@@ -0,0 +1,170 @@
+package otelmessaging
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+// tracingChannel wraps a base Channel, opens a producer-kind span per
+// Send and a consumer-kind span per handler invocation, and carries
+// the trace context from one to the other through Headers.Custom.
+type tracingChannel[T any] struct {
+	base          messaging.Channel[T]
+	destination   string
+	system        string
+	consumerGroup string
+	tracer        trace.Tracer
+	propagator    propagation.TextMapPropagator
+}
+
+// NewTracingChannel wraps base with a Channel that traces every Send
+// and every handler subscribed through it. destination names the
+// channel in span names ("send <destination>", "process
+// <destination>") and in the messaging.destination.name attribute; it
+// must be non-empty.
+//
+// Send starts a producer span and injects its context into a copy of
+// Headers.Custom before delegating. Each handler runs inside a
+// consumer span whose parent is the context extracted from
+// Headers.Custom, falling back to the span already in the handler ctx.
+// Handler errors and panics mark the span as Error; panics are
+// re-raised for the channel to recover.
+//
+// Only the tracerProvider + name + system + propagator fields of the
+// shared Options are consulted. The returned Channel is safe for
+// concurrent use as long as base is.
+func NewTracingChannel[T any](destination string, base messaging.Channel[T], opts ...Option) messaging.Channel[T] {
+	cassert.NotEmpty(destination, "destination is empty")
+	cassert.NotNil(base, "base channel is nil")
+
+	options := NewOptions(opts...)
+
+	return &tracingChannel[T]{
+		base:          base,
+		destination:   destination,
+		system:        options.system,
+		consumerGroup: options.consumerGroup,
+		tracer:        options.tracerProvider.Tracer(options.name),
+		propagator:    options.propagator,
+	}
+}
+
+// Send starts a producer span, injects its context into msg and
+// delegates to base. A nil ctx is passed straight to base so it can
+// reject it.
+func (c *tracingChannel[T]) Send(ctx context.Context, msg messaging.Message[T]) error {
+	cassert.NotNil(c, "tracing channel is nil")
+
+	if ctx == nil {
+		return c.base.Send(ctx, msg)
+	}
+
+	ctx, span := c.tracer.Start(ctx, operationSend+" "+c.destination,
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(c.attributes(operationSend, msg.Headers)...),
+	)
+	defer span.End()
+
+	msg.Headers = injectHeaders(ctx, c.propagator, msg.Headers)
+
+	err := c.base.Send(ctx, msg)
+	if err != nil {
+		recordError(span, err)
+	}
+
+	return err
+}
+
+// Subscribe registers handler on base wrapped in a consumer span.
+func (c *tracingChannel[T]) Subscribe(handler messaging.Handler[T]) (messaging.Cancel, error) {
+	cassert.NotNil(c, "tracing channel is nil")
+
+	if handler == nil {
+		return c.base.Subscribe(nil)
+	}
+
+	return c.base.Subscribe(c.wrap(handler))
+}
+
+// wrap returns handler decorated with a consumer span.
+func (c *tracingChannel[T]) wrap(handler messaging.Handler[T]) messaging.Handler[T] {
+	return func(ctx context.Context, msg messaging.Message[T]) error {
+		parent := c.propagator.Extract(ctx, HeadersCarrier(msg.Headers.Custom))
+
+		attrs := c.attributes(operationProcess, msg.Headers)
+		if msg.Headers.DeliveryCount > 0 {
+			attrs = append(attrs, attribute.Int(attrDeliveryCount, msg.Headers.DeliveryCount))
+		}
+
+		if c.consumerGroup != "" {
+			attrs = append(attrs, attribute.String(attrConsumerGroup, c.consumerGroup))
+		}
+
+		spanCtx, span := c.tracer.Start(parent, operationProcess+" "+c.destination,
+			trace.WithSpanKind(trace.SpanKindConsumer),
+			trace.WithAttributes(attrs...),
+		)
+		defer span.End()
+
+		defer func() {
+			r := recover()
+			if r == nil {
+				return
+			}
+
+			span.SetAttributes(attribute.String(attrErrorType, "panic"))
+			span.SetStatus(codes.Error, fmt.Sprint(r))
+
+			panic(r)
+		}()
+
+		err := handler(spanCtx, msg)
+		if err != nil {
+			recordError(span, err)
+		}
+
+		return err
+	}
+}
+
+// attributes returns the semantic-convention attributes shared by the
+// producer and consumer spans.
+func (c *tracingChannel[T]) attributes(operation string, headers messaging.Headers) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		attribute.String(attrSystem, c.system),
+		attribute.String(attrDestination, c.destination),
+		attribute.String(attrOperationName, operation),
+		attribute.String(attrOperationType, operation),
+	}
+
+	if headers.MessageID != "" {
+		attrs = append(attrs, attribute.String(attrMessageID, headers.MessageID))
+	}
+
+	if headers.CorrelationID != "" {
+		attrs = append(attrs, attribute.String(attrConversationID, headers.CorrelationID))
+	}
+
+	return attrs
+}
+
+// recordError marks span as failed with err.
+func recordError(span trace.Span, err error) {
+	span.SetAttributes(attribute.String(attrErrorType, errorType(err)))
+	span.SetStatus(codes.Error, err.Error())
+	span.RecordError(err)
+}
+
+// errorType returns the low-cardinality error.type value for err: its
+// dynamic type name, as recommended by the semantic conventions.
+func errorType(err error) string {
+	return fmt.Sprintf("%T", err)
+}
@@ -0,0 +1,233 @@
+package otelmessaging
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	otrace "go.opentelemetry.io/otel/trace"
+
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+// findSpanAttr returns the string value of the attribute matching key on
+// the given snapshot, or "" when missing.
+func findSpanAttr(span trace.ReadOnlySpan, key string) string {
+	for _, kv := range span.Attributes() {
+		if string(kv.Key) == key {
+			return kv.Value.Emit()
+		}
+	}
+	return ""
+}
+
+// findSpan returns the ended span named name, or nil.
+func findSpan(recorder *tracetest.SpanRecorder, name string) trace.ReadOnlySpan {
+	for _, span := range recorder.Ended() {
+		if span.Name() == name {
+			return span
+		}
+	}
+	return nil
+}
+
+// newTracingPipeline returns a recorder and a tracing decorator over a
+// PipelineChannel (synchronous, so spans are ended on Send return).
+func newTracingPipeline(t *testing.T) (*tracetest.SpanRecorder, messaging.Channel[string]) {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := trace.NewTracerProvider(trace.WithSpanProcessor(recorder))
+
+	ch := NewTracingChannel("orders", messaging.NewPipelineChannel[string](),
+		WithTracerProvider(provider),
+		WithPropagator(propagation.TraceContext{}),
+	)
+
+	return recorder, ch
+}
+
+func TestNewTracingChannel(t *testing.T) {
+	t.Parallel()
+
+	ch := NewTracingChannel("orders", messaging.NewPipelineChannel[int]())
+	if ch == nil {
+		t.Fatal("expected non-nil channel")
+	}
+}
+
+func TestTracingChannel_ProducerAndConsumerSpans(t *testing.T) {
+	t.Parallel()
+
+	recorder, ch := newTracingPipeline(t)
+
+	var received messaging.Message[string]
+
+	_, _ = ch.Subscribe(func(ctx context.Context, msg messaging.Message[string]) error {
+		received = msg
+
+		if !otrace.SpanContextFromContext(ctx).IsValid() {
+			t.Error("expected the handler ctx to carry the consumer span")
+		}
+
+		return nil
+	})
+
+	msg := messaging.NewMessage("hello", nil)
+	msg.Headers.MessageID = "m-1"
+	msg.Headers.CorrelationID = "c-1"
+
+	err := ch.Send(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	_, ok := received.Headers.Custom["traceparent"]
+	if !ok {
+		t.Fatalf("expected traceparent injected, got %v", received.Headers.Custom)
+	}
+
+	if msg.Headers.Custom != nil {
+		t.Fatal("expected the caller's message untouched")
+	}
+
+	producer := findSpan(recorder, "send orders")
+	consumer := findSpan(recorder, "process orders")
+
+	if producer == nil || consumer == nil {
+		t.Fatalf("expected producer and consumer spans, got %d spans", len(recorder.Ended()))
+	}
+
+	if producer.SpanKind() != otrace.SpanKindProducer || consumer.SpanKind() != otrace.SpanKindConsumer {
+		t.Fatalf("unexpected kinds %v / %v", producer.SpanKind(), consumer.SpanKind())
+	}
+
+	if consumer.Parent().SpanID() != producer.SpanContext().SpanID() {
+		t.Fatal("expected the consumer span to be a child of the producer span")
+	}
+
+	for key, want := range map[string]string{
+		attrSystem:         defaultSystem,
+		attrDestination:    "orders",
+		attrOperationName:  operationSend,
+		attrMessageID:      "m-1",
+		attrConversationID: "c-1",
+	} {
+		got := findSpanAttr(producer, key)
+		if got != want {
+			t.Fatalf("producer %s = %q, want %q", key, got, want)
+		}
+	}
+
+	if findSpanAttr(consumer, attrOperationType) != operationProcess {
+		t.Fatal("expected process operation type on the consumer span")
+	}
+}
+
+func TestTracingChannel_ExtractsAcrossSerialization(t *testing.T) {
+	t.Parallel()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := trace.NewTracerProvider(trace.WithSpanProcessor(recorder))
+
+	ch := NewTracingChannel("orders", messaging.NewPipelineChannel[string](),
+		WithTracerProvider(provider),
+		WithPropagator(propagation.TraceContext{}),
+	)
+
+	_, _ = ch.Subscribe(func(_ context.Context, _ messaging.Message[string]) error { return nil })
+
+	// Simulate a message produced in another process: the trace
+	// context only travels inside Headers.Custom, the ctx is empty.
+	remoteCtx, remote := provider.Tracer("remote").Start(context.Background(), "remote send")
+	remote.End()
+
+	headers := InjectHeaders(remoteCtx, messaging.Headers{}, WithPropagator(propagation.TraceContext{}))
+
+	handler := ch.(*tracingChannel[string]).wrap(func(_ context.Context, _ messaging.Message[string]) error { return nil })
+
+	err := handler(context.Background(), messaging.Message[string]{Payload: "x", Headers: headers})
+	if err != nil {
+		t.Fatalf("handler: %v", err)
+	}
+
+	consumer := findSpan(recorder, "process orders")
+	if consumer == nil {
+		t.Fatal("expected consumer span")
+	}
+
+	if consumer.Parent().SpanID() != remote.SpanContext().SpanID() || !consumer.Parent().IsRemote() {
+		t.Fatal("expected the consumer span to be parented to the remote producer")
+	}
+}
+
+func TestTracingChannel_Errors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("handler error marks both spans", func(t *testing.T) {
+		t.Parallel()
+
+		recorder, ch := newTracingPipeline(t)
+		boom := errors.New("boom")
+
+		_, _ = ch.Subscribe(func(_ context.Context, _ messaging.Message[string]) error { return boom })
+
+		err := ch.Send(context.Background(), messaging.NewMessage("x", nil))
+		if !errors.Is(err, boom) {
+			t.Fatalf("expected boom, got %v", err)
+		}
+
+		for _, name := range []string{"send orders", "process orders"} {
+			span := findSpan(recorder, name)
+			if span == nil || span.Status().Code != codes.Error {
+				t.Fatalf("expected %s to be marked Error", name)
+			}
+
+			if findSpanAttr(span, attrErrorType) == "" {
+				t.Fatalf("expected error.type on %s", name)
+			}
+		}
+	})
+
+	t.Run("handler panic marks the consumer span and re-panics", func(t *testing.T) {
+		t.Parallel()
+
+		recorder, ch := newTracingPipeline(t)
+
+		_, _ = ch.Subscribe(func(_ context.Context, _ messaging.Message[string]) error { panic("kaboom") })
+
+		err := ch.Send(context.Background(), messaging.NewMessage("x", nil))
+		if !errors.Is(err, messaging.ErrHandlerPanic) {
+			t.Fatalf("expected ErrHandlerPanic from the channel, got %v", err)
+		}
+
+		consumer := findSpan(recorder, "process orders")
+		if consumer == nil || consumer.Status().Code != codes.Error || findSpanAttr(consumer, attrErrorType) != "panic" {
+			t.Fatal("expected the consumer span marked as a panic")
+		}
+	})
+
+	t.Run("nil ctx and nil handler delegated", func(t *testing.T) {
+		t.Parallel()
+
+		recorder, ch := newTracingPipeline(t)
+
+		err := ch.Send(nil, messaging.NewMessage("x", nil)) //nolint:staticcheck
+		if !errors.Is(err, messaging.ErrContextNil) {
+			t.Fatalf("expected ErrContextNil, got %v", err)
+		}
+
+		_, err = ch.Subscribe(nil)
+		if !errors.Is(err, messaging.ErrHandlerNil) {
+			t.Fatalf("expected ErrHandlerNil, got %v", err)
+		}
+
+		if len(recorder.Ended()) != 0 {
+			t.Fatal("expected no spans")
+		}
+	})
+}
@@ -0,0 +1,85 @@
+// Package otelmessaging provides OpenTelemetry instrumentation for the
+// messaging module: channel decorators that emit spans and metrics
+// following the OTel messaging semantic conventions, and a text-map
+// carrier that moves trace context through Headers.Custom.
+//
+// The package exposes:
+//
+//   - NewTracingChannel: producer-kind span per Send ("send <dest>")
+//     and consumer-kind span per handler invocation ("process <dest>").
+//     Send injects the trace context into Headers.Custom; the handler
+//     side extracts it, so the consumer span is parented to the
+//     producer span even after the message crossed a serializing
+//     driver (codec, outbox, Redis Streams).
+//   - NewMetricsChannel: sent / consumed counters, send and process
+//     duration histograms, dispatch latency (Headers.Timestamp to
+//     handler start), handler panics, rejected sends and, with
+//     WithDepthFn, an observable queue depth gauge.
+//   - NewMetricsErrorHandler: an ErrorHandler decorator counting the
+//     messages a channel drops (overflow under OverflowDropNewest /
+//     OverflowDropOldest, expiration), labelled with WithOverflowPolicy.
+//   - NewMetricsDeadLetterChannel: counts DLQ publications.
+//   - HeadersCarrier, InjectHeaders, ExtractHeaders: the propagation
+//     primitives the decorators use, for drivers and custom endpoints.
+//
+// The decorators only intercept Send and Subscribe. Lifecycle stays on
+// the decorated channel: keep passing the base TopicChannel or
+// QueueChannel to lifecycle.Build. Handlers must be subscribed through
+// the decorator to be instrumented. Stack them as tracing → metrics →
+// base so the metrics are recorded inside the consumer span:
+//
+//	orders := messaging.NewQueueChannel[Order]("orders",
+//	    messaging.WithErrorHandler(otelmessaging.NewMetricsErrorHandler("orders", nil,
+//	        otelmessaging.WithOverflowPolicy(messaging.OverflowDropOldest))),
+//	    messaging.WithOverflowPolicy(messaging.OverflowDropOldest),
+//	)
+//	ch := otelmessaging.NewTracingChannel("orders",
+//	    otelmessaging.NewMetricsChannel("orders", orders))
+//
+// Messaging patterns (filters, routers, transformers, …) subscribe to
+// and send on the channels they are built with, so they are
+// instrumented through them:
+//
+//   - NewPatternSource decorates the channel a pattern subscribes to:
+//     the pattern's handler runs inside a consumer span and is counted
+//     on the process metrics, both labelled with
+//     messaging.consumer.group.name set to the pattern name. Sends the
+//     pattern makes on a NewTracingChannel become children of that
+//     span.
+//   - NewPatternMetrics exports the pattern's messaging.Stats
+//     (delivered, sent, failed, dropped, dead-lettered, in flight) as
+//     observable instruments under messaging.pattern.*.
+//
+// For a Message Filter from orders to paid:
+//
+//	src := otelmessaging.NewPatternSource("paid", "orders", orders)
+//	dst := otelmessaging.NewTracingChannel("paid", paid)
+//	f := filter.NewFilter("paid", src, dst, isPaid)
+//	cancel := otelmessaging.NewPatternMetrics("paid", f)
+//
+// Instrument construction failures are reported through otel.Handle
+// and replaced by no-op instruments, so a broken meter never breaks
+// the channel.
+//
+// Defaults pull the global meter and tracer providers and the global
+// propagator (`otel.GetMeterProvider()`, `otel.GetTracerProvider()`,
+// `otel.GetTextMapPropagator()`). Tests and alternative pipelines
+// override them via WithMeterProvider / WithTracerProvider /
+// WithPropagator.
+package otelmessaging
+
+import (
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+var (
+	_ messaging.Channel[any]                       = (*tracingChannel[any])(nil)
+	_ messaging.Channel[any]                       = (*metricsChannel[any])(nil)
+	_ messaging.Channel[messaging.DeadLetter[any]] = (*deadLetterChannel[any])(nil)
+)
+
+// DepthFn reports the number of messages currently buffered by a
+// channel. Installed via WithDepthFn and sampled on every metric
+// collection. Implementations must be cheap and safe for concurrent
+// use.
+type DepthFn func() int
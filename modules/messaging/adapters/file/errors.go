@@ -0,0 +1,71 @@
+package file
+
+import (
+	"errors"
+	"fmt"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+	cerrs "github.com/guidomantilla/yarumo/core/common/errs"
+)
+
+// AdapterType is the error domain identifier for file adapter
+// operations.
+const AdapterType = "file-adapter"
+
+var (
+	_ error = (*Error)(nil)
+)
+
+// Sentinel errors for file adapter operations.
+var (
+	// ErrAdapterFailed is the top-level sentinel embedded in every
+	// adapter-domain Error returned by ErrAdapter.
+	ErrAdapterFailed = errors.New("file adapter failed")
+	// ErrDirFailed indicates that a directory the adapter needs could
+	// not be found or created.
+	ErrDirFailed = errors.New("preparing directory failed")
+	// ErrScanFailed indicates that listing the inbound directory
+	// failed.
+	ErrScanFailed = errors.New("scanning directory failed")
+	// ErrReadFailed indicates that reading an inbound file failed.
+	ErrReadFailed = errors.New("reading file failed")
+	// ErrMoveFailed indicates that moving a file to the done or error
+	// folder failed.
+	ErrMoveFailed = errors.New("moving file failed")
+	// ErrMetadataFailed indicates that the MetadataStore installed with
+	// WithMetadataStore returned an error.
+	ErrMetadataFailed = errors.New("metadata store failed")
+	// ErrPublishFailed indicates that the destination channel Send
+	// returned a non-nil error.
+	ErrPublishFailed = errors.New("publishing message failed")
+	// ErrFileNameInvalid indicates that the outbound file name is
+	// empty or not a plain base name.
+	ErrFileNameInvalid = errors.New("invalid file name")
+	// ErrWriteFailed indicates that writing an outbound file failed.
+	ErrWriteFailed = errors.New("writing file failed")
+)
+
+// Error is the domain error type for file adapter operations.
+type Error struct {
+	cerrs.TypedError
+}
+
+// Error returns the formatted error string including the type
+// classification.
+func (e *Error) Error() string {
+	cassert.NotNil(e, "error is nil")
+	cassert.NotNil(e.Err, "internal error is nil")
+
+	return fmt.Sprintf("file adapter %s error: %s", e.Type, e.Err)
+}
+
+// ErrAdapter wraps the given causes into a domain Error joined with
+// ErrAdapterFailed.
+func ErrAdapter(causes ...error) error {
+	return &Error{
+		TypedError: cerrs.TypedError{
+			Type: AdapterType,
+			Err:  errors.Join(append(causes, ErrAdapterFailed)...),
+		},
+	}
+}
@@ -0,0 +1,49 @@
+package file
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestError_Error(t *testing.T) {
+	t.Parallel()
+
+	t.Run("includes type prefix and joined causes", func(t *testing.T) {
+		t.Parallel()
+
+		err := ErrAdapter(ErrWriteFailed)
+
+		msg := err.Error()
+		if !strings.HasPrefix(msg, "file adapter "+AdapterType) {
+			t.Fatalf("expected prefix %q, got %q", "file adapter "+AdapterType, msg)
+		}
+
+		if !strings.Contains(msg, ErrWriteFailed.Error()) {
+			t.Fatalf("expected cause %q in message, got %q", ErrWriteFailed.Error(), msg)
+		}
+
+		if !strings.Contains(msg, ErrAdapterFailed.Error()) {
+			t.Fatalf("expected sentinel %q in message, got %q", ErrAdapterFailed.Error(), msg)
+		}
+	})
+
+	t.Run("ErrAdapter joins all causes with ErrAdapterFailed", func(t *testing.T) {
+		t.Parallel()
+
+		boom := errors.New("custom failure")
+
+		err := ErrAdapter(ErrWriteFailed, boom)
+		if !errors.Is(err, ErrAdapterFailed) {
+			t.Fatal("expected ErrAdapterFailed in chain")
+		}
+
+		if !errors.Is(err, ErrWriteFailed) {
+			t.Fatal("expected ErrWriteFailed in chain")
+		}
+
+		if !errors.Is(err, boom) {
+			t.Fatal("expected origin error in chain")
+		}
+	})
+}
@@ -0,0 +1,20 @@
+package file
+
+import (
+	"io"
+	"os"
+
+	"github.com/guidomantilla/yarumo/messaging/patterns/transformers/claimcheck"
+)
+
+// Open opens the file a NewReferenceInbound reference points at for
+// streaming. The caller must close the returned reader. Errors are
+// wrapped in ErrAdapter(ErrReadFailed, ...).
+func Open(ref claimcheck.ClaimCheckReference) (io.ReadCloser, error) {
+	f, err := os.Open(ref.Key)
+	if err != nil {
+		return nil, ErrAdapter(ErrReadFailed, err)
+	}
+
+	return f, nil
+}
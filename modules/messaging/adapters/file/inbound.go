@@ -0,0 +1,463 @@
+package file
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+	"github.com/guidomantilla/yarumo/core/common/lifecycle"
+	cuids "github.com/guidomantilla/yarumo/core/common/uids"
+	"github.com/guidomantilla/yarumo/messaging"
+	"github.com/guidomantilla/yarumo/messaging/patterns/transformers/claimcheck"
+	"github.com/guidomantilla/yarumo/messaging/stores"
+)
+
+// inbound is the inbound file adapter implementation. It owns one
+// poll goroutine (spawned in Start, stopped in Stop) that scans dir
+// and publishes each candidate either as its contents (content != nil)
+// or as a reference to its done-folder path (refs != nil).
+type inbound struct {
+	name         string
+	dir          string
+	content      messaging.Channel[[]byte]
+	refs         messaging.Channel[claimcheck.ClaimCheckReference]
+	pattern      string
+	pollInterval time.Duration
+	minFileAge   time.Duration
+	doneDir      string
+	errorDir     string
+	metadata     stores.MetadataStore
+	metadataTTL  time.Duration
+	uid          cuids.UID
+	clock        messaging.Clock
+	errorHandler messaging.ErrorHandler
+	stats        messaging.StatsRecorder
+
+	started      atomic.Bool
+	workerCancel context.CancelFunc
+
+	done      chan struct{}
+	startOnce sync.Once
+	stopOnce  sync.Once
+	doneOnce  sync.Once
+}
+
+// NewInbound constructs an Inbound that polls dir and publishes every
+// matching file to dst as a Message[[]byte] holding its contents. The
+// adapter is not running on return; call lifecycle.Build (or Start
+// directly) to begin polling.
+//
+// name is used in lifecycle logs and as Headers.Source and must be
+// non-empty. dir and dst are mandatory.
+//
+// Options:
+//   - WithPattern: file name glob (default "*").
+//   - WithPollInterval: scan interval (default DefaultPollInterval).
+//   - WithMinFileAge: skip files modified more recently than this.
+//   - WithDoneDir / WithErrorDir: destination folders (default
+//     "<dir>/done" and "<dir>/error").
+//   - WithMetadataStore: fingerprint store guarding against re-publication.
+//   - WithUIDGenerator: MessageID / CorrelationID generator.
+//   - WithClock: poll ticker and file-age clock.
+//   - WithErrorHandler: failure hook (default messaging.DefaultErrorHandler).
+func NewInbound(name string, dir string, dst messaging.Channel[[]byte], opts ...Option) Inbound {
+	cassert.NotNil(dst, "destination channel is nil")
+
+	in := newInbound(name, dir, opts...)
+	in.content = dst
+
+	return in
+}
+
+// NewReferenceInbound constructs an Inbound like NewInbound that moves
+// every matching file to the done folder first and then publishes a
+// claimcheck.ClaimCheckReference whose Key is the file's path there.
+// The file contents never travel through the channel; consumers read
+// them with Open and own the cleanup of the done folder. It accepts
+// the same options as NewInbound.
+func NewReferenceInbound(name string, dir string, dst messaging.Channel[claimcheck.ClaimCheckReference], opts ...Option) Inbound {
+	cassert.NotNil(dst, "destination channel is nil")
+
+	in := newInbound(name, dir, opts...)
+	in.refs = dst
+
+	return in
+}
+
+// newInbound builds the inbound adapter shared by both constructors.
+func newInbound(name string, dir string, opts ...Option) *inbound {
+	cassert.NotEmpty(name, "name is empty")
+	cassert.NotEmpty(dir, "directory is empty")
+
+	options := NewOptions(opts...)
+
+	doneDir := options.doneDir
+	if doneDir == "" {
+		doneDir = filepath.Join(dir, "done")
+	}
+
+	errorDir := options.errorDir
+	if errorDir == "" {
+		errorDir = filepath.Join(dir, "error")
+	}
+
+	in := &inbound{
+		name:         name,
+		dir:          dir,
+		pattern:      options.pattern,
+		pollInterval: options.pollInterval,
+		minFileAge:   options.minFileAge,
+		doneDir:      doneDir,
+		errorDir:     errorDir,
+		metadata:     options.metadata,
+		metadataTTL:  options.metadataTTL,
+		uid:          options.uid,
+		clock:        options.clock,
+		errorHandler: options.errorHandler,
+		done:         make(chan struct{}),
+	}
+
+	in.stats.UseClock(options.clock)
+
+	return in
+}
+
+// Name returns the adapter's identity used in lifecycle logs.
+func (in *inbound) Name() string {
+	cassert.NotNil(in, "inbound is nil")
+
+	return in.name
+}
+
+// Start checks that dir exists, creates the done and error folders and
+// spawns the poll goroutine, which scans once right away and then on
+// every tick. Start is idempotent — a second invocation returns nil
+// without spawning another goroutine.
+func (in *inbound) Start(ctx context.Context) error {
+	cassert.NotNil(in, "inbound is nil")
+
+	var startErr error
+
+	in.startOnce.Do(func() {
+		info, err := os.Stat(in.dir)
+		if err == nil && !info.IsDir() {
+			err = os.ErrInvalid
+		}
+
+		if err == nil {
+			err = os.MkdirAll(in.doneDir, dirMode)
+		}
+
+		if err == nil {
+			err = os.MkdirAll(in.errorDir, dirMode)
+		}
+
+		if err != nil {
+			startErr = lifecycle.ErrStart(ErrAdapter(ErrDirFailed, err))
+
+			in.doneOnce.Do(func() { close(in.done) })
+
+			return
+		}
+
+		workerCtx, workerCancel := context.WithCancel(ctx)
+		in.workerCancel = workerCancel
+		in.started.Store(true)
+
+		// The ticker is armed before the goroutine starts so tests
+		// driving a fake clock can rely on it being registered.
+		ticker := in.clock.NewTicker(in.pollInterval)
+
+		go in.run(workerCtx, ticker)
+	})
+
+	return startErr
+}
+
+// Stop cancels the poll goroutine and waits for it to finish the file
+// in progress, up to ctx's deadline. Stop is idempotent.
+func (in *inbound) Stop(ctx context.Context) error {
+	cassert.NotNil(in, "inbound is nil")
+
+	in.stopOnce.Do(func() {
+		if in.workerCancel != nil {
+			in.workerCancel()
+
+			return
+		}
+
+		in.doneOnce.Do(func() { close(in.done) })
+	})
+
+	select {
+	case <-in.done:
+		return nil
+	case <-ctx.Done():
+		return lifecycle.ErrShutdown(lifecycle.ErrShutdownTimeout, ctx.Err())
+	}
+}
+
+// Done returns the channel that is closed after the poll goroutine has
+// exited.
+func (in *inbound) Done() <-chan struct{} {
+	cassert.NotNil(in, "inbound is nil")
+
+	return in.done
+}
+
+// Stats returns a snapshot of the adapter's runtime statistics:
+// Delivered counts the files processed, Sent the messages published
+// and Failed the files that failed. Subscribers is 1 while the poll
+// goroutine runs.
+func (in *inbound) Stats() messaging.Stats {
+	cassert.NotNil(in, "inbound is nil")
+
+	stats := in.stats.Snapshot()
+
+	select {
+	case <-in.done:
+	default:
+		if in.started.Load() {
+			stats.Subscribers = 1
+		}
+	}
+
+	return stats
+}
+
+// run is the poll loop.
+func (in *inbound) run(ctx context.Context, ticker messaging.Ticker) {
+	defer in.doneOnce.Do(func() { close(in.done) })
+	defer ticker.Stop()
+
+	in.poll(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+			in.poll(ctx)
+		}
+	}
+}
+
+// poll scans dir once and processes every candidate, oldest first,
+// until ctx is cancelled.
+func (in *inbound) poll(ctx context.Context) {
+	candidates, err := in.scan()
+	if err != nil {
+		in.report(ctx, in.dir, ErrAdapter(ErrScanFailed, err))
+
+		return
+	}
+
+	for _, c := range candidates {
+		if ctx.Err() != nil {
+			return
+		}
+
+		end := in.stats.Begin()
+
+		err = in.process(ctx, c)
+		if err != nil {
+			in.report(ctx, c.path, err)
+		}
+
+		end(err)
+	}
+}
+
+// scan lists the regular, non-hidden files in dir whose name matches
+// the pattern and that are at least minFileAge old, sorted by
+// modification time then name.
+func (in *inbound) scan() ([]candidate, error) {
+	entries, err := os.ReadDir(in.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	now := in.clock.Now()
+
+	var candidates []candidate
+
+	for _, entry := range entries {
+		name := entry.Name()
+
+		if !entry.Type().IsRegular() || strings.HasPrefix(name, ".") {
+			continue
+		}
+
+		matched, _ := filepath.Match(in.pattern, name)
+		if !matched {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			// The file vanished between ReadDir and Info.
+			continue
+		}
+
+		if in.minFileAge > 0 && now.Sub(info.ModTime()) < in.minFileAge {
+			continue
+		}
+
+		candidates = append(candidates, candidate{
+			path:    filepath.Join(in.dir, name),
+			name:    name,
+			size:    info.Size(),
+			modTime: info.ModTime(),
+		})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if !candidates[i].modTime.Equal(candidates[j].modTime) {
+			return candidates[i].modTime.Before(candidates[j].modTime)
+		}
+
+		return candidates[i].name < candidates[j].name
+	})
+
+	return candidates, nil
+}
+
+// process publishes one candidate and moves it to the done or error
+// folder. A candidate whose fingerprint is already recorded is moved
+// to done without being published again. A metadata lookup failure
+// leaves the file in place for the next poll.
+func (in *inbound) process(ctx context.Context, c candidate) error {
+	key := c.fingerprint()
+
+	if in.metadata != nil {
+		seen, err := in.metadata.Has(ctx, key)
+		if err != nil {
+			return ErrAdapter(ErrMetadataFailed, err)
+		}
+
+		if seen {
+			_, err = moveFile(c.path, in.doneDir)
+			if err != nil {
+				return ErrAdapter(ErrMoveFailed, err)
+			}
+
+			return nil
+		}
+	}
+
+	if in.refs != nil {
+		return in.publishReference(ctx, c, key)
+	}
+
+	return in.publishContent(ctx, c, key)
+}
+
+// publishContent reads the candidate, publishes its contents and then
+// records and moves it.
+func (in *inbound) publishContent(ctx context.Context, c candidate, key string) error {
+	data, err := os.ReadFile(c.path)
+	if err != nil {
+		return in.fail(c.path, ErrAdapter(ErrReadFailed, err))
+	}
+
+	msg := messaging.NewMessage(data, in.uid)
+	in.stamp(&msg.Headers, c)
+
+	err = in.content.Send(ctx, msg)
+	if err != nil && ctx.Err() != nil {
+		// Stopping: leave the file for the next run.
+		return ErrAdapter(ErrPublishFailed, err)
+	}
+
+	if err != nil {
+		return in.fail(c.path, ErrAdapter(ErrPublishFailed, err))
+	}
+
+	in.stats.RecordSent()
+	in.remember(ctx, c, key)
+
+	_, err = moveFile(c.path, in.doneDir)
+	if err != nil {
+		return ErrAdapter(ErrMoveFailed, err)
+	}
+
+	return nil
+}
+
+// publishReference moves the candidate to the done folder and then
+// publishes a reference to its new path.
+func (in *inbound) publishReference(ctx context.Context, c candidate, key string) error {
+	dest, err := moveFile(c.path, in.doneDir)
+	if err != nil {
+		return ErrAdapter(ErrMoveFailed, err)
+	}
+
+	msg := messaging.NewMessage(claimcheck.ClaimCheckReference{Key: dest}, in.uid)
+	in.stamp(&msg.Headers, c)
+
+	err = in.refs.Send(ctx, msg)
+	if err != nil && ctx.Err() != nil {
+		// Stopping: put the file back for the next run.
+		_ = os.Rename(dest, c.path)
+
+		return ErrAdapter(ErrPublishFailed, err)
+	}
+
+	if err != nil {
+		return in.fail(dest, ErrAdapter(ErrPublishFailed, err))
+	}
+
+	in.stats.RecordSent()
+	in.remember(ctx, c, key)
+
+	return nil
+}
+
+// stamp sets Headers.Source and the file metadata Custom keys.
+func (in *inbound) stamp(h *messaging.Headers, c candidate) {
+	h.Source = in.name
+	h.Custom = map[string]any{
+		HeaderFileName:    c.name,
+		HeaderFilePath:    c.path,
+		HeaderFileSize:    c.size,
+		HeaderFileModTime: c.modTime,
+	}
+}
+
+// remember records the candidate's fingerprint. A failure is reported
+// but does not fail the file: the message is already published.
+func (in *inbound) remember(ctx context.Context, c candidate, key string) {
+	if in.metadata == nil {
+		return
+	}
+
+	err := in.metadata.Add(ctx, key, in.metadataTTL)
+	if err != nil {
+		in.report(ctx, c.path, ErrAdapter(ErrMetadataFailed, err))
+	}
+}
+
+// fail moves path to the error folder and returns cause, joined with
+// the move error when the move fails too.
+func (in *inbound) fail(path string, cause error) error {
+	_, err := moveFile(path, in.errorDir)
+	if err != nil {
+		return ErrAdapter(cause, ErrMoveFailed, err)
+	}
+
+	return cause
+}
+
+// report forwards err to the configured ErrorHandler with the path
+// of the file (or directory) it concerns as the subject.
+func (in *inbound) report(ctx context.Context, path string, err error) {
+	if in.errorHandler != nil {
+		in.errorHandler(ctx, path, err)
+	}
+}
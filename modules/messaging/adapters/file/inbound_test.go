@@ -0,0 +1,412 @@
+package file
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/guidomantilla/yarumo/core/common/lifecycle"
+	"github.com/guidomantilla/yarumo/messaging"
+	"github.com/guidomantilla/yarumo/messaging/messagingtest"
+	"github.com/guidomantilla/yarumo/messaging/patterns/transformers/claimcheck"
+	"github.com/guidomantilla/yarumo/messaging/stores"
+)
+
+// writeFile creates dir/name with content and fails t on error.
+func writeFile(t *testing.T, dir string, name string, content string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+
+	err := os.WriteFile(path, []byte(content), 0o600)
+	if err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+
+	return path
+}
+
+// exists reports whether path exists.
+func exists(path string) bool {
+	_, err := os.Stat(path)
+
+	return err == nil
+}
+
+// captureErrors returns a thread-safe ErrorHandler that records every
+// reported error and a getter that returns a copy.
+func captureErrors() (messaging.ErrorHandler, func() []error) {
+	var mu sync.Mutex
+
+	captured := []error{}
+
+	handler := func(_ context.Context, _ any, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		captured = append(captured, err)
+	}
+
+	get := func() []error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return append([]error(nil), captured...)
+	}
+
+	return handler, get
+}
+
+func startInbound(t *testing.T, in Inbound) {
+	t.Helper()
+
+	err := in.Start(context.Background())
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+
+	t.Cleanup(func() { _ = in.Stop(context.Background()) })
+}
+
+func TestNewInbound(t *testing.T) {
+	t.Parallel()
+
+	t.Run("publishes matching files and moves them to done", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		writeFile(t, dir, "batch-1.csv", "a,b\n1,2\n")
+		writeFile(t, dir, "notes.txt", "ignored")
+		writeFile(t, dir, ".batch-2.csv", "hidden")
+
+		err := os.Mkdir(filepath.Join(dir, "nested.csv"), 0o755)
+		if err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+
+		dst := messagingtest.NewRecordingChannel[[]byte]()
+		in := NewInbound("partner-drop", dir, dst,
+			WithPattern("*.csv"),
+			WithClock(messagingtest.NewFakeClock(time.Time{})),
+		)
+		startInbound(t, in)
+
+		msgs := dst.WaitFor(t, 1)
+
+		if string(msgs[0].Payload) != "a,b\n1,2\n" {
+			t.Fatalf("unexpected payload %q", msgs[0].Payload)
+		}
+
+		h := msgs[0].Headers
+		if h.Source != "partner-drop" || h.Custom[HeaderFileName] != "batch-1.csv" ||
+			h.Custom[HeaderFilePath] != filepath.Join(dir, "batch-1.csv") || h.Custom[HeaderFileSize] != int64(8) {
+			t.Fatalf("unexpected headers %+v", h)
+		}
+
+		if _, ok := h.Custom[HeaderFileModTime].(time.Time); !ok {
+			t.Fatal("expected modification time header")
+		}
+
+		done := filepath.Join(dir, "done", "batch-1.csv")
+		messagingtest.WaitUntil(t, func() bool { return exists(done) }, "expected %s", done)
+
+		if exists(filepath.Join(dir, "batch-1.csv")) {
+			t.Fatal("expected file to leave the inbound directory")
+		}
+
+		for _, name := range []string{"notes.txt", ".batch-2.csv", "nested.csv"} {
+			if !exists(filepath.Join(dir, name)) {
+				t.Fatalf("expected %s to be left alone", name)
+			}
+		}
+
+		dst.AssertCount(t, 1)
+	})
+
+	t.Run("picks up new files on the next tick, oldest first", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		clock := messagingtest.NewFakeClock(time.Time{})
+		dst := messagingtest.NewRecordingChannel[[]byte]()
+
+		writeFile(t, dir, "first.csv", "first")
+
+		in := NewInbound("partner-drop", dir, dst, WithClock(clock), WithPollInterval(time.Minute))
+		startInbound(t, in)
+
+		// The initial scan publishes first.csv; wait for it so the
+		// files below are only seen by the tick.
+		dst.WaitFor(t, 1)
+		clock.WaitForTimers(t, 1)
+
+		older := writeFile(t, dir, "b.csv", "older")
+		newer := writeFile(t, dir, "a.csv", "newer")
+
+		base := time.Now().Add(-time.Hour)
+
+		err := errors.Join(os.Chtimes(older, base, base), os.Chtimes(newer, base.Add(time.Second), base.Add(time.Second)))
+		if err != nil {
+			t.Fatalf("chtimes: %v", err)
+		}
+
+		clock.Advance(time.Minute)
+
+		dst.WaitFor(t, 3)
+		dst.AssertPayloads(t, []byte("first"), []byte("older"), []byte("newer"))
+	})
+
+	t.Run("moves files that fail to publish to the error folder", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		writeFile(t, dir, "batch.csv", "x")
+
+		boom := errors.New("boom")
+		dst := messagingtest.NewRecordingChannel[[]byte]()
+
+		_, err := dst.Subscribe(func(_ context.Context, _ messaging.Message[[]byte]) error { return boom })
+		if err != nil {
+			t.Fatalf("subscribe: %v", err)
+		}
+
+		hook, errs := captureErrors()
+		errorDir := filepath.Join(t.TempDir(), "rejected")
+
+		in := NewInbound("partner-drop", dir, dst, WithErrorHandler(hook), WithErrorDir(errorDir))
+		startInbound(t, in)
+
+		failed := filepath.Join(errorDir, "batch.csv")
+		messagingtest.WaitUntil(t, func() bool { return exists(failed) }, "expected %s", failed)
+		messagingtest.WaitUntil(t, func() bool { return in.Stats().Failed == 1 }, "expected one failure")
+
+		got := errs()
+		if len(got) != 1 || !errors.Is(got[0], ErrPublishFailed) || !errors.Is(got[0], boom) {
+			t.Fatalf("expected ErrPublishFailed joined with cause, got %v", got)
+		}
+	})
+
+	t.Run("does not republish files recorded in the metadata store", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		path := writeFile(t, dir, "batch.csv", "x")
+
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatalf("stat: %v", err)
+		}
+
+		store := stores.NewInMemoryMetadataStore("files")
+		seen := candidate{path: path, name: "batch.csv", size: info.Size(), modTime: info.ModTime()}
+
+		err = store.Add(context.Background(), seen.fingerprint(), time.Hour)
+		if err != nil {
+			t.Fatalf("add: %v", err)
+		}
+
+		dst := messagingtest.NewRecordingChannel[[]byte]()
+
+		startInbound(t, NewInbound("partner-drop", dir, dst, WithMetadataStore(store, time.Hour)))
+
+		done := filepath.Join(dir, "done", "batch.csv")
+		messagingtest.WaitUntil(t, func() bool { return exists(done) }, "expected %s", done)
+
+		dst.AssertCount(t, 0)
+	})
+
+	t.Run("records published files in the metadata store", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		path := writeFile(t, dir, "batch.csv", "x")
+
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatalf("stat: %v", err)
+		}
+
+		store := stores.NewInMemoryMetadataStore("files")
+		dst := messagingtest.NewRecordingChannel[[]byte]()
+
+		startInbound(t, NewInbound("partner-drop", dir, dst, WithMetadataStore(store, 0)))
+
+		dst.WaitFor(t, 1)
+
+		key := candidate{path: path, name: "batch.csv", size: info.Size(), modTime: info.ModTime()}.fingerprint()
+
+		messagingtest.WaitUntil(t, func() bool {
+			ok, _ := store.Has(context.Background(), key)
+
+			return ok
+		}, "expected fingerprint to be recorded")
+	})
+
+	t.Run("skips files younger than the minimum age", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		now := time.Now()
+		clock := messagingtest.NewFakeClock(now)
+
+		path := writeFile(t, dir, "batch.csv", "x")
+
+		err := os.Chtimes(path, now, now)
+		if err != nil {
+			t.Fatalf("chtimes: %v", err)
+		}
+
+		in := newInbound("partner-drop", dir, WithClock(clock), WithMinFileAge(time.Minute))
+
+		candidates, err := in.scan()
+		if err != nil || len(candidates) != 0 {
+			t.Fatalf("expected no candidate yet, got %v (%v)", candidates, err)
+		}
+
+		clock.Advance(time.Minute)
+
+		candidates, err = in.scan()
+		if err != nil || len(candidates) != 1 || candidates[0].name != "batch.csv" {
+			t.Fatalf("expected batch.csv once old enough, got %v (%v)", candidates, err)
+		}
+	})
+}
+
+func TestNewReferenceInbound(t *testing.T) {
+	t.Parallel()
+
+	t.Run("publishes a reference to the file in the done folder", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		writeFile(t, dir, "batch.csv", "a,b\n")
+
+		dst := messagingtest.NewRecordingChannel[claimcheck.ClaimCheckReference]()
+
+		startInbound(t, NewReferenceInbound("partner-drop", dir, dst))
+
+		msgs := dst.WaitFor(t, 1)
+
+		want := filepath.Join(dir, "done", "batch.csv")
+		if msgs[0].Payload.Key != want {
+			t.Fatalf("expected key %q, got %q", want, msgs[0].Payload.Key)
+		}
+
+		if msgs[0].Headers.Custom[HeaderFileName] != "batch.csv" {
+			t.Fatalf("unexpected headers %+v", msgs[0].Headers)
+		}
+
+		r, err := Open(msgs[0].Payload)
+		if err != nil {
+			t.Fatalf("open: %v", err)
+		}
+
+		defer func() { _ = r.Close() }()
+
+		data, err := io.ReadAll(r)
+		if err != nil || string(data) != "a,b\n" {
+			t.Fatalf("unexpected content %q (%v)", data, err)
+		}
+	})
+
+	t.Run("moves the file to the error folder when publishing fails", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		writeFile(t, dir, "batch.csv", "x")
+
+		dst := messagingtest.NewRecordingChannel[claimcheck.ClaimCheckReference]()
+
+		_, err := dst.Subscribe(func(_ context.Context, _ messaging.Message[claimcheck.ClaimCheckReference]) error {
+			return errors.New("boom")
+		})
+		if err != nil {
+			t.Fatalf("subscribe: %v", err)
+		}
+
+		startInbound(t, NewReferenceInbound("partner-drop", dir, dst, WithErrorHandler(messaging.SilentErrorHandler)))
+
+		failed := filepath.Join(dir, "error", "batch.csv")
+		messagingtest.WaitUntil(t, func() bool { return exists(failed) }, "expected %s", failed)
+
+		if exists(filepath.Join(dir, "done", "batch.csv")) {
+			t.Fatal("expected file to leave the done folder")
+		}
+	})
+}
+
+func TestInbound_Lifecycle(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Start fails when the directory is missing", func(t *testing.T) {
+		t.Parallel()
+
+		in := NewInbound("partner-drop", filepath.Join(t.TempDir(), "missing"), messagingtest.NewRecordingChannel[[]byte]())
+
+		err := in.Start(context.Background())
+		if !errors.Is(err, lifecycle.ErrStartFailed) || !errors.Is(err, ErrDirFailed) {
+			t.Fatalf("expected ErrDirFailed start error, got %v", err)
+		}
+
+		err = in.Stop(context.Background())
+		if err != nil {
+			t.Fatalf("stop: %v", err)
+		}
+	})
+
+	t.Run("Start and Stop are idempotent", func(t *testing.T) {
+		t.Parallel()
+
+		in := NewInbound("partner-drop", t.TempDir(), messagingtest.NewRecordingChannel[[]byte]())
+
+		if in.Name() != "partner-drop" {
+			t.Fatalf("unexpected name %q", in.Name())
+		}
+
+		for range 2 {
+			err := in.Start(context.Background())
+			if err != nil {
+				t.Fatalf("start: %v", err)
+			}
+		}
+
+		if in.Stats().Subscribers != 1 {
+			t.Fatal("expected the poll goroutine to be reported")
+		}
+
+		for range 2 {
+			err := in.Stop(context.Background())
+			if err != nil {
+				t.Fatalf("stop: %v", err)
+			}
+		}
+
+		select {
+		case <-in.Done():
+		default:
+			t.Fatal("expected Done to be closed")
+		}
+
+		if in.Stats().Subscribers != 0 {
+			t.Fatal("expected no poll goroutine after Stop")
+		}
+	})
+
+	t.Run("Stop before Start closes Done", func(t *testing.T) {
+		t.Parallel()
+
+		in := NewInbound("partner-drop", t.TempDir(), messagingtest.NewRecordingChannel[[]byte]())
+
+		err := in.Stop(context.Background())
+		if err != nil {
+			t.Fatalf("stop: %v", err)
+		}
+
+		<-in.Done()
+	})
+}
@@ -0,0 +1,113 @@
+package file
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dirMode is the permission of the folders the adapters create.
+const dirMode fs.FileMode = 0o755
+
+// candidate is an inbound file selected by a directory scan.
+type candidate struct {
+	path    string
+	name    string
+	size    int64
+	modTime time.Time
+}
+
+// fingerprint identifies one version of the candidate's file: a file
+// rewritten under the same name gets a new fingerprint.
+func (c candidate) fingerprint() string {
+	return c.path + "|" + strconv.FormatInt(c.size, 10) + "|" + strconv.FormatInt(c.modTime.UnixNano(), 10)
+}
+
+// moveFile renames path into dir, keeping its base name unless it is
+// already taken there, and returns the destination path.
+func moveFile(path string, dir string) (string, error) {
+	dest, err := freePath(dir, filepath.Base(path))
+	if err != nil {
+		return "", err
+	}
+
+	err = os.Rename(path, dest)
+	if err != nil {
+		return "", err
+	}
+
+	return dest, nil
+}
+
+// freePath returns dir/name, or dir/<stem>-<n><ext> with the smallest
+// n >= 1 that is not taken when dir/name already exists.
+func freePath(dir string, name string) (string, error) {
+	ext := filepath.Ext(name)
+	stem := strings.TrimSuffix(name, ext)
+	dest := filepath.Join(dir, name)
+
+	for n := 1; ; n++ {
+		_, err := os.Lstat(dest)
+		if errors.Is(err, fs.ErrNotExist) {
+			return dest, nil
+		}
+
+		if err != nil {
+			return "", err
+		}
+
+		dest = filepath.Join(dir, stem+"-"+strconv.Itoa(n)+ext)
+	}
+}
+
+// writeAtomic writes data to dir/name through a hidden temporary file
+// in the same directory that is synced, given mode and renamed into
+// place, so readers observe either no file or the complete one.
+func writeAtomic(dir string, name string, data []byte, mode fs.FileMode) error {
+	file, err := os.CreateTemp(dir, "."+name+".tmp-*")
+	if err != nil {
+		return err
+	}
+
+	tmp := file.Name()
+
+	_, err = file.Write(data)
+	if err == nil {
+		err = file.Sync()
+	}
+
+	if err == nil {
+		err = file.Chmod(mode)
+	}
+
+	closeErr := file.Close()
+	if err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = os.Rename(tmp, filepath.Join(dir, name))
+	}
+
+	if err != nil {
+		_ = os.Remove(tmp)
+
+		return err
+	}
+
+	return nil
+}
+
+// validFileName reports whether name is a plain base name that stays
+// inside the target directory.
+func validFileName(name string) bool {
+	if name == "" || name == "." || name == ".." {
+		return false
+	}
+
+	return filepath.Base(name) == name && !strings.ContainsAny(name, `/\`)
+}
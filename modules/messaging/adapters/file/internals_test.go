@@ -0,0 +1,135 @@
+package file
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/guidomantilla/yarumo/messaging/patterns/transformers/claimcheck"
+)
+
+func TestCandidate_Fingerprint(t *testing.T) {
+	t.Parallel()
+
+	base := candidate{path: "/in/a.csv", name: "a.csv", size: 3, modTime: time.Unix(0, 7)}
+
+	if base.fingerprint() != "/in/a.csv|3|7" {
+		t.Fatalf("unexpected fingerprint %q", base.fingerprint())
+	}
+
+	rewritten := base
+	rewritten.modTime = time.Unix(0, 8)
+
+	if rewritten.fingerprint() == base.fingerprint() {
+		t.Fatal("expected a rewritten file to get a new fingerprint")
+	}
+}
+
+func TestFreePath(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	got, err := freePath(dir, "a.csv")
+	if err != nil || got != filepath.Join(dir, "a.csv") {
+		t.Fatalf("expected the plain name, got %q %v", got, err)
+	}
+
+	writeFile(t, dir, "a.csv", "x")
+	writeFile(t, dir, "a-1.csv", "x")
+
+	got, err = freePath(dir, "a.csv")
+	if err != nil || got != filepath.Join(dir, "a-2.csv") {
+		t.Fatalf("expected the first free suffix, got %q %v", got, err)
+	}
+}
+
+func TestMoveFile(t *testing.T) {
+	t.Parallel()
+
+	src := t.TempDir()
+	dst := t.TempDir()
+	writeFile(t, dst, "a.csv", "old")
+
+	dest, err := moveFile(writeFile(t, src, "a.csv", "new"), dst)
+	if err != nil {
+		t.Fatalf("move: %v", err)
+	}
+
+	if dest != filepath.Join(dst, "a-1.csv") || readFile(t, dest) != "new" || readFile(t, filepath.Join(dst, "a.csv")) != "old" {
+		t.Fatalf("expected the existing file to be kept, got %q", dest)
+	}
+
+	_, err = moveFile(filepath.Join(src, "missing.csv"), dst)
+	if err == nil {
+		t.Fatal("expected an error for a missing file")
+	}
+}
+
+func TestWriteAtomic(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	err := writeAtomic(dir, "a.csv", []byte("data"), 0o640)
+	if err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	info, err := os.Stat(filepath.Join(dir, "a.csv"))
+	if err != nil || info.Mode().Perm() != 0o640 {
+		t.Fatalf("expected mode 0640, got %v %v", info, err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("expected no temporary files, got %v %v", entries, err)
+	}
+
+	err = writeAtomic(filepath.Join(dir, "missing"), "a.csv", []byte("data"), 0o640)
+	if err == nil {
+		t.Fatal("expected an error for a missing directory")
+	}
+}
+
+func TestValidFileName(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{"a.csv", "batch-2026.csv", ".hidden"} {
+		if !validFileName(name) {
+			t.Fatalf("expected %q to be valid", name)
+		}
+	}
+
+	for _, name := range []string{"", ".", "..", "../a.csv", "dir/a.csv", `dir\a.csv`} {
+		if validFileName(name) {
+			t.Fatalf("expected %q to be invalid", name)
+		}
+	}
+}
+
+func TestOpen(t *testing.T) {
+	t.Parallel()
+
+	path := writeFile(t, t.TempDir(), "a.csv", "data")
+
+	reader, err := Open(claimcheck.ClaimCheckReference{Key: path})
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+
+	data, err := io.ReadAll(reader)
+	_ = reader.Close()
+
+	if err != nil || string(data) != "data" {
+		t.Fatalf("unexpected content %q %v", data, err)
+	}
+
+	_, err = Open(claimcheck.ClaimCheckReference{Key: path + ".missing"})
+	if !errors.Is(err, ErrReadFailed) {
+		t.Fatalf("expected ErrReadFailed, got %v", err)
+	}
+}
@@ -0,0 +1,216 @@
+package file
+
+import (
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"time"
+
+	cuids "github.com/guidomantilla/yarumo/core/common/uids"
+	"github.com/guidomantilla/yarumo/messaging"
+	"github.com/guidomantilla/yarumo/messaging/stores"
+)
+
+const (
+	// DefaultPollInterval is the default inbound poll interval.
+	DefaultPollInterval = time.Second
+	// DefaultMetadataTTL is the default TTL of the fingerprints
+	// recorded in the MetadataStore.
+	DefaultMetadataTTL = 7 * 24 * time.Hour
+	// DefaultFileMode is the default permission of outbound files.
+	DefaultFileMode fs.FileMode = 0o644
+)
+
+// Option is a functional option for configuring adapter Options. The
+// inbound and outbound adapters share one Option type; each option
+// documents the side it applies to and is ignored by the other.
+type Option func(opts *Options)
+
+// Options holds the configuration for the file adapters.
+type Options struct {
+	errorHandler messaging.ErrorHandler
+	clock        messaging.Clock
+
+	pattern      string
+	pollInterval time.Duration
+	minFileAge   time.Duration
+	doneDir      string
+	errorDir     string
+	metadata     stores.MetadataStore
+	metadataTTL  time.Duration
+	uid          cuids.UID
+
+	fileName FileNameFn
+	fileMode fs.FileMode
+	dlq      messaging.Channel[messaging.DeadLetter[[]byte]]
+}
+
+// NewOptions creates a new Options with sensible defaults and applies
+// the given options. Defaults: messaging.DefaultErrorHandler,
+// messaging.SystemClock, pattern "*", DefaultPollInterval, no minimum
+// file age, done and error folders under the inbound directory, no
+// MetadataStore, DefaultMetadataTTL, no uid generator, the default
+// file name (see WithFileName) and DefaultFileMode.
+func NewOptions(opts ...Option) *Options {
+	options := &Options{
+		errorHandler: messaging.DefaultErrorHandler,
+		clock:        messaging.SystemClock(),
+		pattern:      "*",
+		pollInterval: DefaultPollInterval,
+		metadataTTL:  DefaultMetadataTTL,
+		fileMode:     DefaultFileMode,
+	}
+
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	return options
+}
+
+// WithErrorHandler installs an observability hook fired once per
+// failed file. The default (when WithErrorHandler is not passed) is
+// messaging.DefaultErrorHandler, which logs via common/log. Pass
+// messaging.SilentErrorHandler to opt out. Nil values are ignored.
+func WithErrorHandler(handler messaging.ErrorHandler) Option {
+	return func(opts *Options) {
+		if handler != nil {
+			opts.errorHandler = handler
+		}
+	}
+}
+
+// WithClock sets the clock driving the inbound poll ticker, the
+// WithMinFileAge check, the default outbound file name and the
+// outbound DeadLetter.FailedAt stamp. Nil values are ignored (the
+// system clock is preserved).
+func WithClock(clock messaging.Clock) Option {
+	return func(opts *Options) {
+		if clock != nil {
+			opts.clock = clock
+		}
+	}
+}
+
+// WithPattern sets the filepath.Match glob inbound file names must
+// match (default "*"). Malformed patterns and empty values are
+// ignored.
+func WithPattern(pattern string) Option {
+	return func(opts *Options) {
+		if pattern == "" {
+			return
+		}
+
+		_, err := filepath.Match(pattern, "")
+		if err == nil {
+			opts.pattern = pattern
+		}
+	}
+}
+
+// WithPollInterval sets the interval between inbound directory scans.
+// Non-positive values are ignored.
+func WithPollInterval(d time.Duration) Option {
+	return func(opts *Options) {
+		if d > 0 {
+			opts.pollInterval = d
+		}
+	}
+}
+
+// WithMinFileAge makes the inbound adapter skip files modified less
+// than d ago, so uploads still in progress are not picked up half
+// written. Non-positive values are ignored.
+func WithMinFileAge(d time.Duration) Option {
+	return func(opts *Options) {
+		if d > 0 {
+			opts.minFileAge = d
+		}
+	}
+}
+
+// WithDoneDir sets the folder processed inbound files are moved to.
+// Empty values are ignored.
+func WithDoneDir(dir string) Option {
+	return func(opts *Options) {
+		dir = strings.TrimSpace(dir)
+		if dir != "" {
+			opts.doneDir = dir
+		}
+	}
+}
+
+// WithErrorDir sets the folder failed inbound files are moved to.
+// Empty values are ignored.
+func WithErrorDir(dir string) Option {
+	return func(opts *Options) {
+		dir = strings.TrimSpace(dir)
+		if dir != "" {
+			opts.errorDir = dir
+		}
+	}
+}
+
+// WithMetadataStore installs the MetadataStore the inbound adapter
+// records published file fingerprints in, so a file that survives a
+// crash is not published twice. ttl bounds how long a fingerprint is
+// kept (non-positive keeps DefaultMetadataTTL). Nil stores are ignored.
+func WithMetadataStore(store stores.MetadataStore, ttl time.Duration) Option {
+	return func(opts *Options) {
+		if store == nil {
+			return
+		}
+
+		opts.metadata = store
+
+		if ttl > 0 {
+			opts.metadataTTL = ttl
+		}
+	}
+}
+
+// WithUIDGenerator sets the generator the inbound adapter uses to
+// populate MessageID and CorrelationID. Without it those fields are
+// left empty. Nil values are ignored.
+func WithUIDGenerator(uid cuids.UID) Option {
+	return func(opts *Options) {
+		if uid != nil {
+			opts.uid = uid
+		}
+	}
+}
+
+// WithFileName sets how the outbound adapter names each file. The
+// default uses Headers.Custom[HeaderFileName] when it holds a string,
+// then Headers.MessageID, then the clock's current Unix nanoseconds.
+// Nil values are ignored.
+func WithFileName(fn FileNameFn) Option {
+	return func(opts *Options) {
+		if fn != nil {
+			opts.fileName = fn
+		}
+	}
+}
+
+// WithFileMode sets the permission of outbound files (default
+// DefaultFileMode). Zero values are ignored.
+func WithFileMode(mode fs.FileMode) Option {
+	return func(opts *Options) {
+		if mode != 0 {
+			opts.fileMode = mode.Perm()
+		}
+	}
+}
+
+// WithDLQChannel installs the Dead Letter Channel the outbound adapter
+// publishes a DeadLetter[[]byte] to when a write fails. The
+// publication is best-effort: failures of the DLQ Send itself are
+// swallowed, and the ErrorHandler fires independently. Nil values are
+// ignored.
+func WithDLQChannel(dlq messaging.Channel[messaging.DeadLetter[[]byte]]) Option {
+	return func(opts *Options) {
+		if dlq != nil {
+			opts.dlq = dlq
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package file
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cuids "github.com/guidomantilla/yarumo/core/common/uids"
+	"github.com/guidomantilla/yarumo/messaging"
+	"github.com/guidomantilla/yarumo/messaging/messagingtest"
+	"github.com/guidomantilla/yarumo/messaging/stores"
+)
+
+func TestNewOptions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("applies defaults", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions()
+
+		if opts.errorHandler == nil || opts.clock == nil {
+			t.Fatal("expected default error handler and clock")
+		}
+
+		if opts.pattern != "*" || opts.pollInterval != DefaultPollInterval || opts.minFileAge != 0 {
+			t.Fatalf("unexpected scan defaults %q %v %v", opts.pattern, opts.pollInterval, opts.minFileAge)
+		}
+
+		if opts.doneDir != "" || opts.errorDir != "" {
+			t.Fatal("expected done and error folders to be resolved by the adapter")
+		}
+
+		if opts.metadata != nil || opts.metadataTTL != DefaultMetadataTTL {
+			t.Fatalf("expected no metadata store and the default TTL, got %v", opts.metadataTTL)
+		}
+
+		if opts.uid != nil || opts.fileName != nil || opts.dlq != nil {
+			t.Fatal("expected no uid, file name function or dlq by default")
+		}
+
+		if opts.fileMode != DefaultFileMode {
+			t.Fatalf("expected mode %v, got %v", DefaultFileMode, opts.fileMode)
+		}
+	})
+
+	t.Run("applies options", func(t *testing.T) {
+		t.Parallel()
+
+		clock := messagingtest.NewFakeClock(time.Time{})
+		store := stores.NewInMemoryMetadataStore("files")
+		uid := cuids.NewUID("test", func() (string, error) { return "id", nil })
+		dlq := messaging.NewPipelineChannel[messaging.DeadLetter[[]byte]]()
+		called := false
+
+		opts := NewOptions(
+			WithErrorHandler(func(_ context.Context, _ any, _ error) { called = true }),
+			WithClock(clock),
+			WithPattern("*.csv"),
+			WithPollInterval(time.Minute),
+			WithMinFileAge(5*time.Second),
+			WithDoneDir(" /tmp/done "),
+			WithErrorDir("/tmp/error"),
+			WithMetadataStore(store, time.Hour),
+			WithUIDGenerator(uid),
+			WithFileName(func(_ messaging.Message[[]byte]) string { return "x" }),
+			WithFileMode(0o600),
+			WithDLQChannel(dlq),
+		)
+
+		opts.errorHandler(context.Background(), nil, nil)
+
+		if !called {
+			t.Fatal("expected custom error handler")
+		}
+
+		if opts.clock != clock || opts.pattern != "*.csv" || opts.pollInterval != time.Minute || opts.minFileAge != 5*time.Second {
+			t.Fatal("expected custom clock and scan settings")
+		}
+
+		if opts.doneDir != "/tmp/done" || opts.errorDir != "/tmp/error" {
+			t.Fatalf("unexpected folders %q %q", opts.doneDir, opts.errorDir)
+		}
+
+		if opts.metadata != store || opts.metadataTTL != time.Hour {
+			t.Fatal("expected custom metadata store and TTL")
+		}
+
+		if opts.uid == nil || opts.fileName == nil || opts.fileMode != 0o600 || opts.dlq == nil {
+			t.Fatal("expected custom uid, file name, mode and dlq")
+		}
+	})
+
+	t.Run("ignores invalid values", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(
+			WithErrorHandler(nil),
+			WithClock(nil),
+			WithPattern("["),
+			WithPattern(""),
+			WithPollInterval(0),
+			WithMinFileAge(-time.Second),
+			WithDoneDir(" "),
+			WithErrorDir(""),
+			WithMetadataStore(nil, time.Hour),
+			WithUIDGenerator(nil),
+			WithFileName(nil),
+			WithFileMode(0),
+			WithDLQChannel(nil),
+		)
+
+		if opts.errorHandler == nil || opts.clock == nil {
+			t.Fatal("expected defaults to be preserved")
+		}
+
+		if opts.pattern != "*" || opts.pollInterval != DefaultPollInterval || opts.minFileAge != 0 {
+			t.Fatal("expected scan defaults to be preserved")
+		}
+
+		if opts.doneDir != "" || opts.errorDir != "" || opts.metadata != nil || opts.metadataTTL != DefaultMetadataTTL {
+			t.Fatal("expected folder and metadata defaults to be preserved")
+		}
+
+		if opts.uid != nil || opts.fileName != nil || opts.fileMode != DefaultFileMode || opts.dlq != nil {
+			t.Fatal("expected outbound defaults to be preserved")
+		}
+	})
+
+	t.Run("keeps the default TTL for non-positive values", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithMetadataStore(stores.NewInMemoryMetadataStore("files"), 0))
+
+		if opts.metadata == nil || opts.metadataTTL != DefaultMetadataTTL {
+			t.Fatalf("expected store with default TTL, got %v", opts.metadataTTL)
+		}
+	})
+}
@@ -0,0 +1,235 @@
+package file
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"strconv"
+	"sync"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+	"github.com/guidomantilla/yarumo/core/common/lifecycle"
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+// outbound is the outbound file adapter implementation. It owns a
+// single subscription on the source channel (registered in Start,
+// cancelled in Stop) and writes each received message to dir.
+type outbound struct {
+	name         string
+	src          messaging.Channel[[]byte]
+	dir          string
+	fileName     FileNameFn
+	fileMode     fs.FileMode
+	clock        messaging.Clock
+	dlq          messaging.Channel[messaging.DeadLetter[[]byte]]
+	errorHandler messaging.ErrorHandler
+	stats        messaging.StatsRecorder
+
+	done      chan struct{}
+	startOnce sync.Once
+	stopOnce  sync.Once
+	doneOnce  sync.Once
+
+	mu     sync.Mutex
+	cancel messaging.Cancel
+}
+
+// NewOutbound constructs an Outbound that subscribes to src and writes
+// every received payload to a file in dir atomically (temporary file
+// plus rename). A file with the same name is replaced. The adapter is
+// not running on return; call lifecycle.Build (or Start directly) to
+// register the subscription.
+//
+// name is used in lifecycle logs and must be non-empty. src and dir
+// are mandatory.
+//
+// Options:
+//   - WithFileName: file naming (default HeaderFileName, then
+//     MessageID, then the clock's Unix nanoseconds).
+//   - WithFileMode: file permission (default DefaultFileMode).
+//   - WithDLQChannel: Dead Letter Channel for failed writes.
+//   - WithClock: clock behind the default file name and dead letters.
+//   - WithErrorHandler: failure hook (default messaging.DefaultErrorHandler).
+func NewOutbound(name string, src messaging.Channel[[]byte], dir string, opts ...Option) Outbound {
+	cassert.NotEmpty(name, "name is empty")
+	cassert.NotNil(src, "source channel is nil")
+	cassert.NotEmpty(dir, "directory is empty")
+
+	options := NewOptions(opts...)
+
+	o := &outbound{
+		name:         name,
+		src:          src,
+		dir:          dir,
+		fileName:     options.fileName,
+		fileMode:     options.fileMode,
+		clock:        options.clock,
+		dlq:          options.dlq,
+		errorHandler: options.errorHandler,
+		done:         make(chan struct{}),
+	}
+
+	if o.fileName == nil {
+		o.fileName = o.defaultFileName
+	}
+
+	o.stats.UseClock(options.clock)
+
+	return o
+}
+
+// Name returns the adapter's identity used in lifecycle logs.
+func (o *outbound) Name() string {
+	cassert.NotNil(o, "outbound is nil")
+
+	return o.name
+}
+
+// Start creates dir when missing and registers the write handler as a
+// subscriber on the source channel. Start is idempotent — a second
+// invocation returns nil without re-subscribing.
+func (o *outbound) Start(_ context.Context) error {
+	cassert.NotNil(o, "outbound is nil")
+
+	var startErr error
+
+	o.startOnce.Do(func() {
+		err := os.MkdirAll(o.dir, dirMode)
+		if err != nil {
+			startErr = lifecycle.ErrStart(ErrAdapter(ErrDirFailed, err))
+
+			return
+		}
+
+		cancel, err := o.src.Subscribe(o.handle)
+		if err != nil {
+			startErr = lifecycle.ErrStart(err)
+
+			return
+		}
+
+		o.mu.Lock()
+		o.cancel = cancel
+		o.mu.Unlock()
+	})
+
+	return startErr
+}
+
+// Stop cancels the source-channel subscription and closes Done. Stop
+// is idempotent per the lifecycle.Component contract. It returns
+// lifecycle.ErrShutdown wrapping lifecycle.ErrShutdownTimeout when ctx
+// is already expired on entry; otherwise nil.
+func (o *outbound) Stop(ctx context.Context) error {
+	cassert.NotNil(o, "outbound is nil")
+
+	o.stopOnce.Do(func() {
+		o.mu.Lock()
+		cancel := o.cancel
+		o.cancel = nil
+		o.mu.Unlock()
+
+		if cancel != nil {
+			cancel()
+		}
+
+		o.doneOnce.Do(func() { close(o.done) })
+	})
+
+	select {
+	case <-ctx.Done():
+		return lifecycle.ErrShutdown(lifecycle.ErrShutdownTimeout, ctx.Err())
+	default:
+		return nil
+	}
+}
+
+// Done returns the channel that is closed after Stop has been called.
+func (o *outbound) Done() <-chan struct{} {
+	cassert.NotNil(o, "outbound is nil")
+
+	return o.done
+}
+
+// Stats returns a snapshot of the adapter's runtime statistics: Sent
+// counts the files written, Failed the failed writes and DeadLettered
+// those accepted by the DLQ. Subscribers is 1 while the source
+// subscription is active.
+func (o *outbound) Stats() messaging.Stats {
+	cassert.NotNil(o, "outbound is nil")
+
+	stats := o.stats.Snapshot()
+
+	o.mu.Lock()
+	if o.cancel != nil {
+		stats.Subscribers = 1
+	}
+	o.mu.Unlock()
+
+	return stats
+}
+
+// handle is the Handler[[]byte] subscribed on the source channel. It
+// writes msg; failures are dead-lettered and reported through the
+// configured ErrorHandler. The function itself always returns nil so
+// adapter concerns never propagate to the source channel's Send
+// caller.
+func (o *outbound) handle(ctx context.Context, msg messaging.Message[[]byte]) error {
+	end := o.stats.Begin()
+	defer end(nil)
+
+	err := o.write(msg)
+	if err != nil {
+		o.stats.RecordFailure()
+		o.deadLetter(ctx, msg, err)
+
+		if o.errorHandler != nil {
+			o.errorHandler(ctx, msg, err)
+		}
+
+		return nil
+	}
+
+	o.stats.RecordSent()
+
+	return nil
+}
+
+// write resolves the file name and writes the payload atomically.
+func (o *outbound) write(msg messaging.Message[[]byte]) error {
+	name := o.fileName(msg)
+	if !validFileName(name) {
+		return ErrAdapter(ErrFileNameInvalid)
+	}
+
+	err := writeAtomic(o.dir, name, msg.Payload, o.fileMode)
+	if err != nil {
+		return ErrAdapter(ErrWriteFailed, err)
+	}
+
+	return nil
+}
+
+// defaultFileName names the file after Headers.Custom[HeaderFileName],
+// then Headers.MessageID, then the clock's Unix nanoseconds.
+func (o *outbound) defaultFileName(msg messaging.Message[[]byte]) string {
+	name, ok := msg.Headers.Custom[HeaderFileName].(string)
+	if ok && name != "" {
+		return name
+	}
+
+	if msg.Headers.MessageID != "" {
+		return msg.Headers.MessageID
+	}
+
+	return strconv.FormatInt(o.clock.Now().UnixNano(), 10)
+}
+
+// deadLetter publishes msg to the DLQ best-effort through
+// messaging.PublishDeadLetter, stamped with the adapter clock.
+func (o *outbound) deadLetter(ctx context.Context, msg messaging.Message[[]byte], cause error) {
+	if messaging.PublishDeadLetter(ctx, o.dlq, msg, cause, o.clock.Now()) {
+		o.stats.RecordDeadLetter()
+	}
+}
@@ -0,0 +1,292 @@
+package file
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/guidomantilla/yarumo/core/common/lifecycle"
+	"github.com/guidomantilla/yarumo/messaging"
+	"github.com/guidomantilla/yarumo/messaging/messagingtest"
+)
+
+func startOutbound(t *testing.T, out Outbound) {
+	t.Helper()
+
+	err := out.Start(context.Background())
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+
+	t.Cleanup(func() { _ = out.Stop(context.Background()) })
+}
+
+// readFile returns the content of path and fails t on error.
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read %s: %v", path, err)
+	}
+
+	return string(data)
+}
+
+func TestNewOutbound(t *testing.T) {
+	t.Parallel()
+
+	t.Run("writes each payload to a file named by the default rules", func(t *testing.T) {
+		t.Parallel()
+
+		dir := filepath.Join(t.TempDir(), "out")
+		src := messaging.NewPipelineChannel[[]byte]()
+		clock := messagingtest.NewFakeClock(time.Unix(0, 42))
+
+		out := NewOutbound("partner-out", src, dir, WithClock(clock))
+		startOutbound(t, out)
+
+		msgs := []messaging.Message[[]byte]{
+			{Payload: []byte("a,b"), Headers: messaging.Headers{MessageID: "m-1", Custom: map[string]any{HeaderFileName: "batch.csv"}}},
+			{Payload: []byte("c,d"), Headers: messaging.Headers{MessageID: "m-2"}},
+			{Payload: []byte("e,f")},
+		}
+
+		for _, msg := range msgs {
+			err := src.Send(context.Background(), msg)
+			if err != nil {
+				t.Fatalf("send: %v", err)
+			}
+		}
+
+		want := map[string]string{"batch.csv": "a,b", "m-2": "c,d", strconv.Itoa(42): "e,f"}
+		for name, content := range want {
+			if got := readFile(t, filepath.Join(dir, name)); got != content {
+				t.Fatalf("expected %s to hold %q, got %q", name, content, got)
+			}
+		}
+
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			t.Fatalf("read dir: %v", err)
+		}
+
+		if len(entries) != len(want) {
+			t.Fatalf("expected %d files and no temporaries, got %d", len(want), len(entries))
+		}
+
+		stats := out.Stats()
+		if stats.Delivered != 3 || stats.Sent != 3 || stats.Failed != 0 || stats.Subscribers != 1 {
+			t.Fatalf("unexpected stats %+v", stats)
+		}
+	})
+
+	t.Run("honours WithFileName and WithFileMode", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		src := messaging.NewPipelineChannel[[]byte]()
+
+		startOutbound(t, NewOutbound("partner-out", src, dir,
+			WithFileName(func(msg messaging.Message[[]byte]) string { return msg.Headers.Type + ".csv" }),
+			WithFileMode(0o600),
+		))
+
+		err := src.Send(context.Background(), messaging.Message[[]byte]{Payload: []byte("x"), Headers: messaging.Headers{Type: "orders"}})
+		if err != nil {
+			t.Fatalf("send: %v", err)
+		}
+
+		info, err := os.Stat(filepath.Join(dir, "orders.csv"))
+		if err != nil {
+			t.Fatalf("stat: %v", err)
+		}
+
+		if info.Mode().Perm() != 0o600 {
+			t.Fatalf("expected mode 0600, got %v", info.Mode().Perm())
+		}
+	})
+
+	t.Run("replaces an existing file with the same name", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		src := messaging.NewPipelineChannel[[]byte]()
+		writeFile(t, dir, "batch.csv", "old")
+
+		startOutbound(t, NewOutbound("partner-out", src, dir))
+
+		err := src.Send(context.Background(), messaging.Message[[]byte]{
+			Payload: []byte("new"),
+			Headers: messaging.Headers{Custom: map[string]any{HeaderFileName: "batch.csv"}},
+		})
+		if err != nil {
+			t.Fatalf("send: %v", err)
+		}
+
+		if got := readFile(t, filepath.Join(dir, "batch.csv")); got != "new" {
+			t.Fatalf("expected replaced content, got %q", got)
+		}
+	})
+
+	t.Run("dead-letters messages with an invalid file name", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		src := messaging.NewPipelineChannel[[]byte]()
+		dlq := messagingtest.NewRecordingChannel[messaging.DeadLetter[[]byte]]()
+		hook, errs := captureErrors()
+		instant := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+		out := NewOutbound("partner-out", src, dir,
+			WithDLQChannel(dlq),
+			WithErrorHandler(hook),
+			WithClock(messagingtest.NewFakeClock(instant)),
+		)
+		startOutbound(t, out)
+
+		err := src.Send(context.Background(), messaging.Message[[]byte]{
+			Payload: []byte("x"),
+			Headers: messaging.Headers{Custom: map[string]any{HeaderFileName: "../escape.csv"}},
+		})
+		if err != nil {
+			t.Fatalf("expected failures to stay off the Send path, got %v", err)
+		}
+
+		if exists(filepath.Join(filepath.Dir(dir), "escape.csv")) {
+			t.Fatal("expected no file outside the directory")
+		}
+
+		letters := dlq.Payloads()
+		if len(letters) != 1 || string(letters[0].Original.Payload) != "x" || !letters[0].FailedAt.Equal(instant) {
+			t.Fatalf("unexpected dead letters %+v", letters)
+		}
+
+		if !errors.Is(letters[0].LastError, ErrFileNameInvalid) {
+			t.Fatalf("expected ErrFileNameInvalid, got %v", letters[0].LastError)
+		}
+
+		if got := errs(); len(got) != 1 || !errors.Is(got[0], ErrFileNameInvalid) {
+			t.Fatalf("expected one reported failure, got %v", got)
+		}
+
+		stats := out.Stats()
+		if stats.Failed != 1 || stats.Sent != 0 || stats.DeadLettered != 1 {
+			t.Fatalf("unexpected stats %+v", stats)
+		}
+	})
+
+	t.Run("dead-letters failed writes", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		src := messaging.NewPipelineChannel[[]byte]()
+		dlq := messagingtest.NewRecordingChannel[messaging.DeadLetter[[]byte]]()
+
+		startOutbound(t, NewOutbound("partner-out", src, dir,
+			WithDLQChannel(dlq),
+			WithErrorHandler(messaging.SilentErrorHandler),
+		))
+
+		// A directory in the target's place makes the final rename fail.
+		err := os.Mkdir(filepath.Join(dir, "batch.csv"), 0o755)
+		if err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+
+		err = src.Send(context.Background(), messaging.Message[[]byte]{
+			Payload: []byte("x"),
+			Headers: messaging.Headers{Custom: map[string]any{HeaderFileName: "batch.csv"}},
+		})
+		if err != nil {
+			t.Fatalf("send: %v", err)
+		}
+
+		letters := dlq.Payloads()
+		if len(letters) != 1 || !errors.Is(letters[0].LastError, ErrWriteFailed) {
+			t.Fatalf("expected one ErrWriteFailed dead letter, got %+v", letters)
+		}
+
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			t.Fatalf("read dir: %v", err)
+		}
+
+		if len(entries) != 1 {
+			t.Fatalf("expected the temporary file to be removed, got %d entries", len(entries))
+		}
+	})
+}
+
+func TestOutbound_Lifecycle(t *testing.T) {
+	t.Parallel()
+
+	t.Run("fails to start when the directory cannot be created", func(t *testing.T) {
+		t.Parallel()
+
+		blocker := writeFile(t, t.TempDir(), "blocker", "x")
+		out := NewOutbound("partner-out", messaging.NewPipelineChannel[[]byte](), filepath.Join(blocker, "out"))
+
+		err := out.Start(context.Background())
+		if !errors.Is(err, lifecycle.ErrStartFailed) || !errors.Is(err, ErrDirFailed) {
+			t.Fatalf("expected ErrStartFailed with ErrDirFailed, got %v", err)
+		}
+	})
+
+	t.Run("start and stop are idempotent", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[[]byte]()
+		out := NewOutbound("partner-out", src, t.TempDir())
+
+		if out.Name() != "partner-out" {
+			t.Fatalf("unexpected name %q", out.Name())
+		}
+
+		for range 2 {
+			err := out.Start(context.Background())
+			if err != nil {
+				t.Fatalf("start: %v", err)
+			}
+		}
+
+		if got := out.Stats().Subscribers; got != 1 {
+			t.Fatalf("expected 1 subscriber, got %d", got)
+		}
+
+		for range 2 {
+			err := out.Stop(context.Background())
+			if err != nil {
+				t.Fatalf("stop: %v", err)
+			}
+		}
+
+		select {
+		case <-out.Done():
+		default:
+			t.Fatal("expected Done to be closed")
+		}
+
+		if got := out.Stats().Subscribers; got != 0 {
+			t.Fatalf("expected no subscribers after stop, got %d", got)
+		}
+	})
+
+	t.Run("stop reports an expired context", func(t *testing.T) {
+		t.Parallel()
+
+		out := NewOutbound("partner-out", messaging.NewPipelineChannel[[]byte](), t.TempDir())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := out.Stop(ctx)
+		if !errors.Is(err, lifecycle.ErrShutdownTimeout) {
+			t.Fatalf("expected ErrShutdownTimeout, got %v", err)
+		}
+	})
+}
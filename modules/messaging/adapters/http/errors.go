@@ -0,0 +1,70 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+	cerrs "github.com/guidomantilla/yarumo/core/common/errs"
+)
+
+// AdapterType is the error domain identifier for HTTP adapter
+// operations.
+const AdapterType = "http-adapter"
+
+var (
+	_ error = (*Error)(nil)
+)
+
+// Sentinel errors for HTTP adapter operations.
+var (
+	// ErrAdapterFailed is the top-level sentinel embedded in every
+	// adapter-domain Error returned by ErrAdapter.
+	ErrAdapterFailed = errors.New("http adapter failed")
+	// ErrBodyTooLarge indicates that an inbound request body exceeded
+	// the limit installed with WithMaxBodySize.
+	ErrBodyTooLarge = errors.New("request body too large")
+	// ErrReadBodyFailed indicates that reading an inbound request body
+	// failed.
+	ErrReadBodyFailed = errors.New("reading request body failed")
+	// ErrDecodeFailed indicates that an inbound request body could not
+	// be decoded into the payload type.
+	ErrDecodeFailed = errors.New("decoding request body failed")
+	// ErrHeaderFailed indicates that a mapped HTTP header could not be
+	// converted into its messaging header (Priority, ExpirationTime).
+	ErrHeaderFailed = errors.New("mapping header failed")
+	// ErrPublishFailed indicates that the inbound channel Send or
+	// gateway Request returned a non-nil error.
+	ErrPublishFailed = errors.New("publishing message failed")
+	// ErrEncodeFailed indicates that a payload could not be encoded
+	// into an HTTP body.
+	ErrEncodeFailed = errors.New("encoding body failed")
+	// ErrDeliveryFailed indicates that the outbound HTTP call failed
+	// or answered with a non-2xx status.
+	ErrDeliveryFailed = errors.New("http delivery failed")
+)
+
+// Error is the domain error type for HTTP adapter operations.
+type Error struct {
+	cerrs.TypedError
+}
+
+// Error returns the formatted error string including the type
+// classification.
+func (e *Error) Error() string {
+	cassert.NotNil(e, "error is nil")
+	cassert.NotNil(e.Err, "internal error is nil")
+
+	return fmt.Sprintf("http adapter %s error: %s", e.Type, e.Err)
+}
+
+// ErrAdapter wraps the given causes into a domain Error joined with
+// ErrAdapterFailed.
+func ErrAdapter(causes ...error) error {
+	return &Error{
+		TypedError: cerrs.TypedError{
+			Type: AdapterType,
+			Err:  errors.Join(append(causes, ErrAdapterFailed)...),
+		},
+	}
+}
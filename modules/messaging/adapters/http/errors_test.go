@@ -0,0 +1,49 @@
+package http
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestError_Error(t *testing.T) {
+	t.Parallel()
+
+	t.Run("includes type prefix and joined causes", func(t *testing.T) {
+		t.Parallel()
+
+		err := ErrAdapter(ErrDeliveryFailed)
+
+		msg := err.Error()
+		if !strings.HasPrefix(msg, "http adapter "+AdapterType) {
+			t.Fatalf("expected prefix %q, got %q", "http adapter "+AdapterType, msg)
+		}
+
+		if !strings.Contains(msg, ErrDeliveryFailed.Error()) {
+			t.Fatalf("expected cause %q in message, got %q", ErrDeliveryFailed.Error(), msg)
+		}
+
+		if !strings.Contains(msg, ErrAdapterFailed.Error()) {
+			t.Fatalf("expected sentinel %q in message, got %q", ErrAdapterFailed.Error(), msg)
+		}
+	})
+
+	t.Run("ErrAdapter joins all causes with ErrAdapterFailed", func(t *testing.T) {
+		t.Parallel()
+
+		boom := errors.New("custom failure")
+
+		err := ErrAdapter(ErrDeliveryFailed, boom)
+		if !errors.Is(err, ErrAdapterFailed) {
+			t.Fatal("expected ErrAdapterFailed in chain")
+		}
+
+		if !errors.Is(err, ErrDeliveryFailed) {
+			t.Fatal("expected ErrDeliveryFailed in chain")
+		}
+
+		if !errors.Is(err, boom) {
+			t.Fatal("expected origin error in chain")
+		}
+	})
+}
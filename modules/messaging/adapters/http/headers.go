@@ -0,0 +1,149 @@
+package http
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+// headerMapping pairs a canonical HTTP header name with the messaging
+// header it is copied to and from.
+type headerMapping struct {
+	http   string
+	header string
+}
+
+// headerField reads and writes one recognised messaging.Headers field
+// as its HTTP string representation.
+type headerField struct {
+	get func(h messaging.Headers) string
+	set func(h *messaging.Headers, v string) error
+}
+
+// headerFields maps the recognised messaging.Headers field names to
+// their HTTP accessors. Names absent from this table are treated as
+// Custom map keys.
+var headerFields = map[string]headerField{
+	"MessageID":     stringField(func(h *messaging.Headers) *string { return &h.MessageID }),
+	"CorrelationID": stringField(func(h *messaging.Headers) *string { return &h.CorrelationID }),
+	"CausationID":   stringField(func(h *messaging.Headers) *string { return &h.CausationID }),
+	"ReplyTo":       stringField(func(h *messaging.Headers) *string { return &h.ReplyTo }),
+	"Type":          stringField(func(h *messaging.Headers) *string { return &h.Type }),
+	"Source":        stringField(func(h *messaging.Headers) *string { return &h.Source }),
+	"ContentType":   stringField(func(h *messaging.Headers) *string { return &h.ContentType }),
+	"Priority": {
+		get: func(h messaging.Headers) string {
+			if h.Priority == 0 {
+				return ""
+			}
+
+			return strconv.Itoa(int(h.Priority))
+		},
+		set: func(h *messaging.Headers, v string) error {
+			p, err := strconv.ParseUint(v, 10, 8)
+			if err != nil {
+				return err
+			}
+
+			h.Priority = uint8(p)
+
+			return nil
+		},
+	},
+	"ExpirationTime": {
+		get: func(h messaging.Headers) string {
+			if h.ExpirationTime.IsZero() {
+				return ""
+			}
+
+			return h.ExpirationTime.Format(time.RFC3339Nano)
+		},
+		set: func(h *messaging.Headers, v string) error {
+			t, err := time.Parse(time.RFC3339Nano, v)
+			if err != nil {
+				return err
+			}
+
+			h.ExpirationTime = t
+
+			return nil
+		},
+	},
+}
+
+// stringField builds the accessors of a plain string Headers field
+// from a function returning its address.
+func stringField(ref func(h *messaging.Headers) *string) headerField {
+	return headerField{
+		get: func(h messaging.Headers) string { return *ref(&h) },
+		set: func(h *messaging.Headers, v string) error {
+			*ref(h) = v
+
+			return nil
+		},
+	}
+}
+
+// importHeaders copies every mapped, non-empty HTTP header from src
+// onto h. The Custom map is cloned before it is written so a map shared
+// with another envelope is never mutated.
+func importHeaders(h *messaging.Headers, src http.Header, mappings []headerMapping) error {
+	cloned := false
+
+	for _, m := range mappings {
+		v := src.Get(m.http)
+		if v == "" {
+			continue
+		}
+
+		field, ok := headerFields[m.header]
+		if ok {
+			err := field.set(h, v)
+			if err != nil {
+				return fmt.Errorf("%s: %w", m.http, err)
+			}
+
+			continue
+		}
+
+		if !cloned {
+			h.Custom = maps.Clone(h.Custom)
+			if h.Custom == nil {
+				h.Custom = map[string]any{}
+			}
+
+			cloned = true
+		}
+
+		h.Custom[m.header] = v
+	}
+
+	return nil
+}
+
+// exportHeaders returns the HTTP headers for every mapping whose
+// messaging header is set on h. Custom values are formatted with %v.
+func exportHeaders(h messaging.Headers, mappings []headerMapping) map[string]string {
+	out := make(map[string]string, len(mappings))
+
+	for _, m := range mappings {
+		var v string
+
+		field, ok := headerFields[m.header]
+		if ok {
+			v = field.get(h)
+		} else if raw, found := h.Custom[m.header]; found && raw != nil {
+			v = fmt.Sprint(raw)
+		}
+
+		if v != "" {
+			out[m.http] = v
+		}
+	}
+
+	return out
+}
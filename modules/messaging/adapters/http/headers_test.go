@@ -0,0 +1,170 @@
+package http
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+func TestImportHeaders(t *testing.T) {
+	t.Parallel()
+
+	mappings := []headerMapping{
+		{http: "X-Event-Id", header: "MessageID"},
+		{http: "X-Event-Type", header: "Type"},
+		{http: "X-Priority", header: "Priority"},
+		{http: "X-Expires", header: "ExpirationTime"},
+		{http: "X-Tenant", header: "tenant"},
+	}
+
+	t.Run("copies mapped headers into fields and Custom", func(t *testing.T) {
+		t.Parallel()
+
+		src := http.Header{}
+		src.Set("X-Event-Id", "evt-1")
+		src.Set("X-Event-Type", "order.created")
+		src.Set("X-Priority", "7")
+		src.Set("X-Expires", "2030-01-02T03:04:05Z")
+		src.Set("X-Tenant", "acme")
+		src.Set("X-Unmapped", "ignored")
+
+		shared := map[string]any{"origin": "webhook"}
+		h := messaging.Headers{MessageID: "generated", Custom: shared}
+
+		err := importHeaders(&h, src, mappings)
+		if err != nil {
+			t.Fatalf("import: %v", err)
+		}
+
+		if h.MessageID != "evt-1" || h.Type != "order.created" || h.Priority != 7 {
+			t.Fatalf("unexpected fields: %+v", h)
+		}
+
+		if !h.ExpirationTime.Equal(time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)) {
+			t.Fatalf("unexpected expiration %v", h.ExpirationTime)
+		}
+
+		if h.Custom["tenant"] != "acme" || h.Custom["origin"] != "webhook" || len(h.Custom) != 2 {
+			t.Fatalf("unexpected custom %v", h.Custom)
+		}
+
+		if _, ok := shared["tenant"]; ok {
+			t.Fatal("expected shared Custom map to stay untouched")
+		}
+	})
+
+	t.Run("leaves absent headers alone", func(t *testing.T) {
+		t.Parallel()
+
+		h := messaging.Headers{MessageID: "generated"}
+
+		err := importHeaders(&h, http.Header{}, mappings)
+		if err != nil {
+			t.Fatalf("import: %v", err)
+		}
+
+		if h.MessageID != "generated" || h.Custom != nil {
+			t.Fatalf("unexpected headers %+v", h)
+		}
+	})
+
+	t.Run("fails on invalid priority", func(t *testing.T) {
+		t.Parallel()
+
+		src := http.Header{}
+		src.Set("X-Priority", "high")
+
+		var h messaging.Headers
+
+		err := importHeaders(&h, src, mappings)
+		if err == nil {
+			t.Fatal("expected error for X-Priority=\"high\"")
+		}
+	})
+
+	t.Run("fails on invalid expiration", func(t *testing.T) {
+		t.Parallel()
+
+		src := http.Header{}
+		src.Set("X-Expires", "tomorrow")
+
+		var h messaging.Headers
+
+		err := importHeaders(&h, src, mappings)
+		if err == nil {
+			t.Fatal("expected error for X-Expires=\"tomorrow\"")
+		}
+	})
+}
+
+func TestExportHeaders(t *testing.T) {
+	t.Parallel()
+
+	t.Run("writes set fields and Custom values", func(t *testing.T) {
+		t.Parallel()
+
+		mappings := []headerMapping{
+			{http: "X-Correlation-Id", header: "CorrelationID"},
+			{http: "X-Priority", header: "Priority"},
+			{http: "X-Expires", header: "ExpirationTime"},
+			{http: "X-Attempt", header: "attempt"},
+			{http: "X-Source", header: "Source"},
+		}
+
+		h := messaging.Headers{
+			CorrelationID:  "corr-1",
+			Priority:       3,
+			ExpirationTime: time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC),
+			Custom:         map[string]any{"attempt": 2},
+		}
+
+		got := exportHeaders(h, mappings)
+
+		want := map[string]string{
+			"X-Correlation-Id": "corr-1",
+			"X-Priority":       "3",
+			"X-Expires":        "2030-01-02T03:04:05Z",
+			"X-Attempt":        "2",
+		}
+
+		if len(got) != len(want) {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+
+		for k, v := range want {
+			if got[k] != v {
+				t.Fatalf("expected %s=%q, got %q", k, v, got[k])
+			}
+		}
+	})
+
+	t.Run("round-trips through importHeaders", func(t *testing.T) {
+		t.Parallel()
+
+		mappings := []headerMapping{
+			{http: "X-Type", header: "Type"},
+			{http: "X-Reply-To", header: "ReplyTo"},
+			{http: "X-Tenant", header: "tenant"},
+		}
+
+		in := messaging.Headers{Type: "t", ReplyTo: "replies", Custom: map[string]any{"tenant": "acme"}}
+
+		src := http.Header{}
+		for k, v := range exportHeaders(in, mappings) {
+			src.Set(k, v)
+		}
+
+		var out messaging.Headers
+
+		err := importHeaders(&out, src, mappings)
+		if err != nil {
+			t.Fatalf("import: %v", err)
+		}
+
+		if out.Type != "t" || out.ReplyTo != "replies" || out.Custom["tenant"] != "acme" {
+			t.Fatalf("unexpected headers %+v", out)
+		}
+	})
+}
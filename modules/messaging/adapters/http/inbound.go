@@ -0,0 +1,198 @@
+package http
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+	cuids "github.com/guidomantilla/yarumo/core/common/uids"
+	"github.com/guidomantilla/yarumo/messaging"
+	"github.com/guidomantilla/yarumo/messaging/codec"
+	"github.com/guidomantilla/yarumo/messaging/patterns/endpoints/gateway"
+)
+
+// inbound holds the request-decoding configuration shared by the
+// channel and gateway handlers.
+type inbound struct {
+	format       codec.Format
+	headers      []headerMapping
+	errorHandler messaging.ErrorHandler
+	uid          cuids.UID
+	maxBodySize  int64
+}
+
+// inboundHandler publishes every decoded request to a channel and
+// answers 202 Accepted.
+type inboundHandler[T any] struct {
+	inbound
+
+	channel messaging.Channel[T]
+}
+
+// gatewayHandler sends every decoded request through a gateway and
+// answers 200 OK with the encoded reply.
+type gatewayHandler[Req, Res any] struct {
+	inbound
+
+	gateway gateway.Gateway[Req, Res]
+}
+
+// NewInboundHandler returns a net/http Handler that decodes each
+// request body into a T, maps the configured HTTP headers onto
+// messaging.Headers and publishes the Message[T] to channel. A
+// successful Send is answered with 202 Accepted and an empty body.
+// The handler does not restrict the HTTP method; mount it on a method
+// pattern ("POST /hooks/orders") to do so.
+//
+// Options:
+//   - WithFormat: payload format (default JSON).
+//   - WithHeaderMapping: HTTP header to messaging header mappings.
+//   - WithUIDGenerator: MessageID / CorrelationID generator.
+//   - WithMaxBodySize: body size limit (default DefaultMaxBodySize).
+//   - WithErrorHandler: failure hook (default messaging.DefaultErrorHandler).
+func NewInboundHandler[T any](channel messaging.Channel[T], opts ...Option) http.Handler {
+	cassert.NotNil(channel, "channel is nil")
+
+	return &inboundHandler[T]{
+		inbound: newInbound(NewOptions(opts...)),
+		channel: channel,
+	}
+}
+
+// NewGatewayHandler returns a net/http Handler that decodes each
+// request like NewInboundHandler but sends it through gw with
+// Gateway.RequestMessage, so the mapped headers travel with the
+// request. The reply payload is encoded with the configured format
+// and answered with 200 OK. The gateway must be started by the
+// caller; its request timeout bounds the webhook response time.
+//
+// It accepts the same options as NewInboundHandler.
+func NewGatewayHandler[Req, Res any](gw gateway.Gateway[Req, Res], opts ...Option) http.Handler {
+	cassert.NotNil(gw, "gateway is nil")
+
+	return &gatewayHandler[Req, Res]{
+		inbound: newInbound(NewOptions(opts...)),
+		gateway: gw,
+	}
+}
+
+// ServeHTTP decodes the request and publishes it to the channel.
+func (h *inboundHandler[T]) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cassert.NotNil(h, "inbound handler is nil")
+
+	msg, status, err := decodeMessage[T](&h.inbound, r)
+	if err != nil {
+		h.fail(w, r, msg, status, err)
+
+		return
+	}
+
+	err = h.channel.Send(r.Context(), msg)
+	if err != nil {
+		h.fail(w, r, msg, http.StatusServiceUnavailable, ErrAdapter(ErrPublishFailed, err))
+
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// ServeHTTP decodes the request, waits for the gateway reply and
+// writes it as the response body.
+func (h *gatewayHandler[Req, Res]) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cassert.NotNil(h, "gateway handler is nil")
+
+	msg, status, err := decodeMessage[Req](&h.inbound, r)
+	if err != nil {
+		h.fail(w, r, msg, status, err)
+
+		return
+	}
+
+	res, err := h.gateway.RequestMessage(r.Context(), msg)
+	if err != nil {
+		h.fail(w, r, msg, gatewayStatus(err), ErrAdapter(ErrPublishFailed, err))
+
+		return
+	}
+
+	body, err := h.format.Marshal(res)
+	if err != nil {
+		h.fail(w, r, msg, http.StatusInternalServerError, ErrAdapter(ErrEncodeFailed, err))
+
+		return
+	}
+
+	w.Header().Set("Content-Type", h.format.ContentType())
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body)
+}
+
+// newInbound extracts the inbound configuration from options.
+func newInbound(options *Options) inbound {
+	return inbound{
+		format:       options.format,
+		headers:      options.headers,
+		errorHandler: options.errorHandler,
+		uid:          options.uid,
+		maxBodySize:  options.maxBodySize,
+	}
+}
+
+// fail reports err through the ErrorHandler and answers with status.
+// The response body carries only the status text so internal error
+// details never leak to the webhook caller.
+func (in *inbound) fail(w http.ResponseWriter, r *http.Request, msg any, status int, err error) {
+	if in.errorHandler != nil {
+		in.errorHandler(r.Context(), msg, err)
+	}
+
+	http.Error(w, http.StatusText(status), status)
+}
+
+// decodeMessage reads the request body, decodes it into a T and builds
+// the Message[T] envelope with the mapped headers applied on top of
+// the generated ones. On failure it returns the HTTP status to answer
+// with alongside the adapter error.
+func decodeMessage[T any](in *inbound, r *http.Request) (messaging.Message[T], int, error) {
+	var payload T
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, in.maxBodySize+1))
+	if err != nil {
+		return messaging.Message[T]{}, http.StatusBadRequest, ErrAdapter(ErrReadBodyFailed, err)
+	}
+
+	if int64(len(body)) > in.maxBodySize {
+		return messaging.Message[T]{}, http.StatusRequestEntityTooLarge, ErrAdapter(ErrBodyTooLarge)
+	}
+
+	err = in.format.Unmarshal(body, &payload)
+	if err != nil {
+		return messaging.Message[T]{}, http.StatusBadRequest, ErrAdapter(ErrDecodeFailed, err)
+	}
+
+	msg := messaging.NewMessage(payload, in.uid)
+
+	err = importHeaders(&msg.Headers, r.Header, in.headers)
+	if err != nil {
+		return msg, http.StatusBadRequest, ErrAdapter(ErrHeaderFailed, err)
+	}
+
+	return msg, 0, nil
+}
+
+// gatewayStatus maps a gateway Request error onto the HTTP status
+// answered to the webhook caller.
+func gatewayStatus(err error) int {
+	switch {
+	case errors.Is(err, gateway.ErrRequestTimeout):
+		return http.StatusGatewayTimeout
+	case errors.Is(err, gateway.ErrRequestSendFailed),
+		errors.Is(err, gateway.ErrGatewayNotStarted),
+		errors.Is(err, gateway.ErrGatewayShuttingDown):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,320 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	cuids "github.com/guidomantilla/yarumo/core/common/uids"
+	"github.com/guidomantilla/yarumo/messaging"
+	"github.com/guidomantilla/yarumo/messaging/codec"
+	"github.com/guidomantilla/yarumo/messaging/messagingtest"
+	"github.com/guidomantilla/yarumo/messaging/patterns/endpoints/gateway"
+)
+
+type order struct {
+	ID    string `json:"id"`
+	Total int    `json:"total"`
+}
+
+// newCounterUID returns a UID that emits sequential test ids
+// ("id-1", "id-2", ...). Goroutine-safe.
+func newCounterUID() cuids.UID {
+	var n int64
+
+	return cuids.NewUID("test", func() (string, error) {
+		return fmt.Sprintf("id-%d", atomic.AddInt64(&n, 1)), nil
+	})
+}
+
+// captureErrors returns a thread-safe ErrorHandler that records every
+// reported error and a getter that returns a copy.
+func captureErrors() (messaging.ErrorHandler, func() []error) {
+	var mu sync.Mutex
+
+	captured := []error{}
+
+	handler := func(_ context.Context, _ any, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		captured = append(captured, err)
+	}
+
+	get := func() []error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return append([]error(nil), captured...)
+	}
+
+	return handler, get
+}
+
+func serve(t *testing.T, handler http.Handler, body string, headers map[string]string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPost, "/hooks", strings.NewReader(body))
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestNewInboundHandler(t *testing.T) {
+	t.Parallel()
+
+	t.Run("publishes decoded payload with mapped headers and answers 202", func(t *testing.T) {
+		t.Parallel()
+
+		ch := messagingtest.NewRecordingChannel[order]()
+
+		handler := NewInboundHandler[order](ch,
+			WithUIDGenerator(newCounterUID()),
+			WithHeaderMapping("X-Event-Type", "Type"),
+			WithHeaderMapping("X-Tenant", "tenant"),
+		)
+
+		rec := serve(t, handler, `{"id":"o-1","total":42}`, map[string]string{
+			"X-Event-Type": "order.created",
+			"X-Tenant":     "acme",
+		})
+
+		if rec.Code != http.StatusAccepted {
+			t.Fatalf("expected 202, got %d", rec.Code)
+		}
+
+		ch.AssertPayloads(t, order{ID: "o-1", Total: 42})
+
+		h := ch.Messages()[0].Headers
+		if h.MessageID != "id-1" || h.CorrelationID != "id-2" {
+			t.Fatalf("expected generated ids, got %q/%q", h.MessageID, h.CorrelationID)
+		}
+
+		if h.Type != "order.created" || h.Custom["tenant"] != "acme" {
+			t.Fatalf("expected mapped headers, got %+v", h)
+		}
+	})
+
+	t.Run("decodes with the configured format", func(t *testing.T) {
+		t.Parallel()
+
+		ch := messagingtest.NewRecordingChannel[order]()
+		format := codec.NewCBORFormat()
+
+		body, err := format.Marshal(order{ID: "o-2", Total: 7})
+		if err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+
+		rec := serve(t, NewInboundHandler[order](ch, WithFormat(format)), string(body), nil)
+		if rec.Code != http.StatusAccepted {
+			t.Fatalf("expected 202, got %d", rec.Code)
+		}
+
+		ch.AssertPayloads(t, order{ID: "o-2", Total: 7})
+	})
+
+	t.Run("answers 400 on undecodable body", func(t *testing.T) {
+		t.Parallel()
+
+		ch := messagingtest.NewRecordingChannel[order]()
+		hook, errs := captureErrors()
+
+		rec := serve(t, NewInboundHandler[order](ch, WithErrorHandler(hook)), `{not json`, nil)
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400, got %d", rec.Code)
+		}
+
+		ch.AssertCount(t, 0)
+
+		got := errs()
+		if len(got) != 1 || !errors.Is(got[0], ErrDecodeFailed) || !errors.Is(got[0], ErrAdapterFailed) {
+			t.Fatalf("expected ErrDecodeFailed, got %v", got)
+		}
+	})
+
+	t.Run("answers 400 on invalid mapped header", func(t *testing.T) {
+		t.Parallel()
+
+		ch := messagingtest.NewRecordingChannel[order]()
+		hook, errs := captureErrors()
+
+		handler := NewInboundHandler[order](ch, WithErrorHandler(hook), WithHeaderMapping("X-Priority", "Priority"))
+
+		rec := serve(t, handler, `{"id":"o-1"}`, map[string]string{"X-Priority": "urgent"})
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400, got %d", rec.Code)
+		}
+
+		ch.AssertCount(t, 0)
+
+		got := errs()
+		if len(got) != 1 || !errors.Is(got[0], ErrHeaderFailed) {
+			t.Fatalf("expected ErrHeaderFailed, got %v", got)
+		}
+	})
+
+	t.Run("answers 413 on oversized body", func(t *testing.T) {
+		t.Parallel()
+
+		ch := messagingtest.NewRecordingChannel[order]()
+		hook, errs := captureErrors()
+
+		handler := NewInboundHandler[order](ch, WithErrorHandler(hook), WithMaxBodySize(8))
+
+		rec := serve(t, handler, `{"id":"o-1","total":42}`, nil)
+		if rec.Code != http.StatusRequestEntityTooLarge {
+			t.Fatalf("expected 413, got %d", rec.Code)
+		}
+
+		ch.AssertCount(t, 0)
+
+		got := errs()
+		if len(got) != 1 || !errors.Is(got[0], ErrBodyTooLarge) {
+			t.Fatalf("expected ErrBodyTooLarge, got %v", got)
+		}
+	})
+
+	t.Run("answers 503 when the channel rejects the message", func(t *testing.T) {
+		t.Parallel()
+
+		ch := messagingtest.NewRecordingChannel[order]()
+		hook, errs := captureErrors()
+		boom := errors.New("boom")
+
+		_, err := ch.Subscribe(func(_ context.Context, _ messaging.Message[order]) error { return boom })
+		if err != nil {
+			t.Fatalf("subscribe: %v", err)
+		}
+
+		rec := serve(t, NewInboundHandler[order](ch, WithErrorHandler(hook)), `{"id":"o-1"}`, nil)
+		if rec.Code != http.StatusServiceUnavailable {
+			t.Fatalf("expected 503, got %d", rec.Code)
+		}
+
+		if strings.Contains(rec.Body.String(), "boom") {
+			t.Fatalf("expected internal error to stay hidden, got %q", rec.Body.String())
+		}
+
+		got := errs()
+		if len(got) != 1 || !errors.Is(got[0], ErrPublishFailed) || !errors.Is(got[0], boom) {
+			t.Fatalf("expected ErrPublishFailed joined with cause, got %v", got)
+		}
+	})
+}
+
+func TestNewGatewayHandler(t *testing.T) {
+	t.Parallel()
+
+	newGateway := func(t *testing.T, reply bool, opts ...gateway.Option) gateway.Gateway[order, order] {
+		t.Helper()
+
+		req := messaging.NewPipelineChannel[order]()
+		rep := messaging.NewPipelineChannel[order]()
+
+		_, err := req.Subscribe(func(ctx context.Context, msg messaging.Message[order]) error {
+			if !reply {
+				return nil
+			}
+
+			return rep.Send(ctx, messaging.Message[order]{
+				Payload: order{ID: msg.Payload.ID + "/" + msg.Headers.Type, Total: msg.Payload.Total * 2},
+				Headers: messaging.Headers{CorrelationID: msg.Headers.CorrelationID},
+			})
+		})
+		if err != nil {
+			t.Fatalf("subscribe: %v", err)
+		}
+
+		opts = append(opts, gateway.WithUIDGenerator(newCounterUID()))
+
+		return gateway.NewGateway[order, order]("webhook-gw", req, rep, opts...)
+	}
+
+	t.Run("answers 200 with the encoded reply", func(t *testing.T) {
+		t.Parallel()
+
+		gw := newGateway(t, true)
+
+		err := gw.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		t.Cleanup(func() { _ = gw.Stop(context.Background()) })
+
+		handler := NewGatewayHandler[order, order](gw, WithHeaderMapping("X-Event-Type", "Type"))
+
+		rec := serve(t, handler, `{"id":"o-1","total":21}`, map[string]string{"X-Event-Type": "quote"})
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", rec.Code)
+		}
+
+		if ct := rec.Header().Get("Content-Type"); ct != codec.ContentTypeJSON {
+			t.Fatalf("expected JSON content type, got %q", ct)
+		}
+
+		if body := strings.TrimSpace(rec.Body.String()); body != `{"id":"o-1/quote","total":42}` {
+			t.Fatalf("unexpected body %s", body)
+		}
+	})
+
+	t.Run("answers 504 when the reply times out", func(t *testing.T) {
+		t.Parallel()
+
+		gw := newGateway(t, false, gateway.WithRequestTimeout(20*time.Millisecond))
+
+		err := gw.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		t.Cleanup(func() { _ = gw.Stop(context.Background()) })
+
+		hook, errs := captureErrors()
+
+		rec := serve(t, NewGatewayHandler[order, order](gw, WithErrorHandler(hook)), `{"id":"o-1"}`, nil)
+		if rec.Code != http.StatusGatewayTimeout {
+			t.Fatalf("expected 504, got %d", rec.Code)
+		}
+
+		got := errs()
+		if len(got) != 1 || !errors.Is(got[0], gateway.ErrRequestTimeout) {
+			t.Fatalf("expected ErrRequestTimeout, got %v", got)
+		}
+	})
+
+	t.Run("answers 503 when the gateway is not started", func(t *testing.T) {
+		t.Parallel()
+
+		gw := newGateway(t, true)
+
+		rec := serve(t, NewGatewayHandler[order, order](gw, WithErrorHandler(messaging.SilentErrorHandler)), `{"id":"o-1"}`, nil)
+		if rec.Code != http.StatusServiceUnavailable {
+			t.Fatalf("expected 503, got %d", rec.Code)
+		}
+	})
+
+	t.Run("answers 400 on undecodable body", func(t *testing.T) {
+		t.Parallel()
+
+		gw := newGateway(t, true)
+
+		rec := serve(t, NewGatewayHandler[order, order](gw, WithErrorHandler(messaging.SilentErrorHandler)), `[]`, nil)
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400, got %d", rec.Code)
+		}
+	})
+}
@@ -0,0 +1,177 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+
+	chttp "github.com/guidomantilla/yarumo/core/common/http"
+	cuids "github.com/guidomantilla/yarumo/core/common/uids"
+	"github.com/guidomantilla/yarumo/messaging"
+	"github.com/guidomantilla/yarumo/messaging/codec"
+)
+
+// DefaultMaxBodySize is the default limit for inbound request bodies
+// applied when WithMaxBodySize is not configured.
+const DefaultMaxBodySize int64 = 1 << 20 // 1 MB
+
+// Option is a functional option for configuring adapter Options. The
+// inbound handlers and the outbound adapter share one Option type;
+// each option documents the side it applies to and is ignored by the
+// other.
+type Option func(opts *Options)
+
+// Options holds the configuration for the HTTP adapters.
+//
+// dlq is stored as any because the Channel[DeadLetter[T]] it holds is
+// parameterized by T, which Options itself is not. NewOutbound
+// type-asserts it to Channel[DeadLetter[T]] at build time and panics
+// via cassert when the T from WithDLQChannel does not match.
+type Options struct {
+	format       codec.Format
+	headers      []headerMapping
+	errorHandler messaging.ErrorHandler
+
+	uid         cuids.UID
+	maxBodySize int64
+
+	client chttp.Client
+	method string
+	dlq    any
+	clock  messaging.Clock
+}
+
+// NewOptions creates a new Options with sensible defaults and applies
+// the given options. Defaults: JSON format, no header mappings,
+// messaging.DefaultErrorHandler, no uid generator, DefaultMaxBodySize,
+// chttp.NewClient(), POST and messaging.SystemClock.
+func NewOptions(opts ...Option) *Options {
+	options := &Options{
+		format:       codec.NewJSONFormat(),
+		errorHandler: messaging.DefaultErrorHandler,
+		maxBodySize:  DefaultMaxBodySize,
+		client:       chttp.NewClient(),
+		method:       http.MethodPost,
+		clock:        messaging.SystemClock(),
+	}
+
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	return options
+}
+
+// WithFormat sets the codec.Format used to decode inbound bodies,
+// encode gateway replies and encode outbound bodies. Its content type
+// is stamped on every body the adapters write. Nil values are ignored.
+func WithFormat(format codec.Format) Option {
+	return func(opts *Options) {
+		if format != nil {
+			opts.format = format
+		}
+	}
+}
+
+// WithHeaderMapping maps the HTTP header httpHeader onto the messaging
+// header header, in both directions: inbound handlers copy the HTTP
+// value into Headers, the outbound adapter copies the Headers value
+// into the HTTP request. header is a recognised Headers field name or
+// a Headers.Custom key (see the package documentation). Repeat the
+// option to map several headers. Empty names are ignored.
+func WithHeaderMapping(httpHeader string, header string) Option {
+	return func(opts *Options) {
+		httpHeader = strings.TrimSpace(httpHeader)
+		header = strings.TrimSpace(header)
+
+		if httpHeader == "" || header == "" {
+			return
+		}
+
+		opts.headers = append(opts.headers, headerMapping{
+			http:   http.CanonicalHeaderKey(httpHeader),
+			header: header,
+		})
+	}
+}
+
+// WithErrorHandler installs an observability hook fired once per
+// failed inbound request or outbound delivery. The default (when
+// WithErrorHandler is not passed) is messaging.DefaultErrorHandler,
+// which logs via common/log. Pass messaging.SilentErrorHandler to opt
+// out. Nil values are ignored.
+func WithErrorHandler(handler messaging.ErrorHandler) Option {
+	return func(opts *Options) {
+		if handler != nil {
+			opts.errorHandler = handler
+		}
+	}
+}
+
+// WithUIDGenerator sets the generator inbound handlers use to populate
+// MessageID and CorrelationID when no mapped header supplies them.
+// Without it those fields are left empty. Nil values are ignored.
+func WithUIDGenerator(uid cuids.UID) Option {
+	return func(opts *Options) {
+		if uid != nil {
+			opts.uid = uid
+		}
+	}
+}
+
+// WithMaxBodySize sets the maximum inbound request body size in bytes.
+// Larger bodies are answered with 413. Non-positive values are
+// ignored.
+func WithMaxBodySize(n int64) Option {
+	return func(opts *Options) {
+		if n > 0 {
+			opts.maxBodySize = n
+		}
+	}
+}
+
+// WithClient sets the HTTP client the outbound adapter hands to
+// rest.Call. Compose the retry and breaker transports into it for
+// resilient delivery. Nil values are ignored.
+func WithClient(client chttp.Client) Option {
+	return func(opts *Options) {
+		if client != nil {
+			opts.client = client
+		}
+	}
+}
+
+// WithMethod sets the HTTP method of outbound requests (default POST).
+// Empty values are ignored.
+func WithMethod(method string) Option {
+	return func(opts *Options) {
+		method = strings.TrimSpace(method)
+		if method != "" {
+			opts.method = strings.ToUpper(method)
+		}
+	}
+}
+
+// WithDLQChannel installs the Dead Letter Channel the outbound adapter
+// publishes a DeadLetter[T] to when a delivery fails. The publication
+// is best-effort: failures of the DLQ Send itself are swallowed, and
+// the ErrorHandler fires independently. Type parameter T must match
+// the adapter's T; mismatches are caught with cassert at build time.
+// Nil values are ignored.
+func WithDLQChannel[T any](dlq messaging.Channel[messaging.DeadLetter[T]]) Option {
+	return func(opts *Options) {
+		if dlq != nil {
+			opts.dlq = dlq
+		}
+	}
+}
+
+// WithClock sets the clock the outbound adapter reads to stamp
+// DeadLetter.FailedAt and the stats failure timestamps. Nil values are
+// ignored (the system clock is preserved).
+func WithClock(clock messaging.Clock) Option {
+	return func(opts *Options) {
+		if clock != nil {
+			opts.clock = clock
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	chttp "github.com/guidomantilla/yarumo/core/common/http"
+	cuids "github.com/guidomantilla/yarumo/core/common/uids"
+	"github.com/guidomantilla/yarumo/messaging"
+	"github.com/guidomantilla/yarumo/messaging/codec"
+	"github.com/guidomantilla/yarumo/messaging/messagingtest"
+)
+
+func TestNewOptions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("applies defaults", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions()
+
+		if opts.format == nil || opts.format.ContentType() != codec.ContentTypeJSON {
+			t.Fatal("expected JSON format by default")
+		}
+
+		if opts.errorHandler == nil {
+			t.Fatal("expected default error handler")
+		}
+
+		if opts.maxBodySize != DefaultMaxBodySize {
+			t.Fatalf("expected max body size %d, got %d", DefaultMaxBodySize, opts.maxBodySize)
+		}
+
+		if opts.client == nil {
+			t.Fatal("expected default client")
+		}
+
+		if opts.method != http.MethodPost {
+			t.Fatalf("expected POST, got %q", opts.method)
+		}
+
+		if opts.uid != nil || opts.dlq != nil || len(opts.headers) != 0 {
+			t.Fatal("expected no uid, dlq or header mappings by default")
+		}
+
+		if opts.clock == nil {
+			t.Fatal("expected system clock by default")
+		}
+	})
+
+	t.Run("applies options", func(t *testing.T) {
+		t.Parallel()
+
+		client := chttp.NewClient()
+		uid := cuids.NewUID("test", func() (string, error) { return "id", nil })
+		dlq := messaging.NewPipelineChannel[messaging.DeadLetter[int]]()
+		clock := messagingtest.NewFakeClock(time.Time{})
+		called := false
+
+		opts := NewOptions(
+			WithFormat(codec.NewCBORFormat()),
+			WithHeaderMapping("x-event-type", "Type"),
+			WithHeaderMapping("X-Tenant", "tenant"),
+			WithErrorHandler(func(_ context.Context, _ any, _ error) { called = true }),
+			WithUIDGenerator(uid),
+			WithMaxBodySize(64),
+			WithClient(client),
+			WithMethod("put"),
+			WithDLQChannel(dlq),
+			WithClock(clock),
+		)
+
+		if opts.format.ContentType() != codec.ContentTypeCBOR {
+			t.Fatalf("expected CBOR format, got %q", opts.format.ContentType())
+		}
+
+		want := []headerMapping{{http: "X-Event-Type", header: "Type"}, {http: "X-Tenant", header: "tenant"}}
+		if len(opts.headers) != len(want) || opts.headers[0] != want[0] || opts.headers[1] != want[1] {
+			t.Fatalf("expected canonical mappings %v, got %v", want, opts.headers)
+		}
+
+		opts.errorHandler(context.Background(), nil, nil)
+
+		if !called {
+			t.Fatal("expected custom error handler")
+		}
+
+		if opts.uid == nil || opts.maxBodySize != 64 || opts.client == nil || opts.dlq == nil {
+			t.Fatal("expected uid, max body size, client and dlq to be set")
+		}
+
+		if opts.method != http.MethodPut {
+			t.Fatalf("expected PUT, got %q", opts.method)
+		}
+
+		if opts.clock != clock {
+			t.Fatal("expected custom clock")
+		}
+	})
+
+	t.Run("ignores nil, empty and non-positive values", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(
+			WithFormat(nil),
+			WithHeaderMapping("", "Type"),
+			WithHeaderMapping("X-Type", " "),
+			WithErrorHandler(nil),
+			WithUIDGenerator(nil),
+			WithMaxBodySize(0),
+			WithClient(nil),
+			WithMethod(""),
+			WithDLQChannel[int](nil),
+			WithClock(nil),
+		)
+
+		if opts.format == nil || opts.errorHandler == nil || opts.client == nil || opts.clock == nil {
+			t.Fatal("expected defaults to be preserved")
+		}
+
+		if len(opts.headers) != 0 || opts.uid != nil || opts.dlq != nil {
+			t.Fatal("expected no mappings, uid or dlq")
+		}
+
+		if opts.maxBodySize != DefaultMaxBodySize || opts.method != http.MethodPost {
+			t.Fatal("expected default max body size and method")
+		}
+	})
+}
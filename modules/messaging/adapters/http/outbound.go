@@ -0,0 +1,238 @@
+package http
+
+import (
+	"context"
+	"sync"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+	chttp "github.com/guidomantilla/yarumo/core/common/http"
+	"github.com/guidomantilla/yarumo/core/common/lifecycle"
+	"github.com/guidomantilla/yarumo/core/common/rest"
+	"github.com/guidomantilla/yarumo/messaging"
+	"github.com/guidomantilla/yarumo/messaging/codec"
+)
+
+// outbound is the outbound HTTP adapter implementation. It owns a
+// single subscription on the source channel (registered in Start,
+// cancelled in Stop) and delivers each received message with one
+// rest.Call.
+type outbound[T any] struct {
+	name         string
+	src          messaging.Channel[T]
+	url          string
+	method       string
+	client       chttp.Client
+	format       codec.Format
+	headers      []headerMapping
+	dlq          messaging.Channel[messaging.DeadLetter[T]]
+	errorHandler messaging.ErrorHandler
+	clock        messaging.Clock
+	stats        messaging.StatsRecorder
+
+	done      chan struct{}
+	startOnce sync.Once
+	stopOnce  sync.Once
+	doneOnce  sync.Once
+
+	mu     sync.Mutex
+	cancel messaging.Cancel
+}
+
+// NewOutbound constructs an Outbound that subscribes to src and sends
+// every received Message[T] to url: the payload encoded with the
+// configured format as the body, the mapped headers as HTTP headers.
+// Any 2xx answer is a successful delivery. The adapter is not running
+// on return; call lifecycle.Build (or Start directly) to register the
+// subscription.
+//
+// name is used in lifecycle logs and must be non-empty. src and url
+// are mandatory.
+//
+// Options:
+//   - WithClient: HTTP client carrying the retry / breaker transports
+//     (default chttp.NewClient()).
+//   - WithMethod: HTTP method (default POST).
+//   - WithFormat: payload format (default JSON).
+//   - WithHeaderMapping: messaging header to HTTP header mappings.
+//   - WithDLQChannel: Dead Letter Channel for failed deliveries.
+//   - WithErrorHandler: failure hook (default messaging.DefaultErrorHandler).
+//   - WithClock: clock stamping dead letters (default messaging.SystemClock).
+func NewOutbound[T any](name string, src messaging.Channel[T], url string, opts ...Option) Outbound[T] {
+	cassert.NotEmpty(name, "name is empty")
+	cassert.NotNil(src, "source channel is nil")
+	cassert.NotEmpty(url, "url is empty")
+
+	options := NewOptions(opts...)
+
+	var dlq messaging.Channel[messaging.DeadLetter[T]]
+
+	if options.dlq != nil {
+		typed, ok := options.dlq.(messaging.Channel[messaging.DeadLetter[T]])
+		cassert.True(ok, "WithDLQChannel type parameter does not match outbound type T")
+
+		dlq = typed
+	}
+
+	o := &outbound[T]{
+		name:         name,
+		src:          src,
+		url:          url,
+		method:       options.method,
+		client:       options.client,
+		format:       options.format,
+		headers:      options.headers,
+		dlq:          dlq,
+		errorHandler: options.errorHandler,
+		clock:        options.clock,
+		done:         make(chan struct{}),
+	}
+
+	o.stats.UseClock(options.clock)
+
+	return o
+}
+
+// Name returns the adapter's identity used in lifecycle logs.
+func (o *outbound[T]) Name() string {
+	cassert.NotNil(o, "outbound is nil")
+
+	return o.name
+}
+
+// Start registers the delivery handler as a subscriber on the source
+// channel. It satisfies the lifecycle.Component worker-style contract:
+// Start returns immediately after the subscription is in place; the
+// deliveries run in the source channel's goroutine model. Start is
+// idempotent — a second invocation returns nil without re-subscribing.
+func (o *outbound[T]) Start(_ context.Context) error {
+	cassert.NotNil(o, "outbound is nil")
+
+	var startErr error
+
+	o.startOnce.Do(func() {
+		cancel, err := o.src.Subscribe(o.handle)
+		if err != nil {
+			startErr = lifecycle.ErrStart(err)
+
+			return
+		}
+
+		o.mu.Lock()
+		o.cancel = cancel
+		o.mu.Unlock()
+	})
+
+	return startErr
+}
+
+// Stop cancels the source-channel subscription and closes Done. Stop
+// is idempotent per the lifecycle.Component contract. It returns
+// lifecycle.ErrShutdown wrapping lifecycle.ErrShutdownTimeout when ctx
+// is already expired on entry; otherwise nil.
+func (o *outbound[T]) Stop(ctx context.Context) error {
+	cassert.NotNil(o, "outbound is nil")
+
+	o.stopOnce.Do(func() {
+		o.mu.Lock()
+		cancel := o.cancel
+		o.cancel = nil
+		o.mu.Unlock()
+
+		if cancel != nil {
+			cancel()
+		}
+
+		o.doneOnce.Do(func() { close(o.done) })
+	})
+
+	select {
+	case <-ctx.Done():
+		return lifecycle.ErrShutdown(lifecycle.ErrShutdownTimeout, ctx.Err())
+	default:
+		return nil
+	}
+}
+
+// Done returns the channel that is closed after Stop has been called.
+func (o *outbound[T]) Done() <-chan struct{} {
+	cassert.NotNil(o, "outbound is nil")
+
+	return o.done
+}
+
+// Stats returns a snapshot of the adapter's runtime statistics: Sent
+// counts successful deliveries, Failed the failed ones and
+// DeadLettered those accepted by the DLQ. Subscribers is 1 while the
+// source subscription is active.
+func (o *outbound[T]) Stats() messaging.Stats {
+	cassert.NotNil(o, "outbound is nil")
+
+	stats := o.stats.Snapshot()
+
+	o.mu.Lock()
+	if o.cancel != nil {
+		stats.Subscribers = 1
+	}
+	o.mu.Unlock()
+
+	return stats
+}
+
+// handle is the Handler[T] subscribed on the source channel. It
+// delivers msg; failures are dead-lettered and reported through the
+// configured ErrorHandler. The function itself always returns nil so
+// adapter concerns never propagate to the source channel's Send
+// caller.
+func (o *outbound[T]) handle(ctx context.Context, msg messaging.Message[T]) error {
+	end := o.stats.Begin()
+	defer end(nil)
+
+	err := o.deliver(ctx, msg)
+	if err != nil {
+		o.stats.RecordFailure()
+		o.deadLetter(ctx, msg, err)
+
+		if o.errorHandler != nil {
+			o.errorHandler(ctx, msg, err)
+		}
+
+		return nil
+	}
+
+	o.stats.RecordSent()
+
+	return nil
+}
+
+// deliver encodes msg and executes the HTTP request via rest.Call.
+func (o *outbound[T]) deliver(ctx context.Context, msg messaging.Message[T]) error {
+	body, err := o.format.Marshal(msg.Payload)
+	if err != nil {
+		return ErrAdapter(ErrEncodeFailed, err)
+	}
+
+	headers := exportHeaders(msg.Headers, o.headers)
+	headers["Content-Type"] = o.format.ContentType()
+
+	spec := &rest.RequestSpec{
+		Method:  o.method,
+		URL:     o.url,
+		Headers: headers,
+		Body:    body,
+	}
+
+	_, err = rest.Call[[]byte](ctx, spec, rest.WithClient(o.client))
+	if err != nil {
+		return ErrAdapter(ErrDeliveryFailed, err)
+	}
+
+	return nil
+}
+
+// deadLetter publishes msg to the DLQ best-effort through
+// messaging.PublishDeadLetter, stamped with the adapter clock.
+func (o *outbound[T]) deadLetter(ctx context.Context, msg messaging.Message[T], cause error) {
+	if messaging.PublishDeadLetter(ctx, o.dlq, msg, cause, o.clock.Now()) {
+		o.stats.RecordDeadLetter()
+	}
+}
@@ -0,0 +1,309 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	chttp "github.com/guidomantilla/yarumo/core/common/http"
+	"github.com/guidomantilla/yarumo/core/common/rest"
+	"github.com/guidomantilla/yarumo/messaging"
+	"github.com/guidomantilla/yarumo/messaging/messagingtest"
+)
+
+// captured is one request received by the test server.
+type captured struct {
+	method  string
+	headers http.Header
+	body    string
+}
+
+// newServer starts an httptest server that records every request and
+// answers with status. Requests are returned by the getter.
+func newServer(t *testing.T, status int) (*httptest.Server, func() []captured) {
+	t.Helper()
+
+	var mu sync.Mutex
+
+	requests := []captured{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		mu.Lock()
+		requests = append(requests, captured{method: r.Method, headers: r.Header.Clone(), body: string(body)})
+		mu.Unlock()
+
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+
+	get := func() []captured {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return append([]captured(nil), requests...)
+	}
+
+	return srv, get
+}
+
+func startOutbound(t *testing.T, out Outbound[order]) {
+	t.Helper()
+
+	err := out.Start(context.Background())
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+
+	t.Cleanup(func() { _ = out.Stop(context.Background()) })
+}
+
+func TestNewOutbound(t *testing.T) {
+	t.Parallel()
+
+	t.Run("posts the encoded payload with mapped headers", func(t *testing.T) {
+		t.Parallel()
+
+		srv, requests := newServer(t, http.StatusNoContent)
+		src := messaging.NewPipelineChannel[order]()
+
+		out := NewOutbound[order]("orders-webhook", src, srv.URL,
+			WithHeaderMapping("X-Event-Type", "Type"),
+			WithHeaderMapping("X-Tenant", "tenant"),
+		)
+		startOutbound(t, out)
+
+		err := src.Send(context.Background(), messaging.Message[order]{
+			Payload: order{ID: "o-1", Total: 42},
+			Headers: messaging.Headers{Type: "order.created", Custom: map[string]any{"tenant": "acme"}},
+		})
+		if err != nil {
+			t.Fatalf("send: %v", err)
+		}
+
+		got := requests()
+		if len(got) != 1 {
+			t.Fatalf("expected 1 request, got %d", len(got))
+		}
+
+		if got[0].method != http.MethodPost || got[0].body != `{"id":"o-1","total":42}` {
+			t.Fatalf("unexpected request %s %s", got[0].method, got[0].body)
+		}
+
+		if got[0].headers.Get("Content-Type") != "application/json" ||
+			got[0].headers.Get("X-Event-Type") != "order.created" ||
+			got[0].headers.Get("X-Tenant") != "acme" {
+			t.Fatalf("unexpected headers %v", got[0].headers)
+		}
+
+		stats := out.Stats()
+		if stats.Delivered != 1 || stats.Sent != 1 || stats.Failed != 0 || stats.Subscribers != 1 {
+			t.Fatalf("unexpected stats %+v", stats)
+		}
+	})
+
+	t.Run("honours WithMethod", func(t *testing.T) {
+		t.Parallel()
+
+		srv, requests := newServer(t, http.StatusOK)
+		src := messaging.NewPipelineChannel[order]()
+
+		startOutbound(t, NewOutbound[order]("orders-webhook", src, srv.URL, WithMethod(http.MethodPut)))
+
+		err := src.Send(context.Background(), messaging.Message[order]{Payload: order{ID: "o-1"}})
+		if err != nil {
+			t.Fatalf("send: %v", err)
+		}
+
+		if got := requests(); len(got) != 1 || got[0].method != http.MethodPut {
+			t.Fatalf("expected one PUT, got %v", got)
+		}
+	})
+
+	t.Run("dead-letters failed deliveries", func(t *testing.T) {
+		t.Parallel()
+
+		srv, _ := newServer(t, http.StatusInternalServerError)
+		src := messaging.NewPipelineChannel[order]()
+		dlq := messagingtest.NewRecordingChannel[messaging.DeadLetter[order]]()
+		hook, errs := captureErrors()
+		instant := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+		out := NewOutbound[order]("orders-webhook", src, srv.URL,
+			WithDLQChannel(dlq),
+			WithErrorHandler(hook),
+			WithClock(messagingtest.NewFakeClock(instant)),
+		)
+		startOutbound(t, out)
+
+		err := src.Send(context.Background(), messaging.Message[order]{Payload: order{ID: "o-1"}})
+		if err != nil {
+			t.Fatalf("expected failures to stay off the Send path, got %v", err)
+		}
+
+		letters := dlq.Payloads()
+		if len(letters) != 1 || letters[0].Original.Payload.ID != "o-1" || !letters[0].FailedAt.Equal(instant) {
+			t.Fatalf("unexpected dead letters %+v", letters)
+		}
+
+		var httpErr *rest.HTTPError
+		if !errors.Is(letters[0].LastError, ErrDeliveryFailed) || !errors.As(letters[0].LastError, &httpErr) {
+			t.Fatalf("expected ErrDeliveryFailed with HTTPError, got %v", letters[0].LastError)
+		}
+
+		if httpErr.StatusCode != http.StatusInternalServerError {
+			t.Fatalf("expected status 500, got %d", httpErr.StatusCode)
+		}
+
+		if got := errs(); len(got) != 1 || !errors.Is(got[0], ErrDeliveryFailed) {
+			t.Fatalf("expected one reported failure, got %v", got)
+		}
+
+		stats := out.Stats()
+		if stats.Failed != 1 || stats.Sent != 0 || stats.DeadLettered != 1 {
+			t.Fatalf("unexpected stats %+v", stats)
+		}
+	})
+
+	t.Run("delivers through the configured client", func(t *testing.T) {
+		t.Parallel()
+
+		srv, requests := newServer(t, http.StatusOK)
+		src := messaging.NewPipelineChannel[order]()
+
+		var calls atomic.Int32
+
+		transport := chttp.RoundTripperFn(func(req *http.Request) (*http.Response, error) {
+			if calls.Add(1) == 1 {
+				return nil, errors.New("connection reset")
+			}
+
+			return http.DefaultTransport.RoundTrip(req)
+		})
+		client := chttp.NewClient(chttp.WithTransport(transport))
+		dlq := messagingtest.NewRecordingChannel[messaging.DeadLetter[order]]()
+
+		startOutbound(t, NewOutbound[order]("orders-webhook", src, srv.URL,
+			WithClient(client),
+			WithDLQChannel(dlq),
+			WithErrorHandler(messaging.SilentErrorHandler),
+		))
+
+		for _, id := range []string{"o-1", "o-2"} {
+			err := src.Send(context.Background(), messaging.Message[order]{Payload: order{ID: id}})
+			if err != nil {
+				t.Fatalf("send: %v", err)
+			}
+		}
+
+		if calls.Load() != 2 {
+			t.Fatalf("expected 2 transport calls, got %d", calls.Load())
+		}
+
+		if got := requests(); len(got) != 1 || got[0].body != `{"id":"o-2","total":0}` {
+			t.Fatalf("expected only o-2 to reach the server, got %v", got)
+		}
+
+		if letters := dlq.Payloads(); len(letters) != 1 || letters[0].Original.Payload.ID != "o-1" {
+			t.Fatalf("expected o-1 dead-lettered, got %+v", letters)
+		}
+	})
+
+	t.Run("reports encode failures", func(t *testing.T) {
+		t.Parallel()
+
+		srv, requests := newServer(t, http.StatusOK)
+		src := messaging.NewPipelineChannel[chan int]()
+		hook, errs := captureErrors()
+
+		out := NewOutbound[chan int]("chan-webhook", src, srv.URL, WithErrorHandler(hook))
+
+		err := out.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		t.Cleanup(func() { _ = out.Stop(context.Background()) })
+
+		err = src.Send(context.Background(), messaging.Message[chan int]{Payload: make(chan int)})
+		if err != nil {
+			t.Fatalf("send: %v", err)
+		}
+
+		if len(requests()) != 0 {
+			t.Fatal("expected no request for an unencodable payload")
+		}
+
+		if got := errs(); len(got) != 1 || !errors.Is(got[0], ErrEncodeFailed) {
+			t.Fatalf("expected ErrEncodeFailed, got %v", got)
+		}
+	})
+}
+
+func TestOutbound_Lifecycle(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Start and Stop are idempotent and Stop unsubscribes", func(t *testing.T) {
+		t.Parallel()
+
+		srv, requests := newServer(t, http.StatusOK)
+		src := messaging.NewPipelineChannel[order]()
+
+		out := NewOutbound[order]("orders-webhook", src, srv.URL)
+
+		if out.Name() != "orders-webhook" {
+			t.Fatalf("unexpected name %q", out.Name())
+		}
+
+		for range 2 {
+			err := out.Start(context.Background())
+			if err != nil {
+				t.Fatalf("start: %v", err)
+			}
+		}
+
+		for range 2 {
+			err := out.Stop(context.Background())
+			if err != nil {
+				t.Fatalf("stop: %v", err)
+			}
+		}
+
+		select {
+		case <-out.Done():
+		default:
+			t.Fatal("expected Done to be closed")
+		}
+
+		if out.Stats().Subscribers != 0 {
+			t.Fatal("expected no subscription after Stop")
+		}
+
+		_ = src.Send(context.Background(), messaging.Message[order]{Payload: order{ID: "o-1"}})
+
+		if len(requests()) != 0 {
+			t.Fatal("expected no delivery after Stop")
+		}
+	})
+
+	t.Run("Stop reports an expired context", func(t *testing.T) {
+		t.Parallel()
+
+		out := NewOutbound[order]("orders-webhook", messaging.NewPipelineChannel[order](), "http://localhost")
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := out.Stop(ctx)
+		if err == nil {
+			t.Fatal("expected error on expired context")
+		}
+	})
+}
@@ -0,0 +1,93 @@
+// Package http provides HTTP channel adapters that connect webhook
+// traffic to messaging.Channel[T].
+//
+// Two directions are covered:
+//
+//   - Inbound: NewInboundHandler returns a net/http Handler that
+//     decodes each request body into a T, maps the configured HTTP
+//     headers onto messaging.Headers, publishes the Message[T] to a
+//     channel and answers 202 Accepted. NewGatewayHandler does the
+//     same through a gateway.Gateway[Req, Res] and answers 200 OK with
+//     the encoded reply, turning an asynchronous flow into a
+//     synchronous webhook response.
+//   - Outbound: NewOutbound subscribes to a Channel[T] and POSTs every
+//     received message to a fixed URL via core/common/rest.Call.
+//     Failed deliveries are published to the Dead Letter Channel
+//     installed with WithDLQChannel.
+//
+// # Wire format
+//
+// Only the payload travels in the HTTP body; it is (de)serialized with
+// a codec.Format (WithFormat, defaulting to codec.NewJSONFormat). The
+// envelope headers travel as HTTP headers, one per WithHeaderMapping
+// entry. A mapping names an HTTP header and a messaging header: the
+// recognised Headers fields (MessageID, CorrelationID, CausationID,
+// ReplyTo, Type, Source, ContentType, Priority and ExpirationTime, the
+// latter as RFC 3339) are read and written in place, any other name
+// is a Headers.Custom key. Unmapped HTTP headers are ignored.
+//
+// # Resilience
+//
+// The outbound adapter executes requests through the chttp.Client
+// installed with WithClient. Retries and circuit breaking are not
+// re-implemented here: compose the retry and breaker transports from
+// modules/extension/common/http into that client, exactly as any
+// other rest.Call consumer does:
+//
+//	transport := chttpretry.NewRetryTransport(
+//	    chttpbreaker.NewBreakerTransport(http.DefaultTransport, breaker),
+//	    retrier,
+//	)
+//	out := adapterhttp.NewOutbound[Order]("orders-webhook", orders, url,
+//	    adapterhttp.WithClient(chttp.NewClient(chttp.WithTransport(transport))),
+//	    adapterhttp.WithDLQChannel(ordersDLQ),
+//	)
+//
+// By the time a delivery reaches the DLQ every configured retry has
+// already been spent.
+//
+// # Lifecycle
+//
+// The inbound handlers are plain http.Handler values and are mounted
+// on whatever server the application runs (managed/http). Outbound
+// implements common/lifecycle.Component (worker-style): Start
+// subscribes to the source channel and returns immediately; Stop
+// cancels the subscription and closes Done.
+//
+// # Error handling
+//
+// Inbound failures are answered with the matching HTTP status (400
+// for undecodable bodies or headers, 413 for oversized bodies, 503
+// when the channel or gateway is unavailable, 504 on gateway timeout)
+// and reported through the ErrorHandler. The outbound handler always
+// returns nil so delivery failures never propagate to the source
+// channel's Send caller; they are reported through the ErrorHandler
+// and dead-lettered.
+package http
+
+import (
+	"net/http"
+
+	"github.com/guidomantilla/yarumo/core/common/lifecycle"
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+var (
+	_ http.Handler  = (*inboundHandler[any])(nil)
+	_ http.Handler  = (*gatewayHandler[any, any])(nil)
+	_ Outbound[any] = (*outbound[any])(nil)
+
+	_ ErrAdapterFn = ErrAdapter
+)
+
+// Outbound is the public interface for the outbound HTTP adapter. It
+// embeds lifecycle.Component so callers wire it up with
+// lifecycle.Build, and messaging.Inspectable to expose its runtime
+// statistics.
+type Outbound[T any] interface {
+	lifecycle.Component
+	messaging.Inspectable
+}
+
+// ErrAdapterFn is the function type for ErrAdapter.
+type ErrAdapterFn func(causes ...error) error
@@ -0,0 +1,193 @@
+package messaging
+
+import (
+	"hash/fnv"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+)
+
+var (
+	_ LoadBalancer = (*roundRobin)(nil)
+	_ LoadBalancer = (*weighted)(nil)
+	_ LoadBalancer = (*leastInFlight)(nil)
+	_ LoadBalancer = random{}
+	_ LoadBalancer = (*stickyByKey)(nil)
+)
+
+// SubscribeWith registers handler on ch with the given
+// SubscriptionOptions when ch implements OptionSubscriber, and falls
+// back to a plain Subscribe — the options are ignored — otherwise.
+func SubscribeWith[T any](ch Channel[T], handler Handler[T], opts ...SubscriptionOption) (Cancel, error) {
+	cassert.NotNil(ch, "channel is nil")
+
+	subscriber, ok := ch.(OptionSubscriber[T])
+	if !ok {
+		return ch.Subscribe(handler)
+	}
+
+	return subscriber.SubscribeWith(handler, opts...)
+}
+
+// RoundRobin returns a LoadBalancer that hands messages to the
+// candidates in turn. It is the QueueChannel default.
+func RoundRobin() LoadBalancer {
+	return &roundRobin{}
+}
+
+// roundRobin is the RoundRobin strategy.
+type roundRobin struct {
+	next atomic.Uint64
+}
+
+// Select returns the next candidate in the rotation.
+func (b *roundRobin) Select(_ Headers, candidates []SubscriberState) int {
+	return int((b.next.Add(1) - 1) % uint64(len(candidates)))
+}
+
+// Weighted returns a LoadBalancer that shares messages among the
+// candidates in proportion to their weight (see
+// WithSubscriptionWeight), interleaved rather than in bursts: weights
+// 2 and 1 give a, b, a, a, b, a, ... — the smooth weighted round-robin.
+func Weighted() LoadBalancer {
+	return &weighted{current: map[uint64]int{}}
+}
+
+// weighted is the Weighted strategy. current holds each subscriber's
+// accumulated credit.
+type weighted struct {
+	mu      sync.Mutex
+	current map[uint64]int
+}
+
+// Select credits every candidate its weight and returns the one with
+// the most credit, which then pays the total back. Credit of
+// subscribers no longer offered is forgotten once they outnumber the
+// candidates.
+func (b *weighted) Select(_ Headers, candidates []SubscriberState) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	best, total := 0, 0
+
+	for i, c := range candidates {
+		b.current[c.ID] += c.Weight
+		total += c.Weight
+
+		if b.current[c.ID] > b.current[candidates[best].ID] {
+			best = i
+		}
+	}
+
+	b.current[candidates[best].ID] -= total
+
+	if len(b.current) > 2*len(candidates) {
+		kept := make(map[uint64]int, len(candidates))
+		for _, c := range candidates {
+			kept[c.ID] = b.current[c.ID]
+		}
+
+		b.current = kept
+	}
+
+	return best
+}
+
+// LeastInFlight returns a LoadBalancer that hands each message to the
+// candidate handling the fewest messages, so fast subscribers take
+// more work than slow ones. Ties rotate.
+func LeastInFlight() LoadBalancer {
+	return &leastInFlight{}
+}
+
+// leastInFlight is the LeastInFlight strategy.
+type leastInFlight struct {
+	next atomic.Uint64
+}
+
+// Select returns the least busy candidate, scanning from a rotating
+// offset so ties are spread.
+func (b *leastInFlight) Select(_ Headers, candidates []SubscriberState) int {
+	n := len(candidates)
+	offset := int((b.next.Add(1) - 1) % uint64(n))
+	best := offset
+
+	for k := 1; k < n; k++ {
+		i := (offset + k) % n
+		if candidates[i].InFlight < candidates[best].InFlight {
+			best = i
+		}
+	}
+
+	return best
+}
+
+// Random returns a LoadBalancer that hands each message to a uniformly
+// random candidate.
+func Random() LoadBalancer {
+	return random{}
+}
+
+// random is the Random strategy.
+type random struct{}
+
+// Select returns a random candidate.
+func (random) Select(_ Headers, candidates []SubscriberState) int {
+	return rand.IntN(len(candidates)) //nolint:gosec // load spreading, not security
+}
+
+// StickyByKey returns a LoadBalancer that sends every message with the
+// same key (extracted by fn) to the same subscriber while it stays
+// subscribed and below its capacity. It uses rendezvous hashing, so a
+// subscriber joining or leaving only moves the keys it gains or held.
+// Messages with an empty key rotate like RoundRobin. Unlike
+// WithPartitionKey, it does not serialize the messages of a key.
+func StickyByKey(fn PartitionKeyFn) LoadBalancer {
+	cassert.NotNil(fn, "key function is nil")
+
+	return &stickyByKey{key: fn}
+}
+
+// stickyByKey is the StickyByKey strategy.
+type stickyByKey struct {
+	key  PartitionKeyFn
+	next atomic.Uint64
+}
+
+// Select returns the candidate with the highest hash of the message
+// key and its ID.
+func (b *stickyByKey) Select(headers Headers, candidates []SubscriberState) int {
+	key := b.key(headers)
+	if key == "" {
+		return int((b.next.Add(1) - 1) % uint64(len(candidates)))
+	}
+
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(key))
+	seed := hash.Sum64()
+
+	best, bestScore := 0, uint64(0)
+
+	for i, c := range candidates {
+		score := mix64(seed ^ c.ID)
+		if i == 0 || score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+
+	return best
+}
+
+// mix64 is the splitmix64 finalizer: it spreads the bits of x so that
+// nearby inputs get unrelated scores.
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+
+	return x
+}
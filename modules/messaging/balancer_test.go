@@ -0,0 +1,247 @@
+package messaging
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+// states builds n candidates with IDs 1..n and weight 1.
+func states(n int) []SubscriberState {
+	out := make([]SubscriberState, n)
+	for i := range out {
+		out[i] = SubscriberState{ID: uint64(i + 1), Weight: 1}
+	}
+
+	return out
+}
+
+// picks runs lb.Select n times over candidates and returns the indices.
+func picks(lb LoadBalancer, headers Headers, candidates []SubscriberState, n int) []int {
+	out := make([]int, n)
+	for i := range out {
+		out[i] = lb.Select(headers, candidates)
+	}
+
+	return out
+}
+
+func TestRoundRobin(t *testing.T) {
+	t.Parallel()
+
+	got := picks(RoundRobin(), Headers{}, states(3), 7)
+	if want := []int{0, 1, 2, 0, 1, 2, 0}; !slices.Equal(got, want) {
+		t.Fatalf("picks = %v, want %v", got, want)
+	}
+}
+
+func TestWeighted(t *testing.T) {
+	t.Parallel()
+
+	t.Run("interleaves in proportion to weight", func(t *testing.T) {
+		t.Parallel()
+
+		candidates := []SubscriberState{{ID: 1, Weight: 2}, {ID: 2, Weight: 1}}
+
+		got := picks(Weighted(), Headers{}, candidates, 6)
+		if want := []int{0, 1, 0, 0, 1, 0}; !slices.Equal(got, want) {
+			t.Fatalf("picks = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("equal weights rotate", func(t *testing.T) {
+		t.Parallel()
+
+		got := picks(Weighted(), Headers{}, states(3), 6)
+		if want := []int{0, 1, 2, 0, 1, 2}; !slices.Equal(got, want) {
+			t.Fatalf("picks = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("forgets subscribers no longer offered", func(t *testing.T) {
+		t.Parallel()
+
+		lb := Weighted().(*weighted)
+
+		for id := range uint64(50) {
+			lb.Select(Headers{}, []SubscriberState{{ID: id, Weight: 1}})
+		}
+
+		if len(lb.current) > 2 {
+			t.Fatalf("expected stale credit pruned, %d entries left", len(lb.current))
+		}
+	})
+}
+
+func TestLeastInFlight(t *testing.T) {
+	t.Parallel()
+
+	t.Run("picks the least busy candidate", func(t *testing.T) {
+		t.Parallel()
+
+		candidates := []SubscriberState{{ID: 1, InFlight: 3}, {ID: 2, InFlight: 1}, {ID: 3, InFlight: 2}}
+
+		for _, got := range picks(LeastInFlight(), Headers{}, candidates, 3) {
+			if got != 1 {
+				t.Fatalf("picked %d, want 1", got)
+			}
+		}
+	})
+
+	t.Run("ties rotate", func(t *testing.T) {
+		t.Parallel()
+
+		got := picks(LeastInFlight(), Headers{}, states(3), 3)
+		if want := []int{0, 1, 2}; !slices.Equal(got, want) {
+			t.Fatalf("picks = %v, want %v", got, want)
+		}
+	})
+}
+
+func TestRandom(t *testing.T) {
+	t.Parallel()
+
+	seen := map[int]bool{}
+
+	for _, got := range picks(Random(), Headers{}, states(3), 300) {
+		if got < 0 || got > 2 {
+			t.Fatalf("picked %d, out of range", got)
+		}
+
+		seen[got] = true
+	}
+
+	if len(seen) != 3 {
+		t.Fatalf("expected every candidate picked, got %v", seen)
+	}
+}
+
+func TestStickyByKey(t *testing.T) {
+	t.Parallel()
+
+	key := func(id string) Headers { return Headers{CorrelationID: id} }
+
+	t.Run("same key goes to the same subscriber", func(t *testing.T) {
+		t.Parallel()
+
+		lb := StickyByKey(PartitionByCorrelationID)
+		candidates := states(4)
+
+		for _, id := range []string{"m1", "m2", "m3"} {
+			first := candidates[lb.Select(key(id), candidates)].ID
+
+			reversed := slices.Clone(candidates)
+			slices.Reverse(reversed)
+
+			if got := reversed[lb.Select(key(id), reversed)].ID; got != first {
+				t.Fatalf("key %s moved from %d to %d when the order changed", id, first, got)
+			}
+		}
+	})
+
+	t.Run("removing another subscriber keeps the key in place", func(t *testing.T) {
+		t.Parallel()
+
+		lb := StickyByKey(PartitionByCorrelationID)
+		candidates := states(4)
+
+		for i := range 20 {
+			h := key(string(rune('a' + i)))
+			owner := candidates[lb.Select(h, candidates)].ID
+
+			victim := uint64(1)
+			if owner == victim {
+				victim = 2
+			}
+
+			rest := slices.DeleteFunc(slices.Clone(candidates), func(s SubscriberState) bool { return s.ID == victim })
+			if got := rest[lb.Select(h, rest)].ID; got != owner {
+				t.Fatalf("key %v moved from %d to %d", h.CorrelationID, owner, got)
+			}
+		}
+	})
+
+	t.Run("keys spread over the subscribers", func(t *testing.T) {
+		t.Parallel()
+
+		lb := StickyByKey(PartitionByCorrelationID)
+		seen := map[int]bool{}
+
+		for i := range 100 {
+			seen[lb.Select(key(string(rune('A'+i))), states(3))] = true
+		}
+
+		if len(seen) != 3 {
+			t.Fatalf("expected keys on every subscriber, got %v", seen)
+		}
+	})
+
+	t.Run("empty key rotates", func(t *testing.T) {
+		t.Parallel()
+
+		got := picks(StickyByKey(PartitionByCorrelationID), Headers{}, states(2), 4)
+		if want := []int{0, 1, 0, 1}; !slices.Equal(got, want) {
+			t.Fatalf("picks = %v, want %v", got, want)
+		}
+	})
+}
+
+func TestSubscribeWith(t *testing.T) {
+	t.Parallel()
+
+	t.Run("applies the options on a QueueChannel", func(t *testing.T) {
+		t.Parallel()
+
+		qc := NewQueueChannel[int]("q-subscribe-with").(*queue[int])
+
+		cancel, err := SubscribeWith[int](qc, func(context.Context, Message[int]) error { return nil },
+			WithSubscriptionWeight(3), WithSubscriptionCapacity(2))
+		if err != nil {
+			t.Fatalf("SubscribeWith returned %v", err)
+		}
+
+		defer cancel()
+
+		qc.mu.RLock()
+		sub := qc.byID[qc.order[0]]
+		qc.mu.RUnlock()
+
+		if sub.weight != 3 || sub.capacity != 2 {
+			t.Fatalf("expected weight 3 and capacity 2, got %d and %d", sub.weight, sub.capacity)
+		}
+	})
+
+	t.Run("falls back to Subscribe on other channels", func(t *testing.T) {
+		t.Parallel()
+
+		ch := NewPipelineChannel[int]()
+		called := false
+
+		_, err := SubscribeWith(ch, func(context.Context, Message[int]) error {
+			called = true
+
+			return nil
+		}, WithSubscriptionCapacity(1))
+		if err != nil {
+			t.Fatalf("SubscribeWith returned %v", err)
+		}
+
+		err = ch.Send(context.Background(), NewMessage(1, nil))
+		if err != nil {
+			t.Fatalf("Send returned %v", err)
+		}
+
+		if !called {
+			t.Fatal("expected the handler subscribed")
+		}
+	})
+
+	t.Run("rejects a nil handler on a QueueChannel", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := SubscribeWith[int](NewQueueChannel[int]("q-nil-handler"), nil)
+		if err == nil {
+			t.Fatal("expected an error for a nil handler")
+		}
+	})
+}
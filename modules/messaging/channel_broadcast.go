@@ -32,15 +32,22 @@ type broadcast[T any] struct {
 	mu     sync.RWMutex
 	nextID uint64
 	byID   map[uint64]Handler[T]
+	stats  StatsRecorder
+
+	interceptors interceptorChain[T]
 }
 
 // NewBroadcastChannel creates a synchronous parallel Channel[T] with
 // barrier semantics. Send blocks until every subscriber's handler has
 // finished and returns the joined errors (nil when all handlers
 // succeed).
-func NewBroadcastChannel[T any]() Channel[T] {
+//
+// Like PipelineChannel, the only option it honors is WithInterceptors;
+// the rest are ignored.
+func NewBroadcastChannel[T any](opts ...Option) Channel[T] {
 	return &broadcast[T]{
-		byID: map[uint64]Handler[T]{},
+		byID:         map[uint64]Handler[T]{},
+		interceptors: extractInterceptors[T](NewOptions(opts...).interceptors),
 	}
 }
 
@@ -52,7 +59,12 @@ func NewBroadcastChannel[T any]() Channel[T] {
 // Returns ErrSend(ErrContextNil) when ctx is nil.
 func (c *broadcast[T]) Send(ctx context.Context, msg Message[T]) error {
 	cassert.NotNil(c, "broadcastChannel is nil")
-	
+
+	return c.interceptors.send(ctx, msg, c.send)
+}
+
+// send is Send without the interceptor chain.
+func (c *broadcast[T]) send(ctx context.Context, msg Message[T]) error {
 	if ctx == nil {
 		return ErrSend(ErrContextNil)
 	}
@@ -61,7 +73,11 @@ func (c *broadcast[T]) Send(ctx context.Context, msg Message[T]) error {
 	handlers := slices.Collect(maps.Values(c.byID))
 	c.mu.RUnlock()
 
+	c.stats.RecordSent()
+
 	if len(handlers) == 0 {
+		c.stats.RecordDrop()
+
 		return nil
 	}
 
@@ -71,7 +87,9 @@ func (c *broadcast[T]) Send(ctx context.Context, msg Message[T]) error {
 
 	for i, handler := range handlers {
 		wg.Go(func() {
+			end := c.stats.Begin()
 			errs[i] = invokeHandler(ctx, msg, handler)
+			end(errs[i])
 		})
 	}
 
@@ -85,6 +103,21 @@ func (c *broadcast[T]) Send(ctx context.Context, msg Message[T]) error {
 	return ErrSend(joined)
 }
 
+// Stats returns a snapshot of the channel's runtime statistics. The
+// broadcast is unbuffered, so BufferLength and BufferCapacity are
+// always zero; a Send with no subscribers is counted as dropped.
+func (c *broadcast[T]) Stats() Stats {
+	cassert.NotNil(c, "broadcastChannel is nil")
+
+	stats := c.stats.Snapshot()
+
+	c.mu.RLock()
+	stats.Subscribers = len(c.byID)
+	c.mu.RUnlock()
+
+	return stats
+}
+
 // Subscribe registers handler and returns a Cancel that detaches it.
 // Cancel is idempotent and O(1). Subscribe returns
 // ErrSubscribe(ErrHandlerNil) when handler is nil.
@@ -95,6 +128,8 @@ func (c *broadcast[T]) Subscribe(handler Handler[T]) (Cancel, error) {
 		return nil, ErrSubscribe(ErrHandlerNil)
 	}
 
+	handler = c.interceptors.wrap(handler)
+
 	c.mu.Lock()
 	c.nextID++
 	id := c.nextID
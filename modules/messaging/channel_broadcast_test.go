@@ -256,3 +256,26 @@ func TestBroadcastChannel_Subscribe(t *testing.T) {
 		}
 	})
 }
+
+func TestBroadcastChannel_Stats(t *testing.T) {
+	t.Parallel()
+
+	ch := NewBroadcastChannel[int]().(*broadcast[int])
+
+	_, err := ch.Subscribe(func(_ context.Context, _ Message[int]) error { return errors.New("boom") })
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	_, err = ch.Subscribe(func(_ context.Context, _ Message[int]) error { return nil })
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	_ = ch.Send(context.Background(), NewMessage(1, nil))
+
+	got := ch.Stats()
+	if got.Subscribers != 2 || got.Sent != 1 || got.Delivered != 2 || got.Failed != 1 || got.InFlight != 0 {
+		t.Fatalf("Stats = %+v, want Subscribers=2 Sent=1 Delivered=2 Failed=1 InFlight=0", got)
+	}
+}
@@ -0,0 +1,698 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+	cerrs "github.com/guidomantilla/yarumo/core/common/errs"
+	"github.com/guidomantilla/yarumo/core/common/lifecycle"
+)
+
+// durable is the file-backed variant of QueueChannel: the same point-
+// to-point, round-robin, worker-pool dispatch, but every accepted
+// message is first appended to a segmented write-ahead log on local
+// disk and only removed from it (acknowledged) once a handler has
+// finished with it. Messages still pending when the process dies — or
+// when Stop gives up after its drain timeout — are replayed by the next
+// Start against the same directory.
+//
+// # Delivery guarantee
+//
+// At-least-once. A message is acknowledged after its handler returns,
+// regardless of the outcome: failures follow the QueueChannel path
+// (ErrorHandler hook + DLQ publication) and are then acknowledged, so
+// the log never wedges on a poison message. Workers only take a
+// message off the backlog while at least one subscriber is attached,
+// so a backlog replayed by Start waits for the first Subscribe instead
+// of being acknowledged undelivered. A crash between the
+// handler returning and the acknowledgement reaching disk replays the
+// message on the next Start; handlers must be idempotent.
+//
+// # Serialisation
+//
+// Messages are stored as JSON (Payload and Headers). Payload types must
+// round-trip through encoding/json; Headers.Custom values come back as
+// their JSON-decoded shapes (float64, string, map[string]any, ...).
+// The publisher's Send ctx is not persisted: replayed messages reach
+// the handler with the worker's lifecycle ctx only.
+//
+// # Buffering
+//
+// The log is the buffer of record; WithBufferSize bounds how many
+// accepted-but-undispatched messages are held in memory, and
+// WithOverflowPolicy applies to that bound exactly as on QueueChannel
+// (a dropped message is acknowledged immediately so it is not replayed).
+// Messages recovered on Start bypass the bound.
+//
+// # Lifecycle
+//
+// The log is opened (and recovered) lazily by the first Start or Send,
+// so — as with QueueChannel — messages sent before Start are buffered
+// and dispatched once the workers run. Stop rejects new Sends and lets
+// the workers drain the in-memory backlog, bounded by the drain
+// timeout; whatever is left — everything, when no subscriber is
+// attached — stays unacknowledged on disk. The log is flushed and
+// closed once every worker has exited, after which Done closes.
+// Stopping a channel that was never started closes the log straight
+// away, keeping any buffered messages for the next Start.
+type durable[T any] struct {
+	name             string
+	dir              string
+	bufferSize       int
+	workerCount      int
+	drainTimeout     time.Duration
+	errorHandler     ErrorHandler
+	expiredHandler   ErrorHandler
+	overflowPolicy   OverflowPolicy
+	dlq              Channel[DeadLetter[T]]
+	interceptors     interceptorChain[T]
+	segmentSize      int64
+	segmentRetention int
+	fsyncPolicy      FsyncPolicy
+	fsyncInterval    time.Duration
+	clock            Clock
+	stats            StatsRecorder
+
+	log     atomic.Pointer[wal]
+	closed  atomic.Bool
+	done    chan struct{}
+	abandon chan struct{}
+
+	// pendingMu guards pending, reserved and changed. reserved counts
+	// backlog slots claimed by in-flight Sends whose log append has not
+	// completed yet. changed is closed and replaced every time pending
+	// shrinks, grows or the channel stops, so both idle workers and
+	// blocked senders can wait on it alongside their own cancellation
+	// signal.
+	pendingMu sync.Mutex
+	pending   []durableItem[T]
+	reserved  int
+	changed   chan struct{}
+
+	mu       sync.RWMutex
+	nextID   uint64
+	order    []uint64
+	byID     map[uint64]Handler[T]
+	rotation uint64
+
+	openOnce sync.Once
+	openErr  error
+
+	workerWG    sync.WaitGroup
+	startOnce   sync.Once
+	stopOnce    sync.Once
+	abandonOnce sync.Once
+	doneOnce    sync.Once
+}
+
+// durableItem is one in-memory backlog entry: the log offset to
+// acknowledge once dispatched plus the envelope to dispatch.
+type durableItem[T any] struct {
+	offset uint64
+	env    envelope[T]
+}
+
+// NewDurableQueueChannel constructs a durable, file-backed
+// QueueChannel[T] whose write-ahead log lives in dir. name is used in
+// lifecycle logs; both name and dir must be non-empty. dir is created on
+// Start when missing and must not be shared with another channel.
+//
+// Besides the QueueChannel options (WithBufferSize, WithWorkerCount,
+// WithDrainTimeout, WithOverflowPolicy, WithErrorHandler,
+// WithExpiredHandler, WithDLQChannel) the durable queue honours WithSegmentSize,
+// WithSegmentRetention, WithFsyncPolicy and WithFsyncInterval.
+//
+// The returned channel is not running; call lifecycle.Build (or Start
+// directly) to recover the log and spawn the worker pool.
+func NewDurableQueueChannel[T any](name string, dir string, opts ...Option) Channel[T] {
+	cassert.NotEmpty(name, "name is empty")
+	cassert.NotEmpty(dir, "dir is empty")
+
+	options := NewOptions(opts...)
+
+	c := &durable[T]{
+		name:             name,
+		dir:              dir,
+		bufferSize:       options.bufferSize,
+		workerCount:      options.workerCount,
+		drainTimeout:     options.drainTimeout,
+		overflowPolicy:   options.overflowPolicy,
+		segmentSize:      options.segmentSize,
+		segmentRetention: options.segmentRetention,
+		fsyncPolicy:      options.fsyncPolicy,
+		fsyncInterval:    options.fsyncInterval,
+		clock:            options.clock,
+		done:             make(chan struct{}),
+		abandon:          make(chan struct{}),
+		changed:          make(chan struct{}),
+		byID:             map[uint64]Handler[T]{},
+	}
+
+	c.stats.UseClock(options.clock)
+	c.errorHandler = c.stats.dropHook(options.errorHandler)
+	c.expiredHandler = c.stats.dropHook(expiredHook(options))
+	c.dlq = countDeadLetters(extractDLQ[T](options.dlq), &c.stats)
+	c.interceptors = extractInterceptors[T](options.interceptors)
+
+	return c
+}
+
+// Name returns the channel's identity used in lifecycle logs.
+func (c *durable[T]) Name() string {
+	cassert.NotNil(c, "DurableQueueChannel is nil")
+
+	return c.name
+}
+
+// Start opens the write-ahead log (unless a prior Send already did),
+// replaying every unacknowledged record into the in-memory backlog in
+// offset order, and spawns the worker pool. A recovery failure is
+// returned wrapped in lifecycle.ErrStart and leaves the channel
+// unusable. Start is idempotent.
+func (c *durable[T]) Start(ctx context.Context) error {
+	cassert.NotNil(c, "DurableQueueChannel is nil")
+
+	var startErr error
+
+	c.startOnce.Do(func() {
+		log, err := c.open()
+		if err != nil {
+			startErr = lifecycle.ErrStart(err)
+
+			return
+		}
+
+		for range c.workerCount {
+			c.workerWG.Go(func() { c.run(ctx) })
+		}
+
+		if c.fsyncPolicy == FsyncInterval {
+			c.workerWG.Go(func() { c.syncLoop(log) })
+		}
+
+		go c.awaitDrain(log)
+	})
+
+	return startErr
+}
+
+// open opens and recovers the write-ahead log exactly once. Recovered
+// records are placed ahead of anything already buffered so replay
+// preserves offset order.
+func (c *durable[T]) open() (*wal, error) {
+	c.openOnce.Do(func() {
+		log, records, err := openWAL(c.dir, c.segmentSize, c.segmentRetention, c.fsyncPolicy == FsyncAlways)
+		if err != nil {
+			c.openErr = err
+
+			return
+		}
+
+		recovered := make([]durableItem[T], 0, len(records))
+
+		for _, record := range records {
+			var msg Message[T]
+
+			decodeErr := json.Unmarshal(record.data, &msg)
+			if decodeErr != nil {
+				_ = log.close()
+				c.openErr = cerrs.Wrap(ErrLogCorrupted, decodeErr)
+
+				return
+			}
+
+			recovered = append(recovered, durableItem[T]{offset: record.offset, env: envelope[T]{msg: msg}})
+		}
+
+		c.pendingMu.Lock()
+		c.pending = recovered
+		c.pendingMu.Unlock()
+
+		c.log.Store(log)
+	})
+
+	return c.log.Load(), c.openErr
+}
+
+// awaitDrain flushes and closes the log once every worker goroutine has
+// exited, then closes done exactly once.
+func (c *durable[T]) awaitDrain(log *wal) {
+	c.workerWG.Wait()
+
+	err := log.close()
+	if err != nil && c.errorHandler != nil {
+		c.errorHandler(context.Background(), nil, err)
+	}
+
+	c.doneOnce.Do(func() { close(c.done) })
+}
+
+// Stop rejects further Sends and waits for the workers to drain the
+// in-memory backlog, bounded by the configured drain timeout or by
+// ctx's deadline (whichever is tighter). On timeout the workers are
+// told to abandon the remaining backlog after their in-flight message;
+// those records stay unacknowledged on disk and are replayed by the
+// next Start. Stop is idempotent per the lifecycle.Component contract.
+func (c *durable[T]) Stop(ctx context.Context) error {
+	cassert.NotNil(c, "DurableQueueChannel is nil")
+
+	c.stopOnce.Do(func() {
+		c.mu.Lock()
+		c.closed.Store(true)
+		c.mu.Unlock()
+
+		c.notify()
+
+		// Consume startOnce so a late Start is a no-op. When it had not
+		// run yet there are no workers to drain: close the log (if a
+		// Send opened it) and done directly so Done observers converge.
+		started := true
+
+		c.startOnce.Do(func() { started = false })
+
+		if started {
+			return
+		}
+
+		log := c.log.Load()
+		if log != nil {
+			_ = log.close()
+		}
+
+		c.doneOnce.Do(func() { close(c.done) })
+	})
+
+	timeout := c.drainTimeout
+	if timeout <= 0 {
+		timeout = defaultDrainTimeout
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	select {
+	case <-c.done:
+		return nil
+	case <-waitCtx.Done():
+		c.abandonOnce.Do(func() { close(c.abandon) })
+
+		return lifecycle.ErrShutdown(lifecycle.ErrShutdownTimeout, waitCtx.Err())
+	}
+}
+
+// Done returns the channel that is closed after every worker has exited
+// and the log has been flushed and closed.
+func (c *durable[T]) Done() <-chan struct{} {
+	cassert.NotNil(c, "DurableQueueChannel is nil")
+
+	return c.done
+}
+
+// Send appends msg to the write-ahead log and queues it for dispatch.
+// When Send returns nil the message is on disk (subject to the
+// configured FsyncPolicy) and will be delivered — now, or after a
+// restart. Returns ErrSend(ErrContextNil) on nil ctx, ErrSend(ErrClosed)
+// after Stop, and ErrSend(ErrEncode / ErrRecordTooLarge / ErrLogIO /
+// ErrLogCorrupted) when the message cannot be persisted or the log
+// cannot be opened.
+// The in-memory backlog bound follows the configured OverflowPolicy.
+func (c *durable[T]) Send(ctx context.Context, msg Message[T]) error {
+	cassert.NotNil(c, "DurableQueueChannel is nil")
+
+	return c.interceptors.send(ctx, msg, c.send)
+}
+
+// send is Send without the interceptor chain.
+func (c *durable[T]) send(ctx context.Context, msg Message[T]) error {
+	if ctx == nil {
+		return ErrSend(ErrContextNil)
+	}
+
+	if c.closed.Load() {
+		return ErrSend(ErrClosed)
+	}
+
+	log, err := c.open()
+	if err != nil {
+		return ErrSend(err)
+	}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return ErrSend(ErrEncode, err)
+	}
+
+	claimed, err := c.reserve(ctx, msg)
+	if err != nil {
+		return err
+	}
+
+	if !claimed {
+		c.stats.RecordSent()
+
+		return nil
+	}
+
+	offset, err := log.append(data)
+	if err != nil {
+		c.release()
+
+		return ErrSend(err)
+	}
+
+	c.pendingMu.Lock()
+	c.pending = append(c.pending, durableItem[T]{offset: offset, env: envelope[T]{sendCtx: ctx, msg: msg}})
+	c.reserved--
+	c.pendingMu.Unlock()
+
+	c.stats.RecordSent()
+	c.notify()
+
+	return nil
+}
+
+// Stats returns a snapshot of the channel's runtime statistics.
+// BufferLength is the in-memory backlog of logged messages awaiting
+// dispatch; BufferCapacity is its bound. Sent counts messages accepted
+// by Send in this process, not those recovered from the log.
+func (c *durable[T]) Stats() Stats {
+	cassert.NotNil(c, "DurableQueueChannel is nil")
+
+	stats := c.stats.Snapshot()
+	stats.BufferCapacity = c.bufferSize
+
+	c.pendingMu.Lock()
+	stats.BufferLength = len(c.pending)
+	c.pendingMu.Unlock()
+
+	c.mu.RLock()
+	stats.Subscribers = len(c.order)
+	c.mu.RUnlock()
+
+	return stats
+}
+
+// Subscribe registers handler at the end of the rotation pool, waking
+// workers parked on a backlog with no subscribers, and returns a
+// Cancel that detaches it. Cancel is idempotent. Subscribe
+// returns ErrSubscribe(ErrHandlerNil) when handler is nil and
+// ErrSubscribe(ErrClosed) when the channel has been stopped.
+func (c *durable[T]) Subscribe(handler Handler[T]) (Cancel, error) {
+	cassert.NotNil(c, "DurableQueueChannel is nil")
+
+	if handler == nil {
+		return nil, ErrSubscribe(ErrHandlerNil)
+	}
+
+	handler = c.interceptors.wrap(handler)
+
+	c.mu.Lock()
+	if c.closed.Load() {
+		c.mu.Unlock()
+
+		return nil, ErrSubscribe(ErrClosed)
+	}
+
+	c.nextID++
+	id := c.nextID
+	c.byID[id] = handler
+	c.order = append(c.order, id)
+	c.mu.Unlock()
+
+	c.notify()
+
+	var once sync.Once
+
+	cancel := func() {
+		once.Do(func() {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+
+			delete(c.byID, id)
+
+			if i := slices.Index(c.order, id); i >= 0 {
+				c.order = slices.Delete(c.order, i, i+1)
+			}
+		})
+	}
+
+	return cancel, nil
+}
+
+// reserve claims a backlog slot for msg according to the overflow
+// policy before the message is appended to the log, so a rejected or
+// dropped message never reaches disk. It returns true when a slot was
+// claimed and false (with a nil error) when msg itself was dropped
+// under DropNewest / DropOldest after firing the hook.
+func (c *durable[T]) reserve(ctx context.Context, msg Message[T]) (bool, error) {
+	for {
+		c.pendingMu.Lock()
+
+		if len(c.pending)+c.reserved < c.bufferSize {
+			c.reserved++
+			c.pendingMu.Unlock()
+
+			return true, nil
+		}
+
+		switch c.overflowPolicy {
+		case OverflowReject:
+			c.pendingMu.Unlock()
+
+			return false, ErrSend(ErrBufferFull)
+		case OverflowDropNewest:
+			c.pendingMu.Unlock()
+			c.reportOverflow(ctx, msg)
+
+			return false, nil
+		case OverflowDropOldest:
+			if len(c.pending) > 0 {
+				evicted := c.pending[0]
+				c.pending = c.pending[1:]
+				c.reserved++
+				c.pendingMu.Unlock()
+
+				c.ack(ctx, evicted)
+				c.reportOverflow(ctx, evicted.env.msg)
+
+				return true, nil
+			}
+
+			c.pendingMu.Unlock()
+			c.reportOverflow(ctx, msg)
+
+			return false, nil
+		}
+
+		changed := c.changed
+		c.pendingMu.Unlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return false, ErrSend(ErrTimeout, ctx.Err())
+		}
+
+		if c.closed.Load() {
+			return false, ErrSend(ErrClosed)
+		}
+	}
+}
+
+// release returns a slot claimed by reserve when the append failed.
+func (c *durable[T]) release() {
+	c.pendingMu.Lock()
+	c.reserved--
+	c.pendingMu.Unlock()
+
+	c.notify()
+}
+
+// notify wakes every goroutine waiting on the current changed channel
+// and installs a fresh one for the next wait.
+func (c *durable[T]) notify() {
+	c.pendingMu.Lock()
+	close(c.changed)
+	c.changed = make(chan struct{})
+	c.pendingMu.Unlock()
+}
+
+// next pops the oldest backlog entry, blocking while the backlog is
+// empty or no subscriber is attached. It returns false when the worker
+// should exit: the channel is stopped and the backlog drained (or left
+// on disk for want of subscribers), or Stop abandoned the drain.
+func (c *durable[T]) next() (durableItem[T], bool) {
+	for {
+		select {
+		case <-c.abandon:
+			return durableItem[T]{}, false
+		default:
+		}
+
+		c.pendingMu.Lock()
+
+		if len(c.pending) > 0 && c.subscribed() {
+			item := c.pending[0]
+			c.pending[0] = durableItem[T]{}
+			c.pending = c.pending[1:]
+			c.pendingMu.Unlock()
+
+			c.notify()
+
+			return item, true
+		}
+
+		if c.closed.Load() {
+			c.pendingMu.Unlock()
+
+			return durableItem[T]{}, false
+		}
+
+		changed := c.changed
+		c.pendingMu.Unlock()
+
+		select {
+		case <-changed:
+		case <-c.abandon:
+			return durableItem[T]{}, false
+		}
+	}
+}
+
+// subscribed reports whether at least one subscriber is attached.
+func (c *durable[T]) subscribed() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return len(c.order) > 0
+}
+
+// requeue puts item back at the head of the backlog, unacknowledged,
+// after its last subscriber detached between next and dispatch.
+func (c *durable[T]) requeue(item durableItem[T]) {
+	c.pendingMu.Lock()
+	c.pending = slices.Insert(c.pending, 0, item)
+	c.pendingMu.Unlock()
+
+	c.notify()
+}
+
+// run is one worker's loop: pop, dispatch, acknowledge. A message that
+// found no subscriber is requeued rather than acknowledged.
+func (c *durable[T]) run(workerCtx context.Context) {
+	for {
+		item, ok := c.next()
+		if !ok {
+			return
+		}
+
+		handlerCtx := mergeContexts(workerCtx, item.env.sendCtx)
+
+		if !c.dispatch(handlerCtx, item.env.msg) {
+			c.requeue(item)
+
+			continue
+		}
+
+		c.ack(handlerCtx, item)
+	}
+}
+
+// dispatch selects the next subscriber via round-robin and invokes its
+// handler with panic recovery, mirroring QueueChannel: failures go to
+// the ErrorHandler hook and the DLQ. An expired message (see
+// WithExpiredHandler) is not dispatched; it is still acknowledged.
+// dispatch returns false, without reporting anything, when no
+// subscriber is attached, so the caller keeps the message on the log.
+func (c *durable[T]) dispatch(ctx context.Context, msg Message[T]) bool {
+	if dropExpired(ctx, msg, c.clock.Now(), c.expiredHandler, c.dlq) {
+		return true
+	}
+
+	c.mu.Lock()
+
+	n := len(c.order)
+	if n == 0 {
+		c.mu.Unlock()
+
+		return false
+	}
+
+	idx := c.rotation % uint64(n)
+	c.rotation++
+	handler := c.byID[c.order[idx]]
+	c.mu.Unlock()
+
+	end := c.stats.Begin()
+	err := invokeHandler(ctx, msg, handler)
+	end(err)
+
+	if err == nil {
+		return true
+	}
+
+	if c.errorHandler != nil {
+		c.errorHandler(ctx, msg, err)
+	}
+
+	PublishDeadLetter(ctx, c.dlq, msg, err, c.clock.Now())
+
+	return true
+}
+
+// ack marks item's offset as processed in the log. A failed ack is
+// reported through the ErrorHandler hook; the message will be replayed
+// on the next Start.
+func (c *durable[T]) ack(ctx context.Context, item durableItem[T]) {
+	log := c.log.Load()
+	if log == nil {
+		return
+	}
+
+	err := log.ack(item.offset)
+	if err != nil && !errors.Is(err, ErrClosed) && c.errorHandler != nil {
+		c.errorHandler(ctx, item.env.msg, err)
+	}
+}
+
+// reportOverflow fires the ErrorHandler hook for an overflow drop.
+func (c *durable[T]) reportOverflow(ctx context.Context, msg Message[T]) {
+	if c.errorHandler != nil {
+		c.errorHandler(ctx, msg, errors.Join(ErrOverflow, ErrDropped))
+	}
+}
+
+// syncLoop flushes the log every fsync interval under FsyncInterval.
+// It exits when the channel stops; the final flush happens when the
+// log is closed.
+func (c *durable[T]) syncLoop(log *wal) {
+	ticker := c.clock.NewTicker(c.fsyncInterval)
+	defer ticker.Stop()
+
+	for {
+		c.pendingMu.Lock()
+		changed := c.changed
+		c.pendingMu.Unlock()
+
+		if c.closed.Load() {
+			return
+		}
+
+		select {
+		case <-ticker.C():
+			err := log.sync()
+			if err != nil && c.errorHandler != nil {
+				c.errorHandler(context.Background(), nil, err)
+			}
+		case <-changed:
+		case <-c.abandon:
+			return
+		}
+	}
+}
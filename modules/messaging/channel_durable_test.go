@@ -0,0 +1,952 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/guidomantilla/yarumo/core/common/lifecycle"
+	lctests "github.com/guidomantilla/yarumo/core/common/lifecycle/tests"
+)
+
+// startBlockedDurable starts a single-worker durable queue whose only
+// subscriber parks on gate, so the first Send occupies the worker and
+// later Sends accumulate in the in-memory backlog.
+func startBlockedDurable(t *testing.T, opts ...Option) (*durable[int], chan struct{}) {
+	t.Helper()
+
+	gate := make(chan struct{})
+	entered := make(chan struct{}, 1)
+
+	opts = append([]Option{WithWorkerCount(1), WithDrainTimeout(time.Second)}, opts...)
+	ch := NewDurableQueueChannel[int]("d-blocked", t.TempDir(), opts...).(*durable[int])
+
+	_, err := ch.Subscribe(func(_ context.Context, _ Message[int]) error {
+		select {
+		case entered <- struct{}{}:
+		default:
+		}
+
+		<-gate
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Subscribe returned %v", err)
+	}
+
+	err = ch.Start(context.Background())
+	if err != nil {
+		t.Fatalf("Start returned %v", err)
+	}
+
+	t.Cleanup(func() {
+		select {
+		case <-gate:
+		default:
+			close(gate)
+		}
+
+		_ = ch.Stop(context.Background())
+	})
+
+	err = ch.Send(context.Background(), NewMessage[int](0, nil))
+	if err != nil {
+		t.Fatalf("Send returned %v", err)
+	}
+
+	<-entered
+
+	return ch, gate
+}
+
+func TestNewDurableQueueChannel(t *testing.T) {
+	t.Parallel()
+
+	t.Run("applies options", func(t *testing.T) {
+		t.Parallel()
+
+		ch := NewDurableQueueChannel[int]("d-1", t.TempDir(),
+			WithWorkerCount(3),
+			WithSegmentSize(4096),
+			WithSegmentRetention(2),
+			WithFsyncPolicy(FsyncNever),
+		).(*durable[int])
+
+		if ch.Name() != "d-1" {
+			t.Fatalf("expected name d-1, got %q", ch.Name())
+		}
+
+		if ch.workerCount != 3 || ch.segmentSize != 4096 || ch.segmentRetention != 2 || ch.fsyncPolicy != FsyncNever {
+			t.Fatalf("options not applied: %+v", ch)
+		}
+	})
+
+	t.Run("does not touch the filesystem", func(t *testing.T) {
+		t.Parallel()
+
+		dir := filepath.Join(t.TempDir(), "lazy")
+
+		_ = NewDurableQueueChannel[int]("d-lazy", dir).(*durable[int])
+
+		_, err := os.Stat(dir)
+		if !errors.Is(err, os.ErrNotExist) {
+			t.Fatalf("expected dir to be created on Start only, got %v", err)
+		}
+	})
+}
+
+func TestDurableQueueChannel_Send(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil ctx", func(t *testing.T) {
+		t.Parallel()
+
+		ch := NewDurableQueueChannel[int]("d-nil", t.TempDir()).(*durable[int])
+
+		//nolint:staticcheck // exercising the nil guard
+		err := ch.Send(nil, NewMessage[int](1, nil))
+		if !errors.Is(err, ErrContextNil) {
+			t.Fatalf("expected ErrContextNil, got %v", err)
+		}
+	})
+
+	t.Run("before start is buffered and delivered", func(t *testing.T) {
+		t.Parallel()
+
+		ch := NewDurableQueueChannel[int]("d-unstarted", t.TempDir(), WithDrainTimeout(time.Second)).(*durable[int])
+
+		received := make(chan int, 1)
+
+		_, err := ch.Subscribe(func(_ context.Context, msg Message[int]) error {
+			received <- msg.Payload
+
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Subscribe returned %v", err)
+		}
+
+		err = ch.Send(context.Background(), NewMessage[int](42, nil))
+		if err != nil {
+			t.Fatalf("Send returned %v", err)
+		}
+
+		err = ch.Start(context.Background())
+		if err != nil {
+			t.Fatalf("Start returned %v", err)
+		}
+
+		t.Cleanup(func() { _ = ch.Stop(context.Background()) })
+
+		got := <-received
+		if got != 42 {
+			t.Fatalf("expected 42, got %d", got)
+		}
+	})
+
+	t.Run("unusable dir", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "file")
+
+		err := os.WriteFile(path, []byte("x"), 0o600)
+		if err != nil {
+			t.Fatalf("WriteFile returned %v", err)
+		}
+
+		ch := NewDurableQueueChannel[int]("d-send-file", path).(*durable[int])
+
+		err = ch.Send(context.Background(), NewMessage[int](1, nil))
+		if !errors.Is(err, ErrLogIO) {
+			t.Fatalf("expected ErrLogIO, got %v", err)
+		}
+	})
+
+	t.Run("after stop", func(t *testing.T) {
+		t.Parallel()
+
+		ch := NewDurableQueueChannel[int]("d-stopped", t.TempDir()).(*durable[int])
+
+		err := ch.Start(context.Background())
+		if err != nil {
+			t.Fatalf("Start returned %v", err)
+		}
+
+		_ = ch.Stop(context.Background())
+
+		err = ch.Send(context.Background(), NewMessage[int](1, nil))
+		if !errors.Is(err, ErrClosed) {
+			t.Fatalf("expected ErrClosed, got %v", err)
+		}
+	})
+
+	t.Run("unencodable payload", func(t *testing.T) {
+		t.Parallel()
+
+		ch := NewDurableQueueChannel[func()]("d-encode", t.TempDir()).(*durable[func()])
+
+		err := ch.Start(context.Background())
+		if err != nil {
+			t.Fatalf("Start returned %v", err)
+		}
+
+		t.Cleanup(func() { _ = ch.Stop(context.Background()) })
+
+		err = ch.Send(context.Background(), NewMessage[func()](func() {}, nil))
+		if !errors.Is(err, ErrEncode) {
+			t.Fatalf("expected ErrEncode, got %v", err)
+		}
+	})
+}
+
+func TestDurableQueueChannel_Subscribe(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil handler", func(t *testing.T) {
+		t.Parallel()
+
+		ch := NewDurableQueueChannel[int]("d-sub-nil", t.TempDir()).(*durable[int])
+
+		_, err := ch.Subscribe(nil)
+		if !errors.Is(err, ErrHandlerNil) {
+			t.Fatalf("expected ErrHandlerNil, got %v", err)
+		}
+	})
+
+	t.Run("after stop", func(t *testing.T) {
+		t.Parallel()
+
+		ch := NewDurableQueueChannel[int]("d-sub-closed", t.TempDir()).(*durable[int])
+		_ = ch.Stop(context.Background())
+
+		_, err := ch.Subscribe(func(context.Context, Message[int]) error { return nil })
+		if !errors.Is(err, ErrClosed) {
+			t.Fatalf("expected ErrClosed, got %v", err)
+		}
+	})
+
+	t.Run("cancel detaches", func(t *testing.T) {
+		t.Parallel()
+
+		ch := NewDurableQueueChannel[int]("d-sub-cancel", t.TempDir()).(*durable[int])
+
+		cancel, err := ch.Subscribe(func(context.Context, Message[int]) error { return nil })
+		if err != nil {
+			t.Fatalf("Subscribe returned %v", err)
+		}
+
+		cancel()
+		cancel()
+
+		if len(ch.order) != 0 || len(ch.byID) != 0 {
+			t.Fatalf("expected no subscribers, got order=%v", ch.order)
+		}
+	})
+}
+
+func TestDurableQueueChannel_RoundRobin(t *testing.T) {
+	t.Parallel()
+
+	ch := NewDurableQueueChannel[int]("d-rr", t.TempDir(), WithWorkerCount(1), WithDrainTimeout(time.Second)).(*durable[int])
+
+	var (
+		mu    sync.Mutex
+		fired = map[int]int{}
+		seen  = make(chan struct{}, 16)
+	)
+
+	for label := range 3 {
+		_, err := ch.Subscribe(func(_ context.Context, _ Message[int]) error {
+			mu.Lock()
+			fired[label]++
+			mu.Unlock()
+
+			seen <- struct{}{}
+
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Subscribe returned %v", err)
+		}
+	}
+
+	errChan := make(chan error, 1)
+
+	closeFn, err := lifecycle.Build(context.Background(), ch, errChan)
+	if err != nil {
+		t.Fatalf("lifecycle.Build returned %v", err)
+	}
+
+	t.Cleanup(func() { closeFn(context.Background(), time.Second) })
+
+	for i := range 9 {
+		err = ch.Send(context.Background(), NewMessage[int](i, nil))
+		if err != nil {
+			t.Fatalf("Send returned %v", err)
+		}
+	}
+
+	for range 9 {
+		<-seen
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	for label, count := range fired {
+		if count != 3 {
+			t.Errorf("sub %d fired %d times, expected 3", label, count)
+		}
+	}
+}
+
+func TestDurableQueueChannel_Recovery(t *testing.T) {
+	t.Parallel()
+
+	t.Run("replays messages left after drain timeout", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		gate := make(chan struct{})
+
+		first := NewDurableQueueChannel[string]("d-crash", dir,
+			WithWorkerCount(1),
+			WithDrainTimeout(20*time.Millisecond),
+		).(*durable[string])
+
+		_, err := first.Subscribe(func(_ context.Context, _ Message[string]) error {
+			<-gate
+
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Subscribe returned %v", err)
+		}
+
+		err = first.Start(context.Background())
+		if err != nil {
+			t.Fatalf("Start returned %v", err)
+		}
+
+		for _, payload := range []string{"a", "b", "c"} {
+			msg := NewMessage(payload, nil)
+			msg.Headers.CorrelationID = "corr-1"
+
+			err = first.Send(context.Background(), msg)
+			if err != nil {
+				t.Fatalf("Send returned %v", err)
+			}
+		}
+
+		err = first.Stop(context.Background())
+		if !errors.Is(err, lifecycle.ErrShutdownTimeout) {
+			t.Fatalf("expected ErrShutdownTimeout, got %v", err)
+		}
+
+		// Release the in-flight handler; "a" is acknowledged, "b" and
+		// "c" were abandoned and stay on disk.
+		close(gate)
+		<-first.Done()
+
+		second := NewDurableQueueChannel[string]("d-recover", dir, WithWorkerCount(1), WithDrainTimeout(time.Second)).(*durable[string])
+
+		received := make(chan Message[string], 4)
+
+		_, err = second.Subscribe(func(_ context.Context, msg Message[string]) error {
+			received <- msg
+
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Subscribe returned %v", err)
+		}
+
+		err = second.Start(context.Background())
+		if err != nil {
+			t.Fatalf("Start returned %v", err)
+		}
+
+		for _, want := range []string{"b", "c"} {
+			select {
+			case msg := <-received:
+				if msg.Payload != want {
+					t.Fatalf("expected %q, got %q", want, msg.Payload)
+				}
+
+				if msg.Headers.CorrelationID != "corr-1" {
+					t.Fatalf("expected headers to survive, got %+v", msg.Headers)
+				}
+			case <-time.After(time.Second):
+				t.Fatalf("timed out waiting for %q", want)
+			}
+		}
+
+		err = second.Stop(context.Background())
+		if err != nil {
+			t.Fatalf("Stop returned %v", err)
+		}
+
+		third := NewDurableQueueChannel[string]("d-empty", dir).(*durable[string])
+
+		err = third.Start(context.Background())
+		if err != nil {
+			t.Fatalf("Start returned %v", err)
+		}
+
+		third.pendingMu.Lock()
+		remaining := len(third.pending)
+		third.pendingMu.Unlock()
+
+		_ = third.Stop(context.Background())
+
+		if remaining != 0 {
+			t.Fatalf("expected nothing to replay, got %d", remaining)
+		}
+	})
+
+	t.Run("fails start on undecodable record", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+
+		log, _, err := openWAL(dir, defaultSegmentSize, 0, true)
+		if err != nil {
+			t.Fatalf("openWAL returned %v", err)
+		}
+
+		_, _ = log.append([]byte("not json"))
+		_ = log.close()
+
+		ch := NewDurableQueueChannel[int]("d-bad", dir).(*durable[int])
+
+		err = ch.Start(context.Background())
+		if !errors.Is(err, ErrLogCorrupted) {
+			t.Fatalf("expected ErrLogCorrupted, got %v", err)
+		}
+	})
+
+	t.Run("fails start on unusable dir", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "file")
+
+		err := os.WriteFile(path, []byte("x"), 0o600)
+		if err != nil {
+			t.Fatalf("WriteFile returned %v", err)
+		}
+
+		ch := NewDurableQueueChannel[int]("d-file", path).(*durable[int])
+
+		err = ch.Start(context.Background())
+		if !errors.Is(err, ErrLogIO) {
+			t.Fatalf("expected ErrLogIO, got %v", err)
+		}
+	})
+}
+
+func TestDurableQueueChannel_HandlerFailure(t *testing.T) {
+	t.Parallel()
+
+	dlq := NewPipelineChannel[DeadLetter[int]]()
+	letters := make(chan DeadLetter[int], 1)
+
+	_, err := dlq.Subscribe(func(_ context.Context, m Message[DeadLetter[int]]) error {
+		letters <- m.Payload
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("dlq Subscribe returned %v", err)
+	}
+
+	hookErrs := make(chan error, 4)
+	wantErr := errors.New("durable boom")
+
+	dir := t.TempDir()
+	ch := NewDurableQueueChannel[int]("d-dlq", dir,
+		WithDrainTimeout(time.Second),
+		WithDLQChannel(dlq),
+		WithErrorHandler(func(_ context.Context, _ any, err error) { hookErrs <- err }),
+	).(*durable[int])
+
+	_, err = ch.Subscribe(func(_ context.Context, _ Message[int]) error { return wantErr })
+	if err != nil {
+		t.Fatalf("Subscribe returned %v", err)
+	}
+
+	err = ch.Start(context.Background())
+	if err != nil {
+		t.Fatalf("Start returned %v", err)
+	}
+
+	err = ch.Send(context.Background(), NewMessage[int](7, nil))
+	if err != nil {
+		t.Fatalf("Send returned %v", err)
+	}
+
+	dl := <-letters
+	if dl.Original.Payload != 7 || !errors.Is(dl.LastError, wantErr) {
+		t.Fatalf("unexpected dead letter %+v", dl)
+	}
+
+	got := <-hookErrs
+	if !errors.Is(got, wantErr) {
+		t.Fatalf("expected hook error %v, got %v", wantErr, got)
+	}
+
+	err = ch.Stop(context.Background())
+	if err != nil {
+		t.Fatalf("Stop returned %v", err)
+	}
+
+	// The failed message was acknowledged: nothing to replay.
+	reopened, records, err := openWAL(dir, defaultSegmentSize, 0, true)
+	if err != nil {
+		t.Fatalf("openWAL returned %v", err)
+	}
+	defer reopened.close()
+
+	if len(records) != 0 {
+		t.Fatalf("expected failed message to be acknowledged, got %d records", len(records))
+	}
+}
+
+func TestDurableQueueChannel_NoSubscribers(t *testing.T) {
+	t.Parallel()
+
+	t.Run("holds messages until a subscriber attaches", func(t *testing.T) {
+		t.Parallel()
+
+		hookErrs := make(chan error, 1)
+
+		ch := NewDurableQueueChannel[int]("d-empty-subs", t.TempDir(),
+			WithErrorHandler(func(_ context.Context, _ any, err error) { hookErrs <- err }),
+		).(*durable[int])
+
+		err := ch.Start(context.Background())
+		if err != nil {
+			t.Fatalf("Start returned %v", err)
+		}
+
+		t.Cleanup(func() { _ = ch.Stop(context.Background()) })
+
+		err = ch.Send(context.Background(), NewMessage[int](1, nil))
+		if err != nil {
+			t.Fatalf("Send returned %v", err)
+		}
+
+		select {
+		case err = <-hookErrs:
+			t.Fatalf("unexpected hook error %v", err)
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		received := make(chan int, 1)
+
+		_, err = ch.Subscribe(func(_ context.Context, msg Message[int]) error {
+			received <- msg.Payload
+
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Subscribe returned %v", err)
+		}
+
+		select {
+		case payload := <-received:
+			if payload != 1 {
+				t.Fatalf("expected 1, got %d", payload)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("held message not delivered after Subscribe")
+		}
+	})
+
+	t.Run("replays the recovered backlog to a late subscriber", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+
+		first := NewDurableQueueChannel[int]("d-late-first", dir, WithDrainTimeout(time.Second)).(*durable[int])
+
+		err := first.Start(context.Background())
+		if err != nil {
+			t.Fatalf("Start returned %v", err)
+		}
+
+		for i := 1; i <= 3; i++ {
+			err = first.Send(context.Background(), NewMessage(i, nil))
+			if err != nil {
+				t.Fatalf("Send returned %v", err)
+			}
+		}
+
+		err = first.Stop(context.Background())
+		if err != nil {
+			t.Fatalf("Stop returned %v", err)
+		}
+
+		second := NewDurableQueueChannel[int]("d-late-second", dir, WithWorkerCount(1), WithDrainTimeout(time.Second)).(*durable[int])
+
+		err = second.Start(context.Background())
+		if err != nil {
+			t.Fatalf("Start returned %v", err)
+		}
+
+		time.Sleep(20 * time.Millisecond)
+
+		received := make(chan int, 3)
+
+		_, err = second.Subscribe(func(_ context.Context, msg Message[int]) error {
+			received <- msg.Payload
+
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Subscribe returned %v", err)
+		}
+
+		for want := 1; want <= 3; want++ {
+			select {
+			case got := <-received:
+				if got != want {
+					t.Fatalf("expected %d, got %d", want, got)
+				}
+			case <-time.After(time.Second):
+				t.Fatalf("timed out waiting for %d", want)
+			}
+		}
+
+		err = second.Stop(context.Background())
+		if err != nil {
+			t.Fatalf("Stop returned %v", err)
+		}
+	})
+}
+
+func TestDurableQueueChannel_Overflow(t *testing.T) {
+	t.Parallel()
+
+	t.Run("reject", func(t *testing.T) {
+		t.Parallel()
+
+		ch, _ := startBlockedDurable(t, WithBufferSize(1))
+
+		err := ch.Send(context.Background(), NewMessage[int](1, nil))
+		if err != nil {
+			t.Fatalf("Send returned %v", err)
+		}
+
+		err = ch.Send(context.Background(), NewMessage[int](2, nil))
+		if !errors.Is(err, ErrBufferFull) {
+			t.Fatalf("expected ErrBufferFull, got %v", err)
+		}
+	})
+
+	t.Run("block until ctx expires", func(t *testing.T) {
+		t.Parallel()
+
+		ch, _ := startBlockedDurable(t, WithBufferSize(1), WithOverflowPolicy(OverflowBlock))
+
+		_ = ch.Send(context.Background(), NewMessage[int](1, nil))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		err := ch.Send(ctx, NewMessage[int](2, nil))
+		if !errors.Is(err, ErrTimeout) {
+			t.Fatalf("expected ErrTimeout, got %v", err)
+		}
+	})
+
+	t.Run("block until slot opens", func(t *testing.T) {
+		t.Parallel()
+
+		ch, gate := startBlockedDurable(t, WithBufferSize(1), WithOverflowPolicy(OverflowBlock))
+
+		_ = ch.Send(context.Background(), NewMessage[int](1, nil))
+
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			close(gate)
+		}()
+
+		err := ch.Send(context.Background(), NewMessage[int](2, nil))
+		if err != nil {
+			t.Fatalf("expected blocked Send to succeed, got %v", err)
+		}
+	})
+
+	t.Run("drop newest", func(t *testing.T) {
+		t.Parallel()
+
+		dropped := make(chan any, 1)
+
+		ch, _ := startBlockedDurable(t,
+			WithBufferSize(1),
+			WithOverflowPolicy(OverflowDropNewest),
+			WithErrorHandler(func(_ context.Context, msg any, err error) {
+				if errors.Is(err, ErrOverflow) && errors.Is(err, ErrDropped) {
+					dropped <- msg
+				}
+			}),
+		)
+
+		_ = ch.Send(context.Background(), NewMessage[int](1, nil))
+
+		err := ch.Send(context.Background(), NewMessage[int](2, nil))
+		if err != nil {
+			t.Fatalf("DropNewest Send should return nil, got %v", err)
+		}
+
+		msg := (<-dropped).(Message[int])
+		if msg.Payload != 2 {
+			t.Fatalf("expected new msg (2) dropped, got %d", msg.Payload)
+		}
+
+		if ch.log.Load().next != 2 {
+			t.Fatalf("expected dropped message not to reach the log, next=%d", ch.log.Load().next)
+		}
+	})
+
+	t.Run("drop oldest", func(t *testing.T) {
+		t.Parallel()
+
+		dropped := make(chan any, 1)
+
+		ch, _ := startBlockedDurable(t,
+			WithBufferSize(1),
+			WithOverflowPolicy(OverflowDropOldest),
+			WithErrorHandler(func(_ context.Context, msg any, err error) {
+				if errors.Is(err, ErrOverflow) {
+					dropped <- msg
+				}
+			}),
+		)
+
+		_ = ch.Send(context.Background(), NewMessage[int](1, nil))
+
+		err := ch.Send(context.Background(), NewMessage[int](2, nil))
+		if err != nil {
+			t.Fatalf("DropOldest Send should return nil, got %v", err)
+		}
+
+		msg := (<-dropped).(Message[int])
+		if msg.Payload != 1 {
+			t.Fatalf("expected oldest (1) evicted, got %d", msg.Payload)
+		}
+
+		ch.pendingMu.Lock()
+		defer ch.pendingMu.Unlock()
+
+		if len(ch.pending) != 1 || ch.pending[0].env.msg.Payload != 2 {
+			t.Fatalf("expected backlog [2], got %+v", ch.pending)
+		}
+	})
+}
+
+func TestDurableQueueChannel_FsyncInterval(t *testing.T) {
+	t.Parallel()
+
+	ch := NewDurableQueueChannel[int]("d-interval", t.TempDir(),
+		WithFsyncPolicy(FsyncInterval),
+		WithFsyncInterval(5*time.Millisecond),
+	).(*durable[int])
+
+	received := make(chan struct{}, 1)
+
+	_, err := ch.Subscribe(func(context.Context, Message[int]) error {
+		received <- struct{}{}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Subscribe returned %v", err)
+	}
+
+	err = ch.Start(context.Background())
+	if err != nil {
+		t.Fatalf("Start returned %v", err)
+	}
+
+	err = ch.Send(context.Background(), NewMessage[int](1, nil))
+	if err != nil {
+		t.Fatalf("Send returned %v", err)
+	}
+
+	<-received
+	time.Sleep(20 * time.Millisecond)
+
+	err = ch.Stop(context.Background())
+	if err != nil {
+		t.Fatalf("Stop returned %v", err)
+	}
+}
+
+func TestDurableQueueChannel_Stop(t *testing.T) {
+	t.Parallel()
+
+	t.Run("before start closes done", func(t *testing.T) {
+		t.Parallel()
+
+		ch := NewDurableQueueChannel[int]("d-stop-early", t.TempDir()).(*durable[int])
+
+		err := ch.Stop(context.Background())
+		if err != nil {
+			t.Fatalf("Stop returned %v", err)
+		}
+
+		select {
+		case <-ch.Done():
+		default:
+			t.Fatal("expected Done to be closed")
+		}
+
+		err = ch.Start(context.Background())
+		if err != nil {
+			t.Fatalf("late Start returned %v", err)
+		}
+
+		if ch.log.Load() != nil {
+			t.Fatal("expected late Start to be a no-op")
+		}
+	})
+
+	t.Run("before start keeps buffered messages on disk", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+
+		ch := NewDurableQueueChannel[int]("d-stop-buffered", dir).(*durable[int])
+
+		err := ch.Send(context.Background(), NewMessage[int](5, nil))
+		if err != nil {
+			t.Fatalf("Send returned %v", err)
+		}
+
+		err = ch.Stop(context.Background())
+		if err != nil {
+			t.Fatalf("Stop returned %v", err)
+		}
+
+		reopened, records, err := openWAL(dir, defaultSegmentSize, 0, true)
+		if err != nil {
+			t.Fatalf("openWAL returned %v", err)
+		}
+		defer reopened.close()
+
+		if len(records) != 1 {
+			t.Fatalf("expected buffered message on disk, got %d records", len(records))
+		}
+	})
+
+	t.Run("idempotent", func(t *testing.T) {
+		t.Parallel()
+
+		ch := NewDurableQueueChannel[int]("d-stop-idem", t.TempDir()).(*durable[int])
+
+		err := ch.Start(context.Background())
+		if err != nil {
+			t.Fatalf("Start returned %v", err)
+		}
+
+		lctests.AssertIdempotentStop(t, ch)
+	})
+}
+
+func TestDurableQueueChannel_Expiration(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	expired := make(chan error, 1)
+	delivered := make(chan int, 2)
+
+	ch := NewDurableQueueChannel[int]("d-expiration", dir,
+		WithExpiredHandler(func(_ context.Context, _ any, err error) { expired <- err }),
+	).(*durable[int])
+
+	_, err := ch.Subscribe(func(_ context.Context, msg Message[int]) error {
+		delivered <- msg.Payload
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Subscribe returned %v", err)
+	}
+
+	err = ch.Start(context.Background())
+	if err != nil {
+		t.Fatalf("Start returned %v", err)
+	}
+
+	_ = ch.Send(context.Background(), expiredMessage(1))
+	_ = ch.Send(context.Background(), NewMessage(2, nil))
+
+	got := <-expired
+	if !errors.Is(got, ErrExpired) {
+		t.Fatalf("expected ErrExpired, got %v", got)
+	}
+
+	payload := <-delivered
+	if payload != 2 {
+		t.Fatalf("expected only the live message dispatched, got %d", payload)
+	}
+
+	err = ch.Stop(context.Background())
+	if err != nil {
+		t.Fatalf("Stop returned %v", err)
+	}
+
+	// The expired record was acknowledged: a restart replays nothing.
+	reopened := NewDurableQueueChannel[int]("d-expiration", dir,
+		WithExpiredHandler(func(_ context.Context, _ any, err error) { expired <- err }),
+	).(*durable[int])
+
+	err = reopened.Start(context.Background())
+	if err != nil {
+		t.Fatalf("Start returned %v", err)
+	}
+
+	t.Cleanup(func() { _ = reopened.Stop(context.Background()) })
+
+	select {
+	case err = <-expired:
+		t.Fatalf("expired record replayed: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestDurableQueueChannel_Stats(t *testing.T) {
+	t.Parallel()
+
+	ch, gate := startBlockedDurable(t, WithBufferSize(4))
+
+	for i := 1; i <= 2; i++ {
+		err := ch.Send(context.Background(), NewMessage(i, nil))
+		if err != nil {
+			t.Fatalf("Send returned %v", err)
+		}
+	}
+
+	got := ch.Stats()
+	if got.Sent != 3 || got.BufferLength != 2 || got.BufferCapacity != 4 || got.Subscribers != 1 || got.InFlight != 1 {
+		t.Fatalf("while blocked: %+v, want Sent=3 BufferLength=2 BufferCapacity=4 Subscribers=1 InFlight=1", got)
+	}
+
+	close(gate)
+
+	err := ch.Stop(context.Background())
+	if err != nil {
+		t.Fatalf("Stop returned %v", err)
+	}
+
+	got = ch.Stats()
+	if got.Delivered != 3 || got.InFlight != 0 || got.BufferLength != 0 {
+		t.Fatalf("after Stop: %+v, want Delivered=3 InFlight=0 BufferLength=0", got)
+	}
+}
@@ -18,6 +18,8 @@ import (
 // wiring that beats nil-checks on a Channel[T] field.
 type null[T any] struct {
 	errorHandler ErrorHandler
+	interceptors interceptorChain[T]
+	stats        StatsRecorder
 }
 
 // NewNullChannel returns a Channel[T] that drops every message sent to
@@ -26,7 +28,12 @@ type null[T any] struct {
 // Subscribed handlers are accepted for interface compatibility but
 // are never invoked.
 func NewNullChannel[T any](opts ...Option) Channel[T] {
-	return &null[T]{errorHandler: NewOptions(opts...).errorHandler}
+	options := NewOptions(opts...)
+
+	c := &null[T]{interceptors: extractInterceptors[T](options.interceptors)}
+	c.errorHandler = c.stats.dropHook(options.errorHandler)
+
+	return c
 }
 
 // Send drops msg on the floor and notifies the ErrorHandler hook with
@@ -36,17 +43,30 @@ func NewNullChannel[T any](opts ...Option) Channel[T] {
 // nil ctx never reaches a handler).
 func (c *null[T]) Send(ctx context.Context, msg Message[T]) error {
 	cassert.NotNil(c, "nullChannel is nil")
+
+	return c.interceptors.send(ctx, msg, c.send)
+}
+
+// send is Send without the interceptor chain.
+func (c *null[T]) send(ctx context.Context, msg Message[T]) error {
 	if ctx == nil {
 		return ErrSend(ErrContextNil)
 	}
 
-	if c.errorHandler != nil {
-		c.errorHandler(ctx, msg, ErrDropped)
-	}
+	c.stats.RecordSent()
+	c.errorHandler(ctx, msg, ErrDropped)
 
 	return nil
 }
 
+// Stats returns a snapshot of the channel's runtime statistics. Every
+// sent message is also counted as dropped; nothing is ever delivered.
+func (c *null[T]) Stats() Stats {
+	cassert.NotNil(c, "nullChannel is nil")
+
+	return c.stats.Snapshot()
+}
+
 // Subscribe accepts handler for interface compatibility and returns a
 // no-op Cancel. The handler is never invoked — NullChannel does not
 // dispatch. Returns ErrSubscribe(ErrHandlerNil) when handler is nil,
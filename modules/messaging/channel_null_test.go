@@ -177,3 +177,21 @@ func TestNewNullChannel(t *testing.T) {
 		}
 	})
 }
+
+func TestNullChannel_Stats(t *testing.T) {
+	t.Parallel()
+
+	ch := NewNullChannel[int]().(*null[int])
+
+	for i := range 3 {
+		err := ch.Send(context.Background(), NewMessage(i, nil))
+		if err != nil {
+			t.Fatalf("Send: %v", err)
+		}
+	}
+
+	got := ch.Stats()
+	if got.Sent != 3 || got.Dropped != 3 || got.Delivered != 0 {
+		t.Fatalf("Stats = %+v, want Sent=3 Dropped=3 Delivered=0", got)
+	}
+}
@@ -22,6 +22,9 @@ type pipeline[T any] struct {
 	nextID uint64
 	order  []uint64
 	byID   map[uint64]Handler[T]
+	stats  StatsRecorder
+
+	interceptors interceptorChain[T]
 }
 
 // NewPipelineChannel creates a synchronous Channel[T] that dispatches
@@ -33,9 +36,13 @@ type pipeline[T any] struct {
 // the caller's transaction, cache invalidation, metrics that must be
 // flushed before the response, or a "bridge to async" step that hands
 // the message off to a TopicChannel.
-func NewPipelineChannel[T any]() Channel[T] {
+//
+// The pipeline has no buffer, workers or error hook, so the only
+// option it honors is WithInterceptors; the rest are ignored.
+func NewPipelineChannel[T any](opts ...Option) Channel[T] {
 	return &pipeline[T]{
-		byID: map[uint64]Handler[T]{},
+		byID:         map[uint64]Handler[T]{},
+		interceptors: extractInterceptors[T](NewOptions(opts...).interceptors),
 	}
 }
 
@@ -56,12 +63,23 @@ func NewPipelineChannel[T any]() Channel[T] {
 func (c *pipeline[T]) Send(ctx context.Context, msg Message[T]) error {
 	cassert.NotNil(c, "pipeline is nil")
 
+	return c.interceptors.send(ctx, msg, c.send)
+}
+
+// send is Send without the interceptor chain.
+func (c *pipeline[T]) send(ctx context.Context, msg Message[T]) error {
 	if ctx == nil {
 		return ErrSend(ErrContextNil)
 	}
 
 	handlers := snapshotHandlers(&c.mu, &c.order, c.byID)
 
+	c.stats.RecordSent()
+
+	if len(handlers) == 0 {
+		c.stats.RecordDrop()
+	}
+
 	steps := make([]StepResult, len(handlers))
 	failed := -1
 
@@ -72,7 +90,10 @@ func (c *pipeline[T]) Send(ctx context.Context, msg Message[T]) error {
 			continue
 		}
 
+		end := c.stats.Begin()
 		steps[i] = invokeStep(ctx, msg, i, handler)
+		end(steps[i].Err)
+
 		if steps[i].Status != StepStatusOK {
 			failed = i
 		}
@@ -85,6 +106,21 @@ func (c *pipeline[T]) Send(ctx context.Context, msg Message[T]) error {
 	return ErrSend(&ChainError{Steps: steps, Failed: failed}, ErrChainFailed)
 }
 
+// Stats returns a snapshot of the channel's runtime statistics. The
+// pipeline is unbuffered, so BufferLength and BufferCapacity are
+// always zero; a Send with no subscribers is counted as dropped.
+func (c *pipeline[T]) Stats() Stats {
+	cassert.NotNil(c, "pipeline is nil")
+
+	stats := c.stats.Snapshot()
+
+	c.mu.RLock()
+	stats.Subscribers = len(c.order)
+	c.mu.RUnlock()
+
+	return stats
+}
+
 // Subscribe registers handler at the end of the chain and returns a
 // Cancel that detaches it. Cancel is idempotent. Subscribe returns
 // ErrSubscribe(ErrHandlerNil) when handler is nil.
@@ -101,6 +137,8 @@ func (c *pipeline[T]) Subscribe(handler Handler[T]) (Cancel, error) {
 		return nil, ErrSubscribe(ErrHandlerNil)
 	}
 
+	handler = c.interceptors.wrap(handler)
+
 	c.mu.Lock()
 	c.nextID++
 	id := c.nextID
@@ -379,3 +379,26 @@ func TestPipelineChannel_Concurrent(t *testing.T) {
 		t.Fatalf("expected %d deliveries, got %d", want, got)
 	}
 }
+
+func TestPipelineChannel_Stats(t *testing.T) {
+	t.Parallel()
+
+	ch := NewPipelineChannel[int]().(*pipeline[int])
+
+	_, err := ch.Subscribe(func(_ context.Context, _ Message[int]) error { return errors.New("boom") })
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	_, err = ch.Subscribe(func(_ context.Context, _ Message[int]) error { return nil })
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	_ = ch.Send(context.Background(), NewMessage(1, nil))
+
+	got := ch.Stats()
+	if got.Subscribers != 2 || got.Sent != 1 || got.Delivered != 1 || got.Failed != 1 {
+		t.Fatalf("Stats = %+v, want Subscribers=2 Sent=1 Delivered=1 Failed=1 (second step skipped)", got)
+	}
+}
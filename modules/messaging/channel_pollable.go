@@ -2,8 +2,10 @@ package messaging
 
 import (
 	"context"
+	"errors"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	cassert "github.com/guidomantilla/yarumo/core/common/assert"
 )
@@ -20,22 +22,53 @@ import (
 // Send and multiple consumers may call Receive simultaneously; the
 // fairness across competing Receivers is whatever Go's channel runtime
 // guarantees (FIFO across the goroutines blocked on the same channel).
+//
+// Receive never yields a message whose Headers.ExpirationTime has
+// passed: expired messages are discarded through expiredHandler (and
+// the DLQ when configured) and Receive moves on to the next one.
+//
+// ReceiveWithLease hands out the same messages under a lease whose
+// timer puts the message back in buf when it runs out. mu serializes
+// those requeues with Close so none lands on a closed buf.
 type pollable[T any] struct {
-	buf       chan Message[T]
-	closed    atomic.Bool
-	closeOnce sync.Once
+	buf               chan Message[T]
+	visibilityTimeout time.Duration
+	maxDeliveries     int
+	clock             Clock
+	errorHandler      ErrorHandler
+	expiredHandler    ErrorHandler
+	dlq               Channel[DeadLetter[T]]
+	interceptors      interceptorChain[T]
+	stats             StatsRecorder
+	mu                sync.RWMutex
+	closed            atomic.Bool
+	closeOnce         sync.Once
 }
 
 // NewPollableChannel constructs a PollableChannel[T] with the given
 // options. The buffer capacity is configured via WithBufferSize
-// (default defaultBufferSize). The channel is immediately usable —
-// there is no Start step.
+// (default defaultBufferSize); expired messages are reported through
+// WithExpiredHandler (or WithErrorHandler) and WithDLQChannel. Leases
+// follow WithVisibilityTimeout, WithMaxDeliveries and WithClock;
+// messages given up on are reported through WithErrorHandler. The
+// channel is immediately usable — there is no Start step.
 func NewPollableChannel[T any](opts ...Option) PollableChannel[T] {
 	options := NewOptions(opts...)
 
-	return &pollable[T]{
-		buf: make(chan Message[T], options.bufferSize),
+	c := &pollable[T]{
+		buf:               make(chan Message[T], options.bufferSize),
+		visibilityTimeout: options.visibilityTimeout,
+		maxDeliveries:     options.maxDeliveries,
+		clock:             options.clock,
 	}
+
+	c.stats.UseClock(options.clock)
+	c.errorHandler = c.stats.dropHook(options.errorHandler)
+	c.expiredHandler = c.stats.dropHook(expiredHook(options))
+	c.dlq = countDeadLetters(extractDLQ[T](options.dlq), &c.stats)
+	c.interceptors = extractInterceptors[T](options.interceptors)
+
+	return c
 }
 
 // Send enqueues msg into the internal buffer. When the buffer has
@@ -46,6 +79,11 @@ func NewPollableChannel[T any](opts ...Option) PollableChannel[T] {
 func (c *pollable[T]) Send(ctx context.Context, msg Message[T]) error {
 	cassert.NotNil(c, "PollableChannel is nil")
 
+	return c.interceptors.send(ctx, msg, c.send)
+}
+
+// send is Send without the interceptor chain.
+func (c *pollable[T]) send(ctx context.Context, msg Message[T]) error {
 	if ctx == nil {
 		return ErrSend(ErrContextNil)
 	}
@@ -58,12 +96,16 @@ func (c *pollable[T]) Send(ctx context.Context, msg Message[T]) error {
 	// capacity we avoid the select + ctx.Done channel allocation cost.
 	select {
 	case c.buf <- msg:
+		c.stats.RecordSent()
+
 		return nil
 	default:
 	}
 
 	select {
 	case c.buf <- msg:
+		c.stats.RecordSent()
+
 		return nil
 	case <-ctx.Done():
 		return ErrSend(ErrTimeout, ctx.Err())
@@ -74,7 +116,9 @@ func (c *pollable[T]) Send(ctx context.Context, msg Message[T]) error {
 // channel is closed AND drained. After Close, Receive keeps yielding
 // buffered messages until the buffer is empty; only then does it
 // return ErrReceive(ErrChannelClosed). This drain-then-close protocol
-// matches the documented Spring PollableChannel semantics.
+// matches the documented Spring PollableChannel semantics. Messages
+// past their Headers.ExpirationTime are discarded (see
+// WithExpiredHandler) and Receive keeps waiting for a live one.
 func (c *pollable[T]) Receive(ctx context.Context) (Message[T], error) {
 	cassert.NotNil(c, "PollableChannel is nil")
 
@@ -84,6 +128,76 @@ func (c *pollable[T]) Receive(ctx context.Context) (Message[T], error) {
 		return zero, ErrReceive(ErrContextNil)
 	}
 
+	msg, err := c.receiveLive(ctx)
+	if err != nil {
+		return zero, err
+	}
+
+	// A received message is handed off to the caller: the delivery is
+	// counted but never stays in flight.
+	end := c.stats.Begin()
+	end(nil)
+
+	return msg, nil
+}
+
+// ReceiveWithLease blocks and fails like Receive, but returns the
+// message under a Lease of WithVisibilityTimeout. The leased message
+// carries Headers.DeliveryCount incremented by one and counts as in
+// flight until the lease is settled: Ack counts it delivered, Nack or
+// expiry count it failed and requeue it at the tail of the buffer
+// (see Lease and WithMaxDeliveries).
+func (c *pollable[T]) ReceiveWithLease(ctx context.Context) (Lease[T], error) {
+	cassert.NotNil(c, "PollableChannel is nil")
+
+	if ctx == nil {
+		return nil, ErrReceive(ErrContextNil)
+	}
+
+	msg, err := c.receiveLive(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	msg.Headers.DeliveryCount++
+
+	l := &lease[T]{
+		channel:  c,
+		msg:      msg,
+		ctx:      context.WithoutCancel(ctx),
+		end:      c.stats.Begin(),
+		deadline: c.clock.Now().Add(c.visibilityTimeout),
+	}
+
+	l.mu.Lock()
+	l.timer = c.clock.AfterFunc(c.visibilityTimeout, l.expire)
+	l.mu.Unlock()
+
+	return l, nil
+}
+
+// receiveLive takes the next buffered message that has not expired,
+// discarding the expired ones on the way.
+func (c *pollable[T]) receiveLive(ctx context.Context) (Message[T], error) {
+	for {
+		msg, err := c.receive(ctx)
+		if err != nil {
+			return msg, err
+		}
+
+		if dropExpired(ctx, msg, c.clock.Now(), c.expiredHandler, c.dlq) {
+			continue
+		}
+
+		return msg, nil
+	}
+}
+
+// receive takes the next buffered message regardless of expiry. It
+// is the body of Receive minus the expiry filter.
+func (c *pollable[T]) receive(ctx context.Context) (Message[T], error) {
+	var zero Message[T]
+
 	// Fast path: if a message is already buffered, take it without
 	// touching ctx.Done. This also matters for the post-Close drain —
 	// a closed Go channel still delivers buffered values via the same
@@ -110,6 +224,21 @@ func (c *pollable[T]) Receive(ctx context.Context) (Message[T], error) {
 	}
 }
 
+// Stats returns a snapshot of the channel's runtime statistics.
+// Delivered counts the messages returned by Receive and
+// ReceiveWithLease; InFlight counts the unsettled leases and Failed
+// the nacked or expired ones. Subscribers is always zero because the
+// channel has no handlers.
+func (c *pollable[T]) Stats() Stats {
+	cassert.NotNil(c, "PollableChannel is nil")
+
+	stats := c.stats.Snapshot()
+	stats.BufferLength = len(c.buf)
+	stats.BufferCapacity = cap(c.buf)
+
+	return stats
+}
+
 // Close marks the channel as closed. Subsequent Send calls return
 // ErrSend(ErrClosed); pending and buffered messages remain receivable
 // until the buffer is drained, at which point Receive returns
@@ -118,9 +247,200 @@ func (c *pollable[T]) Close() error {
 	cassert.NotNil(c, "PollableChannel is nil")
 
 	c.closeOnce.Do(func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
 		c.closed.Store(true)
 		close(c.buf)
 	})
 
 	return nil
 }
+
+// requeue puts a released lease's message back at the tail of the
+// buffer. A message already leased WithMaxDeliveries times is
+// dead-lettered with ErrMaxDeliveries instead; one that does not fit
+// (buffer full, channel closed) with ErrRedeliveryFailed.
+func (c *pollable[T]) requeue(ctx context.Context, msg Message[T]) {
+	if c.maxDeliveries > 0 && msg.Headers.DeliveryCount >= c.maxDeliveries {
+		c.giveUp(ctx, msg, ErrMaxDeliveries)
+
+		return
+	}
+
+	err := c.offer(msg)
+	if err != nil {
+		c.giveUp(ctx, msg, errors.Join(ErrRedeliveryFailed, err))
+	}
+}
+
+// offer enqueues msg without blocking.
+func (c *pollable[T]) offer(msg Message[T]) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.closed.Load() {
+		return ErrClosed
+	}
+
+	select {
+	case c.buf <- msg:
+		return nil
+	default:
+		return ErrBufferFull
+	}
+}
+
+// giveUp reports msg to the ErrorHandler with reason and publishes it
+// to the DLQ with reason as LastError.
+func (c *pollable[T]) giveUp(ctx context.Context, msg Message[T], reason error) {
+	c.errorHandler(ctx, msg, reason)
+	PublishDeadLetter(ctx, c.dlq, msg, reason, c.clock.Now())
+}
+
+// lease is the pollable Lease. mu guards deadline, timer and settled;
+// settled flips exactly once, by Ack, Nack or expire, and end closes
+// the stats delivery opened by ReceiveWithLease at the same time.
+type lease[T any] struct {
+	channel *pollable[T]
+	msg     Message[T]
+	ctx     context.Context
+	end     func(err error)
+
+	mu       sync.Mutex
+	deadline time.Time
+	timer    Timer
+	settled  bool
+}
+
+// Message returns the leased message.
+func (l *lease[T]) Message() Message[T] {
+	cassert.NotNil(l, "lease is nil")
+
+	return l.msg
+}
+
+// Deadline returns the instant the lease expires.
+func (l *lease[T]) Deadline() time.Time {
+	cassert.NotNil(l, "lease is nil")
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.deadline
+}
+
+// Ack settles the lease and drops the message for good. It returns
+// ErrLease(ErrLeaseLost) when the lease was already settled or its
+// deadline has passed, and ErrLease(ErrContextNil) for a nil ctx.
+func (l *lease[T]) Ack(ctx context.Context) error {
+	cassert.NotNil(l, "lease is nil")
+
+	if ctx == nil {
+		return ErrLease(ErrContextNil)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.held() {
+		return ErrLease(ErrLeaseLost)
+	}
+
+	l.settle(nil)
+
+	return nil
+}
+
+// Nack settles the lease and requeues the message after requeueDelay,
+// immediately when it is not positive. It fails like Ack.
+func (l *lease[T]) Nack(ctx context.Context, requeueDelay time.Duration) error {
+	cassert.NotNil(l, "lease is nil")
+
+	if ctx == nil {
+		return ErrLease(ErrContextNil)
+	}
+
+	l.mu.Lock()
+
+	if !l.held() {
+		l.mu.Unlock()
+
+		return ErrLease(ErrLeaseLost)
+	}
+
+	l.settle(ErrLeaseLost)
+	l.mu.Unlock()
+
+	requeueCtx := context.WithoutCancel(ctx)
+
+	if requeueDelay <= 0 {
+		l.channel.requeue(requeueCtx, l.msg)
+
+		return nil
+	}
+
+	l.channel.clock.AfterFunc(requeueDelay, func() { l.channel.requeue(requeueCtx, l.msg) })
+
+	return nil
+}
+
+// Extend moves the deadline to extension from now. It fails like Ack
+// and returns ErrLease(ErrLeaseExtension) when extension is not
+// positive.
+func (l *lease[T]) Extend(ctx context.Context, extension time.Duration) error {
+	cassert.NotNil(l, "lease is nil")
+
+	if ctx == nil {
+		return ErrLease(ErrContextNil)
+	}
+
+	if extension <= 0 {
+		return ErrLease(ErrLeaseExtension)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.held() {
+		return ErrLease(ErrLeaseLost)
+	}
+
+	l.deadline = l.channel.clock.Now().Add(extension)
+	l.timer.Reset(extension)
+
+	return nil
+}
+
+// expire is the lease timer's callback: it settles a lease whose
+// deadline has passed and requeues the message. A callback that lost
+// the race with Extend finds the deadline moved and does nothing; the
+// reset timer fires again.
+func (l *lease[T]) expire() {
+	l.mu.Lock()
+
+	if l.settled || l.channel.clock.Now().Before(l.deadline) {
+		l.mu.Unlock()
+
+		return
+	}
+
+	l.settle(ErrLeaseLost)
+	l.mu.Unlock()
+
+	l.channel.requeue(l.ctx, l.msg)
+}
+
+// held reports whether the lease is still unsettled and within its
+// deadline. Callers hold mu.
+func (l *lease[T]) held() bool {
+	return !l.settled && l.channel.clock.Now().Before(l.deadline)
+}
+
+// settle marks the lease settled, stops its timer and closes its stats
+// delivery with err. Callers hold mu.
+func (l *lease[T]) settle(err error) {
+	l.settled = true
+	l.timer.Stop()
+	l.end(err)
+}
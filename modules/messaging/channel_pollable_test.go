@@ -375,3 +375,340 @@ func TestPollableChannel_Close(t *testing.T) {
 		}
 	})
 }
+
+func TestPollableChannel_ReceiveSkipsExpired(t *testing.T) {
+	t.Parallel()
+
+	dlq := NewPipelineChannel[DeadLetter[int]]()
+
+	var (
+		expired []int
+		letters []DeadLetter[int]
+	)
+
+	_, err := dlq.Subscribe(func(_ context.Context, m Message[DeadLetter[int]]) error {
+		letters = append(letters, m.Payload)
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("dlq Subscribe returned %v", err)
+	}
+
+	ch := NewPollableChannel[int](
+		WithBufferSize(4),
+		WithDLQChannel(dlq),
+		WithExpiredHandler(func(_ context.Context, msg any, _ error) {
+			expired = append(expired, msg.(Message[int]).Payload)
+		}),
+	)
+
+	_ = ch.Send(context.Background(), expiredMessage(1))
+	_ = ch.Send(context.Background(), expiredMessage(2))
+	_ = ch.Send(context.Background(), NewMessage(3, nil))
+
+	msg, err := ch.Receive(context.Background())
+	if err != nil {
+		t.Fatalf("Receive returned %v", err)
+	}
+
+	if msg.Payload != 3 {
+		t.Fatalf("expected the live message, got %d", msg.Payload)
+	}
+
+	if len(expired) != 2 || expired[0] != 1 || expired[1] != 2 {
+		t.Fatalf("expected both expired messages reported, got %v", expired)
+	}
+
+	if len(letters) != 2 || !errors.Is(letters[0].LastError, ErrExpired) {
+		t.Fatalf("expected two ErrExpired dead letters, got %v", letters)
+	}
+
+	_ = ch.Close()
+
+	_, err = ch.Receive(context.Background())
+	if !errors.Is(err, ErrChannelClosed) {
+		t.Fatalf("expected ErrChannelClosed, got %v", err)
+	}
+}
+
+func TestPollableChannel_Stats(t *testing.T) {
+	t.Parallel()
+
+	ch := NewPollableChannel[int](WithBufferSize(4), WithErrorHandler(SilentErrorHandler)).(*pollable[int])
+
+	err := ch.Send(context.Background(), expiredMessage(0))
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	err = ch.Send(context.Background(), NewMessage(1, nil))
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	got := ch.Stats()
+	if got.BufferLength != 2 || got.BufferCapacity != 4 || got.Sent != 2 {
+		t.Fatalf("before Receive: %+v, want BufferLength=2 BufferCapacity=4 Sent=2", got)
+	}
+
+	_, err = ch.Receive(context.Background())
+	if err != nil {
+		t.Fatalf("Receive: %v", err)
+	}
+
+	got = ch.Stats()
+	if got.BufferLength != 0 || got.Delivered != 1 || got.Dropped != 1 || got.InFlight != 0 {
+		t.Fatalf("after Receive: %+v, want BufferLength=0 Delivered=1 Dropped=1 InFlight=0", got)
+	}
+}
+
+func TestPollableChannel_ReceiveWithLease(t *testing.T) {
+	t.Parallel()
+
+	// leased sends payload to a fresh channel and leases it back.
+	leased := func(t *testing.T, opts ...Option) (*pollable[int], Lease[int]) {
+		t.Helper()
+
+		ch := NewPollableChannel[int](append([]Option{WithErrorHandler(SilentErrorHandler)}, opts...)...).(*pollable[int])
+
+		err := ch.Send(context.Background(), NewMessage(7, nil))
+		if err != nil {
+			t.Fatalf("Send: %v", err)
+		}
+
+		l, err := ch.ReceiveWithLease(context.Background())
+		if err != nil {
+			t.Fatalf("ReceiveWithLease: %v", err)
+		}
+
+		return ch, l
+	}
+
+	t.Run("returns ErrContextNil on nil ctx", func(t *testing.T) {
+		t.Parallel()
+
+		ch := NewPollableChannel[int]()
+
+		//nolint:staticcheck // intentional nil ctx to exercise the guard
+		_, err := ch.ReceiveWithLease(nil)
+		if !errors.Is(err, ErrContextNil) {
+			t.Fatalf("expected ErrContextNil, got %v", err)
+		}
+	})
+
+	t.Run("Ack settles the lease", func(t *testing.T) {
+		t.Parallel()
+
+		ch, l := leased(t)
+
+		if l.Message().Payload != 7 || l.Message().Headers.DeliveryCount != 1 {
+			t.Fatalf("unexpected leased message %+v", l.Message())
+		}
+
+		if got := ch.Stats(); got.InFlight != 1 {
+			t.Fatalf("while leased: %+v, want InFlight=1", got)
+		}
+
+		err := l.Ack(context.Background())
+		if err != nil {
+			t.Fatalf("Ack: %v", err)
+		}
+
+		err = l.Ack(context.Background())
+		if !errors.Is(err, ErrLeaseLost) || !errors.Is(err, ErrLeaseFailed) {
+			t.Fatalf("second Ack: expected ErrLeaseLost, got %v", err)
+		}
+
+		err = l.Nack(context.Background(), 0)
+		if !errors.Is(err, ErrLeaseLost) {
+			t.Fatalf("Nack after Ack: expected ErrLeaseLost, got %v", err)
+		}
+
+		got := ch.Stats()
+		if got.InFlight != 0 || got.Delivered != 1 || got.Failed != 0 || got.BufferLength != 0 {
+			t.Fatalf("after Ack: %+v, want InFlight=0 Delivered=1 Failed=0 BufferLength=0", got)
+		}
+	})
+
+	t.Run("Nack requeues with an incremented delivery count", func(t *testing.T) {
+		t.Parallel()
+
+		ch, l := leased(t)
+
+		err := l.Nack(context.Background(), 0)
+		if err != nil {
+			t.Fatalf("Nack: %v", err)
+		}
+
+		again, err := ch.ReceiveWithLease(context.Background())
+		if err != nil {
+			t.Fatalf("ReceiveWithLease: %v", err)
+		}
+
+		if again.Message().Headers.DeliveryCount != 2 {
+			t.Fatalf("expected DeliveryCount 2, got %d", again.Message().Headers.DeliveryCount)
+		}
+
+		if got := ch.Stats(); got.Failed != 1 {
+			t.Fatalf("after Nack: %+v, want Failed=1", got)
+		}
+	})
+
+	t.Run("Nack honors the requeue delay", func(t *testing.T) {
+		t.Parallel()
+
+		ch, l := leased(t)
+
+		err := l.Nack(context.Background(), 50*time.Millisecond)
+		if err != nil {
+			t.Fatalf("Nack: %v", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		_, err = ch.ReceiveWithLease(ctx)
+		if !errors.Is(err, ErrTimeout) {
+			t.Fatalf("expected nothing before the delay, got %v", err)
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		again, err := ch.ReceiveWithLease(ctx)
+		if err != nil {
+			t.Fatalf("ReceiveWithLease after the delay: %v", err)
+		}
+
+		if again.Message().Payload != 7 {
+			t.Fatalf("unexpected payload %d", again.Message().Payload)
+		}
+	})
+
+	t.Run("expired lease is requeued", func(t *testing.T) {
+		t.Parallel()
+
+		ch, l := leased(t, WithVisibilityTimeout(20*time.Millisecond))
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		again, err := ch.ReceiveWithLease(ctx)
+		if err != nil {
+			t.Fatalf("ReceiveWithLease: %v", err)
+		}
+
+		if again.Message().Headers.DeliveryCount != 2 {
+			t.Fatalf("expected DeliveryCount 2, got %d", again.Message().Headers.DeliveryCount)
+		}
+
+		err = l.Ack(context.Background())
+		if !errors.Is(err, ErrLeaseLost) {
+			t.Fatalf("Ack of expired lease: expected ErrLeaseLost, got %v", err)
+		}
+	})
+
+	t.Run("Extend keeps the message invisible", func(t *testing.T) {
+		t.Parallel()
+
+		ch, l := leased(t, WithVisibilityTimeout(30*time.Millisecond))
+
+		err := l.Extend(context.Background(), 0)
+		if !errors.Is(err, ErrLeaseExtension) {
+			t.Fatalf("expected ErrLeaseExtension, got %v", err)
+		}
+
+		err = l.Extend(context.Background(), time.Second)
+		if err != nil {
+			t.Fatalf("Extend: %v", err)
+		}
+
+		if time.Until(l.Deadline()) < 500*time.Millisecond {
+			t.Fatalf("deadline not extended: %v", l.Deadline())
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 80*time.Millisecond)
+		defer cancel()
+
+		_, err = ch.ReceiveWithLease(ctx)
+		if !errors.Is(err, ErrTimeout) {
+			t.Fatalf("expected the extended lease to hold the message, got %v", err)
+		}
+
+		err = l.Ack(context.Background())
+		if err != nil {
+			t.Fatalf("Ack: %v", err)
+		}
+	})
+
+	// captured returns a DLQ that records the published dead letters.
+	captured := func(t *testing.T) (Channel[DeadLetter[int]], *[]DeadLetter[int]) {
+		t.Helper()
+
+		dlq := NewPipelineChannel[DeadLetter[int]]()
+
+		var letters []DeadLetter[int]
+
+		_, err := dlq.Subscribe(func(_ context.Context, m Message[DeadLetter[int]]) error {
+			letters = append(letters, m.Payload)
+
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("dlq Subscribe returned %v", err)
+		}
+
+		return dlq, &letters
+	}
+
+	t.Run("dead-letters past max deliveries", func(t *testing.T) {
+		t.Parallel()
+
+		dlq, letters := captured(t)
+
+		ch, l := leased(t, WithMaxDeliveries(2), WithDLQChannel(dlq))
+
+		err := l.Nack(context.Background(), 0)
+		if err != nil {
+			t.Fatalf("Nack: %v", err)
+		}
+
+		l, err = ch.ReceiveWithLease(context.Background())
+		if err != nil {
+			t.Fatalf("ReceiveWithLease: %v", err)
+		}
+
+		err = l.Nack(context.Background(), 0)
+		if err != nil {
+			t.Fatalf("Nack: %v", err)
+		}
+
+		if got := ch.Stats(); got.BufferLength != 0 || got.DeadLettered != 1 {
+			t.Fatalf("after the last Nack: %+v, want BufferLength=0 DeadLettered=1", got)
+		}
+
+		if len(*letters) != 1 || !errors.Is((*letters)[0].LastError, ErrMaxDeliveries) || (*letters)[0].Original.Headers.DeliveryCount != 2 {
+			t.Fatalf("unexpected dead letters %+v", *letters)
+		}
+	})
+
+	t.Run("dead-letters a lease released after Close", func(t *testing.T) {
+		t.Parallel()
+
+		dlq, letters := captured(t)
+
+		ch, l := leased(t, WithDLQChannel(dlq))
+
+		_ = ch.Close()
+
+		err := l.Nack(context.Background(), 0)
+		if err != nil {
+			t.Fatalf("Nack: %v", err)
+		}
+
+		if len(*letters) != 1 || !errors.Is((*letters)[0].LastError, ErrRedeliveryFailed) || !errors.Is((*letters)[0].LastError, ErrClosed) {
+			t.Fatalf("unexpected dead letters %+v", *letters)
+		}
+	})
+}
@@ -2,6 +2,7 @@ package messaging
 
 import (
 	"context"
+	"hash/fnv"
 	"slices"
 	"sync"
 	"sync/atomic"
@@ -13,10 +14,25 @@ import (
 
 // QueueChannel is the point-to-point async Channel[T] implementation:
 // every message is delivered to EXACTLY ONE subscriber, selected by
-// round-robin among the registered handlers. Multiple worker
+// the configured LoadBalancer (round-robin by default, see
+// WithLoadBalancer) among the registered handlers. Subscribers
+// registered through SubscribeWith may declare a weight and a
+// capacity; a subscriber at its capacity is skipped, and a worker only
+// waits when every subscriber is saturated. Multiple worker
 // goroutines (see WithWorkerCount) consume from the inbound buffer in
 // parallel, so a slow handler on one worker does not block the next
-// worker from picking up the next message.
+// worker from picking up the next message. Workers take messages in
+// arrival order by default; WithDispatchOrder(DispatchPriority) makes
+// them take the highest-priority pending message next (see
+// DispatchOrder).
+//
+// WithPartitionKey (or WithPartitioning) trades the shared pool for
+// one lane per worker: a distributor hashes each message's key to a
+// fixed lane, so the messages of a key are handled one at a time in
+// arrival order, and lane i is served by subscriber i modulo the live
+// subscriber count — the mapping follows subscribers as they join or
+// cancel, and subscribers beyond the lane count stay idle. Lanes
+// ignore the load balancer and subscriber capacities.
 //
 // QueueChannel implements both Channel[T] and lifecycle.Component
 // (worker-style): Start spawns the configured worker pool; Stop
@@ -40,18 +56,27 @@ type queue[T any] struct {
 	workerCount    int
 	drainTimeout   time.Duration
 	errorHandler   ErrorHandler
+	expiredHandler ErrorHandler
 	overflowPolicy OverflowPolicy
+	partitionKey   PartitionKeyFn
+	loadBalancer   LoadBalancer
+	clock          Clock
 	dlq            Channel[DeadLetter[T]]
+	interceptors   interceptorChain[T]
+	redelivery     *redeliverer[T]
+	stats          StatsRecorder
 
-	inbound chan envelope[T]
+	inbound mailbox[T]
+	lanes   []chan envelope[T]
 	done    chan struct{}
 	closed  atomic.Bool
 
-	mu       sync.RWMutex
-	nextID   uint64
-	order    []uint64
-	byID     map[uint64]Handler[T]
-	rotation uint64
+	mu         sync.RWMutex
+	ready      *sync.Cond
+	nextID     uint64
+	order      []uint64
+	byID       map[uint64]*subscription[T]
+	candidates []SubscriberState
 
 	workerWG  sync.WaitGroup
 	startOnce sync.Once
@@ -69,18 +94,37 @@ func NewQueueChannel[T any](name string, opts ...Option) Channel[T] {
 
 	options := NewOptions(opts...)
 
-	return &queue[T]{
+	c := &queue[T]{
 		name:           name,
 		bufferSize:     options.bufferSize,
 		workerCount:    options.workerCount,
 		drainTimeout:   options.drainTimeout,
-		errorHandler:   options.errorHandler,
 		overflowPolicy: options.overflowPolicy,
-		dlq:            extractDLQ[T](options.dlq),
-		inbound:        make(chan envelope[T], options.bufferSize),
+		partitionKey:   options.partitionKey,
+		loadBalancer:   options.loadBalancer,
+		clock:          options.clock,
+		inbound:        newMailbox[T](options.bufferSize, options.dispatchOrder, options.priorityAging, options.clock),
 		done:           make(chan struct{}),
-		byID:           map[uint64]Handler[T]{},
+		byID:           map[uint64]*subscription[T]{},
 	}
+
+	c.ready = sync.NewCond(&c.mu)
+
+	c.stats.UseClock(options.clock)
+	c.errorHandler = c.stats.dropHook(options.errorHandler)
+	c.expiredHandler = c.stats.dropHook(expiredHook(options))
+	c.dlq = countDeadLetters(extractDLQ[T](options.dlq), &c.stats)
+	c.interceptors = extractInterceptors[T](options.interceptors)
+	c.redelivery = newRedeliverer(options.redelivery, c.errorHandler, c.dlq, options.clock)
+
+	if c.partitionKey != nil {
+		c.lanes = make([]chan envelope[T], c.workerCount)
+		for i := range c.lanes {
+			c.lanes[i] = make(chan envelope[T], max(1, c.bufferSize/c.workerCount))
+		}
+	}
+
+	return c
 }
 
 // Name returns the channel's identity used in lifecycle logs.
@@ -92,14 +136,30 @@ func (c *queue[T]) Name() string {
 
 // Start spawns the worker pool. Each worker consumes from the
 // inbound buffer and dispatches each message to one subscriber
-// chosen by round-robin among the currently registered handlers.
-// Start is idempotent.
+// chosen by the load balancer among the currently registered handlers.
+// Under WithPartitionKey it spawns the distributor instead and binds
+// each worker to its lane. With WithRedeliveryPolicy it also spawns
+// the redelivery scheduler. Start is idempotent.
 func (c *queue[T]) Start(ctx context.Context) error {
 	cassert.NotNil(c, "QueueChannel is nil")
 
 	c.startOnce.Do(func() {
-		for range c.workerCount {
-			c.workerWG.Go(func() { c.run(ctx) })
+		if c.lanes != nil {
+			c.workerWG.Go(c.distribute)
+
+			for lane := range c.lanes {
+				c.workerWG.Go(func() { c.runLane(ctx, lane) })
+			}
+		}
+
+		if c.lanes == nil {
+			for range c.workerCount {
+				c.workerWG.Go(func() { c.run(ctx) })
+			}
+		}
+
+		if c.redelivery != nil {
+			c.workerWG.Go(c.redelivery.run)
 		}
 
 		go c.awaitDrain()
@@ -117,14 +177,20 @@ func (c *queue[T]) awaitDrain() {
 
 // Stop closes the inbound buffer and waits for every worker to
 // finish processing in-flight messages, bounded by the configured
-// drain timeout or by ctx's deadline (whichever is tighter). Stop is
-// idempotent per the lifecycle.Component contract.
+// drain timeout or by ctx's deadline (whichever is tighter). Messages
+// still waiting for redelivery are dead-lettered. Stop is idempotent
+// per the lifecycle.Component contract.
 func (c *queue[T]) Stop(ctx context.Context) error {
 	cassert.NotNil(c, "QueueChannel is nil")
 
 	c.stopOnce.Do(func() {
 		c.closed.Store(true)
-		close(c.inbound)
+
+		if c.redelivery != nil {
+			c.redelivery.close()
+		}
+
+		c.inbound.close()
 	})
 
 	// Defensive: WithDrainTimeout rejects non-positive values, but a
@@ -164,6 +230,11 @@ func (c *queue[T]) Done() <-chan struct{} {
 func (c *queue[T]) Send(ctx context.Context, msg Message[T]) error {
 	cassert.NotNil(c, "QueueChannel is nil")
 
+	return c.interceptors.send(ctx, msg, c.send)
+}
+
+// send is Send without the interceptor chain.
+func (c *queue[T]) send(ctx context.Context, msg Message[T]) error {
 	if ctx == nil {
 		return ErrSend(ErrContextNil)
 	}
@@ -172,24 +243,48 @@ func (c *queue[T]) Send(ctx context.Context, msg Message[T]) error {
 		return ErrSend(ErrClosed)
 	}
 
-	return sendWithPolicy(ctx, c.inbound, msg, c.overflowPolicy, c.errorHandler)
+	err := c.inbound.put(ctx, msg, c.overflowPolicy, c.errorHandler)
+	if err != nil {
+		return err
+	}
+
+	c.stats.RecordSent()
+
+	return nil
 }
 
-// Subscribe registers handler at the end of the rotation pool and
+// Subscribe registers handler at the end of the subscriber pool and
 // returns a Cancel that detaches it. Cancel is idempotent. Subscribe
 // returns ErrSubscribe(ErrHandlerNil) when handler is nil and
 // ErrSubscribe(ErrClosed) when the channel has been stopped.
 //
-// All registered handlers are equivalent peers competing for
-// messages: subsequent messages are distributed round-robin among
-// the live set, skipping cancelled handlers.
+// All registered handlers are peers competing for messages:
+// subsequent messages are distributed among the live set by the load
+// balancer, skipping cancelled handlers. Subscribe is SubscribeWith
+// without options: weight 1, unbounded capacity.
 func (c *queue[T]) Subscribe(handler Handler[T]) (Cancel, error) {
 	cassert.NotNil(c, "QueueChannel is nil")
 
+	return c.SubscribeWith(handler)
+}
+
+// SubscribeWith is Subscribe with the weight and capacity of the
+// subscription set by opts (see WithSubscriptionWeight and
+// WithSubscriptionCapacity).
+func (c *queue[T]) SubscribeWith(handler Handler[T], opts ...SubscriptionOption) (Cancel, error) {
+	cassert.NotNil(c, "QueueChannel is nil")
+
 	if handler == nil {
 		return nil, ErrSubscribe(ErrHandlerNil)
 	}
 
+	options := NewSubscriptionOptions(opts...)
+	sub := &subscription[T]{
+		handler:  c.interceptors.wrap(handler),
+		weight:   options.weight,
+		capacity: options.capacity,
+	}
+
 	// closed check + register under the same lock as Stop's
 	// close-of-inbound so a Subscribe racing with Stop either bails
 	// out cleanly or completes before Stop closes the channel. No
@@ -203,8 +298,9 @@ func (c *queue[T]) Subscribe(handler Handler[T]) (Cancel, error) {
 
 	c.nextID++
 	id := c.nextID
-	c.byID[id] = handler
+	c.byID[id] = sub
 	c.order = append(c.order, id)
+	c.ready.Broadcast()
 	c.mu.Unlock()
 
 	var once sync.Once
@@ -219,6 +315,8 @@ func (c *queue[T]) Subscribe(handler Handler[T]) (Cancel, error) {
 			if i := slices.Index(c.order, id); i >= 0 {
 				c.order = slices.Delete(c.order, i, i+1)
 			}
+
+			c.ready.Broadcast()
 		})
 	}
 
@@ -226,15 +324,130 @@ func (c *queue[T]) Subscribe(handler Handler[T]) (Cancel, error) {
 }
 
 // run is one worker's loop. It consumes from inbound until the
-// channel is closed, then exits. The WaitGroup accounting is handled
-// by the wg.Go call site in Start.
+// channel is closed and drained, then exits. The WaitGroup accounting
+// is handled by the wg.Go call site in Start.
 func (c *queue[T]) run(workerCtx context.Context) {
-	for env := range c.inbound {
-		c.dispatch(workerCtx, env)
+	for {
+		env, ok := c.inbound.take(nil)
+		if !ok {
+			return
+		}
+
+		c.dispatch(workerCtx, env, -1)
+	}
+}
+
+// distribute is the partitioned channel's distributor loop. It moves
+// every message from inbound to the lane its key hashes to (keyless
+// messages rotate over the lanes) and closes the lanes once inbound is
+// closed and drained, so the lane workers drain and exit in turn.
+func (c *queue[T]) distribute() {
+	defer func() {
+		for _, lane := range c.lanes {
+			close(lane)
+		}
+	}()
+
+	var rotation uint64
+
+	for {
+		env, ok := c.inbound.take(nil)
+		if !ok {
+			return
+		}
+
+		key := c.partitionKey(env.msg.Headers)
+		if key == "" {
+			c.lanes[rotation%uint64(len(c.lanes))] <- env
+			rotation++
+
+			continue
+		}
+
+		hash := fnv.New32a()
+		_, _ = hash.Write([]byte(key))
+		c.lanes[hash.Sum32()%uint32(len(c.lanes))] <- env
 	}
 }
 
-// dispatch selects the next subscriber via round-robin and invokes
+// runLane is one partitioned worker's loop: it dispatches the messages
+// of its lane, in order, until the distributor closes the lane.
+func (c *queue[T]) runLane(workerCtx context.Context, lane int) {
+	for env := range c.lanes[lane] {
+		c.dispatch(workerCtx, env, lane)
+	}
+}
+
+// acquire selects the subscriber for the next message of lane and
+// counts the message in its in-flight total; release undoes the count.
+// For the shared pool (lane < 0) the load balancer picks among the
+// subscribers below their capacity, waiting while every subscriber is
+// saturated. A lane is served by the subscriber at its position
+// modulo the live subscriber count, whatever its load. acquire reports
+// false when no subscriber is registered, and returns the
+// ErrLoadBalancerPanic error when the load balancer panicked and the
+// first candidate was picked instead.
+func (c *queue[T]) acquire(lane int, headers Headers) (*subscription[T], bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for {
+		n := len(c.order)
+		if n == 0 {
+			return nil, false, nil
+		}
+
+		if lane >= 0 {
+			sub := c.byID[c.order[lane%n]]
+			sub.inFlight++
+
+			return sub, true, nil
+		}
+
+		c.candidates = c.candidates[:0]
+
+		for _, id := range c.order {
+			sub := c.byID[id]
+			if sub.capacity > 0 && sub.inFlight >= sub.capacity {
+				continue
+			}
+
+			c.candidates = append(c.candidates, SubscriberState{
+				ID:       id,
+				Weight:   sub.weight,
+				Capacity: sub.capacity,
+				InFlight: sub.inFlight,
+			})
+		}
+
+		if len(c.candidates) == 0 {
+			c.ready.Wait()
+
+			continue
+		}
+
+		idx, err := selectSubscriber(c.loadBalancer, headers, c.candidates)
+		if idx < 0 || idx >= len(c.candidates) {
+			idx = 0
+		}
+
+		sub := c.byID[c.candidates[idx].ID]
+		sub.inFlight++
+
+		return sub, true, err
+	}
+}
+
+// release ends the in-flight count acquire started for sub and wakes
+// the workers waiting for a subscriber below its capacity.
+func (c *queue[T]) release(sub *subscription[T]) {
+	c.mu.Lock()
+	sub.inFlight--
+	c.ready.Broadcast()
+	c.mu.Unlock()
+}
+
+// dispatch selects the subscriber for lane (see acquire) and invokes
 // its handler with panic recovery. The handler ctx is the worker's
 // lifecycle ctx merged with the publisher's Send ctx values (see
 // mergeContexts): cancellation, deadline and Err follow the worker
@@ -244,35 +457,82 @@ func (c *queue[T]) run(workerCtx context.Context) {
 // Errors and recovered panics are routed through the configured
 // ErrorHandler. Messages arriving when no subscribers are registered
 // are dropped and surfaced via the hook with ErrNoSubscribers.
-func (c *queue[T]) dispatch(workerCtx context.Context, env envelope[T]) {
+// Messages dequeued past their Headers.ExpirationTime are not
+// dispatched (see WithExpiredHandler). Under WithRedeliveryPolicy a
+// failed message is rescheduled instead of reported and dead-lettered
+// until its attempts are exhausted.
+func (c *queue[T]) dispatch(workerCtx context.Context, env envelope[T], lane int) {
 	handlerCtx := mergeContexts(workerCtx, env.sendCtx)
 
-	c.mu.Lock()
-
-	n := len(c.order)
-	if n == 0 {
-		c.mu.Unlock()
+	if dropExpired(handlerCtx, env.msg, c.clock.Now(), c.expiredHandler, c.dlq) {
+		return
+	}
 
-		if c.errorHandler != nil {
-			c.errorHandler(handlerCtx, env.msg, ErrNoSubscribers)
-		}
+	sub, ok, err := c.acquire(lane, env.msg.Headers)
+	if !ok {
+		c.errorHandler(handlerCtx, env.msg, ErrNoSubscribers)
 
 		return
 	}
 
-	idx := c.rotation % uint64(n)
-	c.rotation++
-	handler := c.byID[c.order[idx]]
-	c.mu.Unlock()
+	if err != nil {
+		c.errorHandler(handlerCtx, env.msg, err)
+	}
+
+	if c.redelivery != nil {
+		env.msg.Headers.DeliveryCount++
+	}
+
+	end := c.stats.Begin()
+	err = invokeHandler(handlerCtx, env.msg, sub.handler)
+	end(err)
+	c.release(sub)
 
-	err := invokeHandler(handlerCtx, env.msg, handler)
 	if err == nil {
 		return
 	}
 
-	if c.errorHandler != nil {
-		c.errorHandler(handlerCtx, env.msg, err)
+	if c.redelivery != nil && c.redelivery.schedule(env.sendCtx, env.msg, err, c.requeue) {
+		return
 	}
 
-	publishDeadLetter(handlerCtx, c.dlq, env.msg, err)
+	c.errorHandler(handlerCtx, env.msg, err)
+	PublishDeadLetter(handlerCtx, c.dlq, env.msg, err, c.clock.Now())
+}
+
+// Stats returns a snapshot of the channel's runtime statistics.
+// BufferLength and BufferCapacity describe the inbound buffer plus,
+// when partitioned, the lanes.
+func (c *queue[T]) Stats() Stats {
+	cassert.NotNil(c, "QueueChannel is nil")
+
+	stats := c.stats.Snapshot()
+	stats.BufferLength, stats.BufferCapacity = c.inbound.size()
+
+	for _, lane := range c.lanes {
+		stats.BufferLength += len(lane)
+		stats.BufferCapacity += cap(lane)
+	}
+
+	c.mu.RLock()
+	stats.Subscribers = len(c.order)
+	c.mu.RUnlock()
+
+	return stats
+}
+
+// requeue hands a message back to the inbound buffer for redelivery.
+// It never blocks: a full buffer rejects the message.
+func (c *queue[T]) requeue(ctx context.Context, msg Message[T]) error {
+	return c.inbound.put(ctx, msg, OverflowReject, nil)
+}
+
+// subscription is one QueueChannel subscriber: its handler, declared
+// weight and capacity, and the messages it is handling. inFlight is
+// guarded by the channel's mu.
+type subscription[T any] struct {
+	handler  Handler[T]
+	weight   int
+	capacity int
+	inFlight int
 }
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/guidomantilla/yarumo/core/common/lifecycle"
+	cretry "github.com/guidomantilla/yarumo/core/common/resilience/retry"
 )
 
 func TestNewQueueChannel(t *testing.T) {
@@ -490,11 +491,13 @@ func TestQueueChannel_WithDLQChannel_PublishesOnHandlerError(t *testing.T) {
 	}
 
 	wantErr := errors.New("queue boom")
+	instant := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
 
 	ch := NewQueueChannel[int]("q-dlq",
 		WithBufferSize(8),
 		WithDrainTimeout(time.Second),
 		WithDLQChannel(dlq),
+		WithClock(fixedClock{now: instant}),
 	).(*queue[int])
 
 	_, err = ch.Subscribe(func(_ context.Context, _ Message[int]) error {
@@ -530,6 +533,9 @@ func TestQueueChannel_WithDLQChannel_PublishesOnHandlerError(t *testing.T) {
 	if !errors.Is(dl.LastError, wantErr) {
 		t.Fatalf("expected DeadLetter.LastError=%v, got %v", wantErr, dl.LastError)
 	}
+	if !dl.FailedAt.Equal(instant) {
+		t.Fatalf("expected DeadLetter.FailedAt from the channel clock (%v), got %v", instant, dl.FailedAt)
+	}
 }
 
 func TestQueueChannel_Stop_DrainTimeoutDefaultWhenFieldZero(t *testing.T) {
@@ -540,9 +546,9 @@ func TestQueueChannel_Stop_DrainTimeoutDefaultWhenFieldZero(t *testing.T) {
 		bufferSize:   1,
 		workerCount:  1,
 		drainTimeout: 0,
-		inbound:      make(chan envelope[int], 1),
+		inbound:      newFIFOMailbox[int](1),
 		done:         make(chan struct{}),
-		byID:         map[uint64]Handler[int]{},
+		byID:         map[uint64]*subscription[int]{},
 	}
 
 	start := time.Now()
@@ -560,3 +566,834 @@ func TestQueueChannel_Stop_DrainTimeoutDefaultWhenFieldZero(t *testing.T) {
 		t.Fatalf("Stop returned in %v — guard did not apply default timeout", elapsed)
 	}
 }
+
+func TestQueueChannel_PriorityDispatch(t *testing.T) {
+	t.Parallel()
+
+	qc := NewQueueChannel[int]("q-priority",
+		WithBufferSize(8),
+		WithDispatchOrder(DispatchPriority),
+		WithPriorityAging(time.Hour),
+	).(*queue[int])
+
+	var (
+		mu  sync.Mutex
+		got []int
+	)
+
+	signal := make(chan struct{}, 8)
+
+	_, err := qc.Subscribe(func(_ context.Context, msg Message[int]) error {
+		mu.Lock()
+		got = append(got, msg.Payload)
+		mu.Unlock()
+
+		signal <- struct{}{}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Subscribe returned %v", err)
+	}
+
+	// Buffer before Start so the single worker sees every message.
+	for i, p := range []uint8{0, 9, 3, 9} {
+		msg := NewMessage(i, nil)
+		msg.Headers.Priority = p
+
+		err = qc.Send(context.Background(), msg)
+		if err != nil {
+			t.Fatalf("Send returned %v", err)
+		}
+	}
+
+	err = qc.Start(context.Background())
+	if err != nil {
+		t.Fatalf("Start returned %v", err)
+	}
+
+	t.Cleanup(func() { _ = qc.Stop(context.Background()) })
+
+	for range 4 {
+		<-signal
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	want := []int{1, 3, 2, 0}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("dispatch order: got %v want %v", got, want)
+		}
+	}
+}
+
+func TestQueueChannel_Expiration(t *testing.T) {
+	t.Parallel()
+
+	dlq := NewPipelineChannel[DeadLetter[int]]()
+
+	var (
+		mu      sync.Mutex
+		letters []DeadLetter[int]
+		expired []int
+		got     []int
+	)
+
+	_, err := dlq.Subscribe(func(_ context.Context, m Message[DeadLetter[int]]) error {
+		mu.Lock()
+		letters = append(letters, m.Payload)
+		mu.Unlock()
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("dlq Subscribe: %v", err)
+	}
+
+	qc := NewQueueChannel[int]("q-expiration",
+		WithBufferSize(8),
+		WithDLQChannel(dlq),
+		WithExpiredHandler(func(_ context.Context, msg any, err error) {
+			if !errors.Is(err, ErrExpired) || !errors.Is(err, ErrDropped) {
+				t.Errorf("unexpected hook error %v", err)
+			}
+
+			mu.Lock()
+			expired = append(expired, msg.(Message[int]).Payload)
+			mu.Unlock()
+		}),
+	).(*queue[int])
+
+	_, err = qc.Subscribe(func(_ context.Context, msg Message[int]) error {
+		mu.Lock()
+		got = append(got, msg.Payload)
+		mu.Unlock()
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	err = qc.Start(context.Background())
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	_ = qc.Send(context.Background(), expiredMessage(1))
+	_ = qc.Send(context.Background(), NewMessage(2, nil))
+	_ = qc.Stop(context.Background())
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if len(got) != 1 || got[0] != 2 {
+		t.Fatalf("expected only the live message dispatched, got %v", got)
+	}
+	if len(expired) != 1 || expired[0] != 1 {
+		t.Fatalf("expected the expired message reported, got %v", expired)
+	}
+	if len(letters) != 1 || !errors.Is(letters[0].LastError, ErrExpired) {
+		t.Fatalf("expected one ErrExpired dead letter, got %v", letters)
+	}
+}
+
+func TestQueueChannel_Redelivery(t *testing.T) {
+	t.Parallel()
+
+	policy := RedeliveryPolicy{MaxAttempts: 3, Delay: time.Millisecond, Backoff: cretry.BackoffExponential}
+
+	t.Run("redelivers until the handler succeeds", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			mu     sync.Mutex
+			counts []int
+			failed int
+		)
+
+		qc := NewQueueChannel[int]("q-redelivery-ok",
+			WithRedeliveryPolicy(policy),
+			WithErrorHandler(func(_ context.Context, _ any, _ error) {
+				mu.Lock()
+				failed++
+				mu.Unlock()
+			}),
+		).(*queue[int])
+
+		delivered := make(chan struct{})
+
+		_, err := qc.Subscribe(func(_ context.Context, msg Message[int]) error {
+			mu.Lock()
+			counts = append(counts, msg.Headers.DeliveryCount)
+			mu.Unlock()
+
+			if msg.Headers.DeliveryCount < 3 {
+				return errors.New("transient")
+			}
+
+			close(delivered)
+
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Subscribe: %v", err)
+		}
+
+		_ = qc.Start(context.Background())
+		_ = qc.Send(context.Background(), NewMessage(1, nil))
+
+		select {
+		case <-delivered:
+		case <-time.After(2 * time.Second):
+			t.Fatal("message was not redelivered")
+		}
+
+		_ = qc.Stop(context.Background())
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		if len(counts) != 3 || counts[0] != 1 || counts[2] != 3 {
+			t.Fatalf("expected deliveries 1..3, got %v", counts)
+		}
+		if failed != 0 {
+			t.Fatalf("expected rescheduled failures not reported, got %d", failed)
+		}
+	})
+
+	t.Run("dead-letters once attempts are exhausted", func(t *testing.T) {
+		t.Parallel()
+
+		dlq := NewPipelineChannel[DeadLetter[int]]()
+		letters := make(chan DeadLetter[int], 4)
+
+		_, err := dlq.Subscribe(func(_ context.Context, m Message[DeadLetter[int]]) error {
+			letters <- m.Payload
+
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("dlq Subscribe: %v", err)
+		}
+
+		var reported atomic.Int32
+
+		qc := NewQueueChannel[int]("q-redelivery-exhausted",
+			WithRedeliveryPolicy(policy),
+			WithErrorHandler(func(_ context.Context, _ any, _ error) {
+				reported.Add(1)
+			}),
+			WithDLQChannel(dlq),
+		).(*queue[int])
+
+		_, err = qc.Subscribe(func(_ context.Context, _ Message[int]) error {
+			return errors.New("permanent")
+		})
+		if err != nil {
+			t.Fatalf("Subscribe: %v", err)
+		}
+
+		_ = qc.Start(context.Background())
+		_ = qc.Send(context.Background(), NewMessage(1, nil))
+
+		select {
+		case letter := <-letters:
+			if letter.Original.Headers.DeliveryCount != 3 {
+				t.Fatalf("expected 3 deliveries, got %d", letter.Original.Headers.DeliveryCount)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("message was not dead-lettered")
+		}
+
+		_ = qc.Stop(context.Background())
+
+		if len(letters) != 0 {
+			t.Fatalf("expected a single dead letter, got %d more", len(letters))
+		}
+		if reported.Load() != 1 {
+			t.Fatalf("expected the final failure reported once, got %d", reported.Load())
+		}
+	})
+
+	t.Run("Stop dead-letters pending redeliveries", func(t *testing.T) {
+		t.Parallel()
+
+		dlq := NewPipelineChannel[DeadLetter[int]]()
+		letters := make(chan DeadLetter[int], 1)
+
+		_, err := dlq.Subscribe(func(_ context.Context, m Message[DeadLetter[int]]) error {
+			letters <- m.Payload
+
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("dlq Subscribe: %v", err)
+		}
+
+		qc := NewQueueChannel[int]("q-redelivery-stop",
+			WithRedeliveryPolicy(RedeliveryPolicy{MaxAttempts: 3, Delay: time.Hour}),
+			WithErrorHandler(SilentErrorHandler),
+			WithDLQChannel(dlq),
+		).(*queue[int])
+
+		failed := make(chan struct{})
+
+		_, err = qc.Subscribe(func(_ context.Context, _ Message[int]) error {
+			close(failed)
+
+			return errors.New("transient")
+		})
+		if err != nil {
+			t.Fatalf("Subscribe: %v", err)
+		}
+
+		_ = qc.Start(context.Background())
+		_ = qc.Send(context.Background(), NewMessage(1, nil))
+		<-failed
+
+		// Give the worker a moment to hand the failure to the scheduler.
+		time.Sleep(20 * time.Millisecond)
+
+		err = qc.Stop(context.Background())
+		if err != nil {
+			t.Fatalf("Stop: %v", err)
+		}
+
+		select {
+		case letter := <-letters:
+			if !errors.Is(letter.LastError, ErrRedeliveryFailed) || !errors.Is(letter.LastError, ErrClosed) {
+				t.Fatalf("unexpected LastError %v", letter.LastError)
+			}
+		default:
+			t.Fatal("expected the pending redelivery dead-lettered")
+		}
+	})
+}
+
+func TestQueueChannel_Stats(t *testing.T) {
+	t.Parallel()
+
+	dlq := NewPipelineChannel[DeadLetter[int]]()
+
+	ch := NewQueueChannel[int]("q-stats",
+		WithBufferSize(4),
+		WithDrainTimeout(time.Second),
+		WithErrorHandler(SilentErrorHandler),
+		WithDLQChannel(dlq),
+	).(*queue[int])
+
+	_, err := ch.Subscribe(func(_ context.Context, msg Message[int]) error {
+		if msg.Payload == 1 {
+			return errors.New("boom")
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	for i := range 2 {
+		err = ch.Send(context.Background(), NewMessage(i, nil))
+		if err != nil {
+			t.Fatalf("Send: %v", err)
+		}
+	}
+
+	got := ch.Stats()
+	if got.BufferLength != 2 || got.BufferCapacity != 4 || got.Subscribers != 1 || got.Sent != 2 {
+		t.Fatalf("before Start: %+v, want BufferLength=2 BufferCapacity=4 Subscribers=1 Sent=2", got)
+	}
+
+	err = ch.Start(context.Background())
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	_ = ch.Stop(context.Background())
+
+	got = ch.Stats()
+	if got.BufferLength != 0 || got.Delivered != 2 || got.Failed != 1 || got.DeadLettered != 1 || got.InFlight != 0 {
+		t.Fatalf("after Stop: %+v, want BufferLength=0 Delivered=2 Failed=1 DeadLettered=1 InFlight=0", got)
+	}
+
+	if got.LastErrorTime.IsZero() {
+		t.Fatal("LastErrorTime not set after a failure")
+	}
+}
+
+func TestQueueChannel_Partitioning(t *testing.T) {
+	t.Parallel()
+
+	t.Run("delivers each key in order, one at a time", func(t *testing.T) {
+		t.Parallel()
+
+		ch := NewQueueChannel[int]("q-partitioned",
+			WithBufferSize(256),
+			WithWorkerCount(4),
+			WithDrainTimeout(5*time.Second),
+			WithPartitioning(),
+		).(*queue[int])
+
+		const (
+			keys    = 8
+			perKey  = 25
+			handled = keys * perKey
+		)
+
+		var (
+			mu     sync.Mutex
+			seen   = make(map[string][]int)
+			active = make(map[string]int)
+			racing atomic.Bool
+		)
+
+		_, err := ch.Subscribe(func(_ context.Context, msg Message[int]) error {
+			key := msg.Headers.CorrelationID
+
+			mu.Lock()
+			active[key]++
+			if active[key] > 1 {
+				racing.Store(true)
+			}
+			mu.Unlock()
+
+			time.Sleep(100 * time.Microsecond)
+
+			mu.Lock()
+			active[key]--
+			seen[key] = append(seen[key], msg.Payload)
+			mu.Unlock()
+
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Subscribe: %v", err)
+		}
+
+		err = ch.Start(context.Background())
+		if err != nil {
+			t.Fatalf("Start: %v", err)
+		}
+
+		for i := range perKey {
+			for k := range keys {
+				msg := NewMessage(i, nil)
+				msg.Headers.CorrelationID = string(rune('a' + k))
+
+				err = ch.Send(context.Background(), msg)
+				if err != nil {
+					t.Fatalf("Send: %v", err)
+				}
+			}
+		}
+
+		err = ch.Stop(context.Background())
+		if err != nil {
+			t.Fatalf("Stop: %v", err)
+		}
+
+		if racing.Load() {
+			t.Fatal("two messages of one key were handled concurrently")
+		}
+
+		total := 0
+
+		for key, payloads := range seen {
+			total += len(payloads)
+
+			for i, payload := range payloads {
+				if payload != i {
+					t.Fatalf("key %s delivered out of order: %v", key, payloads)
+				}
+			}
+		}
+
+		if total != handled {
+			t.Fatalf("handled %d messages, want %d", total, handled)
+		}
+	})
+
+	t.Run("keyless messages still reach a subscriber", func(t *testing.T) {
+		t.Parallel()
+
+		ch := NewQueueChannel[int]("q-keyless",
+			WithWorkerCount(3),
+			WithDrainTimeout(time.Second),
+			WithPartitionKey(PartitionByHeader("tenant")),
+		).(*queue[int])
+
+		var handled atomic.Int32
+
+		_, err := ch.Subscribe(func(_ context.Context, _ Message[int]) error {
+			handled.Add(1)
+
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Subscribe: %v", err)
+		}
+
+		err = ch.Start(context.Background())
+		if err != nil {
+			t.Fatalf("Start: %v", err)
+		}
+
+		for i := range 6 {
+			err = ch.Send(context.Background(), NewMessage(i, nil))
+			if err != nil {
+				t.Fatalf("Send: %v", err)
+			}
+		}
+
+		_ = ch.Stop(context.Background())
+
+		if handled.Load() != 6 {
+			t.Fatalf("handled %d messages, want 6", handled.Load())
+		}
+	})
+
+	t.Run("lanes rebalance as subscribers join and cancel", func(t *testing.T) {
+		t.Parallel()
+
+		ch := NewQueueChannel[int]("q-rebalance", WithWorkerCount(4), WithPartitioning()).(*queue[int])
+
+		first, err := ch.Subscribe(func(context.Context, Message[int]) error { return nil })
+		if err != nil {
+			t.Fatalf("Subscribe: %v", err)
+		}
+
+		_, err = ch.Subscribe(func(context.Context, Message[int]) error { return nil })
+		if err != nil {
+			t.Fatalf("Subscribe: %v", err)
+		}
+
+		owners := func() []uint64 {
+			ch.mu.RLock()
+			defer ch.mu.RUnlock()
+
+			result := make([]uint64, len(ch.lanes))
+			for lane := range ch.lanes {
+				result[lane] = ch.order[lane%len(ch.order)]
+			}
+
+			return result
+		}
+
+		got := owners()
+		if got[0] != 1 || got[1] != 2 || got[2] != 1 || got[3] != 2 {
+			t.Fatalf("lane owners = %v, want [1 2 1 2]", got)
+		}
+
+		first()
+
+		got = owners()
+		for lane, owner := range got {
+			if owner != 2 {
+				t.Fatalf("lane %d owned by %d after cancel, want 2", lane, owner)
+			}
+		}
+
+		sub, ok, _ := ch.acquire(3, Headers{})
+		if !ok {
+			t.Fatal("acquire reported no subscriber")
+		}
+
+		ch.release(sub)
+	})
+
+	t.Run("Stats counts the lanes", func(t *testing.T) {
+		t.Parallel()
+
+		ch := NewQueueChannel[int]("q-lane-stats", WithBufferSize(8), WithWorkerCount(2), WithPartitioning()).(*queue[int])
+
+		got := ch.Stats()
+		if got.BufferCapacity != 16 {
+			t.Fatalf("BufferCapacity = %d, want 16 (inbound 8 + 2 lanes of 4)", got.BufferCapacity)
+		}
+	})
+}
+
+func TestQueueChannel_LoadBalancing(t *testing.T) {
+	t.Parallel()
+
+	// counter returns a handler counting its messages on n and
+	// signalling each one on done.
+	counter := func(n *atomic.Int32, done chan<- struct{}) Handler[int] {
+		return func(context.Context, Message[int]) error {
+			n.Add(1)
+			done <- struct{}{}
+
+			return nil
+		}
+	}
+
+	t.Run("weighted subscribers share in proportion", func(t *testing.T) {
+		t.Parallel()
+
+		qc := NewQueueChannel[int]("q-weighted", WithBufferSize(8), WithLoadBalancer(Weighted()))
+
+		var heavy, light atomic.Int32
+
+		done := make(chan struct{}, 6)
+
+		_, err := SubscribeWith(qc, counter(&heavy, done), WithSubscriptionWeight(2))
+		if err != nil {
+			t.Fatalf("SubscribeWith returned %v", err)
+		}
+
+		_, err = SubscribeWith(qc, counter(&light, done))
+		if err != nil {
+			t.Fatalf("SubscribeWith returned %v", err)
+		}
+
+		component := qc.(lifecycle.Component)
+
+		err = component.Start(context.Background())
+		if err != nil {
+			t.Fatalf("Start returned %v", err)
+		}
+
+		t.Cleanup(func() { _ = component.Stop(context.Background()) })
+
+		for i := range 6 {
+			err = qc.Send(context.Background(), NewMessage(i, nil))
+			if err != nil {
+				t.Fatalf("Send returned %v", err)
+			}
+		}
+
+		for range 6 {
+			<-done
+		}
+
+		if heavy.Load() != 4 || light.Load() != 2 {
+			t.Fatalf("heavy/light = %d/%d, want 4/2", heavy.Load(), light.Load())
+		}
+	})
+
+	t.Run("panicking key function falls back to the first subscriber", func(t *testing.T) {
+		t.Parallel()
+
+		hookErrs := make(chan error, 2)
+
+		qc := NewQueueChannel[int]("q-lb-panic", WithBufferSize(8),
+			WithLoadBalancer(StickyByKey(func(Headers) string { panic("key boom") })),
+			WithErrorHandler(func(_ context.Context, _ any, err error) { hookErrs <- err }),
+		)
+
+		var first, second atomic.Int32
+
+		done := make(chan struct{}, 2)
+
+		_, err := qc.Subscribe(counter(&first, done))
+		if err != nil {
+			t.Fatalf("Subscribe returned %v", err)
+		}
+
+		_, err = qc.Subscribe(counter(&second, done))
+		if err != nil {
+			t.Fatalf("Subscribe returned %v", err)
+		}
+
+		component := qc.(lifecycle.Component)
+
+		err = component.Start(context.Background())
+		if err != nil {
+			t.Fatalf("Start returned %v", err)
+		}
+
+		t.Cleanup(func() { _ = component.Stop(context.Background()) })
+
+		for i := range 2 {
+			err = qc.Send(context.Background(), NewMessage(i, nil))
+			if err != nil {
+				t.Fatalf("Send returned %v", err)
+			}
+		}
+
+		for range 2 {
+			<-done
+
+			got := <-hookErrs
+			if !errors.Is(got, ErrLoadBalancerPanic) {
+				t.Fatalf("expected ErrLoadBalancerPanic, got %v", got)
+			}
+		}
+
+		if first.Load() != 2 || second.Load() != 0 {
+			t.Fatalf("first/second = %d/%d, want 2/0", first.Load(), second.Load())
+		}
+	})
+
+	t.Run("saturated subscriber is skipped", func(t *testing.T) {
+		t.Parallel()
+
+		qc := NewQueueChannel[int]("q-capacity", WithBufferSize(8), WithWorkerCount(2))
+
+		release := make(chan struct{})
+		slowStarted := make(chan struct{}, 1)
+
+		var slow, fast atomic.Int32
+
+		_, err := SubscribeWith(qc, func(context.Context, Message[int]) error {
+			slow.Add(1)
+			slowStarted <- struct{}{}
+			<-release
+
+			return nil
+		}, WithSubscriptionCapacity(1))
+		if err != nil {
+			t.Fatalf("SubscribeWith returned %v", err)
+		}
+
+		done := make(chan struct{}, 4)
+
+		_, err = qc.Subscribe(counter(&fast, done))
+		if err != nil {
+			t.Fatalf("Subscribe returned %v", err)
+		}
+
+		component := qc.(lifecycle.Component)
+
+		err = component.Start(context.Background())
+		if err != nil {
+			t.Fatalf("Start returned %v", err)
+		}
+
+		t.Cleanup(func() { _ = component.Stop(context.Background()) })
+
+		err = qc.Send(context.Background(), NewMessage(0, nil))
+		if err != nil {
+			t.Fatalf("Send returned %v", err)
+		}
+
+		<-slowStarted
+
+		for i := range 4 {
+			err = qc.Send(context.Background(), NewMessage(i+1, nil))
+			if err != nil {
+				t.Fatalf("Send returned %v", err)
+			}
+		}
+
+		for range 4 {
+			<-done
+		}
+
+		close(release)
+
+		if slow.Load() != 1 || fast.Load() != 4 {
+			t.Fatalf("slow/fast = %d/%d, want 1/4", slow.Load(), fast.Load())
+		}
+	})
+
+	t.Run("workers wait while every subscriber is saturated", func(t *testing.T) {
+		t.Parallel()
+
+		qc := NewQueueChannel[int]("q-saturated", WithBufferSize(8), WithWorkerCount(3))
+
+		var (
+			inFlight atomic.Int32
+			peak     atomic.Int32
+			handled  = make(chan struct{}, 3)
+		)
+
+		_, err := SubscribeWith(qc, func(context.Context, Message[int]) error {
+			n := inFlight.Add(1)
+			if n > peak.Load() {
+				peak.Store(n)
+			}
+
+			time.Sleep(5 * time.Millisecond)
+			inFlight.Add(-1)
+			handled <- struct{}{}
+
+			return nil
+		}, WithSubscriptionCapacity(1))
+		if err != nil {
+			t.Fatalf("SubscribeWith returned %v", err)
+		}
+
+		component := qc.(lifecycle.Component)
+
+		err = component.Start(context.Background())
+		if err != nil {
+			t.Fatalf("Start returned %v", err)
+		}
+
+		t.Cleanup(func() { _ = component.Stop(context.Background()) })
+
+		for i := range 3 {
+			err = qc.Send(context.Background(), NewMessage(i, nil))
+			if err != nil {
+				t.Fatalf("Send returned %v", err)
+			}
+		}
+
+		for range 3 {
+			<-handled
+		}
+
+		if peak.Load() != 1 {
+			t.Fatalf("peak concurrency = %d, want 1", peak.Load())
+		}
+	})
+
+	t.Run("cancelling the last subscriber releases a waiting worker", func(t *testing.T) {
+		t.Parallel()
+
+		var dropped atomic.Int32
+
+		qc := NewQueueChannel[int]("q-cancel-waiting", WithBufferSize(8), WithWorkerCount(2),
+			WithErrorHandler(func(_ context.Context, _ any, err error) {
+				if errors.Is(err, ErrNoSubscribers) {
+					dropped.Add(1)
+				}
+			})).(*queue[int])
+
+		release := make(chan struct{})
+		started := make(chan struct{}, 1)
+
+		cancel, err := SubscribeWith[int](qc, func(context.Context, Message[int]) error {
+			started <- struct{}{}
+			<-release
+
+			return nil
+		}, WithSubscriptionCapacity(1))
+		if err != nil {
+			t.Fatalf("SubscribeWith returned %v", err)
+		}
+
+		err = qc.Start(context.Background())
+		if err != nil {
+			t.Fatalf("Start returned %v", err)
+		}
+
+		t.Cleanup(func() { _ = qc.Stop(context.Background()) })
+
+		for i := range 2 {
+			err = qc.Send(context.Background(), NewMessage(i, nil))
+			if err != nil {
+				t.Fatalf("Send returned %v", err)
+			}
+		}
+
+		<-started
+		cancel()
+
+		deadline := time.Now().Add(time.Second)
+		for dropped.Load() != 1 {
+			if time.Now().After(deadline) {
+				t.Fatal("waiting worker was not released by cancel")
+			}
+
+			time.Sleep(time.Millisecond)
+		}
+
+		close(release)
+	})
+}
@@ -30,10 +30,14 @@ import (
 // pattern as TopicChannel so Stop converges even when Start runs
 // concurrently with Stop.
 type scheduled[T any] struct {
-	name         string
-	drainTimeout time.Duration
-	errorHandler ErrorHandler
-	dlq          Channel[DeadLetter[T]]
+	name           string
+	drainTimeout   time.Duration
+	clock          Clock
+	errorHandler   ErrorHandler
+	expiredHandler ErrorHandler
+	dlq            Channel[DeadLetter[T]]
+	interceptors   interceptorChain[T]
+	stats          StatsRecorder
 
 	started      atomic.Bool
 	closed       atomic.Bool
@@ -64,11 +68,15 @@ type scheduled[T any] struct {
 // scheduledItem is one entry on the scheduler's min-heap. The
 // publisher's sendCtx is carried alongside the message so the
 // dispatcher can merge it with the worker ctx the same way Topic
-// and Queue do.
+// and Queue do. cause and target are only set by the redeliverer:
+// the handler error that triggered the redelivery and the function
+// that hands the message back to its consumer.
 type scheduledItem[T any] struct {
 	deliverAt time.Time
 	sendCtx   context.Context
 	msg       Message[T]
+	cause     error
+	target    redeliverFn[T]
 }
 
 // scheduledHeap is a container/heap-compatible min-heap of
@@ -109,21 +117,29 @@ func (h *scheduledHeap[T]) Pop() any {
 // NewScheduledChannel constructs a ScheduledChannel[T] with the given
 // name and options. name is used in lifecycle logs and must be
 // non-empty. The returned channel is not running; call lifecycle.Build
-// (or Start directly) to spawn the scheduler goroutine.
+// (or Start directly) to spawn the scheduler goroutine. Deliveries are
+// scheduled on the WithClock clock (SystemClock by default).
 func NewScheduledChannel[T any](name string, opts ...Option) ScheduledChannel[T] {
 	cassert.NotEmpty(name, "name is empty")
 
 	options := NewOptions(opts...)
 
-	return &scheduled[T]{
+	c := &scheduled[T]{
 		name:         name,
 		drainTimeout: options.drainTimeout,
-		errorHandler: options.errorHandler,
-		dlq:          extractDLQ[T](options.dlq),
+		clock:        options.clock,
 		done:         make(chan struct{}),
 		wake:         make(chan struct{}, 1),
 		subs:         map[uint64]Handler[T]{},
 	}
+
+	c.stats.UseClock(options.clock)
+	c.errorHandler = c.stats.dropHook(options.errorHandler)
+	c.expiredHandler = c.stats.dropHook(expiredHook(options))
+	c.dlq = countDeadLetters(extractDLQ[T](options.dlq), &c.stats)
+	c.interceptors = extractInterceptors[T](options.interceptors)
+
+	return c
 }
 
 // Name returns the channel's identity used in lifecycle logs.
@@ -231,12 +247,12 @@ func (c *scheduled[T]) Done() <-chan struct{} {
 }
 
 // Send delivers msg as soon as possible. Equivalent to SendAt with
-// deliverAt = time.Now(). Returns ErrSend(ErrClosed) after Stop and
+// deliverAt = now. Returns ErrSend(ErrClosed) after Stop and
 // ErrSend(ErrContextNil) when ctx is nil.
 func (c *scheduled[T]) Send(ctx context.Context, msg Message[T]) error {
 	cassert.NotNil(c, "ScheduledChannel is nil")
 
-	return c.enqueue(ctx, time.Now(), msg)
+	return c.enqueue(ctx, c.clock.Now(), msg)
 }
 
 // SendAt schedules msg for delivery at deliverAt (wall clock). A
@@ -255,7 +271,7 @@ func (c *scheduled[T]) SendAt(ctx context.Context, deliverAt time.Time, msg Mess
 func (c *scheduled[T]) SendAfter(ctx context.Context, delay time.Duration, msg Message[T]) error {
 	cassert.NotNil(c, "ScheduledChannel is nil")
 
-	return c.enqueue(ctx, time.Now().Add(delay), msg)
+	return c.enqueue(ctx, c.clock.Now().Add(delay), msg)
 }
 
 // Subscribe registers handler for dispatch. Subscribe is independent
@@ -270,6 +286,8 @@ func (c *scheduled[T]) Subscribe(handler Handler[T]) (Cancel, error) {
 		return nil, ErrSubscribe(ErrHandlerNil)
 	}
 
+	handler = c.interceptors.wrap(handler)
+
 	c.subsMu.Lock()
 	if c.closed.Load() {
 		c.subsMu.Unlock()
@@ -295,10 +313,37 @@ func (c *scheduled[T]) Subscribe(handler Handler[T]) (Cancel, error) {
 	return cancel, nil
 }
 
-// enqueue is the shared body of Send/SendAt/SendAfter. It validates
-// ctx + closed state, pushes the item onto the heap and wakes the
-// scheduler so it can re-arm its timer if the new head changed.
+// Stats returns a snapshot of the channel's runtime statistics.
+// BufferLength is the number of scheduled messages not yet due;
+// BufferCapacity is zero because the schedule is unbounded.
+func (c *scheduled[T]) Stats() Stats {
+	cassert.NotNil(c, "ScheduledChannel is nil")
+
+	stats := c.stats.Snapshot()
+
+	c.queueMu.Lock()
+	stats.BufferLength = c.queue.Len()
+	c.queueMu.Unlock()
+
+	c.subsMu.RLock()
+	stats.Subscribers = len(c.subs)
+	c.subsMu.RUnlock()
+
+	return stats
+}
+
+// enqueue is the shared body of Send/SendAt/SendAfter. It runs the
+// interceptor chain around push.
 func (c *scheduled[T]) enqueue(ctx context.Context, deliverAt time.Time, msg Message[T]) error {
+	return c.interceptors.send(ctx, msg, func(ctx context.Context, msg Message[T]) error {
+		return c.push(ctx, deliverAt, msg)
+	})
+}
+
+// push validates ctx + closed state, pushes the item onto the heap and
+// wakes the scheduler so it can re-arm its timer if the new head
+// changed.
+func (c *scheduled[T]) push(ctx context.Context, deliverAt time.Time, msg Message[T]) error {
 	if ctx == nil {
 		return ErrSend(ErrContextNil)
 	}
@@ -311,6 +356,7 @@ func (c *scheduled[T]) enqueue(ctx context.Context, deliverAt time.Time, msg Mes
 	heap.Push(&c.queue, scheduledItem[T]{deliverAt: deliverAt, sendCtx: ctx, msg: msg})
 	c.queueMu.Unlock()
 
+	c.stats.RecordSent()
 	c.signalWake()
 
 	return nil
@@ -344,7 +390,7 @@ func (c *scheduled[T]) run(workerCtx context.Context) {
 			return
 		}
 
-		now := time.Now()
+		now := c.clock.Now()
 
 		c.queueMu.Lock()
 		ready, wait, hasItem := c.peekReady(now)
@@ -366,9 +412,9 @@ func (c *scheduled[T]) run(workerCtx context.Context) {
 			continue
 		}
 
-		timer := time.NewTimer(wait)
+		timer := c.clock.NewTimer(wait)
 		select {
-		case <-timer.C:
+		case <-timer.C():
 		case <-c.wake:
 			timer.Stop()
 		case <-workerCtx.Done():
@@ -403,20 +449,31 @@ func (c *scheduled[T]) peekReady(now time.Time) (*scheduledItem[T], time.Duratio
 // publisher's sendCtx so trace span / correlation id / slogctx attrs
 // propagate while Done/Deadline follow the worker. Errors and
 // recovered panics are routed through the configured ErrorHandler and
-// (when configured) the DLQ.
+// (when configured) the DLQ. An item whose Headers.ExpirationTime has
+// passed by the time it comes due is not dispatched (see
+// WithExpiredHandler).
 func (c *scheduled[T]) dispatch(workerCtx context.Context, item scheduledItem[T]) {
 	c.subsMu.RLock()
 	snapshot := slices.Collect(maps.Values(c.subs))
 	c.subsMu.RUnlock()
 
 	if len(snapshot) == 0 {
+		c.stats.RecordDrop()
+
 		return
 	}
 
 	handlerCtx := mergeContexts(workerCtx, item.sendCtx)
 
+	if dropExpired(handlerCtx, item.msg, c.clock.Now(), c.expiredHandler, c.dlq) {
+		return
+	}
+
 	for _, handler := range snapshot {
+		end := c.stats.Begin()
 		err := invokeHandler(handlerCtx, item.msg, handler)
+		end(err)
+
 		if err == nil {
 			continue
 		}
@@ -425,6 +482,6 @@ func (c *scheduled[T]) dispatch(workerCtx context.Context, item scheduledItem[T]
 			c.errorHandler(handlerCtx, item.msg, err)
 		}
 
-		publishDeadLetter(handlerCtx, c.dlq, item.msg, err)
+		PublishDeadLetter(handlerCtx, c.dlq, item.msg, err, c.clock.Now())
 	}
 }
@@ -632,3 +632,81 @@ func TestScheduledChannel_ConcurrentSendAt(t *testing.T) {
 
 	_ = sc.Stop(context.Background())
 }
+
+func TestScheduledChannel_Expiration(t *testing.T) {
+	t.Parallel()
+
+	expired := make(chan any, 1)
+	delivered := make(chan int, 1)
+
+	sc := NewScheduledChannel[int]("sc-expiration",
+		WithExpiredHandler(func(_ context.Context, msg any, err error) {
+			if !errors.Is(err, ErrExpired) {
+				t.Errorf("expected ErrExpired, got %v", err)
+			}
+
+			expired <- msg
+		}),
+	).(*scheduled[int])
+
+	_, err := sc.Subscribe(func(_ context.Context, msg Message[int]) error {
+		delivered <- msg.Payload
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Subscribe returned %v", err)
+	}
+
+	err = sc.Start(context.Background())
+	if err != nil {
+		t.Fatalf("Start returned %v", err)
+	}
+
+	t.Cleanup(func() { _ = sc.Stop(context.Background()) })
+
+	// Live when scheduled, expired by the time it comes due.
+	msg := NewMessage(1, nil)
+	msg.Headers.ExpirationTime = time.Now().Add(10 * time.Millisecond)
+
+	err = sc.SendAfter(context.Background(), 50*time.Millisecond, msg)
+	if err != nil {
+		t.Fatalf("SendAfter returned %v", err)
+	}
+
+	select {
+	case got := <-expired:
+		if got.(Message[int]).Payload != 1 {
+			t.Fatalf("unexpected expired message %v", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the expired report")
+	}
+
+	select {
+	case got := <-delivered:
+		t.Fatalf("expired message dispatched: %d", got)
+	default:
+	}
+}
+
+func TestScheduledChannel_Stats(t *testing.T) {
+	t.Parallel()
+
+	ch := NewScheduledChannel[int]("s-stats", WithDrainTimeout(time.Second)).(*scheduled[int])
+
+	_, err := ch.Subscribe(func(_ context.Context, _ Message[int]) error { return nil })
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	err = ch.SendAfter(context.Background(), time.Hour, NewMessage(1, nil))
+	if err != nil {
+		t.Fatalf("SendAfter: %v", err)
+	}
+
+	got := ch.Stats()
+	if got.BufferLength != 1 || got.BufferCapacity != 0 || got.Subscribers != 1 || got.Sent != 1 || got.Delivered != 0 {
+		t.Fatalf("Stats = %+v, want BufferLength=1 BufferCapacity=0 Subscribers=1 Sent=1 Delivered=0", got)
+	}
+}
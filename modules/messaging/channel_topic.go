@@ -35,6 +35,10 @@ import (
 // that inbox. Per-inbox errors are joined via errors.Join in the
 // Send return value.
 //
+// Each worker takes messages from its inbox in arrival order by
+// default; WithDispatchOrder(DispatchPriority) makes it take the
+// highest-priority pending message next (see DispatchOrder).
+//
 // # Lifecycle
 //
 // Subscribe before Start registers the subscriber but defers spawning
@@ -69,8 +73,15 @@ type topic[T any] struct {
 	bufferSize     int
 	drainTimeout   time.Duration
 	errorHandler   ErrorHandler
+	expiredHandler ErrorHandler
 	overflowPolicy OverflowPolicy
+	dispatchOrder  DispatchOrder
+	priorityAging  time.Duration
+	clock          Clock
 	dlq            Channel[DeadLetter[T]]
+	interceptors   interceptorChain[T]
+	redelivery     *redeliverer[T]
+	stats          StatsRecorder
 
 	started      atomic.Bool
 	closed       atomic.Bool
@@ -96,10 +107,23 @@ type topic[T any] struct {
 // subscriber; only its worker drains it.
 type subscriber[T any] struct {
 	handler Handler[T]
-	inbox   chan envelope[T]
+	inbox   mailbox[T]
 	done    chan struct{}
 }
 
+// requeue hands a message back to the subscriber's inbox for
+// redelivery. It never blocks: a full inbox rejects the message, and a
+// cancelled subscriber reports ErrClosed.
+func (s *subscriber[T]) requeue(ctx context.Context, msg Message[T]) error {
+	select {
+	case <-s.done:
+		return ErrSend(ErrClosed)
+	default:
+	}
+
+	return s.inbox.put(ctx, msg, OverflowReject, nil)
+}
+
 // NewTopicChannel constructs a TopicChannel[T] with the given name and
 // options. name is used in lifecycle logs and must be non-empty.
 //
@@ -112,16 +136,26 @@ func NewTopicChannel[T any](name string, opts ...Option) Channel[T] {
 
 	options := NewOptions(opts...)
 
-	return &topic[T]{
+	c := &topic[T]{
 		name:           name,
 		bufferSize:     options.bufferSize,
 		drainTimeout:   options.drainTimeout,
-		errorHandler:   options.errorHandler,
 		overflowPolicy: options.overflowPolicy,
-		dlq:            extractDLQ[T](options.dlq),
+		dispatchOrder:  options.dispatchOrder,
+		priorityAging:  options.priorityAging,
+		clock:          options.clock,
 		done:           make(chan struct{}),
 		subs:           map[uint64]*subscriber[T]{},
 	}
+
+	c.stats.UseClock(options.clock)
+	c.errorHandler = c.stats.dropHook(options.errorHandler)
+	c.expiredHandler = c.stats.dropHook(expiredHook(options))
+	c.dlq = countDeadLetters(extractDLQ[T](options.dlq), &c.stats)
+	c.interceptors = extractInterceptors[T](options.interceptors)
+	c.redelivery = newRedeliverer(options.redelivery, c.errorHandler, c.dlq, options.clock)
+
+	return c
 }
 
 // Name returns the channel's identity used in lifecycle logs.
@@ -169,6 +203,10 @@ func (c *topic[T]) Start(ctx context.Context) error {
 			c.spawnSubWorker(workerCtx, sub)
 		}
 
+		if c.redelivery != nil {
+			c.workerWG.Go(c.redelivery.run)
+		}
+
 		go c.awaitDrain()
 
 		// If Stop ran before this Start completed, release the sentinel
@@ -192,7 +230,8 @@ func (c *topic[T]) awaitDrain() {
 // and waits for all workers to drain pending messages, bounded by the
 // configured drain timeout or by ctx (whichever is tighter). Returns
 // lifecycle.ErrShutdown wrapping lifecycle.ErrShutdownTimeout when
-// the drain does not complete in time. Stop is idempotent per the
+// the drain does not complete in time. Messages still waiting for
+// redelivery are dead-lettered. Stop is idempotent per the
 // lifecycle.Component contract.
 func (c *topic[T]) Stop(ctx context.Context) error {
 	cassert.NotNil(c, "TopicChannel is nil")
@@ -200,11 +239,15 @@ func (c *topic[T]) Stop(ctx context.Context) error {
 	c.stopOnce.Do(func() {
 		c.closed.Store(true)
 
+		if c.redelivery != nil {
+			c.redelivery.close()
+		}
+
 		c.mu.Lock()
 		cancelFn := c.workerCancel
 		startedNow := c.started.Load()
 		for _, sub := range c.subs {
-			close(sub.inbox)
+			sub.inbox.close()
 		}
 		c.mu.Unlock()
 
@@ -258,6 +301,11 @@ func (c *topic[T]) Done() <-chan struct{} {
 func (c *topic[T]) Send(ctx context.Context, msg Message[T]) error {
 	cassert.NotNil(c, "TopicChannel is nil")
 
+	return c.interceptors.send(ctx, msg, c.send)
+}
+
+// send is Send without the interceptor chain.
+func (c *topic[T]) send(ctx context.Context, msg Message[T]) error {
 	if ctx == nil {
 		return ErrSend(ErrContextNil)
 	}
@@ -270,7 +318,11 @@ func (c *topic[T]) Send(ctx context.Context, msg Message[T]) error {
 	snapshot := slices.Collect(maps.Values(c.subs))
 	c.mu.RUnlock()
 
+	c.stats.RecordSent()
+
 	if len(snapshot) == 0 {
+		c.stats.RecordDrop()
+
 		return nil
 	}
 
@@ -282,7 +334,7 @@ func (c *topic[T]) Send(ctx context.Context, msg Message[T]) error {
 		default:
 		}
 
-		err := sendWithPolicy(ctx, sub.inbox, msg, c.overflowPolicy, c.errorHandler)
+		err := sub.inbox.put(ctx, msg, c.overflowPolicy, c.errorHandler)
 		if err != nil {
 			errs = append(errs, err)
 		}
@@ -291,6 +343,30 @@ func (c *topic[T]) Send(ctx context.Context, msg Message[T]) error {
 	return errors.Join(errs...)
 }
 
+// Stats returns a snapshot of the channel's runtime statistics.
+// BufferLength and BufferCapacity are summed over the subscribers'
+// inboxes. Sent counts Send calls past the closed check, whether or
+// not every inbox accepted the message; a Send with no subscribers is
+// also counted as dropped.
+func (c *topic[T]) Stats() Stats {
+	cassert.NotNil(c, "TopicChannel is nil")
+
+	stats := c.stats.Snapshot()
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	stats.Subscribers = len(c.subs)
+
+	for _, sub := range c.subs {
+		length, capacity := sub.inbox.size()
+		stats.BufferLength += length
+		stats.BufferCapacity += capacity
+	}
+
+	return stats
+}
+
 // Subscribe registers handler with its own bounded inbox and worker
 // goroutine. Subscribe order is NOT a contract: each subscriber owns
 // its own inbox and worker, so the fan-out loop in Send enqueues to
@@ -309,9 +385,11 @@ func (c *topic[T]) Subscribe(handler Handler[T]) (Cancel, error) {
 		return nil, ErrSubscribe(ErrHandlerNil)
 	}
 
+	handler = c.interceptors.wrap(handler)
+
 	sub := &subscriber[T]{
 		handler: handler,
-		inbox:   make(chan envelope[T], c.bufferSize),
+		inbox:   newMailbox[T](c.bufferSize, c.dispatchOrder, c.priorityAging, c.clock),
 		done:    make(chan struct{}),
 	}
 
@@ -358,32 +436,45 @@ func (c *topic[T]) Subscribe(handler Handler[T]) (Cancel, error) {
 // drains sub.inbox, invokes the handler under panic recovery, and
 // exits when (a) sub.inbox is closed by Stop or (b) sub.done is
 // closed by Cancel. Errors and recovered panics are routed through
-// the configured ErrorHandler. workerCtx is captured as a parameter
-// (not read from c.workerCtx) so spawnSubWorker callers can pass it
-// under the same critical section that observed started=true,
-// avoiding a race with Start's write.
+// the configured ErrorHandler; messages dequeued past their
+// Headers.ExpirationTime are skipped (see WithExpiredHandler). Under
+// WithRedeliveryPolicy a failed message is rescheduled to this
+// subscriber only, until its attempts are exhausted. workerCtx is
+// captured as a parameter (not read from c.workerCtx) so
+// spawnSubWorker callers can pass it under the same critical section
+// that observed started=true, avoiding a race with Start's write.
 func (c *topic[T]) spawnSubWorker(workerCtx context.Context, sub *subscriber[T]) {
 	c.workerWG.Go(func() {
 		for {
-			select {
-			case env, ok := <-sub.inbox:
-				if !ok {
-					return
-				}
-
-				handlerCtx := mergeContexts(workerCtx, env.sendCtx)
-
-				err := invokeHandler(handlerCtx, env.msg, sub.handler)
-				if err != nil {
-					if c.errorHandler != nil {
-						c.errorHandler(handlerCtx, env.msg, err)
-					}
-
-					publishDeadLetter(handlerCtx, c.dlq, env.msg, err)
-				}
-			case <-sub.done:
+			env, ok := sub.inbox.take(sub.done)
+			if !ok {
 				return
 			}
+
+			handlerCtx := mergeContexts(workerCtx, env.sendCtx)
+
+			if dropExpired(handlerCtx, env.msg, c.clock.Now(), c.expiredHandler, c.dlq) {
+				continue
+			}
+
+			if c.redelivery != nil {
+				env.msg.Headers.DeliveryCount++
+			}
+
+			end := c.stats.Begin()
+			err := invokeHandler(handlerCtx, env.msg, sub.handler)
+			end(err)
+
+			if err == nil {
+				continue
+			}
+
+			if c.redelivery != nil && c.redelivery.schedule(env.sendCtx, env.msg, err, sub.requeue) {
+				continue
+			}
+
+			c.errorHandler(handlerCtx, env.msg, err)
+			PublishDeadLetter(handlerCtx, c.dlq, env.msg, err, c.clock.Now())
 		}
 	})
 }
@@ -10,6 +10,7 @@ import (
 
 	"github.com/guidomantilla/yarumo/core/common/lifecycle"
 	lctests "github.com/guidomantilla/yarumo/core/common/lifecycle/tests"
+	cretry "github.com/guidomantilla/yarumo/core/common/resilience/retry"
 )
 
 func TestNewTopicChannel(t *testing.T) {
@@ -1300,3 +1301,236 @@ func TestTopicChannel_Stop_DrainTimeoutDefaultWhenFieldZero(t *testing.T) {
 		t.Fatalf("Stop returned in %v — guard did not apply default timeout", elapsed)
 	}
 }
+
+func TestTopicChannel_PriorityDispatch(t *testing.T) {
+	t.Parallel()
+
+	tc := NewTopicChannel[int]("t-priority",
+		WithBufferSize(8),
+		WithDispatchOrder(DispatchPriority),
+		WithPriorityAging(time.Hour),
+	).(*topic[int])
+
+	var (
+		mu  sync.Mutex
+		got = map[string][]int{}
+	)
+
+	signal := make(chan struct{}, 16)
+
+	for _, name := range []string{"a", "b"} {
+		_, err := tc.Subscribe(func(_ context.Context, msg Message[int]) error {
+			mu.Lock()
+			got[name] = append(got[name], msg.Payload)
+			mu.Unlock()
+
+			signal <- struct{}{}
+
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Subscribe returned %v", err)
+		}
+	}
+
+	// Subscribers registered before Start buffer every message in
+	// their own priority inbox until the workers spawn.
+	for i, p := range []uint8{2, 7, 2, 9} {
+		msg := NewMessage(i, nil)
+		msg.Headers.Priority = p
+
+		err := tc.Send(context.Background(), msg)
+		if err != nil {
+			t.Fatalf("Send returned %v", err)
+		}
+	}
+
+	err := tc.Start(context.Background())
+	if err != nil {
+		t.Fatalf("Start returned %v", err)
+	}
+
+	t.Cleanup(func() { _ = tc.Stop(context.Background()) })
+
+	for range 8 {
+		<-signal
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	want := []int{3, 1, 0, 2}
+	for name, order := range got {
+		for i := range want {
+			if order[i] != want[i] {
+				t.Fatalf("subscriber %s dispatch order: got %v want %v", name, order, want)
+			}
+		}
+	}
+}
+
+func TestTopicChannel_Expiration(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu      sync.Mutex
+		hooked  []error
+		got     []int
+		pending sync.WaitGroup
+	)
+
+	// No WithExpiredHandler: expirations fall back to the ErrorHandler.
+	tc := NewTopicChannel[int]("t-expiration",
+		WithBufferSize(8),
+		WithErrorHandler(func(_ context.Context, _ any, err error) {
+			mu.Lock()
+			hooked = append(hooked, err)
+			mu.Unlock()
+		}),
+	)
+
+	for range 2 {
+		_, err := tc.Subscribe(func(_ context.Context, msg Message[int]) error {
+			mu.Lock()
+			got = append(got, msg.Payload)
+			mu.Unlock()
+
+			pending.Done()
+
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Subscribe: %v", err)
+		}
+	}
+
+	component := tc.(lifecycle.Component)
+
+	err := component.Start(context.Background())
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	t.Cleanup(func() { _ = component.Stop(context.Background()) })
+
+	pending.Add(2)
+
+	_ = tc.Send(context.Background(), expiredMessage(1))
+	_ = tc.Send(context.Background(), NewMessage(2, nil))
+
+	pending.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if len(got) != 2 || got[0] != 2 || got[1] != 2 {
+		t.Fatalf("expected only the live message fanned out, got %v", got)
+	}
+	if len(hooked) != 2 || !errors.Is(hooked[0], ErrExpired) || !errors.Is(hooked[1], ErrExpired) {
+		t.Fatalf("expected one ErrExpired report per subscriber, got %v", hooked)
+	}
+}
+
+func TestTopicChannel_Redelivery(t *testing.T) {
+	t.Parallel()
+
+	tc := NewTopicChannel[int]("t-redelivery",
+		WithRedeliveryPolicy(RedeliveryPolicy{MaxAttempts: 2, Delay: time.Millisecond, Backoff: cretry.BackoffFixed}),
+		WithErrorHandler(SilentErrorHandler),
+	).(*topic[int])
+
+	var healthy, flaky atomic.Int32
+
+	recovered := make(chan struct{})
+
+	_, err := tc.Subscribe(func(_ context.Context, _ Message[int]) error {
+		healthy.Add(1)
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Subscribe healthy: %v", err)
+	}
+
+	_, err = tc.Subscribe(func(_ context.Context, msg Message[int]) error {
+		flaky.Add(1)
+
+		if msg.Headers.DeliveryCount == 1 {
+			return errors.New("transient")
+		}
+
+		close(recovered)
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Subscribe flaky: %v", err)
+	}
+
+	_ = tc.Start(context.Background())
+	_ = tc.Send(context.Background(), NewMessage(1, nil))
+
+	select {
+	case <-recovered:
+	case <-time.After(2 * time.Second):
+		t.Fatal("failing subscriber was not redelivered to")
+	}
+
+	_ = tc.Stop(context.Background())
+
+	if healthy.Load() != 1 {
+		t.Fatalf("expected the healthy subscriber to see the message once, got %d", healthy.Load())
+	}
+	if flaky.Load() != 2 {
+		t.Fatalf("expected the failing subscriber to see it twice, got %d", flaky.Load())
+	}
+}
+
+func TestTopicChannel_Stats(t *testing.T) {
+	t.Parallel()
+
+	ch := NewTopicChannel[int]("t-stats",
+		WithBufferSize(4),
+		WithDrainTimeout(time.Second),
+		WithErrorHandler(SilentErrorHandler),
+	).(*topic[int])
+
+	err := ch.Send(context.Background(), NewMessage(0, nil))
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	got := ch.Stats()
+	if got.Sent != 1 || got.Dropped != 1 {
+		t.Fatalf("without subscribers: %+v, want Sent=1 Dropped=1", got)
+	}
+
+	for range 2 {
+		_, err = ch.Subscribe(func(_ context.Context, _ Message[int]) error { return nil })
+		if err != nil {
+			t.Fatalf("Subscribe: %v", err)
+		}
+	}
+
+	err = ch.Send(context.Background(), NewMessage(1, nil))
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	got = ch.Stats()
+	if got.Subscribers != 2 || got.BufferLength != 2 || got.BufferCapacity != 8 {
+		t.Fatalf("before Start: %+v, want Subscribers=2 BufferLength=2 BufferCapacity=8", got)
+	}
+
+	err = ch.Start(context.Background())
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	_ = ch.Stop(context.Background())
+
+	got = ch.Stats()
+	if got.Delivered != 2 || got.Failed != 0 || got.BufferLength != 0 {
+		t.Fatalf("after Stop: %+v, want Delivered=2 Failed=0 BufferLength=0", got)
+	}
+}
@@ -0,0 +1,64 @@
+package messaging
+
+import (
+	"time"
+)
+
+// systemClock is the Clock returned by SystemClock: a thin adapter over
+// the time package.
+type systemClock struct{}
+
+// Now returns time.Now().
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// NewTimer wraps time.NewTimer.
+func (systemClock) NewTimer(d time.Duration) Timer {
+	return &systemTimer{timer: time.NewTimer(d)}
+}
+
+// NewTicker wraps time.NewTicker.
+func (systemClock) NewTicker(d time.Duration) Ticker {
+	return &systemTicker{ticker: time.NewTicker(d)}
+}
+
+// AfterFunc wraps time.AfterFunc.
+func (systemClock) AfterFunc(d time.Duration, f func()) Timer {
+	return &systemTimer{timer: time.AfterFunc(d, f)}
+}
+
+// systemTimer adapts *time.Timer to Timer.
+type systemTimer struct {
+	timer *time.Timer
+}
+
+// C returns the timer's channel (nil for AfterFunc timers).
+func (t *systemTimer) C() <-chan time.Time {
+	return t.timer.C
+}
+
+// Stop stops the timer.
+func (t *systemTimer) Stop() bool {
+	return t.timer.Stop()
+}
+
+// Reset re-arms the timer.
+func (t *systemTimer) Reset(d time.Duration) bool {
+	return t.timer.Reset(d)
+}
+
+// systemTicker adapts *time.Ticker to Ticker.
+type systemTicker struct {
+	ticker *time.Ticker
+}
+
+// C returns the ticker's channel.
+func (t *systemTicker) C() <-chan time.Time {
+	return t.ticker.C
+}
+
+// Stop stops the ticker.
+func (t *systemTicker) Stop() {
+	t.ticker.Stop()
+}
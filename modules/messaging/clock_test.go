@@ -0,0 +1,59 @@
+package messaging
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSystemClock(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Now reads the wall clock", func(t *testing.T) {
+		t.Parallel()
+
+		before := time.Now()
+		now := SystemClock().Now()
+
+		if now.Before(before) || now.After(time.Now()) {
+			t.Fatalf("expected a wall-clock reading, got %v", now)
+		}
+	})
+
+	t.Run("timer fires and can be reset", func(t *testing.T) {
+		t.Parallel()
+
+		timer := SystemClock().NewTimer(time.Millisecond)
+		<-timer.C()
+
+		if timer.Reset(time.Hour) {
+			t.Fatal("expected Reset to report a fired timer")
+		}
+
+		if !timer.Stop() {
+			t.Fatal("expected Stop to report a pending timer")
+		}
+	})
+
+	t.Run("ticker ticks until stopped", func(t *testing.T) {
+		t.Parallel()
+
+		ticker := SystemClock().NewTicker(time.Millisecond)
+		defer ticker.Stop()
+
+		<-ticker.C()
+		<-ticker.C()
+	})
+
+	t.Run("AfterFunc runs f", func(t *testing.T) {
+		t.Parallel()
+
+		fired := make(chan struct{})
+
+		timer := SystemClock().AfterFunc(time.Millisecond, func() { close(fired) })
+		<-fired
+
+		if timer.C() != nil {
+			t.Fatal("expected a nil channel for an AfterFunc timer")
+		}
+	})
+}
@@ -0,0 +1,67 @@
+package codec
+
+import (
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+// codec is the Codec implementation: a thin typed adapter over a
+// Format.
+type codec[T any] struct {
+	format Format
+}
+
+// NewCodec returns a Codec[T] that serializes envelopes with format.
+// format is mandatory.
+func NewCodec[T any](format Format) Codec[T] {
+	cassert.NotNil(format, "format is nil")
+
+	return &codec[T]{format: format}
+}
+
+// NewJSONCodec returns a Codec[T] over NewJSONFormat.
+func NewJSONCodec[T any]() Codec[T] {
+	return NewCodec[T](NewJSONFormat())
+}
+
+// NewCBORCodec returns a Codec[T] over NewCBORFormat.
+func NewCBORCodec[T any]() Codec[T] {
+	return NewCodec[T](NewCBORFormat())
+}
+
+// NewProtoStructCodec returns a Codec[T] over NewProtoStructFormat.
+func NewProtoStructCodec[T any]() Codec[T] {
+	return NewCodec[T](NewProtoStructFormat())
+}
+
+// ContentType returns the content type of the underlying Format.
+func (c *codec[T]) ContentType() string {
+	cassert.NotNil(c, "codec is nil")
+
+	return c.format.ContentType()
+}
+
+// Encode serializes msg. See Codec.Encode.
+func (c *codec[T]) Encode(msg messaging.Message[T]) ([]byte, error) {
+	cassert.NotNil(c, "codec is nil")
+
+	if msg.Headers.ContentType == "" {
+		msg.Headers.ContentType = c.format.ContentType()
+	}
+
+	return c.format.Marshal(msg)
+}
+
+// Decode deserializes data into a Message[T]. See Codec.Decode.
+func (c *codec[T]) Decode(data []byte) (messaging.Message[T], error) {
+	cassert.NotNil(c, "codec is nil")
+
+	var msg messaging.Message[T]
+
+	err := c.format.Unmarshal(data, &msg)
+	if err != nil {
+		return messaging.Message[T]{}, err
+	}
+
+	return msg, nil
+}
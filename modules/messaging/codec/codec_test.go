@@ -0,0 +1,203 @@
+package codec
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+type order struct {
+	ID     string
+	Amount float64
+	Lines  []int
+	Raw    []byte
+	Meta   map[string]string
+}
+
+func newTestEnvelope() messaging.Message[order] {
+	return messaging.Message[order]{
+		Payload: order{
+			ID:     "o-1",
+			Amount: 99.5,
+			Lines:  []int{1, -2, 300000},
+			Raw:    []byte{0x00, 0xff},
+			Meta:   map[string]string{"k": "v"},
+		},
+		Headers: messaging.Headers{
+			MessageID:      "m-1",
+			CorrelationID:  "c-1",
+			CausationID:    "p-1",
+			ReplyTo:        "replies",
+			Type:           "order.created",
+			Priority:       7,
+			ExpirationTime: time.Date(2030, 1, 2, 3, 4, 5, 6, time.UTC),
+			SequenceNumber: 2,
+			SequenceSize:   5,
+			Timestamp:      time.Date(2026, 5, 6, 7, 8, 9, 10, time.UTC),
+			Source:         "billing",
+			Custom: map[string]any{
+				"tenant": "acme",
+				"retry":  float64(3),
+				"nested": map[string]any{"ok": true, "none": nil},
+				"list":   []any{"a", float64(1.5)},
+			},
+		},
+	}
+}
+
+func allCodecs() map[string]Codec[order] {
+	return map[string]Codec[order]{
+		ContentTypeJSON:        NewJSONCodec[order](),
+		ContentTypeCBOR:        NewCBORCodec[order](),
+		ContentTypeProtoStruct: NewProtoStructCodec[order](),
+	}
+}
+
+func TestNewCodec(t *testing.T) {
+	t.Parallel()
+
+	t.Run("reports the format content type", func(t *testing.T) {
+		t.Parallel()
+
+		for contentType, c := range allCodecs() {
+			if c.ContentType() != contentType {
+				t.Fatalf("content type: got %q want %q", c.ContentType(), contentType)
+			}
+		}
+	})
+
+	t.Run("wraps a custom format", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewCodec[int](NewJSONFormat())
+		if c == nil {
+			t.Fatal("expected non-nil codec")
+		}
+	})
+}
+
+func TestCodec_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for contentType, c := range allCodecs() {
+		t.Run(contentType, func(t *testing.T) {
+			t.Parallel()
+
+			msg := newTestEnvelope()
+
+			data, err := c.Encode(msg)
+			if err != nil {
+				t.Fatalf("encode: %v", err)
+			}
+
+			got, err := c.Decode(data)
+			if err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+
+			want := msg
+			want.Headers.ContentType = contentType
+
+			if !got.Headers.Timestamp.Equal(want.Headers.Timestamp) || !got.Headers.ExpirationTime.Equal(want.Headers.ExpirationTime) {
+				t.Fatalf("timestamps: got %v / %v", got.Headers.Timestamp, got.Headers.ExpirationTime)
+			}
+
+			got.Headers.Timestamp = want.Headers.Timestamp
+			got.Headers.ExpirationTime = want.Headers.ExpirationTime
+
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("round trip mismatch:\n got %#v\nwant %#v", got, want)
+			}
+		})
+	}
+}
+
+func TestCodec_Encode(t *testing.T) {
+	t.Parallel()
+
+	t.Run("preserves an explicit content type", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewJSONCodec[order]()
+
+		msg := newTestEnvelope()
+		msg.Headers.ContentType = "application/vnd.acme+json"
+
+		data, err := c.Encode(msg)
+		if err != nil {
+			t.Fatalf("encode: %v", err)
+		}
+
+		got, err := c.Decode(data)
+		if err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+
+		if got.Headers.ContentType != "application/vnd.acme+json" {
+			t.Fatalf("content type: got %q", got.Headers.ContentType)
+		}
+	})
+
+	t.Run("does not mutate the caller message", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewCBORCodec[order]()
+		msg := newTestEnvelope()
+
+		_, err := c.Encode(msg)
+		if err != nil {
+			t.Fatalf("encode: %v", err)
+		}
+
+		if msg.Headers.ContentType != "" {
+			t.Fatalf("caller message mutated: %q", msg.Headers.ContentType)
+		}
+	})
+
+	t.Run("reports unencodable payloads", func(t *testing.T) {
+		t.Parallel()
+
+		for _, c := range []Codec[chan int]{NewJSONCodec[chan int](), NewCBORCodec[chan int](), NewProtoStructCodec[chan int]()} {
+			_, err := c.Encode(messaging.Message[chan int]{Payload: make(chan int)})
+			if !errors.Is(err, ErrEncode) {
+				t.Fatalf("%s: expected ErrEncode, got %v", c.ContentType(), err)
+			}
+		}
+	})
+}
+
+func TestCodec_Decode(t *testing.T) {
+	t.Parallel()
+
+	t.Run("reports malformed input", func(t *testing.T) {
+		t.Parallel()
+
+		for contentType, c := range allCodecs() {
+			_, err := c.Decode([]byte{0xff, 0xff, 0xff})
+			if !errors.Is(err, ErrDecode) {
+				t.Fatalf("%s: expected ErrDecode, got %v", contentType, err)
+			}
+
+			if !errors.Is(err, ErrCodecFailed) {
+				t.Fatalf("%s: expected ErrCodecFailed, got %v", contentType, err)
+			}
+		}
+	})
+
+	t.Run("reports payload type mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := NewCBORCodec[string]().Encode(messaging.Message[string]{Payload: "text"})
+		if err != nil {
+			t.Fatalf("encode: %v", err)
+		}
+
+		_, err = NewCBORCodec[int]().Decode(data)
+		if !errors.Is(err, ErrDecode) {
+			t.Fatalf("expected ErrDecode, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,77 @@
+package codec
+
+import (
+	"errors"
+	"fmt"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+	cerrs "github.com/guidomantilla/yarumo/core/common/errs"
+)
+
+// CodecType is the error domain identifier for messaging codec
+// operations.
+const CodecType = "messaging-codec"
+
+var (
+	_ error = (*Error)(nil)
+)
+
+// Sentinel errors for messaging codec failure modes.
+var (
+	// ErrCodecFailed is the top-level sentinel embedded in every
+	// codec-domain Error returned by ErrCodec.
+	ErrCodecFailed = errors.New("codec operation failed")
+	// ErrEncode indicates that a value could not be serialized by the
+	// Format.
+	ErrEncode = errors.New("encoding failed")
+	// ErrDecode indicates that the input bytes are malformed for the
+	// Format or do not match the target type.
+	ErrDecode = errors.New("decoding failed")
+	// ErrTypeEmpty indicates that a Registry operation needed a
+	// Headers.Type discriminator and found none.
+	ErrTypeEmpty = errors.New("message type is empty")
+	// ErrTypeUnknown indicates that Headers.Type is not registered in
+	// the Registry.
+	ErrTypeUnknown = errors.New("message type is not registered")
+	// ErrTypeRegistered indicates that Register was called twice for
+	// the same message type.
+	ErrTypeRegistered = errors.New("message type is already registered")
+	// ErrFactoryNil indicates that Register was called with a nil
+	// PayloadFactory.
+	ErrFactoryNil = errors.New("payload factory is nil")
+)
+
+// Causes joined under ErrEncode / ErrDecode by the binary formats.
+var (
+	errUnsupportedValue = errors.New("unsupported value")
+	errMalformed        = errors.New("malformed input")
+	errTruncated        = errors.New("truncated input")
+	errTrailingData     = errors.New("trailing data after value")
+	errTooDeep          = errors.New("nesting too deep")
+	errNonFiniteNumber  = errors.New("number is not finite")
+)
+
+// Error is the domain error type for messaging codec operations.
+type Error struct {
+	cerrs.TypedError
+}
+
+// Error returns the formatted error string including the type
+// classification.
+func (e *Error) Error() string {
+	cassert.NotNil(e, "error is nil")
+	cassert.NotNil(e.Err, "internal error is nil")
+
+	return fmt.Sprintf("messaging-codec %s error: %s", e.Type, e.Err)
+}
+
+// ErrCodec wraps the given causes into a domain Error joined with
+// ErrCodecFailed.
+func ErrCodec(causes ...error) error {
+	return &Error{
+		TypedError: cerrs.TypedError{
+			Type: CodecType,
+			Err:  errors.Join(append(causes, ErrCodecFailed)...),
+		},
+	}
+}
@@ -0,0 +1,53 @@
+package codec
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestError_Error(t *testing.T) {
+	t.Parallel()
+
+	t.Run("includes type prefix and joined causes", func(t *testing.T) {
+		t.Parallel()
+
+		err := ErrCodec(ErrDecode)
+
+		msg := err.Error()
+		if !strings.HasPrefix(msg, "messaging-codec "+CodecType) {
+			t.Fatalf("expected prefix %q, got %q", "messaging-codec "+CodecType, msg)
+		}
+
+		if !strings.Contains(msg, ErrDecode.Error()) {
+			t.Fatalf("expected cause %q in message, got %q", ErrDecode.Error(), msg)
+		}
+
+		if !strings.Contains(msg, ErrCodecFailed.Error()) {
+			t.Fatalf("expected sentinel %q in message, got %q", ErrCodecFailed.Error(), msg)
+		}
+	})
+}
+
+func TestErrCodec(t *testing.T) {
+	t.Parallel()
+
+	t.Run("joins all causes with ErrCodecFailed", func(t *testing.T) {
+		t.Parallel()
+
+		boom := errors.New("custom failure")
+
+		err := ErrCodec(ErrTypeUnknown, boom)
+		if !errors.Is(err, ErrCodecFailed) {
+			t.Fatal("expected ErrCodecFailed in chain")
+		}
+
+		if !errors.Is(err, ErrTypeUnknown) {
+			t.Fatal("expected ErrTypeUnknown in chain")
+		}
+
+		if !errors.Is(err, boom) {
+			t.Fatal("expected origin error in chain")
+		}
+	})
+}
@@ -0,0 +1,588 @@
+package codec
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+)
+
+// CBOR major types (RFC 8949 §3.1).
+const (
+	cborUnsigned byte = 0
+	cborNegative byte = 1
+	cborBytes    byte = 2
+	cborText     byte = 3
+	cborArray    byte = 4
+	cborMap      byte = 5
+	cborSimple   byte = 7
+)
+
+// CBOR simple values and additional-information markers.
+const (
+	cborFalse      byte = 20
+	cborTrue       byte = 21
+	cborNull       byte = 22
+	cborUndefined  byte = 23
+	cborFloat16    byte = 25
+	cborFloat32    byte = 26
+	cborFloat64    byte = 27
+	cborIndefinite byte = 31
+	cborBreak      byte = 0xff
+)
+
+// cborFormat is the Format implementation over RFC 8949 CBOR.
+type cborFormat struct{}
+
+// NewCBORFormat returns the Format backed by CBOR (RFC 8949). Encoding
+// is deterministic: integers use the shortest head, floats are encoded
+// as 64-bit and map keys are sorted in bytewise order of their encoded
+// form. Decoding accepts any well-formed CBOR whose map keys are text
+// strings: byte strings become base64 text (matching how encoding/json
+// represents []byte), tags are unwrapped and indefinite-length items
+// are supported.
+func NewCBORFormat() Format {
+	return &cborFormat{}
+}
+
+// ContentType returns ContentTypeCBOR.
+func (f *cborFormat) ContentType() string {
+	cassert.NotNil(f, "cbor format is nil")
+
+	return ContentTypeCBOR
+}
+
+// Marshal encodes v as CBOR.
+func (f *cborFormat) Marshal(v any) ([]byte, error) {
+	cassert.NotNil(f, "cbor format is nil")
+
+	tree, err := toTree(v)
+	if err != nil {
+		return nil, ErrCodec(ErrEncode, err)
+	}
+
+	data, err := cborEncode(nil, tree)
+	if err != nil {
+		return nil, ErrCodec(ErrEncode, err)
+	}
+
+	return data, nil
+}
+
+// Unmarshal decodes CBOR data into v.
+func (f *cborFormat) Unmarshal(data []byte, v any) error {
+	cassert.NotNil(f, "cbor format is nil")
+
+	decoder := &cborDecoder{data: data}
+
+	tree, err := decoder.value(0)
+	if err != nil {
+		return ErrCodec(ErrDecode, err)
+	}
+
+	if decoder.pos != len(data) {
+		return ErrCodec(ErrDecode, errTrailingData)
+	}
+
+	err = fromTree(tree, v)
+	if err != nil {
+		return ErrCodec(ErrDecode, err)
+	}
+
+	return nil
+}
+
+// cborEncode appends the CBOR encoding of a generic tree node to buf.
+func cborEncode(buf []byte, node any) ([]byte, error) {
+	switch value := node.(type) {
+	case nil:
+		return append(buf, cborSimple<<5|cborNull), nil
+	case bool:
+		if value {
+			return append(buf, cborSimple<<5|cborTrue), nil
+		}
+
+		return append(buf, cborSimple<<5|cborFalse), nil
+	case string:
+		buf = cborHead(buf, cborText, uint64(len(value)))
+
+		return append(buf, value...), nil
+	case json.Number:
+		return cborNumber(buf, value)
+	case []any:
+		buf = cborHead(buf, cborArray, uint64(len(value)))
+
+		for _, item := range value {
+			var err error
+
+			buf, err = cborEncode(buf, item)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return buf, nil
+	case map[string]any:
+		return cborEncodeMap(buf, value)
+	default:
+		return nil, fmt.Errorf("%w: %T", errUnsupportedValue, node)
+	}
+}
+
+// cborEncodeMap appends a map with keys sorted in bytewise order of
+// their encoded form: shorter keys first, then lexicographically.
+func cborEncodeMap(buf []byte, value map[string]any) ([]byte, error) {
+	keys := make([]string, 0, len(value))
+	for key := range value {
+		keys = append(keys, key)
+	}
+
+	slices.SortFunc(keys, func(a, b string) int {
+		if len(a) != len(b) {
+			return len(a) - len(b)
+		}
+
+		return strings.Compare(a, b)
+	})
+
+	buf = cborHead(buf, cborMap, uint64(len(value)))
+
+	for _, key := range keys {
+		buf = cborHead(buf, cborText, uint64(len(key)))
+		buf = append(buf, key...)
+
+		var err error
+
+		buf, err = cborEncode(buf, value[key])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return buf, nil
+}
+
+// cborNumber appends n as a CBOR integer when it is one that fits in 64
+// bits, otherwise as a 64-bit float.
+func cborNumber(buf []byte, n json.Number) ([]byte, error) {
+	text := n.String()
+
+	// Fractions, exponents and negative zero are floats: "-0" is the
+	// JSON form of float64 -0.0, which has no integer encoding.
+	if strings.ContainsAny(text, ".eE") || strings.HasPrefix(text, "-0") {
+		return cborFloat(buf, n)
+	}
+
+	unsigned, err := strconv.ParseUint(text, 10, 64)
+	if err == nil {
+		return cborHead(buf, cborUnsigned, unsigned), nil
+	}
+
+	signed, err := strconv.ParseInt(text, 10, 64)
+	if err == nil {
+		return cborHead(buf, cborNegative, uint64(-(signed + 1))), nil
+	}
+
+	return cborFloat(buf, n)
+}
+
+// cborFloat appends n as a double-precision float.
+func cborFloat(buf []byte, n json.Number) ([]byte, error) {
+	f, err := n.Float64()
+	if err != nil {
+		return nil, err
+	}
+
+	buf = append(buf, cborSimple<<5|cborFloat64)
+
+	return binary.BigEndian.AppendUint64(buf, math.Float64bits(f)), nil
+}
+
+// cborHead appends the shortest head for the given major type and
+// argument.
+func cborHead(buf []byte, major byte, arg uint64) []byte {
+	switch {
+	case arg < 24:
+		return append(buf, major<<5|byte(arg))
+	case arg <= math.MaxUint8:
+		return append(buf, major<<5|24, byte(arg))
+	case arg <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(buf, major<<5|25), uint16(arg))
+	case arg <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(buf, major<<5|26), uint32(arg))
+	default:
+		return binary.BigEndian.AppendUint64(append(buf, major<<5|27), arg)
+	}
+}
+
+// cborDecoder parses CBOR into the generic tree consumed by fromTree.
+type cborDecoder struct {
+	data []byte
+	pos  int
+}
+
+// value decodes the next data item.
+func (d *cborDecoder) value(depth int) (any, error) {
+	if depth > maxDepth {
+		return nil, errTooDeep
+	}
+
+	initial, err := d.byte()
+	if err != nil {
+		return nil, err
+	}
+
+	major := initial >> 5
+	info := initial & 0x1f
+
+	if major == cborSimple {
+		return d.simple(info)
+	}
+
+	if info == cborIndefinite {
+		return d.indefinite(major, depth)
+	}
+
+	arg, err := d.argument(info)
+	if err != nil {
+		return nil, err
+	}
+
+	switch major {
+	case cborUnsigned:
+		return json.Number(strconv.FormatUint(arg, 10)), nil
+	case cborNegative:
+		return cborNegativeNumber(arg), nil
+	case cborBytes:
+		raw, err := d.take(arg)
+		if err != nil {
+			return nil, err
+		}
+
+		return base64.StdEncoding.EncodeToString(raw), nil
+	case cborText:
+		raw, err := d.take(arg)
+		if err != nil {
+			return nil, err
+		}
+
+		return string(raw), nil
+	case cborArray:
+		return d.array(arg, depth)
+	case cborMap:
+		return d.object(arg, depth)
+	default:
+		// Major type 6 (tag): the tag number is dropped and the content
+		// decoded.
+		return d.value(depth + 1)
+	}
+}
+
+// cborNegativeNumber renders the CBOR negative integer -1-arg.
+func cborNegativeNumber(arg uint64) json.Number {
+	if arg <= math.MaxInt64 {
+		return json.Number(strconv.FormatInt(-1-int64(arg), 10))
+	}
+
+	// -1-arg does not fit in an int64: render it from the magnitude.
+	return json.Number("-" + addOne(strconv.FormatUint(arg, 10)))
+}
+
+// addOne adds one to a non-negative decimal string.
+func addOne(digits string) string {
+	out := []byte(digits)
+
+	for i := len(out) - 1; i >= 0; i-- {
+		if out[i] != '9' {
+			out[i]++
+
+			return string(out)
+		}
+
+		out[i] = '0'
+	}
+
+	return "1" + string(out)
+}
+
+// simple decodes a major type 7 item.
+func (d *cborDecoder) simple(info byte) (any, error) {
+	switch info {
+	case cborFalse:
+		return false, nil
+	case cborTrue:
+		return true, nil
+	case cborNull, cborUndefined:
+		return nil, nil
+	case cborFloat16:
+		raw, err := d.take(2)
+		if err != nil {
+			return nil, err
+		}
+
+		return floatNumber(float16(binary.BigEndian.Uint16(raw)))
+	case cborFloat32:
+		raw, err := d.take(4)
+		if err != nil {
+			return nil, err
+		}
+
+		return floatNumber(float64(math.Float32frombits(binary.BigEndian.Uint32(raw))))
+	case cborFloat64:
+		raw, err := d.take(8)
+		if err != nil {
+			return nil, err
+		}
+
+		return floatNumber(math.Float64frombits(binary.BigEndian.Uint64(raw)))
+	default:
+		return nil, fmt.Errorf("%w: simple value %d", errUnsupportedValue, info)
+	}
+}
+
+// float16 converts an IEEE 754 half-precision value to float64.
+func float16(bits uint16) float64 {
+	exponent := int(bits>>10) & 0x1f
+	mantissa := float64(bits & 0x3ff)
+
+	var f float64
+
+	switch exponent {
+	case 0:
+		f = math.Ldexp(mantissa, -24)
+	case 0x1f:
+		f = math.Inf(1)
+		if mantissa != 0 {
+			f = math.NaN()
+		}
+	default:
+		f = math.Ldexp(mantissa+1024, exponent-25)
+	}
+
+	if bits&0x8000 != 0 {
+		return -f
+	}
+
+	return f
+}
+
+// indefinite decodes an indefinite-length string, array or map.
+func (d *cborDecoder) indefinite(major byte, depth int) (any, error) {
+	switch major {
+	case cborBytes, cborText:
+		return d.chunks(major)
+	case cborArray:
+		items := []any{}
+
+		for !d.atBreak() {
+			item, err := d.value(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+
+			items = append(items, item)
+		}
+
+		return items, d.skipBreak()
+	case cborMap:
+		object := map[string]any{}
+
+		for !d.atBreak() {
+			err := d.entry(object, depth)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return object, d.skipBreak()
+	default:
+		return nil, fmt.Errorf("%w: indefinite length for major type %d", errMalformed, major)
+	}
+}
+
+// chunks concatenates the definite-length chunks of an indefinite
+// byte or text string.
+func (d *cborDecoder) chunks(major byte) (any, error) {
+	var raw []byte
+
+	for !d.atBreak() {
+		initial, err := d.byte()
+		if err != nil {
+			return nil, err
+		}
+
+		if initial>>5 != major || initial&0x1f == cborIndefinite {
+			return nil, fmt.Errorf("%w: invalid string chunk", errMalformed)
+		}
+
+		length, err := d.argument(initial & 0x1f)
+		if err != nil {
+			return nil, err
+		}
+
+		chunk, err := d.take(length)
+		if err != nil {
+			return nil, err
+		}
+
+		raw = append(raw, chunk...)
+	}
+
+	err := d.skipBreak()
+	if err != nil {
+		return nil, err
+	}
+
+	if major == cborBytes {
+		return base64.StdEncoding.EncodeToString(raw), nil
+	}
+
+	return string(raw), nil
+}
+
+// array decodes a definite-length array of n items.
+func (d *cborDecoder) array(n uint64, depth int) (any, error) {
+	if n > uint64(len(d.data)-d.pos) {
+		return nil, errTruncated
+	}
+
+	items := make([]any, 0, n)
+
+	for range n {
+		item, err := d.value(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// object decodes a definite-length map of n entries.
+func (d *cborDecoder) object(n uint64, depth int) (any, error) {
+	if n > uint64(len(d.data)-d.pos) {
+		return nil, errTruncated
+	}
+
+	object := make(map[string]any, n)
+
+	for range n {
+		err := d.entry(object, depth)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return object, nil
+}
+
+// entry decodes one key/value pair into object. Keys must be text
+// strings.
+func (d *cborDecoder) entry(object map[string]any, depth int) error {
+	key, err := d.value(depth + 1)
+	if err != nil {
+		return err
+	}
+
+	text, ok := key.(string)
+	if !ok {
+		return fmt.Errorf("%w: map key %v", errUnsupportedValue, key)
+	}
+
+	value, err := d.value(depth + 1)
+	if err != nil {
+		return err
+	}
+
+	object[text] = value
+
+	return nil
+}
+
+// argument reads the argument encoded by the additional information of
+// a head.
+func (d *cborDecoder) argument(info byte) (uint64, error) {
+	switch {
+	case info < 24:
+		return uint64(info), nil
+	case info == 24:
+		b, err := d.byte()
+
+		return uint64(b), err
+	case info == 25:
+		raw, err := d.take(2)
+		if err != nil {
+			return 0, err
+		}
+
+		return uint64(binary.BigEndian.Uint16(raw)), nil
+	case info == 26:
+		raw, err := d.take(4)
+		if err != nil {
+			return 0, err
+		}
+
+		return uint64(binary.BigEndian.Uint32(raw)), nil
+	case info == 27:
+		raw, err := d.take(8)
+		if err != nil {
+			return 0, err
+		}
+
+		return binary.BigEndian.Uint64(raw), nil
+	default:
+		return 0, fmt.Errorf("%w: reserved additional information %d", errMalformed, info)
+	}
+}
+
+// byte reads one byte.
+func (d *cborDecoder) byte() (byte, error) {
+	if d.pos >= len(d.data) {
+		return 0, errTruncated
+	}
+
+	b := d.data[d.pos]
+	d.pos++
+
+	return b, nil
+}
+
+// take reads n bytes.
+func (d *cborDecoder) take(n uint64) ([]byte, error) {
+	if n > uint64(len(d.data)-d.pos) {
+		return nil, errTruncated
+	}
+
+	raw := d.data[d.pos : d.pos+int(n)]
+	d.pos += int(n)
+
+	return raw, nil
+}
+
+// atBreak reports whether the next byte is the break stop code. A
+// truncated input reports false so the caller surfaces errTruncated.
+func (d *cborDecoder) atBreak() bool {
+	return d.pos < len(d.data) && d.data[d.pos] == cborBreak
+}
+
+// skipBreak consumes the break stop code.
+func (d *cborDecoder) skipBreak() error {
+	b, err := d.byte()
+	if err != nil {
+		return err
+	}
+
+	if b != cborBreak {
+		return fmt.Errorf("%w: missing break", errMalformed)
+	}
+
+	return nil
+}
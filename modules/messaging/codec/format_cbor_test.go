@@ -0,0 +1,650 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func mustHex(t *testing.T, s string) []byte {
+	t.Helper()
+
+	data, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatalf("hex: %v", err)
+	}
+
+	return data
+}
+
+// assertMarshal fails t unless format encodes value to the hex
+// string want.
+func assertMarshal(t *testing.T, format Format, value any, want string) {
+	t.Helper()
+
+	got, err := format.Marshal(value)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	if hex.EncodeToString(got) != want {
+		t.Fatalf("got %x want %s", got, want)
+	}
+}
+
+// assertDecodeFails fails t unless format rejects the hex string
+// data with ErrDecode.
+func assertDecodeFails(t *testing.T, format Format, data string) {
+	t.Helper()
+
+	var got any
+
+	err := format.Unmarshal(mustHex(t, data), &got)
+	if !errors.Is(err, ErrDecode) {
+		t.Fatalf("expected ErrDecode, got %v", err)
+	}
+}
+
+// assertUnmarshal fails t unless format decodes the hex string data
+// into an any equal to want.
+func assertUnmarshal(t *testing.T, format Format, data string, want any) {
+	t.Helper()
+
+	var got any
+
+	err := format.Unmarshal(mustHex(t, data), &got)
+	if err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v want %#v", got, want)
+	}
+}
+
+func TestCBORFormat_Marshal(t *testing.T) {
+	t.Parallel()
+
+	format := NewCBORFormat()
+
+	// Vectors from RFC 8949 Appendix A.
+	t.Run("zero", func(t *testing.T) {
+		t.Parallel()
+
+		assertMarshal(t, format, 0, "00")
+	})
+
+	t.Run("small", func(t *testing.T) {
+		t.Parallel()
+
+		assertMarshal(t, format, 23, "17")
+	})
+
+	t.Run("one byte", func(t *testing.T) {
+		t.Parallel()
+
+		assertMarshal(t, format, 24, "1818")
+	})
+
+	t.Run("two bytes", func(t *testing.T) {
+		t.Parallel()
+
+		assertMarshal(t, format, 1000, "1903e8")
+	})
+
+	t.Run("four bytes", func(t *testing.T) {
+		t.Parallel()
+
+		assertMarshal(t, format, 1000000, "1a000f4240")
+	})
+
+	t.Run("eight bytes", func(t *testing.T) {
+		t.Parallel()
+
+		assertMarshal(t, format, uint64(1000000000000), "1b000000e8d4a51000")
+	})
+
+	t.Run("max uint64", func(t *testing.T) {
+		t.Parallel()
+
+		assertMarshal(t, format, uint64(math.MaxUint64), "1bffffffffffffffff")
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		t.Parallel()
+
+		assertMarshal(t, format, -1000, "3903e7")
+	})
+
+	t.Run("min int64", func(t *testing.T) {
+		t.Parallel()
+
+		assertMarshal(t, format, int64(math.MinInt64), "3b7fffffffffffffff")
+	})
+
+	t.Run("float", func(t *testing.T) {
+		t.Parallel()
+
+		assertMarshal(t, format, 1.1, "fb3ff199999999999a")
+	})
+
+	t.Run("false", func(t *testing.T) {
+		t.Parallel()
+
+		assertMarshal(t, format, false, "f4")
+	})
+
+	t.Run("true", func(t *testing.T) {
+		t.Parallel()
+
+		assertMarshal(t, format, true, "f5")
+	})
+
+	t.Run("null", func(t *testing.T) {
+		t.Parallel()
+
+		assertMarshal(t, format, nil, "f6")
+	})
+
+	t.Run("text", func(t *testing.T) {
+		t.Parallel()
+
+		assertMarshal(t, format, "IETF", "6449455446")
+	})
+
+	t.Run("array", func(t *testing.T) {
+		t.Parallel()
+
+		assertMarshal(t, format, []int{1, 2, 3}, "83010203")
+	})
+
+	t.Run("map sorted by encoded key", func(t *testing.T) {
+		t.Parallel()
+
+		assertMarshal(t, format, map[string]int{"aa": 2, "b": 1, "a": 0}, "a361610061620162616102")
+	})
+
+	t.Run("is deterministic", func(t *testing.T) {
+		t.Parallel()
+
+		value := map[string]any{"z": 1, "a": map[string]any{"y": 2, "b": 3}, "m": []any{"x"}}
+
+		first, err := format.Marshal(value)
+		if err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+
+		for range 10 {
+			again, err := format.Marshal(value)
+			if err != nil {
+				t.Fatalf("marshal: %v", err)
+			}
+
+			if !bytes.Equal(first, again) {
+				t.Fatalf("non-deterministic output: %x vs %x", first, again)
+			}
+		}
+	})
+}
+
+func TestCBORFormat_Unmarshal(t *testing.T) {
+	t.Parallel()
+
+	format := NewCBORFormat()
+
+	// Vectors from RFC 8949 Appendix A.
+	t.Run("decodes max uint64", func(t *testing.T) {
+		t.Parallel()
+
+		assertUnmarshal(t, format, "1bffffffffffffffff", float64(math.MaxUint64))
+	})
+
+	t.Run("decodes negative", func(t *testing.T) {
+		t.Parallel()
+
+		assertUnmarshal(t, format, "3903e7", float64(-1000))
+	})
+
+	t.Run("decodes half float", func(t *testing.T) {
+		t.Parallel()
+
+		assertUnmarshal(t, format, "f93e00", 1.5)
+	})
+
+	t.Run("decodes half float subnormal", func(t *testing.T) {
+		t.Parallel()
+
+		assertUnmarshal(t, format, "f90001", 5.960464477539063e-08)
+	})
+
+	t.Run("decodes negative half float", func(t *testing.T) {
+		t.Parallel()
+
+		assertUnmarshal(t, format, "f9c400", float64(-4))
+	})
+
+	t.Run("decodes single float", func(t *testing.T) {
+		t.Parallel()
+
+		assertUnmarshal(t, format, "fa47c35000", float64(100000))
+	})
+
+	t.Run("decodes double float", func(t *testing.T) {
+		t.Parallel()
+
+		assertUnmarshal(t, format, "fb3ff199999999999a", 1.1)
+	})
+
+	t.Run("decodes undefined", func(t *testing.T) {
+		t.Parallel()
+
+		assertUnmarshal(t, format, "f7", nil)
+	})
+
+	t.Run("decodes tagged date string", func(t *testing.T) {
+		t.Parallel()
+
+		assertUnmarshal(t, format, "c074323031332d30332d32315432303a30343a30305a", "2013-03-21T20:04:00Z")
+	})
+
+	t.Run("decodes byte string as base64", func(t *testing.T) {
+		t.Parallel()
+
+		assertUnmarshal(t, format, "4401020304", "AQIDBA==")
+	})
+
+	t.Run("decodes indefinite byte string", func(t *testing.T) {
+		t.Parallel()
+
+		assertUnmarshal(t, format, "5f42010243030405ff", "AQIDBAU=")
+	})
+
+	t.Run("decodes indefinite text string", func(t *testing.T) {
+		t.Parallel()
+
+		assertUnmarshal(t, format, "7f657374726561646d696e67ff", "streaming")
+	})
+
+	t.Run("decodes indefinite array", func(t *testing.T) {
+		t.Parallel()
+
+		assertUnmarshal(t, format, "9f018202039f0405ffff", []any{float64(1), []any{float64(2), float64(3)}, []any{float64(4), float64(5)}})
+	})
+
+	t.Run("decodes indefinite map", func(t *testing.T) {
+		t.Parallel()
+
+		assertUnmarshal(t, format, "bf61610161629f0203ffff", map[string]any{"a": float64(1), "b": []any{float64(2), float64(3)}})
+	})
+
+	t.Run("keeps int64 precision", func(t *testing.T) {
+		t.Parallel()
+
+		var got int64
+
+		err := format.Unmarshal(mustHex(t, "3b7fffffffffffffff"), &got)
+		if err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+
+		if got != math.MinInt64 {
+			t.Fatalf("got %d", got)
+		}
+	})
+
+	t.Run("renders negatives beyond int64", func(t *testing.T) {
+		t.Parallel()
+
+		var got any
+
+		err := format.Unmarshal(mustHex(t, "3bffffffffffffffff"), &got)
+		if err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+
+		if got != -18446744073709551616.0 {
+			t.Fatalf("got %v", got)
+		}
+	})
+
+	t.Run("rejects empty", func(t *testing.T) {
+		t.Parallel()
+
+		assertDecodeFails(t, format, "")
+	})
+
+	t.Run("rejects truncated head", func(t *testing.T) {
+		t.Parallel()
+
+		assertDecodeFails(t, format, "19")
+	})
+
+	t.Run("rejects truncated text", func(t *testing.T) {
+		t.Parallel()
+
+		assertDecodeFails(t, format, "6449")
+	})
+
+	t.Run("rejects truncated array", func(t *testing.T) {
+		t.Parallel()
+
+		assertDecodeFails(t, format, "83")
+	})
+
+	t.Run("rejects truncated map", func(t *testing.T) {
+		t.Parallel()
+
+		assertDecodeFails(t, format, "a2")
+	})
+
+	t.Run("rejects truncated float", func(t *testing.T) {
+		t.Parallel()
+
+		assertDecodeFails(t, format, "fb3ff1")
+	})
+
+	t.Run("rejects truncated half", func(t *testing.T) {
+		t.Parallel()
+
+		assertDecodeFails(t, format, "f93e")
+	})
+
+	t.Run("rejects truncated single", func(t *testing.T) {
+		t.Parallel()
+
+		assertDecodeFails(t, format, "fa47c3")
+	})
+
+	t.Run("rejects reserved info", func(t *testing.T) {
+		t.Parallel()
+
+		assertDecodeFails(t, format, "1c")
+	})
+
+	t.Run("rejects trailing data", func(t *testing.T) {
+		t.Parallel()
+
+		assertDecodeFails(t, format, "0000")
+	})
+
+	t.Run("rejects non-text key", func(t *testing.T) {
+		t.Parallel()
+
+		assertDecodeFails(t, format, "a10102")
+	})
+
+	t.Run("rejects unsupported simple", func(t *testing.T) {
+		t.Parallel()
+
+		assertDecodeFails(t, format, "f0")
+	})
+
+	t.Run("rejects nan", func(t *testing.T) {
+		t.Parallel()
+
+		assertDecodeFails(t, format, "f97e00")
+	})
+
+	t.Run("rejects infinity", func(t *testing.T) {
+		t.Parallel()
+
+		assertDecodeFails(t, format, "fa7f800000")
+	})
+
+	t.Run("rejects indefinite int", func(t *testing.T) {
+		t.Parallel()
+
+		assertDecodeFails(t, format, "1f")
+	})
+
+	t.Run("rejects bad string chunk", func(t *testing.T) {
+		t.Parallel()
+
+		assertDecodeFails(t, format, "5f01ff")
+	})
+
+	t.Run("rejects nested indefinite", func(t *testing.T) {
+		t.Parallel()
+
+		assertDecodeFails(t, format, "5f5fffff")
+	})
+
+	t.Run("rejects unterminated array", func(t *testing.T) {
+		t.Parallel()
+
+		assertDecodeFails(t, format, "9f01")
+	})
+
+	t.Run("rejects unterminated map", func(t *testing.T) {
+		t.Parallel()
+
+		assertDecodeFails(t, format, "bf6161")
+	})
+
+	t.Run("rejects unterminated string", func(t *testing.T) {
+		t.Parallel()
+
+		assertDecodeFails(t, format, "7f")
+	})
+
+	t.Run("rejects bad chunk length", func(t *testing.T) {
+		t.Parallel()
+
+		assertDecodeFails(t, format, "5f5c")
+	})
+
+	t.Run("rejects truncated chunk", func(t *testing.T) {
+		t.Parallel()
+
+		assertDecodeFails(t, format, "5f4201")
+	})
+
+	t.Run("rejects truncated uint32", func(t *testing.T) {
+		t.Parallel()
+
+		assertDecodeFails(t, format, "1a0000")
+	})
+
+	t.Run("rejects truncated uint64", func(t *testing.T) {
+		t.Parallel()
+
+		assertDecodeFails(t, format, "1b00")
+	})
+
+	t.Run("rejects truncated one byte", func(t *testing.T) {
+		t.Parallel()
+
+		assertDecodeFails(t, format, "18")
+	})
+
+	t.Run("rejects truncated tag", func(t *testing.T) {
+		t.Parallel()
+
+		assertDecodeFails(t, format, "c0")
+	})
+
+	t.Run("rejects bad value after key", func(t *testing.T) {
+		t.Parallel()
+
+		assertDecodeFails(t, format, "a161611c")
+	})
+
+	t.Run("rejects bad array item", func(t *testing.T) {
+		t.Parallel()
+
+		assertDecodeFails(t, format, "811c")
+	})
+
+	t.Run("rejects bad map key", func(t *testing.T) {
+		t.Parallel()
+
+		assertDecodeFails(t, format, "a11c")
+	})
+
+	t.Run("rejects bad indefinite item", func(t *testing.T) {
+		t.Parallel()
+
+		assertDecodeFails(t, format, "9f1c")
+	})
+
+	t.Run("rejects bad indefinite map key", func(t *testing.T) {
+		t.Parallel()
+
+		assertDecodeFails(t, format, "bf1c")
+	})
+
+	t.Run("rejects huge array", func(t *testing.T) {
+		t.Parallel()
+
+		assertDecodeFails(t, format, "9bffffffffffffffff")
+	})
+
+	t.Run("rejects huge map", func(t *testing.T) {
+		t.Parallel()
+
+		assertDecodeFails(t, format, "bbffffffffffffffff")
+	})
+
+	t.Run("rejects deep nesting", func(t *testing.T) {
+		t.Parallel()
+
+		data := bytes.Repeat([]byte{0x81}, maxDepth+2)
+		data = append(data, 0x00)
+
+		var got any
+
+		err := format.Unmarshal(data, &got)
+		if !errors.Is(err, errTooDeep) {
+			t.Fatalf("expected errTooDeep, got %v", err)
+		}
+	})
+}
+
+func TestCBORFormat_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	format := NewCBORFormat()
+
+	t.Run("negative zero stays a float", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := format.Marshal(math.Copysign(0, -1))
+		if err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+
+		if hex.EncodeToString(data) != "fb8000000000000000" {
+			t.Fatalf("got %x want fb8000000000000000", data)
+		}
+
+		var got float64
+
+		err = format.Unmarshal(data, &got)
+		if err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+
+		if got != 0 || !math.Signbit(got) {
+			t.Fatalf("got %v, want -0", got)
+		}
+	})
+
+	t.Run("max uint64", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := format.Marshal(uint64(math.MaxUint64))
+		if err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+
+		var got uint64
+
+		err = format.Unmarshal(data, &got)
+		if err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+
+		if got != math.MaxUint64 {
+			t.Fatalf("got %d, want %d", got, uint64(math.MaxUint64))
+		}
+	})
+
+	t.Run("min int64", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := format.Marshal(int64(math.MinInt64))
+		if err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+
+		var got int64
+
+		err = format.Unmarshal(data, &got)
+		if err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+
+		if got != math.MinInt64 {
+			t.Fatalf("got %d, want %d", got, int64(math.MinInt64))
+		}
+	})
+}
+
+func TestCBOREncode(t *testing.T) {
+	t.Parallel()
+
+	t.Run("rejects values outside the tree model", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := cborEncode(nil, []any{struct{}{}})
+		if !errors.Is(err, errUnsupportedValue) {
+			t.Fatalf("expected errUnsupportedValue, got %v", err)
+		}
+
+		_, err = cborEncode(nil, map[string]any{"k": struct{}{}})
+		if !errors.Is(err, errUnsupportedValue) {
+			t.Fatalf("expected errUnsupportedValue, got %v", err)
+		}
+	})
+
+	t.Run("rejects invalid numbers", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := cborEncode(nil, json.Number("1e999"))
+		if err == nil {
+			t.Fatal("expected error for out-of-range number")
+		}
+	})
+}
+
+func TestAddOne(t *testing.T) {
+	t.Parallel()
+
+	t.Run("0", func(t *testing.T) {
+		t.Parallel()
+
+		if got := addOne("0"); got != "1" {
+			t.Fatalf("got %q want %q", got, "1")
+		}
+	})
+
+	t.Run("19", func(t *testing.T) {
+		t.Parallel()
+
+		if got := addOne("19"); got != "20" {
+			t.Fatalf("got %q want %q", got, "20")
+		}
+	})
+
+	t.Run("999", func(t *testing.T) {
+		t.Parallel()
+
+		if got := addOne("999"); got != "1000" {
+			t.Fatalf("got %q want %q", got, "1000")
+		}
+	})
+}
@@ -0,0 +1,47 @@
+package codec
+
+import (
+	"encoding/json"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+)
+
+// jsonFormat is the Format implementation over encoding/json.
+type jsonFormat struct{}
+
+// NewJSONFormat returns the Format backed by encoding/json. It is the
+// reference data model the other formats re-encode.
+func NewJSONFormat() Format {
+	return &jsonFormat{}
+}
+
+// ContentType returns ContentTypeJSON.
+func (f *jsonFormat) ContentType() string {
+	cassert.NotNil(f, "json format is nil")
+
+	return ContentTypeJSON
+}
+
+// Marshal encodes v with json.Marshal.
+func (f *jsonFormat) Marshal(v any) ([]byte, error) {
+	cassert.NotNil(f, "json format is nil")
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, ErrCodec(ErrEncode, err)
+	}
+
+	return data, nil
+}
+
+// Unmarshal decodes data into v with json.Unmarshal.
+func (f *jsonFormat) Unmarshal(data []byte, v any) error {
+	cassert.NotNil(f, "json format is nil")
+
+	err := json.Unmarshal(data, v)
+	if err != nil {
+		return ErrCodec(ErrDecode, err)
+	}
+
+	return nil
+}
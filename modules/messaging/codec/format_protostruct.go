@@ -0,0 +1,399 @@
+package codec
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+)
+
+// Protobuf wire types (https://protobuf.dev/programming-guides/encoding).
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+// Field numbers of google.protobuf.Struct, MapEntry, Value and
+// ListValue (google/protobuf/struct.proto).
+const (
+	structFields    = 1
+	entryKey        = 1
+	entryValue      = 2
+	valueNull       = 1
+	valueNumber     = 2
+	valueString     = 3
+	valueBool       = 4
+	valueStruct     = 5
+	valueList       = 6
+	listValueValues = 1
+)
+
+// protoStructFormat is the Format implementation over the protobuf
+// wire encoding of google.protobuf.Struct.
+type protoStructFormat struct{}
+
+// NewProtoStructFormat returns the Format that encodes values as a
+// serialized google.protobuf.Struct, readable by any protobuf runtime
+// (structpb.Struct in Go). The encoded value must be a JSON object at
+// the top level, which Message[T] always is. Numbers travel as doubles;
+// map entries are written in sorted key order so the output is
+// deterministic.
+func NewProtoStructFormat() Format {
+	return &protoStructFormat{}
+}
+
+// ContentType returns ContentTypeProtoStruct.
+func (f *protoStructFormat) ContentType() string {
+	cassert.NotNil(f, "protobuf struct format is nil")
+
+	return ContentTypeProtoStruct
+}
+
+// Marshal encodes v as a google.protobuf.Struct.
+func (f *protoStructFormat) Marshal(v any) ([]byte, error) {
+	cassert.NotNil(f, "protobuf struct format is nil")
+
+	tree, err := toTree(v)
+	if err != nil {
+		return nil, ErrCodec(ErrEncode, err)
+	}
+
+	object, ok := tree.(map[string]any)
+	if !ok {
+		return nil, ErrCodec(ErrEncode, fmt.Errorf("%w: top-level %T is not an object", errUnsupportedValue, tree))
+	}
+
+	data, err := protoEncodeStruct(nil, object)
+	if err != nil {
+		return nil, ErrCodec(ErrEncode, err)
+	}
+
+	return data, nil
+}
+
+// Unmarshal decodes a serialized google.protobuf.Struct into v.
+func (f *protoStructFormat) Unmarshal(data []byte, v any) error {
+	cassert.NotNil(f, "protobuf struct format is nil")
+
+	tree, err := protoDecodeStruct(data, 0)
+	if err != nil {
+		return ErrCodec(ErrDecode, err)
+	}
+
+	err = fromTree(tree, v)
+	if err != nil {
+		return ErrCodec(ErrDecode, err)
+	}
+
+	return nil
+}
+
+// protoEncodeStruct appends the fields of a Struct message.
+func protoEncodeStruct(buf []byte, object map[string]any) ([]byte, error) {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		value, err := protoEncodeValue(nil, object[key])
+		if err != nil {
+			return nil, err
+		}
+
+		var entry []byte
+
+		entry = protoAppendBytes(entry, entryKey, []byte(key))
+		entry = protoAppendBytes(entry, entryValue, value)
+
+		buf = protoAppendBytes(buf, structFields, entry)
+	}
+
+	return buf, nil
+}
+
+// protoEncodeValue appends the fields of a Value message for node.
+func protoEncodeValue(buf []byte, node any) ([]byte, error) {
+	switch value := node.(type) {
+	case nil:
+		buf = protoAppendTag(buf, valueNull, wireVarint)
+
+		return binary.AppendUvarint(buf, 0), nil
+	case bool:
+		buf = protoAppendTag(buf, valueBool, wireVarint)
+		if value {
+			return binary.AppendUvarint(buf, 1), nil
+		}
+
+		return binary.AppendUvarint(buf, 0), nil
+	case string:
+		return protoAppendBytes(buf, valueString, []byte(value)), nil
+	case json.Number:
+		f, err := value.Float64()
+		if err != nil {
+			return nil, err
+		}
+
+		buf = protoAppendTag(buf, valueNumber, wireFixed64)
+
+		return binary.LittleEndian.AppendUint64(buf, math.Float64bits(f)), nil
+	case []any:
+		var list []byte
+
+		for _, item := range value {
+			encoded, err := protoEncodeValue(nil, item)
+			if err != nil {
+				return nil, err
+			}
+
+			list = protoAppendBytes(list, listValueValues, encoded)
+		}
+
+		return protoAppendBytes(buf, valueList, list), nil
+	case map[string]any:
+		encoded, err := protoEncodeStruct(nil, value)
+		if err != nil {
+			return nil, err
+		}
+
+		return protoAppendBytes(buf, valueStruct, encoded), nil
+	default:
+		return nil, fmt.Errorf("%w: %T", errUnsupportedValue, node)
+	}
+}
+
+// protoAppendTag appends a field tag.
+func protoAppendTag(buf []byte, field int, wireType int) []byte {
+	return binary.AppendUvarint(buf, uint64(field)<<3|uint64(wireType))
+}
+
+// protoAppendBytes appends a length-delimited field. Empty embedded
+// messages are still written so that, for example, an empty string or
+// list keeps its kind.
+func protoAppendBytes(buf []byte, field int, data []byte) []byte {
+	buf = protoAppendTag(buf, field, wireBytes)
+	buf = binary.AppendUvarint(buf, uint64(len(data)))
+
+	return append(buf, data...)
+}
+
+// protoField is one decoded field of a protobuf message.
+type protoField struct {
+	number   int
+	wireType int
+	varint   uint64
+	bytes    []byte
+}
+
+// protoFields splits a serialized message into its fields, validating
+// the wire framing.
+func protoFields(data []byte) ([]protoField, error) {
+	var fields []protoField
+
+	for len(data) > 0 {
+		tag, n := binary.Uvarint(data)
+		if n <= 0 {
+			return nil, errTruncated
+		}
+
+		data = data[n:]
+
+		field := protoField{number: int(tag >> 3), wireType: int(tag & 0x7)}
+		if field.number <= 0 {
+			return nil, fmt.Errorf("%w: field number %d", errMalformed, field.number)
+		}
+
+		var err error
+
+		data, err = field.read(data)
+		if err != nil {
+			return nil, err
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+// read consumes the field payload from data according to its wire type
+// and returns the remaining bytes.
+func (p *protoField) read(data []byte) ([]byte, error) {
+	switch p.wireType {
+	case wireVarint:
+		value, n := binary.Uvarint(data)
+		if n <= 0 {
+			return nil, errTruncated
+		}
+
+		p.varint = value
+
+		return data[n:], nil
+	case wireFixed64:
+		if len(data) < 8 {
+			return nil, errTruncated
+		}
+
+		p.varint = binary.LittleEndian.Uint64(data)
+
+		return data[8:], nil
+	case wireBytes:
+		length, n := binary.Uvarint(data)
+		if n <= 0 || length > uint64(len(data)-n) {
+			return nil, errTruncated
+		}
+
+		p.bytes = data[n : n+int(length)]
+
+		return data[n+int(length):], nil
+	case wireFixed32:
+		if len(data) < 4 {
+			return nil, errTruncated
+		}
+
+		p.varint = uint64(binary.LittleEndian.Uint32(data))
+
+		return data[4:], nil
+	default:
+		return nil, fmt.Errorf("%w: wire type %d", errMalformed, p.wireType)
+	}
+}
+
+// protoDecodeStruct decodes a Struct message into a map. Unknown fields
+// are skipped, as protobuf requires.
+func protoDecodeStruct(data []byte, depth int) (map[string]any, error) {
+	if depth > maxDepth {
+		return nil, errTooDeep
+	}
+
+	fields, err := protoFields(data)
+	if err != nil {
+		return nil, err
+	}
+
+	object := make(map[string]any, len(fields))
+
+	for _, field := range fields {
+		if field.number != structFields || field.wireType != wireBytes {
+			continue
+		}
+
+		key, value, err := protoDecodeEntry(field.bytes, depth)
+		if err != nil {
+			return nil, err
+		}
+
+		object[key] = value
+	}
+
+	return object, nil
+}
+
+// protoDecodeEntry decodes one map<string, Value> entry. A missing key
+// is the empty string and a missing value is null, per proto3 defaults.
+func protoDecodeEntry(data []byte, depth int) (string, any, error) {
+	fields, err := protoFields(data)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var (
+		key   string
+		value any
+	)
+
+	for _, field := range fields {
+		if field.wireType != wireBytes {
+			continue
+		}
+
+		switch field.number {
+		case entryKey:
+			key = string(field.bytes)
+		case entryValue:
+			value, err = protoDecodeValue(field.bytes, depth+1)
+			if err != nil {
+				return "", nil, err
+			}
+		}
+	}
+
+	return key, value, nil
+}
+
+// protoDecodeValue decodes a Value message. When several kinds are
+// present the last one wins (oneof semantics); no kind at all is null.
+func protoDecodeValue(data []byte, depth int) (any, error) {
+	if depth > maxDepth {
+		return nil, errTooDeep
+	}
+
+	fields, err := protoFields(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var value any
+
+	for _, field := range fields {
+		value, err = protoDecodeKind(field, value, depth)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return value, nil
+}
+
+// protoDecodeKind decodes one Value field. Fields that are not a known
+// kind with the expected wire type leave current untouched.
+func protoDecodeKind(field protoField, current any, depth int) (any, error) {
+	switch {
+	case field.number == valueNull && field.wireType == wireVarint:
+		return nil, nil
+	case field.number == valueNumber && field.wireType == wireFixed64:
+		return floatNumber(math.Float64frombits(field.varint))
+	case field.number == valueString && field.wireType == wireBytes:
+		return string(field.bytes), nil
+	case field.number == valueBool && field.wireType == wireVarint:
+		return field.varint != 0, nil
+	case field.number == valueStruct && field.wireType == wireBytes:
+		return protoDecodeStruct(field.bytes, depth+1)
+	case field.number == valueList && field.wireType == wireBytes:
+		return protoDecodeList(field.bytes, depth+1)
+	default:
+		return current, nil
+	}
+}
+
+// protoDecodeList decodes a ListValue message.
+func protoDecodeList(data []byte, depth int) (any, error) {
+	fields, err := protoFields(data)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]any, 0, len(fields))
+
+	for _, field := range fields {
+		if field.number != listValueValues || field.wireType != wireBytes {
+			continue
+		}
+
+		item, err := protoDecodeValue(field.bytes, depth+1)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
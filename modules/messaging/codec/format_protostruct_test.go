@@ -0,0 +1,337 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestProtoStructFormat_Marshal(t *testing.T) {
+	t.Parallel()
+
+	format := NewProtoStructFormat()
+
+	t.Run("empty struct", func(t *testing.T) {
+		t.Parallel()
+
+		assertMarshal(t, format, map[string]any{}, "")
+	})
+
+	t.Run("number", func(t *testing.T) {
+		t.Parallel()
+
+		assertMarshal(t, format, map[string]any{"a": 1}, "0a0e0a0161120911000000000000f03f")
+	})
+
+	t.Run("null", func(t *testing.T) {
+		t.Parallel()
+
+		assertMarshal(t, format, map[string]any{"a": nil}, "0a070a016112020800")
+	})
+
+	t.Run("bool", func(t *testing.T) {
+		t.Parallel()
+
+		assertMarshal(t, format, map[string]any{"a": true}, "0a070a016112022001")
+	})
+
+	t.Run("string", func(t *testing.T) {
+		t.Parallel()
+
+		assertMarshal(t, format, map[string]any{"a": "x"}, "0a080a016112031a0178")
+	})
+
+	t.Run("empty list", func(t *testing.T) {
+		t.Parallel()
+
+		assertMarshal(t, format, map[string]any{"a": []any{}}, "0a070a016112023200")
+	})
+
+	t.Run("nested struct", func(t *testing.T) {
+		t.Parallel()
+
+		assertMarshal(t, format, map[string]any{"a": map[string]any{}}, "0a070a016112022a00")
+	})
+
+	t.Run("sorted keys", func(t *testing.T) {
+		t.Parallel()
+
+		assertMarshal(t, format, map[string]any{"b": false, "a": false}, "0a070a0161120220000a070a016212022000")
+	})
+
+	t.Run("rejects non-object values", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := format.Marshal([]int{1})
+		if !errors.Is(err, ErrEncode) {
+			t.Fatalf("expected ErrEncode, got %v", err)
+		}
+	})
+
+	t.Run("is deterministic", func(t *testing.T) {
+		t.Parallel()
+
+		value := map[string]any{"z": 1, "a": map[string]any{"y": 2, "b": 3}, "m": []any{"x", nil}}
+
+		first, err := format.Marshal(value)
+		if err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+
+		for range 10 {
+			again, err := format.Marshal(value)
+			if err != nil {
+				t.Fatalf("marshal: %v", err)
+			}
+
+			if !bytes.Equal(first, again) {
+				t.Fatalf("non-deterministic output: %x vs %x", first, again)
+			}
+		}
+	})
+}
+
+func TestProtoStructFormat_Unmarshal(t *testing.T) {
+	t.Parallel()
+
+	format := NewProtoStructFormat()
+
+	t.Run("round trips nested values", func(t *testing.T) {
+		t.Parallel()
+
+		value := map[string]any{
+			"n":     float64(-2.5),
+			"s":     "text",
+			"b":     true,
+			"z":     nil,
+			"list":  []any{float64(1), "two", []any{}, map[string]any{"k": false}},
+			"inner": map[string]any{"deep": map[string]any{"x": float64(7)}},
+		}
+
+		data, err := format.Marshal(value)
+		if err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+
+		var got map[string]any
+
+		err = format.Unmarshal(data, &got)
+		if err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+
+		if !reflect.DeepEqual(got, value) {
+			t.Fatalf("got %#v want %#v", got, value)
+		}
+	})
+
+	t.Run("renders integral doubles as integers", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := format.Marshal(map[string]any{"n": 100000000})
+		if err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+
+		var got struct{ N int }
+
+		err = format.Unmarshal(data, &got)
+		if err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+
+		if got.N != 100000000 {
+			t.Fatalf("got %d", got.N)
+		}
+	})
+
+	t.Run("skips unknown fields and applies proto3 defaults", func(t *testing.T) {
+		t.Parallel()
+
+		// Struct with: an unknown varint field 2, an unknown fixed32
+		// field 3, an entry with unknown field 3 and no value, an entry
+		// whose Value carries an unknown field then a string, and a
+		// Value with two kinds where the last one wins.
+		data := mustHex(t, "1001"+"1d00000000"+
+			"0a050a01611801"+
+			"0a0a0a016212053801"+"1a0178"+
+			"0a0a0a01631205"+"1a01782001")
+
+		var got map[string]any
+
+		err := format.Unmarshal(data, &got)
+		if err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+
+		want := map[string]any{"a": nil, "b": "x", "c": true}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %#v want %#v", got, want)
+		}
+	})
+
+	t.Run("skips unknown list and entry fields", func(t *testing.T) {
+		t.Parallel()
+
+		// Value{list_value: ListValue{unknown varint 2, values: [true]}}
+		// plus an entry key sent as a varint (ignored: empty key).
+		data := mustHex(t, "0a0d0a0161120832061001"+"0a022001"+"0a020801")
+
+		var got map[string]any
+
+		err := format.Unmarshal(data, &got)
+		if err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+
+		want := map[string]any{"a": []any{true}, "": nil}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %#v want %#v", got, want)
+		}
+	})
+
+	t.Run("rejects truncated tag", func(t *testing.T) {
+		t.Parallel()
+
+		assertDecodeFails(t, format, "ff")
+	})
+
+	t.Run("rejects zero field number", func(t *testing.T) {
+		t.Parallel()
+
+		assertDecodeFails(t, format, "0000")
+	})
+
+	t.Run("rejects group wire type", func(t *testing.T) {
+		t.Parallel()
+
+		assertDecodeFails(t, format, "0b")
+	})
+
+	t.Run("rejects truncated varint", func(t *testing.T) {
+		t.Parallel()
+
+		assertDecodeFails(t, format, "08ff")
+	})
+
+	t.Run("rejects truncated fixed64", func(t *testing.T) {
+		t.Parallel()
+
+		assertDecodeFails(t, format, "0900")
+	})
+
+	t.Run("rejects truncated fixed32", func(t *testing.T) {
+		t.Parallel()
+
+		assertDecodeFails(t, format, "0d00")
+	})
+
+	t.Run("rejects truncated length", func(t *testing.T) {
+		t.Parallel()
+
+		assertDecodeFails(t, format, "0a05")
+	})
+
+	t.Run("rejects bad entry", func(t *testing.T) {
+		t.Parallel()
+
+		assertDecodeFails(t, format, "0a01ff")
+	})
+
+	t.Run("rejects bad value", func(t *testing.T) {
+		t.Parallel()
+
+		assertDecodeFails(t, format, "0a050a01611201ff")
+	})
+
+	t.Run("rejects non-finite number", func(t *testing.T) {
+		t.Parallel()
+
+		assertDecodeFails(t, format, "0a0e0a0161120911000000000000f07f")
+	})
+
+	t.Run("rejects bad list", func(t *testing.T) {
+		t.Parallel()
+
+		assertDecodeFails(t, format, "0a070a016112023201")
+	})
+
+	t.Run("rejects bad list item", func(t *testing.T) {
+		t.Parallel()
+
+		assertDecodeFails(t, format, "0a090a01611204320201ff")
+	})
+
+	t.Run("rejects bad nested struct", func(t *testing.T) {
+		t.Parallel()
+
+		assertDecodeFails(t, format, "0a070a016112022a01")
+	})
+
+	t.Run("rejects type mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		var got struct{ A int }
+
+		err := format.Unmarshal(mustHex(t, "0a080a016112031a0178"), &got)
+		if !errors.Is(err, ErrDecode) {
+			t.Fatalf("expected ErrDecode, got %v", err)
+		}
+	})
+
+	t.Run("rejects deep nesting", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := protoDecodeStruct(nil, maxDepth+1)
+		if !errors.Is(err, errTooDeep) {
+			t.Fatalf("expected errTooDeep, got %v", err)
+		}
+
+		_, err = protoDecodeValue(nil, maxDepth+1)
+		if !errors.Is(err, errTooDeep) {
+			t.Fatalf("expected errTooDeep, got %v", err)
+		}
+	})
+}
+
+func TestProtoEncodeValue(t *testing.T) {
+	t.Parallel()
+
+	t.Run("rejects a struct", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := protoEncodeValue(nil, struct{}{})
+		if err == nil {
+			t.Fatal("expected error")
+		}
+	})
+
+	t.Run("rejects a list with a struct", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := protoEncodeValue(nil, []any{struct{}{}})
+		if err == nil {
+			t.Fatal("expected error")
+		}
+	})
+
+	t.Run("rejects a map with a struct", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := protoEncodeValue(nil, map[string]any{"k": struct{}{}})
+		if err == nil {
+			t.Fatal("expected error")
+		}
+	})
+
+	t.Run("rejects an out-of-range number", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := protoEncodeValue(nil, json.Number("1e999"))
+		if err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
@@ -0,0 +1,153 @@
+package codec
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+// registry is the Registry implementation: a mutex-guarded map from
+// Headers.Type to PayloadFactory over a single Format.
+type registry struct {
+	format    Format
+	mu        sync.RWMutex
+	factories map[string]PayloadFactory
+}
+
+// envelopeHeaders is the shape decoded in the first pass of
+// registry.Decode: the payload is skipped, only Headers are read.
+type envelopeHeaders struct {
+	Headers messaging.Headers
+}
+
+// envelope is the shape decoded in the second pass of registry.Decode.
+// Payload holds the pointer returned by the PayloadFactory, which
+// encoding/json decodes into in place.
+type envelope struct {
+	Payload any
+	Headers messaging.Headers
+}
+
+// NewRegistry returns an empty Registry that serializes envelopes with
+// format. format is mandatory.
+func NewRegistry(format Format) Registry {
+	cassert.NotNil(format, "format is nil")
+
+	return &registry{
+		format:    format,
+		factories: make(map[string]PayloadFactory),
+	}
+}
+
+// Factory returns the PayloadFactory for payload type T.
+func Factory[T any]() PayloadFactory {
+	return func() any {
+		return new(T)
+	}
+}
+
+// ContentType returns the content type of the underlying Format.
+func (r *registry) ContentType() string {
+	cassert.NotNil(r, "registry is nil")
+
+	return r.format.ContentType()
+}
+
+// Register binds messageType to factory. See Registry.Register.
+func (r *registry) Register(messageType string, factory PayloadFactory) error {
+	cassert.NotNil(r, "registry is nil")
+
+	if messageType == "" {
+		return ErrCodec(ErrTypeEmpty)
+	}
+
+	if factory == nil {
+		return ErrCodec(ErrFactoryNil)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, exists := r.factories[messageType]
+	if exists {
+		return ErrCodec(ErrTypeRegistered, fmt.Errorf("type %q", messageType))
+	}
+
+	r.factories[messageType] = factory
+
+	return nil
+}
+
+// Encode serializes msg after checking that its type is registered.
+// See Registry.Encode.
+func (r *registry) Encode(msg messaging.Message[any]) ([]byte, error) {
+	cassert.NotNil(r, "registry is nil")
+
+	_, err := r.factory(msg.Headers.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	if msg.Headers.ContentType == "" {
+		msg.Headers.ContentType = r.format.ContentType()
+	}
+
+	return r.format.Marshal(msg)
+}
+
+// Decode deserializes data into the payload type registered for its
+// Headers.Type. See Registry.Decode.
+func (r *registry) Decode(data []byte) (messaging.Message[any], error) {
+	cassert.NotNil(r, "registry is nil")
+
+	var head envelopeHeaders
+
+	err := r.format.Unmarshal(data, &head)
+	if err != nil {
+		return messaging.Message[any]{}, err
+	}
+
+	factory, err := r.factory(head.Headers.Type)
+	if err != nil {
+		return messaging.Message[any]{}, err
+	}
+
+	target := factory()
+
+	ptr := reflect.ValueOf(target)
+	if ptr.Kind() != reflect.Pointer || ptr.IsNil() {
+		return messaging.Message[any]{}, ErrCodec(ErrDecode, fmt.Errorf("factory for type %q returned %T, want a non-nil pointer", head.Headers.Type, target))
+	}
+
+	body := envelope{Payload: target}
+
+	err = r.format.Unmarshal(data, &body)
+	if err != nil {
+		return messaging.Message[any]{}, err
+	}
+
+	return messaging.Message[any]{
+		Payload: ptr.Elem().Interface(),
+		Headers: body.Headers,
+	}, nil
+}
+
+// factory returns the factory registered for messageType.
+func (r *registry) factory(messageType string) (PayloadFactory, error) {
+	if messageType == "" {
+		return nil, ErrCodec(ErrTypeEmpty)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	factory, ok := r.factories[messageType]
+	if !ok {
+		return nil, ErrCodec(ErrTypeUnknown, fmt.Errorf("type %q", messageType))
+	}
+
+	return factory, nil
+}
@@ -0,0 +1,274 @@
+package codec
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+type shipped struct {
+	OrderID string
+	Carrier string
+}
+
+func newTestRegistry(t *testing.T, format Format) Registry {
+	t.Helper()
+
+	r := NewRegistry(format)
+
+	err := r.Register("order.created", Factory[order]())
+	if err != nil {
+		t.Fatalf("register order: %v", err)
+	}
+
+	err = r.Register("order.shipped", Factory[shipped]())
+	if err != nil {
+		t.Fatalf("register shipped: %v", err)
+	}
+
+	return r
+}
+
+func TestRegistry_Register(t *testing.T) {
+	t.Parallel()
+
+	t.Run("rejects empty type", func(t *testing.T) {
+		t.Parallel()
+
+		err := NewRegistry(NewJSONFormat()).Register("", Factory[order]())
+		if !errors.Is(err, ErrTypeEmpty) {
+			t.Fatalf("expected ErrTypeEmpty, got %v", err)
+		}
+	})
+
+	t.Run("rejects nil factory", func(t *testing.T) {
+		t.Parallel()
+
+		err := NewRegistry(NewJSONFormat()).Register("order.created", nil)
+		if !errors.Is(err, ErrFactoryNil) {
+			t.Fatalf("expected ErrFactoryNil, got %v", err)
+		}
+	})
+
+	t.Run("rejects duplicate type", func(t *testing.T) {
+		t.Parallel()
+
+		r := newTestRegistry(t, NewJSONFormat())
+
+		err := r.Register("order.created", Factory[shipped]())
+		if !errors.Is(err, ErrTypeRegistered) {
+			t.Fatalf("expected ErrTypeRegistered, got %v", err)
+		}
+	})
+
+	t.Run("is safe for concurrent use", func(t *testing.T) {
+		t.Parallel()
+
+		r := NewRegistry(NewJSONFormat())
+
+		var (
+			wg        sync.WaitGroup
+			mu        sync.Mutex
+			successes int
+		)
+
+		for range 16 {
+			wg.Go(func() {
+				err := r.Register("order.created", Factory[order]())
+				if err == nil {
+					mu.Lock()
+					successes++
+					mu.Unlock()
+				}
+			})
+		}
+
+		wg.Wait()
+
+		if successes != 1 {
+			t.Fatalf("successes: got %d want 1", successes)
+		}
+	})
+}
+
+func TestRegistry_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	formats := []Format{NewJSONFormat(), NewCBORFormat(), NewProtoStructFormat()}
+
+	for _, format := range formats {
+		t.Run(format.ContentType(), func(t *testing.T) {
+			t.Parallel()
+
+			r := newTestRegistry(t, format)
+
+			if r.ContentType() != format.ContentType() {
+				t.Fatalf("content type: got %q", r.ContentType())
+			}
+
+			data, err := r.Encode(messaging.Message[any]{
+				Payload: shipped{OrderID: "o-1", Carrier: "ups"},
+				Headers: messaging.Headers{MessageID: "m-1", Type: "order.shipped"},
+			})
+			if err != nil {
+				t.Fatalf("encode: %v", err)
+			}
+
+			got, err := r.Decode(data)
+			if err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+
+			payload, ok := got.Payload.(shipped)
+			if !ok {
+				t.Fatalf("payload type: got %T want shipped", got.Payload)
+			}
+
+			if payload.OrderID != "o-1" || payload.Carrier != "ups" {
+				t.Fatalf("payload: got %+v", payload)
+			}
+
+			if got.Headers.MessageID != "m-1" || got.Headers.ContentType != format.ContentType() {
+				t.Fatalf("headers: got %+v", got.Headers)
+			}
+		})
+	}
+
+	t.Run("decodes envelopes produced by a typed codec", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := NewCBORCodec[order]().Encode(newTestEnvelope())
+		if err != nil {
+			t.Fatalf("encode: %v", err)
+		}
+
+		got, err := newTestRegistry(t, NewCBORFormat()).Decode(data)
+		if err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+
+		payload, ok := got.Payload.(order)
+		if !ok {
+			t.Fatalf("payload type: got %T want order", got.Payload)
+		}
+
+		if payload.ID != "o-1" || got.Headers.SequenceSize != 5 {
+			t.Fatalf("unexpected message: %+v", got)
+		}
+	})
+}
+
+func TestRegistry_Encode(t *testing.T) {
+	t.Parallel()
+
+	t.Run("rejects missing type", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := newTestRegistry(t, NewJSONFormat()).Encode(messaging.Message[any]{Payload: 1})
+		if !errors.Is(err, ErrTypeEmpty) {
+			t.Fatalf("expected ErrTypeEmpty, got %v", err)
+		}
+	})
+
+	t.Run("rejects unregistered type", func(t *testing.T) {
+		t.Parallel()
+
+		msg := messaging.Message[any]{Payload: 1, Headers: messaging.Headers{Type: "unknown"}}
+
+		_, err := newTestRegistry(t, NewJSONFormat()).Encode(msg)
+		if !errors.Is(err, ErrTypeUnknown) {
+			t.Fatalf("expected ErrTypeUnknown, got %v", err)
+		}
+	})
+}
+
+func TestRegistry_Decode(t *testing.T) {
+	t.Parallel()
+
+	t.Run("rejects malformed input", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := newTestRegistry(t, NewJSONFormat()).Decode([]byte("{"))
+		if !errors.Is(err, ErrDecode) {
+			t.Fatalf("expected ErrDecode, got %v", err)
+		}
+	})
+
+	t.Run("rejects missing type", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := NewJSONCodec[int]().Encode(messaging.Message[int]{Payload: 1})
+		if err != nil {
+			t.Fatalf("encode: %v", err)
+		}
+
+		_, err = newTestRegistry(t, NewJSONFormat()).Decode(data)
+		if !errors.Is(err, ErrTypeEmpty) {
+			t.Fatalf("expected ErrTypeEmpty, got %v", err)
+		}
+	})
+
+	t.Run("rejects unregistered type", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := NewJSONCodec[int]().Encode(messaging.Message[int]{Payload: 1, Headers: messaging.Headers{Type: "unknown"}})
+		if err != nil {
+			t.Fatalf("encode: %v", err)
+		}
+
+		_, err = newTestRegistry(t, NewJSONFormat()).Decode(data)
+		if !errors.Is(err, ErrTypeUnknown) {
+			t.Fatalf("expected ErrTypeUnknown, got %v", err)
+		}
+	})
+
+	t.Run("rejects factories that do not return a pointer", func(t *testing.T) {
+		t.Parallel()
+
+		r := NewRegistry(NewJSONFormat())
+
+		err := r.Register("bad", func() any { return order{} })
+		if err != nil {
+			t.Fatalf("register: %v", err)
+		}
+
+		data, err := NewJSONCodec[order]().Encode(messaging.Message[order]{Headers: messaging.Headers{Type: "bad"}})
+		if err != nil {
+			t.Fatalf("encode: %v", err)
+		}
+
+		_, err = r.Decode(data)
+		if !errors.Is(err, ErrDecode) {
+			t.Fatalf("expected ErrDecode, got %v", err)
+		}
+	})
+
+	t.Run("rejects payloads that do not match the registered type", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := NewJSONCodec[string]().Encode(messaging.Message[string]{Payload: "text", Headers: messaging.Headers{Type: "order.created"}})
+		if err != nil {
+			t.Fatalf("encode: %v", err)
+		}
+
+		_, err = newTestRegistry(t, NewJSONFormat()).Decode(data)
+		if !errors.Is(err, ErrDecode) {
+			t.Fatalf("expected ErrDecode, got %v", err)
+		}
+	})
+
+	t.Run("null payload decodes to the zero value", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := newTestRegistry(t, NewJSONFormat()).Decode([]byte(`{"Payload":null,"Headers":{"Type":"order.shipped"}}`))
+		if err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+
+		if got.Payload != (shipped{}) {
+			t.Fatalf("payload: got %#v", got.Payload)
+		}
+	})
+}
@@ -0,0 +1,65 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strconv"
+)
+
+// maxDepth bounds the nesting of decoded trees so malformed or hostile
+// input cannot exhaust the stack.
+const maxDepth = 512
+
+// toTree converts v into the generic encoding/json tree the binary
+// formats re-encode: map[string]any, []any, string, json.Number, bool
+// and nil. Numbers stay json.Number so integers keep full precision.
+func toTree(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var tree any
+
+	err = decoder.Decode(&tree)
+	if err != nil {
+		return nil, err
+	}
+
+	return tree, nil
+}
+
+// fromTree decodes a generic tree produced by a binary format into v,
+// going through encoding/json so every format shares its data model.
+func fromTree(tree any, v any) error {
+	data, err := json.Marshal(tree)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// floatNumber renders f as a json.Number. Integral values in the int64
+// range are rendered without exponent so they decode into integer
+// fields; negative zero keeps its sign as "-0"; NaN and infinities
+// have no JSON representation.
+func floatNumber(f float64) (json.Number, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return "", errNonFiniteNumber
+	}
+
+	if f == 0 && math.Signbit(f) {
+		return "-0", nil
+	}
+
+	if f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64 {
+		return json.Number(strconv.FormatInt(int64(f), 10)), nil
+	}
+
+	return json.Number(strconv.FormatFloat(f, 'g', -1, 64)), nil
+}
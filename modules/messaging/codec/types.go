@@ -0,0 +1,139 @@
+// Package codec provides the wire-format layer for messaging: it
+// encodes a full Message[T] envelope (Payload plus every Headers field,
+// including Custom, timestamps and sequence fields) to bytes and decodes
+// it back.
+//
+// Three building blocks live here:
+//
+//   - Format is a serialization format for an arbitrary Go value. The
+//     package ships three: NewJSONFormat (encoding/json),
+//     NewCBORFormat (RFC 8949, deterministic map ordering) and
+//     NewProtoStructFormat (the protobuf wire encoding of
+//     google.protobuf.Struct).
+//   - Codec[T] binds a Format to a payload type T and converts
+//     Message[T] envelopes to and from bytes. NewJSONCodec,
+//     NewCBORCodec and NewProtoStructCodec are shorthands for
+//     NewCodec over the matching Format.
+//   - Registry decodes envelopes polymorphically: payload types are
+//     registered under their Headers.Type discriminator and Decode
+//     returns a Message[any] whose Payload holds the registered
+//     concrete type.
+//
+// # Data model
+//
+// Every Format shares the encoding/json data model: struct fields are
+// keyed by their Go name (or json tag), time.Time values travel as
+// RFC 3339 strings, []byte as base64 strings and Custom values come back
+// with JSON typing (numbers as float64, objects as map[string]any). The
+// CBOR and protobuf-struct formats re-encode that model in their own
+// wire representation, so a payload type that round-trips through
+// encoding/json round-trips through all three formats. The
+// protobuf-struct format carries numbers as doubles: integers beyond
+// 2^53 lose precision there.
+//
+// The CBOR and protobuf-struct formats are implemented on top of the
+// standard library only; this package owns no external dependencies
+// beyond core/common.
+//
+// # Content type
+//
+// Encode stamps Headers.ContentType with the Format's content type when
+// the caller left it empty; an explicit value is preserved. Decode does
+// not check Headers.ContentType — transports negotiate the Format
+// before handing bytes to a Codec.
+//
+// # Concurrency
+//
+// All public types in this package are safe for concurrent use by
+// multiple goroutines.
+package codec
+
+import (
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+// Type compliance: the shipped formats satisfy Format, the generic
+// codec satisfies Codec[T], the registry satisfies Registry, and the
+// free error factory matches its declared Fn alias.
+var (
+	_ Format          = (*jsonFormat)(nil)
+	_ Format          = (*cborFormat)(nil)
+	_ Format          = (*protoStructFormat)(nil)
+	_ Codec[any]      = (*codec[any])(nil)
+	_ Registry        = (*registry)(nil)
+	_ ErrCodecFn      = ErrCodec
+	_ PayloadFactory  = Factory[any]()
+	_ NewCodecFn[any] = NewCodec[any]
+)
+
+// Content types reported by the shipped formats.
+const (
+	// ContentTypeJSON is the content type of NewJSONFormat.
+	ContentTypeJSON = "application/json"
+	// ContentTypeCBOR is the content type of NewCBORFormat.
+	ContentTypeCBOR = "application/cbor"
+	// ContentTypeProtoStruct is the content type of
+	// NewProtoStructFormat.
+	ContentTypeProtoStruct = "application/protobuf; proto=google.protobuf.Struct"
+)
+
+// Format serializes arbitrary Go values. Implementations must be safe
+// for concurrent use by multiple goroutines.
+type Format interface {
+	// ContentType returns the MIME-ish identifier of the format, used to
+	// stamp Headers.ContentType on encode.
+	ContentType() string
+	// Marshal encodes v. Failures are returned wrapped in
+	// ErrCodec(ErrEncode, ...).
+	Marshal(v any) ([]byte, error)
+	// Unmarshal decodes data into v, which must be a non-nil pointer.
+	// Failures are returned wrapped in ErrCodec(ErrDecode, ...).
+	Unmarshal(data []byte, v any) error
+}
+
+// Codec converts Message[T] envelopes to and from bytes using a single
+// Format. Implementations must be safe for concurrent use by multiple
+// goroutines.
+type Codec[T any] interface {
+	// ContentType returns the content type of the underlying Format.
+	ContentType() string
+	// Encode serializes msg, Headers included. An empty
+	// Headers.ContentType is stamped with ContentType().
+	Encode(msg messaging.Message[T]) ([]byte, error)
+	// Decode deserializes an envelope previously produced by Encode
+	// (or by any producer using the same Format and data model).
+	Decode(data []byte) (messaging.Message[T], error)
+}
+
+// Registry maps Headers.Type discriminators to payload types so that
+// envelopes can be decoded without knowing T up front. Implementations
+// must be safe for concurrent use by multiple goroutines.
+type Registry interface {
+	// ContentType returns the content type of the underlying Format.
+	ContentType() string
+	// Register binds messageType to factory. It returns an error
+	// wrapping ErrTypeEmpty for an empty messageType, ErrFactoryNil
+	// for a nil factory and ErrTypeRegistered when messageType is
+	// already bound.
+	Register(messageType string, factory PayloadFactory) error
+	// Encode serializes msg. Headers.Type must be registered so the
+	// envelope can be decoded again; otherwise the error wraps
+	// ErrTypeEmpty or ErrTypeUnknown.
+	Encode(msg messaging.Message[any]) ([]byte, error)
+	// Decode reads Headers.Type from data, decodes the payload into the
+	// registered type and returns it as a Message[any] whose Payload
+	// holds a value (not a pointer) of that type. It returns an error
+	// wrapping ErrTypeEmpty or ErrTypeUnknown when the discriminator is
+	// missing or unregistered.
+	Decode(data []byte) (messaging.Message[any], error)
+}
+
+// PayloadFactory returns a non-nil pointer to a fresh zero payload; the
+// Registry decodes into it. Use Factory[T] to build one.
+type PayloadFactory func() any
+
+// ErrCodecFn is the function type for ErrCodec.
+type ErrCodecFn func(causes ...error) error
+
+// NewCodecFn is the function type for NewCodec.
+type NewCodecFn[T any] func(format Format) Codec[T]
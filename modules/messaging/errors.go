@@ -20,6 +20,8 @@ var (
 	_ ErrSendFn      = ErrSend
 	_ ErrSubscribeFn = ErrSubscribe
 	_ ErrReceiveFn   = ErrReceive
+	_ ErrLeaseFn     = ErrLease
+	_ ErrRegistryFn  = ErrRegistry
 )
 
 // ErrSendFn is the function type for ErrSend.
@@ -31,6 +33,12 @@ type ErrSubscribeFn func(causes ...error) error
 // ErrReceiveFn is the function type for ErrReceive.
 type ErrReceiveFn func(causes ...error) error
 
+// ErrLeaseFn is the function type for ErrLease.
+type ErrLeaseFn func(causes ...error) error
+
+// ErrRegistryFn is the function type for ErrRegistry.
+type ErrRegistryFn func(causes ...error) error
+
 // Sentinel errors for messaging operations.
 var (
 	// ErrSendFailed indicates that a Send operation failed.
@@ -55,6 +63,10 @@ var (
 	// during dispatch. The recovered value is embedded in the
 	// resulting StepResult.Err via this sentinel.
 	ErrHandlerPanic = errors.New("handler panicked")
+	// ErrLoadBalancerPanic indicates that a QueueChannel LoadBalancer
+	// (or the key function of StickyByKey) panicked while selecting a
+	// subscriber. The message went to the first candidate instead.
+	ErrLoadBalancerPanic = errors.New("load balancer panicked")
 	// ErrChainFailed indicates that a PipelineChannel send aborted
 	// because at least one step returned an error. The full step trace
 	// is available on the returned *ChainError.
@@ -90,6 +102,70 @@ var (
 	// covers Send/Subscribe on a stopped Channel[T]) to make the
 	// pollable consumer's drain-then-exit loop unambiguous.
 	ErrChannelClosed = errors.New("channel closed for receive")
+	// ErrIntercepted indicates that a ChannelInterceptor vetoed a
+	// message: returned from Send (wrapped in ErrSend) when PreSend
+	// fails, and reported as the handler error when PreHandle fails.
+	ErrIntercepted = errors.New("message rejected by interceptor")
+	// ErrLeaseFailed indicates that an Ack, Nack or Extend on a Lease
+	// failed. Wrapped by the ErrLease(...) factory.
+	ErrLeaseFailed = errors.New("lease operation failed")
+	// ErrLeaseLost indicates that the lease was already settled — by
+	// Ack, Nack or its deadline passing — and the message may now be
+	// leased by another consumer.
+	ErrLeaseLost = errors.New("lease no longer held")
+	// ErrLeaseExtension indicates a non-positive Lease.Extend.
+	ErrLeaseExtension = errors.New("lease extension must be positive")
+	// ErrMaxDeliveries indicates a leased message was dead-lettered
+	// because it had already been delivered WithMaxDeliveries times.
+	ErrMaxDeliveries = errors.New("max deliveries exceeded")
+	// ErrLogIO indicates that the durable queue's write-ahead log
+	// failed to read, write or sync its files.
+	ErrLogIO = errors.New("durable log i/o failed")
+	// ErrLogCorrupted indicates that recovery found a damaged record
+	// outside the tail of the write-ahead log (torn tail writes are
+	// truncated silently; anything else needs operator attention).
+	ErrLogCorrupted = errors.New("durable log corrupted")
+	// ErrRecordTooLarge indicates that an encoded message exceeds the
+	// largest record the durable queue's write-ahead log can frame.
+	ErrRecordTooLarge = errors.New("durable log record too large")
+	// ErrEncode indicates that the durable queue could not serialise
+	// a message before appending it to the write-ahead log.
+	ErrEncode = errors.New("message encoding failed")
+	// ErrExpired indicates a message was dequeued after its
+	// Headers.ExpirationTime and discarded instead of dispatched.
+	// Joined with ErrDropped in the hook payload; used alone as the
+	// DeadLetter.LastError when the message is routed to the DLQ.
+	ErrExpired = errors.New("message expired")
+	// ErrRedeliveryFailed indicates a message scheduled for redelivery
+	// could not be handed back to its consumer (buffer full, channel
+	// stopped or subscriber cancelled) and was dead-lettered before
+	// its attempts were exhausted.
+	ErrRedeliveryFailed = errors.New("redelivery failed")
+	// ErrRegistryFailed indicates that a ChannelRegistry operation
+	// failed. Wrapped by the ErrRegistry(...) factory.
+	ErrRegistryFailed = errors.New("channel registry failed")
+	// ErrChannelNameEmpty indicates that an empty name was passed to a
+	// ChannelRegistry.
+	ErrChannelNameEmpty = errors.New("channel name is empty")
+	// ErrChannelNil indicates that a nil value was passed to
+	// ChannelRegistry.Register.
+	ErrChannelNil = errors.New("channel is nil")
+	// ErrChannelType indicates that a value that is not a Channel[T]
+	// was passed to ChannelRegistry.Register.
+	ErrChannelType = errors.New("value is not a channel")
+	// ErrChannelRegistered indicates that the name passed to
+	// ChannelRegistry.Register is already bound.
+	ErrChannelRegistered = errors.New("channel name already registered")
+	// ErrChannelNotFound indicates that no channel is registered under
+	// the requested name.
+	ErrChannelNotFound = errors.New("channel not found")
+	// ErrChannelTypeMismatch indicates that the channel registered
+	// under a name does not carry the payload type requested by
+	// ResolveChannel.
+	ErrChannelTypeMismatch = errors.New("channel payload type mismatch")
+	// ErrReplyToEmpty indicates that Reply was invoked for a request
+	// without Headers.ReplyTo.
+	ErrReplyToEmpty = errors.New("request has no reply-to")
 )
 
 // StepStatus classifies the outcome of a single pipeline step in a
@@ -233,3 +309,25 @@ func ErrReceive(causes ...error) error {
 		},
 	}
 }
+
+// ErrLease wraps the given causes into a domain Error for Lease
+// failures.
+func ErrLease(causes ...error) error {
+	return &Error{
+		TypedError: cerrs.TypedError{
+			Type: MessagingType,
+			Err:  errors.Join(append(causes, ErrLeaseFailed)...),
+		},
+	}
+}
+
+// ErrRegistry wraps the given causes into a domain Error for
+// ChannelRegistry failures.
+func ErrRegistry(causes ...error) error {
+	return &Error{
+		TypedError: cerrs.TypedError{
+			Type: MessagingType,
+			Err:  errors.Join(append(causes, ErrRegistryFailed)...),
+		},
+	}
+}
@@ -75,3 +75,47 @@ func TestError_Error(t *testing.T) {
 		}
 	})
 }
+
+func TestErrLease(t *testing.T) {
+	t.Parallel()
+
+	t.Run("wraps ErrLeaseFailed", func(t *testing.T) {
+		t.Parallel()
+
+		err := ErrLease(ErrLeaseLost)
+		if !errors.Is(err, ErrLeaseFailed) {
+			t.Fatalf("expected error to wrap ErrLeaseFailed, got %v", err)
+		}
+	})
+
+	t.Run("preserves cause", func(t *testing.T) {
+		t.Parallel()
+
+		err := ErrLease(ErrLeaseLost)
+		if !errors.Is(err, ErrLeaseLost) {
+			t.Fatalf("expected error to wrap ErrLeaseLost, got %v", err)
+		}
+	})
+}
+
+func TestErrRegistry(t *testing.T) {
+	t.Parallel()
+
+	t.Run("wraps ErrRegistryFailed", func(t *testing.T) {
+		t.Parallel()
+
+		err := ErrRegistry(ErrChannelNotFound)
+		if !errors.Is(err, ErrRegistryFailed) {
+			t.Fatalf("expected error to wrap ErrRegistryFailed, got %v", err)
+		}
+	})
+
+	t.Run("preserves cause", func(t *testing.T) {
+		t.Parallel()
+
+		err := ErrRegistry(ErrChannelNotFound)
+		if !errors.Is(err, ErrChannelNotFound) {
+			t.Fatalf("expected error to wrap ErrChannelNotFound, got %v", err)
+		}
+	})
+}
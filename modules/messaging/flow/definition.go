@@ -0,0 +1,130 @@
+package flow
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+)
+
+// Definition is the declarative description of a flow. Field names
+// follow the json tags in every serialized form.
+type Definition struct {
+	// Name identifies the flow in lifecycle logs. Required.
+	Name string `json:"name"`
+	// Channels declares the flow-owned channels.
+	Channels []ChannelDefinition `json:"channels,omitempty"`
+	// Nodes declares the pattern nodes wired between channels.
+	Nodes []NodeDefinition `json:"nodes,omitempty"`
+}
+
+// ChannelDefinition declares a flow-owned channel.
+type ChannelDefinition struct {
+	// Name identifies the channel; nodes reference it by name.
+	// Required and unique across declared and bound channels.
+	Name string `json:"name"`
+	// Type is one of the Channel* constants; empty means pipeline.
+	Type string `json:"type,omitempty"`
+	// BufferSize is the queue or topic buffer capacity.
+	BufferSize int `json:"bufferSize,omitempty"`
+	// Workers is the queue worker count.
+	Workers int `json:"workers,omitempty"`
+	// Overflow is one of the Overflow* constants (queue or topic).
+	Overflow string `json:"overflow,omitempty"`
+	// DrainTimeout bounds the queue or topic drain on Stop.
+	DrainTimeout Duration `json:"drainTimeout,omitempty"`
+}
+
+// NodeDefinition declares a pattern node. Which fields apply depends
+// on Type; see the package documentation.
+type NodeDefinition struct {
+	// Name identifies the node in lifecycle logs. Required and unique.
+	Name string `json:"name"`
+	// Type is one of the Node* constants. Required.
+	Type string `json:"type"`
+	// From is the channel the node reads. Required.
+	From string `json:"from"`
+	// To is the destination channel of every node type but router and
+	// recipientlist.
+	To string `json:"to,omitempty"`
+	// Expression is the filter predicate, the router key, the splitter
+	// list or the transformer payload.
+	Expression string `json:"expression,omitempty"`
+	// Routes maps router keys to channels.
+	Routes map[string]string `json:"routes,omitempty"`
+	// Default is the router channel for keys without a route.
+	Default string `json:"default,omitempty"`
+	// Recipients lists the recipientlist destinations.
+	Recipients []RecipientDefinition `json:"recipients,omitempty"`
+	// Tap is the wiretap side channel.
+	Tap string `json:"tap,omitempty"`
+	// Correlation is the aggregator correlation-key expression.
+	Correlation string `json:"correlation,omitempty"`
+	// Completion is the aggregator completion expression.
+	Completion string `json:"completion,omitempty"`
+	// CompletionSize releases an aggregator group at that many
+	// messages.
+	CompletionSize int `json:"completionSize,omitempty"`
+	// GroupTimeout releases an aggregator group idle that long since
+	// its last message.
+	GroupTimeout Duration `json:"groupTimeout,omitempty"`
+}
+
+// RecipientDefinition is one recipientlist destination: the message is
+// sent to To when When evaluates to true.
+type RecipientDefinition struct {
+	// To is the destination channel. Required.
+	To string `json:"to"`
+	// When is the predicate expression. Required.
+	When string `json:"when"`
+}
+
+// Duration is a time.Duration that serializes as a Go duration string
+// ("250ms", "5s", "1m30s").
+type Duration time.Duration
+
+// UnmarshalText parses a Go duration string.
+func (d *Duration) UnmarshalText(text []byte) error {
+	cassert.NotNil(d, "duration is nil")
+
+	parsed, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+
+	*d = Duration(parsed)
+
+	return nil
+}
+
+// MarshalText formats the duration as a Go duration string.
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(d).String()), nil
+}
+
+// DecodeJSON is the default DecodeFn: json.Unmarshal that also
+// rejects unknown fields, so a misspelled key in a definition fails the
+// load instead of being silently ignored.
+func DecodeJSON(data []byte, v any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	return decoder.Decode(v)
+}
+
+// Load decodes a Definition from data with the configured decoder
+// (WithDecoder, DecodeJSON by default) and builds it with NewFlow.
+// Decoding failures return ErrFlow(ErrDecode, ...).
+func Load(data []byte, opts ...Option) (Flow, error) {
+	options := NewOptions(opts...)
+
+	var definition Definition
+
+	err := options.decoder(data, &definition)
+	if err != nil {
+		return nil, ErrFlow(ErrDecode, err)
+	}
+
+	return NewFlow(definition, opts...)
+}
@@ -0,0 +1,134 @@
+package flow
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDuration(t *testing.T) {
+	t.Parallel()
+
+	t.Run("round-trips a Go duration string", func(t *testing.T) {
+		t.Parallel()
+
+		var d Duration
+
+		err := d.UnmarshalText([]byte("1m30s"))
+		if err != nil {
+			t.Fatalf("UnmarshalText: %v", err)
+		}
+
+		if time.Duration(d) != 90*time.Second {
+			t.Fatalf("duration = %v, want 1m30s", time.Duration(d))
+		}
+
+		text, err := d.MarshalText()
+		if err != nil || string(text) != "1m30s" {
+			t.Fatalf("MarshalText = %q, %v; want 1m30s", text, err)
+		}
+	})
+
+	t.Run("rejects invalid strings", func(t *testing.T) {
+		t.Parallel()
+
+		var d Duration
+
+		err := d.UnmarshalText([]byte("soon"))
+		if err == nil {
+			t.Fatal("expected error")
+		}
+	})
+
+	t.Run("decodes from JSON strings", func(t *testing.T) {
+		t.Parallel()
+
+		var def NodeDefinition
+
+		err := json.Unmarshal([]byte(`{"groupTimeout": "250ms"}`), &def)
+		if err != nil {
+			t.Fatalf("Unmarshal: %v", err)
+		}
+
+		if time.Duration(def.GroupTimeout) != 250*time.Millisecond {
+			t.Fatalf("groupTimeout = %v, want 250ms", time.Duration(def.GroupTimeout))
+		}
+	})
+}
+
+func TestDecodeJSON(t *testing.T) {
+	t.Parallel()
+
+	t.Run("decodes a definition", func(t *testing.T) {
+		t.Parallel()
+
+		var def Definition
+
+		err := DecodeJSON([]byte(`{"name": "f", "channels": [{"name": "a", "type": "queue"}], "nodes": [{"name": "n", "type": "bridge", "from": "a", "to": "b"}]}`), &def)
+		if err != nil {
+			t.Fatalf("DecodeJSON: %v", err)
+		}
+
+		if def.Name != "f" || len(def.Channels) != 1 || def.Channels[0].Type != ChannelQueue || len(def.Nodes) != 1 || def.Nodes[0].To != "b" {
+			t.Fatalf("unexpected definition %+v", def)
+		}
+	})
+
+	t.Run("rejects unknown fields", func(t *testing.T) {
+		t.Parallel()
+
+		var def Definition
+
+		err := DecodeJSON([]byte(`{"name": "f", "nodes": [{"name": "n", "type": "filter", "form": "a"}]}`), &def)
+		if err == nil {
+			t.Fatal("expected error for misspelled field")
+		}
+	})
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	t.Run("malformed data returns ErrDecode", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Load([]byte(`{"name": `))
+		if !errors.Is(err, ErrDecode) || !errors.Is(err, ErrFlowFailed) {
+			t.Fatalf("expected ErrDecode wrapped in ErrFlowFailed, got %v", err)
+		}
+	})
+
+	t.Run("invalid definitions are reported by NewFlow", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Load([]byte(`{"channels": []}`))
+		if !errors.Is(err, ErrInvalidDefinition) {
+			t.Fatalf("expected ErrInvalidDefinition, got %v", err)
+		}
+	})
+
+	t.Run("WithDecoder replaces JSON", func(t *testing.T) {
+		t.Parallel()
+
+		decoder := func(_ []byte, v any) error {
+			def, ok := v.(*Definition)
+			if !ok {
+				return errors.New("unexpected target")
+			}
+
+			def.Name = "decoded"
+
+			return nil
+		}
+
+		f, err := Load([]byte("not json"), WithDecoder(decoder))
+		if err != nil {
+			t.Fatalf("Load: %v", err)
+		}
+
+		if f.Name() != "decoded" {
+			t.Fatalf("Name = %q, want decoded", f.Name())
+		}
+	})
+}
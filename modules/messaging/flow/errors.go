@@ -0,0 +1,66 @@
+package flow
+
+import (
+	"errors"
+	"fmt"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+	cerrs "github.com/guidomantilla/yarumo/core/common/errs"
+)
+
+// FlowType is the error domain identifier for flow operations.
+const FlowType = "messaging-flow"
+
+var (
+	_ error = (*Error)(nil)
+)
+
+// Sentinel errors for flow failure modes.
+var (
+	// ErrFlowFailed is the top-level sentinel embedded in every
+	// flow-domain Error returned by ErrFlow.
+	ErrFlowFailed = errors.New("flow failed")
+	// ErrDecode indicates that a serialized Definition could not be
+	// decoded.
+	ErrDecode = errors.New("definition decoding failed")
+	// ErrInvalidDefinition indicates that a Definition is malformed: a
+	// missing or duplicated name, an unknown channel or node type, a
+	// missing field the node type requires, or an invalid option.
+	ErrInvalidDefinition = errors.New("invalid flow definition")
+	// ErrUnknownChannel indicates that a node or a Flow.Channel call
+	// referenced a channel that is neither declared nor bound with
+	// WithChannel.
+	ErrUnknownChannel = errors.New("unknown channel")
+	// ErrCycle indicates that the node graph has a cycle, so it has no
+	// start order.
+	ErrCycle = errors.New("flow graph has a cycle")
+	// ErrExpression indicates that an expression failed to parse when
+	// the flow was built, failed to evaluate, or evaluated to a value
+	// of the wrong type for its node.
+	ErrExpression = errors.New("expression failed")
+)
+
+// Error is the domain error type for flow operations.
+type Error struct {
+	cerrs.TypedError
+}
+
+// Error returns the formatted error string including the type
+// classification.
+func (e *Error) Error() string {
+	cassert.NotNil(e, "error is nil")
+	cassert.NotNil(e.Err, "internal error is nil")
+
+	return fmt.Sprintf("flow %s error: %s", e.Type, e.Err)
+}
+
+// ErrFlow wraps the given causes into a domain Error joined with
+// ErrFlowFailed.
+func ErrFlow(causes ...error) error {
+	return &Error{
+		TypedError: cerrs.TypedError{
+			Type: FlowType,
+			Err:  errors.Join(append(causes, ErrFlowFailed)...),
+		},
+	}
+}
@@ -0,0 +1,49 @@
+package flow
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestError_Error(t *testing.T) {
+	t.Parallel()
+
+	t.Run("includes type prefix and joined causes", func(t *testing.T) {
+		t.Parallel()
+
+		err := ErrFlow(ErrCycle)
+
+		msg := err.Error()
+		if !strings.HasPrefix(msg, "flow "+FlowType) {
+			t.Fatalf("expected prefix %q, got %q", "flow "+FlowType, msg)
+		}
+
+		if !strings.Contains(msg, ErrCycle.Error()) {
+			t.Fatalf("expected cause %q in message, got %q", ErrCycle.Error(), msg)
+		}
+
+		if !strings.Contains(msg, ErrFlowFailed.Error()) {
+			t.Fatalf("expected sentinel %q in message, got %q", ErrFlowFailed.Error(), msg)
+		}
+	})
+
+	t.Run("ErrFlow joins all causes with ErrFlowFailed", func(t *testing.T) {
+		t.Parallel()
+
+		boom := errors.New("custom failure")
+
+		err := ErrFlow(ErrCycle, boom)
+		if !errors.Is(err, ErrFlowFailed) {
+			t.Fatal("expected ErrFlowFailed in chain")
+		}
+
+		if !errors.Is(err, ErrCycle) {
+			t.Fatal("expected ErrCycle in chain")
+		}
+
+		if !errors.Is(err, boom) {
+			t.Fatal("expected origin error in chain")
+		}
+	})
+}
@@ -0,0 +1,407 @@
+package flow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+	"github.com/guidomantilla/yarumo/core/common/lifecycle"
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+// flow is the Flow implementation. components holds the flow-owned
+// lifecycle-bearing channels followed by the nodes, in start order;
+// stops holds the same components' indexes in stop order. started
+// records how many of them Start brought up so Stop only stops those.
+type flow struct {
+	name       string
+	channels   map[string]messaging.Channel[any]
+	components []lifecycle.Component
+	stops      []int
+
+	done      chan struct{}
+	startOnce sync.Once
+	stopOnce  sync.Once
+	doneOnce  sync.Once
+
+	mu      sync.Mutex
+	started int
+}
+
+// NewFlow validates definition and builds its channels and nodes. The
+// flow is not running on return; call lifecycle.Build (or Start
+// directly) to start the graph.
+//
+// Invalid definitions return ErrFlow(ErrInvalidDefinition, ...),
+// references to undeclared channels ErrFlow(ErrUnknownChannel, ...),
+// unparsable expressions ErrFlow(ErrExpression, ...) and cyclic graphs
+// ErrFlow(ErrCycle, ...). Optional behaviors:
+//
+//   - WithChannel binds application-owned channels by name.
+//   - WithFunc registers extra expression functions.
+//   - WithErrorHandler overrides the error handler of every node and
+//     queue or topic channel.
+func NewFlow(definition Definition, opts ...Option) (Flow, error) {
+	if definition.Name == "" {
+		return nil, ErrFlow(ErrInvalidDefinition, errors.New("flow name is empty"))
+	}
+
+	options := NewOptions(opts...)
+
+	f := &flow{
+		name:     definition.Name,
+		channels: make(map[string]messaging.Channel[any], len(options.channels)+len(definition.Channels)),
+		done:     make(chan struct{}),
+	}
+
+	for name, channel := range options.channels {
+		f.channels[name] = channel
+	}
+
+	owned := make(map[string]int, len(definition.Channels))
+
+	for _, def := range definition.Channels {
+		channel, err := f.declare(def, options)
+		if err != nil {
+			return nil, err
+		}
+
+		component, ok := channel.(lifecycle.Component)
+		if ok {
+			owned[def.Name] = len(f.components)
+			f.components = append(f.components, component)
+		}
+	}
+
+	order, err := startOrder(definition.Nodes)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &builder{options: options, channels: f.channels}
+
+	for _, i := range order {
+		node, err := b.node(definition.Nodes[i])
+		if err != nil {
+			return nil, err
+		}
+
+		f.components = append(f.components, node)
+	}
+
+	f.stops = stopOrder(definition.Nodes, order, owned)
+
+	return f, nil
+}
+
+// Name returns the flow's identity used in lifecycle logs.
+func (f *flow) Name() string {
+	cassert.NotNil(f, "flow is nil")
+
+	return f.name
+}
+
+// Start starts the flow-owned channels, then the nodes from the sinks
+// back to the sources. It satisfies the lifecycle.Component
+// worker-style contract: every channel and node is worker-style, so
+// Start returns once the whole graph is running. When a component
+// fails to start, the ones already started are stopped in reverse and
+// lifecycle.ErrStart wrapping the failure is returned. Start is
+// idempotent — a second invocation returns nil.
+func (f *flow) Start(ctx context.Context) error {
+	cassert.NotNil(f, "flow is nil")
+
+	var startErr error
+
+	f.startOnce.Do(func() {
+		for _, component := range f.components {
+			err := component.Start(ctx)
+			if err != nil {
+				startErr = lifecycle.ErrStart(fmt.Errorf("%s: %w", component.Name(), err))
+
+				break
+			}
+
+			f.mu.Lock()
+			f.started++
+			f.mu.Unlock()
+		}
+
+		if startErr != nil {
+			_ = f.Stop(ctx)
+		}
+	})
+
+	return startErr
+}
+
+// Stop stops the started nodes from the sources onwards, stopping
+// each flow-owned channel right before the first node reading from
+// it, so the channel drains its buffer to subscribers that are still
+// in place. The channels no node reads from stop last, then Done is
+// closed. Channels bound with WithChannel are left running. Stop is
+// idempotent per the lifecycle.Component contract. It returns the
+// joined errors of the components that failed to stop, each already
+// wrapped in lifecycle.ErrShutdown by the component; otherwise nil.
+func (f *flow) Stop(ctx context.Context) error {
+	cassert.NotNil(f, "flow is nil")
+
+	var errs []error
+
+	f.stopOnce.Do(func() {
+		f.mu.Lock()
+		started := f.started
+		f.mu.Unlock()
+
+		for _, i := range f.stops {
+			if i >= started {
+				continue
+			}
+
+			err := f.components[i].Stop(ctx)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", f.components[i].Name(), err))
+			}
+		}
+
+		f.doneOnce.Do(func() { close(f.done) })
+	})
+
+	return errors.Join(errs...)
+}
+
+// Done returns the channel that is closed after Stop has been called.
+func (f *flow) Done() <-chan struct{} {
+	cassert.NotNil(f, "flow is nil")
+
+	return f.done
+}
+
+// Channel returns the declared or bound channel named name.
+func (f *flow) Channel(name string) (messaging.Channel[any], error) {
+	cassert.NotNil(f, "flow is nil")
+
+	channel, ok := f.channels[name]
+	if !ok {
+		return nil, ErrFlow(ErrUnknownChannel, fmt.Errorf("channel %q", name))
+	}
+
+	return channel, nil
+}
+
+// declare validates def, builds the channel and registers it under its
+// name.
+func (f *flow) declare(def ChannelDefinition, options *Options) (messaging.Channel[any], error) {
+	if def.Name == "" {
+		return nil, ErrFlow(ErrInvalidDefinition, errors.New("channel name is empty"))
+	}
+
+	_, exists := f.channels[def.Name]
+	if exists {
+		return nil, ErrFlow(ErrInvalidDefinition, fmt.Errorf("channel %q is declared twice", def.Name))
+	}
+
+	channel, err := newChannel(def, options)
+	if err != nil {
+		return nil, err
+	}
+
+	f.channels[def.Name] = channel
+
+	return channel, nil
+}
+
+// newChannel builds the channel def declares.
+func newChannel(def ChannelDefinition, options *Options) (messaging.Channel[any], error) {
+	async := def.Type == ChannelQueue || def.Type == ChannelTopic
+	tuned := def.BufferSize != 0 || def.Workers != 0 || def.Overflow != "" || def.DrainTimeout != 0
+
+	if tuned && !async {
+		return nil, ErrFlow(ErrInvalidDefinition, fmt.Errorf("channel %q: %s channels take no buffer options", def.Name, channelType(def)))
+	}
+
+	if def.Workers != 0 && def.Type != ChannelQueue {
+		return nil, ErrFlow(ErrInvalidDefinition, fmt.Errorf("channel %q: workers apply to queue channels only", def.Name))
+	}
+
+	if def.BufferSize < 0 || def.Workers < 0 || def.DrainTimeout < 0 {
+		return nil, ErrFlow(ErrInvalidDefinition, fmt.Errorf("channel %q: negative buffer option", def.Name))
+	}
+
+	opts := []messaging.Option{
+		messaging.WithBufferSize(def.BufferSize),
+		messaging.WithWorkerCount(def.Workers),
+		messaging.WithDrainTimeout(time.Duration(def.DrainTimeout)),
+		messaging.WithErrorHandler(options.errorHandler),
+	}
+
+	if def.Overflow != "" {
+		policy, ok := overflowPolicies[def.Overflow]
+		if !ok {
+			return nil, ErrFlow(ErrInvalidDefinition, fmt.Errorf("channel %q: unknown overflow %q", def.Name, def.Overflow))
+		}
+
+		opts = append(opts, messaging.WithOverflowPolicy(policy))
+	}
+
+	switch channelType(def) {
+	case ChannelPipeline:
+		return messaging.NewPipelineChannel[any](), nil
+	case ChannelBroadcast:
+		return messaging.NewBroadcastChannel[any](), nil
+	case ChannelQueue:
+		return messaging.NewQueueChannel[any](def.Name, opts...), nil
+	case ChannelTopic:
+		return messaging.NewTopicChannel[any](def.Name, opts...), nil
+	case ChannelNull:
+		return messaging.NewNullChannel[any](messaging.WithErrorHandler(options.errorHandler)), nil
+	default:
+		return nil, ErrFlow(ErrInvalidDefinition, fmt.Errorf("channel %q: unknown type %q", def.Name, def.Type))
+	}
+}
+
+// overflowPolicies maps the Overflow* names to their policies.
+var overflowPolicies = map[string]messaging.OverflowPolicy{
+	OverflowBlock:      messaging.OverflowBlock,
+	OverflowDropNewest: messaging.OverflowDropNewest,
+	OverflowDropOldest: messaging.OverflowDropOldest,
+	OverflowReject:     messaging.OverflowReject,
+}
+
+// channelType returns def's type, defaulting to pipeline.
+func channelType(def ChannelDefinition) string {
+	if def.Type == "" {
+		return ChannelPipeline
+	}
+
+	return def.Type
+}
+
+// startOrder validates the node names and returns the node indexes in
+// start order: every node after all the nodes it sends to, so
+// subscribers are in place before their producers. Ties keep
+// declaration order. A cycle returns ErrFlow(ErrCycle, ...).
+func startOrder(nodes []NodeDefinition) ([]int, error) {
+	names := make(map[string]bool, len(nodes))
+	readers := make(map[string][]int, len(nodes))
+
+	for i, node := range nodes {
+		if node.Name == "" {
+			return nil, ErrFlow(ErrInvalidDefinition, fmt.Errorf("node %d: name is empty", i))
+		}
+
+		if names[node.Name] {
+			return nil, ErrFlow(ErrInvalidDefinition, fmt.Errorf("node %q is declared twice", node.Name))
+		}
+
+		names[node.Name] = true
+		readers[node.From] = append(readers[node.From], i)
+	}
+
+	// pending[i] counts the edges from node i to nodes not yet placed.
+	pending := make([]int, len(nodes))
+	writers := make([][]int, len(nodes))
+
+	for i, node := range nodes {
+		for _, output := range outputs(node) {
+			for _, j := range readers[output] {
+				pending[i]++
+				writers[j] = append(writers[j], i)
+			}
+		}
+	}
+
+	order := make([]int, 0, len(nodes))
+	placed := make([]bool, len(nodes))
+
+	for len(order) < len(nodes) {
+		next := -1
+
+		for i := range nodes {
+			if !placed[i] && pending[i] == 0 {
+				next = i
+
+				break
+			}
+		}
+
+		if next < 0 {
+			return nil, ErrFlow(ErrCycle, fmt.Errorf("nodes %v", unplaced(nodes, placed)))
+		}
+
+		placed[next] = true
+		order = append(order, next)
+
+		for _, i := range writers[next] {
+			pending[i]--
+		}
+	}
+
+	return order, nil
+}
+
+// stopOrder returns the component indexes in stop order: the nodes in
+// reverse start order, each preceded by the flow-owned channel it reads
+// from when that channel has not been placed yet, then the remaining
+// channels in reverse declaration order. owned maps the flow-owned
+// channel names to their component indexes; the node components follow
+// them in start order.
+func stopOrder(nodes []NodeDefinition, order []int, owned map[string]int) []int {
+	result := make([]int, 0, len(owned)+len(order))
+	placed := make(map[int]bool, len(owned))
+
+	for k := len(order) - 1; k >= 0; k-- {
+		i, ok := owned[nodes[order[k]].From]
+		if ok && !placed[i] {
+			placed[i] = true
+			result = append(result, i)
+		}
+
+		result = append(result, len(owned)+k)
+	}
+
+	for i := len(owned) - 1; i >= 0; i-- {
+		if !placed[i] {
+			result = append(result, i)
+		}
+	}
+
+	return result
+}
+
+// outputs returns every channel node sends to.
+func outputs(node NodeDefinition) []string {
+	var result []string
+
+	for _, name := range []string{node.To, node.Default, node.Tap} {
+		if name != "" {
+			result = append(result, name)
+		}
+	}
+
+	for _, name := range node.Routes {
+		result = append(result, name)
+	}
+
+	for _, recipient := range node.Recipients {
+		result = append(result, recipient.To)
+	}
+
+	return result
+}
+
+// unplaced returns the names of the nodes left out of the start order.
+func unplaced(nodes []NodeDefinition, placed []bool) []string {
+	var result []string
+
+	for i, node := range nodes {
+		if !placed[i] {
+			result = append(result, node.Name)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,677 @@
+package flow
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/guidomantilla/yarumo/core/common/expressions"
+	lctests "github.com/guidomantilla/yarumo/core/common/lifecycle/tests"
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+// captureErrors returns a thread-safe ErrorHandler that appends every
+// reported error to the returned slice (via mutex), and a getter that
+// returns a defensive copy.
+func captureErrors() (messaging.ErrorHandler, func() []error) {
+	var mu sync.Mutex
+
+	captured := []error{}
+
+	handler := func(_ context.Context, _ any, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		captured = append(captured, err)
+	}
+
+	get := func() []error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		out := make([]error, len(captured))
+		copy(out, captured)
+
+		return out
+	}
+
+	return handler, get
+}
+
+// collect subscribes to channel name of f and returns a thread-safe
+// getter for the received payloads.
+func collect(t *testing.T, f Flow, name string) func() []any {
+	t.Helper()
+
+	channel, err := f.Channel(name)
+	if err != nil {
+		t.Fatalf("Channel(%q): %v", name, err)
+	}
+
+	var mu sync.Mutex
+
+	captured := []any{}
+
+	_, err = channel.Subscribe(func(_ context.Context, msg messaging.Message[any]) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		captured = append(captured, msg.Payload)
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Subscribe(%q): %v", name, err)
+	}
+
+	return func() []any {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return slices.Clone(captured)
+	}
+}
+
+// waitFor polls cond until it holds or the deadline passes.
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met before deadline")
+		}
+
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// start builds definition, starts it and stops it on cleanup.
+func start(t *testing.T, definition Definition, opts ...Option) Flow {
+	t.Helper()
+
+	f, err := NewFlow(definition, opts...)
+	if err != nil {
+		t.Fatalf("NewFlow: %v", err)
+	}
+
+	err = f.Start(context.Background())
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	t.Cleanup(func() { _ = f.Stop(context.Background()) })
+
+	return f
+}
+
+// send sends payload with headers to channel name of f.
+func send(t *testing.T, f Flow, name string, payload any, headers messaging.Headers) {
+	t.Helper()
+
+	channel, err := f.Channel(name)
+	if err != nil {
+		t.Fatalf("Channel(%q): %v", name, err)
+	}
+
+	err = channel.Send(context.Background(), messaging.Message[any]{Payload: payload, Headers: headers})
+	if err != nil {
+		t.Fatalf("Send(%q): %v", name, err)
+	}
+}
+
+// pipelines declares a pipeline channel per name.
+func pipelines(names ...string) []ChannelDefinition {
+	defs := make([]ChannelDefinition, len(names))
+	for i, name := range names {
+		defs[i] = ChannelDefinition{Name: name}
+	}
+
+	return defs
+}
+
+func TestLoad_EndToEnd(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(`{
+		"name": "orders",
+		"channels": [
+			{"name": "incoming"},
+			{"name": "lines", "type": "queue", "bufferSize": 16, "workers": 2, "overflow": "block", "drainTimeout": "1s"},
+			{"name": "large"},
+			{"name": "amounts"},
+			{"name": "batches"}
+		],
+		"nodes": [
+			{"name": "batch", "type": "aggregator", "from": "amounts", "to": "batches", "completion": "size >= 2"},
+			{"name": "amount", "type": "transformer", "from": "large", "to": "amounts", "expression": "payload.amount"},
+			{"name": "large-only", "type": "filter", "from": "lines", "to": "large", "expression": "payload.amount > 100"},
+			{"name": "split", "type": "splitter", "from": "incoming", "to": "lines", "expression": "payload.lines"}
+		]
+	}`)
+
+	f, err := Load(data)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	err = f.Start(context.Background())
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	t.Cleanup(func() { _ = f.Stop(context.Background()) })
+
+	batches := collect(t, f, "batches")
+
+	send(t, f, "incoming", map[string]any{
+		"lines": []any{
+			map[string]any{"amount": 50.0},
+			map[string]any{"amount": 150.0},
+			map[string]any{"amount": 300.0},
+		},
+	}, messaging.Headers{MessageID: "o-1", CorrelationID: "o-1"})
+
+	waitFor(t, func() bool { return len(batches()) == 1 })
+
+	got, ok := batches()[0].([]any)
+	if !ok {
+		t.Fatalf("expected []any batch, got %T", batches()[0])
+	}
+
+	slices.SortFunc(got, func(a, b any) int { return int(a.(float64) - b.(float64)) })
+
+	if !reflect.DeepEqual(got, []any{150.0, 300.0}) {
+		t.Fatalf("batch = %v, want [150 300]", got)
+	}
+}
+
+func TestFlow_Nodes(t *testing.T) {
+	t.Parallel()
+
+	t.Run("bridge forwards every message", func(t *testing.T) {
+		t.Parallel()
+
+		f := start(t, Definition{
+			Name:     "bridge",
+			Channels: pipelines("in", "out"),
+			Nodes:    []NodeDefinition{{Name: "b", Type: NodeBridge, From: "in", To: "out"}},
+		})
+		out := collect(t, f, "out")
+
+		send(t, f, "in", "x", messaging.Headers{})
+
+		if got := out(); !reflect.DeepEqual(got, []any{"x"}) {
+			t.Fatalf("out = %v, want [x]", got)
+		}
+	})
+
+	t.Run("router routes by key and falls back to default", func(t *testing.T) {
+		t.Parallel()
+
+		f := start(t, Definition{
+			Name:     "router",
+			Channels: pipelines("in", "eu", "us", "other"),
+			Nodes: []NodeDefinition{{
+				Name:       "r",
+				Type:       NodeRouter,
+				From:       "in",
+				Expression: "payload.region",
+				Routes:     map[string]string{"eu": "eu", "us": "us"},
+				Default:    "other",
+			}},
+		})
+		eu, us, other := collect(t, f, "eu"), collect(t, f, "us"), collect(t, f, "other")
+
+		for _, region := range []string{"eu", "us", "apac"} {
+			send(t, f, "in", map[string]any{"region": region}, messaging.Headers{})
+		}
+
+		if len(eu()) != 1 || len(us()) != 1 || len(other()) != 1 {
+			t.Fatalf("eu=%v us=%v other=%v, want one each", eu(), us(), other())
+		}
+	})
+
+	t.Run("recipientlist sends to every matching recipient", func(t *testing.T) {
+		t.Parallel()
+
+		f := start(t, Definition{
+			Name:     "recipients",
+			Channels: pipelines("in", "audit", "billing"),
+			Nodes: []NodeDefinition{{
+				Name: "rl",
+				Type: NodeRecipientList,
+				From: "in",
+				Recipients: []RecipientDefinition{
+					{To: "audit", When: "true"},
+					{To: "billing", When: "payload.amount > 0"},
+					{To: "audit", When: "headers.custom.vip == true"},
+				},
+			}},
+		})
+		audit, billing := collect(t, f, "audit"), collect(t, f, "billing")
+
+		send(t, f, "in", map[string]any{"amount": 10.0}, messaging.Headers{Custom: map[string]any{"vip": true}})
+		send(t, f, "in", map[string]any{"amount": 0.0}, messaging.Headers{Custom: map[string]any{"vip": false}})
+
+		if len(audit()) != 3 || len(billing()) != 1 {
+			t.Fatalf("audit=%v billing=%v, want 3 and 1", audit(), billing())
+		}
+	})
+
+	t.Run("wiretap copies to the tap", func(t *testing.T) {
+		t.Parallel()
+
+		f := start(t, Definition{
+			Name:     "wiretap",
+			Channels: pipelines("in", "out", "tap"),
+			Nodes:    []NodeDefinition{{Name: "w", Type: NodeWiretap, From: "in", To: "out", Tap: "tap"}},
+		})
+		out, tap := collect(t, f, "out"), collect(t, f, "tap")
+
+		send(t, f, "in", "x", messaging.Headers{})
+
+		if len(out()) != 1 || len(tap()) != 1 {
+			t.Fatalf("out=%v tap=%v, want one each", out(), tap())
+		}
+	})
+
+	t.Run("aggregator releases by size and by timeout", func(t *testing.T) {
+		t.Parallel()
+
+		f := start(t, Definition{
+			Name:     "aggregator",
+			Channels: pipelines("in", "out"),
+			Nodes: []NodeDefinition{{
+				Name:           "a",
+				Type:           NodeAggregator,
+				From:           "in",
+				To:             "out",
+				Correlation:    "payload.order",
+				CompletionSize: 2,
+				GroupTimeout:   Duration(50 * time.Millisecond),
+			}},
+		})
+		out := collect(t, f, "out")
+
+		send(t, f, "in", map[string]any{"order": "a"}, messaging.Headers{})
+		send(t, f, "in", map[string]any{"order": "b"}, messaging.Headers{})
+		send(t, f, "in", map[string]any{"order": "a"}, messaging.Headers{})
+
+		waitFor(t, func() bool { return len(out()) == 2 })
+	})
+
+	t.Run("expressions see headers and custom functions", func(t *testing.T) {
+		t.Parallel()
+
+		double := func(args ...any) (any, error) {
+			return args[0].(float64) * 2, nil
+		}
+
+		f := start(t, Definition{
+			Name:     "headers",
+			Channels: pipelines("in", "typed", "out"),
+			Nodes: []NodeDefinition{
+				{Name: "typed", Type: NodeFilter, From: "in", To: "typed", Expression: `headers.type == "order" && headers.custom.tenant == "acme"`},
+				{Name: "double", Type: NodeTransformer, From: "typed", To: "out", Expression: "double(payload)"},
+			},
+		}, WithFunc("double", double))
+		out := collect(t, f, "out")
+
+		send(t, f, "in", 2.0, messaging.Headers{Type: "order", Custom: map[string]any{"tenant": "acme"}})
+		send(t, f, "in", 3.0, messaging.Headers{Type: "order", Custom: map[string]any{"tenant": "other"}})
+		send(t, f, "in", 4.0, messaging.Headers{Type: "refund", Custom: map[string]any{"tenant": "acme"}})
+
+		if got := out(); !reflect.DeepEqual(got, []any{4.0}) {
+			t.Fatalf("out = %v, want [4]", got)
+		}
+	})
+
+	t.Run("evaluation failures reach the error handler", func(t *testing.T) {
+		t.Parallel()
+
+		handler, errs := captureErrors()
+
+		f := start(t, Definition{
+			Name:     "failures",
+			Channels: pipelines("in", "out"),
+			Nodes:    []NodeDefinition{{Name: "f", Type: NodeFilter, From: "in", To: "out", Expression: "payload.amount"}},
+		}, WithErrorHandler(handler))
+		out := collect(t, f, "out")
+
+		send(t, f, "in", map[string]any{"amount": 1.0}, messaging.Headers{})
+		send(t, f, "in", map[string]any{}, messaging.Headers{})
+
+		if len(out()) != 0 {
+			t.Fatalf("out = %v, want nothing", out())
+		}
+
+		got := errs()
+		if len(got) != 2 || !errors.Is(got[0], ErrExpression) || !errors.Is(got[1], expressions.ErrUnknownField) {
+			t.Fatalf("errors = %v, want ErrExpression (non-bool) then ErrUnknownField", got)
+		}
+	})
+}
+
+func TestNewFlow_Invalid(t *testing.T) {
+	t.Parallel()
+
+	bound := messaging.NewPipelineChannel[any]()
+
+	// assertInvalid builds definition and asserts the failure is want
+	// wrapped in ErrFlowFailed.
+	assertInvalid := func(t *testing.T, definition Definition, want error) {
+		t.Helper()
+
+		_, err := NewFlow(definition, WithChannel("bound", bound))
+		if !errors.Is(err, want) || !errors.Is(err, ErrFlowFailed) {
+			t.Fatalf("expected %v wrapped in ErrFlowFailed, got %v", want, err)
+		}
+	}
+
+	t.Run("empty flow name", func(t *testing.T) {
+		t.Parallel()
+
+		assertInvalid(t, Definition{}, ErrInvalidDefinition)
+	})
+
+	t.Run("empty channel name", func(t *testing.T) {
+		t.Parallel()
+
+		assertInvalid(t, Definition{Name: "f", Channels: []ChannelDefinition{{}}}, ErrInvalidDefinition)
+	})
+
+	t.Run("duplicate channel", func(t *testing.T) {
+		t.Parallel()
+
+		assertInvalid(t, Definition{Name: "f", Channels: pipelines("a", "a")}, ErrInvalidDefinition)
+	})
+
+	t.Run("channel shadows a bound one", func(t *testing.T) {
+		t.Parallel()
+
+		assertInvalid(t, Definition{Name: "f", Channels: pipelines("bound")}, ErrInvalidDefinition)
+	})
+
+	t.Run("unknown channel type", func(t *testing.T) {
+		t.Parallel()
+
+		assertInvalid(t, Definition{Name: "f", Channels: []ChannelDefinition{{Name: "a", Type: "kafka"}}}, ErrInvalidDefinition)
+	})
+
+	t.Run("buffer options on a pipeline", func(t *testing.T) {
+		t.Parallel()
+
+		assertInvalid(t, Definition{Name: "f", Channels: []ChannelDefinition{{Name: "a", BufferSize: 8}}}, ErrInvalidDefinition)
+	})
+
+	t.Run("workers on a topic", func(t *testing.T) {
+		t.Parallel()
+
+		assertInvalid(t, Definition{Name: "f", Channels: []ChannelDefinition{{Name: "a", Type: ChannelTopic, Workers: 2}}}, ErrInvalidDefinition)
+	})
+
+	t.Run("negative buffer size", func(t *testing.T) {
+		t.Parallel()
+
+		assertInvalid(t, Definition{Name: "f", Channels: []ChannelDefinition{{Name: "a", Type: ChannelQueue, BufferSize: -1}}}, ErrInvalidDefinition)
+	})
+
+	t.Run("unknown overflow", func(t *testing.T) {
+		t.Parallel()
+
+		assertInvalid(t, Definition{Name: "f", Channels: []ChannelDefinition{{Name: "a", Type: ChannelQueue, Overflow: "spill"}}}, ErrInvalidDefinition)
+	})
+
+	t.Run("empty node name", func(t *testing.T) {
+		t.Parallel()
+
+		assertInvalid(t, Definition{Name: "f", Channels: pipelines("a", "b"), Nodes: []NodeDefinition{{Type: NodeBridge, From: "a", To: "b"}}}, ErrInvalidDefinition)
+	})
+
+	t.Run("duplicate node", func(t *testing.T) {
+		t.Parallel()
+
+		assertInvalid(t, Definition{Name: "f", Channels: pipelines("a", "b", "c"), Nodes: []NodeDefinition{
+			{Name: "n", Type: NodeBridge, From: "a", To: "b"},
+			{Name: "n", Type: NodeBridge, From: "a", To: "c"},
+		}}, ErrInvalidDefinition)
+	})
+
+	t.Run("unknown node type", func(t *testing.T) {
+		t.Parallel()
+
+		assertInvalid(t, Definition{Name: "f", Channels: pipelines("a"), Nodes: []NodeDefinition{{Name: "n", Type: "enricher", From: "a"}}}, ErrInvalidDefinition)
+	})
+
+	t.Run("missing from", func(t *testing.T) {
+		t.Parallel()
+
+		assertInvalid(t, Definition{Name: "f", Channels: pipelines("a"), Nodes: []NodeDefinition{{Name: "n", Type: NodeBridge, To: "a"}}}, ErrInvalidDefinition)
+	})
+
+	t.Run("missing to", func(t *testing.T) {
+		t.Parallel()
+
+		assertInvalid(t, Definition{Name: "f", Channels: pipelines("a"), Nodes: []NodeDefinition{{Name: "n", Type: NodeBridge, From: "a"}}}, ErrInvalidDefinition)
+	})
+
+	t.Run("unknown channel", func(t *testing.T) {
+		t.Parallel()
+
+		assertInvalid(t, Definition{Name: "f", Channels: pipelines("a"), Nodes: []NodeDefinition{{Name: "n", Type: NodeBridge, From: "a", To: "b"}}}, ErrUnknownChannel)
+	})
+
+	t.Run("missing expression", func(t *testing.T) {
+		t.Parallel()
+
+		assertInvalid(t, Definition{Name: "f", Channels: pipelines("a", "b"), Nodes: []NodeDefinition{{Name: "n", Type: NodeFilter, From: "a", To: "b"}}}, ErrInvalidDefinition)
+	})
+
+	t.Run("unparsable expression", func(t *testing.T) {
+		t.Parallel()
+
+		assertInvalid(t, Definition{Name: "f", Channels: pipelines("a", "b"), Nodes: []NodeDefinition{{Name: "n", Type: NodeFilter, From: "a", To: "b", Expression: "payload >"}}}, ErrExpression)
+	})
+
+	t.Run("router without routes", func(t *testing.T) {
+		t.Parallel()
+
+		assertInvalid(t, Definition{Name: "f", Channels: pipelines("a"), Nodes: []NodeDefinition{{Name: "n", Type: NodeRouter, From: "a", Expression: "payload"}}}, ErrInvalidDefinition)
+	})
+
+	t.Run("recipientlist without recipients", func(t *testing.T) {
+		t.Parallel()
+
+		assertInvalid(t, Definition{Name: "f", Channels: pipelines("a"), Nodes: []NodeDefinition{{Name: "n", Type: NodeRecipientList, From: "a"}}}, ErrInvalidDefinition)
+	})
+
+	t.Run("aggregator without completion", func(t *testing.T) {
+		t.Parallel()
+
+		assertInvalid(t, Definition{Name: "f", Channels: pipelines("a", "b"), Nodes: []NodeDefinition{{Name: "n", Type: NodeAggregator, From: "a", To: "b"}}}, ErrInvalidDefinition)
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		t.Parallel()
+
+		assertInvalid(t, Definition{Name: "f", Channels: pipelines("a", "b"), Nodes: []NodeDefinition{
+			{Name: "ab", Type: NodeBridge, From: "a", To: "b"},
+			{Name: "ba", Type: NodeBridge, From: "b", To: "a"},
+		}}, ErrCycle)
+	})
+}
+
+func TestStopOrder(t *testing.T) {
+	t.Parallel()
+
+	nodes := []NodeDefinition{
+		{Name: "first", From: "in", To: "mid"},
+		{Name: "second", From: "mid", To: "out"},
+	}
+	owned := map[string]int{"in": 0, "mid": 1, "out": 2}
+
+	// start order: second (index 3), first (index 4).
+	stops := stopOrder(nodes, []int{1, 0}, owned)
+	if !reflect.DeepEqual(stops, []int{0, 4, 1, 3, 2}) {
+		t.Fatalf("stops = %v, want [0 4 1 3 2] (each channel before its reader)", stops)
+	}
+}
+
+func TestFlow_Lifecycle(t *testing.T) {
+	t.Parallel()
+
+	t.Run("runs async channels and stops idempotently", func(t *testing.T) {
+		t.Parallel()
+
+		f, err := NewFlow(Definition{
+			Name: "async",
+			Channels: []ChannelDefinition{
+				{Name: "in", Type: ChannelQueue},
+				{Name: "out", Type: ChannelTopic},
+			},
+			Nodes: []NodeDefinition{{Name: "b", Type: NodeBridge, From: "in", To: "out"}},
+		})
+		if err != nil {
+			t.Fatalf("NewFlow: %v", err)
+		}
+
+		if f.Name() != "async" {
+			t.Fatalf("Name = %q, want async", f.Name())
+		}
+
+		err = f.Start(context.Background())
+		if err != nil {
+			t.Fatalf("Start: %v", err)
+		}
+
+		err = f.Start(context.Background())
+		if err != nil {
+			t.Fatalf("second Start: %v", err)
+		}
+
+		out := collect(t, f, "out")
+
+		send(t, f, "in", "x", messaging.Headers{})
+
+		waitFor(t, func() bool { return len(out()) == 1 })
+
+		lctests.AssertIdempotentStop(t, f)
+	})
+
+	t.Run("Stop drains buffered channels to their readers", func(t *testing.T) {
+		t.Parallel()
+
+		handler, errs := captureErrors()
+
+		f, err := NewFlow(Definition{
+			Name: "drain",
+			Channels: []ChannelDefinition{
+				{Name: "in", Type: ChannelQueue, BufferSize: 1000, Workers: 1, DrainTimeout: Duration(5 * time.Second)},
+				{Name: "mid", Type: ChannelQueue, BufferSize: 1000, Workers: 1, DrainTimeout: Duration(5 * time.Second)},
+				{Name: "out"},
+			},
+			Nodes: []NodeDefinition{
+				{Name: "first", Type: NodeBridge, From: "in", To: "mid"},
+				{Name: "second", Type: NodeBridge, From: "mid", To: "out"},
+			},
+		}, WithErrorHandler(handler))
+		if err != nil {
+			t.Fatalf("NewFlow: %v", err)
+		}
+
+		out := collect(t, f, "out")
+
+		err = f.Start(context.Background())
+		if err != nil {
+			t.Fatalf("Start: %v", err)
+		}
+
+		for i := range 200 {
+			send(t, f, "in", i, messaging.Headers{})
+		}
+
+		err = f.Stop(context.Background())
+		if err != nil {
+			t.Fatalf("Stop: %v", err)
+		}
+
+		if len(out()) != 200 {
+			t.Fatalf("delivered = %d, want 200", len(out()))
+		}
+
+		if len(errs()) != 0 {
+			t.Fatalf("errors = %v, want none", errs())
+		}
+	})
+
+	t.Run("failed Start stops what it started", func(t *testing.T) {
+		t.Parallel()
+
+		f, err := NewFlow(Definition{
+			Name:     "broken",
+			Channels: []ChannelDefinition{{Name: "in", Type: ChannelQueue}, {Name: "out"}},
+			Nodes: []NodeDefinition{
+				{Name: "ok", Type: NodeBridge, From: "in", To: "out"},
+				{Name: "bad", Type: NodeBridge, From: "broken", To: "in"},
+			},
+		}, WithChannel("broken", &unsubscribableChannel{}))
+		if err != nil {
+			t.Fatalf("NewFlow: %v", err)
+		}
+
+		err = f.Start(context.Background())
+		if !errors.Is(err, errSubscribe) {
+			t.Fatalf("expected errSubscribe, got %v", err)
+		}
+
+		select {
+		case <-f.Done():
+		case <-time.After(time.Second):
+			t.Fatal("Done not closed after failed Start")
+		}
+
+		in, _ := f.Channel("in")
+		if stats, _ := messaging.StatsOf(in); stats.Subscribers != 0 {
+			t.Fatalf("in subscribers = %d, want 0 after rollback", stats.Subscribers)
+		}
+	})
+
+	t.Run("Channel rejects unknown names and returns bound ones", func(t *testing.T) {
+		t.Parallel()
+
+		bound := messaging.NewPipelineChannel[any]()
+
+		f, err := NewFlow(Definition{Name: "f"}, WithChannel("bound", bound))
+		if err != nil {
+			t.Fatalf("NewFlow: %v", err)
+		}
+
+		got, err := f.Channel("bound")
+		if err != nil || got != bound {
+			t.Fatalf("Channel(bound) = %v, %v; want the bound channel", got, err)
+		}
+
+		_, err = f.Channel("missing")
+		if !errors.Is(err, ErrUnknownChannel) {
+			t.Fatalf("expected ErrUnknownChannel, got %v", err)
+		}
+	})
+}
+
+var errSubscribe = errors.New("subscribe refused")
+
+// unsubscribableChannel is a Channel[any] whose Subscribe always fails.
+type unsubscribableChannel struct{}
+
+func (c *unsubscribableChannel) Send(_ context.Context, _ messaging.Message[any]) error {
+	return nil
+}
+
+func (c *unsubscribableChannel) Subscribe(_ messaging.Handler[any]) (messaging.Cancel, error) {
+	return nil, errSubscribe
+}
@@ -0,0 +1,464 @@
+package flow
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/guidomantilla/yarumo/core/common/expressions"
+	"github.com/guidomantilla/yarumo/core/common/lifecycle"
+	"github.com/guidomantilla/yarumo/messaging"
+	"github.com/guidomantilla/yarumo/messaging/patterns/endpoints/bridge"
+	"github.com/guidomantilla/yarumo/messaging/patterns/routers/aggregator"
+	"github.com/guidomantilla/yarumo/messaging/patterns/routers/filter"
+	"github.com/guidomantilla/yarumo/messaging/patterns/routers/recipientlist"
+	"github.com/guidomantilla/yarumo/messaging/patterns/routers/router"
+	"github.com/guidomantilla/yarumo/messaging/patterns/routers/splitter"
+	"github.com/guidomantilla/yarumo/messaging/patterns/sysmgmt/wiretap"
+	"github.com/guidomantilla/yarumo/messaging/patterns/transformers/transformer"
+)
+
+// expression is a parsed expression bound to the flow's functions.
+type expression struct {
+	source string
+	expr   expressions.Expr
+	funcs  map[string]expressions.Func
+}
+
+// eval evaluates the expression against env, wrapping failures in
+// ErrFlow(ErrExpression, ...).
+func (e *expression) eval(env expressions.Context) (any, error) {
+	value, err := e.expr.Eval(env, e.funcs)
+	if err != nil {
+		return nil, ErrFlow(ErrExpression, fmt.Errorf("%q: %w", e.source, err))
+	}
+
+	return value, nil
+}
+
+// bool evaluates the expression and requires a bool result.
+func (e *expression) bool(env expressions.Context) (bool, error) {
+	value, err := e.eval(env)
+	if err != nil {
+		return false, err
+	}
+
+	result, ok := value.(bool)
+	if !ok {
+		return false, ErrFlow(ErrExpression, fmt.Errorf("%q: want bool, got %T", e.source, value))
+	}
+
+	return result, nil
+}
+
+// string evaluates the expression and requires a string result.
+func (e *expression) string(env expressions.Context) (string, error) {
+	value, err := e.eval(env)
+	if err != nil {
+		return "", err
+	}
+
+	result, ok := value.(string)
+	if !ok {
+		return "", ErrFlow(ErrExpression, fmt.Errorf("%q: want string, got %T", e.source, value))
+	}
+
+	return result, nil
+}
+
+// list evaluates the expression and requires a list result.
+func (e *expression) list(env expressions.Context) ([]any, error) {
+	value, err := e.eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	result, ok := value.([]any)
+	if !ok {
+		return nil, ErrFlow(ErrExpression, fmt.Errorf("%q: want list, got %T", e.source, value))
+	}
+
+	return result, nil
+}
+
+// messageEnv is the evaluation context of a message; see the package
+// documentation for its fields.
+func messageEnv(msg messaging.Message[any]) expressions.Context {
+	custom := msg.Headers.Custom
+	if custom == nil {
+		custom = map[string]any{}
+	}
+
+	return expressions.Context{
+		"payload": msg.Payload,
+		"headers": map[string]any{
+			"messageId":      msg.Headers.MessageID,
+			"correlationId":  msg.Headers.CorrelationID,
+			"causationId":    msg.Headers.CausationID,
+			"replyTo":        msg.Headers.ReplyTo,
+			"type":           msg.Headers.Type,
+			"contentType":    msg.Headers.ContentType,
+			"source":         msg.Headers.Source,
+			"priority":       float64(msg.Headers.Priority),
+			"deliveryCount":  float64(msg.Headers.DeliveryCount),
+			"sequenceNumber": float64(msg.Headers.SequenceNumber),
+			"sequenceSize":   float64(msg.Headers.SequenceSize),
+			"custom":         custom,
+		},
+	}
+}
+
+// groupEnv is the evaluation context of the aggregator completion
+// expression.
+func groupEnv(group []messaging.Message[any]) expressions.Context {
+	return expressions.Context{
+		"size":     float64(len(group)),
+		"payloads": payloads(group),
+	}
+}
+
+// payloads returns the payloads of group in order.
+func payloads(group []messaging.Message[any]) []any {
+	result := make([]any, len(group))
+	for i, msg := range group {
+		result[i] = msg.Payload
+	}
+
+	return result
+}
+
+// builder turns NodeDefinitions into pattern components. channels
+// holds every declared and bound channel by name.
+type builder struct {
+	options  *Options
+	channels map[string]messaging.Channel[any]
+}
+
+// node builds the pattern component def declares.
+func (b *builder) node(def NodeDefinition) (lifecycle.Component, error) {
+	src, err := b.channel(def, def.From)
+	if err != nil {
+		return nil, err
+	}
+
+	switch def.Type {
+	case NodeBridge:
+		return b.bridge(def, src)
+	case NodeFilter:
+		return b.filter(def, src)
+	case NodeRouter:
+		return b.router(def, src)
+	case NodeRecipientList:
+		return b.recipientList(def, src)
+	case NodeSplitter:
+		return b.splitter(def, src)
+	case NodeTransformer:
+		return b.transformer(def, src)
+	case NodeAggregator:
+		return b.aggregator(def, src)
+	case NodeWiretap:
+		return b.wiretap(def, src)
+	default:
+		return nil, ErrFlow(ErrInvalidDefinition, fmt.Errorf("node %q: unknown type %q", def.Name, def.Type))
+	}
+}
+
+// bridge builds a bridge node.
+func (b *builder) bridge(def NodeDefinition, src messaging.Channel[any]) (lifecycle.Component, error) {
+	dst, err := b.channel(def, def.To)
+	if err != nil {
+		return nil, err
+	}
+
+	return bridge.NewBridge(def.Name, src, dst, bridge.WithErrorHandler(b.options.errorHandler)), nil
+}
+
+// filter builds a filter node.
+func (b *builder) filter(def NodeDefinition, src messaging.Channel[any]) (lifecycle.Component, error) {
+	dst, err := b.channel(def, def.To)
+	if err != nil {
+		return nil, err
+	}
+
+	predicate, err := b.expression(def, "expression", def.Expression)
+	if err != nil {
+		return nil, err
+	}
+
+	predicateFn := func(_ context.Context, msg messaging.Message[any]) (bool, error) {
+		return predicate.bool(messageEnv(msg))
+	}
+
+	return filter.NewFilter(def.Name, src, dst, predicateFn, filter.WithErrorHandler(b.options.errorHandler)), nil
+}
+
+// router builds a router node.
+func (b *builder) router(def NodeDefinition, src messaging.Channel[any]) (lifecycle.Component, error) {
+	if len(def.Routes) == 0 {
+		return nil, ErrFlow(ErrInvalidDefinition, fmt.Errorf("node %q: %s requires %q", def.Name, def.Type, "routes"))
+	}
+
+	routes := make(map[string]messaging.Channel[any], len(def.Routes))
+	for key, name := range def.Routes {
+		dst, err := b.channel(def, name)
+		if err != nil {
+			return nil, err
+		}
+
+		routes[key] = dst
+	}
+
+	decide, err := b.expression(def, "expression", def.Expression)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []router.Option[any]{router.WithErrorHandler[any](b.options.errorHandler)}
+
+	if def.Default != "" {
+		fallback, err := b.channel(def, def.Default)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, router.WithDefaultChannel(fallback))
+	}
+
+	routeFn := func(_ context.Context, msg messaging.Message[any]) (string, error) {
+		return decide.string(messageEnv(msg))
+	}
+
+	return router.NewRouter(def.Name, src, routeFn, routes, opts...), nil
+}
+
+// recipientList builds a recipientlist node. Recipients are keyed by
+// their position so two recipients may share a channel.
+func (b *builder) recipientList(def NodeDefinition, src messaging.Channel[any]) (lifecycle.Component, error) {
+	if len(def.Recipients) == 0 {
+		return nil, ErrFlow(ErrInvalidDefinition, fmt.Errorf("node %q: %s requires %q", def.Name, def.Type, "recipients"))
+	}
+
+	keys := make([]string, len(def.Recipients))
+	whens := make([]*expression, len(def.Recipients))
+	routes := make(map[string]messaging.Channel[any], len(def.Recipients))
+
+	for i, recipient := range def.Recipients {
+		dst, err := b.channel(def, recipient.To)
+		if err != nil {
+			return nil, err
+		}
+
+		when, err := b.expression(def, "when", recipient.When)
+		if err != nil {
+			return nil, err
+		}
+
+		keys[i] = fmt.Sprintf("%d:%s", i, recipient.To)
+		whens[i] = when
+		routes[keys[i]] = dst
+	}
+
+	selectorFn := func(_ context.Context, msg messaging.Message[any]) ([]string, error) {
+		env := messageEnv(msg)
+
+		var selected []string
+
+		for i, when := range whens {
+			ok, err := when.bool(env)
+			if err != nil {
+				return nil, err
+			}
+
+			if ok {
+				selected = append(selected, keys[i])
+			}
+		}
+
+		return selected, nil
+	}
+
+	return recipientlist.NewRecipientList(def.Name, src, selectorFn, routes, recipientlist.WithErrorHandler(b.options.errorHandler)), nil
+}
+
+// splitter builds a splitter node.
+func (b *builder) splitter(def NodeDefinition, src messaging.Channel[any]) (lifecycle.Component, error) {
+	dst, err := b.channel(def, def.To)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := b.expression(def, "expression", def.Expression)
+	if err != nil {
+		return nil, err
+	}
+
+	splitFn := func(_ context.Context, msg messaging.Message[any]) ([]any, error) {
+		return items.list(messageEnv(msg))
+	}
+
+	return splitter.NewSplitter(def.Name, src, dst, splitFn, splitter.WithErrorHandler[any](b.options.errorHandler)), nil
+}
+
+// transformer builds a transformer node.
+func (b *builder) transformer(def NodeDefinition, src messaging.Channel[any]) (lifecycle.Component, error) {
+	dst, err := b.channel(def, def.To)
+	if err != nil {
+		return nil, err
+	}
+
+	mapping, err := b.expression(def, "expression", def.Expression)
+	if err != nil {
+		return nil, err
+	}
+
+	transformFn := func(_ context.Context, msg messaging.Message[any]) (messaging.Message[any], error) {
+		payload, err := mapping.eval(messageEnv(msg))
+		if err != nil {
+			return messaging.Message[any]{}, err
+		}
+
+		msg.Payload = payload
+
+		return msg, nil
+	}
+
+	return transformer.NewTransformer(def.Name, src, dst, transformFn, transformer.WithErrorHandler(b.options.errorHandler)), nil
+}
+
+// aggregator builds an aggregator node. The aggregate carries the list
+// of grouped payloads and the headers of the group's first message,
+// with the sequence fields cleared.
+func (b *builder) aggregator(def NodeDefinition, src messaging.Channel[any]) (lifecycle.Component, error) {
+	if def.Completion == "" && def.CompletionSize <= 0 && def.GroupTimeout <= 0 {
+		return nil, ErrFlow(ErrInvalidDefinition, fmt.Errorf("node %q: %s requires %q, %q or %q", def.Name, def.Type, "completion", "completionSize", "groupTimeout"))
+	}
+
+	dst, err := b.channel(def, def.To)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []aggregator.Option[any]{
+		aggregator.WithCompletionSize[any](def.CompletionSize),
+		aggregator.WithGroupTimeout[any](time.Duration(def.GroupTimeout)),
+		aggregator.WithErrorHandler[any](b.options.errorHandler),
+	}
+
+	if def.Correlation != "" {
+		correlation, err := b.expression(def, "correlation", def.Correlation)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, aggregator.WithCorrelationFn(b.correlationFn(def, correlation)))
+	}
+
+	if def.Completion != "" {
+		completion, err := b.expression(def, "completion", def.Completion)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, aggregator.WithCompletionFn(b.completionFn(def, completion)))
+	}
+
+	aggregateFn := func(group []messaging.Message[any]) (messaging.Message[any], error) {
+		headers := group[0].Headers
+		headers.Custom = maps.Clone(headers.Custom)
+		headers.SequenceNumber = 0
+		headers.SequenceSize = 0
+
+		return messaging.Message[any]{Payload: payloads(group), Headers: headers}, nil
+	}
+
+	return aggregator.NewAggregator(def.Name, src, dst, aggregateFn, opts...), nil
+}
+
+// correlationFn adapts the correlation expression. CorrelationFn
+// cannot fail, so an evaluation error is reported to the error
+// handler and yields the empty key, which the aggregator drops.
+func (b *builder) correlationFn(def NodeDefinition, correlation *expression) aggregator.CorrelationFn[any] {
+	return func(msg messaging.Message[any]) string {
+		key, err := correlation.string(messageEnv(msg))
+		if err != nil {
+			b.reportError(msg, fmt.Errorf("node %q: %w", def.Name, err))
+
+			return ""
+		}
+
+		return key
+	}
+}
+
+// completionFn adapts the completion expression. CompletionFn cannot
+// fail, so an evaluation error is reported to the error handler and
+// leaves the group open.
+func (b *builder) completionFn(def NodeDefinition, completion *expression) aggregator.CompletionFn[any] {
+	return func(group []messaging.Message[any]) bool {
+		done, err := completion.bool(groupEnv(group))
+		if err != nil {
+			b.reportError(group[len(group)-1], fmt.Errorf("node %q: %w", def.Name, err))
+
+			return false
+		}
+
+		return done
+	}
+}
+
+// wiretap builds a wiretap node.
+func (b *builder) wiretap(def NodeDefinition, src messaging.Channel[any]) (lifecycle.Component, error) {
+	dst, err := b.channel(def, def.To)
+	if err != nil {
+		return nil, err
+	}
+
+	tap, err := b.channel(def, def.Tap)
+	if err != nil {
+		return nil, err
+	}
+
+	return wiretap.NewWiretap(def.Name, src, dst, tap, wiretap.WithErrorHandler(b.options.errorHandler)), nil
+}
+
+// channel resolves a channel reference of def. An empty reference is
+// a missing required field.
+func (b *builder) channel(def NodeDefinition, name string) (messaging.Channel[any], error) {
+	if name == "" {
+		return nil, ErrFlow(ErrInvalidDefinition, fmt.Errorf("node %q: %s has an empty channel reference", def.Name, def.Type))
+	}
+
+	channel, ok := b.channels[name]
+	if !ok {
+		return nil, ErrFlow(ErrUnknownChannel, fmt.Errorf("node %q: channel %q", def.Name, name))
+	}
+
+	return channel, nil
+}
+
+// expression parses the source of def's field. An empty source is a
+// missing required field.
+func (b *builder) expression(def NodeDefinition, field string, source string) (*expression, error) {
+	if source == "" {
+		return nil, ErrFlow(ErrInvalidDefinition, fmt.Errorf("node %q: %s requires %q", def.Name, def.Type, field))
+	}
+
+	expr, err := expressions.Parse(source)
+	if err != nil {
+		return nil, ErrFlow(ErrExpression, fmt.Errorf("node %q: %s %q: %w", def.Name, field, source, err))
+	}
+
+	return &expression{source: source, expr: expr, funcs: b.options.funcs}, nil
+}
+
+// reportError forwards err to the configured error handler, or to
+// messaging.DefaultErrorHandler when none is configured.
+func (b *builder) reportError(msg messaging.Message[any], err error) {
+	handler := b.options.errorHandler
+	if handler == nil {
+		handler = messaging.DefaultErrorHandler
+	}
+
+	handler(context.Background(), msg, err)
+}
@@ -0,0 +1,79 @@
+package flow
+
+import (
+	"github.com/guidomantilla/yarumo/core/common/expressions"
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+// Option is a functional option for configuring Options.
+type Option func(opts *Options)
+
+// Options holds the configuration for Load and NewFlow.
+type Options struct {
+	decoder      DecodeFn
+	channels     map[string]messaging.Channel[any]
+	funcs        map[string]expressions.Func
+	errorHandler messaging.ErrorHandler
+}
+
+// NewOptions creates a new Options with sensible defaults and applies
+// the given options. Defaults: DecodeJSON, no bound channels, the
+// expressions.DefaultFuncs functions, and every node and channel keeps
+// its own default error handler (messaging.DefaultErrorHandler).
+func NewOptions(opts ...Option) *Options {
+	options := &Options{
+		decoder:  DecodeJSON,
+		channels: map[string]messaging.Channel[any]{},
+		funcs:    expressions.DefaultFuncs(),
+	}
+
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	return options
+}
+
+// WithDecoder sets the function Load decodes definitions with. The
+// default is DecodeJSON. Nil values are ignored.
+func WithDecoder(decoder DecodeFn) Option {
+	return func(opts *Options) {
+		if decoder != nil {
+			opts.decoder = decoder
+		}
+	}
+}
+
+// WithChannel binds an application-owned channel under name, so nodes
+// can reference it like a declared channel. The flow never starts or
+// stops it. Declaring a channel with the same name is an error. Empty
+// names and nil channels are ignored.
+func WithChannel(name string, channel messaging.Channel[any]) Option {
+	return func(opts *Options) {
+		if name != "" && channel != nil {
+			opts.channels[name] = channel
+		}
+	}
+}
+
+// WithFunc registers fn as a function callable from the flow's
+// expressions, next to expressions.DefaultFuncs (a same-named default
+// is replaced). Empty names and nil functions are ignored.
+func WithFunc(name string, fn expressions.Func) Option {
+	return func(opts *Options) {
+		if name != "" && fn != nil {
+			opts.funcs[name] = fn
+		}
+	}
+}
+
+// WithErrorHandler installs handler on every node and every queue or
+// topic channel of the flow, replacing their default
+// messaging.DefaultErrorHandler. Nil values are ignored.
+func WithErrorHandler(handler messaging.ErrorHandler) Option {
+	return func(opts *Options) {
+		if handler != nil {
+			opts.errorHandler = handler
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package flow
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/guidomantilla/yarumo/core/common/expressions"
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+func TestNewOptions(t *testing.T) {
+	t.Parallel()
+
+	opts := NewOptions()
+
+	if reflect.ValueOf(opts.decoder).Pointer() != reflect.ValueOf(DecodeJSON).Pointer() {
+		t.Fatal("expected DecodeJSON decoder")
+	}
+
+	if len(opts.channels) != 0 {
+		t.Fatalf("expected no bound channels, got %d", len(opts.channels))
+	}
+
+	if len(opts.funcs) != len(expressions.DefaultFuncs()) {
+		t.Fatalf("expected the default functions, got %d", len(opts.funcs))
+	}
+
+	if opts.errorHandler != nil {
+		t.Fatal("expected nil error handler")
+	}
+}
+
+func TestWithDecoder(t *testing.T) {
+	t.Parallel()
+
+	decoder := func(_ []byte, _ any) error { return nil }
+
+	opts := NewOptions(WithDecoder(decoder))
+	if reflect.ValueOf(opts.decoder).Pointer() != reflect.ValueOf(decoder).Pointer() {
+		t.Fatal("expected decoder to be set")
+	}
+
+	opts = NewOptions(WithDecoder(nil))
+	if opts.decoder == nil {
+		t.Fatal("expected nil to be ignored")
+	}
+}
+
+func TestWithChannel(t *testing.T) {
+	t.Parallel()
+
+	channel := messaging.NewPipelineChannel[any]()
+
+	opts := NewOptions(WithChannel("in", channel), WithChannel("", channel), WithChannel("nil", nil))
+	if len(opts.channels) != 1 || opts.channels["in"] != channel {
+		t.Fatalf("expected only the valid channel, got %v", opts.channels)
+	}
+}
+
+func TestWithFunc(t *testing.T) {
+	t.Parallel()
+
+	fn := func(_ ...any) (any, error) { return nil, nil }
+
+	opts := NewOptions(WithFunc("custom", fn), WithFunc("", fn), WithFunc("nil", nil))
+	if opts.funcs["custom"] == nil {
+		t.Fatal("expected custom function to be set")
+	}
+
+	if len(opts.funcs) != len(expressions.DefaultFuncs())+1 {
+		t.Fatalf("expected only the valid function added, got %d", len(opts.funcs))
+	}
+}
+
+func TestWithErrorHandler(t *testing.T) {
+	t.Parallel()
+
+	handler := func(_ context.Context, _ any, _ error) {}
+
+	opts := NewOptions(WithErrorHandler(handler))
+	if opts.errorHandler == nil {
+		t.Fatal("expected error handler to be set")
+	}
+
+	opts = NewOptions(WithErrorHandler(nil))
+	if opts.errorHandler != nil {
+		t.Fatal("expected nil to be ignored")
+	}
+}
@@ -0,0 +1,171 @@
+// Package flow builds messaging integration flows from a declarative
+// definition, so that routing can change through configuration instead
+// of a recompile.
+//
+// A Definition names the channels of the flow and the pattern nodes
+// wired between them. Load decodes it from JSON (or any format through
+// WithDecoder — extension/messaging/flow/yaml ships a YAML decoder) and
+// NewFlow turns it into a single Flow: one lifecycle.Component that
+// starts and stops every channel and node of the graph.
+//
+//	name: orders
+//	channels:
+//	  - {name: incoming}
+//	  - {name: lines, type: queue, bufferSize: 256, workers: 4}
+//	  - {name: large}
+//	  - {name: batches}
+//	nodes:
+//	  - {name: split, type: splitter, from: incoming, to: lines, expression: payload.lines}
+//	  - {name: large-only, type: filter, from: lines, to: large, expression: "payload.amount > 100"}
+//	  - {name: batch, type: aggregator, from: large, to: batches, completionSize: 10, groupTimeout: 5s}
+//
+// # Payloads and expressions
+//
+// Flows carry messaging.Message[any]. Predicates, selectors and
+// mappings are core/common/expressions strings, parsed once when the
+// flow is built and evaluated per message against:
+//
+//   - payload: the message payload. Property access (payload.amount)
+//     works on map[string]any, so payloads are expected in the
+//     encoding/json data model — what codec.Registry or a JSON decode
+//     into Message[any] produce.
+//   - headers: messageId, correlationId, causationId, replyTo, type,
+//     contentType, source (strings), priority, deliveryCount,
+//     sequenceNumber, sequenceSize (numbers) and custom (the
+//     Headers.Custom map).
+//
+// The aggregator completion expression sees size (the number of
+// grouped messages) and payloads (their payloads, in arrival order)
+// instead.
+//
+// # Channels
+//
+// ChannelDefinition.Type selects the messaging channel: pipeline (the
+// default), broadcast, queue, topic or null. Queue and topic channels
+// accept bufferSize, overflow (block, dropNewest, dropOldest, reject)
+// and drainTimeout; queue channels also accept workers. Channels owned
+// by the application — a broker driver, an entry point shared with
+// other code — are bound with WithChannel and referenced by name like
+// declared ones; the flow never starts or stops them. Flow.Channel
+// returns any channel of the flow, which is how callers reach its
+// entry and exit points.
+//
+// # Nodes
+//
+// NodeDefinition.Type selects the pattern; every node reads from
+// "from":
+//
+//   - bridge forwards every message to "to".
+//   - filter forwards to "to" the messages whose expression is true.
+//   - router evaluates expression to a key and forwards to
+//     routes[key], or to "default" when the key has no route.
+//   - recipientlist forwards a copy to every recipient whose "when"
+//     expression is true.
+//   - splitter evaluates expression to a list and emits one message per
+//     element to "to".
+//   - transformer replaces the payload with the value of expression
+//     and forwards to "to"; headers are preserved.
+//   - aggregator groups messages by the correlation expression
+//     (default Headers.CorrelationID) and emits the list of their
+//     payloads to "to" once completion is true, completionSize
+//     messages arrived or the group sat idle for groupTimeout.
+//   - wiretap forwards every message to "to" and a copy to "tap".
+//
+// # Lifecycle
+//
+// The node graph must be acyclic. Start starts the flow-owned
+// channels, then the nodes from the sinks back to the sources, so
+// every subscriber is in place before its producer runs. Stop stops
+// the nodes from the sources onwards, stopping each flow-owned channel
+// just before the first node that reads from it, so the channel drains
+// its buffered messages downstream while its readers are still
+// subscribed. A failing Start stops whatever it already started. Like
+// every lifecycle.Component, a Flow is single-use.
+package flow
+
+import (
+	"encoding"
+
+	"github.com/guidomantilla/yarumo/core/common/lifecycle"
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+var (
+	_ Flow                     = (*flow)(nil)
+	_ encoding.TextUnmarshaler = (*Duration)(nil)
+	_ encoding.TextMarshaler   = Duration(0)
+
+	_ DecodeFn  = DecodeJSON
+	_ LoadFn    = Load
+	_ NewFlowFn = NewFlow
+	_ ErrFlowFn = ErrFlow
+)
+
+// Channel types accepted in ChannelDefinition.Type.
+const (
+	// ChannelPipeline is messaging.NewPipelineChannel; the default.
+	ChannelPipeline = "pipeline"
+	// ChannelBroadcast is messaging.NewBroadcastChannel.
+	ChannelBroadcast = "broadcast"
+	// ChannelQueue is messaging.NewQueueChannel.
+	ChannelQueue = "queue"
+	// ChannelTopic is messaging.NewTopicChannel.
+	ChannelTopic = "topic"
+	// ChannelNull is messaging.NewNullChannel.
+	ChannelNull = "null"
+)
+
+// Node types accepted in NodeDefinition.Type.
+const (
+	// NodeBridge is patterns/endpoints/bridge.
+	NodeBridge = "bridge"
+	// NodeFilter is patterns/routers/filter.
+	NodeFilter = "filter"
+	// NodeRouter is patterns/routers/router.
+	NodeRouter = "router"
+	// NodeRecipientList is patterns/routers/recipientlist.
+	NodeRecipientList = "recipientlist"
+	// NodeSplitter is patterns/routers/splitter.
+	NodeSplitter = "splitter"
+	// NodeTransformer is patterns/transformers/transformer.
+	NodeTransformer = "transformer"
+	// NodeAggregator is patterns/routers/aggregator.
+	NodeAggregator = "aggregator"
+	// NodeWiretap is patterns/sysmgmt/wiretap.
+	NodeWiretap = "wiretap"
+)
+
+// Overflow policies accepted in ChannelDefinition.Overflow; they map
+// to the messaging.OverflowPolicy constants.
+const (
+	OverflowBlock      = "block"
+	OverflowDropNewest = "dropNewest"
+	OverflowDropOldest = "dropOldest"
+	OverflowReject     = "reject"
+)
+
+// Flow is a running graph of channels and pattern nodes built from a
+// Definition. It implements lifecycle.Component: Start and Stop drive
+// every flow-owned channel and every node in dependency order (see the
+// package documentation).
+type Flow interface {
+	lifecycle.Component
+	// Channel returns the channel bound to name — declared in the
+	// Definition or bound with WithChannel — so callers can send to the
+	// flow's entry points and subscribe to its exits. Unknown names
+	// return ErrFlow(ErrUnknownChannel, ...).
+	Channel(name string) (messaging.Channel[any], error)
+}
+
+// DecodeFn decodes a serialized Definition from data into v, with the
+// semantics of json.Unmarshal. See WithDecoder.
+type DecodeFn func(data []byte, v any) error
+
+// LoadFn is the function type for Load.
+type LoadFn func(data []byte, opts ...Option) (Flow, error)
+
+// NewFlowFn is the function type for NewFlow.
+type NewFlowFn func(definition Definition, opts ...Option) (Flow, error)
+
+// ErrFlowFn is the function type for ErrFlow.
+type ErrFlowFn func(causes ...error) error
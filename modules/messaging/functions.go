@@ -2,7 +2,10 @@ package messaging
 
 import (
 	"context"
+	"fmt"
+	"time"
 
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
 	clog "github.com/guidomantilla/yarumo/core/common/log"
 )
 
@@ -20,3 +23,74 @@ func DefaultErrorHandler(ctx context.Context, _ any, err error) {
 // genuinely wants to suppress error logging — for example, in tests
 // that intentionally drive failure paths.
 func SilentErrorHandler(_ context.Context, _ any, _ error) {}
+
+// SystemClock returns the wall-clock Clock every component uses unless
+// configured otherwise.
+func SystemClock() Clock {
+	return systemClock{}
+}
+
+// IsExpired reports whether msg carries a Headers.ExpirationTime that
+// lies before now. A zero ExpirationTime never expires. Channels and
+// buffering patterns call it when a message is dequeued or released.
+func IsExpired[T any](msg Message[T], now time.Time) bool {
+	expiration := msg.Headers.ExpirationTime
+
+	return !expiration.IsZero() && now.After(expiration)
+}
+
+// PublishDeadLetter publishes msg to dlq as a DeadLetter whose
+// LastError is cause and whose FailedAt is now — read it from the
+// component's Clock. It is the dead-letter path shared by the channels,
+// the adapters and the extension endpoints. A nil dlq publishes
+// nothing. The Send error is intentionally swallowed: DLQ-of-DLQ is out
+// of scope and the caller has already reported cause through its
+// ErrorHandler. PublishDeadLetter reports whether dlq accepted the
+// dead letter, for callers counting StatsRecorder.RecordDeadLetter.
+func PublishDeadLetter[T any](ctx context.Context, dlq Channel[DeadLetter[T]], msg Message[T], cause error, now time.Time) bool {
+	if dlq == nil {
+		return false
+	}
+
+	err := dlq.Send(ctx, NewMessage(DeadLetter[T]{
+		Original:  msg,
+		LastError: cause,
+		FailedAt:  now,
+	}, nil))
+
+	return err == nil
+}
+
+// StatsOf returns the Stats of v when it implements Inspectable (every
+// channel of this package and every lifecycle-bearing pattern does),
+// and false otherwise.
+func StatsOf(v any) (Stats, bool) {
+	inspectable, ok := v.(Inspectable)
+	if !ok {
+		return Stats{}, false
+	}
+
+	return inspectable.Stats(), true
+}
+
+// PartitionByCorrelationID is the default PartitionKeyFn: messages of
+// one conversation (Headers.CorrelationID) are delivered in order.
+func PartitionByCorrelationID(headers Headers) string {
+	return headers.CorrelationID
+}
+
+// PartitionByHeader returns a PartitionKeyFn keyed on the
+// Headers.Custom entry name, formatted with %v. Messages without the
+// entry have an empty key.
+func PartitionByHeader(name string) PartitionKeyFn {
+	cassert.NotEmpty(name, "name is empty")
+
+	return func(headers Headers) string {
+		value, ok := headers.Custom[name]
+		if !ok || value == nil {
+			return ""
+		}
+
+		return fmt.Sprint(value)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestSilentErrorHandler_OptsOutOfLogging(t *testing.T) {
@@ -31,3 +32,108 @@ func TestDefaultErrorHandler_DoesNotPanicOnNilMsg(t *testing.T) {
 	// to this test but the call must complete cleanly.
 	DefaultErrorHandler(context.Background(), nil, errors.New("smoke"))
 }
+
+// expiredMessage returns a message whose ExpirationTime lies one
+// minute in the past.
+func expiredMessage(payload int) Message[int] {
+	msg := NewMessage(payload, nil)
+	msg.Headers.ExpirationTime = time.Now().Add(-time.Minute)
+
+	return msg
+}
+
+func TestIsExpired(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	t.Run("zero never expires", func(t *testing.T) {
+		t.Parallel()
+
+		msg := NewMessage(1, nil)
+		msg.Headers.ExpirationTime = time.Time{}
+
+		if got := IsExpired(msg, now); got != false {
+			t.Fatalf("IsExpired = %v, want false", got)
+		}
+	})
+
+	t.Run("future is live", func(t *testing.T) {
+		t.Parallel()
+
+		msg := NewMessage(1, nil)
+		msg.Headers.ExpirationTime = now.Add(time.Second)
+
+		if got := IsExpired(msg, now); got != false {
+			t.Fatalf("IsExpired = %v, want false", got)
+		}
+	})
+
+	t.Run("exactly now is live", func(t *testing.T) {
+		t.Parallel()
+
+		msg := NewMessage(1, nil)
+		msg.Headers.ExpirationTime = now
+
+		if got := IsExpired(msg, now); got != false {
+			t.Fatalf("IsExpired = %v, want false", got)
+		}
+	})
+
+	t.Run("past is expired", func(t *testing.T) {
+		t.Parallel()
+
+		msg := NewMessage(1, nil)
+		msg.Headers.ExpirationTime = now.Add(-time.Second)
+
+		if got := IsExpired(msg, now); got != true {
+			t.Fatalf("IsExpired = %v, want true", got)
+		}
+	})
+}
+
+func TestPartitionByHeader(t *testing.T) {
+	t.Parallel()
+
+	key := PartitionByHeader("tenant")
+
+	t.Run("nil custom map", func(t *testing.T) {
+		t.Parallel()
+
+		if got := key(Headers{}); got != "" {
+			t.Fatalf("key = %q, want %q", got, "")
+		}
+	})
+
+	t.Run("missing entry", func(t *testing.T) {
+		t.Parallel()
+
+		if got := key(Headers{Custom: map[string]any{"other": "x"}}); got != "" {
+			t.Fatalf("key = %q, want %q", got, "")
+		}
+	})
+
+	t.Run("nil entry", func(t *testing.T) {
+		t.Parallel()
+
+		if got := key(Headers{Custom: map[string]any{"tenant": nil}}); got != "" {
+			t.Fatalf("key = %q, want %q", got, "")
+		}
+	})
+
+	t.Run("string entry", func(t *testing.T) {
+		t.Parallel()
+
+		if got := key(Headers{Custom: map[string]any{"tenant": "acme"}}); got != "acme" {
+			t.Fatalf("key = %q, want %q", got, "acme")
+		}
+	})
+
+	t.Run("numeric entry", func(t *testing.T) {
+		t.Parallel()
+
+		if got := key(Headers{Custom: map[string]any{"tenant": 42}}); got != "42" {
+			t.Fatalf("key = %q, want %q", got, "42")
+		}
+	})
+}
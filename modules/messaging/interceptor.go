@@ -0,0 +1,206 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+)
+
+// InterceptorFuncs adapts plain functions to ChannelInterceptor[T].
+// Nil fields are pass-throughs, so an interceptor only spells out the
+// hooks it needs:
+//
+//	messaging.InterceptorFuncs[Order]{
+//		PreSendFn: func(ctx context.Context, msg messaging.Message[Order]) (messaging.Message[Order], error) {
+//			msg.Headers.Source = "orders"
+//			return msg, nil
+//		},
+//	}
+type InterceptorFuncs[T any] struct {
+	// PreSendFn backs PreSend.
+	PreSendFn func(ctx context.Context, msg Message[T]) (Message[T], error)
+	// PostSendFn backs PostSend.
+	PostSendFn func(ctx context.Context, msg Message[T], err error)
+	// PreHandleFn backs PreHandle.
+	PreHandleFn func(ctx context.Context, msg Message[T]) (Message[T], error)
+	// AfterHandleFn backs AfterHandle.
+	AfterHandleFn func(ctx context.Context, msg Message[T], err error)
+}
+
+// PreSend calls PreSendFn, or returns msg unchanged when it is nil.
+func (f InterceptorFuncs[T]) PreSend(ctx context.Context, msg Message[T]) (Message[T], error) {
+	if f.PreSendFn == nil {
+		return msg, nil
+	}
+
+	return f.PreSendFn(ctx, msg)
+}
+
+// PostSend calls PostSendFn when it is set.
+func (f InterceptorFuncs[T]) PostSend(ctx context.Context, msg Message[T], err error) {
+	if f.PostSendFn != nil {
+		f.PostSendFn(ctx, msg, err)
+	}
+}
+
+// PreHandle calls PreHandleFn, or returns msg unchanged when it is nil.
+func (f InterceptorFuncs[T]) PreHandle(ctx context.Context, msg Message[T]) (Message[T], error) {
+	if f.PreHandleFn == nil {
+		return msg, nil
+	}
+
+	return f.PreHandleFn(ctx, msg)
+}
+
+// AfterHandle calls AfterHandleFn when it is set.
+func (f InterceptorFuncs[T]) AfterHandle(ctx context.Context, msg Message[T], err error) {
+	if f.AfterHandleFn != nil {
+		f.AfterHandleFn(ctx, msg, err)
+	}
+}
+
+// InterceptSend runs the PreSend and PostSend hooks of interceptors
+// around next with the semantics the channels of this package give
+// WithInterceptors: a PreSend veto skips next and is returned as
+// ErrSend(ErrIntercepted, cause), and a nil ctx goes straight to next.
+// It lets channel drivers living outside this package honor
+// ChannelInterceptor; next is the driver's Send without interceptors.
+func InterceptSend[T any](ctx context.Context, interceptors []ChannelInterceptor[T], msg Message[T], next func(ctx context.Context, msg Message[T]) error) error {
+	return interceptorChain[T](interceptors).send(ctx, msg, next)
+}
+
+// InterceptHandler returns handler decorated with the PreHandle and
+// AfterHandle hooks of interceptors, or handler itself when there are
+// none. Drivers outside this package wrap at Subscribe, as the channels
+// of this package do, so every delivery goes through the hooks.
+func InterceptHandler[T any](interceptors []ChannelInterceptor[T], handler Handler[T]) Handler[T] {
+	return interceptorChain[T](interceptors).wrap(handler)
+}
+
+// interceptorChain is the ordered list installed with WithInterceptors.
+// A nil chain is a pass-through, so channels call it unconditionally.
+type interceptorChain[T any] []ChannelInterceptor[T]
+
+// extractInterceptors converts the type-erased Options.interceptors
+// entries into a chain at channel construction time. An entry
+// registered for a different T than the channel being constructed is
+// reported via cassert (programmer error caught at build, not at first
+// Send) and left out of the chain.
+func extractInterceptors[T any](raw []any) interceptorChain[T] {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	chain := make(interceptorChain[T], 0, len(raw))
+
+	for _, entry := range raw {
+		typed, ok := entry.(ChannelInterceptor[T])
+		cassert.True(ok, "WithInterceptors type parameter does not match channel type T")
+
+		if ok {
+			chain = append(chain, typed)
+		}
+	}
+
+	return chain
+}
+
+// send runs PreSend in registration order, hands the resulting message
+// to next and then runs PostSend in reverse order with next's error. A
+// PreSend error vetoes the Send: next is skipped and the interceptors
+// that already ran see ErrSend(ErrIntercepted, cause) in PostSend,
+// which is also what Send returns. A nil ctx goes straight to next so
+// the channel reports ErrContextNil as usual.
+func (c interceptorChain[T]) send(ctx context.Context, msg Message[T], next func(ctx context.Context, msg Message[T]) error) error {
+	if len(c) == 0 || ctx == nil {
+		return next(ctx, msg)
+	}
+
+	for i, interceptor := range c {
+		out, err := interceptor.PreSend(ctx, msg)
+		if err != nil {
+			err = ErrSend(ErrIntercepted, err)
+			c[:i].postSend(ctx, msg, err)
+
+			return err
+		}
+
+		msg = out
+	}
+
+	err := next(ctx, msg)
+	c.postSend(ctx, msg, err)
+
+	return err
+}
+
+// postSend runs PostSend in reverse registration order.
+func (c interceptorChain[T]) postSend(ctx context.Context, msg Message[T], err error) {
+	for i := len(c) - 1; i >= 0; i-- {
+		c[i].PostSend(ctx, msg, err)
+	}
+}
+
+// wrap returns handler decorated with the PreHandle and AfterHandle
+// hooks, or handler itself when the chain is empty. Channels wrap at
+// Subscribe so every delivery, redeliveries included, goes through the
+// hooks.
+func (c interceptorChain[T]) wrap(handler Handler[T]) Handler[T] {
+	if len(c) == 0 {
+		return handler
+	}
+
+	return func(ctx context.Context, msg Message[T]) error {
+		return c.handle(ctx, msg, handler)
+	}
+}
+
+// handle runs PreHandle in registration order, handler, and AfterHandle
+// in reverse order. A PreHandle error skips handler and is returned
+// joined with ErrIntercepted, so the channel treats it as a handler
+// failure (ErrorHandler, DLQ, redelivery). A handler panic is reported
+// to AfterHandle as ErrHandlerPanic and then re-raised, leaving the
+// channel's own recovery unchanged.
+func (c interceptorChain[T]) handle(ctx context.Context, msg Message[T], handler Handler[T]) error {
+	for i, interceptor := range c {
+		out, err := interceptor.PreHandle(ctx, msg)
+		if err != nil {
+			err = errors.Join(ErrIntercepted, err)
+			c[:i].afterHandle(ctx, msg, err)
+
+			return err
+		}
+
+		msg = out
+	}
+
+	var recovered any
+
+	err := func() (err error) {
+		defer func() {
+			recovered = recover()
+			if recovered != nil {
+				err = fmt.Errorf("%w: %v", ErrHandlerPanic, recovered)
+			}
+		}()
+
+		return handler(ctx, msg)
+	}()
+
+	c.afterHandle(ctx, msg, err)
+
+	if recovered != nil {
+		panic(recovered)
+	}
+
+	return err
+}
+
+// afterHandle runs AfterHandle in reverse registration order.
+func (c interceptorChain[T]) afterHandle(ctx context.Context, msg Message[T], err error) {
+	for i := len(c) - 1; i >= 0; i-- {
+		c[i].AfterHandle(ctx, msg, err)
+	}
+}
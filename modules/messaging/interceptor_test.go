@@ -0,0 +1,630 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingInterceptor appends "<name>.<hook>" to a shared event log
+// for every hook it sees, and vetoes PreSend or PreHandle on demand.
+type recordingInterceptor struct {
+	name       string
+	events     *eventLog
+	vetoSend   error
+	vetoHandle error
+
+	mu      sync.Mutex
+	lastErr error
+}
+
+// eventLog is a goroutine-safe list of hook events.
+type eventLog struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (l *eventLog) add(event string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.events = append(l.events, event)
+}
+
+func (l *eventLog) snapshot() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return append([]string(nil), l.events...)
+}
+
+func (r *recordingInterceptor) PreSend(_ context.Context, msg Message[string]) (Message[string], error) {
+	r.events.add(r.name + ".PreSend")
+
+	if r.vetoSend != nil {
+		return msg, r.vetoSend
+	}
+
+	msg.Payload += "+" + r.name
+
+	return msg, nil
+}
+
+func (r *recordingInterceptor) PostSend(_ context.Context, _ Message[string], err error) {
+	r.events.add(r.name + ".PostSend")
+	r.setLastErr(err)
+}
+
+func (r *recordingInterceptor) PreHandle(_ context.Context, msg Message[string]) (Message[string], error) {
+	r.events.add(r.name + ".PreHandle")
+
+	if r.vetoHandle != nil {
+		return msg, r.vetoHandle
+	}
+
+	return msg, nil
+}
+
+func (r *recordingInterceptor) AfterHandle(_ context.Context, _ Message[string], err error) {
+	r.events.add(r.name + ".AfterHandle")
+	r.setLastErr(err)
+}
+
+func (r *recordingInterceptor) setLastErr(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastErr = err
+}
+
+func (r *recordingInterceptor) lastError() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.lastErr
+}
+
+func equalEvents(got []string, want ...string) bool {
+	return slices.Equal(got, want)
+}
+
+// waitForEvents polls l until it holds n events or fails t after 2s.
+func waitForEvents(t *testing.T, l *eventLog, n int) {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(l.snapshot()) < n {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %d events, got %v", n, l.snapshot())
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// assertInterceptedDelivery subscribes to ch, starts it when it is a
+// lifecycle component, sends one message and fails t unless the
+// handler sees the payload rewritten by the "a" recordingInterceptor
+// and each of its four hooks fired exactly once.
+func assertInterceptedDelivery(t *testing.T, ch Channel[string], events *eventLog) {
+	t.Helper()
+
+	received := make(chan string, 1)
+
+	_, err := ch.Subscribe(func(_ context.Context, msg Message[string]) error {
+		received <- msg.Payload
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+
+	component, ok := ch.(interface {
+		Start(ctx context.Context) error
+		Stop(ctx context.Context) error
+	})
+	if ok {
+		err = component.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		t.Cleanup(func() { _ = component.Stop(context.Background()) })
+	}
+
+	err = ch.Send(context.Background(), Message[string]{Payload: "m"})
+	if err != nil {
+		t.Fatalf("send: %v", err)
+	}
+
+	select {
+	case got := <-received:
+		if got != "m+a" {
+			t.Fatalf("expected the rewritten payload, got %q", got)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for delivery")
+	}
+
+	waitForEvents(t, events, 4)
+
+	counts := map[string]int{}
+	for _, event := range events.snapshot() {
+		counts[event]++
+	}
+
+	for _, hook := range []string{"a.PreSend", "a.PostSend", "a.PreHandle", "a.AfterHandle"} {
+		if counts[hook] != 1 {
+			t.Fatalf("expected %s once, got %v", hook, events.snapshot())
+		}
+	}
+}
+
+func TestInterceptorFuncs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil fields pass through", func(t *testing.T) {
+		t.Parallel()
+
+		var f InterceptorFuncs[string]
+
+		msg := Message[string]{Payload: "x"}
+
+		out, err := f.PreSend(context.Background(), msg)
+		if err != nil || out.Payload != "x" {
+			t.Fatalf("unexpected PreSend result %v %v", out, err)
+		}
+
+		out, err = f.PreHandle(context.Background(), msg)
+		if err != nil || out.Payload != "x" {
+			t.Fatalf("unexpected PreHandle result %v %v", out, err)
+		}
+
+		f.PostSend(context.Background(), msg, nil)
+		f.AfterHandle(context.Background(), msg, nil)
+	})
+
+	t.Run("set fields are called", func(t *testing.T) {
+		t.Parallel()
+
+		calls := 0
+		f := InterceptorFuncs[string]{
+			PreSendFn: func(_ context.Context, msg Message[string]) (Message[string], error) {
+				calls++
+				msg.Payload = "send"
+
+				return msg, nil
+			},
+			PostSendFn: func(_ context.Context, _ Message[string], _ error) { calls++ },
+			PreHandleFn: func(_ context.Context, msg Message[string]) (Message[string], error) {
+				calls++
+				msg.Payload = "handle"
+
+				return msg, nil
+			},
+			AfterHandleFn: func(_ context.Context, _ Message[string], _ error) { calls++ },
+		}
+
+		out, _ := f.PreSend(context.Background(), Message[string]{})
+		if out.Payload != "send" {
+			t.Fatalf("expected PreSendFn result, got %q", out.Payload)
+		}
+
+		out, _ = f.PreHandle(context.Background(), Message[string]{})
+		if out.Payload != "handle" {
+			t.Fatalf("expected PreHandleFn result, got %q", out.Payload)
+		}
+
+		f.PostSend(context.Background(), Message[string]{}, nil)
+		f.AfterHandle(context.Background(), Message[string]{}, nil)
+
+		if calls != 4 {
+			t.Fatalf("expected 4 calls, got %d", calls)
+		}
+	})
+}
+
+func TestWithInterceptors(t *testing.T) {
+	t.Parallel()
+
+	first := InterceptorFuncs[int]{}
+	second := InterceptorFuncs[int]{}
+
+	opts := NewOptions(
+		WithInterceptors[int](first),
+		WithInterceptors[int](nil, second),
+	)
+
+	if len(opts.interceptors) != 2 {
+		t.Fatalf("expected calls to accumulate and nil to be ignored, got %d", len(opts.interceptors))
+	}
+
+	chain := extractInterceptors[int](opts.interceptors)
+	if len(chain) != 2 {
+		t.Fatalf("expected 2 interceptors in the chain, got %d", len(chain))
+	}
+
+	if extractInterceptors[int](nil) != nil {
+		t.Fatal("expected nil chain without interceptors")
+	}
+}
+
+func TestInterceptSend(t *testing.T) {
+	t.Parallel()
+
+	t.Run("runs the send hooks around next", func(t *testing.T) {
+		t.Parallel()
+
+		events := &eventLog{}
+		a := &recordingInterceptor{name: "a", events: events}
+
+		var sent string
+
+		err := InterceptSend(context.Background(), []ChannelInterceptor[string]{a}, Message[string]{Payload: "m"},
+			func(_ context.Context, msg Message[string]) error {
+				sent = msg.Payload
+
+				return nil
+			})
+		if err != nil {
+			t.Fatalf("send: %v", err)
+		}
+
+		if sent != "m+a" || !equalEvents(events.snapshot(), "a.PreSend", "a.PostSend") {
+			t.Fatalf("unexpected payload %q or events %v", sent, events.snapshot())
+		}
+	})
+
+	t.Run("returns a PreSend veto without calling next", func(t *testing.T) {
+		t.Parallel()
+
+		veto := errors.New("no")
+		a := InterceptorFuncs[string]{
+			PreSendFn: func(_ context.Context, msg Message[string]) (Message[string], error) { return msg, veto },
+		}
+
+		err := InterceptSend(context.Background(), []ChannelInterceptor[string]{a}, Message[string]{},
+			func(_ context.Context, _ Message[string]) error {
+				t.Error("next must not run")
+
+				return nil
+			})
+		if !errors.Is(err, ErrIntercepted) || !errors.Is(err, veto) {
+			t.Fatalf("expected ErrIntercepted wrapping the veto, got %v", err)
+		}
+	})
+}
+
+func TestInterceptHandler(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns handler unchanged without interceptors", func(t *testing.T) {
+		t.Parallel()
+
+		calls := 0
+		handler := InterceptHandler[string](nil, func(_ context.Context, _ Message[string]) error {
+			calls++
+
+			return nil
+		})
+
+		_ = handler(context.Background(), Message[string]{})
+
+		if calls != 1 {
+			t.Fatalf("expected one call, got %d", calls)
+		}
+	})
+
+	t.Run("runs the handle hooks around handler", func(t *testing.T) {
+		t.Parallel()
+
+		events := &eventLog{}
+		a := &recordingInterceptor{name: "a", events: events}
+
+		var got string
+
+		handler := InterceptHandler([]ChannelInterceptor[string]{a}, func(_ context.Context, msg Message[string]) error {
+			got = msg.Payload
+
+			return nil
+		})
+
+		err := handler(context.Background(), Message[string]{Payload: "m"})
+		if err != nil {
+			t.Fatalf("handle: %v", err)
+		}
+
+		if got != "m" || !equalEvents(events.snapshot(), "a.PreHandle", "a.AfterHandle") {
+			t.Fatalf("unexpected payload %q or events %v", got, events.snapshot())
+		}
+	})
+}
+
+func TestInterceptorChain_Pipeline(t *testing.T) {
+	t.Parallel()
+
+	t.Run("runs pre hooks in order and post hooks in reverse", func(t *testing.T) {
+		t.Parallel()
+
+		events := &eventLog{}
+		a := &recordingInterceptor{name: "a", events: events}
+		b := &recordingInterceptor{name: "b", events: events}
+
+		ch := NewPipelineChannel[string](WithInterceptors[string](a, b))
+
+		var got string
+
+		_, _ = ch.Subscribe(func(_ context.Context, msg Message[string]) error {
+			events.add("handler")
+			got = msg.Payload
+
+			return nil
+		})
+
+		err := ch.Send(context.Background(), Message[string]{Payload: "m"})
+		if err != nil {
+			t.Fatalf("send: %v", err)
+		}
+
+		if got != "m+a+b" {
+			t.Fatalf("expected the rewritten payload, got %q", got)
+		}
+
+		want := []string{
+			"a.PreSend", "b.PreSend",
+			"a.PreHandle", "b.PreHandle", "handler", "b.AfterHandle", "a.AfterHandle",
+			"b.PostSend", "a.PostSend",
+		}
+		if events := events.snapshot(); !equalEvents(events, want...) {
+			t.Fatalf("expected %v, got %v", want, events)
+		}
+	})
+
+	t.Run("PreSend veto aborts the send", func(t *testing.T) {
+		t.Parallel()
+
+		events := &eventLog{}
+		veto := errors.New("missing source")
+		a := &recordingInterceptor{name: "a", events: events}
+		b := &recordingInterceptor{name: "b", events: events, vetoSend: veto}
+		c := &recordingInterceptor{name: "c", events: events}
+
+		ch := NewPipelineChannel[string](WithInterceptors[string](a, b, c))
+
+		_, _ = ch.Subscribe(func(_ context.Context, _ Message[string]) error {
+			events.add("handler")
+
+			return nil
+		})
+
+		err := ch.Send(context.Background(), Message[string]{Payload: "m"})
+		if !errors.Is(err, ErrSendFailed) || !errors.Is(err, ErrIntercepted) || !errors.Is(err, veto) {
+			t.Fatalf("expected ErrSend(ErrIntercepted, veto), got %v", err)
+		}
+
+		if events := events.snapshot(); !equalEvents(events, "a.PreSend", "b.PreSend", "a.PostSend") {
+			t.Fatalf("unexpected events %v", events)
+		}
+
+		if !errors.Is(a.lastError(), ErrIntercepted) {
+			t.Fatalf("expected PostSend to see the veto, got %v", a.lastError())
+		}
+	})
+
+	t.Run("PreHandle veto skips the handler", func(t *testing.T) {
+		t.Parallel()
+
+		events := &eventLog{}
+		veto := errors.New("not for me")
+		a := &recordingInterceptor{name: "a", events: events, vetoHandle: veto}
+
+		ch := NewPipelineChannel[string](WithInterceptors[string](a))
+
+		_, _ = ch.Subscribe(func(_ context.Context, _ Message[string]) error {
+			events.add("handler")
+
+			return nil
+		})
+
+		err := ch.Send(context.Background(), Message[string]{Payload: "m"})
+
+		var chainErr *ChainError
+		if !errors.As(err, &chainErr) || !errors.Is(chainErr.Steps[0].Err, ErrIntercepted) || !errors.Is(err, veto) {
+			t.Fatalf("expected a failed step with ErrIntercepted, got %v", err)
+		}
+
+		if events := events.snapshot(); !equalEvents(events, "a.PreSend", "a.PreHandle", "a.PostSend") {
+			t.Fatalf("unexpected events %v", events)
+		}
+	})
+
+	t.Run("reports handler panics and keeps the panic status", func(t *testing.T) {
+		t.Parallel()
+
+		events := &eventLog{}
+		a := &recordingInterceptor{name: "a", events: events}
+
+		ch := NewPipelineChannel[string](WithInterceptors[string](a))
+
+		_, _ = ch.Subscribe(func(_ context.Context, _ Message[string]) error {
+			panic("boom")
+		})
+
+		err := ch.Send(context.Background(), Message[string]{Payload: "m"})
+
+		var chainErr *ChainError
+		if !errors.As(err, &chainErr) || chainErr.Steps[0].Status != StepStatusPanic {
+			t.Fatalf("expected a panic step, got %v", err)
+		}
+
+		if events := events.snapshot(); !equalEvents(events, "a.PreSend", "a.PreHandle", "a.AfterHandle", "a.PostSend") {
+			t.Fatalf("unexpected events %v", events)
+		}
+	})
+
+	t.Run("nil ctx bypasses the chain", func(t *testing.T) {
+		t.Parallel()
+
+		events := &eventLog{}
+		ch := NewPipelineChannel[string](WithInterceptors[string](&recordingInterceptor{name: "a", events: events}))
+
+		//nolint:staticcheck // nil ctx is the case under test
+		err := ch.Send(nil, Message[string]{})
+		if !errors.Is(err, ErrContextNil) {
+			t.Fatalf("expected ErrContextNil, got %v", err)
+		}
+
+		if len(events.snapshot()) != 0 {
+			t.Fatalf("expected no hooks, got %v", events.snapshot())
+		}
+	})
+}
+
+func TestInterceptorChain_Channels(t *testing.T) {
+	t.Parallel()
+
+	t.Run("broadcast", func(t *testing.T) {
+		t.Parallel()
+
+		events := &eventLog{}
+		opt := WithInterceptors[string](&recordingInterceptor{name: "a", events: events})
+
+		assertInterceptedDelivery(t, NewBroadcastChannel[string](opt), events)
+	})
+
+	t.Run("topic", func(t *testing.T) {
+		t.Parallel()
+
+		events := &eventLog{}
+		opt := WithInterceptors[string](&recordingInterceptor{name: "a", events: events})
+
+		assertInterceptedDelivery(t, NewTopicChannel[string]("t", opt), events)
+	})
+
+	t.Run("queue", func(t *testing.T) {
+		t.Parallel()
+
+		events := &eventLog{}
+		opt := WithInterceptors[string](&recordingInterceptor{name: "a", events: events})
+
+		assertInterceptedDelivery(t, NewQueueChannel[string]("q", opt), events)
+	})
+
+	t.Run("scheduled", func(t *testing.T) {
+		t.Parallel()
+
+		events := &eventLog{}
+		opt := WithInterceptors[string](&recordingInterceptor{name: "a", events: events})
+
+		assertInterceptedDelivery(t, NewScheduledChannel[string]("s", opt), events)
+	})
+
+	t.Run("durable", func(t *testing.T) {
+		t.Parallel()
+
+		events := &eventLog{}
+		opt := WithInterceptors[string](&recordingInterceptor{name: "a", events: events})
+
+		assertInterceptedDelivery(t, NewDurableQueueChannel[string]("d", t.TempDir(), opt), events)
+	})
+
+	t.Run("PreHandle veto is a handler failure on async channels", func(t *testing.T) {
+		t.Parallel()
+
+		veto := errors.New("not for me")
+		dlq := NewPipelineChannel[DeadLetter[string]]()
+		letters := make(chan DeadLetter[string], 1)
+
+		_, _ = dlq.Subscribe(func(_ context.Context, msg Message[DeadLetter[string]]) error {
+			letters <- msg.Payload
+
+			return nil
+		})
+
+		ch := NewQueueChannel[string]("q",
+			WithInterceptors[string](InterceptorFuncs[string]{
+				PreHandleFn: func(_ context.Context, msg Message[string]) (Message[string], error) { return msg, veto },
+			}),
+			WithDLQChannel(dlq),
+			WithErrorHandler(SilentErrorHandler),
+		)
+
+		_, _ = ch.Subscribe(func(_ context.Context, _ Message[string]) error {
+			t.Error("handler must not run")
+
+			return nil
+		})
+
+		queue, _ := ch.(*queue[string])
+
+		err := queue.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		t.Cleanup(func() { _ = queue.Stop(context.Background()) })
+
+		err = ch.Send(context.Background(), Message[string]{Payload: "m"})
+		if err != nil {
+			t.Fatalf("send: %v", err)
+		}
+
+		select {
+		case letter := <-letters:
+			if !errors.Is(letter.LastError, ErrIntercepted) || !errors.Is(letter.LastError, veto) {
+				t.Fatalf("expected ErrIntercepted dead letter, got %v", letter.LastError)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("timed out waiting for the dead letter")
+		}
+	})
+
+	t.Run("send-only channels run the send hooks", func(t *testing.T) {
+		t.Parallel()
+
+		events := &eventLog{}
+		opt := WithInterceptors[string](&recordingInterceptor{name: "a", events: events})
+
+		err := NewNullChannel[string](opt, WithErrorHandler(SilentErrorHandler)).Send(context.Background(), Message[string]{})
+		if err != nil {
+			t.Fatalf("null send: %v", err)
+		}
+
+		pollable := NewPollableChannel[string](opt)
+
+		err = pollable.Send(context.Background(), Message[string]{Payload: "m"})
+		if err != nil {
+			t.Fatalf("pollable send: %v", err)
+		}
+
+		msg, err := pollable.Receive(context.Background())
+		if err != nil || msg.Payload != "m+a" {
+			t.Fatalf("expected the rewritten payload, got %q %v", msg.Payload, err)
+		}
+
+		want := []string{"a.PreSend", "a.PostSend", "a.PreSend", "a.PostSend"}
+		if events := events.snapshot(); !equalEvents(events, want...) {
+			t.Fatalf("expected %v, got %v", want, events)
+		}
+	})
+
+	t.Run("SendAfter runs the send hooks", func(t *testing.T) {
+		t.Parallel()
+
+		events := &eventLog{}
+		ch := NewScheduledChannel[string]("s", WithInterceptors[string](&recordingInterceptor{name: "a", events: events}))
+
+		err := ch.SendAfter(context.Background(), time.Hour, Message[string]{})
+		if err != nil {
+			t.Fatalf("send after: %v", err)
+		}
+
+		if events := events.snapshot(); !equalEvents(events, "a.PreSend", "a.PostSend") {
+			t.Fatalf("unexpected events %v", events)
+		}
+	})
+}
@@ -0,0 +1,58 @@
+package interceptors
+
+import (
+	"errors"
+	"fmt"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+	cerrs "github.com/guidomantilla/yarumo/core/common/errs"
+)
+
+// InterceptorType is the error domain identifier for interceptor
+// vetoes.
+const InterceptorType = "interceptor"
+
+var (
+	_ error = (*Error)(nil)
+)
+
+// Sentinel errors for interceptor vetoes.
+var (
+	// ErrInterceptorFailed is the top-level sentinel embedded in every
+	// interceptor-domain Error returned by ErrInterceptor.
+	ErrInterceptorFailed = errors.New("interceptor rejected message")
+	// ErrHeaderMissing indicates that a header required by
+	// RequireHeaders is empty.
+	ErrHeaderMissing = errors.New("required header missing")
+	// ErrHeaderInvalid indicates that the ValidateHeaders rule
+	// rejected the headers.
+	ErrHeaderInvalid = errors.New("headers invalid")
+	// ErrPayloadTooLarge indicates that the payload exceeds the
+	// MaxPayloadSize limit.
+	ErrPayloadTooLarge = errors.New("payload too large")
+)
+
+// Error is the domain error type for interceptor vetoes.
+type Error struct {
+	cerrs.TypedError
+}
+
+// Error returns the formatted error string including the type
+// classification.
+func (e *Error) Error() string {
+	cassert.NotNil(e, "error is nil")
+	cassert.NotNil(e.Err, "internal error is nil")
+
+	return fmt.Sprintf("interceptor %s error: %s", e.Type, e.Err)
+}
+
+// ErrInterceptor wraps the given causes into a domain Error joined
+// with ErrInterceptorFailed.
+func ErrInterceptor(causes ...error) error {
+	return &Error{
+		TypedError: cerrs.TypedError{
+			Type: InterceptorType,
+			Err:  errors.Join(append(causes, ErrInterceptorFailed)...),
+		},
+	}
+}
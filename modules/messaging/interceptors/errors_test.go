@@ -0,0 +1,49 @@
+package interceptors
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestError_Error(t *testing.T) {
+	t.Parallel()
+
+	t.Run("includes type prefix and joined causes", func(t *testing.T) {
+		t.Parallel()
+
+		err := ErrInterceptor(ErrHeaderMissing)
+
+		msg := err.Error()
+		if !strings.HasPrefix(msg, "interceptor "+InterceptorType) {
+			t.Fatalf("expected prefix %q, got %q", "interceptor "+InterceptorType, msg)
+		}
+
+		if !strings.Contains(msg, ErrHeaderMissing.Error()) {
+			t.Fatalf("expected cause %q in message, got %q", ErrHeaderMissing.Error(), msg)
+		}
+
+		if !strings.Contains(msg, ErrInterceptorFailed.Error()) {
+			t.Fatalf("expected sentinel %q in message, got %q", ErrInterceptorFailed.Error(), msg)
+		}
+	})
+
+	t.Run("ErrInterceptor joins all causes with ErrInterceptorFailed", func(t *testing.T) {
+		t.Parallel()
+
+		boom := errors.New("custom failure")
+
+		err := ErrInterceptor(ErrHeaderMissing, boom)
+		if !errors.Is(err, ErrInterceptorFailed) {
+			t.Fatal("expected ErrInterceptorFailed in chain")
+		}
+
+		if !errors.Is(err, ErrHeaderMissing) {
+			t.Fatal("expected ErrHeaderMissing in chain")
+		}
+
+		if !errors.Is(err, boom) {
+			t.Fatal("expected origin error in chain")
+		}
+	})
+}
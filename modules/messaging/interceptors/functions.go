@@ -0,0 +1,7 @@
+package interceptors
+
+// Len is the SizeFn of byte-slice and string payloads: their length
+// in bytes.
+func Len[T ~[]byte | ~string](payload T) int {
+	return len(payload)
+}
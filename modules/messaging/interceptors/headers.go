@@ -0,0 +1,105 @@
+package interceptors
+
+import (
+	"context"
+	"fmt"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+// presentFields maps the messaging.Headers field names RequireHeaders
+// recognises to a check for a non-zero value. Names absent from this
+// table are looked up in Headers.Custom.
+var presentFields = map[string]func(h messaging.Headers) bool{
+	"MessageID":      func(h messaging.Headers) bool { return h.MessageID != "" },
+	"CorrelationID":  func(h messaging.Headers) bool { return h.CorrelationID != "" },
+	"CausationID":    func(h messaging.Headers) bool { return h.CausationID != "" },
+	"ReplyTo":        func(h messaging.Headers) bool { return h.ReplyTo != "" },
+	"Type":           func(h messaging.Headers) bool { return h.Type != "" },
+	"Source":         func(h messaging.Headers) bool { return h.Source != "" },
+	"ContentType":    func(h messaging.Headers) bool { return h.ContentType != "" },
+	"Priority":       func(h messaging.Headers) bool { return h.Priority != 0 },
+	"ExpirationTime": func(h messaging.Headers) bool { return !h.ExpirationTime.IsZero() },
+	"Timestamp":      func(h messaging.Headers) bool { return !h.Timestamp.IsZero() },
+}
+
+// requireHeaders is the RequireHeaders interceptor.
+type requireHeaders[T any] struct {
+	messaging.InterceptorFuncs[T]
+
+	names []string
+}
+
+// RequireHeaders returns an interceptor whose PreSend vetoes messages
+// missing any of names. A name is either a messaging.Headers field
+// (MessageID, CorrelationID, CausationID, ReplyTo, Type, Source,
+// ContentType, Priority, ExpirationTime, Timestamp), which must hold a
+// non-zero value, or a Headers.Custom key, which must hold a non-nil
+// value other than "". The veto error is
+// ErrInterceptor(ErrHeaderMissing, ...) naming the first missing
+// header.
+func RequireHeaders[T any](names ...string) messaging.ChannelInterceptor[T] {
+	cassert.NotEmpty(names, "header names are empty")
+
+	return &requireHeaders[T]{names: names}
+}
+
+// PreSend vetoes msg when a required header is missing.
+func (r *requireHeaders[T]) PreSend(_ context.Context, msg messaging.Message[T]) (messaging.Message[T], error) {
+	cassert.NotNil(r, "interceptor is nil")
+
+	for _, name := range r.names {
+		if !headerPresent(msg.Headers, name) {
+			return msg, ErrInterceptor(fmt.Errorf("%w: %s", ErrHeaderMissing, name))
+		}
+	}
+
+	return msg, nil
+}
+
+// validateHeaders is the ValidateHeaders interceptor.
+type validateHeaders[T any] struct {
+	messaging.InterceptorFuncs[T]
+
+	validate ValidateFn
+}
+
+// ValidateHeaders returns an interceptor whose PreSend vetoes messages
+// whose headers validate rejects, for rules RequireHeaders cannot
+// express (formats, allowed values, combinations). The veto error is
+// ErrInterceptor(ErrHeaderInvalid, err).
+func ValidateHeaders[T any](validate ValidateFn) messaging.ChannelInterceptor[T] {
+	cassert.NotNil(validate, "validate function is nil")
+
+	return &validateHeaders[T]{validate: validate}
+}
+
+// PreSend vetoes msg when validate returns an error.
+func (v *validateHeaders[T]) PreSend(_ context.Context, msg messaging.Message[T]) (messaging.Message[T], error) {
+	cassert.NotNil(v, "interceptor is nil")
+
+	err := v.validate(msg.Headers)
+	if err != nil {
+		return msg, ErrInterceptor(ErrHeaderInvalid, err)
+	}
+
+	return msg, nil
+}
+
+// headerPresent reports whether the header called name holds a value.
+func headerPresent(headers messaging.Headers, name string) bool {
+	present, ok := presentFields[name]
+	if ok {
+		return present(headers)
+	}
+
+	value, ok := headers.Custom[name]
+	if !ok || value == nil {
+		return false
+	}
+
+	s, ok := value.(string)
+
+	return !ok || s != ""
+}
@@ -0,0 +1,137 @@
+package interceptors
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+// assertHeaderMissing fails t unless RequireHeaders("Source",
+// "Priority", "tenant") vetoes a message carrying headers with an
+// ErrHeaderMissing that names missing.
+func assertHeaderMissing(t *testing.T, headers messaging.Headers, missing string) {
+	t.Helper()
+
+	interceptor := RequireHeaders[int]("Source", "Priority", "tenant")
+
+	_, err := interceptor.PreSend(context.Background(), messaging.Message[int]{Headers: headers})
+	if !errors.Is(err, ErrHeaderMissing) || !errors.Is(err, ErrInterceptorFailed) {
+		t.Fatalf("expected ErrHeaderMissing, got %v", err)
+	}
+
+	if !strings.Contains(err.Error(), missing) {
+		t.Fatalf("expected %q in %q", missing, err.Error())
+	}
+}
+
+func TestRequireHeaders(t *testing.T) {
+	t.Parallel()
+
+	t.Run("accepts messages carrying every header", func(t *testing.T) {
+		t.Parallel()
+
+		interceptor := RequireHeaders[int]("Source", "Priority", "ExpirationTime", "tenant", "region")
+
+		msg := messaging.Message[int]{Headers: messaging.Headers{
+			Source:         "orders",
+			Priority:       3,
+			ExpirationTime: time.Now(),
+			Custom:         map[string]any{"tenant": "acme", "region": 1},
+		}}
+
+		out, err := interceptor.PreSend(context.Background(), msg)
+		if err != nil {
+			t.Fatalf("expected no veto, got %v", err)
+		}
+
+		if out.Headers.Source != "orders" {
+			t.Fatal("expected the message unchanged")
+		}
+	})
+
+	t.Run("vetoes empty field", func(t *testing.T) {
+		t.Parallel()
+
+		assertHeaderMissing(t, messaging.Headers{}, "Source")
+	})
+
+	t.Run("vetoes zero priority", func(t *testing.T) {
+		t.Parallel()
+
+		assertHeaderMissing(t, messaging.Headers{Source: "s"}, "Priority")
+	})
+
+	t.Run("vetoes absent custom key", func(t *testing.T) {
+		t.Parallel()
+
+		assertHeaderMissing(t, messaging.Headers{Source: "s", Priority: 1}, "tenant")
+	})
+
+	t.Run("vetoes empty custom string", func(t *testing.T) {
+		t.Parallel()
+
+		assertHeaderMissing(t, messaging.Headers{Source: "s", Priority: 1, Custom: map[string]any{"tenant": ""}}, "tenant")
+	})
+
+	t.Run("vetoes nil custom value", func(t *testing.T) {
+		t.Parallel()
+
+		assertHeaderMissing(t, messaging.Headers{Source: "s", Priority: 1, Custom: map[string]any{"tenant": nil}}, "tenant")
+	})
+
+	t.Run("vetoes Send on a channel", func(t *testing.T) {
+		t.Parallel()
+
+		ch := messaging.NewPipelineChannel[int](messaging.WithInterceptors(RequireHeaders[int]("Source")))
+
+		calls := 0
+
+		_, _ = ch.Subscribe(func(_ context.Context, _ messaging.Message[int]) error {
+			calls++
+
+			return nil
+		})
+
+		err := ch.Send(context.Background(), messaging.Message[int]{})
+		if !errors.Is(err, messaging.ErrIntercepted) || !errors.Is(err, ErrHeaderMissing) {
+			t.Fatalf("expected ErrIntercepted with ErrHeaderMissing, got %v", err)
+		}
+
+		err = ch.Send(context.Background(), messaging.Message[int]{Headers: messaging.Headers{Source: "orders"}})
+		if err != nil {
+			t.Fatalf("send: %v", err)
+		}
+
+		if calls != 1 {
+			t.Fatalf("expected only the valid message to be handled, got %d", calls)
+		}
+	})
+}
+
+func TestValidateHeaders(t *testing.T) {
+	t.Parallel()
+
+	rule := errors.New("type must be namespaced")
+
+	interceptor := ValidateHeaders[int](func(headers messaging.Headers) error {
+		if !strings.Contains(headers.Type, ".") {
+			return rule
+		}
+
+		return nil
+	})
+
+	_, err := interceptor.PreSend(context.Background(), messaging.Message[int]{Headers: messaging.Headers{Type: "order.created"}})
+	if err != nil {
+		t.Fatalf("expected no veto, got %v", err)
+	}
+
+	_, err = interceptor.PreSend(context.Background(), messaging.Message[int]{Headers: messaging.Headers{Type: "created"}})
+	if !errors.Is(err, ErrHeaderInvalid) || !errors.Is(err, rule) {
+		t.Fatalf("expected ErrHeaderInvalid with the rule error, got %v", err)
+	}
+}
@@ -0,0 +1,42 @@
+package interceptors
+
+import (
+	"context"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+	"github.com/guidomantilla/yarumo/messaging"
+	"github.com/guidomantilla/yarumo/messaging/patterns/sysmgmt/history"
+)
+
+// historyTrail is the History interceptor.
+type historyTrail[T any] struct {
+	messaging.InterceptorFuncs[T]
+
+	name string
+	key  string
+}
+
+// History returns an interceptor whose PreSend appends name to the
+// Message History trail of every sent message, exactly as a
+// history.History endpoint would (see history.Append). Use it to
+// record the channels a message crossed without inserting an endpoint
+// between them. name is usually the channel's name and must be
+// non-empty.
+//
+// Options:
+//   - WithHistoryKey: Headers.Custom key of the trail (default
+//     history.DefaultHistoryKey).
+func History[T any](name string, opts ...Option) messaging.ChannelInterceptor[T] {
+	cassert.NotEmpty(name, "name is empty")
+
+	options := NewOptions(opts...)
+
+	return &historyTrail[T]{name: name, key: options.historyKey}
+}
+
+// PreSend returns msg with the name appended to its trail.
+func (h *historyTrail[T]) PreSend(_ context.Context, msg messaging.Message[T]) (messaging.Message[T], error) {
+	cassert.NotNil(h, "interceptor is nil")
+
+	return history.Append(msg, h.key, h.name), nil
+}
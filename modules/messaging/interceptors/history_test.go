@@ -0,0 +1,59 @@
+package interceptors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/guidomantilla/yarumo/messaging"
+	"github.com/guidomantilla/yarumo/messaging/patterns/sysmgmt/history"
+)
+
+func TestHistory(t *testing.T) {
+	t.Parallel()
+
+	t.Run("stamps every channel the message crosses", func(t *testing.T) {
+		t.Parallel()
+
+		second := messaging.NewPipelineChannel[int](messaging.WithInterceptors(History[int]("second")))
+		first := messaging.NewPipelineChannel[int](messaging.WithInterceptors(History[int]("first")))
+
+		var trail []string
+
+		_, _ = first.Subscribe(func(ctx context.Context, msg messaging.Message[int]) error {
+			return second.Send(ctx, msg)
+		})
+		_, _ = second.Subscribe(func(_ context.Context, msg messaging.Message[int]) error {
+			trail, _ = msg.Headers.Custom[history.DefaultHistoryKey].([]string)
+
+			return nil
+		})
+
+		in := messaging.Message[int]{Payload: 1}
+
+		err := first.Send(context.Background(), in)
+		if err != nil {
+			t.Fatalf("send: %v", err)
+		}
+
+		if len(trail) != 2 || trail[0] != "first" || trail[1] != "second" {
+			t.Fatalf("expected [first second], got %v", trail)
+		}
+
+		if in.Headers.Custom != nil {
+			t.Fatal("expected the caller's message untouched")
+		}
+	})
+
+	t.Run("honours WithHistoryKey", func(t *testing.T) {
+		t.Parallel()
+
+		out, err := History[int]("orders", WithHistoryKey("trail")).PreSend(context.Background(), messaging.Message[int]{})
+		if err != nil {
+			t.Fatalf("pre send: %v", err)
+		}
+
+		if trail, ok := out.Headers.Custom["trail"].([]string); !ok || len(trail) != 1 || trail[0] != "orders" {
+			t.Fatalf("expected [orders] under trail, got %v", out.Headers.Custom)
+		}
+	})
+}
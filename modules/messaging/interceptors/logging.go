@@ -0,0 +1,97 @@
+package interceptors
+
+import (
+	"context"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+	clog "github.com/guidomantilla/yarumo/core/common/log"
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+// logging is the Logging interceptor.
+type logging[T any] struct {
+	messaging.InterceptorFuncs[T]
+
+	name   string
+	logger clog.Logger
+}
+
+// Logging returns an interceptor that logs every Send outcome
+// (PostSend) and every handler outcome (AfterHandle): successes at
+// Info, failures — vetoes included — at Error with the error. Each
+// line carries the channel name and the message's MessageID,
+// CorrelationID and Type. name identifies the channel in the logs and
+// must be non-empty.
+//
+// Options:
+//   - WithLogger: logger to write to (default the process-global
+//     common/log logger).
+func Logging[T any](name string, opts ...Option) messaging.ChannelInterceptor[T] {
+	cassert.NotEmpty(name, "name is empty")
+
+	options := NewOptions(opts...)
+
+	return &logging[T]{name: name, logger: options.logger}
+}
+
+// PostSend logs the Send outcome.
+func (l *logging[T]) PostSend(ctx context.Context, msg messaging.Message[T], err error) {
+	cassert.NotNil(l, "interceptor is nil")
+
+	if err != nil {
+		l.error(ctx, "message send failed", msg, err)
+
+		return
+	}
+
+	l.info(ctx, "message sent", msg)
+}
+
+// AfterHandle logs the handler outcome.
+func (l *logging[T]) AfterHandle(ctx context.Context, msg messaging.Message[T], err error) {
+	cassert.NotNil(l, "interceptor is nil")
+
+	if err != nil {
+		l.error(ctx, "message handling failed", msg, err)
+
+		return
+	}
+
+	l.info(ctx, "message handled", msg)
+}
+
+// info logs text at Info level.
+func (l *logging[T]) info(ctx context.Context, text string, msg messaging.Message[T]) {
+	args := l.args(msg)
+
+	if l.logger != nil {
+		l.logger.Info(ctx, text, args...)
+
+		return
+	}
+
+	clog.Info(ctx, text, args...)
+}
+
+// error logs text and err at Error level.
+func (l *logging[T]) error(ctx context.Context, text string, msg messaging.Message[T], err error) {
+	args := append(l.args(msg), "error", err.Error())
+
+	if l.logger != nil {
+		l.logger.Error(ctx, text, args...)
+
+		return
+	}
+
+	clog.Error(ctx, text, args...)
+}
+
+// args returns the key/value pairs every log line carries.
+func (l *logging[T]) args(msg messaging.Message[T]) []any {
+	return []any{
+		"channel", l.name,
+		"message_id", msg.Headers.MessageID,
+		"correlation_id", msg.Headers.CorrelationID,
+		"type", msg.Headers.Type,
+	}
+}
@@ -0,0 +1,141 @@
+package interceptors
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+// logLine is one line written to spyLogger.
+type logLine struct {
+	level string
+	msg   string
+	args  []any
+}
+
+// spyLogger records every Info and Error line.
+type spyLogger struct {
+	mu    sync.Mutex
+	lines []logLine
+}
+
+func (s *spyLogger) record(level string, msg string, args []any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lines = append(s.lines, logLine{level: level, msg: msg, args: args})
+}
+
+func (s *spyLogger) snapshot() []logLine {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]logLine(nil), s.lines...)
+}
+
+func (s *spyLogger) Trace(_ context.Context, _ string, _ ...any) {}
+func (s *spyLogger) Debug(_ context.Context, _ string, _ ...any) {}
+func (s *spyLogger) Warn(_ context.Context, _ string, _ ...any)  {}
+func (s *spyLogger) Fatal(_ context.Context, _ string, _ ...any) {}
+
+func (s *spyLogger) Info(_ context.Context, msg string, args ...any) {
+	s.record("info", msg, args)
+}
+
+func (s *spyLogger) Error(_ context.Context, msg string, args ...any) {
+	s.record("error", msg, args)
+}
+
+// arg returns the value logged under key.
+func (l logLine) arg(key string) any {
+	for i := 0; i+1 < len(l.args); i += 2 {
+		if l.args[i] == key {
+			return l.args[i+1]
+		}
+	}
+
+	return nil
+}
+
+func TestLogging(t *testing.T) {
+	t.Parallel()
+
+	t.Run("logs sends and handler outcomes", func(t *testing.T) {
+		t.Parallel()
+
+		spy := &spyLogger{}
+		boom := errors.New("boom")
+
+		ch := messaging.NewPipelineChannel[int](messaging.WithInterceptors(Logging[int]("orders", WithLogger(spy))))
+
+		_, _ = ch.Subscribe(func(_ context.Context, msg messaging.Message[int]) error {
+			if msg.Payload == 2 {
+				return boom
+			}
+
+			return nil
+		})
+
+		_ = ch.Send(context.Background(), messaging.Message[int]{Payload: 1, Headers: messaging.Headers{MessageID: "m-1", Type: "order.created"}})
+		_ = ch.Send(context.Background(), messaging.Message[int]{Payload: 2, Headers: messaging.Headers{MessageID: "m-2"}})
+
+		lines := spy.snapshot()
+
+		want := []struct{ level, msg, id string }{
+			{"info", "message handled", "m-1"},
+			{"info", "message sent", "m-1"},
+			{"error", "message handling failed", "m-2"},
+			{"error", "message send failed", "m-2"},
+		}
+		if len(lines) != len(want) {
+			t.Fatalf("expected %d lines, got %+v", len(want), lines)
+		}
+
+		for i, w := range want {
+			if lines[i].level != w.level || lines[i].msg != w.msg || lines[i].arg("message_id") != w.id || lines[i].arg("channel") != "orders" {
+				t.Fatalf("line %d: expected %+v, got %+v", i, w, lines[i])
+			}
+		}
+
+		if lines[0].arg("type") != "order.created" {
+			t.Fatalf("expected the type attribute, got %+v", lines[0])
+		}
+
+		if lines[2].arg("error") != boom.Error() {
+			t.Fatalf("expected the handler error, got %+v", lines[2])
+		}
+	})
+
+	t.Run("logs vetoes of later interceptors", func(t *testing.T) {
+		t.Parallel()
+
+		spy := &spyLogger{}
+
+		ch := messaging.NewPipelineChannel[int](messaging.WithInterceptors(
+			Logging[int]("orders", WithLogger(spy)),
+			RequireHeaders[int]("Source"),
+		))
+
+		err := ch.Send(context.Background(), messaging.Message[int]{})
+		if !errors.Is(err, ErrHeaderMissing) {
+			t.Fatalf("expected a veto, got %v", err)
+		}
+
+		lines := spy.snapshot()
+		if len(lines) != 1 || lines[0].msg != "message send failed" {
+			t.Fatalf("expected the veto to be logged, got %+v", lines)
+		}
+	})
+
+	t.Run("falls back to the global logger", func(t *testing.T) {
+		t.Parallel()
+
+		interceptor := Logging[int]("orders")
+
+		interceptor.PostSend(context.Background(), messaging.Message[int]{}, nil)
+		interceptor.AfterHandle(context.Background(), messaging.Message[int]{}, errors.New("boom"))
+	})
+}
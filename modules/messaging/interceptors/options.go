@@ -0,0 +1,53 @@
+package interceptors
+
+import (
+	clog "github.com/guidomantilla/yarumo/core/common/log"
+	"github.com/guidomantilla/yarumo/messaging/patterns/sysmgmt/history"
+)
+
+// Option is a functional option for configuring interceptor Options.
+// The interceptors share one Option type; each option documents the
+// interceptor it applies to and is ignored by the others.
+type Option func(opts *Options)
+
+// Options holds the configuration for the stock interceptors.
+type Options struct {
+	historyKey string
+	logger     clog.Logger
+}
+
+// NewOptions creates a new Options with sensible defaults and applies
+// the given options. Defaults: history.DefaultHistoryKey and the
+// process-global common/log logger.
+func NewOptions(opts ...Option) *Options {
+	options := &Options{
+		historyKey: history.DefaultHistoryKey,
+	}
+
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	return options
+}
+
+// WithHistoryKey overrides the Headers.Custom map key History stamps
+// the trail under (default history.DefaultHistoryKey). Empty strings
+// are ignored.
+func WithHistoryKey(key string) Option {
+	return func(opts *Options) {
+		if key != "" {
+			opts.historyKey = key
+		}
+	}
+}
+
+// WithLogger makes Logging write to logger instead of the
+// process-global common/log logger. Nil values are ignored.
+func WithLogger(logger clog.Logger) Option {
+	return func(opts *Options) {
+		if logger != nil {
+			opts.logger = logger
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package interceptors
+
+import (
+	"testing"
+
+	"github.com/guidomantilla/yarumo/messaging/patterns/sysmgmt/history"
+)
+
+func TestNewOptions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("applies defaults", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions()
+
+		if opts.historyKey != history.DefaultHistoryKey || opts.logger != nil {
+			t.Fatalf("unexpected defaults %+v", opts)
+		}
+	})
+
+	t.Run("applies options", func(t *testing.T) {
+		t.Parallel()
+
+		spy := &spyLogger{}
+		opts := NewOptions(WithHistoryKey("trail"), WithLogger(spy))
+
+		if opts.historyKey != "trail" || opts.logger != spy {
+			t.Fatalf("unexpected options %+v", opts)
+		}
+	})
+
+	t.Run("ignores empty values", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithHistoryKey(""), WithLogger(nil))
+
+		if opts.historyKey != history.DefaultHistoryKey || opts.logger != nil {
+			t.Fatalf("expected defaults to be preserved, got %+v", opts)
+		}
+	})
+}
@@ -0,0 +1,42 @@
+package interceptors
+
+import (
+	"context"
+	"fmt"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+// maxPayloadSize is the MaxPayloadSize interceptor.
+type maxPayloadSize[T any] struct {
+	messaging.InterceptorFuncs[T]
+
+	limit int
+	size  SizeFn[T]
+}
+
+// MaxPayloadSize returns an interceptor whose PreSend vetoes messages
+// whose payload measures more than limit according to size. Pass Len
+// for []byte and string payloads; for typed payloads pass a function
+// that measures the encoded form, e.g. the length of the codec output.
+// The veto error is ErrInterceptor(ErrPayloadTooLarge, ...). limit
+// must be positive.
+func MaxPayloadSize[T any](limit int, size SizeFn[T]) messaging.ChannelInterceptor[T] {
+	cassert.True(limit > 0, "limit is not positive")
+	cassert.NotNil(size, "size function is nil")
+
+	return &maxPayloadSize[T]{limit: limit, size: size}
+}
+
+// PreSend vetoes msg when its payload is larger than the limit.
+func (m *maxPayloadSize[T]) PreSend(_ context.Context, msg messaging.Message[T]) (messaging.Message[T], error) {
+	cassert.NotNil(m, "interceptor is nil")
+
+	size := m.size(msg.Payload)
+	if size > m.limit {
+		return msg, ErrInterceptor(fmt.Errorf("%w: %d > %d", ErrPayloadTooLarge, size, m.limit))
+	}
+
+	return msg, nil
+}
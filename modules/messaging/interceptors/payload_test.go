@@ -0,0 +1,35 @@
+package interceptors
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+func TestMaxPayloadSize(t *testing.T) {
+	t.Parallel()
+
+	interceptor := MaxPayloadSize[[]byte](4, Len[[]byte])
+
+	_, err := interceptor.PreSend(context.Background(), messaging.Message[[]byte]{Payload: []byte("1234")})
+	if err != nil {
+		t.Fatalf("expected a payload at the limit to pass, got %v", err)
+	}
+
+	_, err = interceptor.PreSend(context.Background(), messaging.Message[[]byte]{Payload: []byte("12345")})
+	if !errors.Is(err, ErrPayloadTooLarge) || !errors.Is(err, ErrInterceptorFailed) {
+		t.Fatalf("expected ErrPayloadTooLarge, got %v", err)
+	}
+}
+
+func TestLen(t *testing.T) {
+	t.Parallel()
+
+	type text string
+
+	if Len([]byte("abc")) != 3 || Len("héllo") != 6 || Len(text("ab")) != 2 {
+		t.Fatal("expected byte lengths")
+	}
+}
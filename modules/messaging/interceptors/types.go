@@ -0,0 +1,64 @@
+// Package interceptors provides stock messaging.ChannelInterceptor[T]
+// implementations, installed on any channel of the messaging package
+// through messaging.WithInterceptors:
+//
+//   - RequireHeaders vetoes Send when a header is empty, e.g. to make
+//     every message carry Headers.Source; ValidateHeaders runs an
+//     arbitrary rule over the headers.
+//   - History stamps the channel's name onto the Message History trail
+//     (see patterns/sysmgmt/history) of every sent message.
+//   - MaxPayloadSize vetoes Send when the payload exceeds a limit.
+//   - Logging logs every Send and every handler outcome via
+//     common/log.
+//
+// # Vetoes
+//
+// The validating interceptors act in PreSend, so an invalid message is
+// rejected at the publisher: Send returns
+// messaging.ErrSend(messaging.ErrIntercepted, cause), where cause is an
+// ErrInterceptor error carrying the specific sentinel
+// (ErrHeaderMissing, ErrHeaderInvalid, ErrPayloadTooLarge). Nothing is
+// enqueued, dead-lettered or reported to the channel's ErrorHandler.
+//
+// # Ordering
+//
+// Pre hooks run in registration order, so put rewriting interceptors
+// (History) before the ones that should see their result, and
+// Logging first to observe every veto:
+//
+//	messaging.NewQueueChannel[Order]("orders",
+//		messaging.WithInterceptors[Order](
+//			interceptors.Logging[Order]("orders"),
+//			interceptors.RequireHeaders[Order]("Source", "Type"),
+//			interceptors.History[Order]("orders"),
+//		),
+//	)
+package interceptors
+
+import (
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+var (
+	_ messaging.ChannelInterceptor[any] = (*requireHeaders[any])(nil)
+	_ messaging.ChannelInterceptor[any] = (*validateHeaders[any])(nil)
+	_ messaging.ChannelInterceptor[any] = (*historyTrail[any])(nil)
+	_ messaging.ChannelInterceptor[any] = (*maxPayloadSize[any])(nil)
+	_ messaging.ChannelInterceptor[any] = (*logging[any])(nil)
+
+	_ SizeFn[[]byte] = Len[[]byte]
+	_ SizeFn[string] = Len[string]
+
+	_ ErrInterceptorFn = ErrInterceptor
+)
+
+// SizeFn measures a payload for MaxPayloadSize, in whatever unit the
+// limit is expressed in (usually bytes).
+type SizeFn[T any] func(payload T) int
+
+// ValidateFn checks the headers of a message for ValidateHeaders. A
+// non-nil error vetoes the Send.
+type ValidateFn func(headers messaging.Headers) error
+
+// ErrInterceptorFn is the function type for ErrInterceptor.
+type ErrInterceptorFn func(causes ...error) error
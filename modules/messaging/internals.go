@@ -49,6 +49,25 @@ func invokeHandler[T any](ctx context.Context, msg Message[T], handler Handler[T
 	return handler(ctx, msg)
 }
 
+// selectSubscriber asks balancer for a candidate index with panic
+// recovery: Select runs user code (a custom LoadBalancer, or the key
+// function of StickyByKey) on a worker goroutine, usually under the
+// channel's lock. A panic selects index 0 and returns an
+// ErrLoadBalancerPanic-wrapping error.
+func selectSubscriber(balancer LoadBalancer, headers Headers, candidates []SubscriberState) (idx int, err error) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+
+		idx = 0
+		err = fmt.Errorf("%w: %v", ErrLoadBalancerPanic, r)
+	}()
+
+	return balancer.Select(headers, candidates), nil
+}
+
 // invokeStep runs one pipeline handler with panic recovery and timing.
 // The work runs inside an anonymous function so the defer-recover lands
 // BEFORE the outer return captures the StepResult value — keeping the
@@ -174,6 +193,35 @@ func sendBlock[T any](ctx context.Context, env envelope[T], inbound chan envelop
 	}
 }
 
+// expiredHook returns the hook expired messages are reported to: the
+// WithExpiredHandler hook when installed, the ErrorHandler otherwise,
+// so expirations stay visible without explicit wiring.
+func expiredHook(options *Options) ErrorHandler {
+	if options.expiredHandler != nil {
+		return options.expiredHandler
+	}
+
+	return options.errorHandler
+}
+
+// dropExpired reports whether msg has expired at now. An expired
+// message is routed to hook with ErrExpired joined with ErrDropped and,
+// when dlq is configured, published as a DeadLetter whose LastError is
+// ErrExpired. Callers skip dispatch when it returns true.
+func dropExpired[T any](ctx context.Context, msg Message[T], now time.Time, hook ErrorHandler, dlq Channel[DeadLetter[T]]) bool {
+	if !IsExpired(msg, now) {
+		return false
+	}
+
+	if hook != nil {
+		hook(ctx, msg, errors.Join(ErrExpired, ErrDropped))
+	}
+
+	PublishDeadLetter(ctx, dlq, msg, ErrExpired, now)
+
+	return true
+}
+
 // extractDLQ converts the type-erased Options.dlq field into a
 // concrete Channel[DeadLetter[T]] at channel construction time.
 // Returns nil when raw is nil (no DLQ configured); panics via cassert
@@ -191,23 +239,6 @@ func extractDLQ[T any](raw any) Channel[DeadLetter[T]] {
 	return typed
 }
 
-// publishDeadLetter wraps the publish-to-DLQ side-effect used by
-// Topic and Queue dispatchers when a handler returns an error. The
-// Send error is intentionally swallowed — DLQ-of-DLQ is out of scope
-// and the worker already reported the underlying handler error via
-// the ErrorHandler hook.
-func publishDeadLetter[T any](ctx context.Context, dlq Channel[DeadLetter[T]], msg Message[T], handlerErr error) {
-	if dlq == nil {
-		return
-	}
-
-	_ = dlq.Send(ctx, NewMessage(DeadLetter[T]{
-		Original:  msg,
-		LastError: handlerErr,
-		FailedAt:  time.Now(),
-	}, nil))
-}
-
 // generateID returns uid.Generate() or empty when uid is nil or the
 // generator fails. Used by NewMessage to populate MessageID and
 // CorrelationID independently so a failure on one does not abort the
@@ -0,0 +1,329 @@
+package messaging
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// mailbox is the bounded buffer between Send and the dispatch workers
+// of the async channels. QueueChannel owns one; TopicChannel owns one
+// per subscriber. The FIFO implementation wraps a Go channel; the
+// priority implementation (DispatchPriority) is a heap ordered by
+// Headers.Priority with aging.
+type mailbox[T any] interface {
+	// put enqueues msg applying the overflow policy when the mailbox is
+	// full. hook observes drops.
+	put(ctx context.Context, msg Message[T], policy OverflowPolicy, hook ErrorHandler) error
+	// take blocks until an envelope is available and returns it. It
+	// returns false once the mailbox is closed and drained, or as soon
+	// as stop is closed. A nil stop never fires.
+	take(stop <-chan struct{}) (envelope[T], bool)
+	// close stops accepting messages and wakes every waiter. Envelopes
+	// already buffered remain available to take.
+	close()
+	// size returns the number of buffered envelopes and the bound.
+	size() (length int, capacity int)
+}
+
+// newMailbox returns the mailbox implementation selected by order.
+func newMailbox[T any](size int, order DispatchOrder, aging time.Duration, clock Clock) mailbox[T] {
+	if order == DispatchPriority {
+		return newPriorityMailbox[T](size, aging, clock)
+	}
+
+	return newFIFOMailbox[T](size)
+}
+
+// fifoMailbox is the first-in first-out mailbox backed by a buffered
+// Go channel.
+type fifoMailbox[T any] struct {
+	ch chan envelope[T]
+}
+
+// newFIFOMailbox returns a FIFO mailbox holding up to size envelopes.
+func newFIFOMailbox[T any](size int) *fifoMailbox[T] {
+	return &fifoMailbox[T]{ch: make(chan envelope[T], size)}
+}
+
+// put enqueues msg via sendWithPolicy.
+func (m *fifoMailbox[T]) put(ctx context.Context, msg Message[T], policy OverflowPolicy, hook ErrorHandler) error {
+	return sendWithPolicy(ctx, m.ch, msg, policy, hook)
+}
+
+// take receives the next envelope in arrival order.
+func (m *fifoMailbox[T]) take(stop <-chan struct{}) (envelope[T], bool) {
+	select {
+	case env, ok := <-m.ch:
+		return env, ok
+	case <-stop:
+		return envelope[T]{}, false
+	}
+}
+
+// close closes the underlying channel.
+func (m *fifoMailbox[T]) close() {
+	close(m.ch)
+}
+
+// size reports the length and capacity of the underlying channel.
+func (m *fifoMailbox[T]) size() (int, int) {
+	return len(m.ch), cap(m.ch)
+}
+
+// priorityMailbox is the mailbox behind DispatchPriority: take always
+// returns the envelope with the highest effective priority.
+//
+// The effective priority of an envelope is its Headers.Priority plus
+// one level per aging interval it has waited. Because every waiting
+// envelope ages at the same rate, comparing effective priorities at
+// any instant is equivalent to comparing the static rank
+// enqueuedAt - priority*aging (lower rank wins), so the heap never has
+// to be re-ordered as time passes. Ties fall back to arrival order.
+type priorityMailbox[T any] struct {
+	capacity int
+	aging    time.Duration
+	clock    Clock
+
+	// mu guards items, seq, closed and changed. changed is closed and
+	// replaced whenever items shrinks, grows or the mailbox closes, so
+	// idle takers and blocked senders can wait on it alongside their
+	// own cancellation signal.
+	mu      sync.Mutex
+	items   priorityHeap[T]
+	seq     uint64
+	closed  bool
+	changed chan struct{}
+}
+
+// prioritized is one buffered envelope with its ordering keys.
+type prioritized[T any] struct {
+	env      envelope[T]
+	priority uint8
+	rank     int64
+	seq      uint64
+}
+
+// newPriorityMailbox returns a priority mailbox holding up to size
+// envelopes, aging waiting envelopes by one priority level per aging
+// as measured by clock.
+func newPriorityMailbox[T any](size int, aging time.Duration, clock Clock) *priorityMailbox[T] {
+	// Defensive: WithPriorityAging rejects non-positive values, but a
+	// zero aging would order by arrival only. The upper clamp keeps
+	// priority*aging far from int64 overflow.
+	if aging <= 0 {
+		aging = defaultPriorityAging
+	}
+
+	aging = min(aging, maxPriorityAging)
+
+	return &priorityMailbox[T]{
+		capacity: size,
+		aging:    aging,
+		clock:    clock,
+		changed:  make(chan struct{}),
+	}
+}
+
+// put enqueues msg. When the mailbox is full the overflow policy
+// applies as for the FIFO mailbox, except that OverflowDropOldest
+// evicts the buffered envelope with the LOWEST Headers.Priority (the
+// oldest among equals) — or drops msg itself when its priority is
+// lower than everything buffered.
+func (m *priorityMailbox[T]) put(ctx context.Context, msg Message[T], policy OverflowPolicy, hook ErrorHandler) error {
+	env := envelope[T]{sendCtx: ctx, msg: msg}
+
+	for {
+		m.mu.Lock()
+
+		if m.closed {
+			m.mu.Unlock()
+
+			return ErrSend(ErrClosed)
+		}
+
+		if len(m.items) < m.capacity {
+			m.push(env)
+			m.notifyLocked()
+			m.mu.Unlock()
+
+			return nil
+		}
+
+		switch policy {
+		case OverflowReject:
+			m.mu.Unlock()
+
+			return ErrSend(ErrBufferFull)
+		case OverflowDropNewest:
+			m.mu.Unlock()
+			reportDrop(ctx, hook, msg)
+
+			return nil
+		case OverflowDropOldest:
+			evicted, ok := m.evictBelow(msg.Headers.Priority)
+			if ok {
+				m.push(env)
+				m.notifyLocked()
+			}
+			m.mu.Unlock()
+
+			if !ok {
+				reportDrop(ctx, hook, msg)
+
+				return nil
+			}
+
+			reportDrop(ctx, hook, evicted.msg)
+
+			return nil
+		}
+
+		changed := m.changed
+		m.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return ErrSend(ErrTimeout, ctx.Err())
+		}
+	}
+}
+
+// take pops the envelope with the highest effective priority.
+func (m *priorityMailbox[T]) take(stop <-chan struct{}) (envelope[T], bool) {
+	for {
+		m.mu.Lock()
+
+		if len(m.items) > 0 {
+			item := heap.Pop(&m.items).(*prioritized[T])
+			m.notifyLocked()
+			m.mu.Unlock()
+
+			return item.env, true
+		}
+
+		if m.closed {
+			m.mu.Unlock()
+
+			return envelope[T]{}, false
+		}
+
+		changed := m.changed
+		m.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-stop:
+			return envelope[T]{}, false
+		}
+	}
+}
+
+// close marks the mailbox closed and wakes every waiter.
+func (m *priorityMailbox[T]) close() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.closed = true
+	m.notifyLocked()
+}
+
+// size reports the number of buffered envelopes and the capacity.
+func (m *priorityMailbox[T]) size() (int, int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return len(m.items), m.capacity
+}
+
+// push inserts env with its ordering keys. mu must be held.
+func (m *priorityMailbox[T]) push(env envelope[T]) {
+	m.seq++
+	priority := env.msg.Headers.Priority
+
+	heap.Push(&m.items, &prioritized[T]{
+		env:      env,
+		priority: priority,
+		rank:     m.clock.Now().UnixNano() - int64(priority)*int64(m.aging),
+		seq:      m.seq,
+	})
+}
+
+// evictBelow removes and returns the buffered envelope with the lowest
+// Headers.Priority (oldest among equals), provided that priority is not
+// higher than incoming. It returns false when every buffered envelope
+// outranks incoming. mu must be held.
+func (m *priorityMailbox[T]) evictBelow(incoming uint8) (envelope[T], bool) {
+	victim := -1
+
+	for i, item := range m.items {
+		if victim < 0 || item.priority < m.items[victim].priority ||
+			(item.priority == m.items[victim].priority && item.seq < m.items[victim].seq) {
+			victim = i
+		}
+	}
+
+	if victim < 0 || m.items[victim].priority > incoming {
+		return envelope[T]{}, false
+	}
+
+	item := heap.Remove(&m.items, victim).(*prioritized[T])
+
+	return item.env, true
+}
+
+// notifyLocked wakes every goroutine waiting on the current changed
+// channel and installs a fresh one. mu must be held.
+func (m *priorityMailbox[T]) notifyLocked() {
+	close(m.changed)
+	m.changed = make(chan struct{})
+}
+
+// reportDrop fires hook for a message dropped by the overflow policy.
+func reportDrop[T any](ctx context.Context, hook ErrorHandler, msg Message[T]) {
+	if hook != nil {
+		hook(ctx, msg, errors.Join(ErrOverflow, ErrDropped))
+	}
+}
+
+// priorityHeap implements heap.Interface over prioritized envelopes:
+// lowest rank first, then lowest sequence number.
+type priorityHeap[T any] []*prioritized[T]
+
+// Len returns the number of buffered envelopes.
+func (h priorityHeap[T]) Len() int {
+	return len(h)
+}
+
+// Less orders by rank, then by arrival.
+func (h priorityHeap[T]) Less(i, j int) bool {
+	if h[i].rank != h[j].rank {
+		return h[i].rank < h[j].rank
+	}
+
+	return h[i].seq < h[j].seq
+}
+
+// Swap exchanges two entries.
+func (h priorityHeap[T]) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+}
+
+// Push appends x; called by container/heap.
+func (h *priorityHeap[T]) Push(x any) {
+	*h = append(*h, x.(*prioritized[T]))
+}
+
+// Pop removes the last entry; called by container/heap.
+func (h *priorityHeap[T]) Pop() any {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+
+	return item
+}
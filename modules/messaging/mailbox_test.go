@@ -0,0 +1,360 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func priorityMessage(payload int, priority uint8) Message[int] {
+	msg := NewMessage(payload, nil)
+	msg.Headers.Priority = priority
+
+	return msg
+}
+
+func drainPayloads(t *testing.T, m mailbox[int], n int) []int {
+	t.Helper()
+
+	out := make([]int, 0, n)
+
+	for range n {
+		env, ok := m.take(nil)
+		if !ok {
+			t.Fatalf("take returned false after %d envelopes", len(out))
+		}
+
+		out = append(out, env.msg.Payload)
+	}
+
+	return out
+}
+
+func assertPayloads(t *testing.T, got []int, want ...int) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("got %v want %v", got, want)
+	}
+
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v want %v", got, want)
+		}
+	}
+}
+
+func TestNewMailbox(t *testing.T) {
+	t.Parallel()
+
+	t.Run("FIFO by default", func(t *testing.T) {
+		t.Parallel()
+
+		_, ok := newMailbox[int](1, DispatchFIFO, time.Second, SystemClock()).(*fifoMailbox[int])
+		if !ok {
+			t.Fatal("expected *fifoMailbox")
+		}
+	})
+
+	t.Run("priority when requested", func(t *testing.T) {
+		t.Parallel()
+
+		_, ok := newMailbox[int](1, DispatchPriority, time.Second, SystemClock()).(*priorityMailbox[int])
+		if !ok {
+			t.Fatal("expected *priorityMailbox")
+		}
+	})
+
+	t.Run("priority aging is defaulted and clamped", func(t *testing.T) {
+		t.Parallel()
+
+		m := newPriorityMailbox[int](1, 0, SystemClock())
+		if m.aging != defaultPriorityAging {
+			t.Fatalf("expected default aging, got %v", m.aging)
+		}
+
+		m = newPriorityMailbox[int](1, 1000*time.Hour, SystemClock())
+		if m.aging != maxPriorityAging {
+			t.Fatalf("expected clamped aging, got %v", m.aging)
+		}
+	})
+}
+
+func TestFIFOMailbox_Take(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns false when stop fires", func(t *testing.T) {
+		t.Parallel()
+
+		stop := make(chan struct{})
+		close(stop)
+
+		_, ok := newFIFOMailbox[int](1).take(stop)
+		if ok {
+			t.Fatal("expected take to return false")
+		}
+	})
+}
+
+func TestPriorityMailbox_Take(t *testing.T) {
+	t.Parallel()
+
+	t.Run("highest priority first, ties in arrival order", func(t *testing.T) {
+		t.Parallel()
+
+		m := newPriorityMailbox[int](8, time.Hour, SystemClock())
+
+		for i, p := range []uint8{1, 9, 5, 9, 0, 5} {
+			err := m.put(context.Background(), priorityMessage(i, p), OverflowReject, nil)
+			if err != nil {
+				t.Fatalf("put: %v", err)
+			}
+		}
+
+		assertPayloads(t, drainPayloads(t, m, 6), 1, 3, 2, 5, 0, 4)
+	})
+
+	t.Run("aging lets long-waiting low priorities overtake", func(t *testing.T) {
+		t.Parallel()
+
+		m := newPriorityMailbox[int](4, time.Millisecond, SystemClock())
+
+		err := m.put(context.Background(), priorityMessage(0, 0), OverflowReject, nil)
+		if err != nil {
+			t.Fatalf("put: %v", err)
+		}
+
+		// P0 has waited ~50 levels' worth of aging; a fresh P9 only
+		// carries nine.
+		time.Sleep(50 * time.Millisecond)
+
+		err = m.put(context.Background(), priorityMessage(1, 9), OverflowReject, nil)
+		if err != nil {
+			t.Fatalf("put: %v", err)
+		}
+
+		assertPayloads(t, drainPayloads(t, m, 2), 0, 1)
+	})
+
+	t.Run("blocks until an envelope arrives", func(t *testing.T) {
+		t.Parallel()
+
+		m := newPriorityMailbox[int](1, time.Second, SystemClock())
+		got := make(chan int, 1)
+
+		go func() {
+			env, ok := m.take(nil)
+			if ok {
+				got <- env.msg.Payload
+			}
+		}()
+
+		time.Sleep(10 * time.Millisecond)
+
+		err := m.put(context.Background(), priorityMessage(7, 0), OverflowReject, nil)
+		if err != nil {
+			t.Fatalf("put: %v", err)
+		}
+
+		select {
+		case payload := <-got:
+			if payload != 7 {
+				t.Fatalf("got %d", payload)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("take did not wake up")
+		}
+	})
+
+	t.Run("returns false when stop fires", func(t *testing.T) {
+		t.Parallel()
+
+		stop := make(chan struct{})
+		close(stop)
+
+		_, ok := newPriorityMailbox[int](1, time.Second, SystemClock()).take(stop)
+		if ok {
+			t.Fatal("expected take to return false")
+		}
+	})
+
+	t.Run("drains buffered envelopes after close", func(t *testing.T) {
+		t.Parallel()
+
+		m := newPriorityMailbox[int](2, time.Second, SystemClock())
+
+		err := m.put(context.Background(), priorityMessage(1, 0), OverflowReject, nil)
+		if err != nil {
+			t.Fatalf("put: %v", err)
+		}
+
+		m.close()
+
+		assertPayloads(t, drainPayloads(t, m, 1), 1)
+
+		_, ok := m.take(nil)
+		if ok {
+			t.Fatal("expected take to return false once drained")
+		}
+	})
+}
+
+func TestPriorityMailbox_Put(t *testing.T) {
+	t.Parallel()
+
+	t.Run("rejects after close", func(t *testing.T) {
+		t.Parallel()
+
+		m := newPriorityMailbox[int](1, time.Second, SystemClock())
+		m.close()
+
+		err := m.put(context.Background(), priorityMessage(1, 0), OverflowBlock, nil)
+		if !errors.Is(err, ErrClosed) {
+			t.Fatalf("expected ErrClosed, got %v", err)
+		}
+	})
+
+	t.Run("reject returns ErrBufferFull", func(t *testing.T) {
+		t.Parallel()
+
+		m := newPriorityMailbox[int](1, time.Second, SystemClock())
+
+		_ = m.put(context.Background(), priorityMessage(1, 0), OverflowReject, nil)
+
+		err := m.put(context.Background(), priorityMessage(2, 9), OverflowReject, nil)
+		if !errors.Is(err, ErrBufferFull) {
+			t.Fatalf("expected ErrBufferFull, got %v", err)
+		}
+	})
+
+	t.Run("drop newest drops the incoming message", func(t *testing.T) {
+		t.Parallel()
+
+		var dropped []int
+
+		hook := func(_ context.Context, msg any, err error) {
+			if !errors.Is(err, ErrOverflow) || !errors.Is(err, ErrDropped) {
+				t.Errorf("unexpected hook error %v", err)
+			}
+
+			dropped = append(dropped, msg.(Message[int]).Payload)
+		}
+
+		m := newPriorityMailbox[int](1, time.Second, SystemClock())
+
+		_ = m.put(context.Background(), priorityMessage(1, 0), OverflowDropNewest, hook)
+
+		err := m.put(context.Background(), priorityMessage(2, 9), OverflowDropNewest, hook)
+		if err != nil {
+			t.Fatalf("expected nil, got %v", err)
+		}
+
+		assertPayloads(t, dropped, 2)
+		assertPayloads(t, drainPayloads(t, m, 1), 1)
+	})
+
+	t.Run("drop oldest evicts the lowest priority first", func(t *testing.T) {
+		t.Parallel()
+
+		var dropped []int
+
+		hook := func(_ context.Context, msg any, _ error) {
+			dropped = append(dropped, msg.(Message[int]).Payload)
+		}
+
+		m := newPriorityMailbox[int](3, time.Hour, SystemClock())
+
+		for i, p := range []uint8{5, 1, 1} {
+			_ = m.put(context.Background(), priorityMessage(i, p), OverflowDropOldest, hook)
+		}
+
+		err := m.put(context.Background(), priorityMessage(3, 5), OverflowDropOldest, hook)
+		if err != nil {
+			t.Fatalf("expected nil, got %v", err)
+		}
+
+		// The oldest P1 goes first.
+		assertPayloads(t, dropped, 1)
+		assertPayloads(t, drainPayloads(t, m, 3), 0, 3, 2)
+	})
+
+	t.Run("drop oldest drops an incoming message outranked by everything", func(t *testing.T) {
+		t.Parallel()
+
+		var dropped []int
+
+		hook := func(_ context.Context, msg any, _ error) {
+			dropped = append(dropped, msg.(Message[int]).Payload)
+		}
+
+		m := newPriorityMailbox[int](1, time.Hour, SystemClock())
+
+		_ = m.put(context.Background(), priorityMessage(1, 9), OverflowDropOldest, hook)
+		_ = m.put(context.Background(), priorityMessage(2, 0), OverflowDropOldest, nil)
+		_ = m.put(context.Background(), priorityMessage(3, 0), OverflowDropOldest, hook)
+
+		assertPayloads(t, dropped, 3)
+		assertPayloads(t, drainPayloads(t, m, 1), 1)
+	})
+
+	t.Run("block waits for a free slot", func(t *testing.T) {
+		t.Parallel()
+
+		m := newPriorityMailbox[int](1, time.Second, SystemClock())
+
+		_ = m.put(context.Background(), priorityMessage(1, 0), OverflowBlock, nil)
+
+		var wg sync.WaitGroup
+
+		wg.Go(func() {
+			err := m.put(context.Background(), priorityMessage(2, 0), OverflowBlock, nil)
+			if err != nil {
+				t.Errorf("blocked put: %v", err)
+			}
+		})
+
+		time.Sleep(10 * time.Millisecond)
+
+		assertPayloads(t, drainPayloads(t, m, 1), 1)
+
+		wg.Wait()
+
+		assertPayloads(t, drainPayloads(t, m, 1), 2)
+	})
+
+	t.Run("block honours ctx", func(t *testing.T) {
+		t.Parallel()
+
+		m := newPriorityMailbox[int](1, time.Second, SystemClock())
+
+		_ = m.put(context.Background(), priorityMessage(1, 0), OverflowBlock, nil)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		err := m.put(ctx, priorityMessage(2, 0), OverflowBlock, nil)
+		if !errors.Is(err, ErrTimeout) {
+			t.Fatalf("expected ErrTimeout, got %v", err)
+		}
+	})
+
+	t.Run("block fails once the mailbox closes", func(t *testing.T) {
+		t.Parallel()
+
+		m := newPriorityMailbox[int](1, time.Second, SystemClock())
+
+		_ = m.put(context.Background(), priorityMessage(1, 0), OverflowBlock, nil)
+
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			m.close()
+		}()
+
+		err := m.put(context.Background(), priorityMessage(2, 0), OverflowBlock, nil)
+		if !errors.Is(err, ErrClosed) {
+			t.Fatalf("expected ErrClosed, got %v", err)
+		}
+	})
+}
@@ -52,20 +52,29 @@ type Headers struct {
 	// Type is a string discriminator for the payload. Redundant for
 	// in-process generic typing but mandatory once payloads are
 	// serialised to bytes (broker wire format, outbox table, audit
-	// log).
+	// log); codec.Registry dispatches polymorphic decoding on it.
 	Type string
 	// Priority is a 0–9 priority hint (higher = more important).
-	// Honoured by channels with priority dispatch (none yet); ignored
-	// by the in-process PipelineChannel.
+	// Honoured by TopicChannel and QueueChannel under
+	// WithDispatchOrder(DispatchPriority); ignored by the other
+	// channels.
 	Priority uint8
 	// ContentType is a MIME-ish discriminator (e.g. "application/json",
 	// "application/protobuf"). Irrelevant in-process; mandatory for
-	// wire-format drivers.
+	// wire-format drivers. The codec sub-package stamps it on encode
+	// when empty.
 	ContentType string
 	// ExpirationTime instructs the channel/broker to drop the message
 	// if it is dequeued after this instant. Zero value means no
-	// expiration.
+	// expiration. Enforced by every async channel and buffering
+	// pattern; expired messages are reported with ErrExpired (see
+	// WithExpiredHandler).
 	ExpirationTime time.Time
+	// DeliveryCount is the number of times the message has been handed
+	// to a handler, including the current delivery. Stamped by
+	// TopicChannel and QueueChannel when WithRedeliveryPolicy is
+	// configured; zero otherwise.
+	DeliveryCount int
 	// SequenceNumber is the 0-based position of this message within a
 	// logical sequence produced by a Splitter. Zero when not part of a
 	// sequence.
@@ -0,0 +1,260 @@
+package messagingtest
+
+import (
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+// defaultStart is the instant a FakeClock built from the zero time
+// starts at. A fixed, non-zero instant keeps test output reproducible
+// and clear of the zero-time special cases (Headers.ExpirationTime).
+var defaultStart = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// FakeClock is a messaging.Clock whose time only moves through
+// Advance. Timers and tickers fire during Advance, in deadline order,
+// with Now reading their deadline while they fire. Channel deliveries
+// never block (like the time package, a tick nobody received is
+// dropped); AfterFunc callbacks run synchronously on the goroutine
+// that called Advance, outside the clock's lock, so they may use the
+// clock themselves.
+//
+// The zero value is not usable; build one with NewFakeClock. A
+// FakeClock is safe for concurrent use.
+type FakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	pending []*fakeTimer
+}
+
+// NewFakeClock returns a FakeClock reading start. A zero start is
+// replaced with a fixed instant (2000-01-01 UTC).
+func NewFakeClock(start time.Time) *FakeClock {
+	if start.IsZero() {
+		start = defaultStart
+	}
+
+	return &FakeClock{now: start}
+}
+
+// Now returns the clock's current time.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// NewTimer returns a Timer that delivers on its C channel once the
+// clock has been advanced by d. A non-positive d fires on the next
+// Advance, including Advance(0).
+func (c *FakeClock) NewTimer(d time.Duration) messaging.Timer {
+	t := &fakeTimer{clock: c, c: make(chan time.Time, 1)}
+	c.arm(t, d)
+
+	return t
+}
+
+// NewTicker returns a Ticker that delivers on its C channel every
+// time the clock crosses a multiple of d. Like time.NewTicker it
+// panics when d is not positive.
+func (c *FakeClock) NewTicker(d time.Duration) messaging.Ticker {
+	if d <= 0 {
+		panic("messagingtest: non-positive interval for NewTicker")
+	}
+
+	t := &fakeTimer{clock: c, c: make(chan time.Time, 1), period: d}
+	c.arm(t, d)
+
+	return &fakeTicker{timer: t}
+}
+
+// AfterFunc returns a Timer that calls f once the clock has been
+// advanced by d. f runs on the goroutine that calls Advance. The
+// Timer's C channel is nil.
+func (c *FakeClock) AfterFunc(d time.Duration, f func()) messaging.Timer {
+	t := &fakeTimer{clock: c, fn: f}
+	c.arm(t, d)
+
+	return t
+}
+
+// Advance moves the clock forward by d, firing every timer and ticker
+// whose deadline falls within the step, in deadline order. Negative
+// values are ignored.
+func (c *FakeClock) Advance(d time.Duration) {
+	if d < 0 {
+		return
+	}
+
+	c.mu.Lock()
+	target := c.now.Add(d)
+
+	for {
+		t := c.nextDue(target)
+		if t == nil {
+			break
+		}
+
+		if t.deadline.After(c.now) {
+			c.now = t.deadline
+		}
+
+		c.fire(t)
+
+		if t.fn == nil {
+			continue
+		}
+
+		c.mu.Unlock()
+		t.fn()
+		c.mu.Lock()
+	}
+
+	if target.After(c.now) {
+		c.now = target
+	}
+
+	c.mu.Unlock()
+}
+
+// Pending returns the number of timers and tickers armed on the clock.
+func (c *FakeClock) Pending() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.pending)
+}
+
+// WaitForTimers blocks until at least n timers and tickers are armed
+// on the clock and fails t when that does not happen within
+// waitTimeout. Call it before Advance when the component under test
+// arms its timer from its own goroutine.
+func (c *FakeClock) WaitForTimers(t testing.TB, n int) {
+	t.Helper()
+
+	WaitUntil(t, func() bool { return c.Pending() >= n }, "%d pending timers", n)
+}
+
+// arm schedules t to fire d from now.
+func (c *FakeClock) arm(t *fakeTimer, d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.remove(t)
+	t.deadline = c.now.Add(d)
+	c.pending = append(c.pending, t)
+}
+
+// disarm removes t from the pending set and drops a delivered but
+// unreceived value, so a stopped timer never fires late. It reports
+// whether t was pending.
+func (c *FakeClock) disarm(t *fakeTimer) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	wasPending := c.remove(t)
+
+	select {
+	case <-t.c:
+	default:
+	}
+
+	return wasPending
+}
+
+// remove deletes t from the pending set. Callers hold mu.
+func (c *FakeClock) remove(t *fakeTimer) bool {
+	i := slices.Index(c.pending, t)
+	if i < 0 {
+		return false
+	}
+
+	c.pending = slices.Delete(c.pending, i, i+1)
+
+	return true
+}
+
+// nextDue returns the pending timer with the earliest deadline not
+// after target, or nil. Ties fire in arming order. Callers hold mu.
+func (c *FakeClock) nextDue(target time.Time) *fakeTimer {
+	var next *fakeTimer
+
+	for _, t := range c.pending {
+		if t.deadline.After(target) {
+			continue
+		}
+
+		if next == nil || t.deadline.Before(next.deadline) {
+			next = t
+		}
+	}
+
+	return next
+}
+
+// fire delivers t at the current time and re-arms it when it is a
+// ticker; a one-shot timer leaves the pending set. Callers hold mu.
+func (c *FakeClock) fire(t *fakeTimer) {
+	c.remove(t)
+
+	if t.period > 0 {
+		t.deadline = t.deadline.Add(t.period)
+		c.pending = append(c.pending, t)
+	}
+
+	if t.c == nil {
+		return
+	}
+
+	select {
+	case t.c <- c.now:
+	default:
+	}
+}
+
+// fakeTimer is a timer, ticker or AfterFunc registered on a
+// FakeClock. Its schedule is guarded by the clock's mutex.
+type fakeTimer struct {
+	clock    *FakeClock
+	c        chan time.Time
+	fn       func()
+	period   time.Duration
+	deadline time.Time
+}
+
+// C returns the timer's channel (nil for AfterFunc timers).
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.c
+}
+
+// Stop disarms the timer.
+func (t *fakeTimer) Stop() bool {
+	return t.clock.disarm(t)
+}
+
+// Reset re-arms the timer to fire d from the clock's current time.
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	wasPending := t.clock.disarm(t)
+	t.clock.arm(t, d)
+
+	return wasPending
+}
+
+// fakeTicker adapts a periodic fakeTimer to messaging.Ticker.
+type fakeTicker struct {
+	timer *fakeTimer
+}
+
+// C returns the ticker's channel.
+func (t *fakeTicker) C() <-chan time.Time {
+	return t.timer.c
+}
+
+// Stop turns the ticker off.
+func (t *fakeTicker) Stop() {
+	t.timer.clock.disarm(t.timer)
+}
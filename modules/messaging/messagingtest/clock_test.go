@@ -0,0 +1,444 @@
+package messagingtest
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/guidomantilla/yarumo/core/common/lifecycle"
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+func TestNewFakeClock(t *testing.T) {
+	t.Parallel()
+
+	t.Run("zero start uses the fixed instant", func(t *testing.T) {
+		t.Parallel()
+
+		clock := NewFakeClock(time.Time{})
+		if !clock.Now().Equal(defaultStart) {
+			t.Fatalf("expected %v, got %v", defaultStart, clock.Now())
+		}
+	})
+
+	t.Run("start is kept", func(t *testing.T) {
+		t.Parallel()
+
+		start := time.Date(2030, time.March, 3, 12, 0, 0, 0, time.UTC)
+
+		clock := NewFakeClock(start)
+		if !clock.Now().Equal(start) {
+			t.Fatalf("expected %v, got %v", start, clock.Now())
+		}
+	})
+}
+
+func TestFakeClock_Advance(t *testing.T) {
+	t.Parallel()
+
+	t.Run("moves Now", func(t *testing.T) {
+		t.Parallel()
+
+		clock := NewFakeClock(time.Time{})
+		clock.Advance(time.Hour)
+
+		if got := clock.Now().Sub(defaultStart); got != time.Hour {
+			t.Fatalf("expected 1h, got %v", got)
+		}
+	})
+
+	t.Run("negative ignored", func(t *testing.T) {
+		t.Parallel()
+
+		clock := NewFakeClock(time.Time{})
+		clock.Advance(-time.Hour)
+
+		if !clock.Now().Equal(defaultStart) {
+			t.Fatalf("expected %v, got %v", defaultStart, clock.Now())
+		}
+	})
+
+	t.Run("timer fires only once its deadline is reached", func(t *testing.T) {
+		t.Parallel()
+
+		clock := NewFakeClock(time.Time{})
+		timer := clock.NewTimer(time.Minute)
+
+		clock.Advance(59 * time.Second)
+
+		select {
+		case <-timer.C():
+			t.Fatal("timer fired early")
+		default:
+		}
+
+		clock.Advance(time.Second)
+
+		select {
+		case at := <-timer.C():
+			if !at.Equal(defaultStart.Add(time.Minute)) {
+				t.Fatalf("expected delivery at the deadline, got %v", at)
+			}
+		default:
+			t.Fatal("timer did not fire")
+		}
+
+		if clock.Pending() != 0 {
+			t.Fatalf("expected no pending timers, got %d", clock.Pending())
+		}
+	})
+
+	t.Run("ticker fires once per period", func(t *testing.T) {
+		t.Parallel()
+
+		clock := NewFakeClock(time.Time{})
+		ticker := clock.NewTicker(time.Second)
+
+		defer ticker.Stop()
+
+		for i := range 3 {
+			clock.Advance(time.Second)
+
+			select {
+			case <-ticker.C():
+			default:
+				t.Fatalf("tick %d missing", i)
+			}
+		}
+	})
+
+	t.Run("AfterFunc callbacks run in deadline order and see their deadline", func(t *testing.T) {
+		t.Parallel()
+
+		clock := NewFakeClock(time.Time{})
+
+		var seen []time.Duration
+
+		record := func() { seen = append(seen, clock.Now().Sub(defaultStart)) }
+
+		clock.AfterFunc(3*time.Second, record)
+		clock.AfterFunc(time.Second, record)
+		clock.AfterFunc(2*time.Second, record)
+
+		clock.Advance(5 * time.Second)
+
+		if len(seen) != 3 || seen[0] != time.Second || seen[1] != 2*time.Second || seen[2] != 3*time.Second {
+			t.Fatalf("expected [1s 2s 3s], got %v", seen)
+		}
+
+		if got := clock.Now().Sub(defaultStart); got != 5*time.Second {
+			t.Fatalf("expected 5s, got %v", got)
+		}
+	})
+
+	t.Run("AfterFunc may arm new timers on the clock", func(t *testing.T) {
+		t.Parallel()
+
+		clock := NewFakeClock(time.Time{})
+
+		fired := 0
+
+		clock.AfterFunc(time.Second, func() {
+			clock.AfterFunc(time.Second, func() { fired++ })
+		})
+
+		clock.Advance(2 * time.Second)
+
+		if fired != 1 {
+			t.Fatalf("expected the chained callback to fire, fired %d", fired)
+		}
+	})
+}
+
+func TestFakeClock_Timer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Stop prevents firing and reports pending", func(t *testing.T) {
+		t.Parallel()
+
+		clock := NewFakeClock(time.Time{})
+		timer := clock.NewTimer(time.Second)
+
+		if !timer.Stop() {
+			t.Fatal("expected Stop to report a pending timer")
+		}
+
+		if timer.Stop() {
+			t.Fatal("expected a second Stop to report a stopped timer")
+		}
+
+		clock.Advance(time.Minute)
+
+		select {
+		case <-timer.C():
+			t.Fatal("stopped timer fired")
+		default:
+		}
+	})
+
+	t.Run("Stop drops an unreceived delivery", func(t *testing.T) {
+		t.Parallel()
+
+		clock := NewFakeClock(time.Time{})
+		timer := clock.NewTimer(time.Second)
+
+		clock.Advance(time.Second)
+		timer.Stop()
+
+		select {
+		case <-timer.C():
+			t.Fatal("stale delivery after Stop")
+		default:
+		}
+	})
+
+	t.Run("Reset re-arms from the current time", func(t *testing.T) {
+		t.Parallel()
+
+		clock := NewFakeClock(time.Time{})
+		timer := clock.NewTimer(time.Second)
+
+		clock.Advance(500 * time.Millisecond)
+
+		if !timer.Reset(time.Second) {
+			t.Fatal("expected Reset to report a pending timer")
+		}
+
+		clock.Advance(900 * time.Millisecond)
+
+		select {
+		case <-timer.C():
+			t.Fatal("reset timer fired on its old deadline")
+		default:
+		}
+
+		clock.Advance(100 * time.Millisecond)
+
+		select {
+		case <-timer.C():
+		default:
+			t.Fatal("reset timer did not fire")
+		}
+	})
+
+	t.Run("AfterFunc timer has a nil channel", func(t *testing.T) {
+		t.Parallel()
+
+		timer := NewFakeClock(time.Time{}).AfterFunc(time.Second, func() {})
+		if timer.C() != nil {
+			t.Fatal("expected a nil channel")
+		}
+	})
+
+	t.Run("NewTicker panics on a non-positive interval", func(t *testing.T) {
+		t.Parallel()
+
+		defer func() {
+			if recover() == nil {
+				t.Fatal("expected a panic")
+			}
+		}()
+
+		NewFakeClock(time.Time{}).NewTicker(0)
+	})
+}
+
+func TestFakeClock_WaitForTimers(t *testing.T) {
+	t.Parallel()
+
+	clock := NewFakeClock(time.Time{})
+
+	go clock.NewTimer(time.Second)
+
+	clock.WaitForTimers(t, 1)
+
+	if clock.Pending() != 1 {
+		t.Fatalf("expected 1 pending timer, got %d", clock.Pending())
+	}
+}
+
+func TestFakeClock_ScheduledChannel(t *testing.T) {
+	t.Parallel()
+
+	clock := NewFakeClock(time.Time{})
+	out := NewRecordingChannel[string]()
+
+	ch := messaging.NewScheduledChannel[string]("scheduled", messaging.WithClock(clock))
+
+	_, err := ch.Subscribe(out.Send)
+	if err != nil {
+		t.Fatalf("Subscribe returned %v", err)
+	}
+
+	component, _ := ch.(lifecycle.Component)
+
+	err = component.Start(context.Background())
+	if err != nil {
+		t.Fatalf("Start returned %v", err)
+	}
+
+	defer func() { _ = component.Stop(context.Background()) }()
+
+	err = ch.SendAfter(context.Background(), time.Hour, messaging.NewMessage("later", nil))
+	if err != nil {
+		t.Fatalf("SendAfter returned %v", err)
+	}
+
+	clock.WaitForTimers(t, 1)
+	clock.Advance(59 * time.Minute)
+	out.AssertCount(t, 0)
+
+	clock.WaitForTimers(t, 1)
+	clock.Advance(time.Minute)
+
+	out.WaitFor(t, 1)
+	out.AssertPayloads(t, "later")
+}
+
+// startQueue subscribes handler to a QueueChannel built with opts and
+// starts it; the queue stops when the test ends.
+func startQueue[T any](t *testing.T, handler messaging.Handler[T], opts ...messaging.Option) messaging.Channel[T] {
+	t.Helper()
+
+	ch := messaging.NewQueueChannel[T]("queue", opts...)
+
+	_, err := ch.Subscribe(handler)
+	if err != nil {
+		t.Fatalf("Subscribe returned %v", err)
+	}
+
+	component, _ := ch.(lifecycle.Component)
+
+	err = component.Start(context.Background())
+	if err != nil {
+		t.Fatalf("Start returned %v", err)
+	}
+
+	t.Cleanup(func() { _ = component.Stop(context.Background()) })
+
+	return ch
+}
+
+func TestFakeClock_QueueExpiry(t *testing.T) {
+	t.Parallel()
+
+	clock := NewFakeClock(time.Time{})
+	out := NewRecordingChannel[string]()
+	expired := NewRecordingChannel[string]()
+
+	onExpired := func(ctx context.Context, msg any, _ error) {
+		m, ok := msg.(messaging.Message[string])
+		if ok {
+			_ = expired.Send(ctx, m)
+		}
+	}
+
+	ch := startQueue(t, out.Send, messaging.WithClock(clock), messaging.WithExpiredHandler(onExpired))
+
+	expiration := clock.Now().Add(time.Minute)
+
+	send := func(payload string) {
+		msg := messaging.NewMessage(payload, nil)
+		msg.Headers.ExpirationTime = expiration
+
+		err := ch.Send(context.Background(), msg)
+		if err != nil {
+			t.Fatalf("Send returned %v", err)
+		}
+	}
+
+	// The fake clock reads 2000-01-01, long before the wall clock: the
+	// message is only live when expiry is judged on the injected clock.
+	send("live")
+	out.WaitFor(t, 1)
+
+	clock.Advance(2 * time.Minute)
+	send("stale")
+
+	expired.WaitFor(t, 1)
+	expired.AssertPayloads(t, "stale")
+	out.AssertPayloads(t, "live")
+}
+
+func TestFakeClock_QueueRedelivery(t *testing.T) {
+	t.Parallel()
+
+	clock := NewFakeClock(time.Time{})
+	out := NewRecordingChannel[string]()
+
+	var calls atomic.Int32
+
+	handler := func(ctx context.Context, msg messaging.Message[string]) error {
+		if calls.Add(1) == 1 {
+			return errors.New("transient")
+		}
+
+		return out.Send(ctx, msg)
+	}
+
+	policy := messaging.RedeliveryPolicy{MaxAttempts: 2, Delay: time.Hour, MaxDelay: time.Hour}
+	ch := startQueue(t, handler, messaging.WithClock(clock), messaging.WithRedeliveryPolicy(policy),
+		messaging.WithErrorHandler(messaging.SilentErrorHandler))
+
+	err := ch.Send(context.Background(), messaging.NewMessage("retried", nil))
+	if err != nil {
+		t.Fatalf("Send returned %v", err)
+	}
+
+	clock.WaitForTimers(t, 1)
+	clock.Advance(59 * time.Minute)
+	out.AssertCount(t, 0)
+
+	clock.WaitForTimers(t, 1)
+	clock.Advance(time.Minute)
+
+	out.WaitFor(t, 1)
+	out.AssertPayloads(t, "retried")
+}
+
+func TestFakeClock_QueuePriorityAging(t *testing.T) {
+	t.Parallel()
+
+	clock := NewFakeClock(time.Time{})
+	out := NewRecordingChannel[string]()
+	entered := make(chan struct{})
+	release := make(chan struct{})
+
+	handler := func(ctx context.Context, msg messaging.Message[string]) error {
+		if msg.Payload == "gate" {
+			close(entered)
+			<-release
+		}
+
+		return out.Send(ctx, msg)
+	}
+
+	ch := startQueue(t, handler, messaging.WithClock(clock), messaging.WithWorkerCount(1),
+		messaging.WithDispatchOrder(messaging.DispatchPriority), messaging.WithPriorityAging(time.Second))
+
+	send := func(payload string, priority uint8) {
+		msg := messaging.NewMessage(payload, nil)
+		msg.Headers.Priority = priority
+
+		err := ch.Send(context.Background(), msg)
+		if err != nil {
+			t.Fatalf("Send returned %v", err)
+		}
+	}
+
+	// The gate occupies the only worker so the rest queue up.
+	send("gate", 0)
+	<-entered
+
+	send("old", 0)
+	clock.Advance(time.Minute)
+	send("fresh", 9)
+	send("urgent", 9)
+
+	close(release)
+
+	out.WaitFor(t, 4)
+	out.AssertPayloads(t, "gate", "old", "fresh", "urgent")
+}
@@ -0,0 +1,59 @@
+package messagingtest
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+// waitTimeout bounds every wait in this package. It is generous on
+// purpose: the waits return as soon as their condition holds, so the
+// bound only matters for a test that is already failing.
+const waitTimeout = 5 * time.Second
+
+// pollInterval is how often WaitUntil re-evaluates its condition.
+const pollInterval = time.Millisecond
+
+// WaitUntil polls condition until it returns true and fails t, with
+// the message built from format and args, when that does not happen
+// within waitTimeout.
+func WaitUntil(t testing.TB, condition func() bool, format string, args ...any) {
+	t.Helper()
+
+	deadline := time.Now().Add(waitTimeout)
+
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out after %v waiting for %s", waitTimeout, fmt.Sprintf(format, args...))
+
+			return
+		}
+
+		time.Sleep(pollInterval)
+	}
+}
+
+// WaitForDelivered blocks until component — any channel or pattern
+// implementing messaging.Inspectable — reports at least n deliveries
+// in Stats.Delivered, and returns its stats. It fails t when component
+// is not inspectable or the count is not reached within waitTimeout.
+func WaitForDelivered(t testing.TB, component any, n uint64) messaging.Stats {
+	t.Helper()
+
+	stats, ok := messaging.StatsOf(component)
+	if !ok {
+		t.Fatalf("%T does not implement messaging.Inspectable", component)
+
+		return stats
+	}
+
+	WaitUntil(t, func() bool {
+		stats, _ = messaging.StatsOf(component)
+
+		return stats.Delivered >= n
+	}, "%d deliveries on %T", n, component)
+
+	return stats
+}
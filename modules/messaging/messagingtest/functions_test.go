@@ -0,0 +1,38 @@
+package messagingtest
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+func TestWaitUntil(t *testing.T) {
+	t.Parallel()
+
+	var ready atomic.Bool
+
+	go ready.Store(true)
+
+	WaitUntil(t, ready.Load, "ready")
+}
+
+func TestWaitForDelivered(t *testing.T) {
+	t.Parallel()
+
+	ch := messaging.NewPipelineChannel[int]()
+
+	_, _ = ch.Subscribe(func(context.Context, messaging.Message[int]) error { return nil })
+
+	go func() {
+		for i := range 3 {
+			_ = ch.Send(context.Background(), messaging.NewMessage(i, nil))
+		}
+	}()
+
+	stats := WaitForDelivered(t, ch, 3)
+	if stats.Delivered != 3 {
+		t.Fatalf("expected 3 deliveries, got %+v", stats)
+	}
+}
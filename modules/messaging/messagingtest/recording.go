@@ -0,0 +1,216 @@
+package messagingtest
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+// RecordingChannel is a messaging.Channel that records every message
+// sent to it, in order, and then dispatches it synchronously to its
+// subscribers in registration order. Its Send method has the
+// messaging.Handler signature, so subscribing it to another channel
+// taps that channel.
+//
+// The zero value is not usable; build one with NewRecordingChannel. A
+// RecordingChannel is safe for concurrent use.
+type RecordingChannel[T any] struct {
+	mu       sync.Mutex
+	messages []messaging.Message[T]
+	changed  chan struct{}
+	nextID   uint64
+	order    []uint64
+	byID     map[uint64]messaging.Handler[T]
+	stats    messaging.StatsRecorder
+}
+
+// NewRecordingChannel returns an empty RecordingChannel.
+func NewRecordingChannel[T any]() *RecordingChannel[T] {
+	return &RecordingChannel[T]{
+		changed: make(chan struct{}),
+		byID:    map[uint64]messaging.Handler[T]{},
+	}
+}
+
+// Send records msg and runs every subscriber with it, all of them even
+// when one fails. It returns messaging.ErrSend joining the subscriber
+// errors, or messaging.ErrSend(messaging.ErrContextNil) without
+// recording when ctx is nil.
+func (c *RecordingChannel[T]) Send(ctx context.Context, msg messaging.Message[T]) error {
+	if ctx == nil {
+		return messaging.ErrSend(messaging.ErrContextNil)
+	}
+
+	c.mu.Lock()
+	c.messages = append(c.messages, msg)
+	close(c.changed)
+	c.changed = make(chan struct{})
+
+	handlers := make([]messaging.Handler[T], 0, len(c.order))
+	for _, id := range c.order {
+		handlers = append(handlers, c.byID[id])
+	}
+	c.mu.Unlock()
+
+	c.stats.RecordSent()
+
+	var errs []error
+
+	for _, handler := range handlers {
+		end := c.stats.Begin()
+		err := handler(ctx, msg)
+		end(err)
+
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return messaging.ErrSend(errors.Join(errs...))
+	}
+
+	return nil
+}
+
+// Subscribe registers handler. It returns
+// messaging.ErrSubscribe(messaging.ErrHandlerNil) when handler is nil.
+func (c *RecordingChannel[T]) Subscribe(handler messaging.Handler[T]) (messaging.Cancel, error) {
+	if handler == nil {
+		return nil, messaging.ErrSubscribe(messaging.ErrHandlerNil)
+	}
+
+	c.mu.Lock()
+	c.nextID++
+	id := c.nextID
+	c.byID[id] = handler
+	c.order = append(c.order, id)
+	c.mu.Unlock()
+
+	var once sync.Once
+
+	cancel := func() {
+		once.Do(func() {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+
+			delete(c.byID, id)
+			c.order = slices.DeleteFunc(c.order, func(other uint64) bool { return other == id })
+		})
+	}
+
+	return cancel, nil
+}
+
+// Stats reports the recorded messages as Sent and the subscriber
+// runs as Delivered and Failed.
+func (c *RecordingChannel[T]) Stats() messaging.Stats {
+	stats := c.stats.Snapshot()
+
+	c.mu.Lock()
+	stats.Subscribers = len(c.order)
+	c.mu.Unlock()
+
+	return stats
+}
+
+// Messages returns a copy of the recorded messages in send order.
+func (c *RecordingChannel[T]) Messages() []messaging.Message[T] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return slices.Clone(c.messages)
+}
+
+// Payloads returns the payloads of the recorded messages in send
+// order.
+func (c *RecordingChannel[T]) Payloads() []T {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	payloads := make([]T, 0, len(c.messages))
+	for _, msg := range c.messages {
+		payloads = append(payloads, msg.Payload)
+	}
+
+	return payloads
+}
+
+// Len returns the number of recorded messages.
+func (c *RecordingChannel[T]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.messages)
+}
+
+// Reset forgets the recorded messages. Subscribers and stats are
+// kept.
+func (c *RecordingChannel[T]) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.messages = nil
+}
+
+// WaitFor blocks until at least n messages are recorded and returns
+// them. It fails t when that does not happen within waitTimeout.
+func (c *RecordingChannel[T]) WaitFor(t testing.TB, n int) []messaging.Message[T] {
+	t.Helper()
+
+	timer := time.NewTimer(waitTimeout)
+	defer timer.Stop()
+
+	for {
+		c.mu.Lock()
+		if len(c.messages) >= n {
+			messages := slices.Clone(c.messages)
+			c.mu.Unlock()
+
+			return messages
+		}
+
+		have := len(c.messages)
+		changed := c.changed
+		c.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-timer.C:
+			t.Fatalf("timed out after %v waiting for %d recorded messages (have %d)", waitTimeout, n, have)
+
+			return nil
+		}
+	}
+}
+
+// AssertCount fails t unless exactly n messages are recorded.
+func (c *RecordingChannel[T]) AssertCount(t testing.TB, n int) {
+	t.Helper()
+
+	have := c.Len()
+	if have != n {
+		t.Errorf("recorded %d messages, want %d", have, n)
+	}
+}
+
+// AssertPayloads fails t unless the recorded payloads equal want, in
+// order, compared with reflect.DeepEqual.
+func (c *RecordingChannel[T]) AssertPayloads(t testing.TB, want ...T) {
+	t.Helper()
+
+	have := c.Payloads()
+	if len(have) == 0 && len(want) == 0 {
+		return
+	}
+
+	if !reflect.DeepEqual(have, want) {
+		t.Errorf("recorded payloads %v, want %v", have, want)
+	}
+}
@@ -0,0 +1,166 @@
+package messagingtest
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+func TestRecordingChannel_Send(t *testing.T) {
+	t.Parallel()
+
+	t.Run("records in send order", func(t *testing.T) {
+		t.Parallel()
+
+		ch := NewRecordingChannel[int]()
+
+		for i := range 3 {
+			err := ch.Send(context.Background(), messaging.NewMessage(i, nil))
+			if err != nil {
+				t.Fatalf("Send returned %v", err)
+			}
+		}
+
+		ch.AssertCount(t, 3)
+		ch.AssertPayloads(t, 0, 1, 2)
+
+		if len(ch.Messages()) != 3 {
+			t.Fatalf("expected 3 messages, got %d", len(ch.Messages()))
+		}
+	})
+
+	t.Run("nil context rejected without recording", func(t *testing.T) {
+		t.Parallel()
+
+		ch := NewRecordingChannel[int]()
+
+		err := ch.Send(nil, messaging.NewMessage(1, nil)) //nolint:staticcheck // nil ctx is the case under test
+		if !errors.Is(err, messaging.ErrContextNil) {
+			t.Fatalf("expected ErrContextNil, got %v", err)
+		}
+
+		ch.AssertCount(t, 0)
+	})
+
+	t.Run("dispatches to every subscriber and joins their errors", func(t *testing.T) {
+		t.Parallel()
+
+		ch := NewRecordingChannel[int]()
+		boom := errors.New("boom")
+
+		var calls atomic.Int32
+
+		_, _ = ch.Subscribe(func(context.Context, messaging.Message[int]) error {
+			calls.Add(1)
+
+			return boom
+		})
+		_, _ = ch.Subscribe(func(context.Context, messaging.Message[int]) error {
+			calls.Add(1)
+
+			return nil
+		})
+
+		err := ch.Send(context.Background(), messaging.NewMessage(1, nil))
+		if !errors.Is(err, boom) || !errors.Is(err, messaging.ErrSendFailed) {
+			t.Fatalf("expected ErrSend wrapping boom, got %v", err)
+		}
+
+		if calls.Load() != 2 {
+			t.Fatalf("expected 2 handler calls, got %d", calls.Load())
+		}
+
+		stats := ch.Stats()
+		if stats.Sent != 1 || stats.Delivered != 2 || stats.Failed != 1 || stats.Subscribers != 2 {
+			t.Fatalf("unexpected stats %+v", stats)
+		}
+	})
+}
+
+func TestRecordingChannel_Subscribe(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil handler rejected", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewRecordingChannel[int]().Subscribe(nil)
+		if !errors.Is(err, messaging.ErrHandlerNil) {
+			t.Fatalf("expected ErrHandlerNil, got %v", err)
+		}
+	})
+
+	t.Run("cancel detaches the handler", func(t *testing.T) {
+		t.Parallel()
+
+		ch := NewRecordingChannel[int]()
+
+		var calls atomic.Int32
+
+		cancel, _ := ch.Subscribe(func(context.Context, messaging.Message[int]) error {
+			calls.Add(1)
+
+			return nil
+		})
+
+		cancel()
+		cancel()
+
+		_ = ch.Send(context.Background(), messaging.NewMessage(1, nil))
+
+		if calls.Load() != 0 {
+			t.Fatalf("expected no calls after cancel, got %d", calls.Load())
+		}
+
+		ch.AssertCount(t, 1)
+	})
+
+	t.Run("taps another channel", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[string]()
+		tap := NewRecordingChannel[string]()
+
+		_, _ = src.Subscribe(tap.Send)
+		_ = src.Send(context.Background(), messaging.NewMessage("a", nil))
+
+		tap.AssertPayloads(t, "a")
+	})
+}
+
+func TestRecordingChannel_WaitFor(t *testing.T) {
+	t.Parallel()
+
+	ch := NewRecordingChannel[int]()
+
+	go func() {
+		for i := range 5 {
+			_ = ch.Send(context.Background(), messaging.NewMessage(i, nil))
+		}
+	}()
+
+	messages := ch.WaitFor(t, 5)
+	if len(messages) != 5 {
+		t.Fatalf("expected 5 messages, got %d", len(messages))
+	}
+
+	ch.AssertPayloads(t, 0, 1, 2, 3, 4)
+}
+
+func TestRecordingChannel_Reset(t *testing.T) {
+	t.Parallel()
+
+	ch := NewRecordingChannel[int]()
+	_ = ch.Send(context.Background(), messaging.NewMessage(1, nil))
+
+	ch.Reset()
+
+	ch.AssertCount(t, 0)
+	ch.AssertPayloads(t)
+
+	if ch.Stats().Sent != 1 {
+		t.Fatalf("expected stats to survive Reset, got %+v", ch.Stats())
+	}
+}
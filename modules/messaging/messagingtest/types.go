@@ -0,0 +1,58 @@
+// Package messagingtest publishes test helpers for code built on the
+// messaging module: a virtual clock, a recording channel and waits on
+// delivery counters.
+//
+// It is intended for consumption from _test.go files only. Importing
+// it from production code links the testing package into the final
+// binary and is not supported.
+//
+// # FakeClock
+//
+// FakeClock implements messaging.Clock and only moves when the test
+// calls Advance. Hand it to the time-based components through their
+// WithClock options — messaging.NewScheduledChannel and
+// messaging.NewPollableChannel, the delayer, aggregator and
+// resequencer patterns and stores.NewInMemoryMetadataStore — and a
+// flow that would sleep for minutes runs in microseconds:
+//
+//	clock := messagingtest.NewFakeClock(time.Time{})
+//	out := messagingtest.NewRecordingChannel[string]()
+//	d := delayer.NewDelayer("d", in, out, delayer.WithFixedDelay[string](time.Minute), delayer.WithClock[string](clock))
+//	// ... start d, send to in ...
+//	clock.WaitForTimers(t, 1)
+//	clock.Advance(time.Minute)
+//	out.WaitFor(t, 1)
+//
+// Components arm their timers from their own goroutines, so a test
+// that advances the clock right after a Send may race the component
+// into arming. WaitForTimers blocks until the expected number of
+// timers and tickers is pending, which closes that race.
+//
+// # RecordingChannel
+//
+// RecordingChannel is a messaging.Channel that keeps every message
+// sent to it, in order, and dispatches it to its subscribers like a
+// PipelineChannel. Use it as the destination of the component under
+// test, or subscribe its Send method to an existing channel to tap it.
+// WaitFor blocks until N messages were recorded; AssertCount and
+// AssertPayloads check what was.
+//
+// # Waiting on counters
+//
+// WaitForDelivered polls messaging.StatsOf until a channel or pattern
+// reports N deliveries; WaitUntil is the underlying poll for any other
+// condition. Every wait fails the test after waitTimeout instead of
+// hanging it.
+package messagingtest
+
+import (
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+var (
+	_ messaging.Clock        = (*FakeClock)(nil)
+	_ messaging.Timer        = (*fakeTimer)(nil)
+	_ messaging.Ticker       = (*fakeTicker)(nil)
+	_ messaging.Channel[any] = (*RecordingChannel[any])(nil)
+	_ messaging.Inspectable  = (*RecordingChannel[any])(nil)
+)
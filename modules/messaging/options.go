@@ -2,6 +2,8 @@ package messaging
 
 import (
 	"time"
+
+	cretry "github.com/guidomantilla/yarumo/core/common/resilience/retry"
 )
 
 // Default buffer, drain bounds, worker pool size and overflow policy
@@ -13,6 +15,86 @@ const (
 	defaultOverflowPolicy = OverflowReject
 )
 
+// Default and maximum aging interval for DispatchPriority.
+const (
+	defaultPriorityAging = time.Second
+	maxPriorityAging     = 24 * time.Hour
+)
+
+// Default segment size, retention and fsync policy for the durable
+// queue's write-ahead log.
+const (
+	defaultSegmentSize   = 64 << 20
+	defaultFsyncPolicy   = FsyncAlways
+	defaultFsyncInterval = time.Second
+)
+
+// Default lease length for PollableChannel.ReceiveWithLease.
+const defaultVisibilityTimeout = 30 * time.Second
+
+// Default delay bounds for RedeliveryPolicy.
+const (
+	defaultRedeliveryDelay    = 100 * time.Millisecond
+	defaultRedeliveryMaxDelay = 5 * time.Second
+)
+
+// RedeliveryPolicy configures how TopicChannel and QueueChannel retry a
+// failing handler before dead-lettering the message (see
+// WithRedeliveryPolicy). Zero-valued fields take their defaults.
+type RedeliveryPolicy struct {
+	// MaxAttempts is the total number of deliveries, the original one
+	// included. Values below 2 disable redelivery.
+	MaxAttempts int
+	// Delay is the base wait before a redelivery. Defaults to 100ms.
+	Delay time.Duration
+	// MaxDelay caps the wait computed by Backoff. Defaults to 5s.
+	MaxDelay time.Duration
+	// Backoff selects the delay schedule: BackoffFixed (the zero
+	// value) waits Delay every time, BackoffExponential doubles it
+	// per attempt, BackoffRandom waits a random duration below Delay.
+	Backoff cretry.Backoff
+	// RetryIf decides whether a handler error is worth a redelivery;
+	// errors it rejects are dead-lettered immediately. Defaults to
+	// retry.AlwaysRetry.
+	RetryIf cretry.RetryIfFn
+}
+
+// FsyncPolicy controls when the durable queue flushes its write-ahead
+// log to stable storage. The default is FsyncAlways — every Send is on
+// disk before it returns — trading throughput for the strongest crash
+// guarantee.
+type FsyncPolicy int
+
+const (
+	// FsyncAlways syncs the active segment after every append and the
+	// ack index after every acknowledgement. A Send that returned nil
+	// survives a machine crash.
+	FsyncAlways FsyncPolicy = iota
+	// FsyncInterval syncs in the background every fsync interval (see
+	// WithFsyncInterval). A crash can lose up to one interval of
+	// appends; a lost acknowledgement only causes a redelivery.
+	FsyncInterval
+	// FsyncNever leaves flushing to the operating system and only
+	// syncs on Stop. Survives process crashes but not machine crashes.
+	FsyncNever
+)
+
+// DispatchOrder selects the order in which an async channel's workers
+// take buffered messages. The default is DispatchFIFO.
+type DispatchOrder int
+
+const (
+	// DispatchFIFO dispatches messages in arrival order. The default.
+	DispatchFIFO DispatchOrder = iota
+	// DispatchPriority dispatches the buffered message with the highest
+	// effective priority next: Headers.Priority plus one level per
+	// aging interval waited (see WithPriorityAging), ties in arrival
+	// order. Aging guarantees low-priority messages still make progress
+	// under a steady stream of high-priority ones. Under
+	// OverflowDropOldest the lowest-priority message is evicted first.
+	DispatchPriority
+)
+
 // OverflowPolicy controls how an async Channel.Send reacts when the
 // internal buffer is at capacity. The default for NewOptions is
 // OverflowReject — Send returns ErrBufferFull immediately instead of
@@ -55,22 +137,45 @@ type Option func(opts *Options)
 // to avoid a workspace-wide breaking change). The concrete channel
 // constructor type-asserts dlq to Channel[DeadLetter[T]] at build
 // time and panics via cassert when the T from WithDLQChannel does
-// not match the T of the channel being constructed.
+// not match the T of the channel being constructed. interceptors
+// follows the same scheme for ChannelInterceptor[T].
 type Options struct {
 	bufferSize     int
 	drainTimeout   time.Duration
 	workerCount    int
 	errorHandler   ErrorHandler
+	expiredHandler ErrorHandler
 	overflowPolicy OverflowPolicy
+	dispatchOrder  DispatchOrder
+	priorityAging  time.Duration
+	partitionKey   PartitionKeyFn
+	loadBalancer   LoadBalancer
 	dlq            any
+	interceptors   []any
+	redelivery     *RedeliveryPolicy
+
+	visibilityTimeout time.Duration
+	maxDeliveries     int
+	clock             Clock
+
+	segmentSize      int64
+	segmentRetention int
+	fsyncPolicy      FsyncPolicy
+	fsyncInterval    time.Duration
 }
 
 // NewOptions creates a new Options with sensible defaults and applies
 // the given options. Defaults: bufferSize 64, drainTimeout 5s,
-// workerCount 1, ErrorHandler logs via common/log, overflowPolicy
+// workerCount 1, ErrorHandler logs via common/log, expired messages
+// reported through the ErrorHandler, overflowPolicy
 // OverflowReject (Send returns ErrBufferFull when full instead of
-// blocking). Pass WithOverflowPolicy(OverflowBlock) to opt into the
-// historical blocking-Send behavior.
+// blocking), dispatchOrder DispatchFIFO, priorityAging 1s, a fresh
+// RoundRobin load balancer. Pass
+// WithOverflowPolicy(OverflowBlock) to opt into the historical
+// blocking-Send behavior. PollableChannel leases default to a 30s
+// visibility timeout and unlimited deliveries; the clock is
+// SystemClock. The durable queue additionally defaults to 64 MiB
+// segments, no retention of acknowledged segments and FsyncAlways.
 func NewOptions(opts ...Option) *Options {
 	options := &Options{
 		bufferSize:     defaultBufferSize,
@@ -78,6 +183,14 @@ func NewOptions(opts ...Option) *Options {
 		workerCount:    defaultWorkerCount,
 		errorHandler:   DefaultErrorHandler,
 		overflowPolicy: defaultOverflowPolicy,
+		priorityAging:  defaultPriorityAging,
+		loadBalancer:   RoundRobin(),
+
+		visibilityTimeout: defaultVisibilityTimeout,
+		clock:             SystemClock(),
+		segmentSize:       defaultSegmentSize,
+		fsyncPolicy:       defaultFsyncPolicy,
+		fsyncInterval:     defaultFsyncInterval,
 	}
 
 	for _, opt := range opts {
@@ -122,11 +235,26 @@ func WithErrorHandler(handler ErrorHandler) Option {
 	}
 }
 
+// WithExpiredHandler installs the hook fired once per message
+// discarded because it was dequeued after its Headers.ExpirationTime.
+// The hook receives the expired message and ErrExpired joined with
+// ErrDropped. When WithExpiredHandler is not passed, expired messages
+// are reported through the ErrorHandler instead. Expired messages are
+// also published to the DLQ when WithDLQChannel is configured. Nil
+// values are ignored.
+func WithExpiredHandler(handler ErrorHandler) Option {
+	return func(opts *Options) {
+		if handler != nil {
+			opts.expiredHandler = handler
+		}
+	}
+}
+
 // WithWorkerCount sets the number of worker goroutines a
 // QueueChannel spawns to consume from the inbound buffer. Workers
 // compete for messages — each message goes to exactly one worker
-// (and from there to exactly one subscriber via round-robin). Non-
-// positive values are ignored.
+// (and from there to exactly one subscriber picked by the load
+// balancer, see WithLoadBalancer). Non-positive values are ignored.
 func WithWorkerCount(n int) Option {
 	return func(opts *Options) {
 		if n > 0 {
@@ -147,6 +275,67 @@ func WithOverflowPolicy(p OverflowPolicy) Option {
 	}
 }
 
+// WithDispatchOrder selects the order in which TopicChannel and
+// QueueChannel workers take buffered messages. See DispatchOrder.
+// Other channels ignore it. Values outside the defined range are
+// ignored (the previously configured order is preserved).
+func WithDispatchOrder(order DispatchOrder) Option {
+	return func(opts *Options) {
+		if order >= DispatchFIFO && order <= DispatchPriority {
+			opts.dispatchOrder = order
+		}
+	}
+}
+
+// WithPriorityAging sets how long a message must wait under
+// DispatchPriority to gain one priority level. Shorter intervals
+// favor fairness, longer ones favor strict priority. Values above 24h
+// are clamped to 24h; non-positive values are ignored.
+func WithPriorityAging(interval time.Duration) Option {
+	return func(opts *Options) {
+		if interval > 0 {
+			opts.priorityAging = interval
+		}
+	}
+}
+
+// WithPartitioning makes a QueueChannel deliver the messages that
+// share a Headers.CorrelationID in order. It is WithPartitionKey(
+// PartitionByCorrelationID).
+func WithPartitioning() Option {
+	return WithPartitionKey(PartitionByCorrelationID)
+}
+
+// WithPartitionKey makes a QueueChannel partition its messages by the
+// key fn extracts: each worker (see WithWorkerCount) owns a lane, every
+// key hashes to a fixed lane, and each lane is drained by its worker in
+// arrival order. Messages with an empty key are spread over the lanes.
+// Each lane buffers up to bufferSize/workerCount messages behind the
+// inbound buffer; while a lane is full, the messages queued behind its
+// next message wait. Other channels ignore it. Nil values are ignored.
+func WithPartitionKey(fn PartitionKeyFn) Option {
+	return func(opts *Options) {
+		if fn != nil {
+			opts.partitionKey = fn
+		}
+	}
+}
+
+// WithLoadBalancer sets the strategy a QueueChannel uses to pick the
+// subscriber of each message from its shared worker pool; the default
+// is RoundRobin. Whatever the strategy, subscribers at their declared
+// capacity (see WithSubscriptionCapacity) are skipped, and a worker
+// only waits when every subscriber is saturated. Partitioned channels
+// (see WithPartitionKey) bind lanes to subscribers and ignore it, as
+// do the other channels. Nil values are ignored.
+func WithLoadBalancer(lb LoadBalancer) Option {
+	return func(opts *Options) {
+		if lb != nil {
+			opts.loadBalancer = lb
+		}
+	}
+}
+
 // WithDLQChannel installs a Dead Letter Channel destination on a
 // TopicChannel or QueueChannel. When a handler returns a non-nil
 // error during dispatch, the channel publishes a DeadLetter[T]
@@ -154,7 +343,9 @@ func WithOverflowPolicy(p OverflowPolicy) Option {
 // failures of the DLQ Send itself are swallowed. The ErrorHandler
 // hook (see WithErrorHandler) fires INDEPENDENTLY from the DLQ
 // publication; the two are complementary (observability vs reprocess
-// queue), not alternatives.
+// queue), not alternatives. Messages discarded because their
+// Headers.ExpirationTime passed are published too, with ErrExpired as
+// LastError.
 //
 // Type parameter T must match the channel's T at construction;
 // mismatches are caught with cassert at build time. Nil dlq is
@@ -168,3 +359,197 @@ func WithDLQChannel[T any](dlq Channel[DeadLetter[T]]) Option {
 		}
 	}
 }
+
+// WithInterceptors appends interceptors to the channel's interceptor
+// chain; repeated calls accumulate in order. Every channel of this
+// package runs PreSend and PostSend around Send (SendAt and SendAfter
+// included) and PreHandle and AfterHandle around each handler
+// invocation; see ChannelInterceptor for the exact semantics.
+// PollableChannel has no handlers and NullChannel never invokes them,
+// so they only run the send hooks.
+//
+// Type parameter T must match the channel's T at construction;
+// mismatches are caught with cassert at build time. Nil interceptors
+// are ignored.
+func WithInterceptors[T any](interceptors ...ChannelInterceptor[T]) Option {
+	return func(opts *Options) {
+		for _, interceptor := range interceptors {
+			if interceptor != nil {
+				opts.interceptors = append(opts.interceptors, interceptor)
+			}
+		}
+	}
+}
+
+// WithRedeliveryPolicy makes TopicChannel and QueueChannel redeliver
+// a message whose handler failed instead of dead-lettering it at once.
+// The failed message is rescheduled on an internal delay heap, so
+// other messages keep flowing while it waits, and then handed back to
+// the same consumer pool (QueueChannel) or subscriber (TopicChannel).
+// Headers.DeliveryCount tracks the attempts. Rescheduled failures are
+// not reported: the ErrorHandler and the DLQ only receive the message
+// once policy.MaxAttempts is exhausted, RetryIf rejects the error, or
+// the redelivery cannot happen (see ErrRedeliveryFailed). Messages still
+// waiting at Stop are dead-lettered. Other channels ignore it.
+// Policies with MaxAttempts below 2, negative delays or an unknown
+// Backoff are ignored.
+func WithRedeliveryPolicy(policy RedeliveryPolicy) Option {
+	return func(opts *Options) {
+		if policy.MaxAttempts < 2 || policy.Delay < 0 || policy.MaxDelay < 0 {
+			return
+		}
+
+		if policy.Backoff < cretry.BackoffFixed || policy.Backoff > cretry.BackoffRandom {
+			return
+		}
+
+		if policy.Delay == 0 {
+			policy.Delay = defaultRedeliveryDelay
+		}
+
+		if policy.MaxDelay == 0 {
+			policy.MaxDelay = defaultRedeliveryMaxDelay
+		}
+
+		if policy.RetryIf == nil {
+			policy.RetryIf = cretry.AlwaysRetry
+		}
+
+		opts.redelivery = &policy
+	}
+}
+
+// WithVisibilityTimeout sets how long a PollableChannel lease (see
+// PollableChannel.ReceiveWithLease) keeps its message invisible to
+// other consumers before the message is requeued. Other channels
+// ignore it. Non-positive values are ignored.
+func WithVisibilityTimeout(timeout time.Duration) Option {
+	return func(opts *Options) {
+		if timeout > 0 {
+			opts.visibilityTimeout = timeout
+		}
+	}
+}
+
+// WithMaxDeliveries caps how many times a PollableChannel leases the
+// same message: once a message has been leased n times, a Nack or an
+// expired lease dead-letters it with ErrMaxDeliveries instead of
+// requeueing it. Zero (the default) means unlimited. Other channels
+// ignore it. Negative values are ignored.
+func WithMaxDeliveries(n int) Option {
+	return func(opts *Options) {
+		if n >= 0 {
+			opts.maxDeliveries = n
+		}
+	}
+}
+
+// WithClock sets the Clock every channel reads time from: scheduled
+// deliveries, poll leases, expiry checks, redelivery backoff, priority
+// aging and the durable queue's interval fsync. Tests pass a
+// messagingtest.FakeClock. Nil values are ignored.
+func WithClock(clock Clock) Option {
+	return func(opts *Options) {
+		if clock != nil {
+			opts.clock = clock
+		}
+	}
+}
+
+// WithSegmentSize sets the size in bytes at which the durable queue
+// rolls its active write-ahead log segment. Smaller segments reclaim
+// disk sooner once acknowledged; larger ones mean fewer files. Non-
+// positive values are ignored.
+func WithSegmentSize(size int64) Option {
+	return func(opts *Options) {
+		if size > 0 {
+			opts.segmentSize = size
+		}
+	}
+}
+
+// WithSegmentRetention sets how many fully acknowledged segments the
+// durable queue keeps on disk before deleting them (oldest first). The
+// default 0 deletes a segment as soon as every record in it has been
+// acknowledged; a positive value keeps recent history around for
+// inspection. Negative values are ignored.
+func WithSegmentRetention(n int) Option {
+	return func(opts *Options) {
+		if n >= 0 {
+			opts.segmentRetention = n
+		}
+	}
+}
+
+// WithFsyncPolicy selects when the durable queue flushes its write-
+// ahead log to stable storage. See FsyncPolicy for the available
+// strategies. Values outside the defined range are ignored (the
+// previously configured policy is preserved).
+func WithFsyncPolicy(p FsyncPolicy) Option {
+	return func(opts *Options) {
+		if p >= FsyncAlways && p <= FsyncNever {
+			opts.fsyncPolicy = p
+		}
+	}
+}
+
+// WithFsyncInterval sets the background flush cadence used by the
+// durable queue under FsyncInterval. Non-positive values are ignored.
+func WithFsyncInterval(interval time.Duration) Option {
+	return func(opts *Options) {
+		if interval > 0 {
+			opts.fsyncInterval = interval
+		}
+	}
+}
+
+// SubscriptionOption is a functional option for configuring one
+// subscription through SubscribeWith.
+type SubscriptionOption func(opts *SubscriptionOptions)
+
+// SubscriptionOptions holds the configuration of one subscription.
+type SubscriptionOptions struct {
+	weight   int
+	capacity int
+}
+
+// NewSubscriptionOptions creates a new SubscriptionOptions with
+// sensible defaults and applies the given options. Defaults: weight 1,
+// unbounded capacity.
+func NewSubscriptionOptions(opts ...SubscriptionOption) *SubscriptionOptions {
+	options := &SubscriptionOptions{
+		weight: 1,
+	}
+
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	return options
+}
+
+// WithSubscriptionWeight sets the subscription's share of the messages
+// under the Weighted load balancer: a subscriber of weight 3 receives
+// three messages for every one of a subscriber of weight 1. Other
+// strategies only report it in SubscriberState. Non-positive values
+// are ignored.
+func WithSubscriptionWeight(weight int) SubscriptionOption {
+	return func(opts *SubscriptionOptions) {
+		if weight > 0 {
+			opts.weight = weight
+		}
+	}
+}
+
+// WithSubscriptionCapacity caps the messages the subscription handles
+// at once. A QueueChannel skips a subscriber at its capacity instead of
+// queueing work behind it, so a slow handler cannot hold back a worker
+// while faster ones are idle. Non-positive values are ignored (the
+// subscription stays unbounded).
+func WithSubscriptionCapacity(capacity int) SubscriptionOption {
+	return func(opts *SubscriptionOptions) {
+		if capacity > 0 {
+			opts.capacity = capacity
+		}
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"reflect"
 	"testing"
 	"time"
+
+	cretry "github.com/guidomantilla/yarumo/core/common/resilience/retry"
 )
 
 func TestNewOptions(t *testing.T) {
@@ -189,3 +191,423 @@ func TestWithDLQChannel_NilIgnored(t *testing.T) {
 		t.Fatalf("expected nil DLQ to be ignored, got %v", opts.dlq)
 	}
 }
+
+func TestNewOptions_DurableDefaults(t *testing.T) {
+	t.Parallel()
+
+	opts := NewOptions()
+	if opts.segmentSize != defaultSegmentSize {
+		t.Fatalf("expected segmentSize %d, got %d", defaultSegmentSize, opts.segmentSize)
+	}
+	if opts.segmentRetention != 0 {
+		t.Fatalf("expected segmentRetention 0, got %d", opts.segmentRetention)
+	}
+	if opts.fsyncPolicy != FsyncAlways {
+		t.Fatalf("expected FsyncAlways, got %v", opts.fsyncPolicy)
+	}
+	if opts.fsyncInterval != defaultFsyncInterval {
+		t.Fatalf("expected fsyncInterval %v, got %v", defaultFsyncInterval, opts.fsyncInterval)
+	}
+}
+
+func TestWithSegmentSize(t *testing.T) {
+	t.Parallel()
+
+	t.Run("positive value applied", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithSegmentSize(1024))
+		if opts.segmentSize != 1024 {
+			t.Fatalf("expected 1024, got %d", opts.segmentSize)
+		}
+	})
+
+	t.Run("non-positive ignored", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithSegmentSize(0), WithSegmentSize(-1))
+		if opts.segmentSize != defaultSegmentSize {
+			t.Fatalf("expected default %d, got %d", defaultSegmentSize, opts.segmentSize)
+		}
+	})
+}
+
+func TestWithSegmentRetention(t *testing.T) {
+	t.Parallel()
+
+	t.Run("non-negative value applied", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithSegmentRetention(3))
+		if opts.segmentRetention != 3 {
+			t.Fatalf("expected 3, got %d", opts.segmentRetention)
+		}
+	})
+
+	t.Run("negative ignored", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithSegmentRetention(2), WithSegmentRetention(-1))
+		if opts.segmentRetention != 2 {
+			t.Fatalf("expected 2 preserved, got %d", opts.segmentRetention)
+		}
+	})
+}
+
+func TestWithFsyncPolicy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid value applied", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithFsyncPolicy(FsyncInterval))
+		if opts.fsyncPolicy != FsyncInterval {
+			t.Fatalf("expected FsyncInterval, got %v", opts.fsyncPolicy)
+		}
+	})
+
+	t.Run("out of range ignored", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithFsyncPolicy(FsyncNever), WithFsyncPolicy(FsyncPolicy(99)), WithFsyncPolicy(FsyncPolicy(-1)))
+		if opts.fsyncPolicy != FsyncNever {
+			t.Fatalf("expected FsyncNever preserved, got %v", opts.fsyncPolicy)
+		}
+	})
+}
+
+func TestWithFsyncInterval(t *testing.T) {
+	t.Parallel()
+
+	t.Run("positive value applied", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithFsyncInterval(time.Millisecond))
+		if opts.fsyncInterval != time.Millisecond {
+			t.Fatalf("expected 1ms, got %v", opts.fsyncInterval)
+		}
+	})
+
+	t.Run("non-positive ignored", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithFsyncInterval(0))
+		if opts.fsyncInterval != defaultFsyncInterval {
+			t.Fatalf("expected default %v, got %v", defaultFsyncInterval, opts.fsyncInterval)
+		}
+	})
+}
+
+func TestWithDispatchOrder(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default is FIFO", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions()
+		if opts.dispatchOrder != DispatchFIFO {
+			t.Fatalf("expected DispatchFIFO, got %v", opts.dispatchOrder)
+		}
+	})
+
+	t.Run("valid value applied", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithDispatchOrder(DispatchPriority))
+		if opts.dispatchOrder != DispatchPriority {
+			t.Fatalf("expected DispatchPriority, got %v", opts.dispatchOrder)
+		}
+	})
+
+	t.Run("out of range ignored", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithDispatchOrder(DispatchPriority), WithDispatchOrder(DispatchOrder(99)), WithDispatchOrder(DispatchOrder(-1)))
+		if opts.dispatchOrder != DispatchPriority {
+			t.Fatalf("expected DispatchPriority preserved, got %v", opts.dispatchOrder)
+		}
+	})
+}
+
+func TestWithPartitionKey(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default is unpartitioned", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions()
+		if opts.partitionKey != nil {
+			t.Fatal("expected no partition key by default")
+		}
+	})
+
+	t.Run("WithPartitioning keys on the correlation id", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithPartitioning())
+		if opts.partitionKey == nil {
+			t.Fatal("expected a partition key")
+		}
+
+		got := opts.partitionKey(Headers{CorrelationID: "order-1"})
+		if got != "order-1" {
+			t.Fatalf("key = %q, want order-1", got)
+		}
+	})
+
+	t.Run("nil ignored", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithPartitionKey(PartitionByHeader("tenant")), WithPartitionKey(nil))
+		if opts.partitionKey == nil {
+			t.Fatal("expected the previous partition key preserved")
+		}
+	})
+}
+
+func TestWithLoadBalancer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default is round-robin", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions()
+		if _, ok := opts.loadBalancer.(*roundRobin); !ok {
+			t.Fatalf("expected RoundRobin by default, got %T", opts.loadBalancer)
+		}
+	})
+
+	t.Run("installs the balancer", func(t *testing.T) {
+		t.Parallel()
+
+		lb := LeastInFlight()
+
+		opts := NewOptions(WithLoadBalancer(lb))
+		if opts.loadBalancer != lb {
+			t.Fatalf("expected the given balancer, got %T", opts.loadBalancer)
+		}
+	})
+
+	t.Run("nil ignored", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithLoadBalancer(Random()), WithLoadBalancer(nil))
+		if _, ok := opts.loadBalancer.(random); !ok {
+			t.Fatalf("expected the previous balancer preserved, got %T", opts.loadBalancer)
+		}
+	})
+}
+
+func TestNewSubscriptionOptions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("defaults to weight 1 and unbounded capacity", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewSubscriptionOptions()
+		if opts.weight != 1 || opts.capacity != 0 {
+			t.Fatalf("expected weight 1 and capacity 0, got %+v", opts)
+		}
+	})
+
+	t.Run("positive values applied", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewSubscriptionOptions(WithSubscriptionWeight(3), WithSubscriptionCapacity(2))
+		if opts.weight != 3 || opts.capacity != 2 {
+			t.Fatalf("expected weight 3 and capacity 2, got %+v", opts)
+		}
+	})
+
+	t.Run("non-positive values ignored", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewSubscriptionOptions(WithSubscriptionWeight(0), WithSubscriptionCapacity(-1))
+		if opts.weight != 1 || opts.capacity != 0 {
+			t.Fatalf("expected defaults preserved, got %+v", opts)
+		}
+	})
+}
+
+func TestWithVisibilityTimeout(t *testing.T) {
+	t.Parallel()
+
+	t.Run("positive value applied", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithVisibilityTimeout(time.Minute))
+		if opts.visibilityTimeout != time.Minute {
+			t.Fatalf("expected 1m, got %v", opts.visibilityTimeout)
+		}
+	})
+
+	t.Run("non-positive ignored", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithVisibilityTimeout(0), WithVisibilityTimeout(-time.Second))
+		if opts.visibilityTimeout != defaultVisibilityTimeout {
+			t.Fatalf("expected default %v, got %v", defaultVisibilityTimeout, opts.visibilityTimeout)
+		}
+	})
+}
+
+func TestWithMaxDeliveries(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default is unlimited", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions()
+		if opts.maxDeliveries != 0 {
+			t.Fatalf("expected 0, got %d", opts.maxDeliveries)
+		}
+	})
+
+	t.Run("negative ignored", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithMaxDeliveries(3), WithMaxDeliveries(-1))
+		if opts.maxDeliveries != 3 {
+			t.Fatalf("expected 3 preserved, got %d", opts.maxDeliveries)
+		}
+	})
+}
+
+func TestWithPriorityAging(t *testing.T) {
+	t.Parallel()
+
+	t.Run("positive value applied", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithPriorityAging(time.Minute))
+		if opts.priorityAging != time.Minute {
+			t.Fatalf("expected 1m, got %v", opts.priorityAging)
+		}
+	})
+
+	t.Run("non-positive ignored", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithPriorityAging(0), WithPriorityAging(-time.Second))
+		if opts.priorityAging != defaultPriorityAging {
+			t.Fatalf("expected default %v, got %v", defaultPriorityAging, opts.priorityAging)
+		}
+	})
+}
+
+func TestWithExpiredHandler(t *testing.T) {
+	t.Parallel()
+
+	t.Run("defaults to the error handler", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithErrorHandler(SilentErrorHandler))
+		if opts.expiredHandler != nil {
+			t.Fatal("expected no expired handler by default")
+		}
+
+		want := reflect.ValueOf(SilentErrorHandler).Pointer()
+		got := reflect.ValueOf(expiredHook(opts)).Pointer()
+		if got != want {
+			t.Fatal("expected expiredHook to fall back to the error handler")
+		}
+	})
+
+	t.Run("installs the hook", func(t *testing.T) {
+		t.Parallel()
+
+		called := false
+		opts := NewOptions(WithExpiredHandler(func(_ context.Context, _ any, _ error) { called = true }))
+
+		expiredHook(opts)(context.Background(), nil, ErrExpired)
+		if !called {
+			t.Fatal("expected the installed expired handler")
+		}
+	})
+
+	t.Run("nil ignored", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithExpiredHandler(nil))
+		if opts.expiredHandler != nil {
+			t.Fatal("expected nil to be ignored")
+		}
+	})
+}
+
+func TestWithRedeliveryPolicy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("fills defaults", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithRedeliveryPolicy(RedeliveryPolicy{MaxAttempts: 3}))
+		if opts.redelivery == nil {
+			t.Fatal("expected policy installed")
+		}
+		if opts.redelivery.Delay != defaultRedeliveryDelay || opts.redelivery.MaxDelay != defaultRedeliveryMaxDelay {
+			t.Fatalf("expected default delays, got %v/%v", opts.redelivery.Delay, opts.redelivery.MaxDelay)
+		}
+		if opts.redelivery.RetryIf == nil {
+			t.Fatal("expected default RetryIf")
+		}
+	})
+
+	t.Run("invalid policies ignored", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(
+			WithRedeliveryPolicy(RedeliveryPolicy{MaxAttempts: 1}),
+			WithRedeliveryPolicy(RedeliveryPolicy{MaxAttempts: 3, Delay: -time.Second}),
+			WithRedeliveryPolicy(RedeliveryPolicy{MaxAttempts: 3, MaxDelay: -time.Second}),
+			WithRedeliveryPolicy(RedeliveryPolicy{MaxAttempts: 3, Backoff: cretry.Backoff(99)}),
+		)
+		if opts.redelivery != nil {
+			t.Fatalf("expected invalid policies ignored, got %+v", opts.redelivery)
+		}
+	})
+}
+
+// fixedClock is a Clock stub that always reads the same instant.
+type fixedClock struct {
+	systemClock
+
+	now time.Time
+}
+
+func (c fixedClock) Now() time.Time {
+	return c.now
+}
+
+func TestWithClock(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default is the system clock", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions()
+		if _, ok := opts.clock.(systemClock); !ok {
+			t.Fatalf("expected systemClock, got %T", opts.clock)
+		}
+	})
+
+	t.Run("clock applied", func(t *testing.T) {
+		t.Parallel()
+
+		instant := time.Date(2020, time.May, 1, 0, 0, 0, 0, time.UTC)
+
+		opts := NewOptions(WithClock(fixedClock{now: instant}))
+		if !opts.clock.Now().Equal(instant) {
+			t.Fatalf("expected %v, got %v", instant, opts.clock.Now())
+		}
+	})
+
+	t.Run("nil ignored", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithClock(nil))
+		if _, ok := opts.clock.(systemClock); !ok {
+			t.Fatalf("expected systemClock, got %T", opts.clock)
+		}
+	})
+}
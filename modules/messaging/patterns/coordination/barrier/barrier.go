@@ -2,6 +2,7 @@ package barrier
 
 import (
 	"context"
+	"errors"
 	"sync"
 	"time"
 
@@ -29,27 +30,30 @@ type barrierGroup[T any] struct {
 // A background sweeper goroutine evicts groups that exceed
 // WithGroupTimeout without reaching quorum.
 type barrier[T any] struct {
-	name          string
-	src           messaging.Channel[T]
-	dst           messaging.Channel[T]
-	quorum        int
-	groupTimeout  time.Duration
-	maxGroups     int
-	sweepInterval time.Duration
-	errorHandler  messaging.ErrorHandler
-	dropHandler   DropHandler
+	name           string
+	src            messaging.Channel[T]
+	dst            messaging.Channel[T]
+	quorum         int
+	groupTimeout   time.Duration
+	maxGroups      int
+	sweepInterval  time.Duration
+	errorHandler   messaging.ErrorHandler
+	dropHandler    DropHandler
+	expiredHandler messaging.ErrorHandler
+	clock          messaging.Clock
+	stats          messaging.StatsRecorder
 
 	groupsMu sync.Mutex
 	groups   map[string]*barrierGroup[T]
 
 	sweeperWG sync.WaitGroup
 
-	done         chan struct{}
-	sweeperDone  chan struct{}
-	sweeperOnce  sync.Once
-	startOnce    sync.Once
-	stopOnce     sync.Once
-	doneOnce     sync.Once
+	done        chan struct{}
+	sweeperDone chan struct{}
+	sweeperOnce sync.Once
+	startOnce   sync.Once
+	stopOnce    sync.Once
+	doneOnce    sync.Once
 
 	subMu     sync.Mutex
 	subCancel messaging.Cancel
@@ -85,20 +89,26 @@ func NewBarrier[T any](name string, src messaging.Channel[T], dst messaging.Chan
 	options := NewOptions(opts...)
 	cassert.True(options.groupTimeout > 0, "WithGroupTimeout is required (positive duration)")
 
-	return &barrier[T]{
-		name:          name,
-		src:           src,
-		dst:           dst,
-		quorum:        quorum,
-		groupTimeout:  options.groupTimeout,
-		maxGroups:     options.maxGroups,
-		sweepInterval: options.sweepInterval,
-		errorHandler:  options.errorHandler,
-		dropHandler:   options.dropHandler,
-		groups:        map[string]*barrierGroup[T]{},
-		done:          make(chan struct{}),
-		sweeperDone:   make(chan struct{}),
+	b := &barrier[T]{
+		name:           name,
+		src:            src,
+		dst:            dst,
+		quorum:         quorum,
+		groupTimeout:   options.groupTimeout,
+		maxGroups:      options.maxGroups,
+		sweepInterval:  options.sweepInterval,
+		errorHandler:   options.errorHandler,
+		dropHandler:    options.dropHandler,
+		expiredHandler: options.expiredHandler,
+		clock:          options.clock,
+		groups:         map[string]*barrierGroup[T]{},
+		done:           make(chan struct{}),
+		sweeperDone:    make(chan struct{}),
 	}
+
+	b.stats.UseClock(options.clock)
+
+	return b
 }
 
 // Name returns the barrier's identity used in lifecycle logs.
@@ -182,11 +192,15 @@ func (b *barrier[T]) Done() <-chan struct{} {
 
 // handle is the Handler[T] subscribed on the source channel. It
 // accumulates msg into its CorrelationID group and, on quorum,
-// releases the whole group to dst in arrival order. Failures and
+// releases the whole group to dst in arrival order, skipping messages
+// whose Headers.ExpirationTime has passed. Failures and
 // drops flow through the configured hooks; the function itself
 // always returns nil so barrier concerns never propagate to the
 // source channel's Send caller.
 func (b *barrier[T]) handle(ctx context.Context, msg messaging.Message[T]) error {
+	end := b.stats.Begin()
+	defer end(nil)
+
 	correlation := msg.Headers.CorrelationID
 	if correlation == "" {
 		b.reportDrop(ctx, msg)
@@ -201,16 +215,52 @@ func (b *barrier[T]) handle(ctx context.Context, msg messaging.Message[T]) error
 		return nil
 	}
 
+	now := b.clock.Now()
+
 	for _, m := range released {
+		if messaging.IsExpired(m, now) {
+			b.reportExpired(ctx, m)
+
+			continue
+		}
+
 		err := b.dst.Send(ctx, m)
 		if err != nil {
 			b.reportError(ctx, m, ErrBarrier(ErrForwardFailed, err))
+
+			continue
 		}
+
+		b.stats.RecordSent()
 	}
 
 	return nil
 }
 
+// Stats returns a snapshot of the barrier's runtime statistics.
+// BufferLength is the number of messages held in groups still short
+// of their quorum. Subscribers is 1 while the source subscription is
+// active.
+func (b *barrier[T]) Stats() messaging.Stats {
+	cassert.NotNil(b, "barrier is nil")
+
+	stats := b.stats.Snapshot()
+
+	b.groupsMu.Lock()
+	for _, group := range b.groups {
+		stats.BufferLength += len(group.msgs)
+	}
+	b.groupsMu.Unlock()
+
+	b.subMu.Lock()
+	if b.subCancel != nil {
+		stats.Subscribers = 1
+	}
+	b.subMu.Unlock()
+
+	return stats
+}
+
 // appendAndMaybeRelease appends msg to its correlation group and, if
 // the quorum is reached, removes and returns the accumulated slice
 // for release. dropped is true when the message could not be accepted
@@ -222,7 +272,7 @@ func (b *barrier[T]) appendAndMaybeRelease(msg messaging.Message[T], correlation
 	b.groupsMu.Lock()
 	defer b.groupsMu.Unlock()
 
-	now := time.Now()
+	now := b.clock.Now()
 
 	group, exists := b.groups[correlation]
 	if !exists {
@@ -253,6 +303,8 @@ func (b *barrier[T]) appendAndMaybeRelease(msg messaging.Message[T], correlation
 // ErrorHandler is guaranteed non-nil by NewOptions, so the nil-guard
 // is defensive only.
 func (b *barrier[T]) reportError(ctx context.Context, msg messaging.Message[T], err error) {
+	b.stats.RecordFailure()
+
 	if b.errorHandler == nil {
 		return
 	}
@@ -264,6 +316,8 @@ func (b *barrier[T]) reportError(ctx context.Context, msg messaging.Message[T],
 // is nil by default (silent drops); the guard skips invocation in
 // that case.
 func (b *barrier[T]) reportDrop(ctx context.Context, msg messaging.Message[T]) {
+	b.stats.RecordDrop()
+
 	if b.dropHandler == nil {
 		return
 	}
@@ -271,19 +325,36 @@ func (b *barrier[T]) reportDrop(ctx context.Context, msg messaging.Message[T]) {
 	b.dropHandler(ctx, msg)
 }
 
+// reportExpired counts an expired msg as dropped and forwards it to
+// the configured expired handler, or to the DropHandler when none is
+// installed.
+func (b *barrier[T]) reportExpired(ctx context.Context, msg messaging.Message[T]) {
+	b.stats.RecordDrop()
+
+	if b.expiredHandler != nil {
+		b.expiredHandler(ctx, msg, errors.Join(messaging.ErrExpired, messaging.ErrDropped))
+
+		return
+	}
+
+	if b.dropHandler != nil {
+		b.dropHandler(ctx, msg)
+	}
+}
+
 // sweep is the timeout sweeper goroutine. It wakes on sweepInterval
 // and evicts any group whose lastSeen is older than groupTimeout,
 // firing the drop hook for every still-accumulated message. The
 // goroutine exits when sweeperDone is closed (Stop).
 func (b *barrier[T]) sweep() {
-	ticker := time.NewTicker(b.sweepInterval)
+	ticker := b.clock.NewTicker(b.sweepInterval)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-b.sweeperDone:
 			return
-		case <-ticker.C:
+		case <-ticker.C():
 			b.sweepOnce()
 		}
 	}
@@ -293,7 +364,7 @@ func (b *barrier[T]) sweep() {
 // groupsMu but the drop hook is invoked outside the lock so a slow
 // hook does not block incoming Send.
 func (b *barrier[T]) sweepOnce() {
-	now := time.Now()
+	now := b.clock.Now()
 
 	type evicted struct {
 		correlation string
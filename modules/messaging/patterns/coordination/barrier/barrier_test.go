@@ -10,6 +10,7 @@ import (
 
 	"github.com/guidomantilla/yarumo/core/common/lifecycle"
 	"github.com/guidomantilla/yarumo/messaging"
+	"github.com/guidomantilla/yarumo/messaging/messagingtest"
 )
 
 // captureErrors returns a thread-safe ErrorHandler that appends every
@@ -688,6 +689,50 @@ func TestBarrier_Lifecycle(t *testing.T) {
 			t.Fatalf("expected ErrShutdownTimeout, got %v", err)
 		}
 	})
+
+	t.Run("times out on the injected clock", func(t *testing.T) {
+		t.Parallel()
+
+		clock := messagingtest.NewFakeClock(time.Time{})
+		src := messaging.NewPipelineChannel[int]()
+		dst := messaging.NewPipelineChannel[int]()
+
+		dropHandler, getDrops := captureDrops()
+
+		b := NewBarrier("test", src, dst, 2,
+			WithGroupTimeout(time.Hour),
+			WithSweepInterval(time.Minute),
+			WithDropHandler(dropHandler),
+			WithClock(clock))
+
+		err := b.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		t.Cleanup(func() {
+			_ = b.Stop(context.Background())
+		})
+
+		err = src.Send(context.Background(), messaging.Message[int]{
+			Payload: 1,
+			Headers: messaging.Headers{CorrelationID: "stuck"},
+		})
+		if err != nil {
+			t.Fatalf("send: %v", err)
+		}
+
+		clock.WaitForTimers(t, 1)
+		clock.Advance(59 * time.Minute)
+
+		if got := getDrops(); got != 0 {
+			t.Fatalf("expected no drops before the group timeout, got %d", got)
+		}
+
+		clock.Advance(2 * time.Minute)
+
+		messagingtest.WaitUntil(t, func() bool { return getDrops() == 1 }, "group timeout drop")
+	})
 }
 
 func TestBarrier_Options(t *testing.T) {
@@ -739,6 +784,102 @@ func TestBarrier_Options(t *testing.T) {
 	})
 }
 
+func TestBarrier_Expiration(t *testing.T) {
+	t.Parallel()
+
+	send := func(t *testing.T, src messaging.Channel[int], payload int, expiration time.Time) {
+		t.Helper()
+
+		err := src.Send(context.Background(), messaging.Message[int]{
+			Payload: payload,
+			Headers: messaging.Headers{CorrelationID: "saga-1", ExpirationTime: expiration},
+		})
+		if err != nil {
+			t.Fatalf("send %d: %v", payload, err)
+		}
+	}
+
+	t.Run("expired messages are not released", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		dst := messaging.NewPipelineChannel[int]()
+
+		dstHandler, getMsgs := captureMessages()
+		expiredHandler, getExpired := captureErrors()
+
+		_, err := dst.Subscribe(dstHandler)
+		if err != nil {
+			t.Fatalf("subscribe: %v", err)
+		}
+
+		b := NewBarrier("test", src, dst, 2,
+			WithGroupTimeout(time.Second),
+			WithExpiredHandler(expiredHandler),
+		)
+
+		err = b.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		t.Cleanup(func() {
+			_ = b.Stop(context.Background())
+		})
+
+		send(t, src, 1, time.Now().Add(-time.Minute))
+		send(t, src, 2, time.Time{})
+
+		msgs := getMsgs()
+		if len(msgs) != 1 || msgs[0].Payload != 2 {
+			t.Fatalf("expected only the live message released, got %v", msgs)
+		}
+
+		expired := getExpired()
+		if len(expired) != 1 || !errors.Is(expired[0], messaging.ErrExpired) {
+			t.Fatalf("expected one ErrExpired report, got %v", expired)
+		}
+	})
+
+	t.Run("falls back to the drop handler", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		dst := messaging.NewPipelineChannel[int]()
+
+		dropHandler, getDrops := captureDrops()
+
+		b := NewBarrier("test", src, dst, 1,
+			WithGroupTimeout(time.Second),
+			WithDropHandler(dropHandler),
+		)
+
+		err := b.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		t.Cleanup(func() {
+			_ = b.Stop(context.Background())
+		})
+
+		send(t, src, 1, time.Now().Add(-time.Minute))
+
+		if getDrops() != 1 {
+			t.Fatalf("expected 1 drop, got %d", getDrops())
+		}
+	})
+
+	t.Run("nil expired handler is ignored", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithExpiredHandler(nil))
+		if opts.expiredHandler != nil {
+			t.Fatal("expected nil expired handler")
+		}
+	})
+}
+
 // failingChannel is a Channel[T] test double whose Send always returns
 // the configured err. Subscribe is a no-op returning a no-op Cancel.
 type failingChannel[T any] struct {
@@ -752,3 +893,72 @@ func (c *failingChannel[T]) Send(_ context.Context, _ messaging.Message[T]) erro
 func (c *failingChannel[T]) Subscribe(_ messaging.Handler[T]) (messaging.Cancel, error) {
 	return func() {}, nil
 }
+
+func TestBarrier_Stats(t *testing.T) {
+	t.Parallel()
+
+	send := func(t *testing.T, src messaging.Channel[int], correlation string, expiration time.Time) {
+		t.Helper()
+
+		err := src.Send(context.Background(), messaging.Message[int]{
+			Headers: messaging.Headers{CorrelationID: correlation, ExpirationTime: expiration},
+		})
+		if err != nil {
+			t.Fatalf("send: %v", err)
+		}
+	}
+
+	t.Run("counts releases, drops and held messages", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		dst := messaging.NewPipelineChannel[int]()
+
+		b := NewBarrier("test", src, dst, 2, WithGroupTimeout(time.Minute))
+
+		err := b.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		t.Cleanup(func() { _ = b.Stop(context.Background()) })
+
+		// No expired or drop handler: the expiry is still counted.
+		send(t, src, "a", time.Now().Add(-time.Minute))
+		send(t, src, "a", time.Time{})
+		send(t, src, "", time.Time{})
+		send(t, src, "b", time.Time{})
+
+		got := b.Stats()
+		if got.Subscribers != 1 || got.Delivered != 4 || got.Sent != 1 || got.Dropped != 2 || got.Failed != 0 || got.BufferLength != 1 {
+			t.Fatalf("Stats = %+v, want Subscribers=1 Delivered=4 Sent=1 Dropped=2 Failed=0 BufferLength=1", got)
+		}
+	})
+
+	t.Run("counts forward failures", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		dst := &failingChannel[int]{err: errors.New("dst down")}
+
+		b := NewBarrier("test", src, dst, 2,
+			WithGroupTimeout(time.Minute),
+			WithErrorHandler(messaging.SilentErrorHandler),
+		)
+
+		err := b.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		t.Cleanup(func() { _ = b.Stop(context.Background()) })
+
+		send(t, src, "a", time.Time{})
+		send(t, src, "a", time.Time{})
+
+		got := b.Stats()
+		if got.Delivered != 2 || got.Sent != 0 || got.Failed != 2 || got.LastErrorTime.IsZero() {
+			t.Fatalf("Stats = %+v, want Delivered=2 Sent=0 Failed=2 and LastErrorTime set", got)
+		}
+	})
+}
@@ -47,7 +47,10 @@
 // The handler installed on the source channel always returns nil.
 // Forward Send failures (during release) flow through WithErrorHandler;
 // intentional drops (missing correlation, quorum timeout, MaxGroups
-// cap, Stop drain) flow through WithDropHandler. Nothing propagates
+// cap, Stop drain) flow through WithDropHandler. Messages whose
+// Headers.ExpirationTime has passed by the time their group reaches
+// quorum are not forwarded; they flow through WithExpiredHandler (or
+// WithDropHandler when none is installed). Nothing propagates
 // to the source channel's Send caller error path, consistent with the
 // package-wide policy in modules/messaging/CODING_STANDARDS.md.
 package barrier
@@ -57,6 +60,7 @@ import (
 	"time"
 
 	"github.com/guidomantilla/yarumo/core/common/lifecycle"
+	"github.com/guidomantilla/yarumo/messaging"
 )
 
 // DefaultMaxGroups is the default cap on the number of distinct
@@ -78,13 +82,15 @@ var (
 )
 
 // Barrier is the public interface for a Barrier endpoint. It embeds
-// lifecycle.Component so callers wire it up with lifecycle.Build. The
+// lifecycle.Component so callers wire it up with lifecycle.Build, and
+// messaging.Inspectable to expose its runtime statistics. The
 // interface exists (rather than returning lifecycle.Component
 // directly) so the consumer's API surface preserves "this is a
 // Barrier" semantics and the type stays open to future barrier-
 // specific methods without breaking callers.
 type Barrier[T any] interface {
 	lifecycle.Component
+	messaging.Inspectable
 }
 
 // DropHandler is the optional observability hook invoked once per
@@ -96,7 +102,9 @@ type Barrier[T any] interface {
 //   - a group failed to reach quorum within WithGroupTimeout (fires
 //     once per accumulated message in that group);
 //   - Stop was called with still-incomplete groups (fires once per
-//     accumulated message).
+//     accumulated message);
+//   - a released message had passed its Headers.ExpirationTime and no
+//     WithExpiredHandler is installed.
 //
 // msg is type-erased; cast it inside the hook when payload-specific
 // behavior is needed. The hook is invoked from the source channel's
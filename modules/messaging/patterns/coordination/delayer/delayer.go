@@ -2,6 +2,7 @@ package delayer
 
 import (
 	"context"
+	"errors"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -24,14 +25,17 @@ import (
 // new message that would exceed the bound is dropped via the
 // DropHandler hook with ErrMaxPendingExceeded.
 type delayer[T any] struct {
-	name         string
-	src          messaging.Channel[T]
-	dst          messaging.Channel[T]
-	fixedDelay   time.Duration
-	delayFn      DelayFn[T]
-	maxPending   int
-	errorHandler messaging.ErrorHandler
-	dropHandler  DropHandler
+	name           string
+	src            messaging.Channel[T]
+	dst            messaging.Channel[T]
+	fixedDelay     time.Duration
+	delayFn        DelayFn[T]
+	maxPending     int
+	errorHandler   messaging.ErrorHandler
+	dropHandler    DropHandler
+	expiredHandler messaging.ErrorHandler
+	clock          messaging.Clock
+	stats          messaging.StatsRecorder
 
 	internal          messaging.ScheduledChannel[T]
 	internalLifecycle lifecycle.Component
@@ -59,12 +63,15 @@ type delayer[T any] struct {
 //  1. WithFixedDelay — constant per-message duration.
 //  2. WithDelayFn — caller computes the delay per message.
 //  3. Default — uses Headers.ExpirationTime as deliver-at; a zero or
-//     past ExpirationTime forwards immediately.
+//     past ExpirationTime forwards immediately. The header is cleared
+//     on the forwarded message.
 //
 // Other optional behaviors:
 //
 //   - WithMaxPending bounds in-flight messages (default defaultMaxPending).
-//   - WithErrorHandler / WithDropHandler observe failures and drops.
+//   - WithErrorHandler / WithDropHandler / WithExpiredHandler observe
+//     failures, drops and expiries.
+//   - WithClock replaces the system clock (deterministic tests).
 func NewDelayer[T any](name string, src messaging.Channel[T], dst messaging.Channel[T], opts ...Option[T]) Delayer[T] {
 	cassert.NotEmpty(name, "name is empty")
 	cassert.NotNil(src, "source channel is nil")
@@ -72,23 +79,33 @@ func NewDelayer[T any](name string, src messaging.Channel[T], dst messaging.Chan
 
 	options := NewOptions(opts...)
 
-	internal := messaging.NewScheduledChannel[T](name + "-internal")
+	d := &delayer[T]{
+		name:           name,
+		src:            src,
+		dst:            dst,
+		fixedDelay:     options.fixedDelay,
+		delayFn:        options.delayFn,
+		maxPending:     options.maxPending,
+		errorHandler:   options.errorHandler,
+		dropHandler:    options.dropHandler,
+		expiredHandler: options.expiredHandler,
+		clock:          options.clock,
+		done:           make(chan struct{}),
+	}
+
+	d.stats.UseClock(d.clock)
+
+	// Messages that expire while scheduled never reach forward, so the
+	// internal channel hands them back through expire to keep the
+	// pending counter accurate.
+	internal := messaging.NewScheduledChannel[T](name+"-internal", messaging.WithExpiredHandler(d.expire), messaging.WithClock(d.clock))
 	internalLifecycle, ok := internal.(lifecycle.Component)
 	cassert.True(ok, "internal ScheduledChannel must implement lifecycle.Component")
 
-	return &delayer[T]{
-		name:              name,
-		src:               src,
-		dst:               dst,
-		fixedDelay:        options.fixedDelay,
-		delayFn:           options.delayFn,
-		maxPending:        options.maxPending,
-		errorHandler:      options.errorHandler,
-		dropHandler:       options.dropHandler,
-		internal:          internal,
-		internalLifecycle: internalLifecycle,
-		done:              make(chan struct{}),
-	}
+	d.internal = internal
+	d.internalLifecycle = internalLifecycle
+
+	return d
 }
 
 // Name returns the delayer's identity used in lifecycle logs.
@@ -192,20 +209,50 @@ func (d *delayer[T]) Done() <-chan struct{} {
 	return d.done
 }
 
+// Stats returns a snapshot of the delayer's runtime statistics.
+// BufferLength is the number of messages waiting for their delay to
+// elapse and BufferCapacity the WithMaxPending bound. Subscribers is 1
+// while the source subscription is active.
+func (d *delayer[T]) Stats() messaging.Stats {
+	cassert.NotNil(d, "delayer is nil")
+
+	stats := d.stats.Snapshot()
+	stats.BufferLength = int(d.pending.Load())
+	stats.BufferCapacity = d.maxPending
+
+	d.mu.Lock()
+	if d.srcCancel != nil {
+		stats.Subscribers = 1
+	}
+	d.mu.Unlock()
+
+	return stats
+}
+
 // handle is the Handler[T] subscribed on the source channel. It
-// computes the per-message delay, enforces the WithMaxPending bound and
+// computes the per-message delay, discards already-expired messages,
+// enforces the WithMaxPending bound and
 // either forwards immediately (delay <= 0) or schedules deferred
 // delivery on the internal scheduled channel. The function always
 // returns nil so delayer concerns never propagate to the source
 // channel's Send caller.
 func (d *delayer[T]) handle(ctx context.Context, msg messaging.Message[T]) error {
-	delay := d.computeDelay(ctx, msg)
+	end := d.stats.Begin()
+	defer end(nil)
+
+	delay, consumed := d.computeDelay(ctx, msg)
+	if consumed {
+		msg.Headers.ExpirationTime = time.Time{}
+	}
+
+	if messaging.IsExpired(msg, d.clock.Now()) {
+		d.reportExpired(ctx, msg)
+
+		return nil
+	}
 
 	if delay <= 0 {
-		err := d.dst.Send(ctx, msg)
-		if err != nil {
-			d.reportError(ctx, msg, ErrDelayer(ErrForwardFailed, err))
-		}
+		d.send(ctx, msg)
 
 		return nil
 	}
@@ -233,13 +280,22 @@ func (d *delayer[T]) handle(ctx context.Context, msg messaging.Message[T]) error
 // the configured ErrorHandler.
 func (d *delayer[T]) forward(ctx context.Context, msg messaging.Message[T]) error {
 	d.pending.Add(-1)
+	d.send(ctx, msg)
 
+	return nil
+}
+
+// send forwards msg to the destination channel, counting it on
+// success and reporting ErrForwardFailed otherwise.
+func (d *delayer[T]) send(ctx context.Context, msg messaging.Message[T]) {
 	err := d.dst.Send(ctx, msg)
 	if err != nil {
 		d.reportError(ctx, msg, ErrDelayer(ErrForwardFailed, err))
+
+		return
 	}
 
-	return nil
+	d.stats.RecordSent()
 }
 
 // computeDelay selects the configured delay strategy and returns the
@@ -249,26 +305,39 @@ func (d *delayer[T]) forward(ctx context.Context, msg messaging.Message[T]) erro
 //  2. WithDelayFn (if set).
 //  3. Headers.ExpirationTime fallback — delivers when the deadline
 //     elapses; zero/past values forward immediately.
-func (d *delayer[T]) computeDelay(ctx context.Context, msg messaging.Message[T]) time.Duration {
+//
+// consumed is true when the fallback used Headers.ExpirationTime as the
+// deliver-at instant; the caller then clears the header so the message
+// is not discarded as expired on release.
+func (d *delayer[T]) computeDelay(ctx context.Context, msg messaging.Message[T]) (delay time.Duration, consumed bool) {
 	if d.fixedDelay > 0 {
-		return d.fixedDelay
+		return d.fixedDelay, false
 	}
 
 	if d.delayFn != nil {
-		return d.delayFn(ctx, msg)
+		return d.delayFn(ctx, msg), false
 	}
 
 	if msg.Headers.ExpirationTime.IsZero() {
-		return 0
+		return 0, false
 	}
 
-	return time.Until(msg.Headers.ExpirationTime)
+	return msg.Headers.ExpirationTime.Sub(d.clock.Now()), true
+}
+
+// expire is the expired-message hook of the internal scheduled channel.
+// It releases the pending slot the message held and reports it.
+func (d *delayer[T]) expire(ctx context.Context, msg any, _ error) {
+	d.pending.Add(-1)
+	d.reportExpired(ctx, msg)
 }
 
 // reportError forwards err to the configured ErrorHandler. ErrorHandler
 // is guaranteed non-nil by NewOptions (defaults to
 // messaging.DefaultErrorHandler), so the nil-guard is defensive only.
 func (d *delayer[T]) reportError(ctx context.Context, msg messaging.Message[T], err error) {
+	d.stats.RecordFailure()
+
 	if d.errorHandler == nil {
 		return
 	}
@@ -280,9 +349,29 @@ func (d *delayer[T]) reportError(ctx context.Context, msg messaging.Message[T],
 // ErrMaxPendingExceeded. DropHandler is nil by default (silent drop);
 // the guard skips invocation in that case.
 func (d *delayer[T]) reportDrop(ctx context.Context, msg messaging.Message[T]) {
+	d.stats.RecordDrop()
+
 	if d.dropHandler == nil {
 		return
 	}
 
 	d.dropHandler(ctx, msg, ErrDelayer(ErrMaxPendingExceeded))
 }
+
+// reportExpired forwards an expired msg to the configured expired
+// handler, or to the DropHandler when none is installed.
+func (d *delayer[T]) reportExpired(ctx context.Context, msg any) {
+	d.stats.RecordDrop()
+
+	err := errors.Join(messaging.ErrExpired, messaging.ErrDropped)
+
+	if d.expiredHandler != nil {
+		d.expiredHandler(ctx, msg, err)
+
+		return
+	}
+
+	if d.dropHandler != nil {
+		d.dropHandler(ctx, msg, err)
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/guidomantilla/yarumo/core/common/lifecycle"
 	"github.com/guidomantilla/yarumo/messaging"
+	"github.com/guidomantilla/yarumo/messaging/messagingtest"
 )
 
 // captureErrors returns a thread-safe ErrorHandler that appends every
@@ -344,6 +345,174 @@ func TestDelayer_ExpirationTime(t *testing.T) {
 	})
 }
 
+func TestDelayer_Expiration(t *testing.T) {
+	t.Parallel()
+
+	t.Run("message expiring while delayed is not forwarded", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		dst := messaging.NewPipelineChannel[int]()
+
+		sink, get := timingSink()
+		expiredHandler, getExpired := captureErrors()
+
+		_, err := dst.Subscribe(sink)
+		if err != nil {
+			t.Fatalf("subscribe: %v", err)
+		}
+
+		d := NewDelayer("test", src, dst,
+			WithFixedDelay[int](30*time.Millisecond),
+			WithMaxPending[int](1),
+			WithExpiredHandler[int](expiredHandler),
+		)
+
+		err = d.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		t.Cleanup(func() { _ = d.Stop(context.Background()) })
+
+		err = src.Send(context.Background(), messaging.Message[int]{
+			Payload: 1,
+			Headers: messaging.Headers{ExpirationTime: time.Now().Add(5 * time.Millisecond)},
+		})
+		if err != nil {
+			t.Fatalf("send: %v", err)
+		}
+
+		deadline := time.After(2 * time.Second)
+		for len(getExpired()) != 1 {
+			select {
+			case <-deadline:
+				t.Fatal("never reported as expired")
+			case <-time.After(5 * time.Millisecond):
+			}
+		}
+
+		if !errors.Is(getExpired()[0], messaging.ErrExpired) {
+			t.Fatalf("expected ErrExpired, got %v", getExpired()[0])
+		}
+
+		// The pending slot was released: a second message fits.
+		err = src.Send(context.Background(), messaging.Message[int]{Payload: 2})
+		if err != nil {
+			t.Fatalf("send: %v", err)
+		}
+
+		for len(get()) != 1 {
+			select {
+			case <-deadline:
+				t.Fatal("live message never delivered")
+			case <-time.After(5 * time.Millisecond):
+			}
+		}
+
+		if get()[0].payload != 2 {
+			t.Fatalf("expected payload 2, got %d", get()[0].payload)
+		}
+	})
+
+	t.Run("already expired message falls back to the drop handler", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		dst := messaging.NewPipelineChannel[int]()
+
+		sink, get := timingSink()
+		dropHandler, getDrops := captureDrops()
+
+		_, err := dst.Subscribe(sink)
+		if err != nil {
+			t.Fatalf("subscribe: %v", err)
+		}
+
+		d := NewDelayer("test", src, dst,
+			WithDelayFn(func(_ context.Context, _ messaging.Message[int]) time.Duration { return 0 }),
+			WithDropHandler[int](dropHandler),
+		)
+
+		err = d.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		t.Cleanup(func() { _ = d.Stop(context.Background()) })
+
+		err = src.Send(context.Background(), messaging.Message[int]{
+			Payload: 1,
+			Headers: messaging.Headers{ExpirationTime: time.Now().Add(-time.Minute)},
+		})
+		if err != nil {
+			t.Fatalf("send: %v", err)
+		}
+
+		if len(get()) != 0 {
+			t.Fatalf("expected nothing forwarded, got %v", get())
+		}
+
+		drops := getDrops()
+		if len(drops) != 1 || !errors.Is(drops[0], messaging.ErrExpired) {
+			t.Fatalf("expected one ErrExpired drop, got %v", drops)
+		}
+	})
+
+	t.Run("fallback consumes the header so async destinations keep the message", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		dst := messaging.NewQueueChannel[int]("dst")
+
+		delivered := make(chan messaging.Message[int], 1)
+
+		_, err := dst.Subscribe(func(_ context.Context, msg messaging.Message[int]) error {
+			delivered <- msg
+
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("subscribe: %v", err)
+		}
+
+		dstLifecycle := dst.(lifecycle.Component)
+
+		err = dstLifecycle.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start dst: %v", err)
+		}
+
+		t.Cleanup(func() { _ = dstLifecycle.Stop(context.Background()) })
+
+		d := NewDelayer("test", src, dst)
+
+		err = d.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		t.Cleanup(func() { _ = d.Stop(context.Background()) })
+
+		err = src.Send(context.Background(), messaging.Message[int]{
+			Payload: 1,
+			Headers: messaging.Headers{ExpirationTime: time.Now().Add(20 * time.Millisecond)},
+		})
+		if err != nil {
+			t.Fatalf("send: %v", err)
+		}
+
+		select {
+		case msg := <-delivered:
+			if !msg.Headers.ExpirationTime.IsZero() {
+				t.Fatalf("expected ExpirationTime cleared, got %v", msg.Headers.ExpirationTime)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("never delivered")
+		}
+	})
+}
+
 func TestDelayer_ImmediateForward(t *testing.T) {
 	t.Parallel()
 
@@ -689,6 +858,75 @@ func TestDelayer_Lifecycle(t *testing.T) {
 	})
 }
 
+func TestDelayer_Clock(t *testing.T) {
+	t.Parallel()
+
+	t.Run("fixed delay elapses on the injected clock", func(t *testing.T) {
+		t.Parallel()
+
+		clock := messagingtest.NewFakeClock(time.Time{})
+		src := messaging.NewPipelineChannel[int]()
+		dst := messagingtest.NewRecordingChannel[int]()
+
+		d := NewDelayer("test", src, dst, WithFixedDelay[int](time.Hour), WithClock[int](clock))
+
+		err := d.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		t.Cleanup(func() { _ = d.Stop(context.Background()) })
+
+		err = src.Send(context.Background(), messaging.Message[int]{Payload: 1})
+		if err != nil {
+			t.Fatalf("send: %v", err)
+		}
+
+		clock.WaitForTimers(t, 1)
+		clock.Advance(time.Hour - time.Second)
+		dst.AssertCount(t, 0)
+
+		clock.WaitForTimers(t, 1)
+		clock.Advance(time.Second)
+
+		dst.WaitFor(t, 1)
+		dst.AssertPayloads(t, 1)
+	})
+
+	t.Run("ExpirationTime fallback reads the injected clock", func(t *testing.T) {
+		t.Parallel()
+
+		clock := messagingtest.NewFakeClock(time.Time{})
+		src := messaging.NewPipelineChannel[int]()
+		dst := messagingtest.NewRecordingChannel[int]()
+
+		d := NewDelayer("test", src, dst, WithClock[int](clock))
+
+		err := d.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		t.Cleanup(func() { _ = d.Stop(context.Background()) })
+
+		msg := messaging.Message[int]{Payload: 7}
+		msg.Headers.ExpirationTime = clock.Now().Add(10 * time.Minute)
+
+		err = src.Send(context.Background(), msg)
+		if err != nil {
+			t.Fatalf("send: %v", err)
+		}
+
+		clock.WaitForTimers(t, 1)
+		clock.Advance(10 * time.Minute)
+
+		got := dst.WaitFor(t, 1)
+		if !got[0].Headers.ExpirationTime.IsZero() {
+			t.Fatalf("expected ExpirationTime cleared, got %v", got[0].Headers.ExpirationTime)
+		}
+	})
+}
+
 func TestDelayer_Options(t *testing.T) {
 	t.Parallel()
 
@@ -729,6 +967,26 @@ func TestDelayer_Options(t *testing.T) {
 		}
 	})
 
+	t.Run("WithClock(nil) preserves the system clock", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithClock[int](nil))
+		if opts.clock == nil {
+			t.Fatal("expected system clock preserved on nil arg")
+		}
+	})
+
+	t.Run("WithClock installs the clock", func(t *testing.T) {
+		t.Parallel()
+
+		clock := messagingtest.NewFakeClock(time.Time{})
+
+		opts := NewOptions(WithClock[int](clock))
+		if opts.clock != clock {
+			t.Fatalf("expected the fake clock, got %T", opts.clock)
+		}
+	})
+
 	t.Run("defaults install messaging.DefaultErrorHandler", func(t *testing.T) {
 		t.Parallel()
 
@@ -756,3 +1014,47 @@ func (c *failingChannel[T]) Send(_ context.Context, _ messaging.Message[T]) erro
 func (c *failingChannel[T]) Subscribe(_ messaging.Handler[T]) (messaging.Cancel, error) {
 	return func() {}, nil
 }
+
+func TestDelayer_Stats(t *testing.T) {
+	t.Parallel()
+
+	clock := messagingtest.NewFakeClock(time.Time{})
+	src := messaging.NewPipelineChannel[int]()
+	dst := messagingtest.NewRecordingChannel[int]()
+
+	d := NewDelayer("test", src, dst,
+		WithFixedDelay[int](time.Hour),
+		WithMaxPending[int](2),
+		WithClock[int](clock),
+	)
+
+	err := d.Start(context.Background())
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+
+	t.Cleanup(func() { _ = d.Stop(context.Background()) })
+
+	for i := range 3 {
+		err = src.Send(context.Background(), messaging.Message[int]{Payload: i})
+		if err != nil {
+			t.Fatalf("send %d: %v", i, err)
+		}
+	}
+
+	got := d.Stats()
+	if got.Subscribers != 1 || got.Delivered != 3 || got.Sent != 0 || got.Dropped != 1 || got.BufferLength != 2 || got.BufferCapacity != 2 {
+		t.Fatalf("Stats = %+v, want Subscribers=1 Delivered=3 Sent=0 Dropped=1 BufferLength=2 BufferCapacity=2", got)
+	}
+
+	clock.WaitForTimers(t, 1)
+	clock.Advance(time.Hour)
+	dst.WaitFor(t, 2)
+
+	messagingtest.WaitUntil(t, func() bool { return d.Stats().Sent == 2 }, "expected 2 messages sent")
+
+	got = d.Stats()
+	if got.BufferLength != 0 || got.Failed != 0 {
+		t.Fatalf("Stats = %+v, want BufferLength=0 Failed=0 after the delay", got)
+	}
+}
@@ -18,23 +18,28 @@ type Option[T any] func(opts *Options[T])
 
 // Options holds the configuration for a Delayer[T].
 type Options[T any] struct {
-	fixedDelay   time.Duration
-	delayFn      DelayFn[T]
-	maxPending   int
-	errorHandler messaging.ErrorHandler
-	dropHandler  DropHandler
+	fixedDelay     time.Duration
+	delayFn        DelayFn[T]
+	maxPending     int
+	errorHandler   messaging.ErrorHandler
+	dropHandler    DropHandler
+	expiredHandler messaging.ErrorHandler
+	clock          messaging.Clock
 }
 
 // NewOptions creates a new Options[T] with sensible defaults and
 // applies the given options. The default ErrorHandler is
 // messaging.DefaultErrorHandler (logs via common/log); the default
-// DropHandler is nil (drops are silent unless wired); maxPending is
+// DropHandler and expired handler are nil (drops are silent unless
+// wired); maxPending is
 // defaultMaxPending; no fixedDelay and no DelayFn are set so the
-// fallback path uses Headers.ExpirationTime.
+// fallback path uses Headers.ExpirationTime; the clock is
+// messaging.SystemClock.
 func NewOptions[T any](opts ...Option[T]) *Options[T] {
 	options := &Options[T]{
 		maxPending:   defaultMaxPending,
 		errorHandler: messaging.DefaultErrorHandler,
+		clock:        messaging.SystemClock(),
 	}
 
 	for _, opt := range opts {
@@ -111,3 +116,32 @@ func WithDropHandler[T any](handler DropHandler) Option[T] {
 		}
 	}
 }
+
+// WithExpiredHandler installs an observability hook fired once per
+// message discarded because its Headers.ExpirationTime passed before
+// the configured delay elapsed. Only WithFixedDelay and WithDelayFn
+// delays can expire a message; the Headers.ExpirationTime fallback
+// consumes the header as the deliver-at instant. The hook receives the
+// expired message and messaging.ErrExpired joined with
+// messaging.ErrDropped. The default (when WithExpiredHandler is not
+// passed) reports expired messages through WithDropHandler. Nil
+// arguments are ignored.
+func WithExpiredHandler[T any](handler messaging.ErrorHandler) Option[T] {
+	return func(opts *Options[T]) {
+		if handler != nil {
+			opts.expiredHandler = handler
+		}
+	}
+}
+
+// WithClock sets the time source the delayer schedules against. It is
+// handed to the internal ScheduledChannel too, so a fake clock (see
+// messagingtest.FakeClock) drives both the delay computation and the
+// release. Nil values are ignored (the system clock is preserved).
+func WithClock[T any](clock messaging.Clock) Option[T] {
+	return func(opts *Options[T]) {
+		if clock != nil {
+			opts.clock = clock
+		}
+	}
+}
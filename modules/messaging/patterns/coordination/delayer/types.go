@@ -17,7 +17,14 @@
 //     is configured, the delayer uses Headers.ExpirationTime as the
 //     deliver-at deadline. An ExpirationTime in the past forwards
 //     immediately; an unset (zero) ExpirationTime also forwards
-//     immediately.
+//     immediately. The header is consumed: the forwarded message
+//     carries a zero ExpirationTime so downstream channels do not
+//     discard it as expired.
+//
+// Under the first two strategies Headers.ExpirationTime keeps its
+// usual meaning: a message whose expiration passes before its delay
+// elapses is not forwarded and is reported through WithExpiredHandler
+// (WithDropHandler when none is installed).
 //
 // A computed delay of zero or less always forwards immediately on the
 // same dispatcher goroutine as the source subscription — the deferred
@@ -80,13 +87,15 @@ var (
 )
 
 // Delayer is the public interface for a Delayer pattern. It embeds
-// lifecycle.Component so callers wire it up with lifecycle.Build. The
-// interface exists (rather than returning lifecycle.Component directly)
-// so the consumer's API surface preserves "this is a Delayer"
-// semantics and the type stays open to future delayer-specific methods
-// without breaking callers.
+// lifecycle.Component so callers wire it up with lifecycle.Build, and
+// messaging.Inspectable to expose its runtime statistics. The
+// interface exists (rather than returning lifecycle.Component
+// directly) so the consumer's API surface preserves "this is a
+// Delayer" semantics and the type stays open to future
+// delayer-specific methods without breaking callers.
 type Delayer[T any] interface {
 	lifecycle.Component
+	messaging.Inspectable
 }
 
 // DelayFn computes the delay applied to msg before forwarding it to the
@@ -97,9 +106,12 @@ type Delayer[T any] interface {
 type DelayFn[T any] func(ctx context.Context, msg messaging.Message[T]) time.Duration
 
 // DropHandler is the optional observability hook invoked once per
-// message dropped because the pending queue exceeded WithMaxPending.
-// msg is type-erased; cast it inside the hook when payload-specific
-// behavior is needed. err is always ErrDelayer(ErrMaxPendingExceeded).
+// message dropped because the pending queue exceeded WithMaxPending,
+// and once per expired message when no WithExpiredHandler is
+// installed. msg is type-erased; cast it inside the hook when
+// payload-specific behavior is needed. err is
+// ErrDelayer(ErrMaxPendingExceeded) for backpressure drops and
+// messaging.ErrExpired joined with messaging.ErrDropped for expiries.
 // The hook is invoked synchronously from the source channel's
 // dispatcher and must not block — long observability work should be
 // dispatched asynchronously by the implementer.
@@ -0,0 +1,135 @@
+package saga
+
+import (
+	"context"
+	"errors"
+	"maps"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+// stepDefinition is the Definition returned by NewStepDefinition. It
+// dispatches each event to the StepFunc registered for the instance's
+// current step.
+type stepDefinition[E any, C any, S any] struct {
+	steps      map[string]StepFunc[E, C, S]
+	compensate CompensateFunc[C, S]
+}
+
+// NewStepDefinition constructs a Definition that runs steps[state.Step]
+// for every event; new instances start at StartStep. A step moves the
+// instance forward by returning the next step name in Outcome.Step.
+// Events arriving at a step with no StepFunc are unhandled and
+// dropped. compensate may be nil when the saga has nothing to undo.
+// The steps map is cloned so post-construction mutation is ignored.
+func NewStepDefinition[E any, C any, S any](steps map[string]StepFunc[E, C, S], compensate CompensateFunc[C, S]) Definition[E, C, S] {
+	cassert.NotEmpty(steps, "steps map is empty")
+
+	return &stepDefinition[E, C, S]{
+		steps:      maps.Clone(steps),
+		compensate: compensate,
+	}
+}
+
+// Handle runs the StepFunc registered for state.Step, or returns
+// ErrUnhandledEvent when there is none.
+func (d *stepDefinition[E, C, S]) Handle(ctx context.Context, state *State[S], msg messaging.Message[E]) (Outcome[C], error) {
+	cassert.NotNil(d, "step definition is nil")
+
+	step, ok := d.steps[state.Step]
+	if !ok {
+		return Outcome[C]{}, ErrUnhandledEvent
+	}
+
+	return step(ctx, state, msg)
+}
+
+// Compensate delegates to the configured CompensateFunc.
+func (d *stepDefinition[E, C, S]) Compensate(ctx context.Context, state State[S], cause error) []Command[C] {
+	cassert.NotNil(d, "step definition is nil")
+
+	if d.compensate == nil {
+		return nil
+	}
+
+	return d.compensate(ctx, state, cause)
+}
+
+// machineDefinition is the Definition returned by
+// NewMachineDefinition. Each event fires a transition on a Machine
+// restored to the instance's step, and runs the action registered for
+// the state the machine lands on.
+type machineDefinition[E any, C any, S any] struct {
+	newMachine MachineFn
+	event      EventFn[E]
+	actions    map[string]StepFunc[E, C, S]
+	compensate CompensateFunc[C, S]
+}
+
+// NewMachineDefinition constructs a Definition driven by a finite
+// state machine. For every event it builds a Machine with newMachine,
+// restores it to state.Step (new instances keep the machine's initial
+// state), fires event(msg) with msg as the guard context, and runs
+// actions[target] for the state reached, if any. The instance's step
+// becomes the reached state regardless of the action's Outcome.Step.
+// A rejected transition is an unhandled event; a step the machine
+// does not know fails the instance. The action sees the step the
+// instance was in before the transition. compensate may be nil.
+func NewMachineDefinition[E any, C any, S any](newMachine MachineFn, event EventFn[E], actions map[string]StepFunc[E, C, S], compensate CompensateFunc[C, S]) Definition[E, C, S] {
+	cassert.NotNil(newMachine, "machine function is nil")
+	cassert.NotNil(event, "event function is nil")
+
+	return &machineDefinition[E, C, S]{
+		newMachine: newMachine,
+		event:      event,
+		actions:    maps.Clone(actions),
+		compensate: compensate,
+	}
+}
+
+// Handle fires the event's transition and runs the target state's
+// action.
+func (d *machineDefinition[E, C, S]) Handle(ctx context.Context, state *State[S], msg messaging.Message[E]) (Outcome[C], error) {
+	cassert.NotNil(d, "machine definition is nil")
+
+	machine := d.newMachine()
+	cassert.NotNil(machine, "machine function returned nil")
+
+	if state.Step != StartStep {
+		err := machine.Restore(state.Step)
+		if err != nil {
+			return Outcome[C]{}, err
+		}
+	}
+
+	err := machine.Send(d.event(msg), msg)
+	if err != nil {
+		return Outcome[C]{}, errors.Join(ErrUnhandledEvent, err)
+	}
+
+	outcome := Outcome[C]{}
+
+	action, ok := d.actions[machine.Current()]
+	if ok {
+		outcome, err = action(ctx, state, msg)
+		if err != nil {
+			return Outcome[C]{}, err
+		}
+	}
+
+	outcome.Step = machine.Current()
+
+	return outcome, nil
+}
+
+// Compensate delegates to the configured CompensateFunc.
+func (d *machineDefinition[E, C, S]) Compensate(ctx context.Context, state State[S], cause error) []Command[C] {
+	cassert.NotNil(d, "machine definition is nil")
+
+	if d.compensate == nil {
+		return nil
+	}
+
+	return d.compensate(ctx, state, cause)
+}
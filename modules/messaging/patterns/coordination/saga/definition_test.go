@@ -0,0 +1,243 @@
+package saga
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+// errNoTransition is returned by fakeMachine.Send for an event with
+// no transition from the current state.
+var errNoTransition = errors.New("no transition")
+
+// fakeMachine is a Machine test double with the same contract as
+// *fsm.Machine: transitions are keyed by "from/event".
+type fakeMachine struct {
+	current     string
+	states      map[string]bool
+	transitions map[string]string
+}
+
+func (m *fakeMachine) Current() string {
+	return m.current
+}
+
+func (m *fakeMachine) Send(event string, _ any) error {
+	to, ok := m.transitions[m.current+"/"+event]
+	if !ok {
+		return errNoTransition
+	}
+
+	m.current = to
+
+	return nil
+}
+
+func (m *fakeMachine) Restore(state string) error {
+	if !m.states[state] {
+		return errors.New("state not found")
+	}
+
+	m.current = state
+
+	return nil
+}
+
+// newOrderMachine builds the order machine: new → reserving →
+// charging → done.
+func newOrderMachine() Machine {
+	return &fakeMachine{
+		current: "new",
+		states:  map[string]bool{"new": true, "reserving": true, "charging": true, "done": true},
+		transitions: map[string]string{
+			"new/placed":         "reserving",
+			"reserving/reserved": "charging",
+			"charging/charged":   "done",
+		},
+	}
+}
+
+// payloadEvent uses the message payload as the machine event.
+func payloadEvent(msg messaging.Message[string]) string {
+	return msg.Payload
+}
+
+func TestNewMachineDefinition(t *testing.T) {
+	t.Parallel()
+
+	actions := map[string]StepFunc[string, string, order]{
+		"reserving": func(_ context.Context, _ *State[order], _ messaging.Message[string]) (Outcome[string], error) {
+			return Outcome[string]{Step: "ignored", Commands: []Command[string]{command("inventory", "reserve")}}, nil
+		},
+		"done": func(_ context.Context, _ *State[order], _ messaging.Message[string]) (Outcome[string], error) {
+			return Outcome[string]{Complete: true}, nil
+		},
+	}
+
+	definition := NewMachineDefinition(newOrderMachine, payloadEvent, actions, nil)
+
+	t.Run("new instance starts at the machine's initial state", func(t *testing.T) {
+		t.Parallel()
+
+		state := State[order]{CorrelationID: "o-1"}
+
+		outcome, err := definition.Handle(context.Background(), &state, event("o-1", "placed"))
+		if err != nil {
+			t.Fatalf("handle: %v", err)
+		}
+
+		if outcome.Step != "reserving" {
+			t.Fatalf("expected step reserving, got %q", outcome.Step)
+		}
+
+		if len(outcome.Commands) != 1 || outcome.Commands[0].Message.Payload != "reserve" {
+			t.Fatalf("expected the reserving action's command, got %+v", outcome.Commands)
+		}
+	})
+
+	t.Run("restores the instance step before firing", func(t *testing.T) {
+		t.Parallel()
+
+		state := State[order]{CorrelationID: "o-1", Step: "charging"}
+
+		outcome, err := definition.Handle(context.Background(), &state, event("o-1", "charged"))
+		if err != nil {
+			t.Fatalf("handle: %v", err)
+		}
+
+		if outcome.Step != "done" || !outcome.Complete {
+			t.Fatalf("expected completed at done, got %+v", outcome)
+		}
+	})
+
+	t.Run("states without an action only move the step", func(t *testing.T) {
+		t.Parallel()
+
+		state := State[order]{CorrelationID: "o-1", Step: "reserving"}
+
+		outcome, err := definition.Handle(context.Background(), &state, event("o-1", "reserved"))
+		if err != nil {
+			t.Fatalf("handle: %v", err)
+		}
+
+		if outcome.Step != "charging" || len(outcome.Commands) != 0 {
+			t.Fatalf("expected a bare move to charging, got %+v", outcome)
+		}
+	})
+
+	t.Run("rejected transition is an unhandled event", func(t *testing.T) {
+		t.Parallel()
+
+		state := State[order]{CorrelationID: "o-1", Step: "reserving"}
+
+		_, err := definition.Handle(context.Background(), &state, event("o-1", "charged"))
+		if !errors.Is(err, ErrUnhandledEvent) || !errors.Is(err, errNoTransition) {
+			t.Fatalf("expected ErrUnhandledEvent joined with the machine error, got %v", err)
+		}
+	})
+
+	t.Run("unknown step fails the instance", func(t *testing.T) {
+		t.Parallel()
+
+		state := State[order]{CorrelationID: "o-1", Step: "lost"}
+
+		_, err := definition.Handle(context.Background(), &state, event("o-1", "charged"))
+		if err == nil || errors.Is(err, ErrUnhandledEvent) {
+			t.Fatalf("expected a step failure, got %v", err)
+		}
+	})
+
+	t.Run("nil compensate yields no commands", func(t *testing.T) {
+		t.Parallel()
+
+		cmds := definition.Compensate(context.Background(), State[order]{}, ErrTimeout)
+		if cmds != nil {
+			t.Fatalf("expected no compensation, got %+v", cmds)
+		}
+	})
+}
+
+func TestSaga_MachineDefinition(t *testing.T) {
+	t.Parallel()
+
+	actions := map[string]StepFunc[string, string, order]{
+		"reserving": func(_ context.Context, _ *State[order], _ messaging.Message[string]) (Outcome[string], error) {
+			return Outcome[string]{Commands: []Command[string]{command("inventory", "reserve")}}, nil
+		},
+		"charging": func(_ context.Context, state *State[order], _ messaging.Message[string]) (Outcome[string], error) {
+			state.Data.Reserved = true
+
+			return Outcome[string]{Commands: []Command[string]{command("payments", "charge")}}, nil
+		},
+		"done": func(_ context.Context, _ *State[order], _ messaging.Message[string]) (Outcome[string], error) {
+			return Outcome[string]{Complete: true, Commands: []Command[string]{command("shipping", "ship")}}, nil
+		},
+	}
+
+	drop, drops := captureDrops()
+	f := newFixture(t, NewMachineDefinition(newOrderMachine, payloadEvent, actions, nil), WithDropHandler(drop))
+
+	f.send(t, event("o-1", "placed"), event("o-1", "charged"), event("o-1", "reserved"))
+
+	state, err := f.saga.Instance(context.Background(), "o-1")
+	if err != nil {
+		t.Fatalf("instance: %v", err)
+	}
+
+	if state.Step != "charging" || !state.Data.Reserved {
+		t.Fatalf("unexpected state %+v", state)
+	}
+
+	if drops() != 1 {
+		t.Fatalf("expected the out-of-order event to be dropped, got %d drops", drops())
+	}
+
+	f.send(t, event("o-1", "charged"))
+
+	got := payloads(f.commands())
+	if !equal(got, []string{"reserve", "charge", "ship"}) {
+		t.Fatalf("unexpected commands %v", got)
+	}
+}
+
+func TestNewStepDefinition(t *testing.T) {
+	t.Parallel()
+
+	t.Run("step without a StepFunc is an unhandled event", func(t *testing.T) {
+		t.Parallel()
+
+		state := State[order]{CorrelationID: "o-1", Step: "shipped"}
+
+		_, err := orderDefinition().Handle(context.Background(), &state, event("o-1", "charged"))
+		if !errors.Is(err, ErrUnhandledEvent) {
+			t.Fatalf("expected ErrUnhandledEvent, got %v", err)
+		}
+	})
+
+	t.Run("clones the steps map", func(t *testing.T) {
+		t.Parallel()
+
+		steps := map[string]StepFunc[string, string, order]{
+			StartStep: func(_ context.Context, _ *State[order], _ messaging.Message[string]) (Outcome[string], error) {
+				return Outcome[string]{Step: "started"}, nil
+			},
+		}
+
+		definition := NewStepDefinition[string, string, order](steps, nil)
+
+		delete(steps, StartStep)
+
+		state := State[order]{CorrelationID: "o-1"}
+
+		outcome, err := definition.Handle(context.Background(), &state, event("o-1", "placed"))
+		if err != nil {
+			t.Fatalf("handle: %v", err)
+		}
+
+		if outcome.Step != "started" {
+			t.Fatalf("expected step started, got %q", outcome.Step)
+		}
+	})
+}
@@ -0,0 +1,74 @@
+package saga
+
+import (
+	"errors"
+	"fmt"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+	cerrs "github.com/guidomantilla/yarumo/core/common/errs"
+)
+
+// SagaType is the error domain identifier for saga operations.
+const SagaType = "saga"
+
+var (
+	_ error = (*Error)(nil)
+)
+
+// Sentinel errors for saga operations.
+var (
+	// ErrSagaFailed is the top-level sentinel embedded in every
+	// saga-domain Error returned by ErrSaga.
+	ErrSagaFailed = errors.New("saga failed")
+	// ErrUnhandledEvent indicates that the instance does not expect
+	// the event in its current step. Definitions return it; the Saga
+	// drops the event.
+	ErrUnhandledEvent = errors.New("event not handled in current step")
+	// ErrStoreFailed indicates that the state store returned an error
+	// loading, saving or deleting an instance.
+	ErrStoreFailed = errors.New("saga state store failed")
+	// ErrStepFailed indicates that the Definition returned an error
+	// for an event; the instance was compensated.
+	ErrStepFailed = errors.New("saga step failed")
+	// ErrTimeout indicates that the instance missed its deadline; the
+	// instance was compensated.
+	ErrTimeout = errors.New("saga timed out")
+	// ErrCommandFailed indicates that a command could not be sent to
+	// its output channel; the instance was compensated.
+	ErrCommandFailed = errors.New("saga command send failed")
+	// ErrUnknownChannel indicates that a command named an output
+	// channel missing from the outputs map.
+	ErrUnknownChannel = errors.New("unknown output channel")
+	// ErrCompensationFailed indicates that a compensating command
+	// could not be sent to its output channel.
+	ErrCompensationFailed = errors.New("saga compensation send failed")
+	// ErrCompensationPanic indicates that the Definition's Compensate
+	// panicked; the instance was forgotten without compensating
+	// commands.
+	ErrCompensationPanic = errors.New("saga compensation panicked")
+)
+
+// Error is the domain error type for saga operations.
+type Error struct {
+	cerrs.TypedError
+}
+
+// Error returns the formatted error string including the type
+// classification.
+func (e *Error) Error() string {
+	cassert.NotNil(e, "error is nil")
+	cassert.NotNil(e.Err, "internal error is nil")
+
+	return fmt.Sprintf("saga %s error: %s", e.Type, e.Err)
+}
+
+// ErrSaga wraps the given causes into a domain Error joined with
+// ErrSagaFailed.
+func ErrSaga(causes ...error) error {
+	return &Error{
+		TypedError: cerrs.TypedError{
+			Type: SagaType,
+			Err:  errors.Join(append(causes, ErrSagaFailed)...),
+		},
+	}
+}
@@ -0,0 +1,49 @@
+package saga
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestError_Error(t *testing.T) {
+	t.Parallel()
+
+	t.Run("includes type prefix and joined causes", func(t *testing.T) {
+		t.Parallel()
+
+		err := ErrSaga(ErrStepFailed)
+
+		msg := err.Error()
+		if !strings.HasPrefix(msg, "saga "+SagaType) {
+			t.Fatalf("expected prefix %q, got %q", "saga "+SagaType, msg)
+		}
+
+		if !strings.Contains(msg, ErrStepFailed.Error()) {
+			t.Fatalf("expected cause %q in message, got %q", ErrStepFailed.Error(), msg)
+		}
+
+		if !strings.Contains(msg, ErrSagaFailed.Error()) {
+			t.Fatalf("expected sentinel %q in message, got %q", ErrSagaFailed.Error(), msg)
+		}
+	})
+
+	t.Run("ErrSaga joins all causes with ErrSagaFailed", func(t *testing.T) {
+		t.Parallel()
+
+		boom := errors.New("custom failure")
+
+		err := ErrSaga(ErrStepFailed, boom)
+		if !errors.Is(err, ErrSagaFailed) {
+			t.Fatal("expected ErrSagaFailed in chain")
+		}
+
+		if !errors.Is(err, ErrStepFailed) {
+			t.Fatal("expected ErrStepFailed in chain")
+		}
+
+		if !errors.Is(err, boom) {
+			t.Fatal("expected origin error in chain")
+		}
+	})
+}
@@ -0,0 +1,106 @@
+package saga
+
+import (
+	"time"
+
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+// Option is a functional option for configuring saga Options. Saga
+// has no payload-typed options (the store is a constructor argument),
+// so Option is non-generic, matching barrier and idempotent.
+type Option func(opts *Options)
+
+// Options holds the configuration for a Saga.
+type Options struct {
+	timeout       time.Duration
+	sweepInterval time.Duration
+	errorHandler  messaging.ErrorHandler
+	dropHandler   DropHandler
+	clock         messaging.Clock
+}
+
+// NewOptions creates a new Options with sensible defaults and applies
+// the given options. The default timeout is zero (instances have no
+// deadline unless a step arms one); the default sweepInterval is
+// DefaultSweepInterval; the default ErrorHandler is
+// messaging.DefaultErrorHandler (logs via common/log); the default
+// DropHandler is nil (silent drops); the default clock is
+// messaging.SystemClock.
+func NewOptions(opts ...Option) *Options {
+	options := &Options{
+		timeout:       0,
+		sweepInterval: DefaultSweepInterval,
+		errorHandler:  messaging.DefaultErrorHandler,
+		dropHandler:   nil,
+		clock:         messaging.SystemClock(),
+	}
+
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	return options
+}
+
+// WithTimeout arms a deadline of d for every new instance. An
+// instance that has not completed by its deadline is compensated
+// with ErrTimeout. Steps can re-arm it through Outcome.Timeout.
+// Non-positive values are ignored.
+func WithTimeout(d time.Duration) Option {
+	return func(opts *Options) {
+		if d > 0 {
+			opts.timeout = d
+		}
+	}
+}
+
+// WithSweepInterval sets the cadence at which the sweeper looks for
+// instances past their deadline. Non-positive values are ignored.
+func WithSweepInterval(d time.Duration) Option {
+	return func(opts *Options) {
+		if d > 0 {
+			opts.sweepInterval = d
+		}
+	}
+}
+
+// WithErrorHandler installs an observability hook fired once per
+// store failure, step failure, timeout and command or compensation
+// Send failure. The default (when WithErrorHandler is not passed) is
+// messaging.DefaultErrorHandler, which logs each failure via
+// common/log. Pass messaging.SilentErrorHandler to opt out, or any
+// custom hook to redirect. Nil values are ignored (the previously
+// installed handler is preserved).
+func WithErrorHandler(handler messaging.ErrorHandler) Option {
+	return func(opts *Options) {
+		if handler != nil {
+			opts.errorHandler = handler
+		}
+	}
+}
+
+// WithDropHandler installs an observability hook fired once per
+// intentional drop (missing correlation, unhandled event). The
+// default (when WithDropHandler is not passed) is nil — intentional
+// drops are silent. Nil arguments are ignored (the previously
+// installed handler is preserved).
+func WithDropHandler(handler DropHandler) Option {
+	return func(opts *Options) {
+		if handler != nil {
+			opts.dropHandler = handler
+		}
+	}
+}
+
+// WithClock sets the time source for instance deadlines and the
+// sweeper ticker. Tests pass a messagingtest.FakeClock to time out
+// instances without sleeping. Nil values are ignored (the system
+// clock is preserved).
+func WithClock(clock messaging.Clock) Option {
+	return func(opts *Options) {
+		if clock != nil {
+			opts.clock = clock
+		}
+	}
+}
@@ -0,0 +1,616 @@
+package saga
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+	"github.com/guidomantilla/yarumo/core/common/lifecycle"
+	"github.com/guidomantilla/yarumo/messaging"
+	"github.com/guidomantilla/yarumo/messaging/stores"
+)
+
+// instanceLock serialises the events of one correlation. refs counts
+// the goroutines holding or waiting for mu so the lock can be removed
+// from the map once nobody needs it.
+type instanceLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// saga is the Saga implementation. It owns one subscription per input
+// channel (registered in Start, cancelled in Stop), loads and persists
+// instances through the store, and tracks the deadlines of the
+// instances it has seen for the timeout sweeper.
+type saga[E any, C any, S any] struct {
+	name          string
+	inputs        []messaging.Channel[E]
+	outputs       map[string]messaging.Channel[C]
+	definition    Definition[E, C, S]
+	store         stores.MessageStore[State[S]]
+	timeout       time.Duration
+	sweepInterval time.Duration
+	errorHandler  messaging.ErrorHandler
+	dropHandler   DropHandler
+	clock         messaging.Clock
+	stats         messaging.StatsRecorder
+
+	locksMu sync.Mutex
+	locks   map[string]*instanceLock
+
+	activeMu sync.Mutex
+	active   map[string]time.Time
+
+	sweeperWG sync.WaitGroup
+
+	done        chan struct{}
+	sweeperDone chan struct{}
+	sweeperOnce sync.Once
+	startOnce   sync.Once
+	stopOnce    sync.Once
+	doneOnce    sync.Once
+
+	subMu   sync.Mutex
+	cancels []messaging.Cancel
+}
+
+// NewSaga constructs a Saga that subscribes to every input channel,
+// runs definition for each event correlated by Headers.CorrelationID,
+// keeps the instances in store and emits commands to outputs by name.
+// The Saga is not running on return; call lifecycle.Build (or Start
+// directly) to register the subscriptions and spawn the sweeper.
+//
+// name is used in lifecycle logs and must be non-empty. At least one
+// input is required and none may be nil; outputs, definition and
+// store are mandatory. The inputs slice and outputs map are cloned so
+// post-construction mutation is ignored.
+//
+// Optional behaviors:
+//
+//   - WithTimeout arms a deadline for every new instance.
+//   - WithSweepInterval tunes the sweeper cadence (default
+//     DefaultSweepInterval).
+//   - WithErrorHandler overrides the default
+//     messaging.DefaultErrorHandler (which logs via common/log) with a
+//     custom hook for store, step, timeout and Send failures.
+//   - WithDropHandler installs an optional hook for observing
+//     intentional drops; nil by default (silent drop).
+func NewSaga[E any, C any, S any](name string, inputs []messaging.Channel[E], outputs map[string]messaging.Channel[C], definition Definition[E, C, S], store stores.MessageStore[State[S]], opts ...Option) Saga[S] {
+	cassert.NotEmpty(name, "name is empty")
+	cassert.NotEmpty(inputs, "input channels are empty")
+	cassert.NotNil(outputs, "output channels map is nil")
+	cassert.NotNil(definition, "definition is nil")
+	cassert.NotNil(store, "state store is nil")
+
+	for _, input := range inputs {
+		cassert.NotNil(input, "input channel is nil")
+	}
+
+	options := NewOptions(opts...)
+
+	s := &saga[E, C, S]{
+		name:          name,
+		inputs:        slices.Clone(inputs),
+		outputs:       maps.Clone(outputs),
+		definition:    definition,
+		store:         store,
+		timeout:       options.timeout,
+		sweepInterval: options.sweepInterval,
+		errorHandler:  options.errorHandler,
+		dropHandler:   options.dropHandler,
+		clock:         options.clock,
+		locks:         map[string]*instanceLock{},
+		active:        map[string]time.Time{},
+		done:          make(chan struct{}),
+		sweeperDone:   make(chan struct{}),
+	}
+
+	s.stats.UseClock(options.clock)
+
+	return s
+}
+
+// Name returns the saga's identity used in lifecycle logs.
+func (s *saga[E, C, S]) Name() string {
+	cassert.NotNil(s, "saga is nil")
+
+	return s.name
+}
+
+// Start restores the deadlines of the stored instances (see restore),
+// registers the event handler as a subscriber on every input channel
+// and spawns the timeout sweeper goroutine. It satisfies the
+// lifecycle.Component worker-style contract: Start returns
+// immediately after the subscriptions are in place. When a Subscribe
+// fails, the subscriptions already made are cancelled and Start
+// returns lifecycle.ErrStart. Start is idempotent — a second
+// invocation returns nil without re-subscribing.
+func (s *saga[E, C, S]) Start(ctx context.Context) error {
+	cassert.NotNil(s, "saga is nil")
+
+	var startErr error
+
+	s.startOnce.Do(func() {
+		s.restore(ctx)
+
+		cancels := make([]messaging.Cancel, 0, len(s.inputs))
+
+		for _, input := range s.inputs {
+			cancel, err := input.Subscribe(s.handle)
+			if err != nil {
+				for _, c := range cancels {
+					c()
+				}
+
+				startErr = lifecycle.ErrStart(err)
+
+				return
+			}
+
+			cancels = append(cancels, cancel)
+		}
+
+		s.subMu.Lock()
+		s.cancels = cancels
+		s.subMu.Unlock()
+
+		s.sweeperWG.Go(s.sweep)
+	})
+
+	return startErr
+}
+
+// Stop cancels every input subscription, stops the sweeper and closes
+// Done. Running instances stay in the store. Stop is idempotent per
+// the lifecycle.Component contract. It returns lifecycle.ErrShutdown
+// wrapping lifecycle.ErrShutdownTimeout when ctx expires before the
+// sweeper goroutine has exited.
+func (s *saga[E, C, S]) Stop(ctx context.Context) error {
+	cassert.NotNil(s, "saga is nil")
+
+	s.stopOnce.Do(func() {
+		s.subMu.Lock()
+		cancels := s.cancels
+		s.cancels = nil
+		s.subMu.Unlock()
+
+		for _, cancel := range cancels {
+			cancel()
+		}
+
+		s.sweeperOnce.Do(func() { close(s.sweeperDone) })
+
+		s.sweeperWG.Wait()
+
+		s.doneOnce.Do(func() { close(s.done) })
+	})
+
+	select {
+	case <-ctx.Done():
+		return lifecycle.ErrShutdown(lifecycle.ErrShutdownTimeout, ctx.Err())
+	default:
+		return nil
+	}
+}
+
+// Done returns the channel that is closed after Stop has completed.
+func (s *saga[E, C, S]) Done() <-chan struct{} {
+	cassert.NotNil(s, "saga is nil")
+
+	return s.done
+}
+
+// Instance returns the persisted state of the instance for
+// correlationID. Store errors are returned as-is, so a missing
+// instance matches stores.ErrStoreNotFound.
+func (s *saga[E, C, S]) Instance(ctx context.Context, correlationID string) (State[S], error) {
+	cassert.NotNil(s, "saga is nil")
+
+	env, err := s.store.Get(ctx, correlationID)
+	if err != nil {
+		return State[S]{}, err
+	}
+
+	return env.Payload, nil
+}
+
+// Stats returns a snapshot of the saga's runtime statistics.
+// BufferLength is the number of running instances this Saga tracks.
+// Subscribers is the number of active input subscriptions.
+func (s *saga[E, C, S]) Stats() messaging.Stats {
+	cassert.NotNil(s, "saga is nil")
+
+	stats := s.stats.Snapshot()
+
+	s.activeMu.Lock()
+	stats.BufferLength = len(s.active)
+	s.activeMu.Unlock()
+
+	s.subMu.Lock()
+	stats.Subscribers = len(s.cancels)
+	s.subMu.Unlock()
+
+	return stats
+}
+
+// handle is the Handler[E] subscribed on every input channel. It
+// loads the event's instance, runs the definition, persists the
+// outcome and emits its commands, compensating the instance when the
+// step or a command fails. Failures and drops flow through the
+// configured hooks; the function itself always returns nil so saga
+// concerns never propagate to the input channel's Send caller.
+func (s *saga[E, C, S]) handle(ctx context.Context, msg messaging.Message[E]) error {
+	end := s.stats.Begin()
+	defer end(nil)
+
+	correlation := msg.Headers.CorrelationID
+	if correlation == "" {
+		s.reportDrop(ctx, msg)
+
+		return nil
+	}
+
+	unlock := s.lock(correlation)
+	defer unlock()
+
+	now := s.clock.Now()
+
+	state, err := s.load(ctx, correlation, now)
+	if err != nil {
+		s.reportError(ctx, msg, ErrSaga(ErrStoreFailed, err))
+
+		return nil
+	}
+
+	if !state.Deadline.IsZero() && !now.Before(state.Deadline) {
+		s.reportDrop(ctx, msg)
+		s.compensate(ctx, state, "", ErrSaga(ErrTimeout))
+
+		return nil
+	}
+
+	previous := state
+
+	outcome, err := s.definition.Handle(ctx, &state, msg)
+	if errors.Is(err, ErrUnhandledEvent) {
+		s.reportDrop(ctx, msg)
+
+		return nil
+	}
+
+	if err != nil {
+		s.compensate(ctx, previous, msg.Headers.MessageID, ErrSaga(ErrStepFailed, err))
+
+		return nil
+	}
+
+	if outcome.Step != "" {
+		state.Step = outcome.Step
+	}
+
+	if outcome.Timeout > 0 {
+		state.Deadline = now.Add(outcome.Timeout)
+	}
+
+	state.UpdatedAt = now
+
+	err = s.persist(ctx, state, outcome.Complete)
+	if err != nil {
+		s.reportError(ctx, msg, ErrSaga(ErrStoreFailed, err))
+
+		return nil
+	}
+
+	for _, cmd := range outcome.Commands {
+		err = s.emit(ctx, state, msg.Headers.MessageID, cmd)
+		if err != nil {
+			s.compensate(ctx, state, msg.Headers.MessageID, ErrSaga(ErrCommandFailed, err))
+
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// load returns the stored instance for correlation, or a new one
+// starting at now when the store has none.
+func (s *saga[E, C, S]) load(ctx context.Context, correlation string, now time.Time) (State[S], error) {
+	env, err := s.store.Get(ctx, correlation)
+	if errors.Is(err, stores.ErrStoreNotFound) {
+		state := State[S]{
+			CorrelationID: correlation,
+			Step:          StartStep,
+			StartedAt:     now,
+		}
+
+		if s.timeout > 0 {
+			state.Deadline = now.Add(s.timeout)
+		}
+
+		return state, nil
+	}
+
+	if err != nil {
+		return State[S]{}, err
+	}
+
+	return env.Payload, nil
+}
+
+// persist saves state, or deletes it when the instance is complete,
+// and updates the deadline tracking accordingly.
+func (s *saga[E, C, S]) persist(ctx context.Context, state State[S], complete bool) error {
+	if complete {
+		s.forget(state.CorrelationID)
+
+		return s.store.Delete(ctx, state.CorrelationID)
+	}
+
+	env := messaging.Message[State[S]]{
+		Headers: messaging.Headers{
+			CorrelationID: state.CorrelationID,
+			Timestamp:     state.UpdatedAt,
+		},
+		Payload: state,
+	}
+
+	err := s.store.Put(ctx, state.CorrelationID, env)
+	if err != nil {
+		return err
+	}
+
+	s.track(state.CorrelationID, state.Deadline)
+
+	return nil
+}
+
+// emit sends cmd to its output channel, stamping the instance's
+// CorrelationID and causationID into empty headers.
+func (s *saga[E, C, S]) emit(ctx context.Context, state State[S], causationID string, cmd Command[C]) error {
+	output, ok := s.outputs[cmd.Channel]
+	if !ok {
+		return errors.Join(ErrUnknownChannel, fmt.Errorf("channel %q", cmd.Channel))
+	}
+
+	out := cmd.Message
+	if out.Headers.CorrelationID == "" {
+		out.Headers.CorrelationID = state.CorrelationID
+	}
+
+	if out.Headers.CausationID == "" {
+		out.Headers.CausationID = causationID
+	}
+
+	err := output.Send(ctx, out)
+	if err != nil {
+		return err
+	}
+
+	s.stats.RecordSent()
+
+	return nil
+}
+
+// compensate reports cause, emits the definition's compensating
+// commands for state and forgets the instance. A compensating command
+// that fails to send is reported and skipped; the rest still go out.
+func (s *saga[E, C, S]) compensate(ctx context.Context, state State[S], causationID string, cause error) {
+	s.reportError(ctx, state, cause)
+
+	for _, cmd := range s.compensateWithRecover(ctx, state, cause) {
+		err := s.emit(ctx, state, causationID, cmd)
+		if err != nil {
+			s.reportError(ctx, cmd.Message, ErrSaga(ErrCompensationFailed, err))
+		}
+	}
+
+	s.forget(state.CorrelationID)
+
+	err := s.store.Delete(ctx, state.CorrelationID)
+	if err != nil {
+		s.reportError(ctx, state, ErrSaga(ErrStoreFailed, err))
+	}
+}
+
+// compensateWithRecover invokes the Definition's Compensate under
+// panic recovery — it also runs on the sweeper goroutine, where no
+// channel dispatcher would catch a panic. A panic is reported as
+// ErrSaga(ErrCompensationPanic, "panic: <value>") and yields no
+// commands.
+func (s *saga[E, C, S]) compensateWithRecover(ctx context.Context, state State[S], cause error) (cmds []Command[C]) {
+	defer func() {
+		rec := recover()
+		if rec == nil {
+			return
+		}
+
+		cmds = nil
+
+		s.reportError(ctx, state, ErrSaga(ErrCompensationPanic, fmt.Errorf("panic: %v", rec)))
+	}()
+
+	return s.definition.Compensate(ctx, state, cause)
+}
+
+// lock acquires the per-correlation lock and returns its release
+// function.
+func (s *saga[E, C, S]) lock(correlation string) func() {
+	s.locksMu.Lock()
+
+	l, ok := s.locks[correlation]
+	if !ok {
+		l = &instanceLock{}
+		s.locks[correlation] = l
+	}
+
+	l.refs++
+	s.locksMu.Unlock()
+
+	l.mu.Lock()
+
+	return func() {
+		l.mu.Unlock()
+
+		s.locksMu.Lock()
+		defer s.locksMu.Unlock()
+
+		l.refs--
+		if l.refs == 0 {
+			delete(s.locks, correlation)
+		}
+	}
+}
+
+// restore tracks the deadline of every instance already in the store
+// when the store implements stores.KeyLister, so instances left
+// running by a previous process still time out. Store failures are
+// reported with ErrStoreFailed and skip the affected instances; other
+// stores are left alone and their instances are tracked again on
+// their next event.
+func (s *saga[E, C, S]) restore(ctx context.Context) {
+	lister, ok := s.store.(stores.KeyLister)
+	if !ok {
+		return
+	}
+
+	keys, err := lister.Keys(ctx)
+	if err != nil {
+		s.reportError(ctx, nil, ErrSaga(ErrStoreFailed, err))
+
+		return
+	}
+
+	for _, correlation := range keys {
+		env, err := s.store.Get(ctx, correlation)
+		if errors.Is(err, stores.ErrStoreNotFound) {
+			continue
+		}
+
+		if err != nil {
+			s.reportError(ctx, correlation, ErrSaga(ErrStoreFailed, err))
+
+			continue
+		}
+
+		s.track(correlation, env.Payload.Deadline)
+	}
+}
+
+// track records a running instance and its deadline (zero for none).
+func (s *saga[E, C, S]) track(correlation string, deadline time.Time) {
+	s.activeMu.Lock()
+	defer s.activeMu.Unlock()
+
+	s.active[correlation] = deadline
+}
+
+// forget stops tracking an instance.
+func (s *saga[E, C, S]) forget(correlation string) {
+	s.activeMu.Lock()
+	defer s.activeMu.Unlock()
+
+	delete(s.active, correlation)
+}
+
+// reportError forwards err to the configured ErrorHandler.
+// ErrorHandler is guaranteed non-nil by NewOptions, so the nil-guard
+// is defensive only.
+func (s *saga[E, C, S]) reportError(ctx context.Context, msg any, err error) {
+	s.stats.RecordFailure()
+
+	if s.errorHandler == nil {
+		return
+	}
+
+	s.errorHandler(ctx, msg, err)
+}
+
+// reportDrop forwards msg to the configured DropHandler. DropHandler
+// is nil by default (silent drops); the guard skips invocation in
+// that case.
+func (s *saga[E, C, S]) reportDrop(ctx context.Context, msg messaging.Message[E]) {
+	s.stats.RecordDrop()
+
+	if s.dropHandler == nil {
+		return
+	}
+
+	s.dropHandler(ctx, msg)
+}
+
+// sweep is the timeout sweeper goroutine. It wakes on sweepInterval
+// and compensates tracked instances past their deadline. The
+// goroutine exits when sweeperDone is closed (Stop).
+func (s *saga[E, C, S]) sweep() {
+	ticker := s.clock.NewTicker(s.sweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.sweeperDone:
+			return
+		case <-ticker.C():
+			s.sweepOnce()
+		}
+	}
+}
+
+// sweepOnce performs one timeout pass. Due instances are collected
+// under activeMu and expired one by one under their own lock, so a
+// concurrent event for the same correlation is never interleaved.
+func (s *saga[E, C, S]) sweepOnce() {
+	now := s.clock.Now()
+
+	var due []string
+
+	s.activeMu.Lock()
+	for correlation, deadline := range s.active {
+		if deadline.IsZero() || now.Before(deadline) {
+			continue
+		}
+
+		due = append(due, correlation)
+	}
+	s.activeMu.Unlock()
+
+	for _, correlation := range due {
+		s.expire(context.Background(), correlation, now)
+	}
+}
+
+// expire reloads the instance for correlation and compensates it with
+// ErrTimeout if its stored deadline has passed. An instance that
+// completed or had its deadline re-armed in the meantime is left
+// alone.
+func (s *saga[E, C, S]) expire(ctx context.Context, correlation string, now time.Time) {
+	unlock := s.lock(correlation)
+	defer unlock()
+
+	env, err := s.store.Get(ctx, correlation)
+	if errors.Is(err, stores.ErrStoreNotFound) {
+		s.forget(correlation)
+
+		return
+	}
+
+	if err != nil {
+		s.reportError(ctx, correlation, ErrSaga(ErrStoreFailed, err))
+
+		return
+	}
+
+	state := env.Payload
+	if state.Deadline.IsZero() || now.Before(state.Deadline) {
+		s.track(correlation, state.Deadline)
+
+		return
+	}
+
+	s.compensate(ctx, state, "", ErrSaga(ErrTimeout))
+}
@@ -0,0 +1,981 @@
+package saga
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/guidomantilla/yarumo/core/common/lifecycle"
+	"github.com/guidomantilla/yarumo/messaging"
+	"github.com/guidomantilla/yarumo/messaging/messagingtest"
+	"github.com/guidomantilla/yarumo/messaging/stores"
+)
+
+// order is the saga data of the order-fulfilment test definition.
+type order struct {
+	Reserved bool
+	Charged  bool
+}
+
+// captureErrors returns a thread-safe ErrorHandler that appends every
+// reported error, and a getter that returns a defensive copy.
+func captureErrors() (messaging.ErrorHandler, func() []error) {
+	var mu sync.Mutex
+
+	captured := []error{}
+
+	handler := func(_ context.Context, _ any, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		captured = append(captured, err)
+	}
+
+	get := func() []error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		out := make([]error, len(captured))
+		copy(out, captured)
+
+		return out
+	}
+
+	return handler, get
+}
+
+// captureDrops returns a thread-safe DropHandler that counts every
+// dropped message and a getter for the count.
+func captureDrops() (DropHandler, func() int32) {
+	var n int32
+
+	handler := func(_ context.Context, _ any) {
+		atomic.AddInt32(&n, 1)
+	}
+
+	get := func() int32 {
+		return atomic.LoadInt32(&n)
+	}
+
+	return handler, get
+}
+
+// captureCommands returns a Handler[string] that records every
+// received command and a getter that returns a defensive copy.
+func captureCommands() (messaging.Handler[string], func() []messaging.Message[string]) {
+	var mu sync.Mutex
+
+	captured := []messaging.Message[string]{}
+
+	handler := func(_ context.Context, msg messaging.Message[string]) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		captured = append(captured, msg)
+
+		return nil
+	}
+
+	get := func() []messaging.Message[string] {
+		mu.Lock()
+		defer mu.Unlock()
+
+		out := make([]messaging.Message[string], len(captured))
+		copy(out, captured)
+
+		return out
+	}
+
+	return handler, get
+}
+
+// command builds a Command for the given output channel and payload.
+func command(channel, payload string) Command[string] {
+	return Command[string]{Channel: channel, Message: messaging.Message[string]{Payload: payload}}
+}
+
+// event builds an event for correlation with the given payload and a
+// MessageID derived from both.
+func event(correlation, payload string) messaging.Message[string] {
+	return messaging.Message[string]{
+		Payload: payload,
+		Headers: messaging.Headers{MessageID: correlation + "/" + payload, CorrelationID: correlation},
+	}
+}
+
+// orderDefinition is the order-fulfilment step definition: reserve
+// stock, charge the payment, ship. A "declined" payment fails the
+// charging step; compensation releases the stock once reserved.
+func orderDefinition() Definition[string, string, order] {
+	steps := map[string]StepFunc[string, string, order]{
+		StartStep: func(_ context.Context, _ *State[order], msg messaging.Message[string]) (Outcome[string], error) {
+			if msg.Payload != "placed" {
+				return Outcome[string]{}, ErrUnhandledEvent
+			}
+
+			return Outcome[string]{Step: "reserving", Commands: []Command[string]{command("inventory", "reserve")}}, nil
+		},
+		"reserving": func(_ context.Context, state *State[order], msg messaging.Message[string]) (Outcome[string], error) {
+			if msg.Payload != "reserved" {
+				return Outcome[string]{}, ErrUnhandledEvent
+			}
+
+			state.Data.Reserved = true
+
+			return Outcome[string]{Step: "charging", Commands: []Command[string]{command("payments", "charge")}}, nil
+		},
+		"charging": func(_ context.Context, state *State[order], msg messaging.Message[string]) (Outcome[string], error) {
+			switch msg.Payload {
+			case "charged":
+				state.Data.Charged = true
+
+				return Outcome[string]{Complete: true, Commands: []Command[string]{command("shipping", "ship")}}, nil
+			case "declined":
+				return Outcome[string]{}, errors.New("payment declined")
+			default:
+				return Outcome[string]{}, ErrUnhandledEvent
+			}
+		},
+	}
+
+	compensate := func(_ context.Context, state State[order], _ error) []Command[string] {
+		if !state.Data.Reserved {
+			return nil
+		}
+
+		return []Command[string]{command("inventory", "release")}
+	}
+
+	return NewStepDefinition(steps, compensate)
+}
+
+// fixture is a started saga over one pipeline input and three
+// capturing pipeline outputs.
+type fixture struct {
+	input    messaging.Channel[string]
+	store    stores.MessageStore[State[order]]
+	saga     Saga[order]
+	commands func() []messaging.Message[string]
+}
+
+// newFixture wires and starts an order saga with opts over a fresh
+// in-memory store.
+func newFixture(t *testing.T, definition Definition[string, string, order], opts ...Option) *fixture {
+	t.Helper()
+
+	return newFixtureWithStore(t, stores.NewInMemoryMessageStore[State[order]](), definition, opts...)
+}
+
+// newFixtureWithStore wires and starts an order saga with opts over
+// store.
+func newFixtureWithStore(t *testing.T, store stores.MessageStore[State[order]], definition Definition[string, string, order], opts ...Option) *fixture {
+	t.Helper()
+
+	h, get := captureCommands()
+
+	outputs := map[string]messaging.Channel[string]{}
+
+	for _, name := range []string{"inventory", "payments", "shipping"} {
+		ch := messaging.NewPipelineChannel[string]()
+
+		_, err := ch.Subscribe(h)
+		if err != nil {
+			t.Fatalf("subscribe %s: %v", name, err)
+		}
+
+		outputs[name] = ch
+	}
+
+	f := &fixture{
+		input:    messaging.NewPipelineChannel[string](),
+		store:    store,
+		commands: get,
+	}
+
+	f.saga = NewSaga("orders", []messaging.Channel[string]{f.input}, outputs, definition, f.store, opts...)
+
+	err := f.saga.Start(context.Background())
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+
+	t.Cleanup(func() { _ = f.saga.Stop(context.Background()) })
+
+	return f
+}
+
+// send publishes the events on the fixture input.
+func (f *fixture) send(t *testing.T, events ...messaging.Message[string]) {
+	t.Helper()
+
+	for _, e := range events {
+		err := f.input.Send(context.Background(), e)
+		if err != nil {
+			t.Fatalf("send %q: %v", e.Payload, err)
+		}
+	}
+}
+
+// payloads returns the payloads of msgs in order.
+func payloads(msgs []messaging.Message[string]) []string {
+	out := make([]string, 0, len(msgs))
+
+	for _, m := range msgs {
+		out = append(out, m.Payload)
+	}
+
+	return out
+}
+
+// equal reports whether two string slices hold the same values in
+// the same order.
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// waitFor polls cond until it holds or the timeout elapses.
+func waitFor(t *testing.T, timeout time.Duration, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return
+		}
+
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	t.Fatal("condition not met before timeout")
+}
+
+func TestNewSaga(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns non-nil component", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewSaga("orders",
+			[]messaging.Channel[string]{messaging.NewPipelineChannel[string]()},
+			map[string]messaging.Channel[string]{},
+			orderDefinition(),
+			stores.NewInMemoryMessageStore[State[order]](),
+		)
+		if s == nil {
+			t.Fatal("expected non-nil saga")
+		}
+
+		if s.Name() != "orders" {
+			t.Fatalf("expected name orders, got %q", s.Name())
+		}
+	})
+}
+
+func TestSaga_Steps(t *testing.T) {
+	t.Parallel()
+
+	t.Run("runs the happy path to completion", func(t *testing.T) {
+		t.Parallel()
+
+		f := newFixture(t, orderDefinition())
+
+		f.send(t, event("o-1", "placed"), event("o-1", "reserved"))
+
+		state, err := f.saga.Instance(context.Background(), "o-1")
+		if err != nil {
+			t.Fatalf("instance: %v", err)
+		}
+
+		if state.Step != "charging" || !state.Data.Reserved {
+			t.Fatalf("unexpected mid-saga state %+v", state)
+		}
+
+		f.send(t, event("o-1", "charged"))
+
+		got := payloads(f.commands())
+		if !equal(got, []string{"reserve", "charge", "ship"}) {
+			t.Fatalf("unexpected commands %v", got)
+		}
+
+		_, err = f.saga.Instance(context.Background(), "o-1")
+		if !errors.Is(err, stores.ErrStoreNotFound) {
+			t.Fatalf("expected completed instance to be deleted, got %v", err)
+		}
+	})
+
+	t.Run("stamps correlation and causation on commands", func(t *testing.T) {
+		t.Parallel()
+
+		f := newFixture(t, orderDefinition())
+
+		f.send(t, event("o-1", "placed"))
+
+		cmds := f.commands()
+		if len(cmds) != 1 {
+			t.Fatalf("expected 1 command, got %d", len(cmds))
+		}
+
+		if cmds[0].Headers.CorrelationID != "o-1" {
+			t.Fatalf("expected CorrelationID o-1, got %q", cmds[0].Headers.CorrelationID)
+		}
+
+		if cmds[0].Headers.CausationID != "o-1/placed" {
+			t.Fatalf("expected CausationID o-1/placed, got %q", cmds[0].Headers.CausationID)
+		}
+	})
+
+	t.Run("keeps correlations independent", func(t *testing.T) {
+		t.Parallel()
+
+		f := newFixture(t, orderDefinition())
+
+		f.send(t, event("o-1", "placed"), event("o-2", "placed"), event("o-2", "reserved"))
+
+		first, err := f.saga.Instance(context.Background(), "o-1")
+		if err != nil {
+			t.Fatalf("instance o-1: %v", err)
+		}
+
+		second, err := f.saga.Instance(context.Background(), "o-2")
+		if err != nil {
+			t.Fatalf("instance o-2: %v", err)
+		}
+
+		if first.Step != "reserving" || second.Step != "charging" {
+			t.Fatalf("unexpected steps o-1=%q o-2=%q", first.Step, second.Step)
+		}
+	})
+
+	t.Run("merges events from several inputs", func(t *testing.T) {
+		t.Parallel()
+
+		placed := messaging.NewPipelineChannel[string]()
+		reserved := messaging.NewPipelineChannel[string]()
+		store := stores.NewInMemoryMessageStore[State[order]]()
+		outputs := map[string]messaging.Channel[string]{
+			"inventory": messaging.NewPipelineChannel[string](),
+			"payments":  messaging.NewPipelineChannel[string](),
+		}
+
+		s := NewSaga("orders", []messaging.Channel[string]{placed, reserved}, outputs, orderDefinition(), store,
+			WithErrorHandler(messaging.SilentErrorHandler))
+
+		err := s.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		t.Cleanup(func() { _ = s.Stop(context.Background()) })
+
+		err = placed.Send(context.Background(), event("o-1", "placed"))
+		if err != nil {
+			t.Fatalf("send placed: %v", err)
+		}
+
+		err = reserved.Send(context.Background(), event("o-1", "reserved"))
+		if err != nil {
+			t.Fatalf("send reserved: %v", err)
+		}
+
+		state, err := s.Instance(context.Background(), "o-1")
+		if err != nil {
+			t.Fatalf("instance: %v", err)
+		}
+
+		if state.Step != "charging" {
+			t.Fatalf("expected step charging, got %q", state.Step)
+		}
+	})
+}
+
+func TestSaga_Drops(t *testing.T) {
+	t.Parallel()
+
+	t.Run("drops events without correlation", func(t *testing.T) {
+		t.Parallel()
+
+		drop, drops := captureDrops()
+		f := newFixture(t, orderDefinition(), WithDropHandler(drop))
+
+		f.send(t, messaging.Message[string]{Payload: "placed"})
+
+		if drops() != 1 {
+			t.Fatalf("expected 1 drop, got %d", drops())
+		}
+
+		if len(f.commands()) != 0 {
+			t.Fatal("expected no commands")
+		}
+	})
+
+	t.Run("drops unhandled events and keeps the instance", func(t *testing.T) {
+		t.Parallel()
+
+		drop, drops := captureDrops()
+		f := newFixture(t, orderDefinition(), WithDropHandler(drop))
+
+		f.send(t, event("o-1", "charged"), event("o-1", "placed"), event("o-1", "charged"))
+
+		if drops() != 2 {
+			t.Fatalf("expected 2 drops, got %d", drops())
+		}
+
+		state, err := f.saga.Instance(context.Background(), "o-1")
+		if err != nil {
+			t.Fatalf("instance: %v", err)
+		}
+
+		if state.Step != "reserving" {
+			t.Fatalf("expected step reserving, got %q", state.Step)
+		}
+	})
+}
+
+func TestSaga_Compensation(t *testing.T) {
+	t.Parallel()
+
+	t.Run("failed step compensates and forgets the instance", func(t *testing.T) {
+		t.Parallel()
+
+		handler, errs := captureErrors()
+		f := newFixture(t, orderDefinition(), WithErrorHandler(handler))
+
+		f.send(t, event("o-1", "placed"), event("o-1", "reserved"), event("o-1", "declined"))
+
+		got := payloads(f.commands())
+		if !equal(got, []string{"reserve", "charge", "release"}) {
+			t.Fatalf("unexpected commands %v", got)
+		}
+
+		captured := errs()
+		if len(captured) != 1 || !errors.Is(captured[0], ErrStepFailed) {
+			t.Fatalf("expected one ErrStepFailed, got %v", captured)
+		}
+
+		_, err := f.saga.Instance(context.Background(), "o-1")
+		if !errors.Is(err, stores.ErrStoreNotFound) {
+			t.Fatalf("expected compensated instance to be deleted, got %v", err)
+		}
+	})
+
+	t.Run("unknown output channel compensates", func(t *testing.T) {
+		t.Parallel()
+
+		steps := map[string]StepFunc[string, string, order]{
+			StartStep: func(_ context.Context, state *State[order], _ messaging.Message[string]) (Outcome[string], error) {
+				state.Data.Reserved = true
+
+				return Outcome[string]{Step: "reserving", Commands: []Command[string]{command("inventory", "reserve"), command("nowhere", "x")}}, nil
+			},
+		}
+
+		compensate := func(_ context.Context, state State[order], _ error) []Command[string] {
+			if !state.Data.Reserved {
+				return nil
+			}
+
+			return []Command[string]{command("inventory", "release")}
+		}
+
+		handler, errs := captureErrors()
+		f := newFixture(t, NewStepDefinition(steps, compensate), WithErrorHandler(handler))
+
+		f.send(t, event("o-1", "placed"))
+
+		got := payloads(f.commands())
+		if !equal(got, []string{"reserve", "release"}) {
+			t.Fatalf("unexpected commands %v", got)
+		}
+
+		captured := errs()
+		if len(captured) != 1 || !errors.Is(captured[0], ErrCommandFailed) || !errors.Is(captured[0], ErrUnknownChannel) {
+			t.Fatalf("expected one ErrCommandFailed/ErrUnknownChannel, got %v", captured)
+		}
+	})
+
+	t.Run("failed compensating command is reported", func(t *testing.T) {
+		t.Parallel()
+
+		steps := map[string]StepFunc[string, string, order]{
+			StartStep: func(_ context.Context, _ *State[order], _ messaging.Message[string]) (Outcome[string], error) {
+				return Outcome[string]{}, errors.New("boom")
+			},
+		}
+
+		compensate := func(_ context.Context, _ State[order], _ error) []Command[string] {
+			return []Command[string]{command("nowhere", "undo")}
+		}
+
+		handler, errs := captureErrors()
+		f := newFixture(t, NewStepDefinition(steps, compensate), WithErrorHandler(handler))
+
+		f.send(t, event("o-1", "placed"))
+
+		captured := errs()
+		if len(captured) != 2 {
+			t.Fatalf("expected 2 errors, got %v", captured)
+		}
+
+		if !errors.Is(captured[0], ErrStepFailed) || !errors.Is(captured[1], ErrCompensationFailed) {
+			t.Fatalf("expected ErrStepFailed then ErrCompensationFailed, got %v", captured)
+		}
+	})
+}
+
+func TestSaga_Timeout(t *testing.T) {
+	t.Parallel()
+
+	t.Run("sweeper compensates instances past their deadline", func(t *testing.T) {
+		t.Parallel()
+
+		handler, errs := captureErrors()
+		f := newFixture(t, orderDefinition(),
+			WithTimeout(30*time.Millisecond),
+			WithSweepInterval(5*time.Millisecond),
+			WithErrorHandler(handler),
+		)
+
+		f.send(t, event("o-1", "placed"), event("o-1", "reserved"))
+
+		waitFor(t, time.Second, func() bool { return len(errs()) == 1 })
+
+		if !errors.Is(errs()[0], ErrTimeout) {
+			t.Fatalf("expected ErrTimeout, got %v", errs()[0])
+		}
+
+		got := payloads(f.commands())
+		if !equal(got, []string{"reserve", "charge", "release"}) {
+			t.Fatalf("unexpected commands %v", got)
+		}
+
+		_, err := f.saga.Instance(context.Background(), "o-1")
+		if !errors.Is(err, stores.ErrStoreNotFound) {
+			t.Fatalf("expected timed-out instance to be deleted, got %v", err)
+		}
+	})
+
+	t.Run("sweeper recovers a panicking Compensate", func(t *testing.T) {
+		t.Parallel()
+
+		steps := map[string]StepFunc[string, string, order]{
+			StartStep: func(_ context.Context, _ *State[order], _ messaging.Message[string]) (Outcome[string], error) {
+				return Outcome[string]{Step: "waiting"}, nil
+			},
+		}
+
+		compensate := func(_ context.Context, _ State[order], _ error) []Command[string] {
+			panic("compensate boom")
+		}
+
+		handler, errs := captureErrors()
+		f := newFixture(t, NewStepDefinition[string, string, order](steps, compensate),
+			WithTimeout(10*time.Millisecond),
+			WithSweepInterval(5*time.Millisecond),
+			WithErrorHandler(handler),
+		)
+
+		f.send(t, event("o-1", "placed"))
+
+		waitFor(t, time.Second, func() bool { return len(errs()) == 2 })
+
+		if !errors.Is(errs()[0], ErrTimeout) || !errors.Is(errs()[1], ErrCompensationPanic) {
+			t.Fatalf("expected ErrTimeout then ErrCompensationPanic, got %v", errs())
+		}
+
+		if len(f.commands()) != 0 {
+			t.Fatalf("expected no compensating commands, got %v", payloads(f.commands()))
+		}
+
+		_, err := f.saga.Instance(context.Background(), "o-1")
+		if !errors.Is(err, stores.ErrStoreNotFound) {
+			t.Fatalf("expected timed-out instance to be deleted, got %v", err)
+		}
+	})
+
+	t.Run("outcome timeout re-arms the deadline", func(t *testing.T) {
+		t.Parallel()
+
+		steps := map[string]StepFunc[string, string, order]{
+			StartStep: func(_ context.Context, _ *State[order], _ messaging.Message[string]) (Outcome[string], error) {
+				return Outcome[string]{Step: "waiting", Timeout: time.Hour}, nil
+			},
+		}
+
+		handler, errs := captureErrors()
+		f := newFixture(t, NewStepDefinition[string, string, order](steps, nil),
+			WithTimeout(10*time.Millisecond),
+			WithSweepInterval(5*time.Millisecond),
+			WithErrorHandler(handler),
+		)
+
+		f.send(t, event("o-1", "placed"))
+
+		time.Sleep(50 * time.Millisecond)
+
+		if len(errs()) != 0 {
+			t.Fatalf("expected no timeout, got %v", errs())
+		}
+
+		state, err := f.saga.Instance(context.Background(), "o-1")
+		if err != nil {
+			t.Fatalf("instance: %v", err)
+		}
+
+		if time.Until(state.Deadline) < 30*time.Minute {
+			t.Fatalf("expected re-armed deadline, got %v", state.Deadline)
+		}
+	})
+
+	t.Run("deadlines of stored instances survive a restart", func(t *testing.T) {
+		t.Parallel()
+
+		clock := messagingtest.NewFakeClock(time.Time{})
+		store := stores.NewInMemoryMessageStore[State[order]]()
+
+		running := State[order]{CorrelationID: "o-1", Step: "charging", Data: order{Reserved: true}, Deadline: clock.Now().Add(time.Minute)}
+
+		err := store.Put(context.Background(), "o-1", messaging.Message[State[order]]{Payload: running})
+		if err != nil {
+			t.Fatalf("put: %v", err)
+		}
+
+		handler, errs := captureErrors()
+		f := newFixtureWithStore(t, store, orderDefinition(),
+			WithSweepInterval(time.Second),
+			WithErrorHandler(handler),
+			WithClock(clock),
+		)
+
+		clock.WaitForTimers(t, 1)
+		clock.Advance(2 * time.Minute)
+
+		messagingtest.WaitUntil(t, func() bool { return len(errs()) == 1 }, "restored instance timeout")
+
+		if !errors.Is(errs()[0], ErrTimeout) {
+			t.Fatalf("expected ErrTimeout, got %v", errs()[0])
+		}
+
+		got := payloads(f.commands())
+		if !equal(got, []string{"release"}) {
+			t.Fatalf("unexpected commands %v", got)
+		}
+	})
+
+	t.Run("late event for an expired instance compensates it", func(t *testing.T) {
+		t.Parallel()
+
+		drop, drops := captureDrops()
+		handler, errs := captureErrors()
+		f := newFixture(t, orderDefinition(), WithDropHandler(drop), WithErrorHandler(handler))
+
+		expired := State[order]{CorrelationID: "o-1", Step: "charging", Data: order{Reserved: true}, Deadline: time.Now().Add(-time.Second)}
+
+		err := f.store.Put(context.Background(), "o-1", messaging.Message[State[order]]{Payload: expired})
+		if err != nil {
+			t.Fatalf("put: %v", err)
+		}
+
+		f.send(t, event("o-1", "charged"))
+
+		if drops() != 1 {
+			t.Fatalf("expected the late event to be dropped, got %d drops", drops())
+		}
+
+		if len(errs()) != 1 || !errors.Is(errs()[0], ErrTimeout) {
+			t.Fatalf("expected one ErrTimeout, got %v", errs())
+		}
+
+		got := payloads(f.commands())
+		if !equal(got, []string{"release"}) {
+			t.Fatalf("unexpected commands %v", got)
+		}
+	})
+}
+
+func TestSaga_StoreFailure(t *testing.T) {
+	t.Parallel()
+
+	t.Run("store errors are reported and no command is sent", func(t *testing.T) {
+		t.Parallel()
+
+		input := messaging.NewPipelineChannel[string]()
+		h, commands := captureCommands()
+		inventory := messaging.NewPipelineChannel[string]()
+
+		_, err := inventory.Subscribe(h)
+		if err != nil {
+			t.Fatalf("subscribe: %v", err)
+		}
+
+		handler, errs := captureErrors()
+		store := &failingStore{err: errors.New("store down")}
+
+		s := NewSaga("orders", []messaging.Channel[string]{input}, map[string]messaging.Channel[string]{"inventory": inventory},
+			orderDefinition(), store, WithErrorHandler(handler))
+
+		err = s.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		t.Cleanup(func() { _ = s.Stop(context.Background()) })
+
+		err = input.Send(context.Background(), event("o-1", "placed"))
+		if err != nil {
+			t.Fatalf("send: %v", err)
+		}
+
+		captured := errs()
+		if len(captured) != 1 || !errors.Is(captured[0], ErrStoreFailed) {
+			t.Fatalf("expected one ErrStoreFailed, got %v", captured)
+		}
+
+		if len(commands()) != 0 {
+			t.Fatalf("expected no commands, got %v", payloads(commands()))
+		}
+	})
+}
+
+func TestSaga_Concurrency(t *testing.T) {
+	t.Parallel()
+
+	t.Run("serialises events per correlation on a concurrent input", func(t *testing.T) {
+		t.Parallel()
+
+		steps := map[string]StepFunc[string, string, int]{
+			StartStep: func(_ context.Context, state *State[int], _ messaging.Message[string]) (Outcome[string], error) {
+				state.Data++
+
+				return Outcome[string]{Step: "counting"}, nil
+			},
+			"counting": func(_ context.Context, state *State[int], _ messaging.Message[string]) (Outcome[string], error) {
+				state.Data++
+
+				return Outcome[string]{}, nil
+			},
+		}
+
+		input := messaging.NewQueueChannel[string]("events", messaging.WithWorkerCount(8), messaging.WithBufferSize(256))
+		store := stores.NewInMemoryMessageStore[State[int]]()
+
+		s := NewSaga("counter", []messaging.Channel[string]{input}, map[string]messaging.Channel[string]{},
+			NewStepDefinition[string, string, int](steps, nil), store)
+
+		err := s.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		lc, ok := input.(lifecycle.Component)
+		if !ok {
+			t.Fatal("queue channel is not a lifecycle.Component")
+		}
+
+		err = lc.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start queue: %v", err)
+		}
+
+		const events = 100
+
+		for i := range events {
+			err = input.Send(context.Background(), event("c-1", string(rune('a'+i%26))))
+			if err != nil {
+				t.Fatalf("send %d: %v", i, err)
+			}
+		}
+
+		_ = lc.Stop(context.Background())
+		_ = s.Stop(context.Background())
+
+		state, err := s.Instance(context.Background(), "c-1")
+		if err != nil {
+			t.Fatalf("instance: %v", err)
+		}
+
+		if state.Data != events {
+			t.Fatalf("expected %d increments, got %d", events, state.Data)
+		}
+	})
+}
+
+func TestSaga_Lifecycle(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Start and Stop are idempotent and close Done", func(t *testing.T) {
+		t.Parallel()
+
+		f := newFixture(t, orderDefinition())
+
+		err := f.saga.Start(context.Background())
+		if err != nil {
+			t.Fatalf("second start: %v", err)
+		}
+
+		if f.saga.Stats().Subscribers != 1 {
+			t.Fatalf("expected 1 subscriber, got %d", f.saga.Stats().Subscribers)
+		}
+
+		err = f.saga.Stop(context.Background())
+		if err != nil {
+			t.Fatalf("stop: %v", err)
+		}
+
+		err = f.saga.Stop(context.Background())
+		if err != nil {
+			t.Fatalf("second stop: %v", err)
+		}
+
+		select {
+		case <-f.saga.Done():
+		default:
+			t.Fatal("expected Done to be closed")
+		}
+
+		f.send(t, event("o-1", "placed"))
+
+		if len(f.commands()) != 0 {
+			t.Fatal("expected no dispatch after Stop")
+		}
+	})
+
+	t.Run("failed Subscribe cancels earlier subscriptions", func(t *testing.T) {
+		t.Parallel()
+
+		good := &countingChannel[string]{}
+		bad := &countingChannel[string]{err: errors.New("closed")}
+
+		s := NewSaga("orders", []messaging.Channel[string]{good, bad}, map[string]messaging.Channel[string]{},
+			orderDefinition(), stores.NewInMemoryMessageStore[State[order]]())
+
+		err := s.Start(context.Background())
+		if !errors.Is(err, lifecycle.ErrStartFailed) {
+			t.Fatalf("expected lifecycle start error, got %v", err)
+		}
+
+		if good.cancelled.Load() != 1 {
+			t.Fatalf("expected the first subscription to be cancelled, got %d", good.cancelled.Load())
+		}
+
+		if s.Stats().Subscribers != 0 {
+			t.Fatalf("expected no subscribers, got %d", s.Stats().Subscribers)
+		}
+	})
+
+	t.Run("Stop with expired ctx returns ErrShutdownTimeout", func(t *testing.T) {
+		t.Parallel()
+
+		f := newFixture(t, orderDefinition())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := f.saga.Stop(ctx)
+		if !errors.Is(err, lifecycle.ErrShutdownTimeout) {
+			t.Fatalf("expected ErrShutdownTimeout, got %v", err)
+		}
+	})
+}
+
+func TestSaga_Stats(t *testing.T) {
+	t.Parallel()
+
+	f := newFixture(t, orderDefinition(), WithErrorHandler(messaging.SilentErrorHandler))
+
+	f.send(t,
+		event("o-1", "placed"),
+		event("o-2", "placed"), event("o-2", "reserved"), event("o-2", "charged"),
+		event("o-3", "charged"),
+	)
+
+	got := f.saga.Stats()
+	if got.Subscribers != 1 || got.Delivered != 5 || got.Sent != 4 || got.Dropped != 1 || got.BufferLength != 1 {
+		t.Fatalf("Stats = %+v, want Subscribers=1 Delivered=5 Sent=4 Dropped=1 BufferLength=1", got)
+	}
+}
+
+func TestSaga_Options(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil and non-positive values are ignored", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(
+			WithTimeout(0),
+			WithSweepInterval(-time.Second),
+			WithErrorHandler(nil),
+			WithDropHandler(nil),
+			WithClock(nil),
+		)
+
+		if opts.timeout != 0 {
+			t.Fatalf("expected no timeout, got %v", opts.timeout)
+		}
+
+		if opts.sweepInterval != DefaultSweepInterval {
+			t.Fatalf("expected default sweep interval, got %v", opts.sweepInterval)
+		}
+
+		if opts.errorHandler == nil {
+			t.Fatal("expected default error handler preserved on nil arg")
+		}
+
+		if opts.dropHandler != nil {
+			t.Fatal("expected no drop handler")
+		}
+
+		if opts.clock == nil {
+			t.Fatal("expected default clock preserved on nil arg")
+		}
+	})
+}
+
+// failingStore is a MessageStore test double whose every operation
+// returns err.
+type failingStore struct {
+	err error
+}
+
+func (s *failingStore) Put(_ context.Context, _ string, _ messaging.Message[State[order]]) error {
+	return s.err
+}
+
+func (s *failingStore) Get(_ context.Context, _ string) (messaging.Message[State[order]], error) {
+	return messaging.Message[State[order]]{}, s.err
+}
+
+func (s *failingStore) Delete(_ context.Context, _ string) error {
+	return s.err
+}
+
+// countingChannel is a Channel[T] test double whose Subscribe returns
+// err when set, and otherwise a Cancel that counts its invocations.
+type countingChannel[T any] struct {
+	err       error
+	cancelled atomic.Int32
+}
+
+func (c *countingChannel[T]) Send(_ context.Context, _ messaging.Message[T]) error {
+	return nil
+}
+
+func (c *countingChannel[T]) Subscribe(_ messaging.Handler[T]) (messaging.Cancel, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	return func() { c.cancelled.Add(1) }, nil
+}
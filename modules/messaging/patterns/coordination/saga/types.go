@@ -0,0 +1,219 @@
+// Package saga provides a Process Manager (saga) pattern over
+// messaging.Channel[T].
+//
+// A Saga subscribes to one or more input Channel[E] and keeps one
+// instance of saga state per Headers.CorrelationID. Every event runs
+// the instance's Definition, which advances the instance (its current
+// Step and its Data) and returns the commands to emit. Commands are
+// routed by name to the output Channel[C] map given at construction.
+// When a step fails, a command cannot be sent, or the instance misses
+// its deadline, the Saga asks the Definition for the compensating
+// commands, emits them and forgets the instance.
+//
+// # Saga vs Barrier / Aggregator
+//
+// Barrier and Aggregator correlate messages but only release them;
+// they carry no state between groups and cannot undo anything. A Saga
+// owns a long-running, multi-step conversation: it remembers where
+// each instance is, decides what to do next from that position, and
+// knows how to roll back the side effects already emitted.
+//
+// # Definitions
+//
+// NewStepDefinition dispatches each event to the StepFunc registered
+// for the instance's current step (StartStep for a new instance).
+// NewMachineDefinition drives the instance with a finite state
+// machine instead — any Machine, such as *fsm.Machine from
+// compute/math/fsm — and runs the StepFunc registered for the state
+// the machine lands on. Both return ErrUnhandledEvent for events the
+// instance does not expect in its current position; the Saga drops
+// those without touching the instance.
+//
+// # State
+//
+// Instances live in a stores.MessageStore[State[S]] keyed by
+// CorrelationID, so any MessageStore backend (the in-memory one,
+// Redis, …) can hold saga state. Each envelope carries the instance
+// in Payload and the CorrelationID in Headers. Instances are deleted
+// when they complete or are compensated. Events for one correlation
+// are processed one at a time; different correlations run
+// concurrently.
+//
+// # Timeouts
+//
+// WithTimeout arms a deadline for every new instance and
+// Outcome.Timeout re-arms it from a step. A sweeper goroutine
+// compensates instances whose deadline has passed with ErrTimeout.
+// Deadlines are persisted in State.Deadline. When the store
+// implements stores.KeyLister (the in-memory store does), Start
+// reloads every stored instance and tracks its deadline, so an
+// instance that expired while no Saga was running is compensated on
+// the first sweep. With any other store, timeouts do not survive a
+// restart: an instance is tracked again only once its next event
+// arrives.
+//
+// # Lifecycle
+//
+// Saga implements common/lifecycle.Component (worker-style): Start
+// restores the stored deadlines, subscribes to every input channel and
+// spawns the timeout sweeper;
+// Stop cancels the subscriptions, stops the sweeper and closes Done.
+// Stop does not compensate running instances — they stay in the
+// store for the next Saga to resume.
+//
+// # Error handling
+//
+// The handler installed on each input channel always returns nil.
+// Store failures, step failures, timeouts and command Send failures
+// flow through WithErrorHandler; intentional drops (missing
+// correlation, unhandled event) flow through WithDropHandler.
+// Nothing propagates to the input channel's Send caller, consistent
+// with the package-wide policy in modules/messaging/CODING_STANDARDS.md.
+package saga
+
+import (
+	"context"
+	"time"
+
+	"github.com/guidomantilla/yarumo/core/common/lifecycle"
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+// StartStep is the step of an instance that has not processed any
+// event yet. NewStepDefinition runs the StepFunc registered under it
+// for the first event of every correlation.
+const StartStep = ""
+
+// DefaultSweepInterval is the default cadence at which the timeout
+// sweeper looks for instances past their deadline. Override with
+// WithSweepInterval.
+const DefaultSweepInterval = 250 * time.Millisecond
+
+var (
+	_ Saga[any] = (*saga[any, any, any])(nil)
+
+	_ Definition[any, any, any] = (*stepDefinition[any, any, any])(nil)
+	_ Definition[any, any, any] = (*machineDefinition[any, any, any])(nil)
+
+	_ ErrSagaFn = ErrSaga
+)
+
+// Saga is the public interface for a Process Manager. It embeds
+// lifecycle.Component so callers wire it up with lifecycle.Build, and
+// messaging.Inspectable to expose its runtime statistics. Instance
+// reads the current state of one saga instance.
+type Saga[S any] interface {
+	lifecycle.Component
+	messaging.Inspectable
+	// Instance returns the state of the instance for correlationID.
+	// It returns an error wrapping stores.ErrStoreNotFound when no
+	// instance is running for it.
+	Instance(ctx context.Context, correlationID string) (State[S], error)
+}
+
+// State is one saga instance as persisted in the store. Definitions
+// read and mutate Data; the Saga owns the remaining fields.
+type State[S any] struct {
+	// CorrelationID identifies the instance; every event and command
+	// of the conversation carries it in Headers.CorrelationID.
+	CorrelationID string
+	// Step is the instance's current position: a StepFunc key for
+	// NewStepDefinition, a state ID for NewMachineDefinition.
+	// StartStep until the first event is processed.
+	Step string
+	// Data is the definition-owned business state.
+	Data S
+	// StartedAt is when the first event of the instance arrived.
+	StartedAt time.Time
+	// UpdatedAt is when the instance last processed an event.
+	UpdatedAt time.Time
+	// Deadline is when the instance is compensated with ErrTimeout if
+	// it has not completed. Zero means no deadline.
+	Deadline time.Time
+}
+
+// Command is a message a step emits. Channel names the output
+// channel, a key of the outputs map passed to NewSaga. Empty
+// CorrelationID and CausationID headers are stamped with the
+// instance's CorrelationID and the triggering event's MessageID.
+type Command[C any] struct {
+	Channel string
+	Message messaging.Message[C]
+}
+
+// Outcome is what a step returns to the Saga.
+type Outcome[C any] struct {
+	// Step is the instance's next step. Empty keeps the current one.
+	// NewMachineDefinition overrides it with the machine's state.
+	Step string
+	// Commands are emitted in order after the instance is persisted.
+	Commands []Command[C]
+	// Complete ends the instance successfully: it is deleted from the
+	// store once Commands are emitted.
+	Complete bool
+	// Timeout, when positive, re-arms the instance deadline to now
+	// plus Timeout.
+	Timeout time.Duration
+}
+
+// StepFunc advances state with msg. It may mutate state.Data and
+// returns the Outcome to apply. A non-nil error compensates the
+// instance, except ErrUnhandledEvent, which drops msg and leaves the
+// instance untouched.
+type StepFunc[E any, C any, S any] func(ctx context.Context, state *State[S], msg messaging.Message[E]) (Outcome[C], error)
+
+// CompensateFunc returns the commands that undo the side effects of
+// an instance that failed or timed out. cause is the failure that
+// triggered the compensation. A nil CompensateFunc compensates with
+// no commands.
+type CompensateFunc[C any, S any] func(ctx context.Context, state State[S], cause error) []Command[C]
+
+// EventFn names the machine event that msg represents for
+// NewMachineDefinition.
+type EventFn[E any] func(msg messaging.Message[E]) string
+
+// Definition is the behaviour of a saga: how an event advances an
+// instance and how a failed instance is compensated. Use
+// NewStepDefinition or NewMachineDefinition, or implement it directly.
+type Definition[E any, C any, S any] interface {
+	// Handle advances state with msg and returns the Outcome to apply.
+	// It returns ErrUnhandledEvent for events the instance does not
+	// expect in its current step.
+	Handle(ctx context.Context, state *State[S], msg messaging.Message[E]) (Outcome[C], error)
+	// Compensate returns the commands that undo the instance's side
+	// effects.
+	Compensate(ctx context.Context, state State[S], cause error) []Command[C]
+}
+
+// Machine is the finite state machine NewMachineDefinition drives.
+// *fsm.Machine from compute/math/fsm satisfies it. A fresh Machine is
+// built per event and moved to the instance's step with Restore, so
+// implementations need not be safe for concurrent use.
+type Machine interface {
+	// Current returns the ID of the current state.
+	Current() string
+	// Send fires event, passing ctx to transition guards.
+	Send(event string, ctx any) error
+	// Restore moves the machine to state without firing a transition.
+	Restore(state string) error
+}
+
+// MachineFn builds a fresh Machine in its initial state.
+// NewMachineDefinition calls it once per event.
+type MachineFn func() Machine
+
+// DropHandler is the optional observability hook invoked once per
+// intentional drop:
+//
+//   - the event had empty CorrelationID (cannot be correlated);
+//   - the Definition returned ErrUnhandledEvent for the event;
+//   - the event arrived for an instance already past its deadline
+//     (the instance is compensated with ErrTimeout).
+//
+// msg is type-erased; cast it inside the hook when payload-specific
+// behavior is needed. The hook is invoked from the input channel's
+// dispatcher and must not block.
+type DropHandler func(ctx context.Context, msg any)
+
+// ErrSagaFn is the function type for ErrSaga.
+type ErrSagaFn func(causes ...error) error
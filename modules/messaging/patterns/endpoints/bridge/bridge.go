@@ -18,6 +18,7 @@ type bridge[T any] struct {
 	src          messaging.Channel[T]
 	dst          messaging.Channel[T]
 	errorHandler messaging.ErrorHandler
+	stats        messaging.StatsRecorder
 
 	done      chan struct{}
 	startOnce sync.Once
@@ -122,16 +123,43 @@ func (b *bridge[T]) Done() <-chan struct{} {
 	return b.done
 }
 
+// Stats returns a snapshot of the bridge's runtime statistics.
+// Subscribers is 1 while the source subscription is active.
+func (b *bridge[T]) Stats() messaging.Stats {
+	cassert.NotNil(b, "bridge is nil")
+
+	stats := b.stats.Snapshot()
+
+	b.mu.Lock()
+	if b.cancel != nil {
+		stats.Subscribers = 1
+	}
+	b.mu.Unlock()
+
+	return stats
+}
+
 // handle is the Handler[T] subscribed on the source channel. It
 // forwards msg to the destination channel; forward failures are
 // reported through the configured ErrorHandler. The function itself
 // always returns nil so bridge concerns never propagate to the source
 // channel's Send caller.
 func (b *bridge[T]) handle(ctx context.Context, msg messaging.Message[T]) error {
+	end := b.stats.Begin()
+	defer end(nil)
+
 	err := b.dst.Send(ctx, msg)
-	if err != nil && b.errorHandler != nil {
-		b.errorHandler(ctx, msg, ErrBridge(ErrForwardFailed, err))
+	if err != nil {
+		b.stats.RecordFailure()
+
+		if b.errorHandler != nil {
+			b.errorHandler(ctx, msg, ErrBridge(ErrForwardFailed, err))
+		}
+
+		return nil
 	}
 
+	b.stats.RecordSent()
+
 	return nil
 }
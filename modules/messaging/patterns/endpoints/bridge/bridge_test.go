@@ -503,3 +503,36 @@ func (c *failingChannel[T]) Send(_ context.Context, _ messaging.Message[T]) erro
 func (c *failingChannel[T]) Subscribe(_ messaging.Handler[T]) (messaging.Cancel, error) {
 	return func() {}, nil
 }
+
+func TestBridge_Stats(t *testing.T) {
+	t.Parallel()
+
+	src := messaging.NewPipelineChannel[int]()
+	dst := &failingChannel[int]{}
+
+	b := NewBridge("stats", src, dst, WithErrorHandler(messaging.SilentErrorHandler))
+
+	err := b.Start(context.Background())
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+
+	t.Cleanup(func() { _ = b.Stop(context.Background()) })
+
+	err = src.Send(context.Background(), messaging.Message[int]{Payload: 1})
+	if err != nil {
+		t.Fatalf("send: %v", err)
+	}
+
+	dst.err = errors.New("down")
+
+	err = src.Send(context.Background(), messaging.Message[int]{Payload: 2})
+	if err != nil {
+		t.Fatalf("send: %v", err)
+	}
+
+	got := b.Stats()
+	if got.Subscribers != 1 || got.Delivered != 2 || got.Sent != 1 || got.Failed != 1 {
+		t.Fatalf("Stats = %+v, want Subscribers=1 Delivered=2 Sent=1 Failed=1", got)
+	}
+}
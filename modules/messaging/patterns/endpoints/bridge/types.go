@@ -46,6 +46,7 @@ package bridge
 
 import (
 	"github.com/guidomantilla/yarumo/core/common/lifecycle"
+	"github.com/guidomantilla/yarumo/messaging"
 )
 
 var (
@@ -56,12 +57,14 @@ var (
 
 // Bridge is the public interface for a one-to-one channel forwarder.
 // It embeds lifecycle.Component so callers wire it up with
-// lifecycle.Build. The interface exists (rather than returning
+// lifecycle.Build, and messaging.Inspectable to expose its runtime
+// statistics. The interface exists (rather than returning
 // lifecycle.Component directly) so the consumer's API surface
 // preserves "this is a Bridge" semantics and the type stays open to
 // future bridge-specific methods without breaking callers.
 type Bridge[T any] interface {
 	lifecycle.Component
+	messaging.Inspectable
 }
 
 // ErrBridgeFn is the function type for ErrBridge.
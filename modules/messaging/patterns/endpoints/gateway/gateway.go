@@ -22,6 +22,8 @@ type gateway[Req, Res any] struct {
 	uid            cuids.UID
 	requestTimeout time.Duration
 	errorHandler   messaging.ErrorHandler
+	registry       messaging.ChannelRegistry
+	stats          messaging.StatsRecorder
 
 	// pendingMu guards pending; the map carries one buffered chan per
 	// in-flight Request so reply-routing never blocks on a slow caller.
@@ -56,6 +58,8 @@ type gateway[Req, Res any] struct {
 //   - WithRequestTimeout overrides the default 5s per-Request timeout.
 //   - WithErrorHandler overrides the default
 //     messaging.DefaultErrorHandler (which logs via common/log).
+//   - WithChannelRegistry publishes replyChan under name so
+//     downstream handlers can answer with messaging.Reply.
 func NewGateway[Req, Res any](name string, requestChan messaging.Channel[Req], replyChan messaging.Channel[Res], opts ...Option) Gateway[Req, Res] {
 	cassert.NotEmpty(name, "name is empty")
 	cassert.NotNil(requestChan, "request channel is nil")
@@ -70,6 +74,7 @@ func NewGateway[Req, Res any](name string, requestChan messaging.Channel[Req], r
 		uid:            options.uid,
 		requestTimeout: options.requestTimeout,
 		errorHandler:   options.errorHandler,
+		registry:       options.registry,
 		pending:        map[string]chan Res{},
 		done:           make(chan struct{}),
 	}
@@ -87,7 +92,9 @@ func (g *gateway[Req, Res]) Name() string {
 // reply channel. It satisfies the lifecycle.Component worker-style
 // contract: Start returns immediately after the subscription is in
 // place; the actual dispatching runs in the reply channel's goroutine
-// model. Start is idempotent — a second invocation returns nil
+// model. With WithChannelRegistry the reply channel is also
+// registered under the gateway's name; a name already taken fails
+// Start. Start is idempotent — a second invocation returns nil
 // without re-subscribing.
 func (g *gateway[Req, Res]) Start(_ context.Context) error {
 	cassert.NotNil(g, "gateway is nil")
@@ -95,8 +102,21 @@ func (g *gateway[Req, Res]) Start(_ context.Context) error {
 	var startErr error
 
 	g.startOnce.Do(func() {
+		if g.registry != nil {
+			err := g.registry.Register(g.name, g.replyChan)
+			if err != nil {
+				startErr = lifecycle.ErrStart(err)
+
+				return
+			}
+		}
+
 		cancel, err := g.replyChan.Subscribe(g.handleReply)
 		if err != nil {
+			if g.registry != nil {
+				g.registry.Unregister(g.name)
+			}
+
 			startErr = lifecycle.ErrStart(err)
 
 			return
@@ -114,11 +134,12 @@ func (g *gateway[Req, Res]) Start(_ context.Context) error {
 	return startErr
 }
 
-// Stop cancels the reply-channel subscription, fails every pending
-// Request with ErrGatewayShuttingDown, and closes Done. Stop is
-// idempotent per the lifecycle.Component contract. It returns
-// lifecycle.ErrShutdown wrapping lifecycle.ErrShutdownTimeout when ctx
-// is already expired on entry; otherwise nil.
+// Stop cancels the reply-channel subscription (and the
+// WithChannelRegistry registration), fails every pending Request with
+// ErrGatewayShuttingDown, and closes Done. Stop is idempotent per the
+// lifecycle.Component contract. It returns lifecycle.ErrShutdown
+// wrapping lifecycle.ErrShutdownTimeout when ctx is already expired on
+// entry; otherwise nil.
 func (g *gateway[Req, Res]) Stop(ctx context.Context) error {
 	cassert.NotNil(g, "gateway is nil")
 
@@ -130,6 +151,10 @@ func (g *gateway[Req, Res]) Stop(ctx context.Context) error {
 
 		if cancel != nil {
 			cancel()
+
+			if g.registry != nil {
+				g.registry.Unregister(g.name)
+			}
 		}
 
 		g.pendingMu.Lock()
@@ -183,6 +208,17 @@ func (g *gateway[Req, Res]) Done() <-chan struct{} {
 func (g *gateway[Req, Res]) Request(ctx context.Context, req Req) (Res, error) {
 	cassert.NotNil(g, "gateway is nil")
 
+	return g.RequestMessage(ctx, messaging.Message[Req]{Payload: req})
+}
+
+// RequestMessage is Request for a caller-built envelope. The headers
+// of msg are preserved except CorrelationID and ReplyTo, which the
+// gateway owns; MessageID defaults to the correlation id and
+// Timestamp to time.Now() when left empty. It returns the same errors
+// as Request.
+func (g *gateway[Req, Res]) RequestMessage(ctx context.Context, msg messaging.Message[Req]) (Res, error) {
+	cassert.NotNil(g, "gateway is nil")
+
 	var zero Res
 
 	if ctx == nil {
@@ -205,22 +241,56 @@ func (g *gateway[Req, Res]) Request(ctx context.Context, req Req) (Res, error) {
 
 	defer g.unregister(corrID)
 
-	msg := messaging.Message[Req]{
-		Payload: req,
-		Headers: messaging.Headers{
-			MessageID:     corrID,
-			CorrelationID: corrID,
-			ReplyTo:       g.name,
-			Timestamp:     time.Now(),
-		},
+	msg.Headers.CorrelationID = corrID
+	msg.Headers.ReplyTo = g.name
+
+	if msg.Headers.MessageID == "" {
+		msg.Headers.MessageID = corrID
+	}
+
+	if msg.Headers.Timestamp.IsZero() {
+		msg.Headers.Timestamp = time.Now()
 	}
 
 	err = g.requestChan.Send(ctx, msg)
 	if err != nil {
+		g.stats.RecordFailure()
+
 		return zero, ErrGateway(ErrRequestSendFailed, err)
 	}
 
-	return g.wait(ctx, respCh)
+	g.stats.RecordSent()
+
+	res, err := g.wait(ctx, respCh)
+	if err != nil {
+		g.stats.RecordFailure()
+	}
+
+	return res, err
+}
+
+// Stats returns a snapshot of the gateway's runtime statistics: Sent
+// counts requests published, Delivered the replies routed back to a
+// waiting caller, Failed the requests that could not be sent or timed
+// out, and Dropped the replies with an unknown CorrelationID. InFlight
+// is the number of Requests awaiting their reply. Subscribers is 1
+// while the reply subscription is active.
+func (g *gateway[Req, Res]) Stats() messaging.Stats {
+	cassert.NotNil(g, "gateway is nil")
+
+	stats := g.stats.Snapshot()
+
+	g.pendingMu.Lock()
+	stats.InFlight = len(g.pending)
+	g.pendingMu.Unlock()
+
+	g.mu.Lock()
+	if g.cancel != nil {
+		stats.Subscribers = 1
+	}
+	g.mu.Unlock()
+
+	return stats
 }
 
 // register installs a buffered waiter chan under corrID in the pending
@@ -308,11 +378,15 @@ func (g *gateway[Req, Res]) handleReply(ctx context.Context, msg messaging.Messa
 	g.pendingMu.Unlock()
 
 	if !ok {
+		g.stats.RecordDrop()
 		g.report(ctx, msg, ErrGateway(ErrUnknownCorrelationID))
 
 		return nil
 	}
 
+	end := g.stats.Begin()
+	defer end(nil)
+
 	// The waiter chan is buffered (size 1) so this send never blocks.
 	ch <- msg.Payload
 
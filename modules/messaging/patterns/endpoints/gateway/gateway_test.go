@@ -190,6 +190,109 @@ func TestGateway_HappyPath(t *testing.T) {
 	})
 }
 
+func TestGateway_RequestMessage(t *testing.T) {
+	t.Parallel()
+
+	t.Run("preserves caller headers and overwrites correlation", func(t *testing.T) {
+		t.Parallel()
+
+		req := messaging.NewPipelineChannel[int]()
+		rep := messaging.NewPipelineChannel[int]()
+
+		var seen messaging.Headers
+
+		_, err := req.Subscribe(func(ctx context.Context, msg messaging.Message[int]) error {
+			seen = msg.Headers
+
+			return rep.Send(ctx, messaging.Message[int]{
+				Payload: msg.Payload + 1,
+				Headers: messaging.Headers{CorrelationID: msg.Headers.CorrelationID},
+			})
+		})
+		if err != nil {
+			t.Fatalf("subscribe: %v", err)
+		}
+
+		g := NewGateway[int, int]("api-gw", req, rep, WithUIDGenerator(newCounterUID()))
+
+		err = g.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		t.Cleanup(func() { _ = g.Stop(context.Background()) })
+
+		res, err := g.RequestMessage(context.Background(), messaging.Message[int]{
+			Payload: 1,
+			Headers: messaging.Headers{
+				MessageID:     "caller-id",
+				CorrelationID: "ignored",
+				ReplyTo:       "ignored",
+				Type:          "order.created",
+				Custom:        map[string]any{"tenant": "acme"},
+			},
+		})
+		if err != nil {
+			t.Fatalf("request: %v", err)
+		}
+
+		if res != 2 {
+			t.Fatalf("expected res=2, got %d", res)
+		}
+
+		if seen.MessageID != "caller-id" || seen.Type != "order.created" || seen.Custom["tenant"] != "acme" {
+			t.Fatalf("caller headers not preserved: %+v", seen)
+		}
+
+		if seen.CorrelationID != "id-1" || seen.ReplyTo != "api-gw" {
+			t.Fatalf("expected gateway-owned CorrelationID/ReplyTo, got %q/%q", seen.CorrelationID, seen.ReplyTo)
+		}
+
+		if seen.Timestamp.IsZero() {
+			t.Fatal("expected Timestamp to be stamped")
+		}
+	})
+
+	t.Run("defaults MessageID to the correlation id", func(t *testing.T) {
+		t.Parallel()
+
+		req := messaging.NewPipelineChannel[int]()
+		rep := messaging.NewPipelineChannel[int]()
+
+		var seenID string
+
+		_, err := req.Subscribe(func(ctx context.Context, msg messaging.Message[int]) error {
+			seenID = msg.Headers.MessageID
+
+			return rep.Send(ctx, messaging.Message[int]{
+				Payload: msg.Payload,
+				Headers: messaging.Headers{CorrelationID: msg.Headers.CorrelationID},
+			})
+		})
+		if err != nil {
+			t.Fatalf("subscribe: %v", err)
+		}
+
+		g := NewGateway[int, int]("api-gw", req, rep, WithUIDGenerator(newCounterUID()))
+
+		err = g.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		t.Cleanup(func() { _ = g.Stop(context.Background()) })
+
+		_, err = g.RequestMessage(context.Background(), messaging.Message[int]{Payload: 1})
+		if err != nil {
+			t.Fatalf("request: %v", err)
+		}
+
+		if seenID != "id-1" {
+			t.Fatalf("expected MessageID id-1, got %q", seenID)
+		}
+	})
+}
+
 func TestGateway_Timeout(t *testing.T) {
 	t.Parallel()
 
@@ -657,6 +760,82 @@ func TestGateway_Lifecycle(t *testing.T) {
 	})
 }
 
+func TestGateway_ChannelRegistry(t *testing.T) {
+	t.Parallel()
+
+	t.Run("downstream replies through messaging.Reply", func(t *testing.T) {
+		t.Parallel()
+
+		registry := messaging.NewChannelRegistry()
+		req := messaging.NewPipelineChannel[int]()
+		rep := messaging.NewPipelineChannel[string]()
+
+		var causation string
+
+		_, err := req.Subscribe(func(ctx context.Context, msg messaging.Message[int]) error {
+			causation = msg.Headers.MessageID
+
+			return messaging.Reply(ctx, registry, msg, messaging.NewMessage(fmt.Sprintf("n=%d", msg.Payload), nil))
+		})
+		if err != nil {
+			t.Fatalf("subscribe: %v", err)
+		}
+
+		g := NewGateway[int, string]("orders-gw", req, rep,
+			WithUIDGenerator(newCounterUID()),
+			WithChannelRegistry(registry),
+		)
+
+		err = g.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		res, err := g.Request(context.Background(), 7)
+		if err != nil {
+			t.Fatalf("request: %v", err)
+		}
+
+		if res != "n=7" {
+			t.Fatalf("expected n=7, got %q", res)
+		}
+
+		if causation == "" {
+			t.Fatal("expected the request to carry a MessageID")
+		}
+
+		_ = g.Stop(context.Background())
+
+		_, err = registry.Resolve("orders-gw")
+		if !errors.Is(err, messaging.ErrChannelNotFound) {
+			t.Fatalf("expected the reply channel unregistered after Stop, got %v", err)
+		}
+	})
+
+	t.Run("Start fails when the name is taken", func(t *testing.T) {
+		t.Parallel()
+
+		registry := messaging.NewChannelRegistry()
+		req := messaging.NewPipelineChannel[int]()
+		rep := messaging.NewPipelineChannel[int]()
+
+		err := registry.Register("orders-gw", messaging.NewPipelineChannel[int]())
+		if err != nil {
+			t.Fatalf("register: %v", err)
+		}
+
+		g := NewGateway[int, int]("orders-gw", req, rep,
+			WithUIDGenerator(newCounterUID()),
+			WithChannelRegistry(registry),
+		)
+
+		err = g.Start(context.Background())
+		if !errors.Is(err, messaging.ErrChannelRegistered) {
+			t.Fatalf("expected ErrChannelRegistered, got %v", err)
+		}
+	})
+}
+
 func TestGateway_Options(t *testing.T) {
 	t.Parallel()
 
@@ -696,6 +875,15 @@ func TestGateway_Options(t *testing.T) {
 		}
 	})
 
+	t.Run("WithChannelRegistry(nil) is a no-op", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithChannelRegistry(nil))
+		if opts.registry != nil {
+			t.Fatal("expected registry unchanged on nil arg")
+		}
+	})
+
 	t.Run("defaults install messaging.DefaultErrorHandler and DefaultRequestTimeout", func(t *testing.T) {
 		t.Parallel()
 
@@ -723,3 +911,57 @@ func (c *failingChannel[T]) Send(_ context.Context, _ messaging.Message[T]) erro
 func (c *failingChannel[T]) Subscribe(_ messaging.Handler[T]) (messaging.Cancel, error) {
 	return func() {}, nil
 }
+
+func TestGateway_Stats(t *testing.T) {
+	t.Parallel()
+
+	req := messaging.NewPipelineChannel[int]()
+	rep := messaging.NewPipelineChannel[int]()
+
+	// Zero is black-holed so its Request times out.
+	_, err := req.Subscribe(func(ctx context.Context, msg messaging.Message[int]) error {
+		if msg.Payload == 0 {
+			return nil
+		}
+
+		return rep.Send(ctx, messaging.Message[int]{
+			Payload: msg.Payload,
+			Headers: messaging.Headers{CorrelationID: msg.Headers.CorrelationID},
+		})
+	})
+	if err != nil {
+		t.Fatalf("subscribe mock: %v", err)
+	}
+
+	g := NewGateway[int, int]("test", req, rep,
+		WithUIDGenerator(newCounterUID()),
+		WithRequestTimeout(50*time.Millisecond),
+		WithErrorHandler(messaging.SilentErrorHandler))
+
+	err = g.Start(context.Background())
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+
+	t.Cleanup(func() { _ = g.Stop(context.Background()) })
+
+	_, err = g.Request(context.Background(), 21)
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+
+	_, err = g.Request(context.Background(), 0)
+	if !errors.Is(err, ErrRequestTimeout) {
+		t.Fatalf("expected ErrRequestTimeout, got %v", err)
+	}
+
+	err = rep.Send(context.Background(), messaging.Message[int]{Headers: messaging.Headers{CorrelationID: "ghost-id"}})
+	if err != nil {
+		t.Fatalf("send stray reply: %v", err)
+	}
+
+	got := g.Stats()
+	if got.Subscribers != 1 || got.Sent != 2 || got.Delivered != 1 || got.Failed != 1 || got.Dropped != 1 || got.InFlight != 0 {
+		t.Fatalf("Stats = %+v, want Subscribers=1 Sent=2 Delivered=1 Failed=1 Dropped=1 InFlight=0", got)
+	}
+}
@@ -17,6 +17,7 @@ type Options struct {
 	errorHandler   messaging.ErrorHandler
 	uid            cuids.UID
 	requestTimeout time.Duration
+	registry       messaging.ChannelRegistry
 }
 
 // NewOptions creates a new Options with sensible defaults and applies
@@ -80,3 +81,16 @@ func WithRequestTimeout(d time.Duration) Option {
 		}
 	}
 }
+
+// WithChannelRegistry makes the Gateway register its reply channel in
+// registry under the Gateway's name on Start (and unregister it on
+// Stop). Since that name is the Headers.ReplyTo stamped on every
+// request, downstream handlers can answer with messaging.Reply
+// without being wired to the reply channel. Nil values are ignored.
+func WithChannelRegistry(registry messaging.ChannelRegistry) Option {
+	return func(opts *Options) {
+		if registry != nil {
+			opts.registry = registry
+		}
+	}
+}
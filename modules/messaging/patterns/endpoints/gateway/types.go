@@ -15,7 +15,9 @@
 // real work) MUST respect Headers.ReplyTo and Headers.CorrelationID and
 // publish its response to the named reply channel with the same
 // CorrelationID echoed back. Without this contract the Gateway cannot
-// correlate responses and every Request will time out.
+// correlate responses and every Request will time out. With
+// WithChannelRegistry the reply channel is registered under the
+// gateway name, and messaging.Reply fulfils the contract in one call.
 //
 // Replies arriving for an unknown CorrelationID are silently dropped
 // (they came too late, after the originating Request returned). This
@@ -49,6 +51,7 @@ import (
 	"time"
 
 	"github.com/guidomantilla/yarumo/core/common/lifecycle"
+	"github.com/guidomantilla/yarumo/messaging"
 )
 
 // DefaultRequestTimeout is the default per-Request timeout applied when
@@ -64,10 +67,12 @@ var (
 )
 
 // Gateway is the public interface for a Messaging Gateway. It embeds
-// lifecycle.Component so callers wire it up with lifecycle.Build, and
-// exposes Request as the synchronous request-reply entry point.
+// lifecycle.Component so callers wire it up with lifecycle.Build and
+// messaging.Inspectable to expose its runtime statistics, and exposes
+// Request as the synchronous request-reply entry point.
 type Gateway[Req, Res any] interface {
 	lifecycle.Component
+	messaging.Inspectable
 	// Request publishes req to the configured request channel with an
 	// auto-generated CorrelationID and Headers.ReplyTo = the Gateway's
 	// name, then waits for a Message[Res] on the reply channel whose
@@ -79,6 +84,11 @@ type Gateway[Req, Res any] interface {
 	// ErrRequestCancelled; gateway shutdown returns the zero Res and
 	// ErrGatewayShuttingDown.
 	Request(ctx context.Context, req Req) (Res, error)
+	// RequestMessage is Request for a caller-built envelope: every
+	// header of msg travels with the request except CorrelationID and
+	// ReplyTo, which the Gateway overwrites. Adapters that map inbound
+	// transport metadata onto Headers use it instead of Request.
+	RequestMessage(ctx context.Context, msg messaging.Message[Req]) (Res, error)
 }
 
 // ErrGatewayFn is the function type for ErrGateway.
@@ -0,0 +1,60 @@
+package guarded
+
+import (
+	"errors"
+	"fmt"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+	cerrs "github.com/guidomantilla/yarumo/core/common/errs"
+)
+
+// GuardedType is the error domain identifier for guarded operations.
+const GuardedType = "guarded"
+
+var (
+	_ error = (*Error)(nil)
+)
+
+// Sentinel errors for guarded operations.
+var (
+	// ErrGuardedFailed is the top-level sentinel embedded in every
+	// guarded-domain Error returned by ErrGuarded.
+	ErrGuardedFailed = errors.New("guarded failed")
+	// ErrHandlerFailed indicates that the downstream handler returned a
+	// non-nil error through the breaker.
+	ErrHandlerFailed = errors.New("guarded handler failed")
+	// ErrHandlerPanic indicates that the downstream handler panicked.
+	// Reported joined with ErrHandlerFailed.
+	ErrHandlerPanic = errors.New("guarded handler panicked")
+	// ErrRerouteFailed indicates that the fallback Channel.Send
+	// returned a non-nil error for a message the breaker rejected.
+	ErrRerouteFailed = errors.New("reroute to fallback failed")
+	// ErrParkedAtStop indicates that a message was still parked when
+	// the Guarded stopped, so it was never handled.
+	ErrParkedAtStop = errors.New("message still parked at stop")
+)
+
+// Error is the domain error type for guarded operations.
+type Error struct {
+	cerrs.TypedError
+}
+
+// Error returns the formatted error string including the type
+// classification.
+func (e *Error) Error() string {
+	cassert.NotNil(e, "error is nil")
+	cassert.NotNil(e.Err, "internal error is nil")
+
+	return fmt.Sprintf("guarded %s error: %s", e.Type, e.Err)
+}
+
+// ErrGuarded wraps the given causes into a domain Error joined with
+// ErrGuardedFailed.
+func ErrGuarded(causes ...error) error {
+	return &Error{
+		TypedError: cerrs.TypedError{
+			Type: GuardedType,
+			Err:  errors.Join(append(causes, ErrGuardedFailed)...),
+		},
+	}
+}
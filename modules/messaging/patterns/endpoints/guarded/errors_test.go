@@ -0,0 +1,49 @@
+package guarded
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestError_Error(t *testing.T) {
+	t.Parallel()
+
+	t.Run("includes type prefix and joined causes", func(t *testing.T) {
+		t.Parallel()
+
+		err := ErrGuarded(ErrHandlerFailed)
+
+		msg := err.Error()
+		if !strings.HasPrefix(msg, "guarded "+GuardedType) {
+			t.Fatalf("expected prefix %q, got %q", "guarded "+GuardedType, msg)
+		}
+
+		if !strings.Contains(msg, ErrHandlerFailed.Error()) {
+			t.Fatalf("expected cause %q in message, got %q", ErrHandlerFailed.Error(), msg)
+		}
+
+		if !strings.Contains(msg, ErrGuardedFailed.Error()) {
+			t.Fatalf("expected sentinel %q in message, got %q", ErrGuardedFailed.Error(), msg)
+		}
+	})
+
+	t.Run("ErrGuarded joins all causes with ErrGuardedFailed", func(t *testing.T) {
+		t.Parallel()
+
+		boom := errors.New("custom failure")
+
+		err := ErrGuarded(ErrHandlerFailed, boom)
+		if !errors.Is(err, ErrGuardedFailed) {
+			t.Fatal("expected ErrGuardedFailed in chain")
+		}
+
+		if !errors.Is(err, ErrHandlerFailed) {
+			t.Fatal("expected ErrHandlerFailed in chain")
+		}
+
+		if !errors.Is(err, boom) {
+			t.Fatal("expected origin error in chain")
+		}
+	})
+}
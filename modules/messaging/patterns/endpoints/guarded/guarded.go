@@ -0,0 +1,384 @@
+package guarded
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+	"github.com/guidomantilla/yarumo/core/common/lifecycle"
+	cbreaker "github.com/guidomantilla/yarumo/core/common/resilience/breaker"
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+// guarded is the Guarded implementation. It owns a single subscription
+// on the source channel (registered in Start, cancelled in Stop) plus a
+// retry goroutine that replays parked messages. stopCtx is the context
+// replays run under; it is cancelled in Stop to end the goroutine.
+type guarded[T any] struct {
+	name          string
+	src           messaging.Channel[T]
+	handler       messaging.Handler[T]
+	breaker       cbreaker.Breaker
+	fallback      messaging.Channel[T]
+	parkCapacity  int
+	retryInterval time.Duration
+	errorHandler  messaging.ErrorHandler
+	dropHandler   DropHandler
+	clock         messaging.Clock
+	stats         messaging.StatsRecorder
+
+	parkMu sync.Mutex
+	parked []messaging.Message[T]
+
+	stopCtx    context.Context
+	stopCancel context.CancelFunc
+	retryWG    sync.WaitGroup
+
+	done      chan struct{}
+	startOnce sync.Once
+	stopOnce  sync.Once
+	doneOnce  sync.Once
+
+	mu     sync.Mutex
+	cancel messaging.Cancel
+}
+
+// NewGuarded constructs a Guarded endpoint that subscribes to src and
+// invokes handler for each Message[T] through breaker. Messages the
+// breaker rejects are parked and replayed once it closes, or rerouted
+// when WithFallback is configured. The endpoint is not running on
+// return; call lifecycle.Build (or Start directly) to register the
+// subscription and spawn the retry goroutine.
+//
+// name is used in lifecycle logs and must be non-empty. src, handler
+// and breaker are mandatory; breaker is caller-owned, so it may also
+// guard other calls to the same downstream. Optional behaviors:
+//
+//   - WithFallback reroutes rejected messages instead of parking them.
+//   - WithParkCapacity bounds the park (default DefaultParkCapacity).
+//   - WithRetryInterval tunes the replay cadence (default
+//     DefaultRetryInterval), measured on WithClock.
+//   - WithErrorHandler overrides the default
+//     messaging.DefaultErrorHandler (which logs via common/log) with a
+//     custom hook for handler and fallback Send failures and for the
+//     messages still parked at Stop.
+//   - WithDropHandler installs an optional hook for observing park
+//     overflows; nil by default.
+func NewGuarded[T any](name string, src messaging.Channel[T], handler messaging.Handler[T], breaker cbreaker.Breaker, opts ...Option[T]) Guarded[T] {
+	cassert.NotEmpty(name, "name is empty")
+	cassert.NotNil(src, "source channel is nil")
+	cassert.NotNil(handler, "handler is nil")
+	cassert.NotNil(breaker, "breaker is nil")
+
+	options := NewOptions(opts...)
+	stopCtx, stopCancel := context.WithCancel(context.Background())
+
+	g := &guarded[T]{
+		name:          name,
+		src:           src,
+		handler:       handler,
+		breaker:       breaker,
+		fallback:      options.fallback,
+		parkCapacity:  options.parkCapacity,
+		retryInterval: options.retryInterval,
+		errorHandler:  options.errorHandler,
+		dropHandler:   options.dropHandler,
+		clock:         options.clock,
+		stopCtx:       stopCtx,
+		stopCancel:    stopCancel,
+		done:          make(chan struct{}),
+	}
+
+	g.stats.UseClock(options.clock)
+
+	return g
+}
+
+// Name returns the guarded endpoint's identity used in lifecycle logs.
+func (g *guarded[T]) Name() string {
+	cassert.NotNil(g, "guarded is nil")
+
+	return g.name
+}
+
+// Start registers the guarded handler as a subscriber on the source
+// channel and spawns the retry goroutine. It satisfies the
+// lifecycle.Component worker-style contract: Start returns immediately
+// after the subscription is in place. Start is idempotent — a second
+// invocation returns nil without re-subscribing.
+func (g *guarded[T]) Start(_ context.Context) error {
+	cassert.NotNil(g, "guarded is nil")
+
+	var startErr error
+
+	g.startOnce.Do(func() {
+		cancel, err := g.src.Subscribe(g.handle)
+		if err != nil {
+			startErr = lifecycle.ErrStart(err)
+
+			return
+		}
+
+		g.mu.Lock()
+		g.cancel = cancel
+		g.mu.Unlock()
+
+		g.retryWG.Go(g.retry)
+	})
+
+	return startErr
+}
+
+// Stop cancels the source-channel subscription, stops the retry
+// goroutine, reports every still-parked message to the ErrorHandler
+// with ErrParkedAtStop, and closes Done. Stop is idempotent per the
+// lifecycle.Component contract. It returns lifecycle.ErrShutdown
+// wrapping lifecycle.ErrShutdownTimeout when ctx is expired once the
+// retry goroutine has exited; otherwise nil.
+func (g *guarded[T]) Stop(ctx context.Context) error {
+	cassert.NotNil(g, "guarded is nil")
+
+	g.stopOnce.Do(func() {
+		g.mu.Lock()
+		cancel := g.cancel
+		g.cancel = nil
+		g.mu.Unlock()
+
+		if cancel != nil {
+			cancel()
+		}
+
+		g.stopCancel()
+		g.retryWG.Wait()
+
+		g.drainAll(ctx)
+
+		g.doneOnce.Do(func() { close(g.done) })
+	})
+
+	select {
+	case <-ctx.Done():
+		return lifecycle.ErrShutdown(lifecycle.ErrShutdownTimeout, ctx.Err())
+	default:
+		return nil
+	}
+}
+
+// Done returns the channel that is closed after Stop has been called.
+func (g *guarded[T]) Done() <-chan struct{} {
+	cassert.NotNil(g, "guarded is nil")
+
+	return g.done
+}
+
+// Stats returns a snapshot of the guarded endpoint's runtime
+// statistics. Subscribers is 1 while the source subscription is
+// active; BufferLength and BufferCapacity report the park (both zero
+// when WithFallback is configured).
+func (g *guarded[T]) Stats() messaging.Stats {
+	cassert.NotNil(g, "guarded is nil")
+
+	stats := g.stats.Snapshot()
+	if g.fallback == nil {
+		stats.BufferLength = g.Parked()
+		stats.BufferCapacity = g.parkCapacity
+	}
+
+	g.mu.Lock()
+	if g.cancel != nil {
+		stats.Subscribers = 1
+	}
+	g.mu.Unlock()
+
+	return stats
+}
+
+// Parked returns the number of messages currently parked.
+func (g *guarded[T]) Parked() int {
+	cassert.NotNil(g, "guarded is nil")
+
+	g.parkMu.Lock()
+	defer g.parkMu.Unlock()
+
+	return len(g.parked)
+}
+
+// handle is the Handler[T] subscribed on the source channel. It
+// invokes the downstream handler through the breaker and reroutes or
+// parks the message when the breaker rejects it. The function itself
+// always returns nil so guarded concerns never propagate to the source
+// channel's Send caller.
+func (g *guarded[T]) handle(ctx context.Context, msg messaging.Message[T]) error {
+	end := g.stats.Begin()
+	defer end(nil)
+
+	err := g.invoke(ctx, msg)
+	if rejected(err) {
+		g.reject(ctx, msg)
+
+		return nil
+	}
+
+	if err != nil {
+		g.reportError(ctx, msg, ErrGuarded(ErrHandlerFailed, err))
+
+		return nil
+	}
+
+	g.stats.RecordSent()
+
+	return nil
+}
+
+// invoke runs the downstream handler for msg through the breaker
+// under panic recovery, so a panicking handler cannot take down the
+// retry goroutine. Panics become errors wrapping ErrHandlerPanic, which
+// callers report as handler failures.
+func (g *guarded[T]) invoke(ctx context.Context, msg messaging.Message[T]) (err error) {
+	defer func() {
+		rec := recover()
+		if rec == nil {
+			return
+		}
+
+		err = fmt.Errorf("%w: %v", ErrHandlerPanic, rec)
+	}()
+
+	return g.breaker.Execute(ctx, func() error {
+		return g.handler(ctx, msg)
+	})
+}
+
+// reject reroutes msg to the fallback channel when one is configured,
+// and parks it otherwise. A full park drops msg.
+func (g *guarded[T]) reject(ctx context.Context, msg messaging.Message[T]) {
+	if g.fallback != nil {
+		err := g.fallback.Send(ctx, msg)
+		if err != nil {
+			g.reportError(ctx, msg, ErrGuarded(ErrRerouteFailed, err))
+
+			return
+		}
+
+		g.stats.RecordSent()
+
+		return
+	}
+
+	g.parkMu.Lock()
+	full := len(g.parked) >= g.parkCapacity
+	if !full {
+		g.parked = append(g.parked, msg)
+	}
+	g.parkMu.Unlock()
+
+	if full {
+		g.reportDrop(ctx, msg)
+	}
+}
+
+// retry is the replay goroutine. It wakes every retryInterval on the
+// configured clock and replays the parked messages. The goroutine
+// exits when stopCtx is cancelled (Stop).
+func (g *guarded[T]) retry() {
+	ticker := g.clock.NewTicker(g.retryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-g.stopCtx.Done():
+			return
+		case <-ticker.C():
+			g.replay()
+		}
+	}
+}
+
+// replay invokes the handler for parked messages in arrival order
+// while the breaker is not open. A message the breaker rejects again
+// goes back to the head of the park and ends the pass; a message the
+// handler fails is reported and not parked again.
+func (g *guarded[T]) replay() {
+	for g.stopCtx.Err() == nil && g.breaker.State() != cbreaker.StateOpen {
+		g.parkMu.Lock()
+		if len(g.parked) == 0 {
+			g.parkMu.Unlock()
+
+			return
+		}
+
+		msg := g.parked[0]
+		g.parked = g.parked[1:]
+		g.parkMu.Unlock()
+
+		err := g.invoke(g.stopCtx, msg)
+		if rejected(err) {
+			g.parkMu.Lock()
+			g.parked = append([]messaging.Message[T]{msg}, g.parked...)
+			g.parkMu.Unlock()
+
+			return
+		}
+
+		if err != nil {
+			g.reportError(g.stopCtx, msg, ErrGuarded(ErrHandlerFailed, err))
+
+			continue
+		}
+
+		g.stats.RecordSent()
+	}
+}
+
+// drainAll empties the park (Stop). Every still-parked message is
+// reported to the ErrorHandler with ErrParkedAtStop — it was accepted
+// but never handled, so unlike a park overflow it must not vanish
+// through the silent-by-default drop hook. drainAll is called from
+// Stop after the retry goroutine has exited, so no race against a
+// concurrent replay.
+func (g *guarded[T]) drainAll(ctx context.Context) {
+	g.parkMu.Lock()
+	pending := g.parked
+	g.parked = nil
+	g.parkMu.Unlock()
+
+	for _, msg := range pending {
+		g.reportError(ctx, msg, ErrGuarded(ErrParkedAtStop))
+	}
+}
+
+// reportError forwards err to the configured ErrorHandler.
+// ErrorHandler is guaranteed non-nil by NewOptions, so the nil-guard
+// is defensive only.
+func (g *guarded[T]) reportError(ctx context.Context, msg messaging.Message[T], err error) {
+	g.stats.RecordFailure()
+
+	if g.errorHandler == nil {
+		return
+	}
+
+	g.errorHandler(ctx, msg, err)
+}
+
+// reportDrop forwards msg to the configured DropHandler. DropHandler
+// is nil by default (silent drops); the guard skips invocation in that
+// case.
+func (g *guarded[T]) reportDrop(ctx context.Context, msg messaging.Message[T]) {
+	g.stats.RecordDrop()
+
+	if g.dropHandler == nil {
+		return
+	}
+
+	g.dropHandler(ctx, msg)
+}
+
+// rejected reports whether err is the breaker refusing the call
+// without invoking the handler (open, or half-open beyond the probe
+// budget).
+func rejected(err error) bool {
+	return errors.Is(err, cbreaker.ErrBreakerOpen) || errors.Is(err, cbreaker.ErrBreakerTooManyRequests)
+}
@@ -0,0 +1,841 @@
+package guarded
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/guidomantilla/yarumo/core/common/lifecycle"
+	cbreaker "github.com/guidomantilla/yarumo/core/common/resilience/breaker"
+	"github.com/guidomantilla/yarumo/messaging"
+	"github.com/guidomantilla/yarumo/messaging/messagingtest"
+)
+
+// captureErrors returns a thread-safe ErrorHandler that appends every
+// reported error, and a getter that returns a defensive copy.
+func captureErrors() (messaging.ErrorHandler, func() []error) {
+	var mu sync.Mutex
+
+	captured := []error{}
+
+	handler := func(_ context.Context, _ any, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		captured = append(captured, err)
+	}
+
+	get := func() []error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		out := make([]error, len(captured))
+		copy(out, captured)
+
+		return out
+	}
+
+	return handler, get
+}
+
+// captureDrops returns a thread-safe DropHandler that appends every
+// dropped message, and a getter that returns a defensive copy.
+func captureDrops() (DropHandler, func() []any) {
+	var mu sync.Mutex
+
+	captured := []any{}
+
+	handler := func(_ context.Context, msg any) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		captured = append(captured, msg)
+	}
+
+	get := func() []any {
+		mu.Lock()
+		defer mu.Unlock()
+
+		out := make([]any, len(captured))
+		copy(out, captured)
+
+		return out
+	}
+
+	return handler, get
+}
+
+// capturePayloads returns a thread-safe Handler[int] that records the
+// payload of every invocation and returns err, and a getter that
+// returns a defensive copy.
+func capturePayloads(err error) (messaging.Handler[int], func() []int) {
+	var mu sync.Mutex
+
+	captured := []int{}
+
+	handler := func(_ context.Context, msg messaging.Message[int]) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		captured = append(captured, msg.Payload)
+
+		return err
+	}
+
+	get := func() []int {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return slices.Clone(captured)
+	}
+
+	return handler, get
+}
+
+// fakeBreaker is a Breaker test double whose state is set by the test.
+// Open breakers reject with ErrBreakerOpen; when reject is set, every
+// call is rejected with it regardless of the reported state.
+type fakeBreaker struct {
+	state  atomic.Int32
+	reject atomic.Pointer[error]
+}
+
+func (b *fakeBreaker) set(state cbreaker.State) {
+	b.state.Store(int32(state))
+}
+
+func (b *fakeBreaker) Execute(_ context.Context, fn func() error) error {
+	reject := b.reject.Load()
+	if reject != nil {
+		return cbreaker.ErrBreaker(*reject)
+	}
+
+	if b.State() == cbreaker.StateOpen {
+		return cbreaker.ErrBreaker(cbreaker.ErrBreakerOpen)
+	}
+
+	err := fn()
+	if err != nil {
+		return cbreaker.ErrBreaker(err)
+	}
+
+	return nil
+}
+
+func (b *fakeBreaker) State() cbreaker.State {
+	return cbreaker.State(b.state.Load())
+}
+
+// waitFor polls cond until it holds or a second elapses.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestNewGuarded(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns non-nil component", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		h, _ := capturePayloads(nil)
+
+		g := NewGuarded("test", src, h, &fakeBreaker{})
+		if g == nil {
+			t.Fatal("expected non-nil component")
+		}
+	})
+
+	t.Run("carries the given name", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		h, _ := capturePayloads(nil)
+
+		g := NewGuarded("payments-guard", src, h, &fakeBreaker{})
+		if got, want := g.Name(), "payments-guard"; got != want {
+			t.Fatalf("Name() = %q, want %q", got, want)
+		}
+	})
+}
+
+func TestGuarded_Closed(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invokes the handler through the breaker", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		h, get := capturePayloads(nil)
+
+		g := NewGuarded("test", src, h, &fakeBreaker{})
+
+		err := g.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		t.Cleanup(func() { _ = g.Stop(context.Background()) })
+
+		for i := range 3 {
+			err = src.Send(context.Background(), messaging.Message[int]{Payload: i})
+			if err != nil {
+				t.Fatalf("send %d: %v", i, err)
+			}
+		}
+
+		if got := get(); !slices.Equal(got, []int{0, 1, 2}) {
+			t.Fatalf("handled = %v, want [0 1 2]", got)
+		}
+	})
+
+	t.Run("handler failure is reported and not parked", func(t *testing.T) {
+		t.Parallel()
+
+		boom := errors.New("downstream down")
+
+		src := messaging.NewPipelineChannel[int]()
+		h, _ := capturePayloads(boom)
+
+		errHandler, getErrs := captureErrors()
+
+		g := NewGuarded("test", src, h, &fakeBreaker{}, WithErrorHandler[int](errHandler))
+
+		err := g.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		t.Cleanup(func() { _ = g.Stop(context.Background()) })
+
+		err = src.Send(context.Background(), messaging.Message[int]{Payload: 1})
+		if err != nil {
+			t.Fatalf("expected nil from src.Send (guarded swallows), got %v", err)
+		}
+
+		errs := getErrs()
+		if len(errs) != 1 {
+			t.Fatalf("expected 1 captured error, got %d", len(errs))
+		}
+
+		if !errors.Is(errs[0], ErrHandlerFailed) || !errors.Is(errs[0], boom) {
+			t.Fatalf("expected ErrHandlerFailed wrapping origin, got %v", errs[0])
+		}
+
+		if got := g.Parked(); got != 0 {
+			t.Fatalf("Parked() = %d, want 0", got)
+		}
+	})
+}
+
+func TestGuarded_Fallback(t *testing.T) {
+	t.Parallel()
+
+	t.Run("open breaker reroutes to the fallback channel", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		fallback := messaging.NewPipelineChannel[int]()
+
+		h, handled := capturePayloads(nil)
+		fh, rerouted := capturePayloads(nil)
+
+		_, err := fallback.Subscribe(fh)
+		if err != nil {
+			t.Fatalf("subscribe: %v", err)
+		}
+
+		breaker := &fakeBreaker{}
+		breaker.set(cbreaker.StateOpen)
+
+		g := NewGuarded("test", src, h, breaker, WithFallback(fallback))
+
+		err = g.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		t.Cleanup(func() { _ = g.Stop(context.Background()) })
+
+		err = src.Send(context.Background(), messaging.Message[int]{Payload: 7})
+		if err != nil {
+			t.Fatalf("send: %v", err)
+		}
+
+		if got := handled(); len(got) != 0 {
+			t.Fatalf("handler must not run while open, got %v", got)
+		}
+
+		if got := rerouted(); !slices.Equal(got, []int{7}) {
+			t.Fatalf("rerouted = %v, want [7]", got)
+		}
+
+		if got := g.Parked(); got != 0 {
+			t.Fatalf("Parked() = %d, want 0", got)
+		}
+	})
+
+	t.Run("fallback Send failure reports ErrRerouteFailed", func(t *testing.T) {
+		t.Parallel()
+
+		boom := errors.New("fallback down")
+
+		src := messaging.NewPipelineChannel[int]()
+		h, _ := capturePayloads(nil)
+
+		errHandler, getErrs := captureErrors()
+
+		breaker := &fakeBreaker{}
+		breaker.set(cbreaker.StateOpen)
+
+		g := NewGuarded("test", src, h, breaker,
+			WithFallback[int](&failingChannel[int]{err: boom}),
+			WithErrorHandler[int](errHandler),
+		)
+
+		err := g.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		t.Cleanup(func() { _ = g.Stop(context.Background()) })
+
+		err = src.Send(context.Background(), messaging.Message[int]{Payload: 1})
+		if err != nil {
+			t.Fatalf("send: %v", err)
+		}
+
+		errs := getErrs()
+		if len(errs) != 1 || !errors.Is(errs[0], ErrRerouteFailed) || !errors.Is(errs[0], boom) {
+			t.Fatalf("expected one ErrRerouteFailed wrapping origin, got %v", errs)
+		}
+	})
+}
+
+func TestGuarded_Park(t *testing.T) {
+	t.Parallel()
+
+	t.Run("parked messages are replayed in order once the breaker closes", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		h, get := capturePayloads(nil)
+
+		breaker := &fakeBreaker{}
+		breaker.set(cbreaker.StateOpen)
+
+		g := NewGuarded("test", src, h, breaker, WithRetryInterval[int](time.Millisecond))
+
+		err := g.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		t.Cleanup(func() { _ = g.Stop(context.Background()) })
+
+		for i := range 3 {
+			err = src.Send(context.Background(), messaging.Message[int]{Payload: i})
+			if err != nil {
+				t.Fatalf("send %d: %v", i, err)
+			}
+		}
+
+		if got := g.Parked(); got != 3 {
+			t.Fatalf("Parked() = %d, want 3", got)
+		}
+
+		if got := get(); len(got) != 0 {
+			t.Fatalf("handler must not run while open, got %v", got)
+		}
+
+		breaker.set(cbreaker.StateClosed)
+
+		waitFor(t, "park to drain", func() bool { return g.Parked() == 0 })
+
+		if got := get(); !slices.Equal(got, []int{0, 1, 2}) {
+			t.Fatalf("replayed = %v, want [0 1 2]", got)
+		}
+	})
+
+	t.Run("replay ticks on the configured clock and recovers handler panics", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		clock := messagingtest.NewFakeClock(time.Time{})
+		errHandler, getErrs := captureErrors()
+
+		h := func(_ context.Context, _ messaging.Message[int]) error {
+			panic("replay boom")
+		}
+
+		breaker := &fakeBreaker{}
+		breaker.set(cbreaker.StateOpen)
+
+		g := NewGuarded("test", src, h, breaker,
+			WithRetryInterval[int](time.Minute),
+			WithClock[int](clock),
+			WithErrorHandler[int](errHandler),
+		)
+
+		err := g.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		t.Cleanup(func() { _ = g.Stop(context.Background()) })
+
+		err = src.Send(context.Background(), messaging.Message[int]{Payload: 1})
+		if err != nil {
+			t.Fatalf("send: %v", err)
+		}
+
+		breaker.set(cbreaker.StateClosed)
+		clock.WaitForTimers(t, 1)
+		clock.Advance(time.Minute)
+
+		waitFor(t, "panic to be reported", func() bool { return len(getErrs()) == 1 })
+
+		got := getErrs()[0]
+		if !errors.Is(got, ErrHandlerPanic) || !errors.Is(got, ErrHandlerFailed) {
+			t.Fatalf("expected ErrHandlerPanic joined with ErrHandlerFailed, got %v", got)
+		}
+
+		if g.Parked() != 0 {
+			t.Fatalf("Parked() = %d, want 0", g.Parked())
+		}
+	})
+
+	t.Run("half-open rejection parks the message", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		h, _ := capturePayloads(nil)
+
+		breaker := &fakeBreaker{}
+		breaker.set(cbreaker.StateHalfOpen)
+
+		tooMany := cbreaker.ErrBreakerTooManyRequests
+		breaker.reject.Store(&tooMany)
+
+		errHandler, getErrs := captureErrors()
+
+		g := NewGuarded("test", src, h, breaker, WithErrorHandler[int](errHandler))
+
+		err := g.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		t.Cleanup(func() { _ = g.Stop(context.Background()) })
+
+		err = src.Send(context.Background(), messaging.Message[int]{Payload: 1})
+		if err != nil {
+			t.Fatalf("send: %v", err)
+		}
+
+		if got := g.Parked(); got != 1 {
+			t.Fatalf("Parked() = %d, want 1", got)
+		}
+
+		if errs := getErrs(); len(errs) != 0 {
+			t.Fatalf("rejections must not be reported as failures, got %v", errs)
+		}
+	})
+
+	t.Run("message rejected again on replay stays parked", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		h, get := capturePayloads(nil)
+
+		breaker := &fakeBreaker{}
+		breaker.set(cbreaker.StateOpen)
+
+		g := NewGuarded("test", src, h, breaker, WithRetryInterval[int](time.Millisecond))
+
+		err := g.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		t.Cleanup(func() { _ = g.Stop(context.Background()) })
+
+		for i := range 2 {
+			err = src.Send(context.Background(), messaging.Message[int]{Payload: i})
+			if err != nil {
+				t.Fatalf("send %d: %v", i, err)
+			}
+		}
+
+		tooMany := cbreaker.ErrBreakerTooManyRequests
+		breaker.reject.Store(&tooMany)
+		breaker.set(cbreaker.StateHalfOpen)
+
+		time.Sleep(10 * time.Millisecond)
+
+		if got := g.Parked(); got != 2 {
+			t.Fatalf("Parked() = %d, want 2", got)
+		}
+
+		breaker.reject.Store(nil)
+		breaker.set(cbreaker.StateClosed)
+
+		waitFor(t, "park to drain", func() bool { return g.Parked() == 0 })
+
+		if got := get(); !slices.Equal(got, []int{0, 1}) {
+			t.Fatalf("replayed = %v, want [0 1]", got)
+		}
+	})
+
+	t.Run("full park drops the message", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		h, _ := capturePayloads(nil)
+
+		dropHandler, getDrops := captureDrops()
+
+		breaker := &fakeBreaker{}
+		breaker.set(cbreaker.StateOpen)
+
+		g := NewGuarded("test", src, h, breaker,
+			WithParkCapacity[int](1),
+			WithDropHandler[int](dropHandler),
+		)
+
+		err := g.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		t.Cleanup(func() { _ = g.Stop(context.Background()) })
+
+		for i := range 2 {
+			err = src.Send(context.Background(), messaging.Message[int]{Payload: i})
+			if err != nil {
+				t.Fatalf("send %d: %v", i, err)
+			}
+		}
+
+		drops := getDrops()
+		if len(drops) != 1 || drops[0].(messaging.Message[int]).Payload != 1 {
+			t.Fatalf("expected the second message to be dropped, got %v", drops)
+		}
+
+		if got := g.Parked(); got != 1 {
+			t.Fatalf("Parked() = %d, want 1", got)
+		}
+	})
+
+	t.Run("Stop reports still-parked messages to the error handler", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		h, _ := capturePayloads(nil)
+
+		var (
+			mu       sync.Mutex
+			reported []int
+		)
+
+		errorHandler := func(_ context.Context, msg any, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			m, ok := msg.(messaging.Message[int])
+			if ok && errors.Is(err, ErrParkedAtStop) {
+				reported = append(reported, m.Payload)
+			}
+		}
+
+		dropHandler, getDrops := captureDrops()
+
+		breaker := &fakeBreaker{}
+		breaker.set(cbreaker.StateOpen)
+
+		g := NewGuarded("test", src, h, breaker,
+			WithErrorHandler[int](errorHandler),
+			WithDropHandler[int](dropHandler))
+
+		err := g.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		for i := range 2 {
+			err = src.Send(context.Background(), messaging.Message[int]{Payload: i})
+			if err != nil {
+				t.Fatalf("send %d: %v", i, err)
+			}
+		}
+
+		err = g.Stop(context.Background())
+		if err != nil {
+			t.Fatalf("stop: %v", err)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		if len(reported) != 2 || reported[0] != 0 || reported[1] != 1 {
+			t.Fatalf("reported = %v, want [0 1]", reported)
+		}
+
+		if got := len(getDrops()); got != 0 {
+			t.Fatalf("drops = %d, want 0", got)
+		}
+
+		if got := g.Parked(); got != 0 {
+			t.Fatalf("Parked() = %d, want 0", got)
+		}
+	})
+}
+
+func TestGuarded_Lifecycle(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Start is idempotent", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		h, get := capturePayloads(nil)
+
+		g := NewGuarded("test", src, h, &fakeBreaker{})
+
+		err := g.Start(context.Background())
+		if err != nil {
+			t.Fatalf("first start: %v", err)
+		}
+
+		err = g.Start(context.Background())
+		if err != nil {
+			t.Fatalf("second start: %v", err)
+		}
+
+		t.Cleanup(func() { _ = g.Stop(context.Background()) })
+
+		err = src.Send(context.Background(), messaging.Message[int]{Payload: 1})
+		if err != nil {
+			t.Fatalf("send: %v", err)
+		}
+
+		if got := len(get()); got != 1 {
+			t.Fatalf("handler should run once (no double subscription), got %d", got)
+		}
+	})
+
+	t.Run("Stop is idempotent", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		h, _ := capturePayloads(nil)
+
+		g := NewGuarded("test", src, h, &fakeBreaker{})
+
+		err := g.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		err = g.Stop(context.Background())
+		if err != nil {
+			t.Fatalf("first stop: %v", err)
+		}
+
+		err = g.Stop(context.Background())
+		if err != nil {
+			t.Fatalf("second stop: %v", err)
+		}
+	})
+
+	t.Run("Done closes after Stop", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		h, _ := capturePayloads(nil)
+
+		g := NewGuarded("test", src, h, &fakeBreaker{})
+
+		err := g.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		select {
+		case <-g.Done():
+			t.Fatal("Done closed before Stop")
+		default:
+		}
+
+		err = g.Stop(context.Background())
+		if err != nil {
+			t.Fatalf("stop: %v", err)
+		}
+
+		select {
+		case <-g.Done():
+		default:
+			t.Fatal("Done not closed after Stop")
+		}
+	})
+
+	t.Run("Stop with expired ctx returns ErrShutdownTimeout", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		h, _ := capturePayloads(nil)
+
+		g := NewGuarded("test", src, h, &fakeBreaker{})
+
+		err := g.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err = g.Stop(ctx)
+		if !errors.Is(err, lifecycle.ErrShutdownTimeout) {
+			t.Fatalf("expected ErrShutdownTimeout, got %v", err)
+		}
+	})
+}
+
+func TestGuarded_Options(t *testing.T) {
+	t.Parallel()
+
+	t.Run("defaults", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions[int]()
+		if opts.errorHandler == nil {
+			t.Fatal("default error handler should be installed")
+		}
+
+		if opts.dropHandler != nil || opts.fallback != nil {
+			t.Fatal("default drop handler and fallback should be nil")
+		}
+
+		if opts.parkCapacity != DefaultParkCapacity || opts.retryInterval != DefaultRetryInterval {
+			t.Fatalf("unexpected defaults capacity=%d interval=%v", opts.parkCapacity, opts.retryInterval)
+		}
+
+		if opts.clock == nil {
+			t.Fatal("default clock should be installed")
+		}
+	})
+
+	t.Run("nil and non-positive values are ignored", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(
+			WithFallback[int](nil),
+			WithParkCapacity[int](0),
+			WithRetryInterval[int](-time.Second),
+			WithErrorHandler[int](nil),
+			WithDropHandler[int](nil),
+			WithClock[int](nil),
+		)
+
+		if opts.fallback != nil || opts.dropHandler != nil || opts.errorHandler == nil || opts.clock == nil {
+			t.Fatal("nil arguments should not override the defaults")
+		}
+
+		if opts.parkCapacity != DefaultParkCapacity || opts.retryInterval != DefaultRetryInterval {
+			t.Fatalf("non-positive values should be ignored, got capacity=%d interval=%v", opts.parkCapacity, opts.retryInterval)
+		}
+	})
+
+	t.Run("positive values are applied", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithParkCapacity[int](5), WithRetryInterval[int](time.Minute))
+		if opts.parkCapacity != 5 || opts.retryInterval != time.Minute {
+			t.Fatalf("got capacity=%d interval=%v", opts.parkCapacity, opts.retryInterval)
+		}
+	})
+}
+
+func TestGuarded_Stats(t *testing.T) {
+	t.Parallel()
+
+	src := messaging.NewPipelineChannel[int]()
+
+	var fail atomic.Bool
+
+	h := func(_ context.Context, _ messaging.Message[int]) error {
+		if fail.Load() {
+			return errors.New("down")
+		}
+
+		return nil
+	}
+
+	breaker := &fakeBreaker{}
+
+	g := NewGuarded("stats", src, h, breaker,
+		WithParkCapacity[int](1),
+		WithRetryInterval[int](time.Hour),
+		WithErrorHandler[int](messaging.SilentErrorHandler),
+	)
+
+	err := g.Start(context.Background())
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+
+	t.Cleanup(func() { _ = g.Stop(context.Background()) })
+
+	send := func(payload int) {
+		err := src.Send(context.Background(), messaging.Message[int]{Payload: payload})
+		if err != nil {
+			t.Fatalf("send: %v", err)
+		}
+	}
+
+	send(1)
+
+	fail.Store(true)
+	send(2)
+
+	breaker.set(cbreaker.StateOpen)
+	send(3)
+	send(4)
+
+	got := g.Stats()
+	if got.Subscribers != 1 || got.Delivered != 4 || got.Sent != 1 || got.Failed != 1 || got.Dropped != 1 {
+		t.Fatalf("Stats = %+v, want Subscribers=1 Delivered=4 Sent=1 Failed=1 Dropped=1", got)
+	}
+
+	if got.BufferLength != 1 || got.BufferCapacity != 1 {
+		t.Fatalf("Stats buffer = %d/%d, want 1/1", got.BufferLength, got.BufferCapacity)
+	}
+}
+
+// failingChannel is a Channel[T] test double whose Send always returns
+// the configured err. Subscribe is a no-op returning a no-op Cancel.
+type failingChannel[T any] struct {
+	err error
+}
+
+func (c *failingChannel[T]) Send(_ context.Context, _ messaging.Message[T]) error {
+	return c.err
+}
+
+func (c *failingChannel[T]) Subscribe(_ messaging.Handler[T]) (messaging.Cancel, error) {
+	return func() {}, nil
+}
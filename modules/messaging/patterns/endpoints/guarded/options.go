@@ -0,0 +1,123 @@
+package guarded
+
+import (
+	"time"
+
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+// Option is a functional option for configuring guarded Options.
+// Option is generic in T because WithFallback carries a T-typed
+// channel; keeping the Option type-parameterized lets WithFallback
+// match the constructor's T without an unsafe-any cast.
+type Option[T any] func(opts *Options[T])
+
+// Options holds the configuration for a Guarded endpoint.
+type Options[T any] struct {
+	fallback      messaging.Channel[T]
+	parkCapacity  int
+	retryInterval time.Duration
+	errorHandler  messaging.ErrorHandler
+	dropHandler   DropHandler
+	clock         messaging.Clock
+}
+
+// NewOptions creates a new Options with sensible defaults and applies
+// the given options. Defaults:
+//
+//   - Fallback: nil (rejected messages are parked).
+//   - Park capacity: DefaultParkCapacity.
+//   - Retry interval: DefaultRetryInterval.
+//   - ErrorHandler: messaging.DefaultErrorHandler (logs via common/log).
+//   - DropHandler: nil (intentional drops are silent unless wired).
+//   - Clock: messaging.SystemClock.
+func NewOptions[T any](opts ...Option[T]) *Options[T] {
+	options := &Options[T]{
+		fallback:      nil,
+		parkCapacity:  DefaultParkCapacity,
+		retryInterval: DefaultRetryInterval,
+		errorHandler:  messaging.DefaultErrorHandler,
+		dropHandler:   nil,
+		clock:         messaging.SystemClock(),
+	}
+
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	return options
+}
+
+// WithFallback reroutes every message the breaker rejects to ch
+// instead of parking it. Nil values are ignored (rejected messages
+// keep being parked).
+func WithFallback[T any](ch messaging.Channel[T]) Option[T] {
+	return func(opts *Options[T]) {
+		if ch != nil {
+			opts.fallback = ch
+		}
+	}
+}
+
+// WithParkCapacity bounds the number of parked messages. Rejected
+// messages beyond the bound are dropped via WithDropHandler.
+// Non-positive values are ignored (the previously configured capacity
+// is preserved).
+func WithParkCapacity[T any](capacity int) Option[T] {
+	return func(opts *Options[T]) {
+		if capacity > 0 {
+			opts.parkCapacity = capacity
+		}
+	}
+}
+
+// WithRetryInterval sets the cadence at which parked messages are
+// replayed while the breaker is not open. Non-positive values are
+// ignored (the previously configured interval is preserved).
+func WithRetryInterval[T any](interval time.Duration) Option[T] {
+	return func(opts *Options[T]) {
+		if interval > 0 {
+			opts.retryInterval = interval
+		}
+	}
+}
+
+// WithErrorHandler installs an observability hook fired once per
+// handler failure or panic, fallback Send failure or message still
+// parked at Stop. The default (when WithErrorHandler is not passed) is
+// messaging.DefaultErrorHandler, which logs each failure via
+// common/log. Pass
+// messaging.SilentErrorHandler to opt out, or any custom hook to
+// redirect. Nil values are ignored (the previously installed handler
+// is preserved).
+func WithErrorHandler[T any](handler messaging.ErrorHandler) Option[T] {
+	return func(opts *Options[T]) {
+		if handler != nil {
+			opts.errorHandler = handler
+		}
+	}
+}
+
+// WithDropHandler installs an observability hook fired once per park
+// overflow. Messages still parked at Stop go to the ErrorHandler
+// instead (see ErrParkedAtStop). The default (when WithDropHandler is
+// not passed) is nil — drops are silent. Nil arguments are ignored
+// (the previously installed handler is preserved).
+func WithDropHandler[T any](handler DropHandler) Option[T] {
+	return func(opts *Options[T]) {
+		if handler != nil {
+			opts.dropHandler = handler
+		}
+	}
+}
+
+// WithClock sets the time source the retry goroutine ticks on. Tests
+// pass a messagingtest.FakeClock to drive replays without sleeping.
+// Nil values are ignored (the system clock is preserved).
+func WithClock[T any](clock messaging.Clock) Option[T] {
+	return func(opts *Options[T]) {
+		if clock != nil {
+			opts.clock = clock
+		}
+	}
+}
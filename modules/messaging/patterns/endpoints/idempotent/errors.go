@@ -21,10 +21,11 @@ var (
 	// ErrIdempotentFailed is the top-level sentinel embedded in every
 	// idempotent-domain Error returned by ErrIdempotent.
 	ErrIdempotentFailed = errors.New("idempotent receiver failed")
-	// ErrStoreCheck indicates that the MetadataStore.Has lookup
-	// returned a non-nil error. The original store error is joined
-	// alongside this sentinel. Has failures are fail-closed: the
-	// message is NOT forwarded.
+	// ErrStoreCheck indicates that the MetadataStore.Has lookup (or
+	// the AtomicMetadataStore.AddIfAbsent call) returned a non-nil
+	// error. The original store error is joined alongside this
+	// sentinel. Check failures are fail-closed: the message is NOT
+	// forwarded.
 	ErrStoreCheck = errors.New("metadata store check failed")
 	// ErrStoreAdd indicates that the MetadataStore.Add record returned
 	// a non-nil error. The original store error is joined alongside
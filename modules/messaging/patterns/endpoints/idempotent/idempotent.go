@@ -22,10 +22,12 @@ type idempotent[T any] struct {
 	src          messaging.Channel[T]
 	dst          messaging.Channel[T]
 	metaStore    stores.MetadataStore
+	atomicStore  stores.AtomicMetadataStore
 	ttl          time.Duration
 	keyFn        KeyFn[T]
 	errorHandler messaging.ErrorHandler
 	dropHandler  DropHandler
+	stats        messaging.StatsRecorder
 
 	done      chan struct{}
 	startOnce sync.Once
@@ -59,12 +61,14 @@ func NewIdempotent[T any](name string, src messaging.Channel[T], dst messaging.C
 	cassert.NotNil(metaStore, "metadata store is nil")
 
 	options := NewOptions(opts...)
+	atomicStore, _ := metaStore.(stores.AtomicMetadataStore)
 
 	return &idempotent[T]{
 		name:         name,
 		src:          src,
 		dst:          dst,
 		metaStore:    metaStore,
+		atomicStore:  atomicStore,
 		ttl:          options.ttl,
 		keyFn:        options.keyFn,
 		errorHandler: options.errorHandler,
@@ -144,12 +148,39 @@ func (i *idempotent[T]) Done() <-chan struct{} {
 	return i.done
 }
 
+// Stats returns a snapshot of the idempotent receiver's runtime statistics.
+// Subscribers is 1 while the source subscription is active.
+func (i *idempotent[T]) Stats() messaging.Stats {
+	cassert.NotNil(i, "idempotent is nil")
+
+	stats := i.stats.Snapshot()
+
+	i.mu.Lock()
+	if i.cancel != nil {
+		stats.Subscribers = 1
+	}
+	i.mu.Unlock()
+
+	return stats
+}
+
 // handle is the Handler[T] subscribed on the source channel. It
-// extracts the dedup key, checks the metadata store, records the key
-// on first sight, and forwards the message to the destination. The
-// function itself always returns nil so idempotent concerns never
-// propagate to the source channel's Send caller.
+// deduplicates msg and counts the delivery as failed at most once,
+// even when both the store and the forward failed. The function itself
+// always returns nil so idempotent concerns never propagate to the
+// source channel's Send caller.
 func (i *idempotent[T]) handle(ctx context.Context, msg messaging.Message[T]) error {
+	end := i.stats.Begin()
+	end(i.process(ctx, msg))
+
+	return nil
+}
+
+// process extracts the dedup key, checks the metadata store, records
+// the key on first sight, and forwards the message to the destination.
+// It returns the last error reported, nil when msg was forwarded or
+// dropped cleanly.
+func (i *idempotent[T]) process(ctx context.Context, msg messaging.Message[T]) error {
 	key := i.keyFn(msg)
 	if key == "" {
 		i.reportDrop(ctx, msg, DropReasonNoKey)
@@ -157,32 +188,74 @@ func (i *idempotent[T]) handle(ctx context.Context, msg messaging.Message[T]) er
 		return nil
 	}
 
-	seen, err := i.metaStore.Has(ctx, key)
-	if err != nil {
-		i.reportError(ctx, msg, ErrIdempotent(ErrStoreCheck, err))
+	fresh, err := i.record(ctx, key)
+	if err != nil && !fresh {
+		err = ErrIdempotent(ErrStoreCheck, err)
+		i.reportError(ctx, msg, err)
 
-		return nil
+		return err
 	}
 
-	if seen {
+	var failed error
+
+	if err != nil {
+		// Fail-open: surface the recording failure but still forward.
+		// A future duplicate is preferred over a known drop.
+		failed = ErrIdempotent(ErrStoreAdd, err)
+		i.reportError(ctx, msg, failed)
+	}
+
+	if !fresh {
 		i.reportDrop(ctx, msg, DropReasonDuplicate)
 
 		return nil
 	}
 
-	err = i.metaStore.Add(ctx, key, i.ttl)
+	err = i.dst.Send(ctx, msg)
 	if err != nil {
-		// Fail-open: surface the recording failure but still forward.
-		// A future duplicate is preferred over a known drop.
-		i.reportError(ctx, msg, ErrIdempotent(ErrStoreAdd, err))
+		failed = ErrIdempotent(ErrForwardFailed, err)
+		i.reportError(ctx, msg, failed)
+
+		return failed
 	}
 
-	err = i.dst.Send(ctx, msg)
+	i.stats.RecordSent()
+
+	return failed
+}
+
+// record checks key against the metadata store and records it when it
+// is new, reporting whether the message should be forwarded. Stores
+// implementing stores.AtomicMetadataStore do both in one AddIfAbsent
+// call. Otherwise record falls back to Has + Add: a Has error is
+// returned with fresh false (fail-closed), while an Add error is
+// returned with fresh true so the message is still forwarded
+// (fail-open).
+func (i *idempotent[T]) record(ctx context.Context, key string) (bool, error) {
+	if i.atomicStore != nil {
+		fresh, err := i.atomicStore.AddIfAbsent(ctx, key, i.ttl)
+		if err != nil {
+			return false, err
+		}
+
+		return fresh, nil
+	}
+
+	seen, err := i.metaStore.Has(ctx, key)
 	if err != nil {
-		i.reportError(ctx, msg, ErrIdempotent(ErrForwardFailed, err))
+		return false, err
 	}
 
-	return nil
+	if seen {
+		return false, nil
+	}
+
+	err = i.metaStore.Add(ctx, key, i.ttl)
+	if err != nil {
+		return true, err
+	}
+
+	return true, nil
 }
 
 // reportError forwards err to the configured ErrorHandler. ErrorHandler
@@ -200,6 +273,8 @@ func (i *idempotent[T]) reportError(ctx context.Context, msg messaging.Message[T
 // DropHandler is nil by default (silent drops); the guard skips
 // invocation in that case.
 func (i *idempotent[T]) reportDrop(ctx context.Context, msg messaging.Message[T], reason DropReason) {
+	i.stats.RecordDrop()
+
 	if i.dropHandler == nil {
 		return
 	}
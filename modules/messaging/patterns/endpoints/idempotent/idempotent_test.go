@@ -394,6 +394,97 @@ func TestIdempotent_StoreErrors(t *testing.T) {
 	})
 }
 
+func TestIdempotent_AtomicStore(t *testing.T) {
+	t.Parallel()
+
+	t.Run("AddIfAbsent replaces Has + Add", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		dst := messaging.NewPipelineChannel[int]()
+
+		h, get := counter()
+
+		_, err := dst.Subscribe(h)
+		if err != nil {
+			t.Fatalf("subscribe: %v", err)
+		}
+
+		ms := &fakeAtomicMetaStore{
+			fakeMetaStore: fakeMetaStore{hasErr: errors.New("Has must not be called")},
+			seen:          map[string]bool{},
+		}
+
+		i := NewIdempotent("test", src, dst, ms)
+
+		err = i.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		for range 2 {
+			err = src.Send(context.Background(), messaging.Message[int]{
+				Payload: 1,
+				Headers: messaging.Headers{MessageID: "abc"},
+			})
+			if err != nil {
+				t.Fatalf("send: %v", err)
+			}
+		}
+
+		if got, want := get(), int32(1); got != want {
+			t.Fatalf("forwarded count: got %d want %d", got, want)
+		}
+
+		if got, want := ms.calls, 2; got != want {
+			t.Fatalf("AddIfAbsent calls: got %d want %d", got, want)
+		}
+	})
+
+	t.Run("AddIfAbsent error fails closed and reports ErrStoreCheck", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		dst := messaging.NewPipelineChannel[int]()
+
+		h, get := counter()
+
+		_, err := dst.Subscribe(h)
+		if err != nil {
+			t.Fatalf("subscribe: %v", err)
+		}
+
+		boom := errors.New("store down")
+		ms := &fakeAtomicMetaStore{err: boom}
+
+		errHandler, getErrs := captureErrors()
+
+		i := NewIdempotent("test", src, dst, ms, WithErrorHandler[int](errHandler))
+
+		err = i.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		err = src.Send(context.Background(), messaging.Message[int]{
+			Payload: 1,
+			Headers: messaging.Headers{MessageID: "abc"},
+		})
+		if err != nil {
+			t.Fatalf("send: %v", err)
+		}
+
+		if got := get(); got != 0 {
+			t.Fatalf("forwarded count: got %d want 0 (AddIfAbsent error must NOT forward)", got)
+		}
+
+		errs := getErrs()
+		if len(errs) != 1 || !errors.Is(errs[0], ErrStoreCheck) || !errors.Is(errs[0], boom) {
+			t.Fatalf("expected one ErrStoreCheck wrapping the store error, got %v", errs)
+		}
+	})
+}
+
 func TestIdempotent_ForwardError(t *testing.T) {
 	t.Parallel()
 
@@ -779,6 +870,33 @@ func (f *fakeMetaStore) Add(_ context.Context, _ string, _ time.Duration) error
 	return f.addErr
 }
 
+// fakeAtomicMetaStore is an AtomicMetadataStore test double that
+// records keys in a map (or returns err from AddIfAbsent) and counts
+// AddIfAbsent calls. It is used from a single goroutine only.
+type fakeAtomicMetaStore struct {
+	fakeMetaStore
+
+	err   error
+	seen  map[string]bool
+	calls int
+}
+
+func (f *fakeAtomicMetaStore) AddIfAbsent(_ context.Context, key string, _ time.Duration) (bool, error) {
+	f.calls++
+
+	if f.err != nil {
+		return false, f.err
+	}
+
+	if f.seen[key] {
+		return false, nil
+	}
+
+	f.seen[key] = true
+
+	return true, nil
+}
+
 // failingChannel is a Channel[T] test double whose Send always returns
 // the configured err. Subscribe is a no-op returning a no-op Cancel.
 type failingChannel[T any] struct {
@@ -792,3 +910,68 @@ func (c *failingChannel[T]) Send(_ context.Context, _ messaging.Message[T]) erro
 func (c *failingChannel[T]) Subscribe(_ messaging.Handler[T]) (messaging.Cancel, error) {
 	return func() {}, nil
 }
+
+func TestIdempotent_Stats(t *testing.T) {
+	t.Parallel()
+
+	t.Run("counts forwards and drops", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		dst := messaging.NewPipelineChannel[int]()
+
+		i := NewIdempotent("test", src, dst, startMetaStore(t))
+
+		err := i.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		t.Cleanup(func() { _ = i.Stop(context.Background()) })
+
+		for _, id := range []string{"abc", "abc", ""} {
+			err = src.Send(context.Background(), messaging.Message[int]{Headers: messaging.Headers{MessageID: id}})
+			if err != nil {
+				t.Fatalf("send %q: %v", id, err)
+			}
+		}
+
+		got := i.Stats()
+		if got.Subscribers != 1 || got.Delivered != 3 || got.Sent != 1 || got.Dropped != 2 || got.Failed != 0 {
+			t.Fatalf("Stats = %+v, want Subscribers=1 Delivered=3 Sent=1 Dropped=2 Failed=0", got)
+		}
+	})
+
+	t.Run("counts a delivery failed once", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		dst := &failingChannel[int]{err: errors.New("dst down")}
+		ms := &fakeMetaStore{addErr: errors.New("store down")}
+
+		errHandler, getErrs := captureErrors()
+
+		i := NewIdempotent("test", src, dst, ms, WithErrorHandler[int](errHandler))
+
+		err := i.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		t.Cleanup(func() { _ = i.Stop(context.Background()) })
+
+		err = src.Send(context.Background(), messaging.Message[int]{Headers: messaging.Headers{MessageID: "abc"}})
+		if err != nil {
+			t.Fatalf("send: %v", err)
+		}
+
+		if len(getErrs()) != 2 {
+			t.Fatalf("expected the store and forward errors reported, got %v", getErrs())
+		}
+
+		got := i.Stats()
+		if got.Delivered != 1 || got.Failed != 1 || got.Sent != 0 {
+			t.Fatalf("Stats = %+v, want Delivered=1 Failed=1 Sent=0", got)
+		}
+	})
+}
@@ -67,6 +67,17 @@
 //     guarantees lost work today. Prefer a known duplicate over a
 //     known drop.
 //
+// # Atomic stores
+//
+// Has followed by Add is not atomic: two replicas receiving the same
+// message concurrently may both see the key as absent and both
+// forward it. When the injected store also implements
+// stores.AtomicMetadataStore (the in-memory store and the Redis store
+// in extension/messaging/stores/redis do), the receiver replaces Has +
+// Add with a single AddIfAbsent call and only the caller that recorded
+// the key forwards. An AddIfAbsent error is fail-closed, like a Has
+// error.
+//
 // # Lifecycle
 //
 // Idempotent implements common/lifecycle.Component (worker-style):
@@ -101,12 +112,14 @@ var (
 
 // Idempotent is the public interface for an Idempotent Receiver. It
 // embeds lifecycle.Component so callers wire it up with
-// lifecycle.Build. The interface exists (rather than returning
+// lifecycle.Build, and messaging.Inspectable to expose its runtime
+// statistics. The interface exists (rather than returning
 // lifecycle.Component directly) so the consumer's API surface
 // preserves "this is an Idempotent" semantics and the type stays open
 // to future idempotent-specific methods without breaking callers.
 type Idempotent[T any] interface {
 	lifecycle.Component
+	messaging.Inspectable
 }
 
 // KeyFn extracts the dedup key from a Message[T]. Implementations must
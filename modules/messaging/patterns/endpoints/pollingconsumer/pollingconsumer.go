@@ -26,6 +26,7 @@ type pollingConsumer[T any] struct {
 	pollInterval   time.Duration
 	maxConcurrency int
 	errorHandler   messaging.ErrorHandler
+	stats          messaging.StatsRecorder
 
 	started      atomic.Bool
 	workerCtx    context.Context
@@ -167,6 +168,34 @@ func (c *pollingConsumer[T]) run(workerCtx context.Context) {
 	}
 }
 
+// Stats returns a snapshot of the consumer's runtime statistics.
+// Delivered counts handler invocations and Failed the handler
+// failures; poll failures reach only the ErrorHandler, since no
+// message was delivered. Subscribers is the number of polling workers
+// while the consumer runs. BufferLength and BufferCapacity mirror the
+// source channel when it is messaging.Inspectable.
+func (c *pollingConsumer[T]) Stats() messaging.Stats {
+	cassert.NotNil(c, "polling consumer is nil")
+
+	stats := c.stats.Snapshot()
+
+	src, ok := messaging.StatsOf(c.src)
+	if ok {
+		stats.BufferLength = src.BufferLength
+		stats.BufferCapacity = src.BufferCapacity
+	}
+
+	select {
+	case <-c.done:
+	default:
+		if c.started.Load() {
+			stats.Subscribers = c.maxConcurrency
+		}
+	}
+
+	return stats
+}
+
 // classifyReceiveError decides whether a Receive error is a clean
 // termination signal (channel closed / ctx cancelled) or a real
 // failure. Termination signals exit silently; real failures are routed
@@ -189,7 +218,10 @@ func (c *pollingConsumer[T]) classifyReceiveError(ctx context.Context, err error
 // errors/panics through the configured ErrorHandler. msg is forwarded
 // to the hook unchanged so observers can inspect the failing payload.
 func (c *pollingConsumer[T]) dispatch(ctx context.Context, msg messaging.Message[T]) {
+	end := c.stats.Begin()
 	err := c.invoke(ctx, msg)
+	end(err)
+
 	if err == nil {
 		return
 	}
@@ -690,3 +690,63 @@ func TestPollingConsumer_Options(t *testing.T) {
 		}
 	})
 }
+
+// brokenPollable delegates the first n Receive calls to the embedded
+// channel and fails every later one, simulating a broker outage.
+type brokenPollable[T any] struct {
+	messaging.PollableChannel[T]
+
+	n     atomic.Int32
+	limit int32
+}
+
+func (b *brokenPollable[T]) Receive(ctx context.Context) (messaging.Message[T], error) {
+	if b.n.Add(1) > b.limit {
+		return messaging.Message[T]{}, errors.New("broker down")
+	}
+
+	return b.PollableChannel.Receive(ctx)
+}
+
+func TestPollingConsumer_Stats(t *testing.T) {
+	t.Parallel()
+
+	inner := messaging.NewPollableChannel[int]()
+	src := &brokenPollable[int]{PollableChannel: inner, limit: 2}
+
+	for i := range 2 {
+		err := inner.Send(context.Background(), messaging.Message[int]{Payload: i})
+		if err != nil {
+			t.Fatalf("send %d: %v", i, err)
+		}
+	}
+
+	handler := func(_ context.Context, msg messaging.Message[int]) error {
+		if msg.Payload == 1 {
+			return errors.New("handler boom")
+		}
+
+		return nil
+	}
+
+	errHandler, getErrs := captureErrors()
+
+	c := NewPollingConsumer("test", src, handler, WithErrorHandler(errHandler))
+
+	err := c.Start(context.Background())
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+
+	t.Cleanup(func() { _ = c.Stop(context.Background()) })
+
+	// One handler failure, then the poll failure that stops the worker.
+	if !waitFor(func() bool { return len(getErrs()) == 2 }) {
+		t.Fatalf("expected 2 errors reported, got %v", getErrs())
+	}
+
+	got := c.Stats()
+	if got.Delivered != 2 || got.Failed != 1 || got.InFlight != 0 {
+		t.Fatalf("Stats = %+v, want Delivered=2 Failed=1 InFlight=0", got)
+	}
+}
@@ -61,6 +61,7 @@ package pollingconsumer
 
 import (
 	"github.com/guidomantilla/yarumo/core/common/lifecycle"
+	"github.com/guidomantilla/yarumo/messaging"
 )
 
 var (
@@ -71,12 +72,15 @@ var (
 
 // PollingConsumer is the public interface for a Polling Consumer
 // endpoint. It embeds lifecycle.Component so callers wire it up with
-// lifecycle.Build. The interface exists (rather than returning
-// lifecycle.Component directly) so the consumer's API surface preserves
-// "this is a PollingConsumer" semantics and the type stays open to
-// future polling-consumer-specific methods without breaking callers.
+// lifecycle.Build, and messaging.Inspectable to expose its runtime
+// statistics. The interface exists (rather than returning
+// lifecycle.Component directly) so the consumer's API surface
+// preserves "this is a PollingConsumer" semantics and the type stays
+// open to future polling-consumer-specific methods without breaking
+// callers.
 type PollingConsumer[T any] interface {
 	lifecycle.Component
+	messaging.Inspectable
 }
 
 // ErrPollingConsumerFn is the function type for ErrPollingConsumer.
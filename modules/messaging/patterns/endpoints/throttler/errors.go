@@ -0,0 +1,56 @@
+package throttler
+
+import (
+	"errors"
+	"fmt"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+	cerrs "github.com/guidomantilla/yarumo/core/common/errs"
+)
+
+// ThrottlerType is the error domain identifier for throttler
+// operations.
+const ThrottlerType = "throttler"
+
+var (
+	_ error = (*Error)(nil)
+)
+
+// Sentinel errors for throttler operations.
+var (
+	// ErrThrottlerFailed is the top-level sentinel embedded in every
+	// throttler-domain Error returned by ErrThrottler.
+	ErrThrottlerFailed = errors.New("throttler failed")
+	// ErrWaitFailed indicates that Limiter.Wait returned a non-nil
+	// error — typically because the dispatch context was cancelled or
+	// the Throttler was stopped while the message waited for a token.
+	ErrWaitFailed = errors.New("wait for rate limit token failed")
+	// ErrForwardFailed indicates that the destination Channel.Send
+	// returned a non-nil error.
+	ErrForwardFailed = errors.New("forward to destination failed")
+)
+
+// Error is the domain error type for throttler operations.
+type Error struct {
+	cerrs.TypedError
+}
+
+// Error returns the formatted error string including the type
+// classification.
+func (e *Error) Error() string {
+	cassert.NotNil(e, "error is nil")
+	cassert.NotNil(e.Err, "internal error is nil")
+
+	return fmt.Sprintf("throttler %s error: %s", e.Type, e.Err)
+}
+
+// ErrThrottler wraps the given causes into a domain Error joined with
+// ErrThrottlerFailed.
+func ErrThrottler(causes ...error) error {
+	return &Error{
+		TypedError: cerrs.TypedError{
+			Type: ThrottlerType,
+			Err:  errors.Join(append(causes, ErrThrottlerFailed)...),
+		},
+	}
+}
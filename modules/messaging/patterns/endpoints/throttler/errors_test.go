@@ -0,0 +1,49 @@
+package throttler
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestError_Error(t *testing.T) {
+	t.Parallel()
+
+	t.Run("includes type prefix and joined causes", func(t *testing.T) {
+		t.Parallel()
+
+		err := ErrThrottler(ErrForwardFailed)
+
+		msg := err.Error()
+		if !strings.HasPrefix(msg, "throttler "+ThrottlerType) {
+			t.Fatalf("expected prefix %q, got %q", "throttler "+ThrottlerType, msg)
+		}
+
+		if !strings.Contains(msg, ErrForwardFailed.Error()) {
+			t.Fatalf("expected cause %q in message, got %q", ErrForwardFailed.Error(), msg)
+		}
+
+		if !strings.Contains(msg, ErrThrottlerFailed.Error()) {
+			t.Fatalf("expected sentinel %q in message, got %q", ErrThrottlerFailed.Error(), msg)
+		}
+	})
+
+	t.Run("ErrThrottler joins all causes with ErrThrottlerFailed", func(t *testing.T) {
+		t.Parallel()
+
+		boom := errors.New("custom failure")
+
+		err := ErrThrottler(ErrForwardFailed, boom)
+		if !errors.Is(err, ErrThrottlerFailed) {
+			t.Fatal("expected ErrThrottlerFailed in chain")
+		}
+
+		if !errors.Is(err, ErrForwardFailed) {
+			t.Fatal("expected ErrForwardFailed in chain")
+		}
+
+		if !errors.Is(err, boom) {
+			t.Fatal("expected origin error in chain")
+		}
+	})
+}
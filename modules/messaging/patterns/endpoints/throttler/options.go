@@ -0,0 +1,123 @@
+package throttler
+
+import (
+	"time"
+
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+// Option is a functional option for configuring throttler Options.
+// Throttler has no T-typed options, so Option is non-generic.
+type Option func(opts *Options)
+
+// Options holds the configuration for a Throttler endpoint.
+type Options struct {
+	keyHeader     string
+	limiterFn     LimiterFn
+	bucketIdleTTL time.Duration
+	failFast      bool
+	errorHandler  messaging.ErrorHandler
+	dropHandler   DropHandler
+	clock         messaging.Clock
+}
+
+// NewOptions creates a new Options with sensible defaults and applies
+// the given options. Defaults:
+//
+//   - Buckets: a single shared bucket (no key header); keyed buckets
+//     are evicted after DefaultBucketIdleTTL unused.
+//   - Mode: blocking (Limiter.Wait).
+//   - ErrorHandler: messaging.DefaultErrorHandler (logs via common/log).
+//   - DropHandler: nil (fail-fast rejections are silent unless wired).
+//   - Clock: messaging.SystemClock.
+func NewOptions(opts ...Option) *Options {
+	options := &Options{
+		keyHeader:     "",
+		limiterFn:     nil,
+		bucketIdleTTL: DefaultBucketIdleTTL,
+		failFast:      false,
+		errorHandler:  messaging.DefaultErrorHandler,
+		dropHandler:   nil,
+		clock:         messaging.SystemClock(),
+	}
+
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	return options
+}
+
+// WithKeyHeader gives each distinct value of Headers.Custom[header]
+// its own bucket, built on first use by fn. Messages without the
+// header (or with an empty value) draw from the shared Limiter passed
+// to NewThrottler. Non-string header values are formatted with
+// fmt.Sprint. The call is a no-op when header is empty or fn is nil.
+func WithKeyHeader(header string, fn LimiterFn) Option {
+	return func(opts *Options) {
+		if header == "" || fn == nil {
+			return
+		}
+
+		opts.keyHeader = header
+		opts.limiterFn = fn
+	}
+}
+
+// WithBucketIdleTTL sets how long a keyed bucket (see WithKeyHeader)
+// may stay unused before it is evicted. A bucket with a message
+// waiting on it is never evicted. Non-positive values are ignored.
+func WithBucketIdleTTL(d time.Duration) Option {
+	return func(opts *Options) {
+		if d > 0 {
+			opts.bucketIdleTTL = d
+		}
+	}
+}
+
+// WithFailFast makes the Throttler use Limiter.Allow instead of
+// Limiter.Wait: a message that finds its bucket empty is dropped
+// (observable via WithDropHandler) instead of waiting for a token.
+func WithFailFast() Option {
+	return func(opts *Options) {
+		opts.failFast = true
+	}
+}
+
+// WithErrorHandler installs an observability hook fired once per Wait
+// failure or forward Send failure. The default (when WithErrorHandler
+// is not passed) is messaging.DefaultErrorHandler, which logs each
+// failure via common/log. Pass messaging.SilentErrorHandler to opt
+// out, or any custom hook to redirect. Nil values are ignored (the
+// previously installed handler is preserved).
+func WithErrorHandler(handler messaging.ErrorHandler) Option {
+	return func(opts *Options) {
+		if handler != nil {
+			opts.errorHandler = handler
+		}
+	}
+}
+
+// WithDropHandler installs an observability hook fired once per
+// message rejected in fail-fast mode. The default (when
+// WithDropHandler is not passed) is nil — rejections are silent. Nil
+// arguments are ignored (the previously installed handler is
+// preserved).
+func WithDropHandler(handler DropHandler) Option {
+	return func(opts *Options) {
+		if handler != nil {
+			opts.dropHandler = handler
+		}
+	}
+}
+
+// WithClock sets the time source keyed-bucket eviction is measured on.
+// Tests pass a messagingtest.FakeClock to evict buckets without
+// sleeping. Nil values are ignored (the system clock is preserved).
+func WithClock(clock messaging.Clock) Option {
+	return func(opts *Options) {
+		if clock != nil {
+			opts.clock = clock
+		}
+	}
+}
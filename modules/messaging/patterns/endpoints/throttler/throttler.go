@@ -0,0 +1,340 @@
+package throttler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+	"github.com/guidomantilla/yarumo/core/common/lifecycle"
+	climiter "github.com/guidomantilla/yarumo/core/common/resilience/limiter"
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+// throttler is the Throttler implementation. It owns a single
+// subscription on the source channel (registered in Start, cancelled
+// in Stop) and forwards each received message to the destination once
+// its bucket admits it. stopCtx is cancelled in Stop so messages
+// blocked in Limiter.Wait are released.
+type throttler[T any] struct {
+	name          string
+	src           messaging.Channel[T]
+	dst           messaging.Channel[T]
+	limiter       climiter.Limiter
+	keyHeader     string
+	limiterFn     LimiterFn
+	bucketIdleTTL time.Duration
+	failFast      bool
+	errorHandler  messaging.ErrorHandler
+	dropHandler   DropHandler
+	clock         messaging.Clock
+	stats         messaging.StatsRecorder
+
+	bucketsMu sync.Mutex
+	buckets   map[string]*keyedBucket
+	lastSweep time.Time
+
+	stopCtx    context.Context
+	stopCancel context.CancelFunc
+
+	done      chan struct{}
+	startOnce sync.Once
+	stopOnce  sync.Once
+	doneOnce  sync.Once
+
+	mu     sync.Mutex
+	cancel messaging.Cancel
+}
+
+// keyedBucket is a cached keyed Limiter with its eviction bookkeeping:
+// the number of messages currently drawing from it and when the last
+// of them finished. Both fields are guarded by bucketsMu.
+type keyedBucket struct {
+	limiter  climiter.Limiter
+	inUse    int
+	lastUsed time.Time
+}
+
+// NewThrottler constructs a Throttler that subscribes to src and
+// forwards each Message[T] to dst once limiter admits it. The
+// throttler is not running on return; call lifecycle.Build (or Start
+// directly) to register the subscription.
+//
+// name is used in lifecycle logs and must be non-empty. src, dst and
+// limiter are mandatory; limiter is the shared bucket and is
+// caller-owned, so it may also be shared with other components.
+// Optional behaviors:
+//
+//   - WithKeyHeader gives each value of a Headers.Custom entry its own
+//     bucket, built on first use by a LimiterFn and evicted once idle
+//     for WithBucketIdleTTL (measured on WithClock).
+//   - WithFailFast drops messages that find their bucket empty instead
+//     of waiting for a token.
+//   - WithErrorHandler overrides the default
+//     messaging.DefaultErrorHandler (which logs via common/log) with a
+//     custom hook for Wait and forward Send failures.
+//   - WithDropHandler installs an optional hook for observing
+//     fail-fast rejections; nil by default (silent drop).
+func NewThrottler[T any](name string, src messaging.Channel[T], dst messaging.Channel[T], limiter climiter.Limiter, opts ...Option) Throttler[T] {
+	cassert.NotEmpty(name, "name is empty")
+	cassert.NotNil(src, "source channel is nil")
+	cassert.NotNil(dst, "destination channel is nil")
+	cassert.NotNil(limiter, "limiter is nil")
+
+	options := NewOptions(opts...)
+	stopCtx, stopCancel := context.WithCancel(context.Background())
+
+	t := &throttler[T]{
+		name:          name,
+		src:           src,
+		dst:           dst,
+		limiter:       limiter,
+		keyHeader:     options.keyHeader,
+		limiterFn:     options.limiterFn,
+		bucketIdleTTL: options.bucketIdleTTL,
+		failFast:      options.failFast,
+		errorHandler:  options.errorHandler,
+		dropHandler:   options.dropHandler,
+		clock:         options.clock,
+		buckets:       map[string]*keyedBucket{},
+		stopCtx:       stopCtx,
+		stopCancel:    stopCancel,
+		done:          make(chan struct{}),
+	}
+
+	t.stats.UseClock(options.clock)
+
+	return t
+}
+
+// Name returns the throttler's identity used in lifecycle logs.
+func (t *throttler[T]) Name() string {
+	cassert.NotNil(t, "throttler is nil")
+
+	return t.name
+}
+
+// Start registers the throttling handler as a subscriber on the source
+// channel. It satisfies the lifecycle.Component worker-style contract:
+// Start returns immediately after the subscription is in place; the
+// actual dispatching runs in the source channel's goroutine model.
+// Start is idempotent — a second invocation returns nil without
+// re-subscribing.
+func (t *throttler[T]) Start(_ context.Context) error {
+	cassert.NotNil(t, "throttler is nil")
+
+	var startErr error
+
+	t.startOnce.Do(func() {
+		cancel, err := t.src.Subscribe(t.handle)
+		if err != nil {
+			startErr = lifecycle.ErrStart(err)
+
+			return
+		}
+
+		t.mu.Lock()
+		t.cancel = cancel
+		t.mu.Unlock()
+	})
+
+	return startErr
+}
+
+// Stop cancels the source-channel subscription, releases every message
+// blocked in Limiter.Wait (each is reported with ErrWaitFailed) and
+// closes Done. Stop is idempotent per the lifecycle.Component
+// contract. It returns lifecycle.ErrShutdown wrapping
+// lifecycle.ErrShutdownTimeout when ctx is already expired on entry;
+// otherwise nil.
+func (t *throttler[T]) Stop(ctx context.Context) error {
+	cassert.NotNil(t, "throttler is nil")
+
+	t.stopOnce.Do(func() {
+		t.mu.Lock()
+		cancel := t.cancel
+		t.cancel = nil
+		t.mu.Unlock()
+
+		if cancel != nil {
+			cancel()
+		}
+
+		t.stopCancel()
+
+		t.doneOnce.Do(func() { close(t.done) })
+	})
+
+	select {
+	case <-ctx.Done():
+		return lifecycle.ErrShutdown(lifecycle.ErrShutdownTimeout, ctx.Err())
+	default:
+		return nil
+	}
+}
+
+// Done returns the channel that is closed after Stop has been called.
+func (t *throttler[T]) Done() <-chan struct{} {
+	cassert.NotNil(t, "throttler is nil")
+
+	return t.done
+}
+
+// Stats returns a snapshot of the throttler's runtime statistics.
+// Subscribers is 1 while the source subscription is active.
+func (t *throttler[T]) Stats() messaging.Stats {
+	cassert.NotNil(t, "throttler is nil")
+
+	stats := t.stats.Snapshot()
+
+	t.mu.Lock()
+	if t.cancel != nil {
+		stats.Subscribers = 1
+	}
+	t.mu.Unlock()
+
+	return stats
+}
+
+// handle is the Handler[T] subscribed on the source channel. It picks
+// the message's bucket, acquires a token (waiting or failing fast per
+// configuration) and forwards the message to the destination. The
+// function itself always returns nil so throttler concerns never
+// propagate to the source channel's Send caller.
+func (t *throttler[T]) handle(ctx context.Context, msg messaging.Message[T]) error {
+	end := t.stats.Begin()
+	defer end(nil)
+
+	bucket, release := t.bucket(msg)
+	defer release()
+
+	if t.failFast && !bucket.Allow() {
+		t.reportDrop(ctx, msg)
+
+		return nil
+	}
+
+	if !t.failFast {
+		err := t.wait(ctx, bucket)
+		if err != nil {
+			t.reportError(ctx, msg, ErrThrottler(ErrWaitFailed, err))
+
+			return nil
+		}
+	}
+
+	err := t.dst.Send(ctx, msg)
+	if err != nil {
+		t.reportError(ctx, msg, ErrThrottler(ErrForwardFailed, err))
+
+		return nil
+	}
+
+	t.stats.RecordSent()
+
+	return nil
+}
+
+// wait blocks on bucket.Wait until a token is available, ctx is
+// cancelled, or the throttler is stopped.
+func (t *throttler[T]) wait(ctx context.Context, bucket climiter.Limiter) error {
+	waitCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	release := context.AfterFunc(t.stopCtx, cancel)
+	defer release()
+
+	return bucket.Wait(waitCtx)
+}
+
+// bucket returns the Limiter msg draws from and a release func the
+// caller invokes once done with it: the keyed bucket of its header
+// value when WithKeyHeader is configured and the header is present,
+// the shared Limiter otherwise. Keyed buckets are built lazily through
+// limiterFn, cached, and evicted by evictIdle.
+func (t *throttler[T]) bucket(msg messaging.Message[T]) (climiter.Limiter, func()) {
+	if t.keyHeader == "" {
+		return t.limiter, func() {}
+	}
+
+	raw, ok := msg.Headers.Custom[t.keyHeader]
+	if !ok || raw == nil {
+		return t.limiter, func() {}
+	}
+
+	key := fmt.Sprint(raw)
+	if key == "" {
+		return t.limiter, func() {}
+	}
+
+	t.bucketsMu.Lock()
+	defer t.bucketsMu.Unlock()
+
+	t.evictIdle(t.clock.Now())
+
+	entry, ok := t.buckets[key]
+	if !ok {
+		limiter := t.limiterFn(key)
+		cassert.NotNil(limiter, "keyed limiter is nil")
+
+		entry = &keyedBucket{limiter: limiter}
+		t.buckets[key] = entry
+	}
+
+	entry.inUse++
+
+	release := func() {
+		t.bucketsMu.Lock()
+		defer t.bucketsMu.Unlock()
+
+		entry.inUse--
+		entry.lastUsed = t.clock.Now()
+	}
+
+	return entry.limiter, release
+}
+
+// evictIdle drops every keyed bucket that no message is drawing from
+// and that has been unused for bucketIdleTTL. The scan runs at most
+// once per bucketIdleTTL, so its cost is amortised across the messages
+// in between. bucketsMu must be held.
+func (t *throttler[T]) evictIdle(now time.Time) {
+	if now.Sub(t.lastSweep) < t.bucketIdleTTL {
+		return
+	}
+
+	t.lastSweep = now
+
+	for key, entry := range t.buckets {
+		if entry.inUse == 0 && now.Sub(entry.lastUsed) >= t.bucketIdleTTL {
+			delete(t.buckets, key)
+		}
+	}
+}
+
+// reportError forwards err to the configured ErrorHandler.
+// ErrorHandler is guaranteed non-nil by NewOptions, so the nil-guard
+// is defensive only.
+func (t *throttler[T]) reportError(ctx context.Context, msg messaging.Message[T], err error) {
+	t.stats.RecordFailure()
+
+	if t.errorHandler == nil {
+		return
+	}
+
+	t.errorHandler(ctx, msg, err)
+}
+
+// reportDrop forwards msg to the configured DropHandler. DropHandler
+// is nil by default (silent drops); the guard skips invocation in that
+// case.
+func (t *throttler[T]) reportDrop(ctx context.Context, msg messaging.Message[T]) {
+	t.stats.RecordDrop()
+
+	if t.dropHandler == nil {
+		return
+	}
+
+	t.dropHandler(ctx, msg)
+}
@@ -0,0 +1,804 @@
+package throttler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/guidomantilla/yarumo/core/common/lifecycle"
+	climiter "github.com/guidomantilla/yarumo/core/common/resilience/limiter"
+	"github.com/guidomantilla/yarumo/messaging"
+	"github.com/guidomantilla/yarumo/messaging/messagingtest"
+)
+
+// captureErrors returns a thread-safe ErrorHandler that appends every
+// reported error, and a getter that returns a defensive copy.
+func captureErrors() (messaging.ErrorHandler, func() []error) {
+	var mu sync.Mutex
+
+	captured := []error{}
+
+	handler := func(_ context.Context, _ any, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		captured = append(captured, err)
+	}
+
+	get := func() []error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		out := make([]error, len(captured))
+		copy(out, captured)
+
+		return out
+	}
+
+	return handler, get
+}
+
+// captureDrops returns a thread-safe DropHandler that appends every
+// dropped message, and a getter that returns a defensive copy.
+func captureDrops() (DropHandler, func() []any) {
+	var mu sync.Mutex
+
+	captured := []any{}
+
+	handler := func(_ context.Context, msg any) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		captured = append(captured, msg)
+	}
+
+	get := func() []any {
+		mu.Lock()
+		defer mu.Unlock()
+
+		out := make([]any, len(captured))
+		copy(out, captured)
+
+		return out
+	}
+
+	return handler, get
+}
+
+// counter returns a Handler[int] that increments the returned int32
+// once per dispatched message.
+func counter() (messaging.Handler[int], func() int32) {
+	var n int32
+
+	handler := func(_ context.Context, _ messaging.Message[int]) error {
+		atomic.AddInt32(&n, 1)
+
+		return nil
+	}
+
+	get := func() int32 {
+		return atomic.LoadInt32(&n)
+	}
+
+	return handler, get
+}
+
+// fakeLimiter is a Limiter test double holding a fixed number of
+// tokens that never refill. Wait blocks until ctx is done once the
+// tokens are exhausted.
+type fakeLimiter struct {
+	mu     sync.Mutex
+	tokens int
+	waits  atomic.Int32
+}
+
+func (l *fakeLimiter) take() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.tokens == 0 {
+		return false
+	}
+
+	l.tokens--
+
+	return true
+}
+
+func (l *fakeLimiter) Allow() bool {
+	return l.take()
+}
+
+func (l *fakeLimiter) Wait(ctx context.Context) error {
+	l.waits.Add(1)
+
+	if l.take() {
+		return nil
+	}
+
+	<-ctx.Done()
+
+	return climiter.ErrWait(ctx.Err())
+}
+
+func TestNewThrottler(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns non-nil component", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		dst := messaging.NewPipelineChannel[int]()
+
+		c := NewThrottler("test", src, dst, &fakeLimiter{})
+		if c == nil {
+			t.Fatal("expected non-nil component")
+		}
+	})
+
+	t.Run("carries the given name", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		dst := messaging.NewPipelineChannel[int]()
+
+		c := NewThrottler("orders-throttler", src, dst, &fakeLimiter{})
+		if got, want := c.Name(), "orders-throttler"; got != want {
+			t.Fatalf("Name() = %q, want %q", got, want)
+		}
+	})
+}
+
+func TestThrottler_Wait(t *testing.T) {
+	t.Parallel()
+
+	t.Run("forwards every message that gets a token", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		dst := messaging.NewPipelineChannel[int]()
+
+		h, get := counter()
+
+		_, err := dst.Subscribe(h)
+		if err != nil {
+			t.Fatalf("subscribe: %v", err)
+		}
+
+		limiter := &fakeLimiter{tokens: 3}
+
+		c := NewThrottler("test", src, dst, limiter)
+
+		err = c.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		for i := range 3 {
+			err = src.Send(context.Background(), messaging.Message[int]{Payload: i})
+			if err != nil {
+				t.Fatalf("send %d: %v", i, err)
+			}
+		}
+
+		if got, want := get(), int32(3); got != want {
+			t.Fatalf("forwarded = %d, want %d", got, want)
+		}
+
+		if got, want := limiter.waits.Load(), int32(3); got != want {
+			t.Fatalf("Wait calls = %d, want %d", got, want)
+		}
+	})
+
+	t.Run("cancelled dispatch ctx reports ErrWaitFailed", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		dst := messaging.NewPipelineChannel[int]()
+
+		h, get := counter()
+
+		_, err := dst.Subscribe(h)
+		if err != nil {
+			t.Fatalf("subscribe: %v", err)
+		}
+
+		errHandler, getErrs := captureErrors()
+
+		c := NewThrottler("test", src, dst, &fakeLimiter{}, WithErrorHandler(errHandler))
+
+		err = c.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err = src.Send(ctx, messaging.Message[int]{Payload: 1})
+		if err != nil {
+			t.Fatalf("expected nil from src.Send (throttler swallows), got %v", err)
+		}
+
+		errs := getErrs()
+		if len(errs) != 1 {
+			t.Fatalf("expected 1 captured error, got %d", len(errs))
+		}
+
+		if !errors.Is(errs[0], ErrWaitFailed) {
+			t.Fatalf("expected ErrWaitFailed, got %v", errs[0])
+		}
+
+		if got := get(); got != 0 {
+			t.Fatalf("forwarded = %d, want 0", got)
+		}
+	})
+
+	t.Run("Stop releases a message blocked in Wait", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		dst := messaging.NewPipelineChannel[int]()
+
+		errHandler, getErrs := captureErrors()
+
+		limiter := &fakeLimiter{}
+
+		c := NewThrottler("test", src, dst, limiter, WithErrorHandler(errHandler))
+
+		err := c.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		sent := make(chan error, 1)
+
+		go func() {
+			sent <- src.Send(context.Background(), messaging.Message[int]{Payload: 1})
+		}()
+
+		deadline := time.Now().Add(time.Second)
+		for limiter.waits.Load() == 0 {
+			if time.Now().After(deadline) {
+				t.Fatal("message never reached Wait")
+			}
+
+			time.Sleep(time.Millisecond)
+		}
+
+		err = c.Stop(context.Background())
+		if err != nil {
+			t.Fatalf("stop: %v", err)
+		}
+
+		select {
+		case err = <-sent:
+			if err != nil {
+				t.Fatalf("send: %v", err)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Stop did not release the blocked Wait")
+		}
+
+		errs := getErrs()
+		if len(errs) != 1 || !errors.Is(errs[0], ErrWaitFailed) {
+			t.Fatalf("expected one ErrWaitFailed, got %v", errs)
+		}
+	})
+}
+
+func TestThrottler_FailFast(t *testing.T) {
+	t.Parallel()
+
+	t.Run("drops messages that find the bucket empty", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		dst := messaging.NewPipelineChannel[int]()
+
+		h, get := counter()
+
+		_, err := dst.Subscribe(h)
+		if err != nil {
+			t.Fatalf("subscribe: %v", err)
+		}
+
+		dropHandler, getDrops := captureDrops()
+
+		limiter := &fakeLimiter{tokens: 1}
+
+		c := NewThrottler("test", src, dst, limiter, WithFailFast(), WithDropHandler(dropHandler))
+
+		err = c.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		for i := range 3 {
+			err = src.Send(context.Background(), messaging.Message[int]{Payload: i})
+			if err != nil {
+				t.Fatalf("send %d: %v", i, err)
+			}
+		}
+
+		if got, want := get(), int32(1); got != want {
+			t.Fatalf("forwarded = %d, want %d", got, want)
+		}
+
+		drops := getDrops()
+		if len(drops) != 2 {
+			t.Fatalf("expected 2 drops, got %d", len(drops))
+		}
+
+		if got := drops[0].(messaging.Message[int]).Payload; got != 1 {
+			t.Fatalf("first drop payload = %d, want 1", got)
+		}
+
+		if got := limiter.waits.Load(); got != 0 {
+			t.Fatalf("fail-fast must not call Wait, got %d calls", got)
+		}
+	})
+}
+
+func TestThrottler_KeyHeader(t *testing.T) {
+	t.Parallel()
+
+	t.Run("each key value gets its own bucket", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		dst := messaging.NewPipelineChannel[int]()
+
+		h, get := counter()
+
+		_, err := dst.Subscribe(h)
+		if err != nil {
+			t.Fatalf("subscribe: %v", err)
+		}
+
+		dropHandler, getDrops := captureDrops()
+
+		var (
+			mu    sync.Mutex
+			built []string
+		)
+
+		limiterFn := func(key string) climiter.Limiter {
+			mu.Lock()
+			defer mu.Unlock()
+
+			built = append(built, key)
+
+			return &fakeLimiter{tokens: 1}
+		}
+
+		c := NewThrottler("test", src, dst, &fakeLimiter{tokens: 1},
+			WithKeyHeader("tenant", limiterFn),
+			WithFailFast(),
+			WithDropHandler(dropHandler),
+		)
+
+		err = c.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		tenants := []any{"acme", "acme", "globex", nil, 42}
+		for i, tenant := range tenants {
+			msg := messaging.Message[int]{Payload: i}
+			if tenant != nil {
+				msg.Headers.Custom = map[string]any{"tenant": tenant}
+			}
+
+			err = src.Send(context.Background(), msg)
+			if err != nil {
+				t.Fatalf("send %d: %v", i, err)
+			}
+		}
+
+		if got, want := get(), int32(4); got != want {
+			t.Fatalf("forwarded = %d, want %d", got, want)
+		}
+
+		drops := getDrops()
+		if len(drops) != 1 || drops[0].(messaging.Message[int]).Payload != 1 {
+			t.Fatalf("expected the second acme message to be dropped, got %v", drops)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		if len(built) != 3 || built[0] != "acme" || built[1] != "globex" || built[2] != "42" {
+			t.Fatalf("built buckets = %v, want [acme globex 42]", built)
+		}
+	})
+
+	t.Run("messages without the header share the constructor limiter", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		dst := messaging.NewPipelineChannel[int]()
+
+		shared := &fakeLimiter{tokens: 2}
+
+		c := NewThrottler("test", src, dst, shared,
+			WithKeyHeader("tenant", func(_ string) climiter.Limiter { return &fakeLimiter{} }),
+			WithFailFast(),
+		)
+
+		err := c.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		for _, custom := range []map[string]any{nil, {"tenant": ""}} {
+			err = src.Send(context.Background(), messaging.Message[int]{Headers: messaging.Headers{Custom: custom}})
+			if err != nil {
+				t.Fatalf("send: %v", err)
+			}
+		}
+
+		if got := shared.tokens; got != 0 {
+			t.Fatalf("shared tokens left = %d, want 0", got)
+		}
+	})
+}
+
+func TestThrottler_BucketEviction(t *testing.T) {
+	t.Parallel()
+
+	t.Run("idle keyed buckets are evicted and rebuilt", func(t *testing.T) {
+		t.Parallel()
+
+		clock := messagingtest.NewFakeClock(time.Time{})
+		src := messaging.NewPipelineChannel[int]()
+		dst := messaging.NewPipelineChannel[int]()
+
+		h, get := counter()
+
+		_, err := dst.Subscribe(h)
+		if err != nil {
+			t.Fatalf("subscribe: %v", err)
+		}
+
+		var (
+			mu    sync.Mutex
+			built []string
+		)
+
+		limiterFn := func(key string) climiter.Limiter {
+			mu.Lock()
+			defer mu.Unlock()
+
+			built = append(built, key)
+
+			return &fakeLimiter{tokens: 1}
+		}
+
+		c := NewThrottler("test", src, dst, &fakeLimiter{},
+			WithKeyHeader("tenant", limiterFn),
+			WithBucketIdleTTL(time.Minute),
+			WithClock(clock),
+			WithFailFast(),
+		)
+
+		err = c.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		send := func(tenant string) {
+			err := src.Send(context.Background(), messaging.Message[int]{Headers: messaging.Headers{Custom: map[string]any{"tenant": tenant}}})
+			if err != nil {
+				t.Fatalf("send %s: %v", tenant, err)
+			}
+		}
+
+		send("acme")
+		clock.Advance(30 * time.Second)
+		send("globex")
+
+		clock.Advance(40 * time.Second)
+		send("globex")
+
+		clock.Advance(30 * time.Second)
+		send("acme")
+
+		// The sweep at 70s evicted acme (idle 70s) but kept globex
+		// (idle 40s), whose second message found its bucket empty;
+		// acme's next message got a fresh bucket.
+		if got, want := get(), int32(3); got != want {
+			t.Fatalf("forwarded = %d, want %d", got, want)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		if len(built) != 3 || built[0] != "acme" || built[1] != "globex" || built[2] != "acme" {
+			t.Fatalf("built buckets = %v, want [acme globex acme]", built)
+		}
+
+		throttled, _ := c.(*throttler[int])
+
+		throttled.bucketsMu.Lock()
+		defer throttled.bucketsMu.Unlock()
+
+		if len(throttled.buckets) != 2 {
+			t.Fatalf("cached buckets = %d, want 2", len(throttled.buckets))
+		}
+	})
+}
+
+func TestThrottler_ForwardFailure(t *testing.T) {
+	t.Parallel()
+
+	t.Run("reports ErrForwardFailed when destination errs", func(t *testing.T) {
+		t.Parallel()
+
+		boom := errors.New("downstream down")
+
+		src := messaging.NewPipelineChannel[int]()
+		dst := &failingChannel[int]{err: boom}
+
+		errHandler, getErrs := captureErrors()
+
+		c := NewThrottler("test", src, dst, &fakeLimiter{tokens: 1}, WithErrorHandler(errHandler))
+
+		err := c.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		err = src.Send(context.Background(), messaging.Message[int]{Payload: 1})
+		if err != nil {
+			t.Fatalf("expected nil from src.Send (throttler swallows), got %v", err)
+		}
+
+		errs := getErrs()
+		if len(errs) != 1 {
+			t.Fatalf("expected 1 captured error, got %d", len(errs))
+		}
+
+		if !errors.Is(errs[0], ErrForwardFailed) {
+			t.Fatalf("expected ErrForwardFailed, got %v", errs[0])
+		}
+
+		if !errors.Is(errs[0], boom) {
+			t.Fatalf("expected origin error in chain, got %v", errs[0])
+		}
+	})
+}
+
+func TestThrottler_Lifecycle(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Start is idempotent", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		dst := messaging.NewPipelineChannel[int]()
+
+		h, get := counter()
+
+		_, err := dst.Subscribe(h)
+		if err != nil {
+			t.Fatalf("subscribe: %v", err)
+		}
+
+		c := NewThrottler("test", src, dst, &fakeLimiter{tokens: 2})
+
+		err = c.Start(context.Background())
+		if err != nil {
+			t.Fatalf("first start: %v", err)
+		}
+
+		err = c.Start(context.Background())
+		if err != nil {
+			t.Fatalf("second start: %v", err)
+		}
+
+		err = src.Send(context.Background(), messaging.Message[int]{Payload: 1})
+		if err != nil {
+			t.Fatalf("send: %v", err)
+		}
+
+		if got, want := get(), int32(1); got != want {
+			t.Fatalf("dest should receive once (no double subscription), got %d", got)
+		}
+	})
+
+	t.Run("Stop is idempotent", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		dst := messaging.NewPipelineChannel[int]()
+
+		c := NewThrottler("test", src, dst, &fakeLimiter{})
+
+		err := c.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		err = c.Stop(context.Background())
+		if err != nil {
+			t.Fatalf("first stop: %v", err)
+		}
+
+		err = c.Stop(context.Background())
+		if err != nil {
+			t.Fatalf("second stop: %v", err)
+		}
+	})
+
+	t.Run("Done closes after Stop", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		dst := messaging.NewPipelineChannel[int]()
+
+		c := NewThrottler("test", src, dst, &fakeLimiter{})
+
+		err := c.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		select {
+		case <-c.Done():
+			t.Fatal("Done closed before Stop")
+		default:
+		}
+
+		err = c.Stop(context.Background())
+		if err != nil {
+			t.Fatalf("stop: %v", err)
+		}
+
+		select {
+		case <-c.Done():
+		default:
+			t.Fatal("Done not closed after Stop")
+		}
+	})
+
+	t.Run("Stop with expired ctx returns ErrShutdownTimeout", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		dst := messaging.NewPipelineChannel[int]()
+
+		c := NewThrottler("test", src, dst, &fakeLimiter{})
+
+		err := c.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err = c.Stop(ctx)
+		if !errors.Is(err, lifecycle.ErrShutdownTimeout) {
+			t.Fatalf("expected ErrShutdownTimeout, got %v", err)
+		}
+	})
+}
+
+func TestThrottler_Options(t *testing.T) {
+	t.Parallel()
+
+	t.Run("defaults", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions()
+		if opts.errorHandler == nil {
+			t.Fatal("default error handler should be installed")
+		}
+
+		if opts.dropHandler != nil {
+			t.Fatal("default drop handler should be nil")
+		}
+
+		if opts.keyHeader != "" || opts.limiterFn != nil || opts.failFast {
+			t.Fatalf("unexpected defaults %+v", opts)
+		}
+
+		if opts.bucketIdleTTL != DefaultBucketIdleTTL || opts.clock == nil {
+			t.Fatalf("unexpected eviction defaults %+v", opts)
+		}
+	})
+
+	t.Run("WithKeyHeader ignores empty header or nil fn", func(t *testing.T) {
+		t.Parallel()
+
+		fn := func(_ string) climiter.Limiter { return &fakeLimiter{} }
+
+		opts := NewOptions(WithKeyHeader("", fn), WithKeyHeader("tenant", nil))
+		if opts.keyHeader != "" || opts.limiterFn != nil {
+			t.Fatalf("expected no key header, got %q", opts.keyHeader)
+		}
+	})
+
+	t.Run("WithBucketIdleTTL and WithClock ignore invalid values", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithBucketIdleTTL(0), WithBucketIdleTTL(-time.Second), WithClock(nil))
+		if opts.bucketIdleTTL != DefaultBucketIdleTTL || opts.clock == nil {
+			t.Fatalf("expected eviction defaults preserved, got %+v", opts)
+		}
+	})
+
+	t.Run("WithErrorHandler(nil) is a no-op", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithErrorHandler(nil))
+		if opts.errorHandler == nil {
+			t.Fatal("nil handler should not override the default")
+		}
+	})
+
+	t.Run("WithDropHandler(nil) is a no-op", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithDropHandler(nil))
+		if opts.dropHandler != nil {
+			t.Fatal("nil handler should leave the drop handler unset")
+		}
+	})
+}
+
+func TestThrottler_Stats(t *testing.T) {
+	t.Parallel()
+
+	src := messaging.NewPipelineChannel[int]()
+	dst := &failingChannel[int]{}
+
+	c := NewThrottler("stats", src, dst, &fakeLimiter{tokens: 2},
+		WithFailFast(),
+		WithErrorHandler(messaging.SilentErrorHandler),
+	)
+
+	err := c.Start(context.Background())
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+
+	t.Cleanup(func() { _ = c.Stop(context.Background()) })
+
+	err = src.Send(context.Background(), messaging.Message[int]{Payload: 1})
+	if err != nil {
+		t.Fatalf("send: %v", err)
+	}
+
+	dst.err = errors.New("down")
+
+	for i := range 2 {
+		err = src.Send(context.Background(), messaging.Message[int]{Payload: i})
+		if err != nil {
+			t.Fatalf("send: %v", err)
+		}
+	}
+
+	got := c.Stats()
+	if got.Subscribers != 1 || got.Delivered != 3 || got.Sent != 1 || got.Failed != 1 || got.Dropped != 1 {
+		t.Fatalf("Stats = %+v, want Subscribers=1 Delivered=3 Sent=1 Failed=1 Dropped=1", got)
+	}
+}
+
+// failingChannel is a Channel[T] test double whose Send always returns
+// the configured err. Subscribe is a no-op returning a no-op Cancel.
+type failingChannel[T any] struct {
+	err error
+}
+
+func (c *failingChannel[T]) Send(_ context.Context, _ messaging.Message[T]) error {
+	return c.err
+}
+
+func (c *failingChannel[T]) Subscribe(_ messaging.Handler[T]) (messaging.Cancel, error) {
+	return func() {}, nil
+}
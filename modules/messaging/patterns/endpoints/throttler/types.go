@@ -0,0 +1,96 @@
+// Package throttler provides a Throttler endpoint over
+// messaging.Channel[T].
+//
+// A Throttler subscribes to a source Channel[T] and forwards each
+// received Message[T] to a destination Channel[T] at a rate bounded by
+// a core/common/resilience/limiter.Limiter. Use it in front of
+// consumers that call rate-limited downstreams (third-party APIs,
+// shared databases) so a burst on the source channel does not turn
+// into a burst on the downstream.
+//
+// # Shared and keyed buckets
+//
+// By default every message draws from the single Limiter passed at
+// construction. WithKeyHeader switches to one bucket per distinct value
+// of Headers.Custom[header] — typically a tenant identifier — built on
+// first use by the supplied LimiterFn, so a noisy tenant exhausts its
+// own budget without starving the others. Messages that do not carry
+// the header keep drawing from the shared Limiter. A keyed bucket left
+// unused for WithBucketIdleTTL (DefaultBucketIdleTTL by default) is
+// evicted, so a high-cardinality key space does not grow memory
+// without bound; the next message for that key builds a fresh bucket.
+// Pick a TTL longer than a bucket takes to refill so eviction never
+// hands a key a fuller budget than it would have had.
+//
+// # Waiting vs failing fast
+//
+// By default the handler blocks in Limiter.Wait until a token is
+// available, applying back-pressure to the source channel's
+// dispatcher. WithFailFast switches to Limiter.Allow: messages that
+// find the bucket empty are dropped and routed to WithDropHandler.
+// Stop cancels every pending Wait.
+//
+// # Lifecycle
+//
+// Throttler implements common/lifecycle.Component (worker-style):
+// Start registers the subscription on the source channel and returns
+// immediately; Stop cancels the subscription and closes Done.
+// Throttler does not spawn goroutines of its own — dispatch
+// concurrency is inherited from the source channel implementation.
+//
+// # Error handling
+//
+// The handler installed on the source channel always returns nil.
+// Wait failures and forward Send failures are surfaced via the
+// Throttler's own ErrorHandler (installed with WithErrorHandler,
+// defaulting to messaging.DefaultErrorHandler which logs via
+// common/log); fail-fast rejections flow through WithDropHandler,
+// consistent with the package-wide policy in
+// modules/messaging/CODING_STANDARDS.md.
+package throttler
+
+import (
+	"context"
+	"time"
+
+	"github.com/guidomantilla/yarumo/core/common/lifecycle"
+	climiter "github.com/guidomantilla/yarumo/core/common/resilience/limiter"
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+var (
+	_ Throttler[any] = (*throttler[any])(nil)
+
+	_ ErrThrottlerFn = ErrThrottler
+)
+
+// Throttler is the public interface for a Throttler endpoint. It
+// embeds lifecycle.Component so callers wire it up with
+// lifecycle.Build, and messaging.Inspectable to expose its runtime
+// statistics. The interface exists (rather than returning
+// lifecycle.Component directly) so the consumer's API surface
+// preserves "this is a Throttler" semantics and the type stays open
+// to future throttler-specific methods without breaking callers.
+type Throttler[T any] interface {
+	lifecycle.Component
+	messaging.Inspectable
+}
+
+// DefaultBucketIdleTTL is the default time a keyed bucket may stay
+// unused before it is evicted. Override with WithBucketIdleTTL.
+const DefaultBucketIdleTTL = 10 * time.Minute
+
+// LimiterFn builds the Limiter of a keyed bucket. It is invoked once
+// per distinct key, the first time a message carrying that key is
+// throttled, and must return a non-nil Limiter.
+type LimiterFn func(key string) climiter.Limiter
+
+// DropHandler is the optional observability hook invoked once per
+// message rejected in fail-fast mode because its bucket was empty.
+// msg is type-erased; cast it inside the hook when payload-specific
+// behavior is needed. The hook is invoked synchronously from the
+// source channel's dispatcher and must not block.
+type DropHandler func(ctx context.Context, msg any)
+
+// ErrThrottlerFn is the function type for ErrThrottler.
+type ErrThrottlerFn func(causes ...error) error
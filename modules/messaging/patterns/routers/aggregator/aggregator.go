@@ -2,7 +2,9 @@ package aggregator
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	cassert "github.com/guidomantilla/yarumo/core/common/assert"
@@ -41,6 +43,7 @@ const minSweeperTick = 10 * time.Millisecond
 //   - WithMaxGroups caps in-flight groups (default 1000) for memory
 //     bounding; n+1-th group fires WithErrorHandler.
 //   - WithErrorHandler / WithDropHandler install observability hooks.
+//   - WithClock replaces the system clock (deterministic tests).
 func NewAggregator[T, U any](name string, src messaging.Channel[T], dst messaging.Channel[U], aggregate AggregateFn[T, U], opts ...Option[T]) Aggregator[T, U] {
 	cassert.NotEmpty(name, "name is empty")
 	cassert.NotNil(src, "source channel is nil")
@@ -53,7 +56,7 @@ func NewAggregator[T, U any](name string, src messaging.Channel[T], dst messagin
 		panic("aggregator requires at least one completion strategy: WithCompletionSize, WithCompletionFn or WithGroupTimeout")
 	}
 
-	return &aggregator[T, U]{
+	a := &aggregator[T, U]{
 		name:           name,
 		src:            src,
 		dst:            dst,
@@ -65,9 +68,15 @@ func NewAggregator[T, U any](name string, src messaging.Channel[T], dst messagin
 		maxGroups:      options.maxGroups,
 		errorHandler:   options.errorHandler,
 		dropHandler:    options.dropHandler,
+		expiredHandler: options.expiredHandler,
+		clock:          options.clock,
 		done:           make(chan struct{}),
 		groups:         map[string]*group[T]{},
 	}
+
+	a.stats.UseClock(options.clock)
+
+	return a
 }
 
 // Name returns the aggregator's identity used in lifecycle logs.
@@ -159,12 +168,38 @@ func (a *aggregator[T, U]) Done() <-chan struct{} {
 	return a.done
 }
 
+// Stats returns a snapshot of the aggregator's runtime statistics.
+// Sent counts the aggregates forwarded to the destination and
+// BufferLength the messages held in incomplete groups. Subscribers is
+// 1 while the source subscription is active.
+func (a *aggregator[T, U]) Stats() messaging.Stats {
+	cassert.NotNil(a, "aggregator is nil")
+
+	stats := a.stats.Snapshot()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.cancel != nil {
+		stats.Subscribers = 1
+	}
+
+	for _, g := range a.groups {
+		stats.BufferLength += len(g.msgs)
+	}
+
+	return stats
+}
+
 // handle is the Handler[T] subscribed on the source channel. It
 // extracts the correlation key, appends the message to (or creates) the
 // matching group, checks completion, and releases the group when
 // complete. Always returns nil so aggregation concerns never propagate
 // to the source channel's Send caller.
 func (a *aggregator[T, U]) handle(ctx context.Context, msg messaging.Message[T]) error {
+	end := a.stats.Begin()
+	defer end(nil)
+
 	key := a.correlation(msg)
 	if key == "" {
 		a.reportDrop(ctx, msg)
@@ -183,13 +218,13 @@ func (a *aggregator[T, U]) handle(ctx context.Context, msg messaging.Message[T])
 			return nil
 		}
 
-		now := time.Now()
+		now := a.clock.Now()
 		g = &group[T]{firstSeen: now}
 		a.groups[key] = g
 	}
 
 	g.msgs = append(g.msgs, msg)
-	g.lastSeen = time.Now()
+	g.lastSeen = a.clock.Now()
 
 	if !a.isComplete(g) {
 		a.mu.Unlock()
@@ -201,7 +236,10 @@ func (a *aggregator[T, U]) handle(ctx context.Context, msg messaging.Message[T])
 	snapshot := g.msgs
 	a.mu.Unlock()
 
-	a.release(ctx, snapshot, nil)
+	err := a.release(ctx, a.dropExpired(ctx, snapshot), nil)
+	if err != nil {
+		a.reportError(ctx, nil, err)
+	}
 
 	return nil
 }
@@ -229,14 +267,14 @@ func (a *aggregator[T, U]) isComplete(g *group[T]) bool {
 func (a *aggregator[T, U]) runSweeper(workerCtx context.Context) {
 	interval := max(a.groupTimeout/sweeperTickDivisor, minSweeperTick)
 
-	ticker := time.NewTicker(interval)
+	ticker := a.clock.NewTicker(interval)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-workerCtx.Done():
 			return
-		case <-ticker.C:
+		case <-ticker.C():
 			a.sweepExpired(workerCtx)
 		}
 	}
@@ -248,7 +286,7 @@ func (a *aggregator[T, U]) runSweeper(workerCtx context.Context) {
 // hook invocations) outside the lock so concurrent producers do not
 // stall waiting for AggregateFn or destination Send to complete.
 func (a *aggregator[T, U]) sweepExpired(ctx context.Context) {
-	now := time.Now()
+	now := a.clock.Now()
 
 	a.mu.Lock()
 
@@ -265,7 +303,7 @@ func (a *aggregator[T, U]) sweepExpired(ctx context.Context) {
 	a.mu.Unlock()
 
 	for _, msgs := range releases {
-		a.release(ctx, msgs, ErrGroupExpired)
+		a.flush(ctx, msgs, ErrGroupExpired)
 	}
 }
 
@@ -284,31 +322,49 @@ func (a *aggregator[T, U]) drainRemaining(ctx context.Context) {
 	a.mu.Unlock()
 
 	for _, msgs := range snapshots {
-		a.release(ctx, msgs, nil)
+		a.flush(ctx, msgs, nil)
 	}
 }
 
-// release folds the group into a single Message[U] via AggregateFn
-// (under panic recovery) and forwards it to dst. Failures route through
-// the ErrorHandler; reason is joined into the error when non-nil so
-// callers can distinguish timeout-released aggregations from normal
-// completions (reason == ErrGroupExpired).
-func (a *aggregator[T, U]) release(ctx context.Context, msgs []messaging.Message[T], reason error) {
-	if len(msgs) == 0 {
+// flush releases a group evicted by the sweeper or by Stop. Its
+// messages were counted as delivered when they arrived, so a failed
+// release counts them as dropped rather than as a failed delivery;
+// the error still reaches the ErrorHandler.
+func (a *aggregator[T, U]) flush(ctx context.Context, msgs []messaging.Message[T], reason error) {
+	msgs = a.dropExpired(ctx, msgs)
+
+	err := a.release(ctx, msgs, reason)
+	if err == nil {
 		return
 	}
 
+	for range msgs {
+		a.stats.RecordDrop()
+	}
+
+	if a.errorHandler != nil {
+		a.errorHandler(ctx, nil, err)
+	}
+}
+
+// release folds the live messages of a group into a single Message[U]
+// via AggregateFn (under panic recovery) and forwards it to dst. The
+// failure is returned for the caller to report; reason is joined into
+// it when non-nil so callers can distinguish timeout-released
+// aggregations from normal completions (reason == ErrGroupExpired).
+// An empty group (every message expired) is not aggregated at all.
+func (a *aggregator[T, U]) release(ctx context.Context, msgs []messaging.Message[T], reason error) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+
 	out, err := a.aggregateWithRecover(msgs)
 	if err != nil {
 		if reason != nil {
-			a.reportError(ctx, nil, ErrAggregator(reason, err))
-
-			return
+			return ErrAggregator(reason, err)
 		}
 
-		a.reportError(ctx, nil, err)
-
-		return
+		return err
 	}
 
 	err = a.dst.Send(ctx, out)
@@ -318,8 +374,37 @@ func (a *aggregator[T, U]) release(ctx context.Context, msgs []messaging.Message
 			causes = append(causes, reason)
 		}
 
-		a.reportError(ctx, nil, ErrAggregator(causes...))
+		return ErrAggregator(causes...)
 	}
+
+	a.stats.RecordSent()
+
+	return nil
+}
+
+// dropExpired returns the messages of msgs that have not expired,
+// reporting every expired one through reportExpired. The input slice
+// is returned as-is when nothing expired.
+func (a *aggregator[T, U]) dropExpired(ctx context.Context, msgs []messaging.Message[T]) []messaging.Message[T] {
+	now := a.clock.Now()
+
+	if !slices.ContainsFunc(msgs, func(m messaging.Message[T]) bool { return messaging.IsExpired(m, now) }) {
+		return msgs
+	}
+
+	live := make([]messaging.Message[T], 0, len(msgs))
+
+	for _, m := range msgs {
+		if messaging.IsExpired(m, now) {
+			a.reportExpired(ctx, m)
+
+			continue
+		}
+
+		live = append(live, m)
+	}
+
+	return live
 }
 
 // aggregateWithRecover invokes AggregateFn under panic recovery. A
@@ -346,6 +431,8 @@ func (a *aggregator[T, U]) aggregateWithRecover(msgs []messaging.Message[T]) (ou
 // reportError forwards err to the configured ErrorHandler. The handler
 // is guaranteed non-nil by NewOptions; the nil-guard is defensive only.
 func (a *aggregator[T, U]) reportError(ctx context.Context, msg any, err error) {
+	a.stats.RecordFailure()
+
 	if a.errorHandler == nil {
 		return
 	}
@@ -357,9 +444,28 @@ func (a *aggregator[T, U]) reportError(ctx context.Context, msg any, err error)
 // nil by default (silent drops); the guard skips invocation in that
 // case.
 func (a *aggregator[T, U]) reportDrop(ctx context.Context, msg any) {
+	a.stats.RecordDrop()
+
 	if a.dropHandler == nil {
 		return
 	}
 
 	a.dropHandler(ctx, msg)
 }
+
+// reportExpired counts an expired msg as dropped and forwards it to
+// the configured expired handler, or to the DropHandler when none is
+// installed.
+func (a *aggregator[T, U]) reportExpired(ctx context.Context, msg messaging.Message[T]) {
+	a.stats.RecordDrop()
+
+	if a.expiredHandler != nil {
+		a.expiredHandler(ctx, msg, errors.Join(messaging.ErrExpired, messaging.ErrDropped))
+
+		return
+	}
+
+	if a.dropHandler != nil {
+		a.dropHandler(ctx, msg)
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/guidomantilla/yarumo/core/common/lifecycle"
 	"github.com/guidomantilla/yarumo/messaging"
+	"github.com/guidomantilla/yarumo/messaging/messagingtest"
 )
 
 // captureErrors returns a thread-safe ErrorHandler that appends every
@@ -317,6 +318,47 @@ func TestAggregator_TimeoutCompletion(t *testing.T) {
 			t.Fatalf("expected [7 8] sweeper release, got %v", got)
 		}
 	})
+
+	t.Run("group timeout elapses on the injected clock", func(t *testing.T) {
+		t.Parallel()
+
+		clock := messagingtest.NewFakeClock(time.Time{})
+		src := messaging.NewPipelineChannel[int]()
+		dst := messagingtest.NewRecordingChannel[[]int]()
+
+		a := NewAggregator("test", src, dst, sumAggregate,
+			WithCompletionSize[int](5),
+			WithGroupTimeout[int](time.Hour),
+			WithClock[int](clock))
+
+		err := a.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		defer func() {
+			_ = a.Stop(context.Background())
+		}()
+
+		ctx := context.Background()
+
+		err = src.Send(ctx, msgWith(7, "slow"))
+		if err != nil {
+			t.Fatalf("send 7: %v", err)
+		}
+
+		err = src.Send(ctx, msgWith(8, "slow"))
+		if err != nil {
+			t.Fatalf("send 8: %v", err)
+		}
+
+		clock.WaitForTimers(t, 1)
+		clock.Advance(30 * time.Minute)
+		clock.Advance(30 * time.Minute)
+
+		dst.WaitFor(t, 1)
+		dst.AssertPayloads(t, []int{7, 8})
+	})
 }
 
 func TestAggregator_EmptyCorrelation(t *testing.T) {
@@ -883,6 +925,99 @@ func TestAggregator_PredicateCompletion(t *testing.T) {
 	})
 }
 
+func TestAggregator_Expiration(t *testing.T) {
+	t.Parallel()
+
+	stale := func(payload int) messaging.Message[int] {
+		msg := msgWith(payload, "g1")
+		msg.Headers.ExpirationTime = time.Now().Add(-time.Minute)
+
+		return msg
+	}
+
+	t.Run("expired messages are left out of the aggregate", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		dst := messaging.NewPipelineChannel[[]int]()
+
+		sink, getCaptured := collect()
+		expiredHandler, getExpired := captureErrors()
+
+		_, err := dst.Subscribe(sink)
+		if err != nil {
+			t.Fatalf("subscribe dst: %v", err)
+		}
+
+		a := NewAggregator("test", src, dst, sumAggregate,
+			WithCompletionSize[int](3),
+			WithExpiredHandler[int](expiredHandler),
+		)
+
+		err = a.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		for _, m := range []messaging.Message[int]{msgWith(1, "g1"), stale(2), msgWith(3, "g1")} {
+			err = src.Send(context.Background(), m)
+			if err != nil {
+				t.Fatalf("send: %v", err)
+			}
+		}
+
+		captured := getCaptured()
+		if len(captured) != 1 || len(captured[0]) != 2 || captured[0][0] != 1 || captured[0][1] != 3 {
+			t.Fatalf("expected [[1 3]], got %v", captured)
+		}
+
+		expired := getExpired()
+		if len(expired) != 1 || !errors.Is(expired[0], messaging.ErrExpired) {
+			t.Fatalf("expected one ErrExpired report, got %v", expired)
+		}
+	})
+
+	t.Run("fully expired group is not aggregated and falls back to the drop handler", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		dst := messaging.NewPipelineChannel[[]int]()
+
+		sink, getCaptured := collect()
+		dropHandler, getDrops := captureDrops()
+
+		_, err := dst.Subscribe(sink)
+		if err != nil {
+			t.Fatalf("subscribe dst: %v", err)
+		}
+
+		a := NewAggregator("test", src, dst, sumAggregate,
+			WithCompletionSize[int](2),
+			WithDropHandler[int](dropHandler),
+		)
+
+		err = a.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		for _, m := range []messaging.Message[int]{stale(1), stale(2)} {
+			err = src.Send(context.Background(), m)
+			if err != nil {
+				t.Fatalf("send: %v", err)
+			}
+		}
+
+		if len(getCaptured()) != 0 {
+			t.Fatalf("expected no aggregate, got %v", getCaptured())
+		}
+
+		if getDrops() != 2 {
+			t.Fatalf("expected 2 drops, got %d", getDrops())
+		}
+	})
+}
+
 func TestAggregator_Options(t *testing.T) {
 	t.Parallel()
 
@@ -949,6 +1084,26 @@ func TestAggregator_Options(t *testing.T) {
 		}
 	})
 
+	t.Run("WithClock(nil) is a no-op", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions[int](WithClock[int](nil))
+		if opts.clock == nil {
+			t.Fatal("expected system clock preserved on nil arg")
+		}
+	})
+
+	t.Run("WithClock installs the clock", func(t *testing.T) {
+		t.Parallel()
+
+		clock := messagingtest.NewFakeClock(time.Time{})
+
+		opts := NewOptions[int](WithClock[int](clock))
+		if opts.clock != clock {
+			t.Fatalf("expected the fake clock, got %T", opts.clock)
+		}
+	})
+
 	t.Run("defaults install messaging.DefaultErrorHandler and headers correlation", func(t *testing.T) {
 		t.Parallel()
 
@@ -981,3 +1136,80 @@ func (c *failingChannel[T]) Send(_ context.Context, _ messaging.Message[T]) erro
 func (c *failingChannel[T]) Subscribe(_ messaging.Handler[T]) (messaging.Cancel, error) {
 	return func() {}, nil
 }
+
+func TestAggregator_Stats(t *testing.T) {
+	t.Parallel()
+
+	t.Run("counts releases, drops and held messages", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		dst := messaging.NewPipelineChannel[[]int]()
+
+		a := NewAggregator("test", src, dst, sumAggregate, WithCompletionSize[int](2))
+
+		err := a.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		t.Cleanup(func() { _ = a.Stop(context.Background()) })
+
+		// No expired or drop handler: the expiry is still counted.
+		expired := msgWith(1, "a")
+		expired.Headers.ExpirationTime = time.Now().Add(-time.Minute)
+
+		for _, msg := range []messaging.Message[int]{expired, msgWith(2, "a"), msgWith(3, ""), msgWith(4, "b")} {
+			err = src.Send(context.Background(), msg)
+			if err != nil {
+				t.Fatalf("send %d: %v", msg.Payload, err)
+			}
+		}
+
+		got := a.Stats()
+		if got.Subscribers != 1 || got.Delivered != 4 || got.Sent != 1 || got.Dropped != 2 || got.Failed != 0 || got.BufferLength != 1 {
+			t.Fatalf("Stats = %+v, want Subscribers=1 Delivered=4 Sent=1 Dropped=2 Failed=0 BufferLength=1", got)
+		}
+	})
+
+	t.Run("sweeper release failures count as drops", func(t *testing.T) {
+		t.Parallel()
+
+		clock := messagingtest.NewFakeClock(time.Time{})
+		src := messaging.NewPipelineChannel[int]()
+		dst := &failingChannel[[]int]{err: errors.New("dst down")}
+
+		errHandler, getErrs := captureErrors()
+
+		a := NewAggregator("test", src, dst, sumAggregate,
+			WithCompletionSize[int](2),
+			WithGroupTimeout[int](time.Hour),
+			WithErrorHandler[int](errHandler),
+			WithClock[int](clock))
+
+		err := a.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		t.Cleanup(func() { _ = a.Stop(context.Background()) })
+
+		for _, msg := range []messaging.Message[int]{msgWith(1, "a"), msgWith(2, "a"), msgWith(3, "b")} {
+			err = src.Send(context.Background(), msg)
+			if err != nil {
+				t.Fatalf("send %d: %v", msg.Payload, err)
+			}
+		}
+
+		clock.WaitForTimers(t, 1)
+		clock.Advance(30 * time.Minute)
+		clock.Advance(30 * time.Minute)
+
+		messagingtest.WaitUntil(t, func() bool { return len(getErrs()) == 2 }, "expected the sweeper release failure reported")
+
+		got := a.Stats()
+		if got.Delivered != 3 || got.Sent != 0 || got.Failed != 1 || got.Dropped != 1 || got.BufferLength != 0 {
+			t.Fatalf("Stats = %+v, want Delivered=3 Sent=0 Failed=1 Dropped=1 BufferLength=0", got)
+		}
+	})
+}
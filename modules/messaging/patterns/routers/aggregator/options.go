@@ -29,14 +29,17 @@ type Options[T any] struct {
 	maxGroups      int
 	errorHandler   messaging.ErrorHandler
 	dropHandler    DropHandler
+	expiredHandler messaging.ErrorHandler
+	clock          messaging.Clock
 }
 
 // NewOptions creates a new Options[T] with sensible defaults and
 // applies the given options. The default CorrelationFn reads
 // msg.Headers.CorrelationID; the default ErrorHandler is
 // messaging.DefaultErrorHandler; the default MaxGroups cap is
-// defaultMaxGroups; the default DropHandler is nil (silent intentional
-// drops); no completion strategy is configured by default — at least
+// defaultMaxGroups; the default DropHandler and expired handler are
+// nil (silent intentional drops); the clock is messaging.SystemClock;
+// no completion strategy is configured by default — at least
 // one of WithCompletionFn, WithCompletionSize or WithGroupTimeout MUST
 // be passed by the caller.
 func NewOptions[T any](opts ...Option[T]) *Options[T] {
@@ -44,6 +47,7 @@ func NewOptions[T any](opts ...Option[T]) *Options[T] {
 		correlation:  defaultCorrelation[T],
 		maxGroups:    defaultMaxGroups,
 		errorHandler: messaging.DefaultErrorHandler,
+		clock:        messaging.SystemClock(),
 	}
 
 	for _, opt := range opts {
@@ -150,3 +154,30 @@ func WithDropHandler[T any](handler DropHandler) Option[T] {
 		}
 	}
 }
+
+// WithExpiredHandler installs an observability hook fired once per
+// message left out of an aggregation because its
+// Headers.ExpirationTime had passed when the group was released. The
+// hook receives the expired message and messaging.ErrExpired joined
+// with messaging.ErrDropped. The default (when WithExpiredHandler is
+// not passed) reports expired messages through WithDropHandler. Nil
+// values are ignored.
+func WithExpiredHandler[T any](handler messaging.ErrorHandler) Option[T] {
+	return func(opts *Options[T]) {
+		if handler != nil {
+			opts.expiredHandler = handler
+		}
+	}
+}
+
+// WithClock sets the time source for group idle tracking, the
+// WithGroupTimeout sweeper ticker and message expiry checks. Tests pass
+// a messagingtest.FakeClock to release timed-out groups without
+// sleeping. Nil values are ignored (the system clock is preserved).
+func WithClock[T any](clock messaging.Clock) Option[T] {
+	return func(opts *Options[T]) {
+		if clock != nil {
+			opts.clock = clock
+		}
+	}
+}
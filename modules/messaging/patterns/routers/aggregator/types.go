@@ -55,6 +55,10 @@
 //     expired groups released by the sweeper when consumers want to
 //     audit "timed-out groups"). Default is nil — silent drop.
 //
+// Messages whose Headers.ExpirationTime has passed when their group is
+// released are left out of the aggregation and reported through
+// WithExpiredHandler (WithDropHandler when none is installed).
+//
 // # Lifecycle
 //
 // Aggregator implements common/lifecycle.Component (worker-style). Start
@@ -92,13 +96,15 @@ var (
 )
 
 // Aggregator is the public interface for an Aggregator pattern. It
-// embeds lifecycle.Component so callers wire it up with lifecycle.Build.
-// The interface exists (rather than returning lifecycle.Component
-// directly) so the consumer's API surface preserves "this is an
-// Aggregator" semantics and the type stays open to future aggregator-
-// specific methods without breaking callers.
+// embeds lifecycle.Component so callers wire it up with
+// lifecycle.Build, and messaging.Inspectable to expose its runtime
+// statistics. The interface exists (rather than returning
+// lifecycle.Component directly) so the consumer's API surface
+// preserves "this is an Aggregator" semantics and the type stays open
+// to future aggregator- specific methods without breaking callers.
 type Aggregator[T, U any] interface {
 	lifecycle.Component
+	messaging.Inspectable
 }
 
 // CorrelationFn extracts the correlation key from msg. The default
@@ -136,6 +142,8 @@ type AggregateFn[T, U any] func(group []messaging.Message[T]) (messaging.Message
 // key from CorrelationFn, group expired by the sweeper without
 // completing (one DropHandler call per expired-and-empty group), and
 // — for visibility — the drained partial groups released during Stop.
+// It also receives expired messages left out of an aggregation when no
+// WithExpiredHandler is installed.
 // It does NOT fire on real failures (AggregateFn error/panic, forward
 // Send failure, MaxGroups exceeded), which route to the ErrorHandler
 // instead.
@@ -172,6 +180,9 @@ type aggregator[T, U any] struct {
 	maxGroups      int
 	errorHandler   messaging.ErrorHandler
 	dropHandler    DropHandler
+	expiredHandler messaging.ErrorHandler
+	clock          messaging.Clock
+	stats          messaging.StatsRecorder
 
 	done         chan struct{}
 	workerCancel context.CancelFunc
@@ -21,6 +21,7 @@ type filter[T any] struct {
 	predicate    PredicateFn[T]
 	errorHandler messaging.ErrorHandler
 	dropHandler  DropHandler
+	stats        messaging.StatsRecorder
 
 	done      chan struct{}
 	startOnce sync.Once
@@ -132,12 +133,33 @@ func (f *filter[T]) Done() <-chan struct{} {
 	return f.done
 }
 
+// Stats returns a snapshot of the filter's runtime statistics:
+// Delivered counts messages taken from the source, Sent the ones
+// forwarded to the destination and Dropped the ones the predicate
+// rejected. Subscribers is 1 while the source subscription is active.
+func (f *filter[T]) Stats() messaging.Stats {
+	cassert.NotNil(f, "filter is nil")
+
+	stats := f.stats.Snapshot()
+
+	f.mu.Lock()
+	if f.cancel != nil {
+		stats.Subscribers = 1
+	}
+	f.mu.Unlock()
+
+	return stats
+}
+
 // handle is the Handler[T] subscribed on the source channel. It runs
 // the predicate under panic recovery, then either forwards the message
 // or routes it to the DropHandler. The function itself always returns
 // nil so filter concerns never propagate to the source channel's Send
 // caller.
 func (f *filter[T]) handle(ctx context.Context, msg messaging.Message[T]) error {
+	end := f.stats.Begin()
+	defer end(nil)
+
 	pass, err := f.evalWithRecover(ctx, msg)
 	if err != nil {
 		f.reportError(ctx, msg, err)
@@ -146,6 +168,7 @@ func (f *filter[T]) handle(ctx context.Context, msg messaging.Message[T]) error
 	}
 
 	if !pass {
+		f.stats.RecordDrop()
 		f.reportDrop(ctx, msg)
 
 		return nil
@@ -154,8 +177,12 @@ func (f *filter[T]) handle(ctx context.Context, msg messaging.Message[T]) error
 	err = f.dst.Send(ctx, msg)
 	if err != nil {
 		f.reportError(ctx, msg, ErrFilter(ErrForwardFailed, err))
+
+		return nil
 	}
 
+	f.stats.RecordSent()
+
 	return nil
 }
 
@@ -181,10 +208,13 @@ func (f *filter[T]) evalWithRecover(ctx context.Context, msg messaging.Message[T
 	return pass, nil
 }
 
-// reportError forwards err to the configured ErrorHandler. ErrorHandler
-// is guaranteed non-nil by NewOptions (defaults to
-// messaging.DefaultErrorHandler), so the nil-guard is defensive only.
+// reportError counts the failure and forwards err to the configured
+// ErrorHandler. ErrorHandler is guaranteed non-nil by NewOptions
+// (defaults to messaging.DefaultErrorHandler), so the nil-guard is
+// defensive only.
 func (f *filter[T]) reportError(ctx context.Context, msg messaging.Message[T], err error) {
+	f.stats.RecordFailure()
+
 	if f.errorHandler == nil {
 		return
 	}
@@ -587,3 +587,49 @@ func (c *failingChannel[T]) Send(_ context.Context, _ messaging.Message[T]) erro
 func (c *failingChannel[T]) Subscribe(_ messaging.Handler[T]) (messaging.Cancel, error) {
 	return func() {}, nil
 }
+
+func TestFilter_Stats(t *testing.T) {
+	t.Parallel()
+
+	src := messaging.NewPipelineChannel[int]()
+	dst := messaging.NewPipelineChannel[int]()
+
+	predicate := func(_ context.Context, msg messaging.Message[int]) (bool, error) {
+		if msg.Payload < 0 {
+			return false, errors.New("negative")
+		}
+
+		return msg.Payload%2 == 0, nil
+	}
+
+	f := NewFilter("stats", src, dst, predicate, WithErrorHandler(messaging.SilentErrorHandler))
+
+	got := f.Stats()
+	if got.Subscribers != 0 {
+		t.Fatalf("Subscribers before Start = %d, want 0", got.Subscribers)
+	}
+
+	err := f.Start(context.Background())
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+
+	for _, p := range []int{1, 2, 4, -1} {
+		err = src.Send(context.Background(), messaging.Message[int]{Payload: p})
+		if err != nil {
+			t.Fatalf("send %d: %v", p, err)
+		}
+	}
+
+	got = f.Stats()
+	if got.Subscribers != 1 || got.Delivered != 4 || got.Sent != 2 || got.Dropped != 1 || got.Failed != 1 || got.InFlight != 0 {
+		t.Fatalf("Stats = %+v, want Subscribers=1 Delivered=4 Sent=2 Dropped=1 Failed=1 InFlight=0", got)
+	}
+
+	_ = f.Stop(context.Background())
+
+	got = f.Stats()
+	if got.Subscribers != 0 {
+		t.Fatalf("Subscribers after Stop = %d, want 0", got.Subscribers)
+	}
+}
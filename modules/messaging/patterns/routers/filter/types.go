@@ -61,13 +61,15 @@ var (
 )
 
 // Filter is the public interface for a Message Filter. It embeds
-// lifecycle.Component so callers wire it up with lifecycle.Build. The
+// lifecycle.Component so callers wire it up with lifecycle.Build, and
+// messaging.Inspectable to expose its runtime statistics. The
 // interface exists (rather than returning lifecycle.Component
 // directly) so the consumer's API surface preserves "this is a
 // Filter" semantics and the type stays open to future filter-specific
 // methods without breaking callers.
 type Filter[T any] interface {
 	lifecycle.Component
+	messaging.Inspectable
 }
 
 // PredicateFn returns true to forward msg to the destination channel
@@ -21,6 +21,7 @@ type recipientList[T any] struct {
 	routes       map[string]messaging.Channel[T]
 	errorHandler messaging.ErrorHandler
 	dropHandler  DropHandler
+	stats        messaging.StatsRecorder
 
 	done      chan struct{}
 	startOnce sync.Once
@@ -134,19 +135,45 @@ func (r *recipientList[T]) Done() <-chan struct{} {
 	return r.done
 }
 
-// handle is the Handler[T] subscribed on the source channel. It runs
-// SelectorFn under panic recovery, then forwards the message to every
-// resolved recipient. Per-recipient errors are reported through the
-// configured ErrorHandler individually so a single failure does not
-// abort delivery to the others. The function itself always returns nil
-// so recipient list concerns never propagate to the source channel's
+// Stats returns a snapshot of the recipient list's runtime statistics.
+// Subscribers is 1 while the source subscription is active.
+func (r *recipientList[T]) Stats() messaging.Stats {
+	cassert.NotNil(r, "recipient list is nil")
+
+	stats := r.stats.Snapshot()
+
+	r.mu.Lock()
+	if r.cancel != nil {
+		stats.Subscribers = 1
+	}
+	r.mu.Unlock()
+
+	return stats
+}
+
+// handle is the Handler[T] subscribed on the source channel. It routes
+// msg and counts the delivery as failed at most once, however many of
+// its recipients failed. The function itself always returns nil so
+// recipient list concerns never propagate to the source channel's
 // Send caller.
 func (r *recipientList[T]) handle(ctx context.Context, msg messaging.Message[T]) error {
+	end := r.stats.Begin()
+	end(r.route(ctx, msg))
+
+	return nil
+}
+
+// route runs SelectorFn under panic recovery, then forwards msg to
+// every resolved recipient. Per-recipient errors are reported through
+// the configured ErrorHandler individually so a single failure does
+// not abort delivery to the others. It returns the last error
+// reported, nil when every recipient accepted msg.
+func (r *recipientList[T]) route(ctx context.Context, msg messaging.Message[T]) error {
 	keys, err := r.selectWithRecover(ctx, msg)
 	if err != nil {
 		r.reportError(ctx, msg, err)
 
-		return nil
+		return err
 	}
 
 	if len(keys) == 0 {
@@ -155,28 +182,41 @@ func (r *recipientList[T]) handle(ctx context.Context, msg messaging.Message[T])
 		return nil
 	}
 
+	var failed error
+
 	for _, key := range keys {
-		r.dispatchOne(ctx, msg, key)
+		err = r.dispatchOne(ctx, msg, key)
+		if err != nil {
+			failed = err
+		}
 	}
 
-	return nil
+	return failed
 }
 
 // dispatchOne resolves a single key and forwards msg to it. Missing
 // keys and forward failures are reported individually to the configured
-// ErrorHandler.
-func (r *recipientList[T]) dispatchOne(ctx context.Context, msg messaging.Message[T], key string) {
+// ErrorHandler and returned.
+func (r *recipientList[T]) dispatchOne(ctx context.Context, msg messaging.Message[T], key string) error {
 	dst, ok := r.routes[key]
 	if !ok {
-		r.reportError(ctx, msg, ErrRecipientList(ErrNoRoute, fmt.Errorf("key=%q", key)))
+		err := ErrRecipientList(ErrNoRoute, fmt.Errorf("key=%q", key))
+		r.reportError(ctx, msg, err)
 
-		return
+		return err
 	}
 
 	err := dst.Send(ctx, msg)
 	if err != nil {
-		r.reportError(ctx, msg, ErrRecipientList(ErrForwardFailed, fmt.Errorf("key=%q", key), err))
+		err = ErrRecipientList(ErrForwardFailed, fmt.Errorf("key=%q", key), err)
+		r.reportError(ctx, msg, err)
+
+		return err
 	}
+
+	r.stats.RecordSent()
+
+	return nil
 }
 
 // selectWithRecover invokes the user-supplied SelectorFn under panic
@@ -217,6 +257,8 @@ func (r *recipientList[T]) reportError(ctx context.Context, msg messaging.Messag
 // nil by default (silent drops); the guard skips invocation in that
 // case.
 func (r *recipientList[T]) reportDrop(ctx context.Context, msg messaging.Message[T]) {
+	r.stats.RecordDrop()
+
 	if r.dropHandler == nil {
 		return
 	}
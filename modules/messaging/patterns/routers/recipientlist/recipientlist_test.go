@@ -639,3 +639,52 @@ func (c *failingChannel[T]) Send(_ context.Context, _ messaging.Message[T]) erro
 func (c *failingChannel[T]) Subscribe(_ messaging.Handler[T]) (messaging.Cancel, error) {
 	return func() {}, nil
 }
+
+func TestRecipientList_Stats(t *testing.T) {
+	t.Parallel()
+
+	src := messaging.NewPipelineChannel[int]()
+	good := messaging.NewPipelineChannel[int]()
+	bad := &failingChannel[int]{err: errors.New("bad down")}
+
+	selector := func(_ context.Context, msg messaging.Message[int]) ([]string, error) {
+		switch msg.Payload {
+		case 0:
+			return nil, nil
+		case 1:
+			return []string{"good", "bad", keyMissing}, nil
+		default:
+			return []string{"good"}, nil
+		}
+	}
+
+	errHandler, getErrs := captureErrors()
+
+	r := NewRecipientList("test", src, selector,
+		map[string]messaging.Channel[int]{"good": good, "bad": bad},
+		WithErrorHandler(errHandler))
+
+	err := r.Start(context.Background())
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+
+	t.Cleanup(func() { _ = r.Stop(context.Background()) })
+
+	for i := range 3 {
+		err = src.Send(context.Background(), messaging.Message[int]{Payload: i})
+		if err != nil {
+			t.Fatalf("send %d: %v", i, err)
+		}
+	}
+
+	if len(getErrs()) != 2 {
+		t.Fatalf("expected one report per failed recipient, got %v", getErrs())
+	}
+
+	// Two recipients failed, but they failed the same delivery.
+	got := r.Stats()
+	if got.Subscribers != 1 || got.Delivered != 3 || got.Sent != 2 || got.Failed != 1 || got.Dropped != 1 {
+		t.Fatalf("Stats = %+v, want Subscribers=1 Delivered=3 Sent=2 Failed=1 Dropped=1", got)
+	}
+}
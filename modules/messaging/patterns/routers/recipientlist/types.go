@@ -51,14 +51,17 @@ var (
 	_ ErrRecipientListFn = ErrRecipientList
 )
 
-// RecipientList is the public interface for a Recipient List. It embeds
-// lifecycle.Component so callers wire it up with lifecycle.Build. The
-// interface exists (rather than returning lifecycle.Component directly)
-// so the consumer's API surface preserves "this is a RecipientList"
-// semantics and the type stays open to future recipient-list-specific
-// methods without breaking callers.
+// RecipientList is the public interface for a Recipient List. It
+// embeds lifecycle.Component so callers wire it up with
+// lifecycle.Build, and messaging.Inspectable to expose its runtime
+// statistics. The interface exists (rather than returning
+// lifecycle.Component directly) so the consumer's API surface
+// preserves "this is a RecipientList" semantics and the type stays
+// open to future recipient-list-specific methods without breaking
+// callers.
 type RecipientList[T any] interface {
 	lifecycle.Component
+	messaging.Inspectable
 }
 
 // SelectorFn returns the ordered list of destination keys for msg. Each
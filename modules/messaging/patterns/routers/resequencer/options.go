@@ -14,11 +14,13 @@ type Option func(opts *Options)
 
 // Options holds the configuration for a Resequencer.
 type Options struct {
-	groupTimeout  time.Duration
-	maxGroups     int
-	sweepInterval time.Duration
-	errorHandler  messaging.ErrorHandler
-	dropHandler   DropHandler
+	groupTimeout   time.Duration
+	maxGroups      int
+	sweepInterval  time.Duration
+	errorHandler   messaging.ErrorHandler
+	dropHandler    DropHandler
+	expiredHandler messaging.ErrorHandler
+	clock          messaging.Clock
 }
 
 // NewOptions creates a new Options with sensible defaults and applies
@@ -27,7 +29,8 @@ type Options struct {
 // default maxGroups is DefaultMaxGroups; the default sweepInterval is
 // DefaultSweepInterval; the default ErrorHandler is
 // messaging.DefaultErrorHandler (logs via common/log); the default
-// DropHandler is nil (silent unless wired).
+// DropHandler and expired handler are nil (silent unless wired); the
+// clock is messaging.SystemClock.
 func NewOptions(opts ...Option) *Options {
 	options := &Options{
 		groupTimeout:  0,
@@ -35,6 +38,7 @@ func NewOptions(opts ...Option) *Options {
 		sweepInterval: DefaultSweepInterval,
 		errorHandler:  messaging.DefaultErrorHandler,
 		dropHandler:   nil,
+		clock:         messaging.SystemClock(),
 	}
 
 	for _, opt := range opts {
@@ -111,3 +115,29 @@ func WithDropHandler(handler DropHandler) Option {
 		}
 	}
 }
+
+// WithExpiredHandler installs an observability hook fired once per
+// message withheld at emit because its Headers.ExpirationTime has
+// passed. The hook receives the expired message and
+// messaging.ErrExpired joined with messaging.ErrDropped. The default
+// (when WithExpiredHandler is not passed) reports expired messages
+// through WithDropHandler. Nil arguments are ignored.
+func WithExpiredHandler(handler messaging.ErrorHandler) Option {
+	return func(opts *Options) {
+		if handler != nil {
+			opts.expiredHandler = handler
+		}
+	}
+}
+
+// WithClock sets the time source for group deadlines, the sweeper
+// ticker and message expiry checks. Tests pass a
+// messagingtest.FakeClock to evict stale groups without sleeping. Nil
+// values are ignored (the system clock is preserved).
+func WithClock(clock messaging.Clock) Option {
+	return func(opts *Options) {
+		if clock != nil {
+			opts.clock = clock
+		}
+	}
+}
@@ -2,6 +2,7 @@ package resequencer
 
 import (
 	"context"
+	"errors"
 	"slices"
 	"sync"
 	"time"
@@ -32,14 +33,17 @@ type seqGroup[T any] struct {
 // and emits them in SequenceNumber order. A background sweeper
 // goroutine evicts groups whose missing position never arrives.
 type resequencer[T any] struct {
-	name          string
-	src           messaging.Channel[T]
-	dst           messaging.Channel[T]
-	groupTimeout  time.Duration
-	maxGroups     int
-	sweepInterval time.Duration
-	errorHandler  messaging.ErrorHandler
-	dropHandler   DropHandler
+	name           string
+	src            messaging.Channel[T]
+	dst            messaging.Channel[T]
+	groupTimeout   time.Duration
+	maxGroups      int
+	sweepInterval  time.Duration
+	errorHandler   messaging.ErrorHandler
+	dropHandler    DropHandler
+	expiredHandler messaging.ErrorHandler
+	clock          messaging.Clock
+	stats          messaging.StatsRecorder
 
 	groupsMu sync.Mutex
 	groups   map[string]*seqGroup[T]
@@ -78,6 +82,7 @@ type resequencer[T any] struct {
 //     custom hook for forward Send failures during emit.
 //   - WithDropHandler installs an optional hook for observing
 //     intentional drops; nil by default (silent drop).
+//   - WithClock replaces the system clock (deterministic tests).
 func NewResequencer[T any](name string, src messaging.Channel[T], dst messaging.Channel[T], opts ...Option) Resequencer[T] {
 	cassert.NotEmpty(name, "name is empty")
 	cassert.NotNil(src, "source channel is nil")
@@ -86,19 +91,25 @@ func NewResequencer[T any](name string, src messaging.Channel[T], dst messaging.
 	options := NewOptions(opts...)
 	cassert.True(options.groupTimeout > 0, "WithGroupTimeout is required (positive duration)")
 
-	return &resequencer[T]{
-		name:          name,
-		src:           src,
-		dst:           dst,
-		groupTimeout:  options.groupTimeout,
-		maxGroups:     options.maxGroups,
-		sweepInterval: options.sweepInterval,
-		errorHandler:  options.errorHandler,
-		dropHandler:   options.dropHandler,
-		groups:        map[string]*seqGroup[T]{},
-		done:          make(chan struct{}),
-		sweeperDone:   make(chan struct{}),
+	r := &resequencer[T]{
+		name:           name,
+		src:            src,
+		dst:            dst,
+		groupTimeout:   options.groupTimeout,
+		maxGroups:      options.maxGroups,
+		sweepInterval:  options.sweepInterval,
+		errorHandler:   options.errorHandler,
+		dropHandler:    options.dropHandler,
+		expiredHandler: options.expiredHandler,
+		clock:          options.clock,
+		groups:         map[string]*seqGroup[T]{},
+		done:           make(chan struct{}),
+		sweeperDone:    make(chan struct{}),
 	}
+
+	r.stats.UseClock(options.clock)
+
+	return r
 }
 
 // Name returns the resequencer's identity used in lifecycle logs.
@@ -183,11 +194,16 @@ func (r *resequencer[T]) Done() <-chan struct{} {
 // handle is the Handler[T] subscribed on the source channel. It
 // validates the message's sequence metadata, buffers it into its
 // correlation group, drains as many consecutive positions as it can,
-// and emits them to dst in order. Failures and drops flow through the
+// and emits them to dst in order. A message whose
+// Headers.ExpirationTime has passed keeps its position in the sequence
+// but is not emitted. Failures and drops flow through the
 // configured hooks; the function itself always returns nil so
 // resequencer concerns never propagate to the source channel's Send
 // caller.
 func (r *resequencer[T]) handle(ctx context.Context, msg messaging.Message[T]) error {
+	end := r.stats.Begin()
+	defer end(nil)
+
 	correlation := msg.Headers.CorrelationID
 	if correlation == "" {
 		r.reportDrop(ctx, msg)
@@ -216,16 +232,51 @@ func (r *resequencer[T]) handle(ctx context.Context, msg messaging.Message[T]) e
 		return nil
 	}
 
+	now := r.clock.Now()
+
 	for _, m := range emit {
+		if messaging.IsExpired(m, now) {
+			r.reportExpired(ctx, m)
+
+			continue
+		}
+
 		err := r.dst.Send(ctx, m)
 		if err != nil {
 			r.reportError(ctx, m, ErrResequencer(ErrForwardFailed, err))
+
+			continue
 		}
+
+		r.stats.RecordSent()
 	}
 
 	return nil
 }
 
+// Stats returns a snapshot of the resequencer's runtime statistics.
+// BufferLength is the number of messages held back waiting for a gap
+// to fill. Subscribers is 1 while the source subscription is active.
+func (r *resequencer[T]) Stats() messaging.Stats {
+	cassert.NotNil(r, "resequencer is nil")
+
+	stats := r.stats.Snapshot()
+
+	r.groupsMu.Lock()
+	for _, group := range r.groups {
+		stats.BufferLength += len(group.msgs)
+	}
+	r.groupsMu.Unlock()
+
+	r.subMu.Lock()
+	if r.subCancel != nil {
+		stats.Subscribers = 1
+	}
+	r.subMu.Unlock()
+
+	return stats
+}
+
 // bufferAndDrain stores msg in its correlation group at the given
 // seqNumber and drains as many consecutive positions starting from
 // nextEmit as possible, returning the contiguous slice to be emitted
@@ -238,7 +289,7 @@ func (r *resequencer[T]) bufferAndDrain(msg messaging.Message[T], correlation st
 	r.groupsMu.Lock()
 	defer r.groupsMu.Unlock()
 
-	now := time.Now()
+	now := r.clock.Now()
 
 	group, exists := r.groups[correlation]
 	if !exists {
@@ -293,6 +344,8 @@ func (r *resequencer[T]) bufferAndDrain(msg messaging.Message[T], correlation st
 // ErrorHandler is guaranteed non-nil by NewOptions, so the nil-guard
 // is defensive only.
 func (r *resequencer[T]) reportError(ctx context.Context, msg messaging.Message[T], err error) {
+	r.stats.RecordFailure()
+
 	if r.errorHandler == nil {
 		return
 	}
@@ -304,6 +357,8 @@ func (r *resequencer[T]) reportError(ctx context.Context, msg messaging.Message[
 // is nil by default (silent drops); the guard skips invocation in
 // that case.
 func (r *resequencer[T]) reportDrop(ctx context.Context, msg messaging.Message[T]) {
+	r.stats.RecordDrop()
+
 	if r.dropHandler == nil {
 		return
 	}
@@ -311,19 +366,36 @@ func (r *resequencer[T]) reportDrop(ctx context.Context, msg messaging.Message[T
 	r.dropHandler(ctx, msg)
 }
 
+// reportExpired counts an expired msg as dropped and forwards it to
+// the configured expired handler, or to the DropHandler when none is
+// installed.
+func (r *resequencer[T]) reportExpired(ctx context.Context, msg messaging.Message[T]) {
+	r.stats.RecordDrop()
+
+	if r.expiredHandler != nil {
+		r.expiredHandler(ctx, msg, errors.Join(messaging.ErrExpired, messaging.ErrDropped))
+
+		return
+	}
+
+	if r.dropHandler != nil {
+		r.dropHandler(ctx, msg)
+	}
+}
+
 // sweep is the timeout sweeper goroutine. It wakes on sweepInterval
 // and evicts any group whose lastSeen is older than groupTimeout,
 // firing the drop hook for every still-buffered (unforwarded)
 // message. The goroutine exits when sweeperDone is closed (Stop).
 func (r *resequencer[T]) sweep() {
-	ticker := time.NewTicker(r.sweepInterval)
+	ticker := r.clock.NewTicker(r.sweepInterval)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-r.sweeperDone:
 			return
-		case <-ticker.C:
+		case <-ticker.C():
 			r.sweepOnce()
 		}
 	}
@@ -333,7 +405,7 @@ func (r *resequencer[T]) sweep() {
 // groupsMu but the drop hook is invoked outside the lock so a slow
 // hook does not block incoming Send.
 func (r *resequencer[T]) sweepOnce() {
-	now := time.Now()
+	now := r.clock.Now()
 
 	type evicted struct {
 		msgs []messaging.Message[T]
@@ -10,6 +10,7 @@ import (
 
 	"github.com/guidomantilla/yarumo/core/common/lifecycle"
 	"github.com/guidomantilla/yarumo/messaging"
+	"github.com/guidomantilla/yarumo/messaging/messagingtest"
 )
 
 // captureErrors returns a thread-safe ErrorHandler that appends every
@@ -557,10 +558,13 @@ func TestResequencer_GroupTimeout(t *testing.T) {
 			t.Fatalf("subscribe: %v", err)
 		}
 
+		clock := messagingtest.NewFakeClock(time.Time{})
+
 		r := NewResequencer("test", src, dst,
 			WithGroupTimeout(50*time.Millisecond),
 			WithSweepInterval(10*time.Millisecond),
-			WithDropHandler(dropHandler))
+			WithDropHandler(dropHandler),
+			WithClock(clock))
 
 		err = r.Start(context.Background())
 		if err != nil {
@@ -582,14 +586,10 @@ func TestResequencer_GroupTimeout(t *testing.T) {
 			t.Fatalf("send: %v", err)
 		}
 
-		deadline := time.Now().Add(2 * time.Second)
-		for time.Now().Before(deadline) {
-			if getDrops() >= 2 {
-				break
-			}
+		clock.WaitForTimers(t, 1)
+		clock.Advance(60 * time.Millisecond)
 
-			time.Sleep(20 * time.Millisecond)
-		}
+		messagingtest.WaitUntil(t, func() bool { return getDrops() >= 2 }, "2 drops after timeout")
 
 		if got, want := getDrops(), int32(2); got != want {
 			t.Fatalf("expected 2 drops after timeout, got %d", got)
@@ -882,6 +882,17 @@ func TestResequencer_Options(t *testing.T) {
 		}
 	})
 
+	t.Run("WithClock installs the clock and ignores nil", func(t *testing.T) {
+		t.Parallel()
+
+		clock := messagingtest.NewFakeClock(time.Time{})
+
+		opts := NewOptions(WithClock(clock), WithClock(nil))
+		if opts.clock != clock {
+			t.Fatalf("expected the fake clock, got %T", opts.clock)
+		}
+	})
+
 	t.Run("non-positive options are ignored", func(t *testing.T) {
 		t.Parallel()
 
@@ -907,6 +918,94 @@ func TestResequencer_Options(t *testing.T) {
 	})
 }
 
+func TestResequencer_Expiration(t *testing.T) {
+	t.Parallel()
+
+	t.Run("expired positions are skipped without stalling the sequence", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		dst := messaging.NewPipelineChannel[int]()
+
+		dstHandler, getMsgs := captureMessages()
+		expiredHandler, getExpired := captureErrors()
+
+		_, err := dst.Subscribe(dstHandler)
+		if err != nil {
+			t.Fatalf("subscribe: %v", err)
+		}
+
+		r := NewResequencer("test", src, dst,
+			WithGroupTimeout(time.Second),
+			WithExpiredHandler(expiredHandler),
+		)
+
+		err = r.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		t.Cleanup(func() {
+			_ = r.Stop(context.Background())
+		})
+
+		stale := seqMsg(10, "c", 0, 3)
+		stale.Headers.ExpirationTime = time.Now().Add(-time.Minute)
+
+		for _, m := range []messaging.Message[int]{seqMsg(30, "c", 2, 3), stale, seqMsg(20, "c", 1, 3)} {
+			err = src.Send(context.Background(), m)
+			if err != nil {
+				t.Fatalf("send: %v", err)
+			}
+		}
+
+		msgs := getMsgs()
+		if len(msgs) != 2 || msgs[0].Payload != 20 || msgs[1].Payload != 30 {
+			t.Fatalf("expected [20 30], got %v", msgs)
+		}
+
+		expired := getExpired()
+		if len(expired) != 1 || !errors.Is(expired[0], messaging.ErrExpired) {
+			t.Fatalf("expected one ErrExpired report, got %v", expired)
+		}
+	})
+
+	t.Run("falls back to the drop handler", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		dst := messaging.NewPipelineChannel[int]()
+
+		dropHandler, getDrops := captureDrops()
+
+		r := NewResequencer("test", src, dst,
+			WithGroupTimeout(time.Second),
+			WithDropHandler(dropHandler),
+		)
+
+		err := r.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		t.Cleanup(func() {
+			_ = r.Stop(context.Background())
+		})
+
+		stale := seqMsg(10, "c", 0, 1)
+		stale.Headers.ExpirationTime = time.Now().Add(-time.Minute)
+
+		err = src.Send(context.Background(), stale)
+		if err != nil {
+			t.Fatalf("send: %v", err)
+		}
+
+		if getDrops() != 1 {
+			t.Fatalf("expected 1 drop, got %d", getDrops())
+		}
+	})
+}
+
 // failingChannel is a Channel[T] test double whose Send always returns
 // the configured err. Subscribe is a no-op returning a no-op Cancel.
 type failingChannel[T any] struct {
@@ -920,3 +1019,41 @@ func (c *failingChannel[T]) Send(_ context.Context, _ messaging.Message[T]) erro
 func (c *failingChannel[T]) Subscribe(_ messaging.Handler[T]) (messaging.Cancel, error) {
 	return func() {}, nil
 }
+
+func TestResequencer_Stats(t *testing.T) {
+	t.Parallel()
+
+	src := messaging.NewPipelineChannel[int]()
+	dst := messaging.NewPipelineChannel[int]()
+
+	r := NewResequencer("test", src, dst, WithGroupTimeout(time.Minute))
+
+	err := r.Start(context.Background())
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+
+	t.Cleanup(func() { _ = r.Stop(context.Background()) })
+
+	// No expired or drop handler: the expiry is still counted.
+	expired := seqMsg(2, "a", 2, 3)
+	expired.Headers.ExpirationTime = time.Now().Add(-time.Minute)
+
+	for _, msg := range []messaging.Message[int]{
+		seqMsg(1, "a", 1, 3),
+		seqMsg(0, "a", 0, 3),
+		expired,
+		seqMsg(1, "b", 1, 2),
+		seqMsg(9, "", 0, 1),
+	} {
+		err = src.Send(context.Background(), msg)
+		if err != nil {
+			t.Fatalf("send %d: %v", msg.Payload, err)
+		}
+	}
+
+	got := r.Stats()
+	if got.Subscribers != 1 || got.Delivered != 5 || got.Sent != 2 || got.Dropped != 2 || got.Failed != 0 || got.BufferLength != 1 {
+		t.Fatalf("Stats = %+v, want Subscribers=1 Delivered=5 Sent=2 Dropped=2 Failed=0 BufferLength=1", got)
+	}
+}
@@ -52,8 +52,12 @@
 // Forward Send failures during emit flow through WithErrorHandler;
 // intentional drops (missing/invalid sequence metadata, duplicate
 // sequence number, MaxGroups cap, quorum timeout, Stop drain) flow
-// through WithDropHandler. Nothing propagates to the source channel's
-// Send caller error path, consistent with the package-wide policy in
+// through WithDropHandler. Messages whose Headers.ExpirationTime has
+// passed by the time their position is emitted are withheld (the
+// cursor still advances past them) and flow through
+// WithExpiredHandler, or WithDropHandler when none is installed.
+// Nothing propagates to the source channel's Send caller error path,
+// consistent with the package-wide policy in
 // modules/messaging/CODING_STANDARDS.md.
 package resequencer
 
@@ -62,6 +66,7 @@ import (
 	"time"
 
 	"github.com/guidomantilla/yarumo/core/common/lifecycle"
+	"github.com/guidomantilla/yarumo/messaging"
 )
 
 // DefaultMaxGroups is the default cap on the number of distinct
@@ -84,12 +89,14 @@ var (
 
 // Resequencer is the public interface for a Resequencer endpoint. It
 // embeds lifecycle.Component so callers wire it up with
-// lifecycle.Build. The interface exists (rather than returning
+// lifecycle.Build, and messaging.Inspectable to expose its runtime
+// statistics. The interface exists (rather than returning
 // lifecycle.Component directly) so the consumer's API surface
 // preserves "this is a Resequencer" semantics and the type stays open
 // to future resequencer-specific methods without breaking callers.
 type Resequencer[T any] interface {
 	lifecycle.Component
+	messaging.Inspectable
 }
 
 // DropHandler is the optional observability hook invoked once per
@@ -104,7 +111,9 @@ type Resequencer[T any] interface {
 //   - a group's missing position never arrived within
 //     WithGroupTimeout (fires once per still-buffered message);
 //   - Stop was called with still-incomplete groups (fires once per
-//     still-buffered message).
+//     still-buffered message);
+//   - an emitted message had passed its Headers.ExpirationTime and no
+//     WithExpiredHandler is installed.
 //
 // msg is type-erased; cast it inside the hook when payload-specific
 // behavior is needed. The hook is invoked from the source channel's
@@ -21,6 +21,7 @@ type router[T any] struct {
 	routes         map[string]messaging.Channel[T]
 	defaultChannel messaging.Channel[T]
 	errorHandler   messaging.ErrorHandler
+	stats          messaging.StatsRecorder
 
 	done      chan struct{}
 	startOnce sync.Once
@@ -133,12 +134,31 @@ func (r *router[T]) Done() <-chan struct{} {
 	return r.done
 }
 
+// Stats returns a snapshot of the router's runtime statistics.
+// Subscribers is 1 while the source subscription is active.
+func (r *router[T]) Stats() messaging.Stats {
+	cassert.NotNil(r, "router is nil")
+
+	stats := r.stats.Snapshot()
+
+	r.mu.Lock()
+	if r.cancel != nil {
+		stats.Subscribers = 1
+	}
+	r.mu.Unlock()
+
+	return stats
+}
+
 // handle is the Handler[T] subscribed on the source channel. It runs
 // RouteFn under panic recovery, resolves the destination, and forwards
 // the message. Routing failures are reported through the configured
 // ErrorHandler; the function itself always returns nil so routing
 // concerns never propagate to the source channel's Send caller.
 func (r *router[T]) handle(ctx context.Context, msg messaging.Message[T]) error {
+	end := r.stats.Begin()
+	defer end(nil)
+
 	key, err := r.decideWithRecover(ctx, msg)
 	if err != nil {
 		r.report(ctx, msg, err)
@@ -156,8 +176,12 @@ func (r *router[T]) handle(ctx context.Context, msg messaging.Message[T]) error
 	err = dst.Send(ctx, msg)
 	if err != nil {
 		r.report(ctx, msg, ErrRoute(ErrForwardFailed, err))
+
+		return nil
 	}
 
+	r.stats.RecordSent()
+
 	return nil
 }
 
@@ -191,8 +215,12 @@ func (r *router[T]) handleNoRoute(ctx context.Context, msg messaging.Message[T],
 		err := r.defaultChannel.Send(ctx, msg)
 		if err != nil {
 			r.report(ctx, msg, ErrRoute(ErrForwardFailed, err))
+
+			return
 		}
 
+		r.stats.RecordSent()
+
 		return
 	}
 
@@ -203,6 +231,8 @@ func (r *router[T]) handleNoRoute(ctx context.Context, msg messaging.Message[T],
 // guaranteed non-nil by NewOptions (defaults to
 // messaging.DefaultErrorHandler), so the nil-guard is defensive only.
 func (r *router[T]) report(ctx context.Context, msg messaging.Message[T], err error) {
+	r.stats.RecordFailure()
+
 	if r.errorHandler == nil {
 		return
 	}
@@ -632,3 +632,39 @@ func (c *failingChannel[T]) Send(_ context.Context, _ messaging.Message[T]) erro
 func (c *failingChannel[T]) Subscribe(_ messaging.Handler[T]) (messaging.Cancel, error) {
 	return func() {}, nil
 }
+
+func TestRouter_Stats(t *testing.T) {
+	t.Parallel()
+
+	src := messaging.NewPipelineChannel[int]()
+	routes := map[string]messaging.Channel[int]{"even": messaging.NewPipelineChannel[int]()}
+
+	decide := func(_ context.Context, msg messaging.Message[int]) (string, error) {
+		if msg.Payload%2 == 0 {
+			return "even", nil
+		}
+
+		return keyMissing, nil
+	}
+
+	r := NewRouter("stats", src, decide, routes, WithErrorHandler[int](messaging.SilentErrorHandler))
+
+	err := r.Start(context.Background())
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+
+	t.Cleanup(func() { _ = r.Stop(context.Background()) })
+
+	for _, p := range []int{1, 2, 4} {
+		err = src.Send(context.Background(), messaging.Message[int]{Payload: p})
+		if err != nil {
+			t.Fatalf("send %d: %v", p, err)
+		}
+	}
+
+	got := r.Stats()
+	if got.Subscribers != 1 || got.Delivered != 3 || got.Sent != 2 || got.Failed != 1 {
+		t.Fatalf("Stats = %+v, want Subscribers=1 Delivered=3 Sent=2 Failed=1", got)
+	}
+}
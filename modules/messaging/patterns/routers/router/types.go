@@ -59,12 +59,14 @@ var (
 
 // Router is the public interface for a Content-Based Router. It
 // embeds lifecycle.Component so callers wire it up with
-// lifecycle.Build. The interface exists (rather than returning
+// lifecycle.Build, and messaging.Inspectable to expose its runtime
+// statistics. The interface exists (rather than returning
 // lifecycle.Component directly) so the consumer's API surface
 // preserves "this is a Router" semantics and the type stays open to
 // future router-specific methods without breaking callers.
 type Router[T any] interface {
 	lifecycle.Component
+	messaging.Inspectable
 }
 
 // RouteFn returns the destination key for msg, or an error. The key is
@@ -0,0 +1,59 @@
+package routingslip
+
+import (
+	"errors"
+	"fmt"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+	cerrs "github.com/guidomantilla/yarumo/core/common/errs"
+)
+
+// RoutingSlipType is the error domain identifier for routing slip
+// operations.
+const RoutingSlipType = "routingslip"
+
+var (
+	_ error = (*Error)(nil)
+)
+
+// Sentinel errors for routing slip operations.
+var (
+	// ErrRoutingSlipFailed is the top-level sentinel embedded in every
+	// routing-slip-domain Error returned by ErrRoutingSlip.
+	ErrRoutingSlipFailed = errors.New("routing slip failed")
+	// ErrInvalidSlip indicates that a slip header holds a value of an
+	// unexpected shape, or that the completed steps do not match the
+	// itinerary.
+	ErrInvalidSlip = errors.New("invalid routing slip")
+	// ErrUnknownStep indicates that the next step name resolves
+	// neither in the routes map nor in the channel registry.
+	ErrUnknownStep = errors.New("unknown routing slip step")
+	// ErrForwardFailed indicates that the step or destination
+	// Channel.Send returned a non-nil error.
+	ErrForwardFailed = errors.New("forward to destination failed")
+)
+
+// Error is the domain error type for routing slip operations.
+type Error struct {
+	cerrs.TypedError
+}
+
+// Error returns the formatted error string including the type
+// classification.
+func (e *Error) Error() string {
+	cassert.NotNil(e, "error is nil")
+	cassert.NotNil(e.Err, "internal error is nil")
+
+	return fmt.Sprintf("routing slip %s error: %s", e.Type, e.Err)
+}
+
+// ErrRoutingSlip wraps the given causes into a domain Error joined
+// with ErrRoutingSlipFailed.
+func ErrRoutingSlip(causes ...error) error {
+	return &Error{
+		TypedError: cerrs.TypedError{
+			Type: RoutingSlipType,
+			Err:  errors.Join(append(causes, ErrRoutingSlipFailed)...),
+		},
+	}
+}
@@ -0,0 +1,49 @@
+package routingslip
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestError_Error(t *testing.T) {
+	t.Parallel()
+
+	t.Run("includes type prefix and joined causes", func(t *testing.T) {
+		t.Parallel()
+
+		err := ErrRoutingSlip(ErrUnknownStep)
+
+		msg := err.Error()
+		if !strings.HasPrefix(msg, "routing slip "+RoutingSlipType) {
+			t.Fatalf("expected prefix %q, got %q", "routing slip "+RoutingSlipType, msg)
+		}
+
+		if !strings.Contains(msg, ErrUnknownStep.Error()) {
+			t.Fatalf("expected cause %q in message, got %q", ErrUnknownStep.Error(), msg)
+		}
+
+		if !strings.Contains(msg, ErrRoutingSlipFailed.Error()) {
+			t.Fatalf("expected sentinel %q in message, got %q", ErrRoutingSlipFailed.Error(), msg)
+		}
+	})
+
+	t.Run("ErrRoutingSlip joins all causes with ErrRoutingSlipFailed", func(t *testing.T) {
+		t.Parallel()
+
+		boom := errors.New("custom failure")
+
+		err := ErrRoutingSlip(ErrInvalidSlip, boom)
+		if !errors.Is(err, ErrRoutingSlipFailed) {
+			t.Fatal("expected ErrRoutingSlipFailed in chain")
+		}
+
+		if !errors.Is(err, ErrInvalidSlip) {
+			t.Fatal("expected ErrInvalidSlip in chain")
+		}
+
+		if !errors.Is(err, boom) {
+			t.Fatal("expected origin error in chain")
+		}
+	})
+}
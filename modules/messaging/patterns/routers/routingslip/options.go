@@ -0,0 +1,74 @@
+package routingslip
+
+import (
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+// Option is a functional option for configuring routing slip Options.
+// RoutingSlip has no T-typed options, so Option is non-generic —
+// matching history and diverging from router/Option[T] is intentional.
+type Option func(opts *Options)
+
+// Options holds the configuration for a RoutingSlip endpoint.
+type Options struct {
+	slipKey      string
+	registry     messaging.ChannelRegistry
+	errorHandler messaging.ErrorHandler
+}
+
+// NewOptions creates a new Options with sensible defaults and applies
+// the given options. The default slip key is DefaultSlipKey
+// ("RoutingSlip"); no channel registry is installed; the default
+// ErrorHandler is messaging.DefaultErrorHandler, which logs via
+// common/log.
+func NewOptions(opts ...Option) *Options {
+	options := &Options{
+		slipKey:      DefaultSlipKey,
+		registry:     nil,
+		errorHandler: messaging.DefaultErrorHandler,
+	}
+
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	return options
+}
+
+// WithSlipKey overrides the Headers.Custom map key of the itinerary;
+// the completed-steps and current-step keys follow it. Producers must
+// then attach itineraries under the same key. Empty strings are
+// ignored (the previously configured key is preserved).
+func WithSlipKey(key string) Option {
+	return func(opts *Options) {
+		if key != "" {
+			opts.slipKey = key
+		}
+	}
+}
+
+// WithChannelRegistry makes the RoutingSlip resolve step names missing
+// from the routes map through registry, so steps can be added at
+// runtime by registering their channel. Nil values are ignored.
+func WithChannelRegistry(registry messaging.ChannelRegistry) Option {
+	return func(opts *Options) {
+		if registry != nil {
+			opts.registry = registry
+		}
+	}
+}
+
+// WithErrorHandler installs an observability hook fired once per
+// malformed slip, unknown step or forward Send failure. The default
+// (when WithErrorHandler is not passed) is
+// messaging.DefaultErrorHandler, which logs each failure via
+// common/log. Pass messaging.SilentErrorHandler to opt out, or any
+// custom hook to redirect. Nil values are ignored (the previously
+// installed handler is preserved).
+func WithErrorHandler(handler messaging.ErrorHandler) Option {
+	return func(opts *Options) {
+		if handler != nil {
+			opts.errorHandler = handler
+		}
+	}
+}
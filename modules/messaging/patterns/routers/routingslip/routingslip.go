@@ -0,0 +1,352 @@
+package routingslip
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+	"github.com/guidomantilla/yarumo/core/common/lifecycle"
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+// routingSlip is the Routing Slip implementation. It owns a single
+// subscription on the source channel (registered in Start, cancelled
+// in Stop) and forwards each received message to the next step of the
+// itinerary carried in its headers, or to the destination once the
+// itinerary is exhausted.
+type routingSlip[T any] struct {
+	name         string
+	src          messaging.Channel[T]
+	dst          messaging.Channel[T]
+	routes       map[string]messaging.Channel[T]
+	slipKey      string
+	completedKey string
+	currentKey   string
+	registry     messaging.ChannelRegistry
+	errorHandler messaging.ErrorHandler
+	stats        messaging.StatsRecorder
+
+	done      chan struct{}
+	startOnce sync.Once
+	stopOnce  sync.Once
+	doneOnce  sync.Once
+
+	mu     sync.Mutex
+	cancel messaging.Cancel
+}
+
+// NewRoutingSlip constructs a Routing Slip endpoint that subscribes to
+// src, forwards each Message[T] to the next step of its itinerary and
+// to dst once the itinerary is complete. Steps must send the message
+// back to src when they finish. The endpoint is not running on return;
+// call lifecycle.Build (or Start directly) to register the
+// subscription.
+//
+// name is used in lifecycle logs and must be non-empty. src, dst and
+// routes are mandatory; routes may be empty when every step resolves
+// through WithChannelRegistry. The routes map is cloned so
+// post-construction mutation is ignored. Optional behaviors:
+//
+//   - WithSlipKey overrides the Headers.Custom key of the itinerary
+//     (default "RoutingSlip").
+//   - WithChannelRegistry resolves step names missing from routes.
+//   - WithErrorHandler overrides the default
+//     messaging.DefaultErrorHandler (which logs via common/log) with a
+//     custom hook for malformed slips, unknown steps and forward Send
+//     failures.
+func NewRoutingSlip[T any](name string, src messaging.Channel[T], dst messaging.Channel[T], routes map[string]messaging.Channel[T], opts ...Option) RoutingSlip[T] {
+	cassert.NotEmpty(name, "name is empty")
+	cassert.NotNil(src, "source channel is nil")
+	cassert.NotNil(dst, "destination channel is nil")
+	cassert.NotNil(routes, "routes map is nil")
+
+	options := NewOptions(opts...)
+
+	return &routingSlip[T]{
+		name:         name,
+		src:          src,
+		dst:          dst,
+		routes:       maps.Clone(routes),
+		slipKey:      options.slipKey,
+		completedKey: options.slipKey + completedSuffix,
+		currentKey:   options.slipKey + currentSuffix,
+		registry:     options.registry,
+		errorHandler: options.errorHandler,
+		done:         make(chan struct{}),
+	}
+}
+
+// Attach returns a copy of msg carrying steps as its itinerary under
+// the slip key, with no step completed yet. Producers call it before
+// sending the message to the RoutingSlip. Headers.Custom is copied so
+// the caller's map is not mutated.
+func (r *routingSlip[T]) Attach(msg messaging.Message[T], steps ...string) messaging.Message[T] {
+	cassert.NotNil(r, "routing slip is nil")
+
+	out := msg
+	out.Headers.Custom = maps.Clone(msg.Headers.Custom)
+
+	if out.Headers.Custom == nil {
+		out.Headers.Custom = map[string]any{}
+	}
+
+	out.Headers.Custom[r.slipKey] = slices.Clone(steps)
+	delete(out.Headers.Custom, r.completedKey)
+	delete(out.Headers.Custom, r.currentKey)
+
+	return out
+}
+
+// Completed returns the steps msg has completed under the slip key,
+// in order. It returns nil when msg carries no completed steps or the
+// header has an unexpected shape.
+func (r *routingSlip[T]) Completed(msg messaging.Message[T]) []string {
+	cassert.NotNil(r, "routing slip is nil")
+
+	completed, ok := toStrings(msg.Headers.Custom[r.completedKey])
+	if !ok {
+		return nil
+	}
+
+	return completed
+}
+
+// Name returns the routing slip's identity used in lifecycle logs.
+func (r *routingSlip[T]) Name() string {
+	cassert.NotNil(r, "routing slip is nil")
+
+	return r.name
+}
+
+// Start registers the routing handler as a subscriber on the source
+// channel. It satisfies the lifecycle.Component worker-style contract:
+// Start returns immediately after the subscription is in place; the
+// actual dispatching runs in the source channel's goroutine model.
+// Start is idempotent — a second invocation returns nil without
+// re-subscribing.
+func (r *routingSlip[T]) Start(_ context.Context) error {
+	cassert.NotNil(r, "routing slip is nil")
+
+	var startErr error
+
+	r.startOnce.Do(func() {
+		cancel, err := r.src.Subscribe(r.handle)
+		if err != nil {
+			startErr = lifecycle.ErrStart(err)
+
+			return
+		}
+
+		r.mu.Lock()
+		r.cancel = cancel
+		r.mu.Unlock()
+	})
+
+	return startErr
+}
+
+// Stop cancels the source-channel subscription and closes Done. Stop
+// is idempotent per the lifecycle.Component contract. It returns
+// lifecycle.ErrShutdown wrapping lifecycle.ErrShutdownTimeout when ctx
+// is already expired on entry; otherwise nil.
+func (r *routingSlip[T]) Stop(ctx context.Context) error {
+	cassert.NotNil(r, "routing slip is nil")
+
+	r.stopOnce.Do(func() {
+		r.mu.Lock()
+		cancel := r.cancel
+		r.cancel = nil
+		r.mu.Unlock()
+
+		if cancel != nil {
+			cancel()
+		}
+
+		r.doneOnce.Do(func() { close(r.done) })
+	})
+
+	select {
+	case <-ctx.Done():
+		return lifecycle.ErrShutdown(lifecycle.ErrShutdownTimeout, ctx.Err())
+	default:
+		return nil
+	}
+}
+
+// Done returns the channel that is closed after Stop has been called.
+func (r *routingSlip[T]) Done() <-chan struct{} {
+	cassert.NotNil(r, "routing slip is nil")
+
+	return r.done
+}
+
+// Stats returns a snapshot of the routing slip's runtime statistics.
+// Subscribers is 1 while the source subscription is active.
+func (r *routingSlip[T]) Stats() messaging.Stats {
+	cassert.NotNil(r, "routing slip is nil")
+
+	stats := r.stats.Snapshot()
+
+	r.mu.Lock()
+	if r.cancel != nil {
+		stats.Subscribers = 1
+	}
+	r.mu.Unlock()
+
+	return stats
+}
+
+// handle is the Handler[T] subscribed on the source channel. It
+// advances the message's slip and forwards it to the next step or, once
+// the itinerary is complete, to the destination. Failures are reported
+// through the configured ErrorHandler; the function itself always
+// returns nil so routing slip concerns never propagate to the source
+// channel's Send caller.
+func (r *routingSlip[T]) handle(ctx context.Context, msg messaging.Message[T]) error {
+	end := r.stats.Begin()
+	defer end(nil)
+
+	next, out, err := r.advance(msg)
+	if err != nil {
+		r.reportError(ctx, msg, ErrRoutingSlip(err))
+
+		return nil
+	}
+
+	target := r.dst
+
+	if next != "" {
+		target, err = r.resolve(next)
+		if err != nil {
+			r.reportError(ctx, out, ErrRoutingSlip(err))
+
+			return nil
+		}
+	}
+
+	err = target.Send(ctx, out)
+	if err != nil {
+		r.reportError(ctx, out, ErrRoutingSlip(ErrForwardFailed, err))
+
+		return nil
+	}
+
+	r.stats.RecordSent()
+
+	return nil
+}
+
+// advance records the step msg returned from and picks the next one.
+// It returns the next step name (empty when the itinerary is complete)
+// and a copy of msg with updated slip headers. A message without an
+// itinerary is returned unchanged with no next step.
+func (r *routingSlip[T]) advance(msg messaging.Message[T]) (string, messaging.Message[T], error) {
+	custom := msg.Headers.Custom
+
+	raw, ok := custom[r.slipKey]
+	if !ok {
+		return "", msg, nil
+	}
+
+	itinerary, ok := toStrings(raw)
+	if !ok {
+		return "", msg, errors.Join(ErrInvalidSlip, fmt.Errorf("itinerary is %T", raw))
+	}
+
+	completed, ok := toStrings(custom[r.completedKey])
+	if !ok {
+		return "", msg, errors.Join(ErrInvalidSlip, fmt.Errorf("completed steps are %T", custom[r.completedKey]))
+	}
+
+	current, ok := custom[r.currentKey].(string)
+	if custom[r.currentKey] != nil && !ok {
+		return "", msg, errors.Join(ErrInvalidSlip, fmt.Errorf("current step is %T", custom[r.currentKey]))
+	}
+
+	if current != "" {
+		completed = append(completed, current)
+	}
+
+	if len(completed) > len(itinerary) || !slices.Equal(completed, itinerary[:len(completed)]) {
+		return "", msg, errors.Join(ErrInvalidSlip, fmt.Errorf("completed %v do not follow itinerary %v", completed, itinerary))
+	}
+
+	out := msg
+	out.Headers.Custom = maps.Clone(custom)
+	out.Headers.Custom[r.completedKey] = completed
+
+	if len(completed) == len(itinerary) {
+		delete(out.Headers.Custom, r.currentKey)
+
+		return "", out, nil
+	}
+
+	next := itinerary[len(completed)]
+	out.Headers.Custom[r.currentKey] = next
+
+	return next, out, nil
+}
+
+// resolve returns the channel of the step called name, looking at the
+// routes map first and then at the channel registry.
+func (r *routingSlip[T]) resolve(name string) (messaging.Channel[T], error) {
+	ch, ok := r.routes[name]
+	if ok {
+		return ch, nil
+	}
+
+	if r.registry == nil {
+		return nil, errors.Join(ErrUnknownStep, fmt.Errorf("step %q", name))
+	}
+
+	ch, err := messaging.ResolveChannel[T](r.registry, name)
+	if err != nil {
+		return nil, errors.Join(ErrUnknownStep, err)
+	}
+
+	return ch, nil
+}
+
+// reportError forwards err to the configured ErrorHandler.
+// ErrorHandler is guaranteed non-nil by NewOptions, so the nil-guard
+// is defensive only.
+func (r *routingSlip[T]) reportError(ctx context.Context, msg messaging.Message[T], err error) {
+	r.stats.RecordFailure()
+
+	if r.errorHandler == nil {
+		return
+	}
+
+	r.errorHandler(ctx, msg, err)
+}
+
+// toStrings converts a slip header value to []string. It accepts nil
+// (no steps), []string, and []any holding only strings — the shape
+// codecs decode a []string into. The returned slice is a copy.
+func toStrings(v any) ([]string, bool) {
+	switch values := v.(type) {
+	case nil:
+		return nil, true
+	case []string:
+		return slices.Clone(values), true
+	case []any:
+		out := make([]string, 0, len(values))
+
+		for _, value := range values {
+			s, ok := value.(string)
+			if !ok {
+				return nil, false
+			}
+
+			out = append(out, s)
+		}
+
+		return out, true
+	default:
+		return nil, false
+	}
+}
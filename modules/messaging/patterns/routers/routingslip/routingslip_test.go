@@ -0,0 +1,565 @@
+package routingslip
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/guidomantilla/yarumo/core/common/lifecycle"
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+// captureErrors returns a thread-safe ErrorHandler that appends every
+// reported error and a getter that returns a defensive copy.
+func captureErrors() (messaging.ErrorHandler, func() []error) {
+	var mu sync.Mutex
+
+	captured := []error{}
+
+	handler := func(_ context.Context, _ any, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		captured = append(captured, err)
+	}
+
+	get := func() []error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		out := make([]error, len(captured))
+		copy(out, captured)
+
+		return out
+	}
+
+	return handler, get
+}
+
+// captureMessages returns a Handler[string] that records every
+// received message and a getter that returns a defensive copy.
+func captureMessages() (messaging.Handler[string], func() []messaging.Message[string]) {
+	var mu sync.Mutex
+
+	captured := []messaging.Message[string]{}
+
+	handler := func(_ context.Context, msg messaging.Message[string]) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		captured = append(captured, msg)
+
+		return nil
+	}
+
+	get := func() []messaging.Message[string] {
+		mu.Lock()
+		defer mu.Unlock()
+
+		out := make([]messaging.Message[string], len(captured))
+		copy(out, captured)
+
+		return out
+	}
+
+	return handler, get
+}
+
+// newStep returns a pipeline channel whose only subscriber appends
+// name to the payload and sends the message back to src.
+func newStep(t *testing.T, name string, src messaging.Channel[string]) messaging.Channel[string] {
+	t.Helper()
+
+	ch := messaging.NewPipelineChannel[string]()
+
+	_, err := ch.Subscribe(func(ctx context.Context, msg messaging.Message[string]) error {
+		msg.Payload += "|" + name
+
+		return src.Send(ctx, msg)
+	})
+	if err != nil {
+		t.Fatalf("subscribe %s: %v", name, err)
+	}
+
+	return ch
+}
+
+// fixture is a started routing slip with three KYC steps and a
+// capturing destination.
+type fixture struct {
+	src      messaging.Channel[string]
+	slip     RoutingSlip[string]
+	received func() []messaging.Message[string]
+	errs     func() []error
+}
+
+// newFixture wires and starts a routing slip over the steps id, aml
+// and address, applying opts.
+func newFixture(t *testing.T, opts ...Option) *fixture {
+	t.Helper()
+
+	src := messaging.NewPipelineChannel[string]()
+	dst := messaging.NewPipelineChannel[string]()
+
+	h, received := captureMessages()
+
+	_, err := dst.Subscribe(h)
+	if err != nil {
+		t.Fatalf("subscribe dst: %v", err)
+	}
+
+	routes := map[string]messaging.Channel[string]{
+		"id":      newStep(t, "id", src),
+		"aml":     newStep(t, "aml", src),
+		"address": newStep(t, "address", src),
+	}
+
+	handler, errs := captureErrors()
+
+	slip := NewRoutingSlip("kyc", src, dst, routes, append([]Option{WithErrorHandler(handler)}, opts...)...)
+
+	err = slip.Start(context.Background())
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+
+	t.Cleanup(func() { _ = slip.Stop(context.Background()) })
+
+	return &fixture{src: src, slip: slip, received: received, errs: errs}
+}
+
+// send publishes msg on the fixture source.
+func (f *fixture) send(t *testing.T, msg messaging.Message[string]) {
+	t.Helper()
+
+	err := f.src.Send(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("send: %v", err)
+	}
+}
+
+// equal reports whether two string slices hold the same values in
+// the same order.
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func TestNewRoutingSlip(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns non-nil component", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewRoutingSlip("kyc", messaging.NewPipelineChannel[string](), messaging.NewPipelineChannel[string](), map[string]messaging.Channel[string]{})
+		if s == nil {
+			t.Fatal("expected non-nil routing slip")
+		}
+
+		if s.Name() != "kyc" {
+			t.Fatalf("expected name kyc, got %q", s.Name())
+		}
+	})
+}
+
+func TestRoutingSlip_Itinerary(t *testing.T) {
+	t.Parallel()
+
+	t.Run("walks the itinerary in order and records completed steps", func(t *testing.T) {
+		t.Parallel()
+
+		f := newFixture(t)
+
+		f.send(t, f.slip.Attach(messaging.Message[string]{Payload: "customer"}, "id", "aml", "address"))
+
+		got := f.received()
+		if len(got) != 1 {
+			t.Fatalf("expected 1 message at the destination, got %d (errors %v)", len(got), f.errs())
+		}
+
+		if got[0].Payload != "customer|id|aml|address" {
+			t.Fatalf("unexpected payload %q", got[0].Payload)
+		}
+
+		if !equal(f.slip.Completed(got[0]), []string{"id", "aml", "address"}) {
+			t.Fatalf("unexpected completed steps %v", f.slip.Completed(got[0]))
+		}
+
+		_, ok := got[0].Headers.Custom[DefaultSlipKey+currentSuffix]
+		if ok {
+			t.Fatal("expected the current step header to be cleared")
+		}
+	})
+
+	t.Run("itineraries differ per message", func(t *testing.T) {
+		t.Parallel()
+
+		f := newFixture(t)
+
+		f.send(t, f.slip.Attach(messaging.Message[string]{Payload: "basic"}, "id"))
+		f.send(t, f.slip.Attach(messaging.Message[string]{Payload: "premium"}, "address", "id", "aml"))
+
+		got := f.received()
+		if len(got) != 2 {
+			t.Fatalf("expected 2 messages, got %d", len(got))
+		}
+
+		if got[0].Payload != "basic|id" || got[1].Payload != "premium|address|id|aml" {
+			t.Fatalf("unexpected payloads %q, %q", got[0].Payload, got[1].Payload)
+		}
+	})
+
+	t.Run("messages without itinerary go straight to the destination", func(t *testing.T) {
+		t.Parallel()
+
+		f := newFixture(t)
+
+		f.send(t, messaging.Message[string]{Payload: "plain"})
+		f.send(t, f.slip.Attach(messaging.Message[string]{Payload: "empty"}))
+
+		got := f.received()
+		if len(got) != 2 || got[0].Payload != "plain" || got[1].Payload != "empty" {
+			t.Fatalf("unexpected messages %+v", got)
+		}
+	})
+
+	t.Run("accepts slips decoded as []any", func(t *testing.T) {
+		t.Parallel()
+
+		f := newFixture(t)
+
+		msg := messaging.Message[string]{
+			Payload: "decoded",
+			Headers: messaging.Headers{Custom: map[string]any{
+				DefaultSlipKey:                   []any{"id", "aml"},
+				DefaultSlipKey + completedSuffix: []any{"id"},
+				DefaultSlipKey + currentSuffix:   "aml",
+			}},
+		}
+
+		f.send(t, msg)
+
+		got := f.received()
+		if len(got) != 1 || got[0].Payload != "decoded" {
+			t.Fatalf("expected the completed message at the destination, got %+v (errors %v)", got, f.errs())
+		}
+
+		if !equal(f.slip.Completed(got[0]), []string{"id", "aml"}) {
+			t.Fatalf("unexpected completed steps %v", f.slip.Completed(got[0]))
+		}
+	})
+
+	t.Run("does not mutate the source headers", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[string]()
+		step := messaging.NewPipelineChannel[string]()
+
+		s := NewRoutingSlip("kyc", src, messaging.NewPipelineChannel[string](), map[string]messaging.Channel[string]{"id": step})
+
+		err := s.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		t.Cleanup(func() { _ = s.Stop(context.Background()) })
+
+		msg := s.Attach(messaging.Message[string]{Payload: "x"}, "id")
+
+		err = src.Send(context.Background(), msg)
+		if err != nil {
+			t.Fatalf("send: %v", err)
+		}
+
+		if len(msg.Headers.Custom) != 1 {
+			t.Fatalf("expected the source Custom map untouched, got %v", msg.Headers.Custom)
+		}
+	})
+
+	t.Run("WithSlipKey moves every slip header", func(t *testing.T) {
+		t.Parallel()
+
+		f := newFixture(t, WithSlipKey("Kyc"))
+
+		f.send(t, messaging.Message[string]{
+			Payload: "custom",
+			Headers: messaging.Headers{Custom: map[string]any{"Kyc": []string{"aml"}}},
+		})
+
+		got := f.received()
+		if len(got) != 1 || got[0].Payload != "custom|aml" {
+			t.Fatalf("unexpected messages %+v", got)
+		}
+
+		completed, ok := got[0].Headers.Custom["KycCompleted"].([]string)
+		if !ok || !equal(completed, []string{"aml"}) {
+			t.Fatalf("unexpected completed header %v", got[0].Headers.Custom["KycCompleted"])
+		}
+	})
+
+	t.Run("Attach and Completed honour WithSlipKey", func(t *testing.T) {
+		t.Parallel()
+
+		f := newFixture(t, WithSlipKey("Kyc"))
+
+		msg := f.slip.Attach(messaging.Message[string]{Payload: "custom"}, "id", "aml")
+
+		_, ok := msg.Headers.Custom[DefaultSlipKey]
+		if ok {
+			t.Fatal("expected no itinerary under the default key")
+		}
+
+		f.send(t, msg)
+
+		got := f.received()
+		if len(got) != 1 || got[0].Payload != "custom|id|aml" {
+			t.Fatalf("unexpected messages %+v (errors %v)", got, f.errs())
+		}
+
+		if !equal(f.slip.Completed(got[0]), []string{"id", "aml"}) {
+			t.Fatalf("unexpected completed steps %v", f.slip.Completed(got[0]))
+		}
+	})
+}
+
+func TestRoutingSlip_Registry(t *testing.T) {
+	t.Parallel()
+
+	t.Run("resolves steps missing from routes through the registry", func(t *testing.T) {
+		t.Parallel()
+
+		registry := messaging.NewChannelRegistry()
+
+		src := messaging.NewPipelineChannel[string]()
+
+		err := registry.Register("sanctions", newStep(t, "sanctions", src))
+		if err != nil {
+			t.Fatalf("register: %v", err)
+		}
+
+		dst := messaging.NewPipelineChannel[string]()
+		h, received := captureMessages()
+
+		_, err = dst.Subscribe(h)
+		if err != nil {
+			t.Fatalf("subscribe dst: %v", err)
+		}
+
+		s := NewRoutingSlip("kyc", src, dst, map[string]messaging.Channel[string]{"id": newStep(t, "id", src)},
+			WithChannelRegistry(registry))
+
+		err = s.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		t.Cleanup(func() { _ = s.Stop(context.Background()) })
+
+		err = src.Send(context.Background(), s.Attach(messaging.Message[string]{Payload: "c"}, "id", "sanctions"))
+		if err != nil {
+			t.Fatalf("send: %v", err)
+		}
+
+		got := received()
+		if len(got) != 1 || got[0].Payload != "c|id|sanctions" {
+			t.Fatalf("unexpected messages %+v", got)
+		}
+	})
+}
+
+func TestRoutingSlip_Errors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unknown step is reported and not forwarded", func(t *testing.T) {
+		t.Parallel()
+
+		f := newFixture(t)
+
+		f.send(t, f.slip.Attach(messaging.Message[string]{Payload: "c"}, "id", "biometrics"))
+
+		if len(f.received()) != 0 {
+			t.Fatal("expected no message at the destination")
+		}
+
+		errs := f.errs()
+		if len(errs) != 1 || !errors.Is(errs[0], ErrUnknownStep) {
+			t.Fatalf("expected one ErrUnknownStep, got %v", errs)
+		}
+	})
+
+	t.Run("malformed slips are reported", func(t *testing.T) {
+		t.Parallel()
+
+		f := newFixture(t)
+
+		for _, custom := range []map[string]any{
+			{DefaultSlipKey: 42},
+			{DefaultSlipKey: []any{"id", 7}},
+			{DefaultSlipKey: []string{"id"}, DefaultSlipKey + completedSuffix: "id"},
+			{DefaultSlipKey: []string{"id"}, DefaultSlipKey + currentSuffix: 1},
+			{DefaultSlipKey: []string{"id"}, DefaultSlipKey + completedSuffix: []string{"aml"}},
+			{DefaultSlipKey: []string{"id"}, DefaultSlipKey + completedSuffix: []string{"id"}, DefaultSlipKey + currentSuffix: "aml"},
+		} {
+			f.send(t, messaging.Message[string]{Payload: "bad", Headers: messaging.Headers{Custom: custom}})
+		}
+
+		errs := f.errs()
+		if len(errs) != 6 {
+			t.Fatalf("expected 6 errors, got %v", errs)
+		}
+
+		for _, err := range errs {
+			if !errors.Is(err, ErrInvalidSlip) {
+				t.Fatalf("expected ErrInvalidSlip, got %v", err)
+			}
+		}
+
+		if len(f.received()) != 0 {
+			t.Fatal("expected no message at the destination")
+		}
+	})
+
+	t.Run("forward failure is reported", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[string]()
+		handler, errs := captureErrors()
+
+		s := NewRoutingSlip("kyc", src, &failingChannel[string]{err: errors.New("down")}, map[string]messaging.Channel[string]{},
+			WithErrorHandler(handler))
+
+		err := s.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		t.Cleanup(func() { _ = s.Stop(context.Background()) })
+
+		err = src.Send(context.Background(), messaging.Message[string]{Payload: "c"})
+		if err != nil {
+			t.Fatalf("send must not surface forward failures, got %v", err)
+		}
+
+		captured := errs()
+		if len(captured) != 1 || !errors.Is(captured[0], ErrForwardFailed) {
+			t.Fatalf("expected one ErrForwardFailed, got %v", captured)
+		}
+	})
+}
+
+func TestRoutingSlip_Lifecycle(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Start and Stop are idempotent and close Done", func(t *testing.T) {
+		t.Parallel()
+
+		f := newFixture(t)
+
+		err := f.slip.Start(context.Background())
+		if err != nil {
+			t.Fatalf("second start: %v", err)
+		}
+
+		err = f.slip.Stop(context.Background())
+		if err != nil {
+			t.Fatalf("stop: %v", err)
+		}
+
+		err = f.slip.Stop(context.Background())
+		if err != nil {
+			t.Fatalf("second stop: %v", err)
+		}
+
+		select {
+		case <-f.slip.Done():
+		default:
+			t.Fatal("expected Done to be closed")
+		}
+	})
+
+	t.Run("Start propagates Subscribe failure", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewRoutingSlip("kyc", &failingChannel[string]{err: errors.New("closed")}, messaging.NewPipelineChannel[string](),
+			map[string]messaging.Channel[string]{})
+
+		err := s.Start(context.Background())
+		if !errors.Is(err, lifecycle.ErrStartFailed) {
+			t.Fatalf("expected lifecycle start error, got %v", err)
+		}
+	})
+
+	t.Run("Stop with expired ctx returns ErrShutdownTimeout", func(t *testing.T) {
+		t.Parallel()
+
+		f := newFixture(t)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := f.slip.Stop(ctx)
+		if !errors.Is(err, lifecycle.ErrShutdownTimeout) {
+			t.Fatalf("expected ErrShutdownTimeout, got %v", err)
+		}
+	})
+}
+
+func TestRoutingSlip_Stats(t *testing.T) {
+	t.Parallel()
+
+	f := newFixture(t)
+
+	f.send(t, f.slip.Attach(messaging.Message[string]{Payload: "c"}, "id", "aml"))
+	f.send(t, f.slip.Attach(messaging.Message[string]{Payload: "c"}, "nope"))
+
+	got := f.slip.Stats()
+	if got.Subscribers != 1 || got.Delivered != 4 || got.Sent != 3 || got.Failed != 1 {
+		t.Fatalf("Stats = %+v, want Subscribers=1 Delivered=4 Sent=3 Failed=1", got)
+	}
+}
+
+func TestRoutingSlip_Options(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil and empty values are ignored", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithSlipKey(""), WithChannelRegistry(nil), WithErrorHandler(nil))
+
+		if opts.slipKey != DefaultSlipKey {
+			t.Fatalf("expected default slip key, got %q", opts.slipKey)
+		}
+
+		if opts.registry != nil {
+			t.Fatal("expected no registry")
+		}
+
+		if opts.errorHandler == nil {
+			t.Fatal("expected default error handler preserved on nil arg")
+		}
+	})
+}
+
+// failingChannel is a Channel[T] test double whose Send and Subscribe
+// always return err.
+type failingChannel[T any] struct {
+	err error
+}
+
+func (c *failingChannel[T]) Send(_ context.Context, _ messaging.Message[T]) error {
+	return c.err
+}
+
+func (c *failingChannel[T]) Subscribe(_ messaging.Handler[T]) (messaging.Cancel, error) {
+	return nil, c.err
+}
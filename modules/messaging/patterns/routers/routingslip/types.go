@@ -0,0 +1,106 @@
+// Package routingslip provides a Routing Slip pattern over
+// messaging.Channel[T].
+//
+// With a Routing Slip the itinerary travels with the message instead
+// of living in the router: every message carries its own ordered list
+// of step names in Headers.Custom[SlipKey]. A RoutingSlip endpoint
+// subscribes to a source Channel[T], records the step the message has
+// just returned from, resolves the next step name to a Channel[T] and
+// forwards the message there. Steps do their work and send the message
+// back to the RoutingSlip's source; once the itinerary is exhausted
+// the message is forwarded to the destination channel. Use it for
+// per-message dynamic pipelines — for example KYC flows whose checks
+// vary by customer tier — where Router and RecipientList, which decide
+// centrally, do not fit.
+//
+// # Header semantics
+//
+// Three Headers.Custom entries drive the slip; the key names default
+// to DefaultSlipKey and derive from it ("<key>Completed",
+// "<key>Current"), so WithSlipKey moves all three:
+//
+//   - Custom[key] holds the itinerary as []string. RoutingSlip.Attach
+//     sets it.
+//   - Custom[key+"Completed"] holds the steps already completed, in
+//     order, like the trail the history pattern records.
+//   - Custom[key+"Current"] names the step the message was last sent
+//     to. When the message comes back it is appended to the completed
+//     list and the next step is itinerary[len(completed)].
+//
+// Values decoded by a codec as []any of strings are accepted, so the
+// slip survives serialization through brokers and stores. A message
+// without an itinerary, or with an empty one, goes straight to the
+// destination. The forwarded message carries a COPY of Headers and
+// Custom so downstream mutations do not leak back into the source.
+//
+// # Step resolution
+//
+// Step names resolve against the routes map given at construction
+// first, then against the messaging.ChannelRegistry installed with
+// WithChannelRegistry. A step that resolves nowhere is reported
+// through WithErrorHandler with ErrUnknownStep and the message is not
+// forwarded.
+//
+// # Lifecycle
+//
+// RoutingSlip implements common/lifecycle.Component (worker-style):
+// Start registers the subscription on the source channel and returns
+// immediately; Stop cancels the subscription and closes Done.
+// RoutingSlip does not spawn goroutines of its own — dispatch
+// concurrency is inherited from the source channel implementation.
+//
+// # Error handling
+//
+// The handler installed on the source channel always returns nil.
+// Malformed slips, unknown steps and forward Send failures are
+// surfaced via the RoutingSlip's own ErrorHandler (installed with
+// WithErrorHandler, defaulting to messaging.DefaultErrorHandler which
+// logs via common/log), consistent with the package-wide policy in
+// modules/messaging/CODING_STANDARDS.md.
+package routingslip
+
+import (
+	"github.com/guidomantilla/yarumo/core/common/lifecycle"
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+// DefaultSlipKey is the default Headers.Custom map key of the
+// itinerary. The completed-steps and current-step entries use it as
+// prefix. Override with WithSlipKey when the default would collide
+// with a caller-defined custom field.
+const DefaultSlipKey = "RoutingSlip"
+
+// completedSuffix and currentSuffix derive the completed-steps and
+// current-step keys from the slip key.
+const (
+	completedSuffix = "Completed"
+	currentSuffix   = "Current"
+)
+
+var (
+	_ RoutingSlip[any] = (*routingSlip[any])(nil)
+
+	_ ErrRoutingSlipFn = ErrRoutingSlip
+)
+
+// RoutingSlip is the public interface for a Routing Slip endpoint. It
+// embeds lifecycle.Component so callers wire it up with
+// lifecycle.Build, and messaging.Inspectable to expose its runtime
+// statistics. The interface exists (rather than returning
+// lifecycle.Component directly) so the consumer's API surface
+// preserves "this is a RoutingSlip" semantics and the type stays open
+// to future routing-slip-specific methods without breaking callers.
+type RoutingSlip[T any] interface {
+	lifecycle.Component
+	messaging.Inspectable
+
+	// Attach returns a copy of msg carrying steps as its itinerary
+	// under the configured slip key, with no step completed yet.
+	Attach(msg messaging.Message[T], steps ...string) messaging.Message[T]
+	// Completed returns the steps msg has completed under the
+	// configured slip key, in order, or nil when there are none.
+	Completed(msg messaging.Message[T]) []string
+}
+
+// ErrRoutingSlipFn is the function type for ErrRoutingSlip.
+type ErrRoutingSlipFn func(causes ...error) error
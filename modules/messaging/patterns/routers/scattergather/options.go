@@ -24,19 +24,22 @@ type Options[T any] struct {
 	maxConcurrentScatters int
 	errorHandler          messaging.ErrorHandler
 	dropHandler           DropHandler
+	clock                 messaging.Clock
 }
 
 // NewOptions creates a new Options[T] with sensible defaults and
 // applies the given options. The default ErrorHandler is
 // messaging.DefaultErrorHandler (logs via common/log); the default
 // MaxConcurrentScatters cap is defaultMaxConcurrentScatters; the
-// default DropHandler is nil (silent intentional drops). No group
+// default DropHandler is nil (silent intentional drops); the default
+// clock is messaging.SystemClock. No group
 // timeout is set by default — WithGroupTimeout MUST be passed
 // explicitly or NewScatterGather panics.
 func NewOptions[T any](opts ...Option[T]) *Options[T] {
 	options := &Options[T]{
 		maxConcurrentScatters: defaultMaxConcurrentScatters,
 		errorHandler:          messaging.DefaultErrorHandler,
+		clock:                 messaging.SystemClock(),
 	}
 
 	for _, opt := range opts {
@@ -104,3 +107,15 @@ func WithDropHandler[T any](handler DropHandler) Option[T] {
 		}
 	}
 }
+
+// WithClock sets the time source for the orphan sweeper and the
+// internal Aggregator's group deadlines. Tests pass a
+// messagingtest.FakeClock to time out gathers without sleeping. Nil
+// values are ignored (the system clock is preserved).
+func WithClock[T any](clock messaging.Clock) Option[T] {
+	return func(opts *Options[T]) {
+		if clock != nil {
+			opts.clock = clock
+		}
+	}
+}
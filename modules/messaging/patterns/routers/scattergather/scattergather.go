@@ -100,6 +100,7 @@ func NewScatterGather[T, U any](name string, src messaging.Channel[T], workers m
 		maxConcurrentScatters: options.maxConcurrentScatters,
 		errorHandler:          options.errorHandler,
 		dropHandler:           options.dropHandler,
+		clock:                 options.clock,
 		done:                  make(chan struct{}),
 		expected:              map[string]expectation{},
 	}
@@ -112,7 +113,8 @@ func NewScatterGather[T, U any](name string, src messaging.Channel[T], workers m
 		aggregator.WithCompletionFn(sg.completion),
 		aggregator.WithGroupTimeout[T](options.groupTimeout),
 		aggregator.WithErrorHandler[T](sg.aggregatorError),
-		aggregator.WithDropHandler[T](sg.aggregatorDrop))
+		aggregator.WithDropHandler[T](sg.aggregatorDrop),
+		aggregator.WithClock[T](options.clock))
 
 	return sg
 }
@@ -203,6 +205,36 @@ func (s *scatterGather[T, U]) Done() <-chan struct{} {
 	return s.done
 }
 
+// Stats returns a snapshot of the scatter-gather's runtime statistics,
+// combined from its internal Recipient List and Aggregator:
+// Subscribers, Delivered and InFlight describe the source side, Sent
+// counts the aggregates forwarded to the aggregate destination,
+// BufferLength the replies held in incomplete gathers, and Failed and
+// Dropped add up both sides.
+func (s *scatterGather[T, U]) Stats() messaging.Stats {
+	cassert.NotNil(s, "scatter-gather is nil")
+
+	scatter := s.scatterer.Stats()
+	gather := s.gatherer.Stats()
+
+	stats := messaging.Stats{
+		BufferLength: gather.BufferLength,
+		Subscribers:  scatter.Subscribers,
+		InFlight:     scatter.InFlight,
+		Sent:         gather.Sent,
+		Delivered:    scatter.Delivered,
+		Failed:       scatter.Failed + gather.Failed,
+		Dropped:      scatter.Dropped + gather.Dropped,
+	}
+
+	stats.LastErrorTime = scatter.LastErrorTime
+	if gather.LastErrorTime.After(stats.LastErrorTime) {
+		stats.LastErrorTime = gather.LastErrorTime
+	}
+
+	return stats
+}
+
 // wrappedSelector intercepts the user-supplied SelectorFn so it can
 // (1) record the expected reply count per correlation id at scatter
 // time and (2) enforce the WithMaxConcurrentScatters cap. The
@@ -230,7 +262,7 @@ func (s *scatterGather[T, U]) wrappedSelector(ctx context.Context, msg messaging
 		return nil, ErrMaxScattersExceeded
 	}
 
-	s.expected[corrID] = expectation{count: len(keys), scatteredAt: time.Now()}
+	s.expected[corrID] = expectation{count: len(keys), scatteredAt: s.clock.Now()}
 	s.mu.Unlock()
 
 	return keys, nil
@@ -356,14 +388,14 @@ func (s *scatterGather[T, U]) runOrphanSweeper(workerCtx context.Context) {
 	interval := max(s.groupTimeout/orphanSweepDivisor, minOrphanSweepTick)
 	ttl := s.groupTimeout * orphanTTLMultiplier
 
-	ticker := time.NewTicker(interval)
+	ticker := s.clock.NewTicker(interval)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-workerCtx.Done():
 			return
-		case <-ticker.C:
+		case <-ticker.C():
 			s.sweepOrphans(workerCtx, ttl)
 		}
 	}
@@ -374,7 +406,7 @@ func (s *scatterGather[T, U]) runOrphanSweeper(workerCtx context.Context) {
 // scatter requests that never received any worker reply. The map
 // snapshot is taken under the lock, hook invocation happens outside.
 func (s *scatterGather[T, U]) sweepOrphans(ctx context.Context, ttl time.Duration) {
-	now := time.Now()
+	now := s.clock.Now()
 
 	s.mu.Lock()
 
@@ -1278,3 +1278,60 @@ func TestErrScatterGather(t *testing.T) {
 		}
 	})
 }
+
+func TestScatterGather_Stats(t *testing.T) {
+	t.Parallel()
+
+	src := messaging.NewPipelineChannel[int]()
+	reply := messaging.NewPipelineChannel[int]()
+	dst := messaging.NewPipelineChannel[int]()
+
+	wA := messaging.NewPipelineChannel[int]()
+	wB := messaging.NewPipelineChannel[int]()
+
+	_ = startWorker(t, wA, reply, func(p int) int { return p + 1 })
+	_ = startWorker(t, wB, reply, func(p int) int { return p + 2 })
+
+	sinkHandler, getMsgs := captureMessages[int]()
+
+	_, err := dst.Subscribe(sinkHandler)
+	if err != nil {
+		t.Fatalf("subscribe dst: %v", err)
+	}
+
+	// Zero selects no worker and is dropped.
+	selector := func(_ context.Context, msg messaging.Message[int]) ([]string, error) {
+		if msg.Payload == 0 {
+			return nil, nil
+		}
+
+		return []string{"a", "b"}, nil
+	}
+
+	sg := NewScatterGather("test", src, map[string]messaging.Channel[int]{"a": wA, "b": wB},
+		reply, dst, selector, sumAggregate,
+		WithGroupTimeout[int](time.Minute))
+
+	err = sg.Start(context.Background())
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+
+	t.Cleanup(func() { _ = sg.Stop(context.Background()) })
+
+	for _, msg := range []messaging.Message[int]{reqMsg(10, "req-1"), reqMsg(0, "req-2")} {
+		err = src.Send(context.Background(), msg)
+		if err != nil {
+			t.Fatalf("send %d: %v", msg.Payload, err)
+		}
+	}
+
+	if len(getMsgs()) != 1 {
+		t.Fatalf("expected 1 aggregated message, got %d", len(getMsgs()))
+	}
+
+	got := sg.Stats()
+	if got.Subscribers != 1 || got.Delivered != 2 || got.Sent != 1 || got.Dropped != 1 || got.Failed != 0 || got.BufferLength != 0 {
+		t.Fatalf("Stats = %+v, want Subscribers=1 Delivered=2 Sent=1 Dropped=1 Failed=0 BufferLength=0", got)
+	}
+}
@@ -85,13 +85,15 @@ var (
 
 // ScatterGather is the public interface for a Scatter-Gather pattern.
 // It embeds lifecycle.Component so callers wire it up with
-// lifecycle.Build. The interface exists (rather than returning
+// lifecycle.Build, and messaging.Inspectable to expose its runtime
+// statistics. The interface exists (rather than returning
 // lifecycle.Component directly) so the consumer's API surface
 // preserves "this is a ScatterGather" semantics and the type stays
 // open to future scatter-gather-specific methods without breaking
 // callers.
 type ScatterGather[T, U any] interface {
 	lifecycle.Component
+	messaging.Inspectable
 }
 
 // SelectorFn is the scatter rule: it returns the ordered list of
@@ -157,6 +159,7 @@ type scatterGather[T, U any] struct {
 	maxConcurrentScatters int
 	errorHandler          messaging.ErrorHandler
 	dropHandler           DropHandler
+	clock                 messaging.Clock
 
 	scatterer recipientlist.RecipientList[T]
 	gatherer  aggregator.Aggregator[T, U]
@@ -22,6 +22,7 @@ type splitter[T, U any] struct {
 	split        SplitFn[T, U]
 	errorHandler messaging.ErrorHandler
 	dropHandler  DropHandler
+	stats        messaging.StatsRecorder
 
 	done      chan struct{}
 	startOnce sync.Once
@@ -140,12 +141,31 @@ func (s *splitter[T, U]) Done() <-chan struct{} {
 	return s.done
 }
 
+// Stats returns a snapshot of the splitter's runtime statistics.
+// Subscribers is 1 while the source subscription is active.
+func (s *splitter[T, U]) Stats() messaging.Stats {
+	cassert.NotNil(s, "splitter is nil")
+
+	stats := s.stats.Snapshot()
+
+	s.mu.Lock()
+	if s.cancel != nil {
+		stats.Subscribers = 1
+	}
+	s.mu.Unlock()
+
+	return stats
+}
+
 // handle is the Handler[T] subscribed on the source channel. It runs
 // the SplitFn under panic recovery, then emits one Message[U] per
 // returned item to the destination. Empty slices route to the
 // DropHandler. The function itself always returns nil so splitter
 // concerns never propagate to the source channel's Send caller.
 func (s *splitter[T, U]) handle(ctx context.Context, msg messaging.Message[T]) error {
+	end := s.stats.Begin()
+	defer end(nil)
+
 	items, err := s.splitWithRecover(ctx, msg)
 	if err != nil {
 		s.reportError(ctx, msg, err)
@@ -198,7 +218,11 @@ func (s *splitter[T, U]) emitChildren(ctx context.Context, original messaging.Me
 		err := s.dst.Send(ctx, child)
 		if err != nil {
 			s.reportError(ctx, original, ErrSplitter(ErrForwardFailed, err))
+
+			continue
 		}
+
+		s.stats.RecordSent()
 	}
 }
 
@@ -222,6 +246,8 @@ func (s *splitter[T, U]) buildChild(original messaging.Message[T], item U, idx,
 // is guaranteed non-nil by NewOptions (defaults to
 // messaging.DefaultErrorHandler), so the nil-guard is defensive only.
 func (s *splitter[T, U]) reportError(ctx context.Context, msg messaging.Message[T], err error) {
+	s.stats.RecordFailure()
+
 	if s.errorHandler == nil {
 		return
 	}
@@ -233,6 +259,8 @@ func (s *splitter[T, U]) reportError(ctx context.Context, msg messaging.Message[
 // is nil by default (silent drops); the guard skips invocation in that
 // case.
 func (s *splitter[T, U]) reportDrop(ctx context.Context, msg messaging.Message[T]) {
+	s.stats.RecordDrop()
+
 	if s.dropHandler == nil {
 		return
 	}
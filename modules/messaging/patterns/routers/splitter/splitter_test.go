@@ -688,3 +688,39 @@ func (c *failingChannel[T]) Send(_ context.Context, _ messaging.Message[T]) erro
 func (c *failingChannel[T]) Subscribe(_ messaging.Handler[T]) (messaging.Cancel, error) {
 	return func() {}, nil
 }
+
+func TestSplitter_Stats(t *testing.T) {
+	t.Parallel()
+
+	src := messaging.NewPipelineChannel[string]()
+	dst := messaging.NewPipelineChannel[int]()
+
+	split := func(ctx context.Context, msg messaging.Message[string]) ([]int, error) {
+		if msg.Payload == "" {
+			return splitToEmpty(ctx, msg)
+		}
+
+		return splitIntoThree(ctx, msg)
+	}
+
+	s := NewSplitter("stats", src, dst, split)
+
+	err := s.Start(context.Background())
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+
+	t.Cleanup(func() { _ = s.Stop(context.Background()) })
+
+	for _, p := range []string{"abc", ""} {
+		err = src.Send(context.Background(), messaging.Message[string]{Payload: p})
+		if err != nil {
+			t.Fatalf("send %q: %v", p, err)
+		}
+	}
+
+	got := s.Stats()
+	if got.Subscribers != 1 || got.Delivered != 2 || got.Sent != 3 || got.Dropped != 1 || got.Failed != 0 {
+		t.Fatalf("Stats = %+v, want Subscribers=1 Delivered=2 Sent=3 Dropped=1 Failed=0", got)
+	}
+}
@@ -73,13 +73,15 @@ var (
 )
 
 // Splitter is the public interface for a Splitter. It embeds
-// lifecycle.Component so callers wire it up with lifecycle.Build. The
+// lifecycle.Component so callers wire it up with lifecycle.Build, and
+// messaging.Inspectable to expose its runtime statistics. The
 // interface exists (rather than returning lifecycle.Component
 // directly) so the consumer's API surface preserves "this is a
 // Splitter" semantics and the type stays open to future
 // splitter-specific methods without breaking callers.
 type Splitter[T, U any] interface {
 	lifecycle.Component
+	messaging.Inspectable
 }
 
 // SplitFn returns the slice of U values to emit for the incoming
@@ -0,0 +1,66 @@
+package window
+
+import (
+	"errors"
+	"fmt"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+	cerrs "github.com/guidomantilla/yarumo/core/common/errs"
+)
+
+// WindowType is the error domain identifier for window operations.
+const WindowType = "window"
+
+var (
+	_ error = (*Error)(nil)
+)
+
+// Sentinel errors for window operations.
+var (
+	// ErrWindowFailed is the top-level sentinel embedded in every
+	// window-domain Error returned by ErrWindow.
+	ErrWindowFailed = errors.New("window failed")
+	// ErrReduceFnFailed indicates that ReduceFn returned a non-nil
+	// error or panicked. The original error (or the recovered value
+	// formatted via fmt) is joined alongside this sentinel.
+	ErrReduceFnFailed = errors.New("reduce function failed")
+	// ErrForwardFailed indicates that the destination Channel.Send
+	// returned a non-nil error after a successful ReduceFn.
+	ErrForwardFailed = errors.New("forward to destination failed")
+	// ErrMaxWindowsExceeded indicates that a message would have opened
+	// a window while the operator already tracked WithMaxWindows
+	// windows. The message is dropped and reported through the
+	// ErrorHandler; existing windows remain untouched.
+	ErrMaxWindowsExceeded = errors.New("max concurrent windows exceeded")
+	// ErrTimestampMissing is passed to the DropHandler for messages
+	// without an event time.
+	ErrTimestampMissing = errors.New("message has no event time")
+	// ErrLate is passed to the DropHandler for messages whose every
+	// window closed more than WithAllowedLateness before the watermark.
+	ErrLate = errors.New("message arrived after the allowed lateness")
+)
+
+// Error is the domain error type for window operations.
+type Error struct {
+	cerrs.TypedError
+}
+
+// Error returns the formatted error string including the type
+// classification.
+func (e *Error) Error() string {
+	cassert.NotNil(e, "error is nil")
+	cassert.NotNil(e.Err, "internal error is nil")
+
+	return fmt.Sprintf("window %s error: %s", e.Type, e.Err)
+}
+
+// ErrWindow wraps the given causes into a domain Error joined with
+// ErrWindowFailed.
+func ErrWindow(causes ...error) error {
+	return &Error{
+		TypedError: cerrs.TypedError{
+			Type: WindowType,
+			Err:  errors.Join(append(causes, ErrWindowFailed)...),
+		},
+	}
+}
@@ -0,0 +1,163 @@
+package window
+
+import (
+	"time"
+
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+// defaultMaxWindows bounds the number of tracked windows (across all
+// keys) when the caller does not pass WithMaxWindows.
+const defaultMaxWindows = 10000
+
+// Option is a functional option for configuring window Options. It is
+// generic over T so options that carry T-typed values (KeyFn,
+// TimestampFn) stay type-safe.
+type Option[T any] func(opts *Options[T])
+
+// Options holds the configuration for a Window operator.
+type Options[T any] struct {
+	key               KeyFn[T]
+	timestamp         TimestampFn[T]
+	maxOutOfOrderness time.Duration
+	allowedLateness   time.Duration
+	idleTimeout       time.Duration
+	maxWindows        int
+	errorHandler      messaging.ErrorHandler
+	dropHandler       DropHandler
+	clock             messaging.Clock
+}
+
+// NewOptions creates a new Options[T] with sensible defaults and
+// applies the given options. Defaults: a single key for the whole
+// stream, event time from Headers.Timestamp, no out-of-orderness, no
+// allowed lateness, no idle timeout, defaultMaxWindows,
+// messaging.DefaultErrorHandler, no DropHandler and
+// messaging.SystemClock.
+func NewOptions[T any](opts ...Option[T]) *Options[T] {
+	options := &Options[T]{
+		key:          defaultKey[T],
+		timestamp:    defaultTimestamp[T],
+		maxWindows:   defaultMaxWindows,
+		errorHandler: messaging.DefaultErrorHandler,
+		clock:        messaging.SystemClock(),
+	}
+
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	return options
+}
+
+// defaultKey puts every message under the same key.
+func defaultKey[T any](_ messaging.Message[T]) string {
+	return ""
+}
+
+// defaultTimestamp reads the event time from Headers.Timestamp.
+func defaultTimestamp[T any](msg messaging.Message[T]) time.Time {
+	return msg.Headers.Timestamp
+}
+
+// WithKeyFn installs the key extractor, e.g. the merchant id of a
+// payment. Each key has its own windows. Nil values are ignored.
+func WithKeyFn[T any](fn KeyFn[T]) Option[T] {
+	return func(opts *Options[T]) {
+		if fn != nil {
+			opts.key = fn
+		}
+	}
+}
+
+// WithTimestampFn overrides the event-time extractor (default
+// Headers.Timestamp). Nil values are ignored.
+func WithTimestampFn[T any](fn TimestampFn[T]) Option[T] {
+	return func(opts *Options[T]) {
+		if fn != nil {
+			opts.timestamp = fn
+		}
+	}
+}
+
+// WithMaxOutOfOrderness holds the watermark d behind the highest event
+// time seen, so messages up to d out of order still make the first
+// emission of their window at the cost of emitting d later.
+// Non-positive values are ignored.
+func WithMaxOutOfOrderness[T any](d time.Duration) Option[T] {
+	return func(opts *Options[T]) {
+		if d > 0 {
+			opts.maxOutOfOrderness = d
+		}
+	}
+}
+
+// WithAllowedLateness keeps fired windows for d past the watermark;
+// late messages within d re-fire their window with the updated
+// content. Non-positive values are ignored.
+func WithAllowedLateness[T any](d time.Duration) Option[T] {
+	return func(opts *Options[T]) {
+		if d > 0 {
+			opts.allowedLateness = d
+		}
+	}
+}
+
+// WithIdleTimeout fires every pending window once no message has
+// arrived for d (processing time), so the last windows of a quiet
+// stream do not wait for the next message. A background sweeper
+// goroutine is spawned in Start. Non-positive values are ignored.
+func WithIdleTimeout[T any](d time.Duration) Option[T] {
+	return func(opts *Options[T]) {
+		if d > 0 {
+			opts.idleTimeout = d
+		}
+	}
+}
+
+// WithMaxWindows caps the number of windows tracked across all keys,
+// fired windows kept for lateness included. A message that would open
+// a window beyond the cap is dropped and the ErrorHandler is invoked
+// with ErrMaxWindowsExceeded. The default is defaultMaxWindows (10000).
+// Non-positive values are ignored.
+func WithMaxWindows[T any](n int) Option[T] {
+	return func(opts *Options[T]) {
+		if n > 0 {
+			opts.maxWindows = n
+		}
+	}
+}
+
+// WithErrorHandler installs an observability hook fired once per real
+// failure (ReduceFn returned an error or panicked, forward Send failed,
+// MaxWindows exceeded). The default is messaging.DefaultErrorHandler;
+// pass messaging.SilentErrorHandler to opt out. Nil values are ignored.
+func WithErrorHandler[T any](handler messaging.ErrorHandler) Option[T] {
+	return func(opts *Options[T]) {
+		if handler != nil {
+			opts.errorHandler = handler
+		}
+	}
+}
+
+// WithDropHandler installs an observability hook fired once per
+// intentional drop (missing timestamp, late message). The default is
+// nil — intentional drops are silent. Nil values are ignored.
+func WithDropHandler[T any](handler DropHandler) Option[T] {
+	return func(opts *Options[T]) {
+		if handler != nil {
+			opts.dropHandler = handler
+		}
+	}
+}
+
+// WithClock sets the time source of the idle sweeper. Tests pass a
+// messagingtest.FakeClock to trigger idle firing without sleeping. Nil
+// values are ignored (the system clock is preserved).
+func WithClock[T any](clock messaging.Clock) Option[T] {
+	return func(opts *Options[T]) {
+		if clock != nil {
+			opts.clock = clock
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package window
+
+import (
+	"time"
+)
+
+// Tumbling returns the strategy of fixed, non-overlapping windows of
+// length size. A non-positive size is a caller bug and panics.
+func Tumbling(size time.Duration) Strategy {
+	if size <= 0 {
+		panic("tumbling window size must be positive")
+	}
+
+	return Strategy{kind: strategyTumbling, size: size, slide: size}
+}
+
+// Sliding returns the strategy of windows of length size starting
+// every slide. slide must be positive and not larger than size, so
+// every message belongs to at least one window; anything else panics.
+func Sliding(size time.Duration, slide time.Duration) Strategy {
+	if size <= 0 || slide <= 0 || slide > size {
+		panic("sliding window requires 0 < slide <= size")
+	}
+
+	return Strategy{kind: strategySliding, size: size, slide: slide}
+}
+
+// Session returns the strategy of per-key windows closed by gap
+// without messages. A non-positive gap panics.
+func Session(gap time.Duration) Strategy {
+	if gap <= 0 {
+		panic("session window gap must be positive")
+	}
+
+	return Strategy{kind: strategySession, size: gap}
+}
+
+// valid reports whether s was built by one of the constructors with
+// usable durations.
+func (s Strategy) valid() bool {
+	switch s.kind {
+	case strategyTumbling, strategySession:
+		return s.size > 0
+	case strategySliding:
+		return s.size > 0 && s.slide > 0 && s.slide <= s.size
+	default:
+		return false
+	}
+}
+
+// assign returns the windows of key that an event at t belongs to, in
+// ascending Start order. A session event opens [t, t+gap), which the
+// operator merges with the overlapping sessions of the key.
+func (s Strategy) assign(key string, t time.Time) []Bounds {
+	if s.kind == strategySession {
+		return []Bounds{{Key: key, Start: t, End: t.Add(s.size)}}
+	}
+
+	last := t.Truncate(s.slide)
+
+	var out []Bounds
+
+	for start := last; start.Add(s.size).After(t); start = start.Add(-s.slide) {
+		out = append(out, Bounds{Key: key, Start: start, End: start.Add(s.size)})
+	}
+
+	// Built newest first; reverse into ascending order.
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+
+	return out
+}
@@ -0,0 +1,141 @@
+package window
+
+import (
+	"testing"
+	"time"
+)
+
+// assertPanics fails t unless fn panics.
+func assertPanics(t *testing.T, fn func()) {
+	t.Helper()
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic")
+		}
+	}()
+
+	fn()
+}
+
+func TestStrategy_Constructors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid tumbling", func(t *testing.T) {
+		t.Parallel()
+
+		if !Tumbling(time.Minute).valid() {
+			t.Fatal("expected valid strategy")
+		}
+	})
+
+	t.Run("valid sliding", func(t *testing.T) {
+		t.Parallel()
+
+		if !Sliding(5*time.Minute, time.Minute).valid() {
+			t.Fatal("expected valid strategy")
+		}
+	})
+
+	t.Run("valid session", func(t *testing.T) {
+		t.Parallel()
+
+		if !Session(time.Minute).valid() {
+			t.Fatal("expected valid strategy")
+		}
+	})
+
+	t.Run("zero Strategy is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		if (Strategy{}).valid() {
+			t.Fatal("expected zero Strategy to be invalid")
+		}
+	})
+
+	t.Run("tumbling zero size panics", func(t *testing.T) {
+		t.Parallel()
+
+		assertPanics(t, func() { Tumbling(0) })
+	})
+
+	t.Run("sliding zero slide panics", func(t *testing.T) {
+		t.Parallel()
+
+		assertPanics(t, func() { Sliding(time.Minute, 0) })
+	})
+
+	t.Run("sliding slide larger than size panics", func(t *testing.T) {
+		t.Parallel()
+
+		assertPanics(t, func() { Sliding(time.Minute, 2*time.Minute) })
+	})
+
+	t.Run("sliding zero size panics", func(t *testing.T) {
+		t.Parallel()
+
+		assertPanics(t, func() { Sliding(0, time.Minute) })
+	})
+
+	t.Run("session negative gap panics", func(t *testing.T) {
+		t.Parallel()
+
+		assertPanics(t, func() { Session(-time.Second) })
+	})
+}
+
+func TestStrategy_Assign(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("tumbling assigns one aligned window", func(t *testing.T) {
+		t.Parallel()
+
+		got := Tumbling(5*time.Minute).assign("k", base.Add(7*time.Minute))
+		if len(got) != 1 {
+			t.Fatalf("expected 1 window, got %d", len(got))
+		}
+
+		want := Bounds{Key: "k", Start: base.Add(5 * time.Minute), End: base.Add(10 * time.Minute)}
+		if got[0] != want {
+			t.Fatalf("expected %v, got %v", want, got[0])
+		}
+	})
+
+	t.Run("event on a boundary opens the next window", func(t *testing.T) {
+		t.Parallel()
+
+		got := Tumbling(5*time.Minute).assign("", base.Add(5*time.Minute))
+		if !got[0].Start.Equal(base.Add(5 * time.Minute)) {
+			t.Fatalf("expected window starting at the boundary, got %v", got[0])
+		}
+	})
+
+	t.Run("sliding assigns size/slide windows in ascending order", func(t *testing.T) {
+		t.Parallel()
+
+		got := Sliding(5*time.Minute, time.Minute).assign("k", base.Add(150*time.Second))
+		if len(got) != 5 {
+			t.Fatalf("expected 5 windows, got %d", len(got))
+		}
+
+		for i, b := range got {
+			start := base.Add(time.Duration(i-2) * time.Minute)
+			if !b.Start.Equal(start) || !b.End.Equal(start.Add(5*time.Minute)) {
+				t.Fatalf("window %d: expected start %v, got %v", i, start, b)
+			}
+		}
+	})
+
+	t.Run("session opens a gap-long window at the event", func(t *testing.T) {
+		t.Parallel()
+
+		ts := base.Add(17 * time.Second)
+
+		got := Session(time.Minute).assign("k", ts)
+		if len(got) != 1 || !got[0].Start.Equal(ts) || !got[0].End.Equal(ts.Add(time.Minute)) {
+			t.Fatalf("expected [%v, +1m), got %v", ts, got)
+		}
+	})
+}
@@ -0,0 +1,195 @@
+// Package window provides event-time windowing over messaging.Channel[T]
+// and messaging.Channel[U].
+//
+// A Window operator subscribes to a source Channel[T], assigns every
+// received Message[T] to one or more time windows of its key, and, when
+// a window closes, folds the window's messages into a single Message[U]
+// via the caller-supplied ReduceFn and forwards it to a destination
+// Channel[U]. Where the aggregator groups by correlation key and closes
+// groups by size or idle time, Window groups by key and event time.
+//
+// # Strategies
+//
+//   - Tumbling(size): fixed, non-overlapping windows [k·size, (k+1)·size).
+//   - Sliding(size, slide): windows of length size starting every slide;
+//     a message belongs to size/slide windows. "Per-merchant counters
+//     over 5-minute windows refreshed every minute" is
+//     Sliding(5*time.Minute, time.Minute).
+//   - Session(gap): per-key windows that stay open while messages keep
+//     arriving less than gap apart; overlapping sessions merge.
+//
+// Windows are aligned to the zero time.Time (time.Time.Truncate), so
+// every operator instance with the same strategy produces the same
+// bounds.
+//
+// # Event time, watermarks and lateness
+//
+// A message's event time is Headers.Timestamp (WithTimestampFn
+// overrides it); messages without one are dropped with
+// ErrTimestampMissing. The operator tracks a single watermark: the
+// highest event time seen minus WithMaxOutOfOrderness. The watermark
+// only moves forward. A window [start, end) fires once the watermark
+// reaches end, so messages up to WithMaxOutOfOrderness out of order
+// are still counted in the first emission.
+//
+// After firing, a window is kept for WithAllowedLateness. A late
+// message that still falls within that period is added to the window
+// and the window fires again with the updated content; consumers treat
+// a later emission for the same Window as replacing the earlier one.
+// Messages later than that are dropped with ErrLate.
+//
+// The watermark only advances when messages arrive. WithIdleTimeout
+// bounds how long the last windows wait on a quiet stream: once no
+// message has arrived for the timeout, every pending window fires and
+// the watermark moves to the end of the latest one. Stop fires every
+// pending window as well.
+//
+// # Observability hooks
+//
+//   - WithErrorHandler routes real failures (ReduceFn returned an error
+//     or panicked, forward Send failed, MaxWindows exceeded). Default:
+//     messaging.DefaultErrorHandler.
+//   - WithDropHandler routes intentional drops (missing timestamp, late
+//     message) with the reason. Default: nil — silent drop.
+//
+// # Lifecycle
+//
+// Window implements common/lifecycle.Component (worker-style). Start
+// registers the subscription on the source channel and spawns the idle
+// sweeper goroutine when WithIdleTimeout is configured. Stop cancels
+// the subscription, stops the sweeper, fires every pending window and
+// closes Done.
+//
+// # Error handling
+//
+// The handler installed on the source channel always returns nil;
+// nothing propagates to the source channel's Send caller, consistent
+// with the package-wide policy in modules/messaging/CODING_STANDARDS.md.
+package window
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/guidomantilla/yarumo/core/common/lifecycle"
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+var (
+	_ Window[any, any] = (*window[any, any])(nil)
+
+	_ ErrWindowFn = ErrWindow
+)
+
+// Window is the public interface for a windowing operator. It embeds
+// lifecycle.Component so callers wire it up with lifecycle.Build, and
+// messaging.Inspectable to expose its runtime statistics.
+type Window[T, U any] interface {
+	lifecycle.Component
+	messaging.Inspectable
+}
+
+// Bounds identifies one window of one key: the half-open event-time
+// interval [Start, End).
+type Bounds struct {
+	// Key is the value returned by KeyFn for the window's messages.
+	Key string
+	// Start is the inclusive lower bound.
+	Start time.Time
+	// End is the exclusive upper bound.
+	End time.Time
+}
+
+// Strategy describes how messages are assigned to windows. Build it
+// with Tumbling, Sliding or Session; the zero value is invalid.
+type Strategy struct {
+	kind  strategyKind
+	size  time.Duration
+	slide time.Duration
+}
+
+// KeyFn extracts the window key from msg. The default returns "" for
+// every message, so the whole stream shares one set of windows.
+type KeyFn[T any] func(msg messaging.Message[T]) string
+
+// TimestampFn returns the event time of msg. The default returns
+// msg.Headers.Timestamp. A zero time drops the message with
+// ErrTimestampMissing.
+type TimestampFn[T any] func(msg messaging.Message[T]) time.Time
+
+// ReduceFn folds the messages of a closed window into a single
+// Message[U] for publication to the destination channel. msgs are in
+// arrival order and safe to retain. A non-nil error is wrapped in
+// ErrWindow(ErrReduceFnFailed, err) and forwarded to the ErrorHandler;
+// a panic is recovered the same way.
+type ReduceFn[T, U any] func(bounds Bounds, msgs []messaging.Message[T]) (messaging.Message[U], error)
+
+// DropHandler is the optional observability hook invoked once per
+// intentional drop, with ErrTimestampMissing or ErrLate as err. msg is
+// type-erased; cast it inside the hook when payload-specific behavior
+// is needed. The hook must not block.
+type DropHandler func(ctx context.Context, msg any, err error)
+
+// ErrWindowFn is the function type for ErrWindow.
+type ErrWindowFn func(causes ...error) error
+
+// strategyKind enumerates the window assignment strategies.
+type strategyKind int
+
+const (
+	strategyInvalid strategyKind = iota
+	strategyTumbling
+	strategySliding
+	strategySession
+)
+
+// pane is the state of one window of one key. dirty is true while the
+// pane holds messages not yet covered by an emission.
+type pane[T any] struct {
+	bounds Bounds
+	msgs   []messaging.Message[T]
+	dirty  bool
+}
+
+// emission is a pane snapshot taken under the lock and reduced outside
+// it.
+type emission[T any] struct {
+	bounds Bounds
+	msgs   []messaging.Message[T]
+}
+
+// window is the Window operator implementation. It owns a single
+// subscription on the source channel, an optional idle sweeper
+// goroutine, and one map+lock protecting the panes and the watermark.
+type window[T, U any] struct {
+	name              string
+	src               messaging.Channel[T]
+	dst               messaging.Channel[U]
+	strategy          Strategy
+	reduce            ReduceFn[T, U]
+	key               KeyFn[T]
+	timestamp         TimestampFn[T]
+	maxOutOfOrderness time.Duration
+	allowedLateness   time.Duration
+	idleTimeout       time.Duration
+	maxWindows        int
+	errorHandler      messaging.ErrorHandler
+	dropHandler       DropHandler
+	clock             messaging.Clock
+	stats             messaging.StatsRecorder
+
+	done         chan struct{}
+	workerCancel context.CancelFunc
+	startOnce    sync.Once
+	stopOnce     sync.Once
+	doneOnce     sync.Once
+	workerWG     sync.WaitGroup
+
+	mu          sync.Mutex
+	cancel      messaging.Cancel
+	panes       map[string][]*pane[T]
+	paneCount   int
+	watermark   time.Time
+	lastArrival time.Time
+}
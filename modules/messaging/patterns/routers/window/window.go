@@ -0,0 +1,548 @@
+package window
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+	"github.com/guidomantilla/yarumo/core/common/lifecycle"
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+// sweeperTickDivisor sets the idle sweeper tick interval as a fraction
+// of the configured idle timeout, so pending windows fire within at
+// most 1.5 × timeout of the last arrival.
+const sweeperTickDivisor = 2
+
+// minSweeperTick caps the minimum sweeper tick interval to avoid
+// pathological CPU burn when WithIdleTimeout is set to a tiny value.
+const minSweeperTick = 10 * time.Millisecond
+
+// NewWindow constructs a Window operator that subscribes to src,
+// assigns each Message[T] to the windows of its key according to
+// strategy, and forwards one Message[U] per fired window to dst.
+//
+// name is used in lifecycle logs and must be non-empty. src, dst and
+// reduce are mandatory. strategy must come from Tumbling, Sliding or
+// Session — the zero Strategy is a caller bug and panics here.
+//
+// Optional behaviors:
+//
+//   - WithKeyFn partitions the stream (default: a single key).
+//   - WithTimestampFn replaces the Headers.Timestamp extractor.
+//   - WithMaxOutOfOrderness and WithAllowedLateness tune the
+//     watermark and how long fired windows accept late messages.
+//   - WithIdleTimeout fires pending windows on a quiet stream.
+//   - WithMaxWindows caps tracked windows (default 10000).
+//   - WithErrorHandler / WithDropHandler install observability hooks.
+//   - WithClock replaces the system clock (deterministic tests).
+func NewWindow[T, U any](name string, src messaging.Channel[T], dst messaging.Channel[U], strategy Strategy, reduce ReduceFn[T, U], opts ...Option[T]) Window[T, U] {
+	cassert.NotEmpty(name, "name is empty")
+	cassert.NotNil(src, "source channel is nil")
+	cassert.NotNil(dst, "destination channel is nil")
+	cassert.NotNil(reduce, "reduce function is nil")
+
+	if !strategy.valid() {
+		panic("window requires a strategy built with Tumbling, Sliding or Session")
+	}
+
+	options := NewOptions(opts...)
+
+	w := &window[T, U]{
+		name:              name,
+		src:               src,
+		dst:               dst,
+		strategy:          strategy,
+		reduce:            reduce,
+		key:               options.key,
+		timestamp:         options.timestamp,
+		maxOutOfOrderness: options.maxOutOfOrderness,
+		allowedLateness:   options.allowedLateness,
+		idleTimeout:       options.idleTimeout,
+		maxWindows:        options.maxWindows,
+		errorHandler:      options.errorHandler,
+		dropHandler:       options.dropHandler,
+		clock:             options.clock,
+		done:              make(chan struct{}),
+		panes:             map[string][]*pane[T]{},
+	}
+
+	w.stats.UseClock(options.clock)
+
+	return w
+}
+
+// Name returns the operator's identity used in lifecycle logs.
+func (w *window[T, U]) Name() string {
+	cassert.NotNil(w, "window is nil")
+
+	return w.name
+}
+
+// Start registers the windowing handler as a subscriber on the source
+// channel and (when WithIdleTimeout is configured) spawns the idle
+// sweeper goroutine. It satisfies the lifecycle.Component worker-style
+// contract: Start returns immediately after subscription and sweeper
+// spawn. Start is idempotent — a second invocation returns nil without
+// re-subscribing.
+func (w *window[T, U]) Start(ctx context.Context) error {
+	cassert.NotNil(w, "window is nil")
+
+	var startErr error
+
+	w.startOnce.Do(func() {
+		cancel, err := w.src.Subscribe(w.handle)
+		if err != nil {
+			startErr = lifecycle.ErrStart(err)
+
+			return
+		}
+
+		w.mu.Lock()
+		w.cancel = cancel
+		w.mu.Unlock()
+
+		if w.idleTimeout > 0 {
+			workerCtx, workerCancel := context.WithCancel(ctx)
+			w.workerCancel = workerCancel
+
+			w.workerWG.Go(func() {
+				w.runSweeper(workerCtx)
+			})
+		}
+	})
+
+	return startErr
+}
+
+// Stop cancels the source-channel subscription, signals the sweeper to
+// exit, waits for it, fires every window still holding messages not
+// yet emitted (so consumers see the trailing windows), and closes
+// Done. Stop is idempotent per the lifecycle.Component contract. It
+// returns lifecycle.ErrShutdown wrapping lifecycle.ErrShutdownTimeout
+// when ctx expires before the flush completes.
+func (w *window[T, U]) Stop(ctx context.Context) error {
+	cassert.NotNil(w, "window is nil")
+
+	w.stopOnce.Do(func() {
+		w.mu.Lock()
+		cancel := w.cancel
+		w.cancel = nil
+		w.mu.Unlock()
+
+		if cancel != nil {
+			cancel()
+		}
+
+		if w.workerCancel != nil {
+			w.workerCancel()
+		}
+
+		w.workerWG.Wait()
+
+		w.flush(ctx)
+
+		w.doneOnce.Do(func() { close(w.done) })
+	})
+
+	select {
+	case <-ctx.Done():
+		return lifecycle.ErrShutdown(lifecycle.ErrShutdownTimeout, ctx.Err())
+	default:
+		return nil
+	}
+}
+
+// Done returns the channel that is closed after Stop has flushed the
+// pending windows.
+func (w *window[T, U]) Done() <-chan struct{} {
+	cassert.NotNil(w, "window is nil")
+
+	return w.done
+}
+
+// Stats returns a snapshot of the operator's runtime statistics. Sent
+// counts the results forwarded to the destination and BufferLength the
+// messages held in tracked windows (a message in several sliding
+// windows counts once per window). Subscribers is 1 while the source
+// subscription is active.
+func (w *window[T, U]) Stats() messaging.Stats {
+	cassert.NotNil(w, "window is nil")
+
+	stats := w.stats.Snapshot()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.cancel != nil {
+		stats.Subscribers = 1
+	}
+
+	for _, panes := range w.panes {
+		for _, p := range panes {
+			stats.BufferLength += len(p.msgs)
+		}
+	}
+
+	return stats
+}
+
+// handle is the Handler[T] subscribed on the source channel. It adds
+// the message to its windows, advances the watermark, and emits every
+// window the watermark has passed. Always returns nil so windowing
+// concerns never propagate to the source channel's Send caller.
+func (w *window[T, U]) handle(ctx context.Context, msg messaging.Message[T]) error {
+	end := w.stats.Begin()
+	defer end(nil)
+
+	t := w.timestamp(msg)
+	if t.IsZero() {
+		w.reportDrop(ctx, msg, ErrTimestampMissing)
+
+		return nil
+	}
+
+	key := w.key(msg)
+
+	w.mu.Lock()
+
+	w.lastArrival = w.clock.Now()
+
+	// Advance the watermark and retire the windows it closes before
+	// adding msg, so closed windows do not count against maxWindows.
+	mark := t.Add(-w.maxOutOfOrderness)
+	if mark.After(w.watermark) {
+		w.watermark = mark
+	}
+
+	emissions := w.fire(false)
+	w.purge()
+
+	var err error
+	if w.strategy.kind == strategySession {
+		err = w.addSession(key, t, msg)
+	} else {
+		err = w.addFixed(key, t, msg)
+	}
+
+	// A late msg lands in windows the watermark already passed; fire
+	// them again with the updated content.
+	emissions = append(emissions, w.fire(false)...)
+	w.mu.Unlock()
+
+	switch {
+	case errors.Is(err, ErrLate):
+		w.reportDrop(ctx, msg, ErrLate)
+	case err != nil:
+		w.reportError(ctx, msg, ErrWindow(err))
+	}
+
+	w.emit(ctx, emissions)
+
+	return nil
+}
+
+// closed reports whether b is past its allowed lateness, i.e. can no
+// longer accept messages. Caller must hold w.mu.
+func (w *window[T, U]) closed(b Bounds) bool {
+	return !b.End.Add(w.allowedLateness).After(w.watermark)
+}
+
+// addFixed adds msg to every tumbling or sliding window of key
+// containing t that is still open. It returns ErrLate when every such
+// window is closed and ErrMaxWindowsExceeded when the windows to open
+// do not fit under maxWindows; msg is added nowhere in either case.
+// Caller must hold w.mu.
+func (w *window[T, U]) addFixed(key string, t time.Time, msg messaging.Message[T]) error {
+	var targets []Bounds
+
+	opened := 0
+
+	for _, b := range w.strategy.assign(key, t) {
+		if w.closed(b) {
+			continue
+		}
+
+		targets = append(targets, b)
+
+		if w.find(key, b.Start) == nil {
+			opened++
+		}
+	}
+
+	if len(targets) == 0 {
+		return ErrLate
+	}
+
+	if w.paneCount+opened > w.maxWindows {
+		return ErrMaxWindowsExceeded
+	}
+
+	for _, b := range targets {
+		p := w.find(key, b.Start)
+		if p == nil {
+			p = &pane[T]{bounds: b}
+			w.panes[key] = append(w.panes[key], p)
+			w.paneCount++
+		}
+
+		p.msgs = append(p.msgs, msg)
+		p.dirty = true
+	}
+
+	return nil
+}
+
+// addSession adds msg to the session of key covering [t, t+gap),
+// merging every open session of key it overlaps into one. It returns
+// ErrLate when the session is already closed and ErrMaxWindowsExceeded
+// when a new session does not fit under maxWindows. Caller must hold
+// w.mu.
+func (w *window[T, U]) addSession(key string, t time.Time, msg messaging.Message[T]) error {
+	b := w.strategy.assign(key, t)[0]
+	if w.closed(b) {
+		return ErrLate
+	}
+
+	merged := &pane[T]{bounds: b, dirty: true}
+
+	var kept []*pane[T]
+
+	for _, p := range w.panes[key] {
+		if p.bounds.Start.Before(merged.bounds.End) && merged.bounds.Start.Before(p.bounds.End) {
+			merged.bounds.Start = minTime(merged.bounds.Start, p.bounds.Start)
+			merged.bounds.End = maxTime(merged.bounds.End, p.bounds.End)
+			merged.msgs = append(merged.msgs, p.msgs...)
+
+			continue
+		}
+
+		kept = append(kept, p)
+	}
+
+	removed := len(w.panes[key]) - len(kept)
+	if removed == 0 && w.paneCount >= w.maxWindows {
+		return ErrMaxWindowsExceeded
+	}
+
+	merged.msgs = append(merged.msgs, msg)
+
+	w.panes[key] = append(kept, merged)
+	w.paneCount += 1 - removed
+
+	return nil
+}
+
+// find returns the pane of key starting at start, or nil. Caller must
+// hold w.mu.
+func (w *window[T, U]) find(key string, start time.Time) *pane[T] {
+	for _, p := range w.panes[key] {
+		if p.bounds.Start.Equal(start) {
+			return p
+		}
+	}
+
+	return nil
+}
+
+// fire snapshots every dirty pane the watermark has reached (every
+// dirty pane when all is true) and marks it clean. Emissions are
+// ordered by End, Start and Key so results leave in event-time order.
+// Caller must hold w.mu.
+func (w *window[T, U]) fire(all bool) []emission[T] {
+	var out []emission[T]
+
+	for _, panes := range w.panes {
+		for _, p := range panes {
+			if !p.dirty || (!all && p.bounds.End.After(w.watermark)) {
+				continue
+			}
+
+			p.dirty = false
+			out = append(out, emission[T]{bounds: p.bounds, msgs: slices.Clone(p.msgs)})
+		}
+	}
+
+	slices.SortFunc(out, func(a, b emission[T]) int {
+		return cmp.Or(
+			a.bounds.End.Compare(b.bounds.End),
+			a.bounds.Start.Compare(b.bounds.Start),
+			cmp.Compare(a.bounds.Key, b.bounds.Key),
+		)
+	})
+
+	return out
+}
+
+// purge forgets every clean pane past its allowed lateness. Caller must
+// hold w.mu.
+func (w *window[T, U]) purge() {
+	for key, panes := range w.panes {
+		kept := panes[:0]
+
+		for _, p := range panes {
+			if !p.dirty && w.closed(p.bounds) {
+				w.paneCount--
+
+				continue
+			}
+
+			kept = append(kept, p)
+		}
+
+		if len(kept) == 0 {
+			delete(w.panes, key)
+
+			continue
+		}
+
+		w.panes[key] = kept
+	}
+}
+
+// runSweeper periodically checks whether the stream went idle and, if
+// so, fires the pending windows. The goroutine exits when workerCtx is
+// cancelled by Stop.
+func (w *window[T, U]) runSweeper(workerCtx context.Context) {
+	interval := max(w.idleTimeout/sweeperTickDivisor, minSweeperTick)
+
+	ticker := w.clock.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-workerCtx.Done():
+			return
+		case <-ticker.C():
+			w.sweepIdle(workerCtx)
+		}
+	}
+}
+
+// sweepIdle fires every dirty pane once no message has arrived for
+// idleTimeout, advancing the watermark to the end of the latest fired
+// window so the fired windows are then purged like any other.
+func (w *window[T, U]) sweepIdle(ctx context.Context) {
+	now := w.clock.Now()
+
+	w.mu.Lock()
+
+	if w.lastArrival.IsZero() || now.Sub(w.lastArrival) < w.idleTimeout {
+		w.mu.Unlock()
+
+		return
+	}
+
+	emissions := w.fire(true)
+	for _, e := range emissions {
+		w.watermark = maxTime(w.watermark, e.bounds.End)
+	}
+
+	w.purge()
+	w.mu.Unlock()
+
+	w.emit(ctx, emissions)
+}
+
+// flush is invoked from Stop after the sweeper has exited and the
+// source subscription has been cancelled. It fires every dirty pane
+// and forgets all windows.
+func (w *window[T, U]) flush(ctx context.Context) {
+	w.mu.Lock()
+	emissions := w.fire(true)
+	w.panes = map[string][]*pane[T]{}
+	w.paneCount = 0
+	w.mu.Unlock()
+
+	w.emit(ctx, emissions)
+}
+
+// emit reduces every emission (under panic recovery) and forwards the
+// result to dst. Failures route through the ErrorHandler.
+func (w *window[T, U]) emit(ctx context.Context, emissions []emission[T]) {
+	for _, e := range emissions {
+		out, err := w.reduceWithRecover(e.bounds, e.msgs)
+		if err != nil {
+			w.reportError(ctx, nil, err)
+
+			continue
+		}
+
+		err = w.dst.Send(ctx, out)
+		if err != nil {
+			w.reportError(ctx, nil, ErrWindow(ErrForwardFailed, err))
+
+			continue
+		}
+
+		w.stats.RecordSent()
+	}
+}
+
+// reduceWithRecover invokes ReduceFn under panic recovery. A returned
+// error becomes ErrWindow(ErrReduceFnFailed, err); a panic becomes
+// ErrWindow(ErrReduceFnFailed, "panic: <value>").
+func (w *window[T, U]) reduceWithRecover(bounds Bounds, msgs []messaging.Message[T]) (out messaging.Message[U], err error) {
+	defer func() {
+		rec := recover()
+		if rec == nil {
+			return
+		}
+
+		err = ErrWindow(ErrReduceFnFailed, fmt.Errorf("panic: %v", rec))
+	}()
+
+	out, err = w.reduce(bounds, msgs)
+	if err != nil {
+		return out, ErrWindow(ErrReduceFnFailed, err)
+	}
+
+	return out, nil
+}
+
+// reportError forwards err to the configured ErrorHandler. The handler
+// is guaranteed non-nil by NewOptions; the nil-guard is defensive only.
+func (w *window[T, U]) reportError(ctx context.Context, msg any, err error) {
+	w.stats.RecordFailure()
+
+	if w.errorHandler == nil {
+		return
+	}
+
+	w.errorHandler(ctx, msg, err)
+}
+
+// reportDrop forwards msg and the drop reason to the configured
+// DropHandler. DropHandler is nil by default (silent drops); the guard
+// skips invocation in that case.
+func (w *window[T, U]) reportDrop(ctx context.Context, msg any, err error) {
+	w.stats.RecordDrop()
+
+	if w.dropHandler == nil {
+		return
+	}
+
+	w.dropHandler(ctx, msg, err)
+}
+
+// minTime returns the earlier of a and b.
+func minTime(a time.Time, b time.Time) time.Time {
+	if b.Before(a) {
+		return b
+	}
+
+	return a
+}
+
+// maxTime returns the later of a and b.
+func maxTime(a time.Time, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+
+	return a
+}
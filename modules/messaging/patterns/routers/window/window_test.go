@@ -0,0 +1,724 @@
+package window
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/guidomantilla/yarumo/core/common/lifecycle"
+	"github.com/guidomantilla/yarumo/messaging"
+	"github.com/guidomantilla/yarumo/messaging/messagingtest"
+)
+
+// base is the event-time origin shared by the tests; it is aligned to
+// every window size used below.
+var base = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// result is the payload forwarded by collectReduce: the window and its
+// payloads in arrival order.
+type result struct {
+	bounds   Bounds
+	payloads []int
+}
+
+// collectReduce folds a window into a result.
+func collectReduce(bounds Bounds, msgs []messaging.Message[int]) (messaging.Message[result], error) {
+	out := result{bounds: bounds}
+	for _, m := range msgs {
+		out.payloads = append(out.payloads, m.Payload)
+	}
+
+	return messaging.Message[result]{Payload: out}, nil
+}
+
+// byCorrelation keys messages by Headers.CorrelationID.
+func byCorrelation(msg messaging.Message[int]) string {
+	return msg.Headers.CorrelationID
+}
+
+// at builds a Message[int] with the given payload, key and event time
+// offset from base.
+func at(payload int, key string, offset time.Duration) messaging.Message[int] {
+	return messaging.Message[int]{
+		Payload: payload,
+		Headers: messaging.Headers{CorrelationID: key, Timestamp: base.Add(offset)},
+	}
+}
+
+// captureErrors returns a thread-safe ErrorHandler or DropHandler
+// recording every reported error, and a getter returning a copy.
+func captureErrors() (func(context.Context, any, error), func() []error) {
+	var mu sync.Mutex
+
+	captured := []error{}
+
+	handler := func(_ context.Context, _ any, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		captured = append(captured, err)
+	}
+
+	get := func() []error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return slices.Clone(captured)
+	}
+
+	return handler, get
+}
+
+// start builds a Window from src to a fresh RecordingChannel, starts it
+// and registers a Stop cleanup.
+func start(t *testing.T, src messaging.Channel[int], strategy Strategy, opts ...Option[int]) (Window[int, result], *messagingtest.RecordingChannel[result]) {
+	t.Helper()
+
+	dst := messagingtest.NewRecordingChannel[result]()
+	w := NewWindow("test", src, dst, strategy, collectReduce, opts...)
+
+	err := w.Start(context.Background())
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+
+	t.Cleanup(func() { _ = w.Stop(context.Background()) })
+
+	return w, dst
+}
+
+// send sends every msg to src, failing t on error.
+func send(t *testing.T, src messaging.Channel[int], msgs ...messaging.Message[int]) {
+	t.Helper()
+
+	for _, msg := range msgs {
+		err := src.Send(context.Background(), msg)
+		if err != nil {
+			t.Fatalf("send %d: %v", msg.Payload, err)
+		}
+	}
+}
+
+// assertResult fails t unless got covers [start, end) of key with the
+// given payloads.
+func assertResult(t *testing.T, got result, key string, start time.Duration, end time.Duration, payloads ...int) {
+	t.Helper()
+
+	want := Bounds{Key: key, Start: base.Add(start), End: base.Add(end)}
+	if got.bounds != want {
+		t.Fatalf("expected bounds %v, got %v", want, got.bounds)
+	}
+
+	if !slices.Equal(got.payloads, payloads) {
+		t.Fatalf("expected payloads %v, got %v", payloads, got.payloads)
+	}
+}
+
+func TestNewWindow(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns a window carrying the given name", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		dst := messaging.NewPipelineChannel[result]()
+
+		w := NewWindow("fraud-counters", src, dst, Tumbling(time.Minute), collectReduce)
+		if w == nil {
+			t.Fatal("expected non-nil window")
+		}
+
+		if w.Name() != "fraud-counters" {
+			t.Fatalf("expected name fraud-counters, got %q", w.Name())
+		}
+	})
+
+	t.Run("panics on the zero Strategy", func(t *testing.T) {
+		t.Parallel()
+
+		defer func() {
+			if recover() == nil {
+				t.Fatal("expected panic for the zero Strategy")
+			}
+		}()
+
+		src := messaging.NewPipelineChannel[int]()
+		dst := messaging.NewPipelineChannel[result]()
+
+		_ = NewWindow("test", src, dst, Strategy{}, collectReduce)
+	})
+}
+
+func TestWindow_Tumbling(t *testing.T) {
+	t.Parallel()
+
+	t.Run("fires a window once the watermark passes its end", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		w, dst := start(t, src, Tumbling(5*time.Minute))
+
+		send(t, src, at(1, "", time.Minute), at(2, "", 2*time.Minute))
+		dst.AssertCount(t, 0)
+
+		send(t, src, at(3, "", 6*time.Minute))
+		dst.AssertCount(t, 1)
+		assertResult(t, dst.Payloads()[0], "", 0, 5*time.Minute, 1, 2)
+
+		err := w.Stop(context.Background())
+		if err != nil {
+			t.Fatalf("stop: %v", err)
+		}
+
+		dst.AssertCount(t, 2)
+		assertResult(t, dst.Payloads()[1], "", 5*time.Minute, 10*time.Minute, 3)
+	})
+
+	t.Run("keys window independently and emit in event-time order", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		_, dst := start(t, src, Tumbling(time.Minute), WithKeyFn(byCorrelation))
+
+		send(t, src,
+			at(1, "m2", 10*time.Second),
+			at(2, "m1", 20*time.Second),
+			at(3, "m1", 70*time.Second),
+			at(4, "m2", 130*time.Second),
+		)
+
+		dst.AssertCount(t, 3)
+
+		got := dst.Payloads()
+		assertResult(t, got[0], "m1", 0, time.Minute, 2)
+		assertResult(t, got[1], "m2", 0, time.Minute, 1)
+		assertResult(t, got[2], "m1", time.Minute, 2*time.Minute, 3)
+	})
+}
+
+func TestWindow_Sliding(t *testing.T) {
+	t.Parallel()
+
+	t.Run("counts a message in every overlapping window", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		_, dst := start(t, src, Sliding(2*time.Minute, time.Minute), WithKeyFn(byCorrelation))
+
+		send(t, src,
+			at(1, "m", 30*time.Second),
+			at(2, "m", 90*time.Second),
+			at(3, "m", 5*time.Minute),
+		)
+
+		dst.AssertCount(t, 3)
+
+		got := dst.Payloads()
+		assertResult(t, got[0], "m", -time.Minute, time.Minute, 1)
+		assertResult(t, got[1], "m", 0, 2*time.Minute, 1, 2)
+		assertResult(t, got[2], "m", time.Minute, 3*time.Minute, 2)
+	})
+}
+
+func TestWindow_Session(t *testing.T) {
+	t.Parallel()
+
+	t.Run("extends a session while messages arrive within the gap", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		_, dst := start(t, src, Session(time.Minute), WithKeyFn(byCorrelation))
+
+		send(t, src,
+			at(1, "u", 0),
+			at(2, "u", 30*time.Second),
+			at(3, "u", 80*time.Second),
+		)
+		dst.AssertCount(t, 0)
+
+		send(t, src, at(4, "u", 5*time.Minute))
+		dst.AssertCount(t, 1)
+		assertResult(t, dst.Payloads()[0], "u", 0, 140*time.Second, 1, 2, 3)
+	})
+
+	t.Run("merges sessions bridged by an out-of-order message", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		_, dst := start(t, src, Session(time.Minute),
+			WithKeyFn(byCorrelation),
+			WithMaxOutOfOrderness[int](5*time.Minute))
+
+		send(t, src,
+			at(1, "u", 0),
+			at(2, "u", 100*time.Second),
+			at(3, "u", 50*time.Second),
+			at(4, "u", 20*time.Minute),
+		)
+
+		dst.AssertCount(t, 1)
+		assertResult(t, dst.Payloads()[0], "u", 0, 160*time.Second, 1, 2, 3)
+	})
+
+	t.Run("keeps sessions of different keys apart", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		_, dst := start(t, src, Session(time.Minute), WithKeyFn(byCorrelation))
+
+		send(t, src,
+			at(1, "a", 0),
+			at(2, "b", 10*time.Second),
+			at(3, "a", 5*time.Minute),
+		)
+
+		dst.AssertCount(t, 2)
+
+		got := dst.Payloads()
+		assertResult(t, got[0], "a", 0, time.Minute, 1)
+		assertResult(t, got[1], "b", 10*time.Second, 70*time.Second, 2)
+	})
+}
+
+func TestWindow_Watermark(t *testing.T) {
+	t.Parallel()
+
+	t.Run("max out-of-orderness holds the window open", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		_, dst := start(t, src, Tumbling(5*time.Minute), WithMaxOutOfOrderness[int](time.Minute))
+
+		send(t, src, at(1, "", time.Minute), at(2, "", 330*time.Second), at(3, "", 4*time.Minute))
+		dst.AssertCount(t, 0)
+
+		send(t, src, at(4, "", 390*time.Second))
+		dst.AssertCount(t, 1)
+		assertResult(t, dst.Payloads()[0], "", 0, 5*time.Minute, 1, 3)
+	})
+
+	t.Run("late message within the allowed lateness re-fires the window", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		dropHandler, getDrops := captureErrors()
+
+		_, dst := start(t, src, Tumbling(5*time.Minute),
+			WithAllowedLateness[int](2*time.Minute),
+			WithDropHandler[int](dropHandler))
+
+		send(t, src, at(1, "", time.Minute), at(2, "", 6*time.Minute))
+		dst.AssertCount(t, 1)
+
+		send(t, src, at(3, "", 3*time.Minute))
+		dst.AssertCount(t, 2)
+		assertResult(t, dst.Payloads()[1], "", 0, 5*time.Minute, 1, 3)
+
+		send(t, src, at(4, "", 8*time.Minute), at(5, "", 4*time.Minute))
+		dst.AssertCount(t, 2)
+
+		drops := getDrops()
+		if len(drops) != 1 || !errors.Is(drops[0], ErrLate) {
+			t.Fatalf("expected one ErrLate drop, got %v", drops)
+		}
+	})
+
+	t.Run("message later than the allowed lateness is dropped", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		dropHandler, getDrops := captureErrors()
+
+		w, dst := start(t, src, Tumbling(time.Minute), WithDropHandler[int](dropHandler))
+
+		send(t, src, at(1, "", 5*time.Minute), at(2, "", 30*time.Second))
+		dst.AssertCount(t, 0)
+
+		drops := getDrops()
+		if len(drops) != 1 || !errors.Is(drops[0], ErrLate) {
+			t.Fatalf("expected one ErrLate drop, got %v", drops)
+		}
+
+		if got := w.Stats().Dropped; got != 1 {
+			t.Fatalf("expected 1 drop in stats, got %d", got)
+		}
+	})
+
+	t.Run("message without event time is dropped", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		dropHandler, getDrops := captureErrors()
+
+		_, dst := start(t, src, Tumbling(time.Minute), WithDropHandler[int](dropHandler))
+
+		send(t, src, messaging.Message[int]{Payload: 1})
+		dst.AssertCount(t, 0)
+
+		drops := getDrops()
+		if len(drops) != 1 || !errors.Is(drops[0], ErrTimestampMissing) {
+			t.Fatalf("expected one ErrTimestampMissing drop, got %v", drops)
+		}
+	})
+
+	t.Run("WithTimestampFn overrides the event time", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		_, dst := start(t, src, Tumbling(time.Minute),
+			WithTimestampFn(func(msg messaging.Message[int]) time.Time {
+				return base.Add(time.Duration(msg.Payload) * time.Second)
+			}))
+
+		send(t, src, messaging.Message[int]{Payload: 10}, messaging.Message[int]{Payload: 70})
+		dst.AssertCount(t, 1)
+		assertResult(t, dst.Payloads()[0], "", 0, time.Minute, 10)
+	})
+}
+
+func TestWindow_IdleTimeout(t *testing.T) {
+	t.Parallel()
+
+	t.Run("fires pending windows once the stream goes quiet", func(t *testing.T) {
+		t.Parallel()
+
+		clock := messagingtest.NewFakeClock(time.Now())
+		src := messaging.NewPipelineChannel[int]()
+
+		_, dst := start(t, src, Tumbling(5*time.Minute),
+			WithIdleTimeout[int](time.Second),
+			WithClock[int](clock))
+
+		clock.WaitForTimers(t, 1)
+
+		send(t, src, at(1, "", time.Minute))
+
+		clock.Advance(500 * time.Millisecond)
+		dst.AssertCount(t, 0)
+
+		clock.Advance(500 * time.Millisecond)
+		dst.WaitFor(t, 1)
+		assertResult(t, dst.Payloads()[0], "", 0, 5*time.Minute, 1)
+	})
+}
+
+func TestWindow_Failures(t *testing.T) {
+	t.Parallel()
+
+	t.Run("MaxWindows exceeded reports the error and drops the message", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		errHandler, getErrs := captureErrors()
+
+		_, dst := start(t, src, Tumbling(time.Minute),
+			WithKeyFn(byCorrelation),
+			WithMaxWindows[int](1),
+			WithErrorHandler[int](errHandler))
+
+		send(t, src, at(1, "a", 0), at(2, "b", 0))
+
+		errs := getErrs()
+		if len(errs) != 1 || !errors.Is(errs[0], ErrMaxWindowsExceeded) {
+			t.Fatalf("expected one ErrMaxWindowsExceeded, got %v", errs)
+		}
+
+		send(t, src, at(3, "a", 2*time.Minute))
+		dst.AssertCount(t, 1)
+		assertResult(t, dst.Payloads()[0], "a", 0, time.Minute, 1)
+	})
+
+	t.Run("session MaxWindows exceeded", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		errHandler, getErrs := captureErrors()
+
+		start(t, src, Session(time.Minute),
+			WithMaxWindows[int](1),
+			WithMaxOutOfOrderness[int](time.Hour),
+			WithErrorHandler[int](errHandler))
+
+		send(t, src, at(1, "", 0), at(2, "", 30*time.Second), at(3, "", 10*time.Minute))
+
+		errs := getErrs()
+		if len(errs) != 1 || !errors.Is(errs[0], ErrMaxWindowsExceeded) {
+			t.Fatalf("expected one ErrMaxWindowsExceeded, got %v", errs)
+		}
+	})
+
+	t.Run("ReduceFn error and panic report ErrReduceFnFailed", func(t *testing.T) {
+		t.Parallel()
+
+		boom := errors.New("boom")
+		calls := 0
+
+		reduce := func(_ Bounds, _ []messaging.Message[int]) (messaging.Message[int], error) {
+			calls++
+			if calls == 1 {
+				return messaging.Message[int]{}, boom
+			}
+
+			panic("kaboom")
+		}
+
+		src := messaging.NewPipelineChannel[int]()
+		dst := messagingtest.NewRecordingChannel[int]()
+		errHandler, getErrs := captureErrors()
+
+		w := NewWindow("test", src, dst, Tumbling(time.Minute), reduce, WithErrorHandler[int](errHandler))
+
+		err := w.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		send(t, src, at(1, "", 0), at(2, "", time.Minute), at(3, "", 2*time.Minute))
+		dst.AssertCount(t, 0)
+
+		errs := getErrs()
+		if len(errs) != 2 {
+			t.Fatalf("expected 2 captured errors, got %v", errs)
+		}
+
+		if !errors.Is(errs[0], ErrReduceFnFailed) || !errors.Is(errs[0], boom) {
+			t.Fatalf("expected ErrReduceFnFailed wrapping boom, got %v", errs[0])
+		}
+
+		if !errors.Is(errs[1], ErrReduceFnFailed) || !errors.Is(errs[1], ErrWindowFailed) {
+			t.Fatalf("expected ErrReduceFnFailed wrapping the panic, got %v", errs[1])
+		}
+
+		_ = w.Stop(context.Background())
+	})
+
+	t.Run("forward failure reports ErrForwardFailed", func(t *testing.T) {
+		t.Parallel()
+
+		boom := errors.New("dst down")
+		src := messaging.NewPipelineChannel[int]()
+		dst := messagingtest.NewRecordingChannel[result]()
+
+		_, err := dst.Subscribe(func(_ context.Context, _ messaging.Message[result]) error { return boom })
+		if err != nil {
+			t.Fatalf("subscribe dst: %v", err)
+		}
+
+		errHandler, getErrs := captureErrors()
+
+		w := NewWindow("test", src, dst, Tumbling(time.Minute), collectReduce, WithErrorHandler[int](errHandler))
+
+		err = w.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		send(t, src, at(1, "", 0), at(2, "", time.Minute))
+
+		errs := getErrs()
+		if len(errs) != 1 || !errors.Is(errs[0], ErrForwardFailed) || !errors.Is(errs[0], boom) {
+			t.Fatalf("expected ErrForwardFailed wrapping boom, got %v", errs)
+		}
+
+		stats := w.Stats()
+		if stats.Sent != 0 || stats.Failed != 1 {
+			t.Fatalf("expected Sent 0 and Failed 1, got %+v", stats)
+		}
+
+		_ = w.Stop(context.Background())
+	})
+}
+
+func TestWindow_Stats(t *testing.T) {
+	t.Parallel()
+
+	t.Run("reports buffered messages, subscribers and sent results", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		w, _ := start(t, src, Sliding(2*time.Minute, time.Minute))
+
+		send(t, src, at(1, "", 90*time.Second))
+
+		stats := w.Stats()
+		if stats.Subscribers != 1 || stats.BufferLength != 2 || stats.Sent != 0 {
+			t.Fatalf("expected Subscribers 1, BufferLength 2, Sent 0, got %+v", stats)
+		}
+
+		send(t, src, at(2, "", 4*time.Minute))
+
+		stats = w.Stats()
+		if stats.BufferLength != 2 || stats.Sent != 2 {
+			t.Fatalf("expected BufferLength 2, Sent 2, got %+v", stats)
+		}
+
+		err := w.Stop(context.Background())
+		if err != nil {
+			t.Fatalf("stop: %v", err)
+		}
+
+		stats = w.Stats()
+		if stats.Subscribers != 0 || stats.BufferLength != 0 || stats.Sent != 4 {
+			t.Fatalf("expected Subscribers 0, BufferLength 0, Sent 4, got %+v", stats)
+		}
+	})
+}
+
+func TestWindow_Lifecycle(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Start is idempotent", func(t *testing.T) {
+		t.Parallel()
+
+		src := messagingtest.NewRecordingChannel[int]()
+		w, _ := start(t, src, Tumbling(time.Minute))
+
+		err := w.Start(context.Background())
+		if err != nil {
+			t.Fatalf("second start: %v", err)
+		}
+
+		if got := src.Stats().Subscribers; got != 1 {
+			t.Fatalf("expected 1 subscriber after double Start, got %d", got)
+		}
+	})
+
+	t.Run("Stop detaches, is idempotent and closes Done", func(t *testing.T) {
+		t.Parallel()
+
+		src := messagingtest.NewRecordingChannel[int]()
+		w, dst := start(t, src, Tumbling(time.Minute), WithIdleTimeout[int](time.Hour))
+
+		select {
+		case <-w.Done():
+			t.Fatal("Done closed before Stop")
+		default:
+		}
+
+		for range 2 {
+			err := w.Stop(context.Background())
+			if err != nil {
+				t.Fatalf("stop: %v", err)
+			}
+		}
+
+		select {
+		case <-w.Done():
+		default:
+			t.Fatal("Done not closed after Stop")
+		}
+
+		send(t, src, at(1, "", 0), at(2, "", time.Hour))
+		dst.AssertCount(t, 0)
+	})
+
+	t.Run("Stop with expired ctx returns ErrShutdownTimeout", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		w, _ := start(t, src, Tumbling(time.Minute))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := w.Stop(ctx)
+		if !errors.Is(err, lifecycle.ErrShutdownTimeout) {
+			t.Fatalf("expected ErrShutdownTimeout, got %v", err)
+		}
+	})
+}
+
+func TestWindow_Options(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil and non-positive arguments are no-ops", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(
+			WithKeyFn[int](nil),
+			WithTimestampFn[int](nil),
+			WithMaxOutOfOrderness[int](0),
+			WithAllowedLateness[int](-time.Second),
+			WithIdleTimeout[int](0),
+			WithMaxWindows[int](0),
+			WithErrorHandler[int](nil),
+			WithDropHandler[int](nil),
+			WithClock[int](nil),
+		)
+
+		if opts.key == nil || opts.timestamp == nil || opts.errorHandler == nil || opts.clock == nil {
+			t.Fatal("expected defaults preserved on nil arguments")
+		}
+
+		if opts.dropHandler != nil {
+			t.Fatal("expected nil drop handler preserved on nil argument")
+		}
+
+		if opts.maxOutOfOrderness != 0 || opts.allowedLateness != 0 || opts.idleTimeout != 0 {
+			t.Fatal("expected durations unchanged on non-positive arguments")
+		}
+
+		if opts.maxWindows != defaultMaxWindows {
+			t.Fatalf("expected default maxWindows preserved, got %d", opts.maxWindows)
+		}
+	})
+
+	t.Run("defaults use a single key and Headers.Timestamp", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions[int]()
+		msg := at(1, "ignored", time.Minute)
+
+		if got := opts.key(msg); got != "" {
+			t.Fatalf("expected empty default key, got %q", got)
+		}
+
+		if got := opts.timestamp(msg); !got.Equal(base.Add(time.Minute)) {
+			t.Fatalf("expected Headers.Timestamp, got %v", got)
+		}
+	})
+
+	t.Run("options install their values", func(t *testing.T) {
+		t.Parallel()
+
+		clock := messagingtest.NewFakeClock(time.Time{})
+
+		opts := NewOptions(
+			WithMaxOutOfOrderness[int](time.Second),
+			WithAllowedLateness[int](2*time.Second),
+			WithIdleTimeout[int](3*time.Second),
+			WithMaxWindows[int](4),
+			WithClock[int](clock),
+		)
+
+		if opts.maxOutOfOrderness != time.Second || opts.allowedLateness != 2*time.Second || opts.idleTimeout != 3*time.Second {
+			t.Fatalf("unexpected durations: %+v", opts)
+		}
+
+		if opts.maxWindows != 4 || opts.clock != clock {
+			t.Fatalf("unexpected maxWindows or clock: %+v", opts)
+		}
+	})
+}
+
+func TestErrWindow(t *testing.T) {
+	t.Parallel()
+
+	err := ErrWindow(ErrLate)
+
+	if !errors.Is(err, ErrWindowFailed) || !errors.Is(err, ErrLate) {
+		t.Fatalf("expected ErrWindowFailed and ErrLate, got %v", err)
+	}
+
+	var werr *Error
+	if !errors.As(err, &werr) || werr.Type != WindowType {
+		t.Fatalf("expected *Error of type %q, got %v", WindowType, err)
+	}
+
+	if werr.Error() == "" {
+		t.Fatal("expected non-empty error string")
+	}
+}
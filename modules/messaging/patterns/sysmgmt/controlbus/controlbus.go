@@ -22,6 +22,9 @@ type controlBus struct {
 	handlers           map[string]Handler
 	unknownVerbHandler Handler
 	errorHandler       messaging.ErrorHandler
+	inspectables       map[string]messaging.Inspectable
+	registry           messaging.ChannelRegistry
+	stats              messaging.StatsRecorder
 
 	done      chan struct{}
 	startOnce sync.Once
@@ -47,6 +50,8 @@ type controlBus struct {
 //     custom hook for handler panics and forward failures.
 //   - WithUnknownVerbHandler overrides the default unknown-verb response
 //     (Result{Success: false, Message: "unknown verb"}).
+//   - WithInspectable and WithChannelRegistry feed the built-in
+//     StatsVerb, installed unless handlers already defines it.
 func NewControlBus(name string, cmdChan messaging.Channel[Command], resChan messaging.Channel[Result], handlers map[string]Handler, opts ...Option) ControlBus {
 	cassert.NotEmpty(name, "name is empty")
 	cassert.NotNil(cmdChan, "command channel is nil")
@@ -55,15 +60,28 @@ func NewControlBus(name string, cmdChan messaging.Channel[Command], resChan mess
 
 	options := NewOptions(opts...)
 
-	return &controlBus{
+	b := &controlBus{
 		name:               name,
 		cmdChan:            cmdChan,
 		resChan:            resChan,
 		handlers:           maps.Clone(handlers),
 		unknownVerbHandler: options.unknownVerbHandler,
 		errorHandler:       options.errorHandler,
+		inspectables:       options.inspectables,
+		registry:           options.registry,
 		done:               make(chan struct{}),
 	}
+
+	if b.handlers == nil {
+		b.handlers = map[string]Handler{}
+	}
+
+	_, overridden := b.handlers[StatsVerb]
+	if !overridden {
+		b.handlers[StatsVerb] = b.handleStats
+	}
+
+	return b
 }
 
 // Name returns the bus's identity used in lifecycle logs.
@@ -135,12 +153,31 @@ func (b *controlBus) Done() <-chan struct{} {
 	return b.done
 }
 
+// Stats returns a snapshot of the control bus's runtime statistics.
+// Subscribers is 1 while the source subscription is active.
+func (b *controlBus) Stats() messaging.Stats {
+	cassert.NotNil(b, "controlBus is nil")
+
+	stats := b.stats.Snapshot()
+
+	b.mu.Lock()
+	if b.cancel != nil {
+		stats.Subscribers = 1
+	}
+	b.mu.Unlock()
+
+	return stats
+}
+
 // handle is the Handler[Command] subscribed on the command channel. It
 // resolves the verb-specific handler, invokes it under panic recovery,
 // and publishes the resulting Result to the reply channel. The function
 // itself always returns nil so bus concerns never propagate to the
 // command channel's Send caller.
 func (b *controlBus) handle(ctx context.Context, msg messaging.Message[Command]) error {
+	end := b.stats.Begin()
+	defer end(nil)
+
 	handler := b.resolve(msg.Payload.Verb)
 
 	result := b.invokeWithRecover(ctx, msg.Payload, handler)
@@ -148,8 +185,12 @@ func (b *controlBus) handle(ctx context.Context, msg messaging.Message[Command])
 	err := b.resChan.Send(ctx, messaging.Message[Result]{Payload: result})
 	if err != nil {
 		b.report(ctx, msg, ErrControlBus(ErrForwardFailed, err))
+
+		return nil
 	}
 
+	b.stats.RecordSent()
+
 	return nil
 }
 
@@ -166,6 +207,49 @@ func (b *controlBus) resolve(verb string) Handler {
 	return h
 }
 
+// handleStats is the built-in StatsVerb handler. It collects the Stats
+// of the bus itself, of every channel in the configured registry and
+// of every WithInspectable component, in that order so later sources
+// win on a name clash, and answers with the ones selected by
+// cmd.Target. An unknown Target yields Success false.
+func (b *controlBus) handleStats(_ context.Context, cmd Command) Result {
+	all := map[string]messaging.Stats{b.name: b.Stats()}
+
+	if b.registry != nil {
+		for _, name := range b.registry.Names() {
+			channel, err := b.registry.Resolve(name)
+			if err != nil {
+				continue
+			}
+
+			stats, ok := messaging.StatsOf(channel)
+			if ok {
+				all[name] = stats
+			}
+		}
+	}
+
+	for name, target := range b.inspectables {
+		all[name] = target.Stats()
+	}
+
+	if cmd.Target == "" {
+		data := make(map[string]any, len(all))
+		for name, stats := range all {
+			data[name] = stats
+		}
+
+		return Result{Command: cmd, Success: true, Message: fmt.Sprintf("%d components", len(data)), Data: data}
+	}
+
+	stats, ok := all[cmd.Target]
+	if !ok {
+		return Result{Command: cmd, Success: false, Message: fmt.Sprintf("unknown target %q", cmd.Target)}
+	}
+
+	return Result{Command: cmd, Success: true, Message: cmd.Target, Data: map[string]any{cmd.Target: stats}}
+}
+
 // invokeWithRecover runs the chosen Handler under panic recovery. A
 // panic becomes a Result{Success: false} whose Message records the
 // panic value and triggers the ErrorHandler with ErrHandlerPanic.
@@ -192,6 +276,8 @@ func (b *controlBus) invokeWithRecover(ctx context.Context, cmd Command, handler
 // guaranteed non-nil by NewOptions (defaults to
 // messaging.DefaultErrorHandler), so the nil-guard is defensive only.
 func (b *controlBus) report(ctx context.Context, msg messaging.Message[Command], err error) {
+	b.stats.RecordFailure()
+
 	if b.errorHandler == nil {
 		return
 	}
@@ -655,6 +655,154 @@ func TestControlBus_Lifecycle(t *testing.T) {
 	})
 }
 
+func TestControlBus_StatsVerb(t *testing.T) {
+	t.Parallel()
+
+	// dispatch starts a bus built from opts, sends cmd and returns the
+	// single published Result.
+	dispatch := func(t *testing.T, handlers map[string]Handler, cmd Command, opts ...Option) Result {
+		t.Helper()
+
+		cmdChan := messaging.NewPipelineChannel[Command]()
+		resChan := messaging.NewPipelineChannel[Result]()
+
+		h, get := captureResults()
+
+		_, err := resChan.Subscribe(h)
+		if err != nil {
+			t.Fatalf("subscribe: %v", err)
+		}
+
+		b := NewControlBus("bus", cmdChan, resChan, handlers, opts...)
+
+		err = b.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		t.Cleanup(func() { _ = b.Stop(context.Background()) })
+
+		err = cmdChan.Send(context.Background(), messaging.Message[Command]{Payload: cmd})
+		if err != nil {
+			t.Fatalf("send: %v", err)
+		}
+
+		results := get()
+		if len(results) != 1 {
+			t.Fatalf("expected 1 result, got %d", len(results))
+		}
+
+		return results[0]
+	}
+
+	t.Run("empty target reports every component", func(t *testing.T) {
+		t.Parallel()
+
+		registry := messaging.NewChannelRegistry()
+
+		orders := messaging.NewNullChannel[int]()
+
+		err := registry.Register("orders", orders)
+		if err != nil {
+			t.Fatalf("register: %v", err)
+		}
+
+		err = orders.Send(context.Background(), messaging.Message[int]{Payload: 1})
+		if err != nil {
+			t.Fatalf("send: %v", err)
+		}
+
+		got := dispatch(t, map[string]Handler{}, Command{Verb: StatsVerb},
+			WithChannelRegistry(registry),
+			WithInspectable("audit", fixedStats{BufferCapacity: 4}),
+		)
+		if !got.Success {
+			t.Fatalf("expected Success=true, got Message %q", got.Message)
+		}
+
+		if len(got.Data) != 3 {
+			t.Fatalf("expected 3 entries (bus, orders, audit), got %v", got.Data)
+		}
+
+		stats, ok := got.Data["orders"].(messaging.Stats)
+		if !ok {
+			t.Fatalf("Data[orders] is %T, want messaging.Stats", got.Data["orders"])
+		}
+
+		if stats.Sent != 1 {
+			t.Fatalf("orders Sent = %d, want 1", stats.Sent)
+		}
+
+		bus, ok := got.Data["bus"].(messaging.Stats)
+		if !ok || bus.Subscribers != 1 {
+			t.Fatalf("Data[bus] = %+v, want Subscribers=1", got.Data["bus"])
+		}
+	})
+
+	t.Run("target narrows the answer to one component", func(t *testing.T) {
+		t.Parallel()
+
+		got := dispatch(t, map[string]Handler{}, Command{Verb: StatsVerb, Target: "audit"},
+			WithInspectable("audit", fixedStats{BufferCapacity: 4}),
+			WithInspectable("billing", fixedStats{}),
+		)
+		if !got.Success {
+			t.Fatalf("expected Success=true, got Message %q", got.Message)
+		}
+
+		stats, ok := got.Data["audit"].(messaging.Stats)
+		if len(got.Data) != 1 || !ok {
+			t.Fatalf("expected only the audit entry, got %v", got.Data)
+		}
+
+		if stats.BufferCapacity != 4 {
+			t.Fatalf("audit BufferCapacity = %d, want 4", stats.BufferCapacity)
+		}
+	})
+
+	t.Run("unknown target yields Success=false", func(t *testing.T) {
+		t.Parallel()
+
+		got := dispatch(t, map[string]Handler{}, Command{Verb: StatsVerb, Target: "missing"})
+		if got.Success {
+			t.Fatal("expected Success=false")
+		}
+
+		if got.Message != `unknown target "missing"` {
+			t.Fatalf("unexpected Message %q", got.Message)
+		}
+	})
+
+	t.Run("nil handlers map still answers the built-in verb", func(t *testing.T) {
+		t.Parallel()
+
+		got := dispatch(t, nil, Command{Verb: StatsVerb})
+		if !got.Success {
+			t.Fatalf("expected Success=true, got Message %q", got.Message)
+		}
+
+		_, ok := got.Data["bus"]
+		if !ok {
+			t.Fatalf("expected the bus entry, got %v", got.Data)
+		}
+	})
+
+	t.Run("user handler overrides the built-in verb", func(t *testing.T) {
+		t.Parallel()
+
+		handlers := map[string]Handler{
+			StatsVerb: func(_ context.Context, cmd Command) Result {
+				return Result{Command: cmd, Success: true, Message: "custom"}
+			},
+		}
+
+		got := dispatch(t, handlers, Command{Verb: StatsVerb})
+		if got.Message != "custom" {
+			t.Fatalf("expected the user handler, got Message %q", got.Message)
+		}
+	})
+}
+
 func TestControlBus_Options(t *testing.T) {
 	t.Parallel()
 
@@ -676,6 +824,27 @@ func TestControlBus_Options(t *testing.T) {
 		}
 	})
 
+	t.Run("WithInspectable ignores empty name and nil target", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(
+			WithInspectable("", fixedStats{}),
+			WithInspectable("null", nil),
+		)
+		if len(opts.inspectables) != 0 {
+			t.Fatalf("expected no inspectables, got %d", len(opts.inspectables))
+		}
+	})
+
+	t.Run("WithChannelRegistry(nil) is a no-op", func(t *testing.T) {
+		t.Parallel()
+
+		opts := NewOptions(WithChannelRegistry(nil))
+		if opts.registry != nil {
+			t.Fatal("expected no registry on nil arg")
+		}
+	})
+
 	t.Run("defaults install messaging.DefaultErrorHandler", func(t *testing.T) {
 		t.Parallel()
 
@@ -703,3 +872,11 @@ func (c *failingChannel[T]) Send(_ context.Context, _ messaging.Message[T]) erro
 func (c *failingChannel[T]) Subscribe(_ messaging.Handler[T]) (messaging.Cancel, error) {
 	return func() {}, nil
 }
+
+// fixedStats is a messaging.Inspectable test double that always
+// reports itself.
+type fixedStats messaging.Stats
+
+func (s fixedStats) Stats() messaging.Stats {
+	return messaging.Stats(s)
+}
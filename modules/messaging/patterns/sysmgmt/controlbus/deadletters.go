@@ -0,0 +1,215 @@
+package controlbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+	cuids "github.com/guidomantilla/yarumo/core/common/uids"
+	"github.com/guidomantilla/yarumo/messaging"
+	"github.com/guidomantilla/yarumo/messaging/stores"
+)
+
+// Verbs of the dead-letter handlers returned by DeadLetterHandlers.
+const (
+	// DeadLetterListVerb lists the entries matching the command's
+	// filter.
+	DeadLetterListVerb = "dlq-list"
+	// DeadLetterGetVerb returns the entries named in ArgIDs.
+	DeadLetterGetVerb = "dlq-get"
+	// DeadLetterDeleteVerb deletes the entries named in ArgIDs.
+	DeadLetterDeleteVerb = "dlq-delete"
+	// DeadLetterReplayVerb replays the entries named in ArgIDs or, when
+	// ArgIDs is absent, every entry matching the command's filter.
+	DeadLetterReplayVerb = "dlq-replay"
+)
+
+// Command.Args keys read by the dead-letter verbs. Command.Target
+// selects the origin channel (stores.DeadLetterFilter.Channel).
+const (
+	// ArgIDs is a comma-separated list of dead-letter entry IDs.
+	ArgIDs = "ids"
+	// ArgError filters on text contained in the letter's LastError.
+	ArgError = "error"
+	// ArgMinAge filters on a minimum age, as a time.ParseDuration
+	// string.
+	ArgMinAge = "minAge"
+	// ArgMaxAge filters on a maximum age, as a time.ParseDuration
+	// string.
+	ArgMaxAge = "maxAge"
+	// ArgLimit caps the number of entries listed or replayed.
+	ArgLimit = "limit"
+)
+
+// DeadLetterHandlers returns the dead-letter verbs over store, to be
+// merged into the handlers map passed to NewControlBus. Replays resolve
+// each entry's origin channel in registry and draw the new MessageID
+// from uid (see stores.ReplayDeadLetter). Result.Data is keyed by entry
+// ID: the stores.DeadLetterEntry for list and get, true for delete and
+// the new MessageID for replay. A command that fails on any entry
+// answers Success false with every failure in Message and the entries
+// that did succeed in Data.
+//
+// The verb names are fixed, so a bus serving stores of several payload
+// types has to rename the keys of all but one of the maps.
+func DeadLetterHandlers[T any](store stores.DeadLetterStore[T], registry messaging.ChannelRegistry, uid cuids.UID) map[string]Handler {
+	cassert.NotNil(store, "dead letter store is nil")
+	cassert.NotNil(registry, "channel registry is nil")
+
+	list := func(ctx context.Context, cmd Command) Result {
+		entries, err := listDeadLetters(ctx, store, cmd)
+		if err != nil {
+			return Result{Command: cmd, Success: false, Message: err.Error()}
+		}
+
+		data := make(map[string]any, len(entries))
+		for _, entry := range entries {
+			data[entry.ID] = entry
+		}
+
+		return Result{Command: cmd, Success: true, Message: fmt.Sprintf("%d dead letters", len(data)), Data: data}
+	}
+
+	get := func(ctx context.Context, cmd Command) Result {
+		return eachDeadLetter(cmd, deadLetterIDs(cmd), func(id string) (any, error) {
+			return store.Get(ctx, id)
+		})
+	}
+
+	remove := func(ctx context.Context, cmd Command) Result {
+		return eachDeadLetter(cmd, deadLetterIDs(cmd), func(id string) (any, error) {
+			return true, store.Delete(ctx, id)
+		})
+	}
+
+	replay := func(ctx context.Context, cmd Command) Result {
+		ids := deadLetterIDs(cmd)
+		if len(ids) == 0 {
+			entries, err := listDeadLetters(ctx, store, cmd)
+			if err != nil {
+				return Result{Command: cmd, Success: false, Message: err.Error()}
+			}
+
+			for _, entry := range entries {
+				ids = append(ids, entry.ID)
+			}
+		}
+
+		return eachDeadLetter(cmd, ids, func(id string) (any, error) {
+			msg, err := stores.ReplayDeadLetter(ctx, store, registry, uid, id)
+
+			return msg.Headers.MessageID, err
+		})
+	}
+
+	return map[string]Handler{
+		DeadLetterListVerb:   list,
+		DeadLetterGetVerb:    get,
+		DeadLetterDeleteVerb: remove,
+		DeadLetterReplayVerb: replay,
+	}
+}
+
+// listDeadLetters lists the entries of store matching the filter
+// carried by cmd.
+func listDeadLetters[T any](ctx context.Context, store stores.DeadLetterStore[T], cmd Command) ([]stores.DeadLetterEntry[T], error) {
+	filter, err := deadLetterFilter(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	return store.List(ctx, filter)
+}
+
+// deadLetterFilter builds a stores.DeadLetterFilter from cmd.Target and
+// the filter arguments. Malformed durations or limits are rejected.
+func deadLetterFilter(cmd Command) (stores.DeadLetterFilter, error) {
+	filter := stores.DeadLetterFilter{Channel: cmd.Target, ErrorContains: cmd.Args[ArgError]}
+
+	var err error
+
+	filter.MinAge, err = durationArg(cmd, ArgMinAge)
+	if err != nil {
+		return filter, err
+	}
+
+	filter.MaxAge, err = durationArg(cmd, ArgMaxAge)
+	if err != nil {
+		return filter, err
+	}
+
+	raw := cmd.Args[ArgLimit]
+	if raw == "" {
+		return filter, nil
+	}
+
+	filter.Limit, err = strconv.Atoi(raw)
+	if err != nil {
+		return filter, fmt.Errorf("invalid %s: %w", ArgLimit, err)
+	}
+
+	return filter, nil
+}
+
+// durationArg parses the optional duration argument key of cmd.
+func durationArg(cmd Command, key string) (time.Duration, error) {
+	raw := cmd.Args[key]
+	if raw == "" {
+		return 0, nil
+	}
+
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", key, err)
+	}
+
+	return d, nil
+}
+
+// deadLetterIDs splits ArgIDs into trimmed, non-empty IDs.
+func deadLetterIDs(cmd Command) []string {
+	var ids []string
+
+	for id := range strings.SplitSeq(cmd.Args[ArgIDs], ",") {
+		id = strings.TrimSpace(id)
+		if id != "" {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
+
+// eachDeadLetter runs op for every id and folds the outcomes into one
+// Result: successes in Data under their id, failures joined into
+// Message. An empty id list is a failure.
+func eachDeadLetter(cmd Command, ids []string, op func(id string) (any, error)) Result {
+	if len(ids) == 0 {
+		return Result{Command: cmd, Success: false, Message: "no dead letters selected"}
+	}
+
+	data := make(map[string]any, len(ids))
+
+	var errs []error
+
+	for _, id := range ids {
+		value, err := op(id)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", id, err))
+
+			continue
+		}
+
+		data[id] = value
+	}
+
+	if len(errs) > 0 {
+		return Result{Command: cmd, Success: false, Message: errors.Join(errs...).Error(), Data: data}
+	}
+
+	return Result{Command: cmd, Success: true, Message: fmt.Sprintf("%d of %d dead letters", len(data), len(ids)), Data: data}
+}
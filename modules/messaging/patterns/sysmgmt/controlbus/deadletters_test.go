@@ -0,0 +1,249 @@
+package controlbus
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	cuids "github.com/guidomantilla/yarumo/core/common/uids"
+	"github.com/guidomantilla/yarumo/messaging"
+	"github.com/guidomantilla/yarumo/messaging/messagingtest"
+	"github.com/guidomantilla/yarumo/messaging/stores"
+)
+
+// deadLetterFixture holds a store with three letters, a registry
+// binding the "orders" channel to a recording channel, and the
+// dead-letter handlers over them.
+type deadLetterFixture struct {
+	store    stores.DeadLetterStore[int]
+	orders   *messagingtest.RecordingChannel[int]
+	handlers map[string]Handler
+	ids      []string
+}
+
+func newDeadLetterFixture(t *testing.T) deadLetterFixture {
+	t.Helper()
+
+	clock := messagingtest.NewFakeClock(time.Time{})
+	store := stores.NewInMemoryDeadLetterStore[int](stores.WithClock(clock))
+	registry := messaging.NewChannelRegistry()
+	orders := messagingtest.NewRecordingChannel[int]()
+
+	err := registry.Register("orders", orders)
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	letters := []struct {
+		channel string
+		payload int
+		err     error
+		age     time.Duration
+	}{
+		{channel: "orders", payload: 1, err: errors.New("db timeout"), age: 2 * time.Hour},
+		{channel: "orders", payload: 2, err: errors.New("db timeout"), age: time.Minute},
+		{channel: "payments", payload: 3, err: errors.New("card declined"), age: time.Minute},
+	}
+
+	ids := make([]string, 0, len(letters))
+
+	for _, l := range letters {
+		id, _ := store.Put(context.Background(), l.channel, messaging.DeadLetter[int]{
+			Original:  messaging.Message[int]{Payload: l.payload},
+			LastError: l.err,
+			FailedAt:  clock.Now().Add(-l.age),
+		})
+		ids = append(ids, id)
+	}
+
+	var seq int
+
+	uid := cuids.NewUID("seq", func() (string, error) {
+		seq++
+
+		return "replay-" + strconv.Itoa(seq), nil
+	})
+
+	return deadLetterFixture{
+		store:    store,
+		orders:   orders,
+		handlers: DeadLetterHandlers(store, registry, uid),
+		ids:      ids,
+	}
+}
+
+func TestDeadLetterHandlers(t *testing.T) {
+	t.Parallel()
+
+	t.Run("list filters by target and args", func(t *testing.T) {
+		t.Parallel()
+
+		f := newDeadLetterFixture(t)
+
+		res := f.handlers[DeadLetterListVerb](context.Background(), Command{
+			Verb:   DeadLetterListVerb,
+			Target: "orders",
+			Args:   map[string]string{ArgError: "timeout", ArgMaxAge: "1h"},
+		})
+		if !res.Success || len(res.Data) != 1 {
+			t.Fatalf("expected one entry, got %+v", res)
+		}
+
+		entry, ok := res.Data[f.ids[1]].(stores.DeadLetterEntry[int])
+		if !ok || entry.Letter.Original.Payload != 2 {
+			t.Fatalf("expected entry %s, got %+v", f.ids[1], res.Data)
+		}
+	})
+
+	t.Run("list rejects malformed arguments", func(t *testing.T) {
+		t.Parallel()
+
+		f := newDeadLetterFixture(t)
+
+		for _, args := range []map[string]string{{ArgMinAge: "soon"}, {ArgMaxAge: "later"}, {ArgLimit: "many"}} {
+			res := f.handlers[DeadLetterListVerb](context.Background(), Command{Verb: DeadLetterListVerb, Args: args})
+			if res.Success || !strings.Contains(res.Message, "invalid") {
+				t.Fatalf("expected invalid argument failure for %v, got %+v", args, res)
+			}
+		}
+	})
+
+	t.Run("get returns the selected entries", func(t *testing.T) {
+		t.Parallel()
+
+		f := newDeadLetterFixture(t)
+
+		res := f.handlers[DeadLetterGetVerb](context.Background(), Command{
+			Verb: DeadLetterGetVerb,
+			Args: map[string]string{ArgIDs: f.ids[0] + ", " + f.ids[2]},
+		})
+		if !res.Success || len(res.Data) != 2 {
+			t.Fatalf("expected two entries, got %+v", res)
+		}
+	})
+
+	t.Run("get without ids fails", func(t *testing.T) {
+		t.Parallel()
+
+		f := newDeadLetterFixture(t)
+
+		res := f.handlers[DeadLetterGetVerb](context.Background(), Command{Verb: DeadLetterGetVerb})
+		if res.Success {
+			t.Fatalf("expected failure, got %+v", res)
+		}
+	})
+
+	t.Run("get reports unknown ids and keeps the found ones", func(t *testing.T) {
+		t.Parallel()
+
+		f := newDeadLetterFixture(t)
+
+		res := f.handlers[DeadLetterGetVerb](context.Background(), Command{
+			Verb: DeadLetterGetVerb,
+			Args: map[string]string{ArgIDs: f.ids[0] + ",missing"},
+		})
+		if res.Success || len(res.Data) != 1 || !strings.Contains(res.Message, "missing") {
+			t.Fatalf("expected partial failure naming the missing id, got %+v", res)
+		}
+	})
+
+	t.Run("delete removes the selected entries", func(t *testing.T) {
+		t.Parallel()
+
+		f := newDeadLetterFixture(t)
+
+		res := f.handlers[DeadLetterDeleteVerb](context.Background(), Command{
+			Verb: DeadLetterDeleteVerb,
+			Args: map[string]string{ArgIDs: f.ids[0]},
+		})
+		if !res.Success {
+			t.Fatalf("expected success, got %+v", res)
+		}
+
+		entries, _ := f.store.List(context.Background(), stores.DeadLetterFilter{})
+		if len(entries) != 2 {
+			t.Fatalf("expected two entries left, got %d", len(entries))
+		}
+	})
+
+	t.Run("replay by ids sends to the origin channel", func(t *testing.T) {
+		t.Parallel()
+
+		f := newDeadLetterFixture(t)
+
+		res := f.handlers[DeadLetterReplayVerb](context.Background(), Command{
+			Verb: DeadLetterReplayVerb,
+			Args: map[string]string{ArgIDs: f.ids[1]},
+		})
+		if !res.Success || res.Data[f.ids[1]] != "replay-1" {
+			t.Fatalf("expected the new MessageID in Data, got %+v", res)
+		}
+
+		f.orders.AssertPayloads(t, 2)
+	})
+
+	t.Run("replay by filter replays every match", func(t *testing.T) {
+		t.Parallel()
+
+		f := newDeadLetterFixture(t)
+
+		res := f.handlers[DeadLetterReplayVerb](context.Background(), Command{Verb: DeadLetterReplayVerb, Target: "orders"})
+		if !res.Success || len(res.Data) != 2 {
+			t.Fatalf("expected two replays, got %+v", res)
+		}
+
+		f.orders.AssertPayloads(t, 1, 2)
+
+		entries, _ := f.store.List(context.Background(), stores.DeadLetterFilter{})
+		if len(entries) != 1 || entries[0].Channel != "payments" {
+			t.Fatalf("expected only the payments entry left, got %+v", entries)
+		}
+	})
+
+	t.Run("replay to an unregistered channel fails and keeps the entry", func(t *testing.T) {
+		t.Parallel()
+
+		f := newDeadLetterFixture(t)
+
+		res := f.handlers[DeadLetterReplayVerb](context.Background(), Command{Verb: DeadLetterReplayVerb, Target: "payments"})
+		if res.Success || len(res.Data) != 0 {
+			t.Fatalf("expected failure, got %+v", res)
+		}
+
+		_, err := f.store.Get(context.Background(), f.ids[2])
+		if err != nil {
+			t.Fatalf("expected the entry kept, got %v", err)
+		}
+	})
+
+	t.Run("verbs dispatch through a ControlBus", func(t *testing.T) {
+		t.Parallel()
+
+		f := newDeadLetterFixture(t)
+
+		cmdChan := messaging.NewPipelineChannel[Command]()
+		resChan := messagingtest.NewRecordingChannel[Result]()
+
+		bus := NewControlBus("bus", cmdChan, resChan, f.handlers)
+
+		err := bus.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		t.Cleanup(func() { _ = bus.Stop(context.Background()) })
+
+		err = cmdChan.Send(context.Background(), messaging.Message[Command]{Payload: Command{Verb: DeadLetterListVerb}})
+		if err != nil {
+			t.Fatalf("send: %v", err)
+		}
+
+		results := resChan.WaitFor(t, 1)
+		if !results[0].Payload.Success || len(results[0].Payload.Data) != 3 {
+			t.Fatalf("expected all three entries, got %+v", results[0].Payload)
+		}
+	})
+}
@@ -13,19 +13,24 @@ type Option func(opts *Options)
 
 // Options holds the configuration for a ControlBus.
 type Options struct {
-	errorHandler         messaging.ErrorHandler
-	unknownVerbHandler   Handler
+	errorHandler       messaging.ErrorHandler
+	unknownVerbHandler Handler
+	inspectables       map[string]messaging.Inspectable
+	registry           messaging.ChannelRegistry
 }
 
 // NewOptions creates a new Options with sensible defaults and applies
 // the given options. The default ErrorHandler is
 // messaging.DefaultErrorHandler (logs via common/log); the default
 // UnknownVerbHandler returns Result{Success: false, Message:
-// "unknown verb"}.
+// "unknown verb"}. No inspectable components and no ChannelRegistry
+// are wired by default, so the built-in stats verb reports only the
+// bus itself.
 func NewOptions(opts ...Option) *Options {
 	options := &Options{
 		errorHandler:       messaging.DefaultErrorHandler,
 		unknownVerbHandler: defaultUnknownVerbHandler,
+		inspectables:       map[string]messaging.Inspectable{},
 	}
 
 	for _, opt := range opts {
@@ -65,6 +70,32 @@ func WithUnknownVerbHandler(handler Handler) Option {
 	}
 }
 
+// WithInspectable exposes target under name to the built-in stats
+// verb. The option accumulates: pass it once per channel or pattern.
+// A later registration under the same name replaces the earlier one.
+// Empty names and nil targets are ignored.
+func WithInspectable(name string, target messaging.Inspectable) Option {
+	return func(opts *Options) {
+		if name != "" && target != nil {
+			opts.inspectables[name] = target
+		}
+	}
+}
+
+// WithChannelRegistry exposes every channel bound in registry to the
+// built-in stats verb, under its registered name. The registry is read
+// on each command, so channels registered after construction are
+// reported too; channels that do not implement messaging.Inspectable
+// are skipped. Entries added with WithInspectable take precedence on a
+// name clash. Nil values are ignored.
+func WithChannelRegistry(registry messaging.ChannelRegistry) Option {
+	return func(opts *Options) {
+		if registry != nil {
+			opts.registry = registry
+		}
+	}
+}
+
 // defaultUnknownVerbHandler is the package-default fallback for unknown
 // verbs. It returns Result{Success: false, Message: "unknown verb"}
 // with the originating Command echoed back.
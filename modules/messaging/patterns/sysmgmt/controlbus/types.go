@@ -18,6 +18,17 @@
 // Result{Success: false} with the panic value recorded in Message, and
 // fires the ErrorHandler with ErrHandlerPanic.
 //
+// # Dead letters
+//
+// DeadLetterHandlers returns verbs over a stores.DeadLetterStore:
+// DeadLetterListVerb, DeadLetterGetVerb, DeadLetterDeleteVerb and
+// DeadLetterReplayVerb. Command.Target selects the origin channel and
+// Command.Args carries the entry IDs (ArgIDs) or the list filter
+// (ArgError, ArgMinAge, ArgMaxAge, ArgLimit), so an operator recovers
+// from a bad deploy with one command:
+//
+//	Command{Verb: DeadLetterReplayVerb, Target: "orders", Args: map[string]string{ArgMaxAge: "1h"}}
+//
 // # Lifecycle
 //
 // ControlBus implements common/lifecycle.Component (worker-style):
@@ -41,8 +52,17 @@ import (
 	"context"
 
 	"github.com/guidomantilla/yarumo/core/common/lifecycle"
+	"github.com/guidomantilla/yarumo/messaging"
 )
 
+// StatsVerb is the verb of the built-in statistics command. A
+// ControlBus answers it with one messaging.Stats per inspectable
+// component in Result.Data, keyed by component name, unless the
+// handlers map passed to NewControlBus registers its own "stats" verb.
+// Command.Target narrows the answer to a single component; empty means
+// all of them.
+const StatsVerb = "stats"
+
 var (
 	_ ControlBus = (*controlBus)(nil)
 
@@ -50,13 +70,15 @@ var (
 )
 
 // ControlBus is the public interface for a Control Bus. It embeds
-// lifecycle.Component so callers wire it up with lifecycle.Build. The
-// interface exists (rather than returning lifecycle.Component directly)
-// so the consumer's API surface preserves "this is a ControlBus"
-// semantics and the type stays open to future control-bus-specific
-// methods without breaking callers.
+// lifecycle.Component so callers wire it up with lifecycle.Build, and
+// messaging.Inspectable to expose its runtime statistics. The
+// interface exists (rather than returning lifecycle.Component
+// directly) so the consumer's API surface preserves "this is a
+// ControlBus" semantics and the type stays open to future
+// control-bus-specific methods without breaking callers.
 type ControlBus interface {
 	lifecycle.Component
+	messaging.Inspectable
 }
 
 // Command is the administrative request envelope dispatched through a
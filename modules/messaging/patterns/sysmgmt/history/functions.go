@@ -0,0 +1,41 @@
+package history
+
+import (
+	"maps"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+// Append returns a copy of msg with name appended to the trail stored
+// under Headers.Custom[key], following the header semantics described
+// in the package doc: an existing []string trail is extended, anything
+// else is replaced by []string{name}. Headers.Custom and the trail are
+// copied so the caller's message is never mutated. The History
+// endpoint and the interceptors.History interceptor both stamp through
+// it.
+func Append[T any](msg messaging.Message[T], key string, name string) messaging.Message[T] {
+	cassert.NotEmpty(key, "history key is empty")
+	cassert.NotEmpty(name, "name is empty")
+
+	out := msg
+	out.Headers.Custom = maps.Clone(msg.Headers.Custom)
+
+	if out.Headers.Custom == nil {
+		out.Headers.Custom = map[string]any{}
+	}
+
+	existing, ok := out.Headers.Custom[key].([]string)
+	if !ok {
+		out.Headers.Custom[key] = []string{name}
+
+		return out
+	}
+
+	trail := make([]string, len(existing), len(existing)+1)
+	copy(trail, existing)
+	trail = append(trail, name)
+	out.Headers.Custom[key] = trail
+
+	return out
+}
@@ -0,0 +1,56 @@
+package history
+
+import (
+	"testing"
+
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+func TestAppend(t *testing.T) {
+	t.Parallel()
+
+	t.Run("seeds a trail when Custom is nil", func(t *testing.T) {
+		t.Parallel()
+
+		out := Append(messaging.Message[int]{Payload: 1}, DefaultHistoryKey, "a")
+
+		trail, ok := out.Headers.Custom[DefaultHistoryKey].([]string)
+		if !ok || len(trail) != 1 || trail[0] != "a" {
+			t.Fatalf("expected [a], got %v", out.Headers.Custom[DefaultHistoryKey])
+		}
+	})
+
+	t.Run("extends an existing trail without mutating the input", func(t *testing.T) {
+		t.Parallel()
+
+		in := messaging.Message[int]{Headers: messaging.Headers{Custom: map[string]any{"trail": []string{"a"}}}}
+
+		out := Append(in, "trail", "b")
+
+		trail := out.Headers.Custom["trail"].([]string)
+		if len(trail) != 2 || trail[0] != "a" || trail[1] != "b" {
+			t.Fatalf("expected [a b], got %v", trail)
+		}
+
+		if original := in.Headers.Custom["trail"].([]string); len(original) != 1 {
+			t.Fatalf("expected the input trail untouched, got %v", original)
+		}
+	})
+
+	t.Run("replaces a value of another shape", func(t *testing.T) {
+		t.Parallel()
+
+		in := messaging.Message[int]{Headers: messaging.Headers{Custom: map[string]any{DefaultHistoryKey: "oops"}}}
+
+		out := Append(in, DefaultHistoryKey, "a")
+
+		trail, ok := out.Headers.Custom[DefaultHistoryKey].([]string)
+		if !ok || len(trail) != 1 || trail[0] != "a" {
+			t.Fatalf("expected [a], got %v", out.Headers.Custom[DefaultHistoryKey])
+		}
+
+		if in.Headers.Custom[DefaultHistoryKey] != "oops" {
+			t.Fatal("expected the input Custom map untouched")
+		}
+	})
+}
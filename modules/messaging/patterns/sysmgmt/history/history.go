@@ -2,7 +2,6 @@ package history
 
 import (
 	"context"
-	"maps"
 	"sync"
 
 	cassert "github.com/guidomantilla/yarumo/core/common/assert"
@@ -21,6 +20,7 @@ type history[T any] struct {
 	dst          messaging.Channel[T]
 	historyKey   string
 	errorHandler messaging.ErrorHandler
+	stats        messaging.StatsRecorder
 
 	done      chan struct{}
 	startOnce sync.Once
@@ -135,6 +135,22 @@ func (h *history[T]) Done() <-chan struct{} {
 	return h.done
 }
 
+// Stats returns a snapshot of the history endpoint's runtime statistics.
+// Subscribers is 1 while the source subscription is active.
+func (h *history[T]) Stats() messaging.Stats {
+	cassert.NotNil(h, "history is nil")
+
+	stats := h.stats.Snapshot()
+
+	h.mu.Lock()
+	if h.cancel != nil {
+		stats.Subscribers = 1
+	}
+	h.mu.Unlock()
+
+	return stats
+}
+
 // handle is the Handler[T] subscribed on the source channel. It
 // appends the endpoint's name to the message's history trail (working
 // on a defensive copy so source-side state is not mutated) and
@@ -143,52 +159,29 @@ func (h *history[T]) Done() <-chan struct{} {
 // always returns nil so history concerns never propagate to the
 // source channel's Send caller.
 func (h *history[T]) handle(ctx context.Context, msg messaging.Message[T]) error {
+	end := h.stats.Begin()
+	defer end(nil)
+
 	stamped := h.stamp(msg)
 
 	err := h.dst.Send(ctx, stamped)
-	if err != nil && h.errorHandler != nil {
-		h.errorHandler(ctx, stamped, ErrHistory(ErrForwardFailed, err))
-	}
-
-	return nil
-}
+	if err != nil {
+		h.stats.RecordFailure()
 
-// stamp returns a copy of msg with the endpoint's name appended to
-// Headers.Custom[historyKey]. The function preserves an existing
-// []string trail (append + store back); otherwise it seeds a fresh
-// []string{name}. Headers.Custom is copied so downstream mutations do
-// not leak back into the source message.
-func (h *history[T]) stamp(msg messaging.Message[T]) messaging.Message[T] {
-	out := msg
-	out.Headers = msg.Headers
-	out.Headers.Custom = cloneCustom(msg.Headers.Custom)
-
-	if out.Headers.Custom == nil {
-		out.Headers.Custom = map[string]any{}
-	}
-
-	existing, ok := out.Headers.Custom[h.historyKey].([]string)
-	if !ok {
-		out.Headers.Custom[h.historyKey] = []string{h.name}
+		if h.errorHandler != nil {
+			h.errorHandler(ctx, stamped, ErrHistory(ErrForwardFailed, err))
+		}
 
-		return out
+		return nil
 	}
 
-	trail := make([]string, len(existing), len(existing)+1)
-	copy(trail, existing)
-	trail = append(trail, h.name)
-	out.Headers.Custom[h.historyKey] = trail
+	h.stats.RecordSent()
 
-	return out
+	return nil
 }
 
-// cloneCustom returns a shallow copy of in or nil when in is nil.
-// Cloning is required so appending to the trail does not mutate the
-// caller's Headers.Custom map.
-func cloneCustom(in map[string]any) map[string]any {
-	if in == nil {
-		return nil
-	}
-
-	return maps.Clone(in)
+// stamp returns a copy of msg with the endpoint's name appended to
+// the trail (see Append).
+func (h *history[T]) stamp(msg messaging.Message[T]) messaging.Message[T] {
+	return Append(msg, h.historyKey, h.name)
 }
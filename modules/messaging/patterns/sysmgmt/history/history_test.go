@@ -683,3 +683,61 @@ func (c *failingChannel[T]) Send(_ context.Context, _ messaging.Message[T]) erro
 func (c *failingChannel[T]) Subscribe(_ messaging.Handler[T]) (messaging.Cancel, error) {
 	return func() {}, nil
 }
+
+func TestHistory_Stats(t *testing.T) {
+	t.Parallel()
+
+	t.Run("counts forwarded messages", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		dst := messaging.NewPipelineChannel[int]()
+
+		h := NewHistory("stage-a", src, dst)
+
+		err := h.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		t.Cleanup(func() { _ = h.Stop(context.Background()) })
+
+		for i := range 2 {
+			err = src.Send(context.Background(), messaging.Message[int]{Payload: i})
+			if err != nil {
+				t.Fatalf("send %d: %v", i, err)
+			}
+		}
+
+		got := h.Stats()
+		if got.Subscribers != 1 || got.Delivered != 2 || got.Sent != 2 || got.Failed != 0 {
+			t.Fatalf("Stats = %+v, want Subscribers=1 Delivered=2 Sent=2 Failed=0", got)
+		}
+	})
+
+	t.Run("counts forward failures", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		dst := &failingChannel[int]{err: errors.New("dst down")}
+
+		h := NewHistory("stage-a", src, dst, WithErrorHandler(messaging.SilentErrorHandler))
+
+		err := h.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		t.Cleanup(func() { _ = h.Stop(context.Background()) })
+
+		err = src.Send(context.Background(), messaging.Message[int]{Payload: 1})
+		if err != nil {
+			t.Fatalf("send: %v", err)
+		}
+
+		got := h.Stats()
+		if got.Delivered != 1 || got.Sent != 0 || got.Failed != 1 || got.LastErrorTime.IsZero() {
+			t.Fatalf("Stats = %+v, want Delivered=1 Sent=0 Failed=1 and LastErrorTime set", got)
+		}
+	})
+}
@@ -51,6 +51,7 @@ package history
 
 import (
 	"github.com/guidomantilla/yarumo/core/common/lifecycle"
+	"github.com/guidomantilla/yarumo/messaging"
 )
 
 var (
@@ -66,12 +67,14 @@ const DefaultHistoryKey = "History"
 
 // History is the public interface for a Message History endpoint. It
 // embeds lifecycle.Component so callers wire it up with
-// lifecycle.Build. The interface exists (rather than returning
+// lifecycle.Build, and messaging.Inspectable to expose its runtime
+// statistics. The interface exists (rather than returning
 // lifecycle.Component directly) so the consumer's API surface
 // preserves "this is a History" semantics and the type stays open to
 // future history-specific methods without breaking callers.
 type History[T any] interface {
 	lifecycle.Component
+	messaging.Inspectable
 }
 
 // ErrHistoryFn is the function type for ErrHistory.
@@ -49,6 +49,7 @@ package wiretap
 
 import (
 	"github.com/guidomantilla/yarumo/core/common/lifecycle"
+	"github.com/guidomantilla/yarumo/messaging"
 )
 
 var (
@@ -58,13 +59,15 @@ var (
 )
 
 // Wiretap is the public interface for a Wire Tap. It embeds
-// lifecycle.Component so callers wire it up with lifecycle.Build. The
+// lifecycle.Component so callers wire it up with lifecycle.Build, and
+// messaging.Inspectable to expose its runtime statistics. The
 // interface exists (rather than returning lifecycle.Component
 // directly) so the consumer's API surface preserves "this is a
 // Wiretap" semantics and the type stays open to future
 // wiretap-specific methods without breaking callers.
 type Wiretap[T any] interface {
 	lifecycle.Component
+	messaging.Inspectable
 }
 
 // ErrWiretapFn is the function type for ErrWiretap.
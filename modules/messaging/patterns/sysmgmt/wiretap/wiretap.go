@@ -19,6 +19,7 @@ type wiretap[T any] struct {
 	dst          messaging.Channel[T]
 	tap          messaging.Channel[T]
 	errorHandler messaging.ErrorHandler
+	stats        messaging.StatsRecorder
 
 	done      chan struct{}
 	startOnce sync.Once
@@ -130,6 +131,22 @@ func (w *wiretap[T]) Done() <-chan struct{} {
 	return w.done
 }
 
+// Stats returns a snapshot of the wire tap's runtime statistics.
+// Subscribers is 1 while the source subscription is active.
+func (w *wiretap[T]) Stats() messaging.Stats {
+	cassert.NotNil(w, "wiretap is nil")
+
+	stats := w.stats.Snapshot()
+
+	w.mu.Lock()
+	if w.cancel != nil {
+		stats.Subscribers = 1
+	}
+	w.mu.Unlock()
+
+	return stats
+}
+
 // handle is the Handler[T] subscribed on the source channel. It sends
 // msg to the primary destination first, then to the tap regardless of
 // the primary outcome. Both failures are reported through the
@@ -137,7 +154,14 @@ func (w *wiretap[T]) Done() <-chan struct{} {
 // The function itself always returns nil so wiretap concerns never
 // propagate to the source channel's Send caller.
 func (w *wiretap[T]) handle(ctx context.Context, msg messaging.Message[T]) error {
+	end := w.stats.Begin()
+	defer end(nil)
+
 	dstErr := w.dst.Send(ctx, msg)
+	if dstErr == nil {
+		w.stats.RecordSent()
+	}
+
 	if dstErr != nil {
 		w.report(ctx, msg, ErrWiretap(ErrForwardFailed, dstErr))
 	}
@@ -154,6 +178,8 @@ func (w *wiretap[T]) handle(ctx context.Context, msg messaging.Message[T]) error
 // guaranteed non-nil by NewOptions (defaults to
 // messaging.DefaultErrorHandler), so the nil-guard is defensive only.
 func (w *wiretap[T]) report(ctx context.Context, msg messaging.Message[T], err error) {
+	w.stats.RecordFailure()
+
 	if w.errorHandler == nil {
 		return
 	}
@@ -564,3 +564,30 @@ func (c *failingChannel[T]) Send(_ context.Context, _ messaging.Message[T]) erro
 func (c *failingChannel[T]) Subscribe(_ messaging.Handler[T]) (messaging.Cancel, error) {
 	return func() {}, nil
 }
+
+func TestWiretap_Stats(t *testing.T) {
+	t.Parallel()
+
+	src := messaging.NewPipelineChannel[int]()
+	dst := messaging.NewPipelineChannel[int]()
+	tap := &failingChannel[int]{err: errors.New("tap down")}
+
+	w := NewWiretap("stats", src, dst, tap, WithErrorHandler(messaging.SilentErrorHandler))
+
+	err := w.Start(context.Background())
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+
+	t.Cleanup(func() { _ = w.Stop(context.Background()) })
+
+	err = src.Send(context.Background(), messaging.Message[int]{Payload: 1})
+	if err != nil {
+		t.Fatalf("send: %v", err)
+	}
+
+	got := w.Stats()
+	if got.Subscribers != 1 || got.Delivered != 1 || got.Sent != 1 || got.Failed != 1 {
+		t.Fatalf("Stats = %+v, want Subscribers=1 Delivered=1 Sent=1 Failed=1", got)
+	}
+}
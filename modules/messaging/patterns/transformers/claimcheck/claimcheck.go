@@ -23,6 +23,7 @@ type claimCheckIn[T any] struct {
 	msgStore     stores.MessageStore[T]
 	keyGen       KeyGenFn
 	errorHandler messaging.ErrorHandler
+	stats        messaging.StatsRecorder
 
 	done      chan struct{}
 	startOnce sync.Once
@@ -141,6 +142,22 @@ func (c *claimCheckIn[T]) Done() <-chan struct{} {
 	return c.done
 }
 
+// Stats returns a snapshot of the ClaimCheckIn runtime statistics.
+// Subscribers is 1 while the source subscription is active.
+func (c *claimCheckIn[T]) Stats() messaging.Stats {
+	cassert.NotNil(c, "claim check in is nil")
+
+	stats := c.stats.Snapshot()
+
+	c.mu.Lock()
+	if c.cancel != nil {
+		stats.Subscribers = 1
+	}
+	c.mu.Unlock()
+
+	return stats
+}
+
 // handle is the Handler[T] subscribed on the source channel. It
 // stores the original envelope in the message store and forwards a
 // reference envelope downstream. The function itself always returns
@@ -151,6 +168,9 @@ func (c *claimCheckIn[T]) Done() <-chan struct{} {
 // is NOT forwarded (the payload was never stored, downstream cannot
 // recover) and the error is routed through WithErrorHandler.
 func (c *claimCheckIn[T]) handle(ctx context.Context, msg messaging.Message[T]) error {
+	end := c.stats.Begin()
+	defer end(nil)
+
 	key := c.keyGen()
 
 	err := c.msgStore.Put(ctx, key, msg)
@@ -177,8 +197,12 @@ func (c *claimCheckIn[T]) handle(ctx context.Context, msg messaging.Message[T])
 	err = c.dst.Send(ctx, ref)
 	if err != nil {
 		c.reportError(ctx, msg, ErrClaimCheck(ErrForwardFailed, err))
+
+		return nil
 	}
 
+	c.stats.RecordSent()
+
 	return nil
 }
 
@@ -186,6 +210,8 @@ func (c *claimCheckIn[T]) handle(ctx context.Context, msg messaging.Message[T])
 // is guaranteed non-nil by NewOptions (defaults to
 // messaging.DefaultErrorHandler), so the nil-guard is defensive only.
 func (c *claimCheckIn[T]) reportError(ctx context.Context, msg messaging.Message[T], err error) {
+	c.stats.RecordFailure()
+
 	if c.errorHandler == nil {
 		return
 	}
@@ -205,6 +231,7 @@ type claimCheckOut[T any] struct {
 	msgStore            stores.MessageStore[T]
 	deleteAfterRetrieve bool
 	errorHandler        messaging.ErrorHandler
+	stats               messaging.StatsRecorder
 
 	done      chan struct{}
 	startOnce sync.Once
@@ -321,12 +348,38 @@ func (c *claimCheckOut[T]) Done() <-chan struct{} {
 	return c.done
 }
 
+// Stats returns a snapshot of the ClaimCheckOut runtime statistics.
+// Subscribers is 1 while the source subscription is active.
+func (c *claimCheckOut[T]) Stats() messaging.Stats {
+	cassert.NotNil(c, "claim check out is nil")
+
+	stats := c.stats.Snapshot()
+
+	c.mu.Lock()
+	if c.cancel != nil {
+		stats.Subscribers = 1
+	}
+	c.mu.Unlock()
+
+	return stats
+}
+
 // handle is the Handler[ClaimCheckReference] subscribed on the source
-// channel. It retrieves the original from the message store using the
+// channel. It resolves ref and counts the delivery as failed at most
+// once, even when both the delete and the forward failed. The function
+// itself always returns nil so claim check concerns never propagate to
+// the source channel's Send caller.
+func (c *claimCheckOut[T]) handle(ctx context.Context, ref messaging.Message[ClaimCheckReference]) error {
+	end := c.stats.Begin()
+	end(c.resolve(ctx, ref))
+
+	return nil
+}
+
+// resolve retrieves the original from the message store using the
 // reference's key, optionally deletes the entry, and forwards the
-// original to the destination. The function itself always returns nil
-// so claim check concerns never propagate to the source channel's
-// Send caller.
+// original to the destination. It returns the last error reported,
+// nil when the original was forwarded cleanly.
 //
 // Store-get failures (including ErrStoreNotFound surfaced by the
 // store as an error) are fail-CLOSED: when Get errors, the original
@@ -334,30 +387,39 @@ func (c *claimCheckOut[T]) Done() <-chan struct{} {
 // Delete failures are fail-OPEN: the original is still forwarded —
 // losing the chance to clean up the store is preferable to losing the
 // message.
-func (c *claimCheckOut[T]) handle(ctx context.Context, ref messaging.Message[ClaimCheckReference]) error {
+func (c *claimCheckOut[T]) resolve(ctx context.Context, ref messaging.Message[ClaimCheckReference]) error {
 	original, err := c.msgStore.Get(ctx, ref.Payload.Key)
 	if err != nil {
-		c.reportRefError(ctx, ref, ErrClaimCheck(ErrStoreGet, err))
+		err = ErrClaimCheck(ErrStoreGet, err)
+		c.reportRefError(ctx, ref, err)
 
-		return nil
+		return err
 	}
 
+	var failed error
+
 	if c.deleteAfterRetrieve {
 		err = c.msgStore.Delete(ctx, ref.Payload.Key)
 		if err != nil {
 			// Fail-open: surface the delete failure but still
 			// forward. Losing the chance to clean up the store is
 			// preferable to losing the message.
-			c.reportRefError(ctx, ref, ErrClaimCheck(ErrStoreDelete, err))
+			failed = ErrClaimCheck(ErrStoreDelete, err)
+			c.reportRefError(ctx, ref, failed)
 		}
 	}
 
 	err = c.dst.Send(ctx, original)
 	if err != nil {
-		c.reportError(ctx, original, ErrClaimCheck(ErrForwardFailed, err))
+		failed = ErrClaimCheck(ErrForwardFailed, err)
+		c.reportError(ctx, original, failed)
+
+		return failed
 	}
 
-	return nil
+	c.stats.RecordSent()
+
+	return failed
 }
 
 // reportRefError forwards err to the configured ErrorHandler, passing
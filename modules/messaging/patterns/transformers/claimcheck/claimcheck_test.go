@@ -994,3 +994,83 @@ func (c *failingChannel[T]) Send(_ context.Context, _ messaging.Message[T]) erro
 func (c *failingChannel[T]) Subscribe(_ messaging.Handler[T]) (messaging.Cancel, error) {
 	return func() {}, nil
 }
+
+func TestClaimCheck_Stats(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ClaimCheckIn counts stored references and store failures", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[int]()
+		dst := messaging.NewPipelineChannel[ClaimCheckReference]()
+
+		in := NewClaimCheckIn("in", src, dst, stores.NewInMemoryMessageStore[int]())
+
+		err := in.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		t.Cleanup(func() { _ = in.Stop(context.Background()) })
+
+		failing := NewClaimCheckIn("in", src, dst, &fakeMsgStore[int]{putErr: errors.New("store down")},
+			WithErrorHandler(messaging.SilentErrorHandler))
+
+		err = failing.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start failing: %v", err)
+		}
+
+		t.Cleanup(func() { _ = failing.Stop(context.Background()) })
+
+		for i := range 2 {
+			err = src.Send(context.Background(), messaging.Message[int]{Payload: i})
+			if err != nil {
+				t.Fatalf("send %d: %v", i, err)
+			}
+		}
+
+		got := in.Stats()
+		if got.Subscribers != 1 || got.Delivered != 2 || got.Sent != 2 || got.Failed != 0 {
+			t.Fatalf("Stats = %+v, want Subscribers=1 Delivered=2 Sent=2 Failed=0", got)
+		}
+
+		got = failing.Stats()
+		if got.Delivered != 2 || got.Sent != 0 || got.Failed != 2 {
+			t.Fatalf("failing Stats = %+v, want Delivered=2 Sent=0 Failed=2", got)
+		}
+	})
+
+	t.Run("ClaimCheckOut counts a delivery failed once", func(t *testing.T) {
+		t.Parallel()
+
+		src := messaging.NewPipelineChannel[ClaimCheckReference]()
+		dst := &failingChannel[int]{err: errors.New("dst down")}
+		ms := &fakeMsgStore[int]{delErr: errors.New("store down")}
+
+		errHandler, getErrs := captureErrors()
+
+		out := NewClaimCheckOut("out", src, dst, ms, WithErrorHandler(errHandler))
+
+		err := out.Start(context.Background())
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+
+		t.Cleanup(func() { _ = out.Stop(context.Background()) })
+
+		err = src.Send(context.Background(), messaging.Message[ClaimCheckReference]{Payload: ClaimCheckReference{Key: "k-1"}})
+		if err != nil {
+			t.Fatalf("send: %v", err)
+		}
+
+		if len(getErrs()) != 2 {
+			t.Fatalf("expected the delete and forward errors reported, got %v", getErrs())
+		}
+
+		got := out.Stats()
+		if got.Subscribers != 1 || got.Delivered != 1 || got.Sent != 0 || got.Failed != 1 {
+			t.Fatalf("Stats = %+v, want Subscribers=1 Delivered=1 Sent=0 Failed=1", got)
+		}
+	})
+}
@@ -78,6 +78,7 @@ package claimcheck
 
 import (
 	"github.com/guidomantilla/yarumo/core/common/lifecycle"
+	"github.com/guidomantilla/yarumo/messaging"
 )
 
 var (
@@ -107,24 +108,27 @@ type ClaimCheckReference struct {
 
 // ClaimCheckIn is the public interface for the heavy-payload producer
 // side of Claim Check. It embeds lifecycle.Component so callers wire
-// it up with lifecycle.Build. The interface exists (rather than
-// returning lifecycle.Component directly) so the consumer's API
-// surface preserves "this is a ClaimCheckIn" semantics and the type
-// stays open to future endpoint-specific methods without breaking
-// callers.
+// it up with lifecycle.Build, and messaging.Inspectable to expose its
+// runtime statistics. The interface exists (rather than returning
+// lifecycle.Component directly) so the consumer's API surface
+// preserves "this is a ClaimCheckIn" semantics and the type stays
+// open to future endpoint-specific methods without breaking callers.
 type ClaimCheckIn[T any] interface {
 	lifecycle.Component
+	messaging.Inspectable
 }
 
-// ClaimCheckOut is the public interface for the heavy-payload consumer
-// side of Claim Check. It embeds lifecycle.Component so callers wire
-// it up with lifecycle.Build. The interface exists (rather than
+// ClaimCheckOut is the public interface for the heavy-payload
+// consumer side of Claim Check. It embeds lifecycle.Component so
+// callers wire it up with lifecycle.Build, and messaging.Inspectable
+// to expose its runtime statistics. The interface exists (rather than
 // returning lifecycle.Component directly) so the consumer's API
 // surface preserves "this is a ClaimCheckOut" semantics and the type
 // stays open to future endpoint-specific methods without breaking
 // callers.
 type ClaimCheckOut[T any] interface {
 	lifecycle.Component
+	messaging.Inspectable
 }
 
 // KeyGenFn returns a fresh opaque key used by ClaimCheckIn to address
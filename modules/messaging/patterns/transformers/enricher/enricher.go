@@ -20,6 +20,7 @@ type enricher[T any] struct {
 	dst          messaging.Channel[T]
 	enrich       EnrichFn[T]
 	errorHandler messaging.ErrorHandler
+	stats        messaging.StatsRecorder
 
 	done      chan struct{}
 	startOnce sync.Once
@@ -125,6 +126,22 @@ func (e *enricher[T]) Done() <-chan struct{} {
 	return e.done
 }
 
+// Stats returns a snapshot of the enricher's runtime statistics.
+// Subscribers is 1 while the source subscription is active.
+func (e *enricher[T]) Stats() messaging.Stats {
+	cassert.NotNil(e, "enricher is nil")
+
+	stats := e.stats.Snapshot()
+
+	e.mu.Lock()
+	if e.cancel != nil {
+		stats.Subscribers = 1
+	}
+	e.mu.Unlock()
+
+	return stats
+}
+
 // handle is the Handler[T] subscribed on the source channel. It runs
 // the enrich callback under panic recovery, then forwards the enriched
 // message. EnrichFn failures and forward failures are reported through
@@ -132,6 +149,9 @@ func (e *enricher[T]) Done() <-chan struct{} {
 // so enricher concerns never propagate to the source channel's Send
 // caller.
 func (e *enricher[T]) handle(ctx context.Context, msg messaging.Message[T]) error {
+	end := e.stats.Begin()
+	defer end(nil)
+
 	enriched, err := e.enrichWithRecover(ctx, msg)
 	if err != nil {
 		e.reportError(ctx, msg, err)
@@ -142,8 +162,12 @@ func (e *enricher[T]) handle(ctx context.Context, msg messaging.Message[T]) erro
 	err = e.dst.Send(ctx, enriched)
 	if err != nil {
 		e.reportError(ctx, msg, ErrEnricher(ErrForwardFailed, err))
+
+		return nil
 	}
 
+	e.stats.RecordSent()
+
 	return nil
 }
 
@@ -173,6 +197,8 @@ func (e *enricher[T]) enrichWithRecover(ctx context.Context, msg messaging.Messa
 // is guaranteed non-nil by NewOptions (defaults to
 // messaging.DefaultErrorHandler), so the nil-guard is defensive only.
 func (e *enricher[T]) reportError(ctx context.Context, msg messaging.Message[T], err error) {
+	e.stats.RecordFailure()
+
 	if e.errorHandler == nil {
 		return
 	}
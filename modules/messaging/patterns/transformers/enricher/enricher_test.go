@@ -576,3 +576,40 @@ func (c *failingChannel[T]) Send(_ context.Context, _ messaging.Message[T]) erro
 func (c *failingChannel[T]) Subscribe(_ messaging.Handler[T]) (messaging.Cancel, error) {
 	return func() {}, nil
 }
+
+func TestEnricher_Stats(t *testing.T) {
+	t.Parallel()
+
+	src := messaging.NewPipelineChannel[int]()
+	dst := messaging.NewPipelineChannel[int]()
+
+	// Negative payloads cannot be enriched.
+	enrich := func(_ context.Context, msg messaging.Message[int]) (messaging.Message[int], error) {
+		if msg.Payload < 0 {
+			return msg, errors.New("no reference data")
+		}
+
+		return msg, nil
+	}
+
+	e := NewEnricher("test", src, dst, enrich, WithErrorHandler(messaging.SilentErrorHandler))
+
+	err := e.Start(context.Background())
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+
+	t.Cleanup(func() { _ = e.Stop(context.Background()) })
+
+	for _, p := range []int{1, -1, 2} {
+		err = src.Send(context.Background(), messaging.Message[int]{Payload: p})
+		if err != nil {
+			t.Fatalf("send %d: %v", p, err)
+		}
+	}
+
+	got := e.Stats()
+	if got.Subscribers != 1 || got.Delivered != 3 || got.Sent != 2 || got.Failed != 1 || got.LastErrorTime.IsZero() {
+		t.Fatalf("Stats = %+v, want Subscribers=1 Delivered=3 Sent=2 Failed=1 and LastErrorTime set", got)
+	}
+}
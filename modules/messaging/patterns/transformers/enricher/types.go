@@ -69,12 +69,14 @@ var (
 
 // Enricher is the public interface for a Content/Header Enricher. It
 // embeds lifecycle.Component so callers wire it up with
-// lifecycle.Build. The interface exists (rather than returning
+// lifecycle.Build, and messaging.Inspectable to expose its runtime
+// statistics. The interface exists (rather than returning
 // lifecycle.Component directly) so the consumer's API surface
-// preserves "this is an Enricher" semantics and the type stays open to
-// future enricher-specific methods without breaking callers.
+// preserves "this is an Enricher" semantics and the type stays open
+// to future enricher-specific methods without breaking callers.
 type Enricher[T any] interface {
 	lifecycle.Component
+	messaging.Inspectable
 }
 
 // EnrichFn maps an input Message[T] to an enriched Message[T]. The
@@ -21,6 +21,7 @@ type headerFilter[T any] struct {
 	dst            messaging.Channel[T]
 	headersToClear []string
 	errorHandler   messaging.ErrorHandler
+	stats          messaging.StatsRecorder
 
 	done      chan struct{}
 	startOnce sync.Once
@@ -132,6 +133,22 @@ func (f *headerFilter[T]) Done() <-chan struct{} {
 	return f.done
 }
 
+// Stats returns a snapshot of the header filter's runtime statistics.
+// Subscribers is 1 while the source subscription is active.
+func (f *headerFilter[T]) Stats() messaging.Stats {
+	cassert.NotNil(f, "header filter is nil")
+
+	stats := f.stats.Snapshot()
+
+	f.mu.Lock()
+	if f.cancel != nil {
+		stats.Subscribers = 1
+	}
+	f.mu.Unlock()
+
+	return stats
+}
+
 // handle is the Handler[T] subscribed on the source channel. It builds
 // a forwarded copy of msg with the configured headers cleared and
 // forwards it to dst. Forward failures are reported through the
@@ -139,6 +156,9 @@ func (f *headerFilter[T]) Done() <-chan struct{} {
 // header filter concerns never propagate to the source channel's Send
 // caller.
 func (f *headerFilter[T]) handle(ctx context.Context, msg messaging.Message[T]) error {
+	end := f.stats.Begin()
+	defer end(nil)
+
 	forwarded := messaging.Message[T]{
 		Payload: msg.Payload,
 		Headers: clearHeaders(msg.Headers, f.headersToClear),
@@ -147,8 +167,12 @@ func (f *headerFilter[T]) handle(ctx context.Context, msg messaging.Message[T])
 	err := f.dst.Send(ctx, forwarded)
 	if err != nil {
 		f.reportError(ctx, msg, ErrHeaderFilter(ErrForwardFailed, err))
+
+		return nil
 	}
 
+	f.stats.RecordSent()
+
 	return nil
 }
 
@@ -156,6 +180,8 @@ func (f *headerFilter[T]) handle(ctx context.Context, msg messaging.Message[T])
 // is guaranteed non-nil by NewOptions (defaults to
 // messaging.DefaultErrorHandler), so the nil-guard is defensive only.
 func (f *headerFilter[T]) reportError(ctx context.Context, msg messaging.Message[T], err error) {
+	f.stats.RecordFailure()
+
 	if f.errorHandler == nil {
 		return
 	}
@@ -211,6 +237,7 @@ var fieldClearers = map[string]func(*messaging.Headers){
 	"ContentType":    func(h *messaging.Headers) { h.ContentType = "" },
 	"Priority":       func(h *messaging.Headers) { h.Priority = 0 },
 	"ExpirationTime": func(h *messaging.Headers) { h.ExpirationTime = time.Time{} },
+	"DeliveryCount":  func(h *messaging.Headers) { h.DeliveryCount = 0 },
 	"SequenceNumber": func(h *messaging.Headers) { h.SequenceNumber = 0 },
 	"SequenceSize":   func(h *messaging.Headers) { h.SequenceSize = 0 },
 	"Timestamp":      func(h *messaging.Headers) { h.Timestamp = time.Time{} },
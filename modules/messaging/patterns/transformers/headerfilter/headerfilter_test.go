@@ -228,7 +228,7 @@ func TestHeaderFilter_ClearKnownFields(t *testing.T) {
 		}
 
 		f := NewHeaderFilter("test", src, dst,
-			WithHeadersToClear("Priority", "ExpirationTime", "SequenceNumber", "SequenceSize"),
+			WithHeadersToClear("Priority", "ExpirationTime", "DeliveryCount", "SequenceNumber", "SequenceSize"),
 		)
 
 		err = f.Start(context.Background())
@@ -242,6 +242,7 @@ func TestHeaderFilter_ClearKnownFields(t *testing.T) {
 				Priority:       9,
 				ExpirationTime: time.Now().Add(time.Hour),
 				SequenceNumber: 3,
+				DeliveryCount:  2,
 				SequenceSize:   10,
 				Type:           "keep",
 			},
@@ -264,6 +265,9 @@ func TestHeaderFilter_ClearKnownFields(t *testing.T) {
 			t.Fatalf("SequenceNumber not cleared, got %d", seen.Headers.SequenceNumber)
 		}
 
+		if seen.Headers.DeliveryCount != 0 {
+			t.Fatalf("DeliveryCount not cleared, got %d", seen.Headers.DeliveryCount)
+		}
 		if seen.Headers.SequenceSize != 0 {
 			t.Fatalf("SequenceSize not cleared, got %d", seen.Headers.SequenceSize)
 		}
@@ -711,3 +715,46 @@ func (c *failingChannel[T]) Send(_ context.Context, _ messaging.Message[T]) erro
 func (c *failingChannel[T]) Subscribe(_ messaging.Handler[T]) (messaging.Cancel, error) {
 	return func() {}, nil
 }
+
+func TestHeaderFilter_Stats(t *testing.T) {
+	t.Parallel()
+
+	src := messaging.NewPipelineChannel[int]()
+	dst := messaging.NewPipelineChannel[int]()
+
+	f := NewHeaderFilter("test", src, dst)
+
+	err := f.Start(context.Background())
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+
+	t.Cleanup(func() { _ = f.Stop(context.Background()) })
+
+	failing := NewHeaderFilter("failing", src, &failingChannel[int]{err: errors.New("dst down")},
+		WithErrorHandler(messaging.SilentErrorHandler))
+
+	err = failing.Start(context.Background())
+	if err != nil {
+		t.Fatalf("start failing: %v", err)
+	}
+
+	t.Cleanup(func() { _ = failing.Stop(context.Background()) })
+
+	for i := range 2 {
+		err = src.Send(context.Background(), messaging.Message[int]{Payload: i})
+		if err != nil {
+			t.Fatalf("send %d: %v", i, err)
+		}
+	}
+
+	got := f.Stats()
+	if got.Subscribers != 1 || got.Delivered != 2 || got.Sent != 2 || got.Failed != 0 {
+		t.Fatalf("Stats = %+v, want Subscribers=1 Delivered=2 Sent=2 Failed=0", got)
+	}
+
+	got = failing.Stats()
+	if got.Delivered != 2 || got.Sent != 0 || got.Failed != 2 || got.LastErrorTime.IsZero() {
+		t.Fatalf("failing Stats = %+v, want Delivered=2 Sent=0 Failed=2 and LastErrorTime set", got)
+	}
+}
@@ -23,8 +23,9 @@
 // arbitrary names (deleted from the Custom map). Recognised struct
 // field names: "MessageID", "CorrelationID", "CausationID", "ReplyTo",
 // "Type", "Source", "ContentType", "Priority", "ExpirationTime",
-// "SequenceNumber", "SequenceSize", "Timestamp". Any other name is
-// treated as a Custom key and removed via delete(Headers.Custom, name).
+// "DeliveryCount", "SequenceNumber", "SequenceSize", "Timestamp". Any
+// other name is treated as a Custom key and removed via
+// delete(Headers.Custom, name).
 //
 // # Immutability
 //
@@ -51,6 +52,7 @@ package headerfilter
 
 import (
 	"github.com/guidomantilla/yarumo/core/common/lifecycle"
+	"github.com/guidomantilla/yarumo/messaging"
 )
 
 var (
@@ -60,13 +62,15 @@ var (
 )
 
 // HeaderFilter is the public interface for a Header Filter. It embeds
-// lifecycle.Component so callers wire it up with lifecycle.Build. The
-// interface exists (rather than returning lifecycle.Component directly)
-// so the consumer's API surface preserves "this is a HeaderFilter"
-// semantics and the type stays open to future header-filter-specific
-// methods without breaking callers.
+// lifecycle.Component so callers wire it up with lifecycle.Build, and
+// messaging.Inspectable to expose its runtime statistics. The
+// interface exists (rather than returning lifecycle.Component
+// directly) so the consumer's API surface preserves "this is a
+// HeaderFilter" semantics and the type stays open to future
+// header-filter-specific methods without breaking callers.
 type HeaderFilter[T any] interface {
 	lifecycle.Component
+	messaging.Inspectable
 }
 
 // ErrHeaderFilterFn is the function type for ErrHeaderFilter.
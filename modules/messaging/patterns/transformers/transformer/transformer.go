@@ -20,6 +20,7 @@ type transformer[T, U any] struct {
 	dst          messaging.Channel[U]
 	transform    TransformFn[T, U]
 	errorHandler messaging.ErrorHandler
+	stats        messaging.StatsRecorder
 
 	done      chan struct{}
 	startOnce sync.Once
@@ -126,6 +127,22 @@ func (x *transformer[T, U]) Done() <-chan struct{} {
 	return x.done
 }
 
+// Stats returns a snapshot of the transformer's runtime statistics.
+// Subscribers is 1 while the source subscription is active.
+func (x *transformer[T, U]) Stats() messaging.Stats {
+	cassert.NotNil(x, "transformer is nil")
+
+	stats := x.stats.Snapshot()
+
+	x.mu.Lock()
+	if x.cancel != nil {
+		stats.Subscribers = 1
+	}
+	x.mu.Unlock()
+
+	return stats
+}
+
 // handle is the Handler[T] subscribed on the source channel. It runs
 // the transform under panic recovery, then forwards the resulting
 // Message[U] to the destination channel. Transformation and forward
@@ -133,6 +150,9 @@ func (x *transformer[T, U]) Done() <-chan struct{} {
 // function itself always returns nil so transformer concerns never
 // propagate to the source channel's Send caller.
 func (x *transformer[T, U]) handle(ctx context.Context, msg messaging.Message[T]) error {
+	end := x.stats.Begin()
+	defer end(nil)
+
 	out, err := x.transformWithRecover(ctx, msg)
 	if err != nil {
 		x.report(ctx, msg, err)
@@ -143,8 +163,12 @@ func (x *transformer[T, U]) handle(ctx context.Context, msg messaging.Message[T]
 	err = x.dst.Send(ctx, out)
 	if err != nil {
 		x.report(ctx, msg, ErrTransformer(ErrForwardFailed, err))
+
+		return nil
 	}
 
+	x.stats.RecordSent()
+
 	return nil
 }
 
@@ -175,6 +199,8 @@ func (x *transformer[T, U]) transformWithRecover(ctx context.Context, msg messag
 // guaranteed non-nil by NewOptions (defaults to
 // messaging.DefaultErrorHandler), so the nil-guard is defensive only.
 func (x *transformer[T, U]) report(ctx context.Context, msg messaging.Message[T], err error) {
+	x.stats.RecordFailure()
+
 	if x.errorHandler == nil {
 		return
 	}
@@ -547,3 +547,39 @@ func (c *failingChannel[T]) Send(_ context.Context, _ messaging.Message[T]) erro
 func (c *failingChannel[T]) Subscribe(_ messaging.Handler[T]) (messaging.Cancel, error) {
 	return func() {}, nil
 }
+
+func TestTransformer_Stats(t *testing.T) {
+	t.Parallel()
+
+	src := messaging.NewPipelineChannel[int]()
+	dst := messaging.NewPipelineChannel[string]()
+
+	transform := func(ctx context.Context, msg messaging.Message[int]) (messaging.Message[string], error) {
+		if msg.Payload < 0 {
+			return messaging.Message[string]{}, errors.New("negative")
+		}
+
+		return intToString(ctx, msg)
+	}
+
+	x := NewTransformer("stats", src, dst, transform, WithErrorHandler(messaging.SilentErrorHandler))
+
+	err := x.Start(context.Background())
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+
+	t.Cleanup(func() { _ = x.Stop(context.Background()) })
+
+	for _, p := range []int{1, -1, 2} {
+		err = src.Send(context.Background(), messaging.Message[int]{Payload: p})
+		if err != nil {
+			t.Fatalf("send %d: %v", p, err)
+		}
+	}
+
+	got := x.Stats()
+	if got.Subscribers != 1 || got.Delivered != 3 || got.Sent != 2 || got.Failed != 1 {
+		t.Fatalf("Stats = %+v, want Subscribers=1 Delivered=3 Sent=2 Failed=1", got)
+	}
+}
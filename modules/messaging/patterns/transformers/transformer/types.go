@@ -59,12 +59,14 @@ var (
 
 // Transformer is the public interface for a Message Translator. It
 // embeds lifecycle.Component so callers wire it up with
-// lifecycle.Build. The interface exists (rather than returning
+// lifecycle.Build, and messaging.Inspectable to expose its runtime
+// statistics. The interface exists (rather than returning
 // lifecycle.Component directly) so the consumer's API surface
 // preserves "this is a Transformer" semantics and the type stays open
 // to future transformer-specific methods without breaking callers.
 type Transformer[T, U any] interface {
 	lifecycle.Component
+	messaging.Inspectable
 }
 
 // TransformFn maps an incoming Message[T] to an outgoing Message[U].
@@ -0,0 +1,219 @@
+package messaging
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	cretry "github.com/guidomantilla/yarumo/core/common/resilience/retry"
+)
+
+// redeliverFn hands a message scheduled for redelivery back to its
+// consumer: the QueueChannel inbound buffer or a TopicChannel
+// subscriber's inbox.
+type redeliverFn[T any] func(ctx context.Context, msg Message[T]) error
+
+// rejectedItem pairs a due item with the error its target returned.
+type rejectedItem[T any] struct {
+	item scheduledItem[T]
+	err  error
+}
+
+// redeliverer is the delay scheduler behind WithRedeliveryPolicy. It
+// reuses the ScheduledChannel min-heap: a failed delivery is pushed
+// with the backoff delay and a single goroutine hands it back to its
+// target when due, so the dispatch workers never sleep on a retry.
+//
+// Hand-back and close are serialized under mu, which lets the owning
+// channel close its mailboxes right after close returns without a
+// due item racing into a closed buffer.
+type redeliverer[T any] struct {
+	policy RedeliveryPolicy
+	hook   ErrorHandler
+	dlq    Channel[DeadLetter[T]]
+	clock  Clock
+
+	mu     sync.Mutex
+	queue  scheduledHeap[T]
+	closed bool
+	wake   chan struct{}
+	stop   chan struct{}
+}
+
+// newRedeliverer returns the redeliverer for policy, or nil when no
+// policy was configured. hook and dlq receive the messages the
+// redeliverer gives up on; clock times the backoff delays.
+func newRedeliverer[T any](policy *RedeliveryPolicy, hook ErrorHandler, dlq Channel[DeadLetter[T]], clock Clock) *redeliverer[T] {
+	if policy == nil {
+		return nil
+	}
+
+	return &redeliverer[T]{
+		policy: *policy,
+		hook:   hook,
+		dlq:    dlq,
+		clock:  clock,
+		wake:   make(chan struct{}, 1),
+		stop:   make(chan struct{}),
+	}
+}
+
+// schedule queues msg for redelivery through target after the
+// backoff delay for its Headers.DeliveryCount. It returns false when
+// the policy gives up — attempts exhausted, RetryIf rejecting cause,
+// or the redeliverer closed — in which case the caller dead-letters
+// msg itself.
+func (r *redeliverer[T]) schedule(sendCtx context.Context, msg Message[T], cause error, target redeliverFn[T]) bool {
+	if msg.Headers.DeliveryCount >= r.policy.MaxAttempts || !r.policy.RetryIf(cause) {
+		return false
+	}
+
+	item := scheduledItem[T]{
+		deliverAt: r.clock.Now().Add(backoffDelay(r.policy, msg.Headers.DeliveryCount)),
+		sendCtx:   sendCtx,
+		msg:       msg,
+		cause:     cause,
+		target:    target,
+	}
+
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+
+		return false
+	}
+
+	heap.Push(&r.queue, item)
+	r.mu.Unlock()
+
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+
+	return true
+}
+
+// run is the scheduler goroutine. It hands every item back to its
+// target when due and exits once close is called.
+func (r *redeliverer[T]) run() {
+	for {
+		r.mu.Lock()
+
+		if r.closed {
+			r.mu.Unlock()
+
+			return
+		}
+
+		wait, hasItem, rejected := r.redeliverDue(r.clock.Now())
+		r.mu.Unlock()
+
+		for _, item := range rejected {
+			r.giveUp(item.item, item.err)
+		}
+
+		if !hasItem {
+			select {
+			case <-r.wake:
+			case <-r.stop:
+				return
+			}
+
+			continue
+		}
+
+		timer := r.clock.NewTimer(wait)
+		select {
+		case <-timer.C():
+		case <-r.wake:
+			timer.Stop()
+		case <-r.stop:
+			timer.Stop()
+
+			return
+		}
+	}
+}
+
+// redeliverDue hands every item due at now back to its target and
+// returns the wait until the next one, with hasItem false when the
+// heap is empty. Items whose target rejected them are returned so the
+// caller can give up on them after releasing mu. Must be called with
+// mu held.
+func (r *redeliverer[T]) redeliverDue(now time.Time) (time.Duration, bool, []rejectedItem[T]) {
+	var rejected []rejectedItem[T]
+
+	for r.queue.Len() > 0 {
+		head := r.queue[0]
+		if head.deliverAt.After(now) {
+			return head.deliverAt.Sub(now), true, rejected
+		}
+
+		item, _ := heap.Pop(&r.queue).(scheduledItem[T])
+
+		err := item.target(item.sendCtx, item.msg)
+		if err != nil {
+			rejected = append(rejected, rejectedItem[T]{item: item, err: err})
+		}
+	}
+
+	return 0, false, rejected
+}
+
+// close stops the scheduler and dead-letters every pending item with
+// ErrClosed. Later schedule calls return false. close is idempotent.
+func (r *redeliverer[T]) close() {
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+
+		return
+	}
+
+	r.closed = true
+	pending := r.queue
+	r.queue = nil
+	r.mu.Unlock()
+
+	close(r.stop)
+
+	for _, item := range pending {
+		r.giveUp(item, ErrClosed)
+	}
+}
+
+// giveUp reports an item whose redelivery could not happen to the
+// hook (ErrRedeliveryFailed joined with reason) and publishes it to
+// the DLQ with the last handler error as LastError.
+func (r *redeliverer[T]) giveUp(item scheduledItem[T], reason error) {
+	ctx := context.WithoutCancel(item.sendCtx)
+	err := errors.Join(ErrRedeliveryFailed, reason)
+
+	if r.hook != nil {
+		r.hook(ctx, item.msg, err)
+	}
+
+	PublishDeadLetter(ctx, r.dlq, item.msg, errors.Join(item.cause, err), r.clock.Now())
+}
+
+// backoffDelay returns the wait before redelivering a message whose
+// delivery number attempt failed, following policy.Backoff and capped
+// at policy.MaxDelay.
+func backoffDelay(policy RedeliveryPolicy, attempt int) time.Duration {
+	delay := policy.Delay
+
+	switch policy.Backoff {
+	case cretry.BackoffExponential:
+		for i := 1; i < attempt && delay < policy.MaxDelay; i++ {
+			delay *= 2
+		}
+	case cretry.BackoffRandom:
+		delay = rand.N(policy.Delay)
+	}
+
+	return min(delay, policy.MaxDelay)
+}
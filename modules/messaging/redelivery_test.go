@@ -0,0 +1,156 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	cretry "github.com/guidomantilla/yarumo/core/common/resilience/retry"
+)
+
+func TestBackoffDelay(t *testing.T) {
+	t.Parallel()
+
+	policy := RedeliveryPolicy{Delay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	t.Run("fixed", func(t *testing.T) {
+		t.Parallel()
+
+		p := policy
+		p.Backoff = cretry.BackoffFixed
+
+		for attempt := 1; attempt <= 5; attempt++ {
+			got := backoffDelay(p, attempt)
+			if got != 100*time.Millisecond {
+				t.Fatalf("attempt %d: expected 100ms, got %v", attempt, got)
+			}
+		}
+	})
+
+	t.Run("exponential capped at MaxDelay", func(t *testing.T) {
+		t.Parallel()
+
+		p := policy
+		p.Backoff = cretry.BackoffExponential
+
+		want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second, time.Second}
+		for i, w := range want {
+			got := backoffDelay(p, i+1)
+			if got != w {
+				t.Fatalf("attempt %d: expected %v, got %v", i+1, w, got)
+			}
+		}
+	})
+
+	t.Run("random below Delay", func(t *testing.T) {
+		t.Parallel()
+
+		p := policy
+		p.Backoff = cretry.BackoffRandom
+
+		for range 50 {
+			got := backoffDelay(p, 1)
+			if got < 0 || got >= p.Delay {
+				t.Fatalf("expected [0, %v), got %v", p.Delay, got)
+			}
+		}
+	})
+}
+
+func TestRedeliverer_Schedule(t *testing.T) {
+	t.Parallel()
+
+	policy := RedeliveryPolicy{MaxAttempts: 3, Delay: time.Hour, MaxDelay: time.Hour, RetryIf: cretry.AlwaysRetry}
+	target := func(_ context.Context, _ Message[int]) error { return nil }
+
+	t.Run("nil policy yields no redeliverer", func(t *testing.T) {
+		t.Parallel()
+
+		if newRedeliverer[int](nil, nil, nil, SystemClock()) != nil {
+			t.Fatal("expected nil redeliverer")
+		}
+	})
+
+	t.Run("gives up when attempts are exhausted", func(t *testing.T) {
+		t.Parallel()
+
+		r := newRedeliverer[int](&policy, nil, nil, SystemClock())
+		msg := NewMessage(1, nil)
+		msg.Headers.DeliveryCount = 3
+
+		if r.schedule(context.Background(), msg, errors.New("boom"), target) {
+			t.Fatal("expected schedule to give up")
+		}
+	})
+
+	t.Run("gives up when RetryIf rejects the error", func(t *testing.T) {
+		t.Parallel()
+
+		p := policy
+		p.RetryIf = func(error) bool { return false }
+		r := newRedeliverer[int](&p, nil, nil, SystemClock())
+		msg := NewMessage(1, nil)
+		msg.Headers.DeliveryCount = 1
+
+		if r.schedule(context.Background(), msg, errors.New("boom"), target) {
+			t.Fatal("expected schedule to give up")
+		}
+	})
+
+	t.Run("close dead-letters pending items and rejects new ones", func(t *testing.T) {
+		t.Parallel()
+
+		dlq := NewPipelineChannel[DeadLetter[int]]()
+
+		var (
+			mu      sync.Mutex
+			letters []DeadLetter[int]
+			hooked  []error
+		)
+
+		_, err := dlq.Subscribe(func(_ context.Context, m Message[DeadLetter[int]]) error {
+			mu.Lock()
+			letters = append(letters, m.Payload)
+			mu.Unlock()
+
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("dlq Subscribe: %v", err)
+		}
+
+		hook := func(_ context.Context, _ any, err error) {
+			mu.Lock()
+			hooked = append(hooked, err)
+			mu.Unlock()
+		}
+
+		cause := errors.New("boom")
+		r := newRedeliverer(&policy, hook, dlq, SystemClock())
+		msg := NewMessage(1, nil)
+		msg.Headers.DeliveryCount = 1
+
+		if !r.schedule(context.Background(), msg, cause, target) {
+			t.Fatal("expected schedule to accept the message")
+		}
+
+		r.close()
+		r.close()
+
+		if r.schedule(context.Background(), msg, cause, target) {
+			t.Fatal("expected schedule to reject after close")
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		if len(hooked) != 1 || !errors.Is(hooked[0], ErrRedeliveryFailed) || !errors.Is(hooked[0], ErrClosed) {
+			t.Fatalf("expected one ErrRedeliveryFailed hook call, got %v", hooked)
+		}
+		if len(letters) != 1 || !errors.Is(letters[0].LastError, cause) {
+			t.Fatalf("expected one dead letter carrying the cause, got %v", letters)
+		}
+	})
+}
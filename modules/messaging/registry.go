@@ -0,0 +1,181 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+)
+
+// registry is the ChannelRegistry implementation: a mutex-guarded map
+// from name to a type-erased Channel[T].
+type registry struct {
+	mu       sync.RWMutex
+	channels map[string]any
+}
+
+// NewChannelRegistry returns an empty ChannelRegistry.
+func NewChannelRegistry() ChannelRegistry {
+	return &registry{channels: map[string]any{}}
+}
+
+// Register binds name to channel. See ChannelRegistry.Register.
+func (r *registry) Register(name string, channel any) error {
+	cassert.NotNil(r, "ChannelRegistry is nil")
+
+	if name == "" {
+		return ErrRegistry(ErrChannelNameEmpty)
+	}
+
+	if isNil(channel) {
+		return ErrRegistry(ErrChannelNil, fmt.Errorf("channel %q", name))
+	}
+
+	if !isChannel(channel) {
+		return ErrRegistry(ErrChannelType, fmt.Errorf("channel %q is %T", name, channel))
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, exists := r.channels[name]
+	if exists {
+		return ErrRegistry(ErrChannelRegistered, fmt.Errorf("channel %q", name))
+	}
+
+	r.channels[name] = channel
+
+	return nil
+}
+
+// Unregister removes name. See ChannelRegistry.Unregister.
+func (r *registry) Unregister(name string) {
+	cassert.NotNil(r, "ChannelRegistry is nil")
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.channels, name)
+}
+
+// Resolve returns the channel bound to name. See
+// ChannelRegistry.Resolve.
+func (r *registry) Resolve(name string) (any, error) {
+	cassert.NotNil(r, "ChannelRegistry is nil")
+
+	if name == "" {
+		return nil, ErrRegistry(ErrChannelNameEmpty)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	channel, ok := r.channels[name]
+	if !ok {
+		return nil, ErrRegistry(ErrChannelNotFound, fmt.Errorf("channel %q", name))
+	}
+
+	return channel, nil
+}
+
+// Names returns the registered names in ascending order.
+func (r *registry) Names() []string {
+	cassert.NotNil(r, "ChannelRegistry is nil")
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.channels))
+	for name := range r.channels {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+// ResolveChannel returns the channel registered under name as a
+// Channel[T]. Returns the Resolve error for unknown names and
+// ErrRegistry(ErrChannelTypeMismatch) when the registered channel
+// carries a different payload type.
+func ResolveChannel[T any](registry ChannelRegistry, name string) (Channel[T], error) {
+	cassert.NotNil(registry, "ChannelRegistry is nil")
+
+	raw, err := registry.Resolve(name)
+	if err != nil {
+		return nil, err
+	}
+
+	channel, ok := raw.(Channel[T])
+	if !ok {
+		var zero T
+
+		return nil, ErrRegistry(ErrChannelTypeMismatch, fmt.Errorf("channel %q is %T, want Channel[%T]", name, raw, zero))
+	}
+
+	return channel, nil
+}
+
+// Reply publishes reply as the response to request on the channel
+// named by request.Headers.ReplyTo, resolved through registry. The
+// reply is stamped with the request's CorrelationID (or its MessageID
+// when the request carries none), so the requester can match it, and
+// with CausationID = the request's MessageID, so the reply chains to
+// the message that caused it. Any other header of reply (including
+// its own ReplyTo, for multi-hop flows) is kept.
+//
+// Returns ErrSend(ErrReplyToEmpty) when the request has no ReplyTo,
+// the ResolveChannel error when the name cannot be resolved to a
+// Channel[Res], and the Send error of the reply channel otherwise.
+func Reply[Req, Res any](ctx context.Context, registry ChannelRegistry, request Message[Req], reply Message[Res]) error {
+	cassert.NotNil(registry, "ChannelRegistry is nil")
+
+	if ctx == nil {
+		return ErrSend(ErrContextNil)
+	}
+
+	if request.Headers.ReplyTo == "" {
+		return ErrSend(ErrReplyToEmpty)
+	}
+
+	channel, err := ResolveChannel[Res](registry, request.Headers.ReplyTo)
+	if err != nil {
+		return err
+	}
+
+	reply.Headers.CorrelationID = request.Headers.CorrelationID
+	if reply.Headers.CorrelationID == "" {
+		reply.Headers.CorrelationID = request.Headers.MessageID
+	}
+
+	reply.Headers.CausationID = request.Headers.MessageID
+
+	return channel.Send(ctx, reply)
+}
+
+// isNil reports whether value is nil or a nil pointer.
+func isNil(value any) bool {
+	if value == nil {
+		return true
+	}
+
+	rv := reflect.ValueOf(value)
+
+	return rv.Kind() == reflect.Pointer && rv.IsNil()
+}
+
+// isChannel reports whether a non-nil value looks like a Channel[T]:
+// it exposes Send and Subscribe. The payload type cannot be checked
+// without knowing T; ResolveChannel checks it at lookup.
+func isChannel(value any) bool {
+	rt := reflect.TypeOf(value)
+
+	_, hasSend := rt.MethodByName("Send")
+	_, hasSubscribe := rt.MethodByName("Subscribe")
+
+	return hasSend && hasSubscribe
+}
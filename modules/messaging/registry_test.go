@@ -0,0 +1,237 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestChannelRegistry_Register(t *testing.T) {
+	t.Parallel()
+
+	t.Run("rejects an empty name", func(t *testing.T) {
+		t.Parallel()
+
+		err := NewChannelRegistry().Register("", NewPipelineChannel[int]())
+		if !errors.Is(err, ErrChannelNameEmpty) {
+			t.Fatalf("expected ErrChannelNameEmpty, got %v", err)
+		}
+	})
+
+	t.Run("rejects a nil value", func(t *testing.T) {
+		t.Parallel()
+
+		err := NewChannelRegistry().Register("orders", nil)
+		if !errors.Is(err, ErrChannelNil) {
+			t.Fatalf("expected ErrChannelNil, got %v", err)
+		}
+	})
+
+	t.Run("rejects a typed nil channel", func(t *testing.T) {
+		t.Parallel()
+
+		var typedNil *pipeline[int]
+
+		err := NewChannelRegistry().Register("orders", typedNil)
+		if !errors.Is(err, ErrChannelNil) {
+			t.Fatalf("expected ErrChannelNil, got %v", err)
+		}
+	})
+
+	t.Run("rejects a non-channel value", func(t *testing.T) {
+		t.Parallel()
+
+		err := NewChannelRegistry().Register("orders", "not a channel")
+		if !errors.Is(err, ErrChannelType) || errors.Is(err, ErrChannelNil) {
+			t.Fatalf("expected ErrChannelType, got %v", err)
+		}
+	})
+
+	t.Run("rejects a duplicate name", func(t *testing.T) {
+		t.Parallel()
+
+		r := NewChannelRegistry()
+
+		err := r.Register("orders", NewPipelineChannel[int]())
+		if err != nil {
+			t.Fatalf("Register: %v", err)
+		}
+
+		err = r.Register("orders", NewPipelineChannel[string]())
+		if !errors.Is(err, ErrChannelRegistered) {
+			t.Fatalf("expected ErrChannelRegistered, got %v", err)
+		}
+	})
+
+	t.Run("Unregister frees the name", func(t *testing.T) {
+		t.Parallel()
+
+		r := NewChannelRegistry()
+		_ = r.Register("orders", NewPipelineChannel[int]())
+		_ = r.Register("audit", NewPipelineChannel[int]())
+
+		got := r.Names()
+		if !slices.Equal(got, []string{"audit", "orders"}) {
+			t.Fatalf("expected sorted names, got %v", got)
+		}
+
+		r.Unregister("orders")
+		r.Unregister("unknown")
+
+		_, err := r.Resolve("orders")
+		if !errors.Is(err, ErrChannelNotFound) {
+			t.Fatalf("expected ErrChannelNotFound, got %v", err)
+		}
+	})
+}
+
+func TestResolveChannel(t *testing.T) {
+	t.Parallel()
+
+	r := NewChannelRegistry()
+	orders := NewPipelineChannel[int]()
+	_ = r.Register("orders", orders)
+
+	t.Run("returns the typed channel", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := ResolveChannel[int](r, "orders")
+		if err != nil {
+			t.Fatalf("ResolveChannel: %v", err)
+		}
+
+		if got != orders {
+			t.Fatal("expected the registered channel")
+		}
+	})
+
+	t.Run("reports a payload type mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ResolveChannel[string](r, "orders")
+		if !errors.Is(err, ErrChannelTypeMismatch) || !errors.Is(err, ErrRegistryFailed) {
+			t.Fatalf("expected ErrChannelTypeMismatch, got %v", err)
+		}
+	})
+
+	t.Run("reports unknown and empty names", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ResolveChannel[int](r, "missing")
+		if !errors.Is(err, ErrChannelNotFound) {
+			t.Fatalf("expected ErrChannelNotFound, got %v", err)
+		}
+
+		_, err = ResolveChannel[int](r, "")
+		if !errors.Is(err, ErrChannelNameEmpty) {
+			t.Fatalf("expected ErrChannelNameEmpty, got %v", err)
+		}
+	})
+}
+
+func TestReply(t *testing.T) {
+	t.Parallel()
+
+	t.Run("sends to ReplyTo with correlation and causation", func(t *testing.T) {
+		t.Parallel()
+
+		r := NewChannelRegistry()
+		replies := NewPipelineChannel[string]()
+		_ = r.Register("replies", replies)
+
+		var got Message[string]
+
+		_, err := replies.Subscribe(func(_ context.Context, msg Message[string]) error {
+			got = msg
+
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Subscribe: %v", err)
+		}
+
+		request := NewMessage(1, nil)
+		request.Headers.MessageID = "req-1"
+		request.Headers.CorrelationID = "conv-1"
+		request.Headers.ReplyTo = "replies"
+
+		reply := NewMessage("ok", nil)
+		reply.Headers.ReplyTo = "next-hop"
+
+		err = Reply(context.Background(), r, request, reply)
+		if err != nil {
+			t.Fatalf("Reply: %v", err)
+		}
+
+		if got.Payload != "ok" || got.Headers.CorrelationID != "conv-1" || got.Headers.CausationID != "req-1" {
+			t.Fatalf("unexpected reply %+v", got)
+		}
+
+		if got.Headers.ReplyTo != "next-hop" {
+			t.Fatalf("expected the reply's own ReplyTo kept, got %q", got.Headers.ReplyTo)
+		}
+	})
+
+	t.Run("correlates to the request MessageID when it has no CorrelationID", func(t *testing.T) {
+		t.Parallel()
+
+		r := NewChannelRegistry()
+		replies := NewPipelineChannel[string]()
+		_ = r.Register("replies", replies)
+
+		var got Message[string]
+
+		_, err := replies.Subscribe(func(_ context.Context, msg Message[string]) error {
+			got = msg
+
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Subscribe: %v", err)
+		}
+
+		request := NewMessage(1, nil)
+		request.Headers.MessageID = "req-1"
+		request.Headers.ReplyTo = "replies"
+
+		err = Reply(context.Background(), r, request, NewMessage("ok", nil))
+		if err != nil {
+			t.Fatalf("Reply: %v", err)
+		}
+
+		if got.Headers.CorrelationID != "req-1" || got.Headers.CausationID != "req-1" {
+			t.Fatalf("expected the reply correlated to req-1, got %+v", got.Headers)
+		}
+	})
+
+	t.Run("rejects requests without ReplyTo", func(t *testing.T) {
+		t.Parallel()
+
+		err := Reply(context.Background(), NewChannelRegistry(), NewMessage(1, nil), NewMessage("ok", nil))
+		if !errors.Is(err, ErrReplyToEmpty) || !errors.Is(err, ErrSendFailed) {
+			t.Fatalf("expected ErrReplyToEmpty, got %v", err)
+		}
+	})
+
+	t.Run("rejects nil ctx", func(t *testing.T) {
+		t.Parallel()
+
+		err := Reply(nil, NewChannelRegistry(), NewMessage(1, nil), NewMessage("ok", nil)) //nolint:staticcheck
+		if !errors.Is(err, ErrContextNil) {
+			t.Fatalf("expected ErrContextNil, got %v", err)
+		}
+	})
+
+	t.Run("propagates resolve errors", func(t *testing.T) {
+		t.Parallel()
+
+		request := NewMessage(1, nil)
+		request.Headers.ReplyTo = "missing"
+
+		err := Reply(context.Background(), NewChannelRegistry(), request, NewMessage("ok", nil))
+		if !errors.Is(err, ErrChannelNotFound) {
+			t.Fatalf("expected ErrChannelNotFound, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,188 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+// Stats is a point-in-time snapshot of the runtime statistics of a
+// channel or pattern. Counters are cumulative since construction.
+//
+// For channels, Sent counts messages accepted by Send (including those
+// later dropped by OverflowDropNewest / OverflowDropOldest) and
+// Delivered counts handler invocations. For patterns, Delivered counts
+// messages taken from the source channel and Sent the messages
+// forwarded downstream. In both, Failed counts the deliveries whose
+// processing failed (handler error or panic, reported failure) and is
+// a subset of Delivered.
+type Stats struct {
+	// BufferLength is the number of messages buffered and not yet
+	// dispatched. Zero for unbuffered channels and patterns.
+	BufferLength int
+	// BufferCapacity is the configured buffer bound (summed over the
+	// subscribers of a TopicChannel). Zero when unbounded or
+	// unbuffered.
+	BufferCapacity int
+	// Subscribers is the number of registered handlers (for patterns:
+	// active subscriptions on the source channel).
+	Subscribers int
+	// InFlight is the number of deliveries currently being processed.
+	InFlight int
+	// Sent is the cumulative number of messages sent (see type doc).
+	Sent uint64
+	// Delivered is the cumulative number of deliveries (see type doc).
+	Delivered uint64
+	// Failed is the cumulative number of failed deliveries.
+	Failed uint64
+	// Dropped is the cumulative number of messages discarded without
+	// being processed: overflow evictions, expirations, no
+	// subscribers, NullChannel sinks, filter rejections.
+	Dropped uint64
+	// DeadLettered is the cumulative number of DeadLetters published
+	// to the DLQ.
+	DeadLettered uint64
+	// LastErrorTime is when the last failure or drop was recorded;
+	// zero when none happened yet.
+	LastErrorTime time.Time
+}
+
+// StatsRecorder accumulates the counters reported in Stats. Channels
+// and patterns embed one and call it from their dispatch paths; it is
+// exported so patterns in sibling packages (and third-party channels)
+// report the same shape. The zero value is ready to use — it reads
+// LastErrorTime from the system clock — and every method except
+// UseClock is safe for concurrent use.
+type StatsRecorder struct {
+	sent         atomic.Uint64
+	delivered    atomic.Uint64
+	failed       atomic.Uint64
+	dropped      atomic.Uint64
+	deadLettered atomic.Uint64
+	inFlight     atomic.Int64
+	lastError    atomic.Int64
+	clock        Clock
+}
+
+// UseClock sets the clock LastErrorTime is read from, so components
+// built WithClock (or a messagingtest.FakeClock) stamp failures on the
+// same time source as the rest of their work. Nil restores the system
+// clock. Call it from the component's constructor, before the recorder
+// is shared.
+func (r *StatsRecorder) UseClock(clock Clock) {
+	r.clock = clock
+}
+
+// RecordSent counts one sent message.
+func (r *StatsRecorder) RecordSent() {
+	r.sent.Add(1)
+}
+
+// Begin counts one delivery and marks it in flight. The returned
+// function ends it; a non-nil err also counts a failure. Call it
+// exactly once.
+func (r *StatsRecorder) Begin() func(err error) {
+	r.delivered.Add(1)
+	r.inFlight.Add(1)
+
+	return func(err error) {
+		r.inFlight.Add(-1)
+
+		if err != nil {
+			r.RecordFailure()
+		}
+	}
+}
+
+// RecordFailure counts one failed delivery.
+func (r *StatsRecorder) RecordFailure() {
+	r.failed.Add(1)
+	r.lastError.Store(r.now().UnixNano())
+}
+
+// RecordDrop counts one dropped message.
+func (r *StatsRecorder) RecordDrop() {
+	r.dropped.Add(1)
+	r.lastError.Store(r.now().UnixNano())
+}
+
+// RecordDeadLetter counts one DeadLetter published to the DLQ.
+func (r *StatsRecorder) RecordDeadLetter() {
+	r.deadLettered.Add(1)
+}
+
+// now reads the configured clock, or the system clock when none is set.
+func (r *StatsRecorder) now() time.Time {
+	if r.clock == nil {
+		return time.Now()
+	}
+
+	return r.clock.Now()
+}
+
+// Snapshot returns the counters as a Stats value. Buffer and
+// subscriber fields are left zero for the caller to fill.
+func (r *StatsRecorder) Snapshot() Stats {
+	stats := Stats{
+		InFlight:     int(r.inFlight.Load()),
+		Sent:         r.sent.Load(),
+		Delivered:    r.delivered.Load(),
+		Failed:       r.failed.Load(),
+		Dropped:      r.dropped.Load(),
+		DeadLettered: r.deadLettered.Load(),
+	}
+
+	last := r.lastError.Load()
+	if last != 0 {
+		stats.LastErrorTime = time.Unix(0, last)
+	}
+
+	return stats
+}
+
+// dropHook wraps hook so that the drops it observes (errors wrapping
+// ErrDropped or ErrNoSubscribers) are counted on stats before being
+// forwarded. Handler failures pass through uncounted: the dispatch
+// path counts them via Begin. The result is never nil so drops are
+// counted even when no hook is configured.
+func (r *StatsRecorder) dropHook(hook ErrorHandler) ErrorHandler {
+	return func(ctx context.Context, msg any, err error) {
+		if errors.Is(err, ErrDropped) || errors.Is(err, ErrNoSubscribers) {
+			r.RecordDrop()
+		}
+
+		if hook != nil {
+			hook(ctx, msg, err)
+		}
+	}
+}
+
+// deadLetterCounter is the DLQ decorator installed by the channels so
+// every accepted DeadLetter is counted on their StatsRecorder.
+type deadLetterCounter[T any] struct {
+	Channel[DeadLetter[T]]
+
+	stats *StatsRecorder
+}
+
+// countDeadLetters wraps dlq so accepted Sends are counted on stats.
+// A nil dlq stays nil.
+func countDeadLetters[T any](dlq Channel[DeadLetter[T]], stats *StatsRecorder) Channel[DeadLetter[T]] {
+	if dlq == nil {
+		return nil
+	}
+
+	return &deadLetterCounter[T]{Channel: dlq, stats: stats}
+}
+
+// Send forwards to the wrapped DLQ and counts the DeadLetter when it
+// was accepted.
+func (c *deadLetterCounter[T]) Send(ctx context.Context, msg Message[DeadLetter[T]]) error {
+	err := c.Channel.Send(ctx, msg)
+	if err == nil {
+		c.stats.RecordDeadLetter()
+	}
+
+	return err
+}
@@ -0,0 +1,230 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestStatsRecorder(t *testing.T) {
+	t.Parallel()
+
+	t.Run("zero value snapshots to zero stats", func(t *testing.T) {
+		t.Parallel()
+
+		var r StatsRecorder
+
+		got := r.Snapshot()
+		if got != (Stats{}) {
+			t.Fatalf("Snapshot = %+v, want zero", got)
+		}
+	})
+
+	t.Run("begin tracks in-flight deliveries until ended", func(t *testing.T) {
+		t.Parallel()
+
+		var r StatsRecorder
+
+		end := r.Begin()
+
+		got := r.Snapshot()
+		if got.Delivered != 1 || got.InFlight != 1 {
+			t.Fatalf("during delivery: Delivered=%d InFlight=%d, want 1/1", got.Delivered, got.InFlight)
+		}
+
+		end(nil)
+
+		got = r.Snapshot()
+		if got.InFlight != 0 || got.Failed != 0 {
+			t.Fatalf("after delivery: InFlight=%d Failed=%d, want 0/0", got.InFlight, got.Failed)
+		}
+
+		if !got.LastErrorTime.IsZero() {
+			t.Fatalf("LastErrorTime = %v, want zero", got.LastErrorTime)
+		}
+	})
+
+	t.Run("ending with an error counts a failure", func(t *testing.T) {
+		t.Parallel()
+
+		var r StatsRecorder
+
+		end := r.Begin()
+		end(errors.New("boom"))
+
+		got := r.Snapshot()
+		if got.Failed != 1 {
+			t.Fatalf("Failed = %d, want 1", got.Failed)
+		}
+
+		if got.LastErrorTime.IsZero() {
+			t.Fatal("LastErrorTime not set")
+		}
+	})
+
+	t.Run("LastErrorTime follows the configured clock", func(t *testing.T) {
+		t.Parallel()
+
+		instant := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+
+		var r StatsRecorder
+
+		r.UseClock(fixedClock{now: instant})
+		r.RecordFailure()
+
+		if got := r.Snapshot().LastErrorTime; !got.Equal(instant) {
+			t.Fatalf("LastErrorTime after failure = %v, want %v", got, instant)
+		}
+
+		r.UseClock(fixedClock{now: instant.Add(time.Hour)})
+		r.RecordDrop()
+
+		if got := r.Snapshot().LastErrorTime; !got.Equal(instant.Add(time.Hour)) {
+			t.Fatalf("LastErrorTime after drop = %v, want %v", got, instant.Add(time.Hour))
+		}
+	})
+
+	t.Run("counters accumulate", func(t *testing.T) {
+		t.Parallel()
+
+		var r StatsRecorder
+
+		r.RecordSent()
+		r.RecordSent()
+		r.RecordDrop()
+		r.RecordDeadLetter()
+
+		got := r.Snapshot()
+		if got.Sent != 2 || got.Dropped != 1 || got.DeadLettered != 1 {
+			t.Fatalf("Snapshot = %+v, want Sent=2 Dropped=1 DeadLettered=1", got)
+		}
+
+		if got.LastErrorTime.IsZero() {
+			t.Fatal("a drop must set LastErrorTime")
+		}
+	})
+}
+
+func TestStatsRecorder_dropHook(t *testing.T) {
+	t.Parallel()
+
+	t.Run("counts drops and forwards to the hook", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			r     StatsRecorder
+			calls int
+		)
+
+		hook := r.dropHook(func(_ context.Context, _ any, _ error) { calls++ })
+
+		hook(context.Background(), nil, errors.Join(ErrOverflow, ErrDropped))
+		hook(context.Background(), nil, ErrNoSubscribers)
+		hook(context.Background(), nil, errors.New("handler failed"))
+
+		if calls != 3 {
+			t.Fatalf("hook calls = %d, want 3", calls)
+		}
+
+		got := r.Snapshot()
+		if got.Dropped != 2 {
+			t.Fatalf("Dropped = %d, want 2", got.Dropped)
+		}
+	})
+
+	t.Run("counts drops without a hook", func(t *testing.T) {
+		t.Parallel()
+
+		var r StatsRecorder
+
+		hook := r.dropHook(nil)
+		if hook == nil {
+			t.Fatal("dropHook(nil) returned nil")
+		}
+
+		hook(context.Background(), nil, ErrDropped)
+
+		got := r.Snapshot()
+		if got.Dropped != 1 {
+			t.Fatalf("Dropped = %d, want 1", got.Dropped)
+		}
+	})
+}
+
+func TestCountDeadLetters(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil dlq stays nil", func(t *testing.T) {
+		t.Parallel()
+
+		var r StatsRecorder
+
+		got := countDeadLetters[int](nil, &r)
+		if got != nil {
+			t.Fatalf("countDeadLetters(nil) = %v, want nil", got)
+		}
+	})
+
+	t.Run("counts accepted dead letters only", func(t *testing.T) {
+		t.Parallel()
+
+		var r StatsRecorder
+
+		dlq := NewPipelineChannel[DeadLetter[int]]()
+
+		_, err := dlq.Subscribe(func(_ context.Context, msg Message[DeadLetter[int]]) error {
+			if msg.Payload.Original.Payload == 2 {
+				return errors.New("rejected")
+			}
+
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Subscribe returned %v", err)
+		}
+
+		counted := countDeadLetters(dlq, &r)
+
+		PublishDeadLetter(context.Background(), counted, NewMessage(1, nil), errors.New("x"), time.Now())
+		PublishDeadLetter(context.Background(), counted, NewMessage(2, nil), errors.New("x"), time.Now())
+
+		got := r.Snapshot()
+		if got.DeadLettered != 1 {
+			t.Fatalf("DeadLettered = %d, want 1", got.DeadLettered)
+		}
+	})
+}
+
+func TestStatsOf(t *testing.T) {
+	t.Parallel()
+
+	t.Run("reports inspectable values", func(t *testing.T) {
+		t.Parallel()
+
+		ch := NewNullChannel[int]()
+
+		err := ch.Send(context.Background(), NewMessage(1, nil))
+		if err != nil {
+			t.Fatalf("Send returned %v", err)
+		}
+
+		got, ok := StatsOf(ch)
+		if !ok {
+			t.Fatal("StatsOf reported a channel of this package as not inspectable")
+		}
+
+		if got.Sent != 1 {
+			t.Fatalf("Sent = %d, want 1", got.Sent)
+		}
+	})
+
+	t.Run("rejects other values", func(t *testing.T) {
+		t.Parallel()
+
+		_, ok := StatsOf("not a channel")
+		if ok {
+			t.Fatal("StatsOf accepted a non-inspectable value")
+		}
+	})
+}
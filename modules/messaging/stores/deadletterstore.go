@@ -0,0 +1,176 @@
+package stores
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+	cpointer "github.com/guidomantilla/yarumo/core/common/pointer"
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+// inMemoryDeadLetterStore is the canonical DeadLetterStore[T] backed by
+// a mutex-guarded map plus the insertion order, which breaks FailedAt
+// ties in List. Entry IDs are a per-store sequence. It is purely
+// passive — no goroutines, no lifecycle.
+type inMemoryDeadLetterStore[T any] struct {
+	clock messaging.Clock
+
+	mu      sync.RWMutex
+	seq     uint64
+	order   []string
+	entries map[string]DeadLetterEntry[T]
+}
+
+// NewInMemoryDeadLetterStore constructs an in-memory
+// DeadLetterStore[T]. The returned store is ready to use immediately;
+// it holds no external resources and does not implement
+// lifecycle.Component. WithClock sets the time source for StoredAt and
+// the age filters. Heavy-dep backends live in
+// extension/messaging/stores/<backend>/.
+func NewInMemoryDeadLetterStore[T any](opts ...Option) DeadLetterStore[T] {
+	options := NewOptions(opts...)
+
+	return &inMemoryDeadLetterStore[T]{
+		clock:   options.clock,
+		entries: map[string]DeadLetterEntry[T]{},
+	}
+}
+
+// Put records letter under a new sequential ID. ctx is honored only
+// for its cancellation signal.
+func (s *inMemoryDeadLetterStore[T]) Put(ctx context.Context, channel string, letter messaging.DeadLetter[T]) (string, error) {
+	cassert.NotNil(s, "in-memory dead letter store is nil")
+
+	err := ctx.Err()
+	if err != nil {
+		return "", ErrStore(err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.seq++
+	id := strconv.FormatUint(s.seq, 10)
+
+	s.entries[id] = DeadLetterEntry[T]{ID: id, Channel: channel, Letter: letter, StoredAt: s.clock.Now()}
+	s.order = append(s.order, id)
+
+	return id, nil
+}
+
+// Get returns the entry stored under id. It returns ErrNotFound when
+// the id does not exist.
+func (s *inMemoryDeadLetterStore[T]) Get(ctx context.Context, id string) (DeadLetterEntry[T], error) {
+	cassert.NotNil(s, "in-memory dead letter store is nil")
+
+	err := ctx.Err()
+	if err != nil {
+		return cpointer.Zero[DeadLetterEntry[T]](), ErrStore(err)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entry, ok := s.entries[id]
+	if !ok {
+		return cpointer.Zero[DeadLetterEntry[T]](), ErrNotFound()
+	}
+
+	return entry, nil
+}
+
+// List returns the entries matching filter ordered by Letter.FailedAt,
+// insertion order breaking ties. Ages are measured against the store's
+// clock.
+func (s *inMemoryDeadLetterStore[T]) List(ctx context.Context, filter DeadLetterFilter) ([]DeadLetterEntry[T], error) {
+	cassert.NotNil(s, "in-memory dead letter store is nil")
+
+	err := ctx.Err()
+	if err != nil {
+		return nil, ErrStore(err)
+	}
+
+	now := s.clock.Now()
+
+	s.mu.RLock()
+
+	matched := make([]DeadLetterEntry[T], 0, len(s.order))
+	for _, id := range s.order {
+		entry := s.entries[id]
+		if matchDeadLetter(filter, entry, now) {
+			matched = append(matched, entry)
+		}
+	}
+
+	s.mu.RUnlock()
+
+	slices.SortStableFunc(matched, func(a, b DeadLetterEntry[T]) int {
+		return a.Letter.FailedAt.Compare(b.Letter.FailedAt)
+	})
+
+	if filter.Limit > 0 && len(matched) > filter.Limit {
+		matched = matched[:filter.Limit]
+	}
+
+	return matched, nil
+}
+
+// Delete removes the entry stored under id. Delete is idempotent: it
+// returns nil whether or not the id was present before the call.
+func (s *inMemoryDeadLetterStore[T]) Delete(ctx context.Context, id string) error {
+	cassert.NotNil(s, "in-memory dead letter store is nil")
+
+	err := ctx.Err()
+	if err != nil {
+		return ErrStore(err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, ok := s.entries[id]
+	if !ok {
+		return nil
+	}
+
+	delete(s.entries, id)
+	s.order = slices.DeleteFunc(s.order, func(other string) bool { return other == id })
+
+	return nil
+}
+
+// matchDeadLetter reports whether entry passes every non-zero field of
+// filter, ages measured at now. Limit is applied by the caller.
+func matchDeadLetter[T any](filter DeadLetterFilter, entry DeadLetterEntry[T], now time.Time) bool {
+	if filter.Channel != "" && entry.Channel != filter.Channel {
+		return false
+	}
+
+	lastError := entry.Letter.LastError
+
+	if filter.Error != nil && !errors.Is(lastError, filter.Error) {
+		return false
+	}
+
+	if filter.ErrorContains != "" && (lastError == nil || !strings.Contains(lastError.Error(), filter.ErrorContains)) {
+		return false
+	}
+
+	age := now.Sub(entry.Letter.FailedAt)
+
+	if filter.MinAge > 0 && age < filter.MinAge {
+		return false
+	}
+
+	if filter.MaxAge > 0 && age > filter.MaxAge {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,232 @@
+package stores
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/guidomantilla/yarumo/messaging"
+	"github.com/guidomantilla/yarumo/messaging/messagingtest"
+)
+
+// letter builds a DeadLetter[int] carrying payload that failed with
+// err at failedAt.
+func letter(payload int, err error, failedAt time.Time) messaging.DeadLetter[int] {
+	return messaging.DeadLetter[int]{
+		Original:  messaging.Message[int]{Payload: payload, Headers: messaging.Headers{MessageID: "m-" + strconv.Itoa(payload)}},
+		LastError: err,
+		FailedAt:  failedAt,
+	}
+}
+
+func TestNewInMemoryDeadLetterStore(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns non-nil store", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewInMemoryDeadLetterStore[int]()
+		if s == nil {
+			t.Fatal("expected non-nil store")
+		}
+	})
+
+	t.Run("honors WithClock", func(t *testing.T) {
+		t.Parallel()
+
+		clock := messagingtest.NewFakeClock(time.Time{})
+
+		s := NewInMemoryDeadLetterStore[int](WithClock(clock)).(*inMemoryDeadLetterStore[int])
+		if s.clock != clock {
+			t.Fatalf("expected the fake clock, got %T", s.clock)
+		}
+	})
+}
+
+func TestInMemoryDeadLetterStore_PutGet(t *testing.T) {
+	t.Parallel()
+
+	t.Run("stores the letter under a new id", func(t *testing.T) {
+		t.Parallel()
+
+		clock := messagingtest.NewFakeClock(time.Time{})
+		s := NewInMemoryDeadLetterStore[int](WithClock(clock))
+
+		boom := errors.New("boom")
+
+		first, err := s.Put(context.Background(), "orders", letter(1, boom, clock.Now()))
+		if err != nil {
+			t.Fatalf("put: %v", err)
+		}
+
+		second, _ := s.Put(context.Background(), "orders", letter(2, boom, clock.Now()))
+		if first == second {
+			t.Fatalf("expected distinct ids, got %q twice", first)
+		}
+
+		entry, err := s.Get(context.Background(), first)
+		if err != nil {
+			t.Fatalf("get: %v", err)
+		}
+
+		if entry.ID != first || entry.Channel != "orders" || entry.Letter.Original.Payload != 1 || !errors.Is(entry.Letter.LastError, boom) {
+			t.Fatalf("unexpected entry %+v", entry)
+		}
+
+		if !entry.StoredAt.Equal(clock.Now()) {
+			t.Fatalf("expected StoredAt from the clock, got %v", entry.StoredAt)
+		}
+	})
+
+	t.Run("unknown id returns ErrStoreNotFound", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewInMemoryDeadLetterStore[int]().Get(context.Background(), "missing")
+		if !errors.Is(err, ErrStoreNotFound) {
+			t.Fatalf("expected ErrStoreNotFound, got %v", err)
+		}
+	})
+
+	t.Run("cancelled ctx returns ErrStore", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		s := NewInMemoryDeadLetterStore[int]()
+
+		_, err := s.Put(ctx, "orders", letter(1, nil, time.Now()))
+		if !errors.Is(err, ErrStoreFailed) || !errors.Is(err, context.Canceled) {
+			t.Fatalf("put: expected ErrStore wrapping context.Canceled, got %v", err)
+		}
+
+		_, err = s.Get(ctx, "1")
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("get: expected context.Canceled, got %v", err)
+		}
+
+		_, err = s.List(ctx, DeadLetterFilter{})
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("list: expected context.Canceled, got %v", err)
+		}
+
+		err = s.Delete(ctx, "1")
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("delete: expected context.Canceled, got %v", err)
+		}
+	})
+}
+
+func TestInMemoryDeadLetterStore_List(t *testing.T) {
+	t.Parallel()
+
+	clock := messagingtest.NewFakeClock(time.Time{})
+	s := NewInMemoryDeadLetterStore[int](WithClock(clock))
+	now := clock.Now()
+	ctx := context.Background()
+
+	// Stored out of failure order on purpose: List sorts by FailedAt.
+	_, _ = s.Put(ctx, "orders", letter(2, messaging.ErrExpired, now.Add(-time.Minute)))
+	_, _ = s.Put(ctx, "orders", letter(1, errors.New("db timeout"), now.Add(-time.Hour)))
+	_, _ = s.Put(ctx, "payments", letter(3, errors.New("db timeout"), now.Add(-time.Second)))
+
+	// assertList fails t unless List(filter) returns the payloads want,
+	// in order.
+	assertList := func(t *testing.T, filter DeadLetterFilter, want ...int) {
+		t.Helper()
+
+		entries, err := s.List(ctx, filter)
+		if err != nil {
+			t.Fatalf("list: %v", err)
+		}
+
+		got := make([]int, 0, len(entries))
+		for _, entry := range entries {
+			got = append(got, entry.Letter.Original.Payload)
+		}
+
+		if !slices.Equal(got, want) {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+
+	t.Run("zero filter lists everything oldest first", func(t *testing.T) {
+		t.Parallel()
+
+		assertList(t, DeadLetterFilter{}, 1, 2, 3)
+	})
+
+	t.Run("channel", func(t *testing.T) {
+		t.Parallel()
+
+		assertList(t, DeadLetterFilter{Channel: "orders"}, 1, 2)
+	})
+
+	t.Run("error identity", func(t *testing.T) {
+		t.Parallel()
+
+		assertList(t, DeadLetterFilter{Error: messaging.ErrExpired}, 2)
+	})
+
+	t.Run("error text", func(t *testing.T) {
+		t.Parallel()
+
+		assertList(t, DeadLetterFilter{ErrorContains: "timeout"}, 1, 3)
+	})
+
+	t.Run("min age", func(t *testing.T) {
+		t.Parallel()
+
+		assertList(t, DeadLetterFilter{MinAge: time.Minute}, 1, 2)
+	})
+
+	t.Run("max age", func(t *testing.T) {
+		t.Parallel()
+
+		assertList(t, DeadLetterFilter{MaxAge: time.Minute}, 2, 3)
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		t.Parallel()
+
+		assertList(t, DeadLetterFilter{Limit: 2}, 1, 2)
+	})
+
+	t.Run("combined", func(t *testing.T) {
+		t.Parallel()
+
+		assertList(t, DeadLetterFilter{Channel: "orders", ErrorContains: "timeout"}, 1)
+	})
+}
+
+func TestInMemoryDeadLetterStore_Delete(t *testing.T) {
+	t.Parallel()
+
+	s := NewInMemoryDeadLetterStore[int]()
+	ctx := context.Background()
+
+	id, _ := s.Put(ctx, "orders", letter(1, nil, time.Now()))
+
+	err := s.Delete(ctx, id)
+	if err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+
+	err = s.Delete(ctx, id)
+	if err != nil {
+		t.Fatalf("second delete: %v", err)
+	}
+
+	_, err = s.Get(ctx, id)
+	if !errors.Is(err, ErrStoreNotFound) {
+		t.Fatalf("expected ErrStoreNotFound after delete, got %v", err)
+	}
+
+	entries, _ := s.List(ctx, DeadLetterFilter{})
+	if len(entries) != 0 {
+		t.Fatalf("expected empty list after delete, got %d", len(entries))
+	}
+}
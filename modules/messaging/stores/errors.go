@@ -37,6 +37,13 @@ var (
 	// permanent entries should use a payload-bearing store, not a
 	// dedup store.
 	ErrInvalidTTL = errors.New("ttl must be positive")
+	// ErrReplayFailed is the sentinel embedded in every Error returned
+	// by ErrReplay: a dead letter could not be sent back to its
+	// channel.
+	ErrReplayFailed = errors.New("dead letter replay failed")
+	// ErrMessageIDFailed indicates that no Headers.MessageID could be
+	// generated for a replayed message (nil or failing uid generator).
+	ErrMessageIDFailed = errors.New("message id generation failed")
 )
 
 // Error is the domain error type for messaging store operations.
@@ -76,3 +83,14 @@ func ErrNotFound(causes ...error) error {
 		},
 	}
 }
+
+// ErrReplay wraps the given causes into a domain Error joined with
+// ErrReplayFailed. Returned by ReplayDeadLetter.
+func ErrReplay(causes ...error) error {
+	return &Error{
+		TypedError: cerrs.TypedError{
+			Type: StoreType,
+			Err:  errors.Join(append(causes, ErrReplayFailed)...),
+		},
+	}
+}
@@ -92,3 +92,23 @@ func TestErrNotFound(t *testing.T) {
 		}
 	})
 }
+
+func TestErrReplay(t *testing.T) {
+	t.Parallel()
+
+	boom := errors.New("custom failure")
+
+	err := ErrReplay(ErrMessageIDFailed, boom)
+	if !errors.Is(err, ErrReplayFailed) {
+		t.Fatal("expected ErrReplayFailed in chain")
+	}
+
+	if !errors.Is(err, ErrMessageIDFailed) || !errors.Is(err, boom) {
+		t.Fatal("expected causes in chain")
+	}
+
+	var storeErr *Error
+	if !errors.As(err, &storeErr) || storeErr.Type != StoreType {
+		t.Fatalf("expected *Error with type %q, got %T", StoreType, err)
+	}
+}
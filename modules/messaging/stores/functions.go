@@ -0,0 +1,84 @@
+package stores
+
+import (
+	"context"
+
+	cassert "github.com/guidomantilla/yarumo/core/common/assert"
+	cpointer "github.com/guidomantilla/yarumo/core/common/pointer"
+	cuids "github.com/guidomantilla/yarumo/core/common/uids"
+	"github.com/guidomantilla/yarumo/messaging"
+)
+
+// RecordDeadLetters returns a Handler that persists every dead letter
+// it receives in store as dead-lettered by channel. Subscribe it on the
+// DLQ channel handed to that channel's messaging.WithDLQChannel:
+//
+//	dlq := messaging.NewPipelineChannel[messaging.DeadLetter[Order]]()
+//	_, _ = dlq.Subscribe(stores.RecordDeadLetters(store, "orders"))
+//	orders := messaging.NewQueueChannel[Order]("orders", messaging.WithDLQChannel(dlq))
+//
+// A failing Put is returned to the DLQ channel, which treats it like
+// any other handler failure.
+func RecordDeadLetters[T any](store DeadLetterStore[T], channel string) messaging.Handler[messaging.DeadLetter[T]] {
+	cassert.NotNil(store, "dead letter store is nil")
+
+	return func(ctx context.Context, msg messaging.Message[messaging.DeadLetter[T]]) error {
+		_, err := store.Put(ctx, channel, msg.Payload)
+
+		return err
+	}
+}
+
+// ReplayDeadLetter sends the entry stored under id back to the channel
+// registry resolves for its Channel and deletes the entry once the
+// send succeeded. The replayed message keeps the original payload and
+// headers except for a new Headers.MessageID drawn from uid, the
+// original MessageID as Headers.CausationID and DeliveryCount reset to
+// zero. A letter that expired stays expired: the channel drops it
+// again unless Headers.ExpirationTime is cleared before replay.
+//
+// It returns the message as sent. Lookup failures are returned as
+// they come from the store (ErrNotFound for an unknown id); resolve,
+// id generation and send failures are wrapped in ErrReplay and leave
+// the entry in place. A failing Delete after a successful send is
+// returned wrapped in ErrStore together with the sent message.
+func ReplayDeadLetter[T any](ctx context.Context, store DeadLetterStore[T], registry messaging.ChannelRegistry, uid cuids.UID, id string) (messaging.Message[T], error) {
+	cassert.NotNil(store, "dead letter store is nil")
+	cassert.NotNil(registry, "channel registry is nil")
+
+	entry, err := store.Get(ctx, id)
+	if err != nil {
+		return cpointer.Zero[messaging.Message[T]](), err
+	}
+
+	channel, err := messaging.ResolveChannel[T](registry, entry.Channel)
+	if err != nil {
+		return cpointer.Zero[messaging.Message[T]](), ErrReplay(err)
+	}
+
+	if uid == nil {
+		return cpointer.Zero[messaging.Message[T]](), ErrReplay(ErrMessageIDFailed)
+	}
+
+	messageID, err := uid.Generate()
+	if err != nil {
+		return cpointer.Zero[messaging.Message[T]](), ErrReplay(ErrMessageIDFailed, err)
+	}
+
+	msg := entry.Letter.Original
+	msg.Headers.CausationID = msg.Headers.MessageID
+	msg.Headers.MessageID = messageID
+	msg.Headers.DeliveryCount = 0
+
+	err = channel.Send(ctx, msg)
+	if err != nil {
+		return cpointer.Zero[messaging.Message[T]](), ErrReplay(err)
+	}
+
+	err = store.Delete(ctx, id)
+	if err != nil {
+		return msg, ErrStore(err)
+	}
+
+	return msg, nil
+}
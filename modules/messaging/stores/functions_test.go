@@ -0,0 +1,155 @@
+package stores
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	cuids "github.com/guidomantilla/yarumo/core/common/uids"
+	"github.com/guidomantilla/yarumo/messaging"
+	"github.com/guidomantilla/yarumo/messaging/messagingtest"
+)
+
+// fixedUID returns a cuids.UID that always generates id.
+func fixedUID(id string) cuids.UID {
+	return cuids.NewUID("fixed", func() (string, error) { return id, nil })
+}
+
+func TestRecordDeadLetters(t *testing.T) {
+	t.Parallel()
+
+	store := NewInMemoryDeadLetterStore[int]()
+	dlq := messaging.NewPipelineChannel[messaging.DeadLetter[int]]()
+
+	_, err := dlq.Subscribe(RecordDeadLetters(store, "orders"))
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+
+	err = dlq.Send(context.Background(), messaging.Message[messaging.DeadLetter[int]]{Payload: letter(1, errors.New("boom"), time.Now())})
+	if err != nil {
+		t.Fatalf("send: %v", err)
+	}
+
+	entries, _ := store.List(context.Background(), DeadLetterFilter{Channel: "orders"})
+	if len(entries) != 1 || entries[0].Letter.Original.Payload != 1 {
+		t.Fatalf("expected the letter recorded for orders, got %+v", entries)
+	}
+}
+
+func TestReplayDeadLetter(t *testing.T) {
+	t.Parallel()
+
+	// setup returns a store holding one letter for "orders", a registry
+	// binding "orders" to a recording channel, and the entry id.
+	setup := func(t *testing.T) (DeadLetterStore[int], messaging.ChannelRegistry, *messagingtest.RecordingChannel[int], string) {
+		t.Helper()
+
+		store := NewInMemoryDeadLetterStore[int]()
+		registry := messaging.NewChannelRegistry()
+		orders := messagingtest.NewRecordingChannel[int]()
+
+		err := registry.Register("orders", orders)
+		if err != nil {
+			t.Fatalf("register: %v", err)
+		}
+
+		original := letter(1, errors.New("boom"), time.Now())
+		original.Original.Headers.DeliveryCount = 3
+		original.Original.Headers.CorrelationID = "conv"
+
+		id, _ := store.Put(context.Background(), "orders", original)
+
+		return store, registry, orders, id
+	}
+
+	t.Run("sends a new message caused by the original and deletes the entry", func(t *testing.T) {
+		t.Parallel()
+
+		store, registry, orders, id := setup(t)
+
+		msg, err := ReplayDeadLetter(context.Background(), store, registry, fixedUID("replayed"), id)
+		if err != nil {
+			t.Fatalf("replay: %v", err)
+		}
+
+		if msg.Headers.MessageID != "replayed" || msg.Headers.CausationID != "m-1" || msg.Headers.CorrelationID != "conv" || msg.Headers.DeliveryCount != 0 {
+			t.Fatalf("unexpected headers %+v", msg.Headers)
+		}
+
+		sent := orders.WaitFor(t, 1)
+		if sent[0].Headers.MessageID != "replayed" || sent[0].Payload != 1 {
+			t.Fatalf("unexpected sent message %+v", sent[0])
+		}
+
+		_, err = store.Get(context.Background(), id)
+		if !errors.Is(err, ErrStoreNotFound) {
+			t.Fatalf("expected the entry deleted, got %v", err)
+		}
+	})
+
+	t.Run("unknown id returns ErrStoreNotFound", func(t *testing.T) {
+		t.Parallel()
+
+		store, registry, _, _ := setup(t)
+
+		_, err := ReplayDeadLetter(context.Background(), store, registry, fixedUID("x"), "missing")
+		if !errors.Is(err, ErrStoreNotFound) {
+			t.Fatalf("expected ErrStoreNotFound, got %v", err)
+		}
+	})
+
+	t.Run("unresolvable channel keeps the entry", func(t *testing.T) {
+		t.Parallel()
+
+		store, _, _, id := setup(t)
+
+		_, err := ReplayDeadLetter(context.Background(), store, messaging.NewChannelRegistry(), fixedUID("x"), id)
+		if !errors.Is(err, ErrReplayFailed) || !errors.Is(err, messaging.ErrChannelNotFound) {
+			t.Fatalf("expected ErrReplay wrapping ErrChannelNotFound, got %v", err)
+		}
+
+		_, err = store.Get(context.Background(), id)
+		if err != nil {
+			t.Fatalf("expected the entry kept, got %v", err)
+		}
+	})
+
+	t.Run("nil or failing uid returns ErrMessageIDFailed", func(t *testing.T) {
+		t.Parallel()
+
+		store, registry, _, id := setup(t)
+
+		_, err := ReplayDeadLetter(context.Background(), store, registry, nil, id)
+		if !errors.Is(err, ErrMessageIDFailed) {
+			t.Fatalf("expected ErrMessageIDFailed, got %v", err)
+		}
+
+		failing := cuids.NewUID("failing", func() (string, error) { return "", errors.New("entropy") })
+
+		_, err = ReplayDeadLetter(context.Background(), store, registry, failing, id)
+		if !errors.Is(err, ErrMessageIDFailed) || !errors.Is(err, ErrReplayFailed) {
+			t.Fatalf("expected ErrReplay wrapping ErrMessageIDFailed, got %v", err)
+		}
+	})
+
+	t.Run("failed send keeps the entry", func(t *testing.T) {
+		t.Parallel()
+
+		store, registry, orders, id := setup(t)
+		boom := errors.New("down")
+
+		_, _ = orders.Subscribe(func(context.Context, messaging.Message[int]) error { return boom })
+
+		_, err := ReplayDeadLetter(context.Background(), store, registry, fixedUID("x"), id)
+		if !errors.Is(err, boom) || !errors.Is(err, ErrReplayFailed) {
+			t.Fatalf("expected ErrReplay wrapping the send error, got %v", err)
+		}
+
+		_, err = store.Get(context.Background(), id)
+		if err != nil {
+			t.Fatalf("expected the entry kept, got %v", err)
+		}
+	})
+}
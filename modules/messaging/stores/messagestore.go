@@ -2,6 +2,8 @@ package stores
 
 import (
 	"context"
+	"maps"
+	"slices"
 	"sync"
 
 	cassert "github.com/guidomantilla/yarumo/core/common/assert"
@@ -87,3 +89,19 @@ func (s *inMemoryMessageStore[T]) Delete(ctx context.Context, key string) error
 
 	return nil
 }
+
+// Keys returns every key currently stored, sorted. ctx is honored only
+// for its cancellation signal, as in Put.
+func (s *inMemoryMessageStore[T]) Keys(ctx context.Context) ([]string, error) {
+	cassert.NotNil(s, "in-memory message store is nil")
+
+	err := ctx.Err()
+	if err != nil {
+		return nil, ErrStore(err)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return slices.Sorted(maps.Keys(s.data)), nil
+}
@@ -231,6 +231,56 @@ func TestInMemoryMessageStore_Delete(t *testing.T) {
 	})
 }
 
+func TestInMemoryMessageStore_Keys(t *testing.T) {
+	t.Parallel()
+
+	t.Run("lists the stored keys", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewInMemoryMessageStore[int]()
+
+		for _, key := range []string{"b", "a", "c"} {
+			err := s.Put(context.Background(), key, messaging.Message[int]{Payload: 1})
+			if err != nil {
+				t.Fatalf("put: %v", err)
+			}
+		}
+
+		err := s.Delete(context.Background(), "c")
+		if err != nil {
+			t.Fatalf("delete: %v", err)
+		}
+
+		lister, ok := s.(KeyLister)
+		if !ok {
+			t.Fatal("in-memory store should implement KeyLister")
+		}
+
+		keys, err := lister.Keys(context.Background())
+		if err != nil {
+			t.Fatalf("keys: %v", err)
+		}
+
+		if len(keys) != 2 || keys[0] != "a" || keys[1] != "b" {
+			t.Fatalf("expected [a b], got %v", keys)
+		}
+	})
+
+	t.Run("returns ErrStore when ctx is already cancelled", func(t *testing.T) {
+		t.Parallel()
+
+		lister, _ := NewInMemoryMessageStore[int]().(KeyLister)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := lister.Keys(ctx)
+		if !errors.Is(err, ErrStoreFailed) {
+			t.Fatalf("expected ErrStoreFailed, got %v", err)
+		}
+	})
+}
+
 func TestInMemoryMessageStore_Concurrent(t *testing.T) {
 	t.Parallel()
 
@@ -8,6 +8,7 @@ import (
 
 	cassert "github.com/guidomantilla/yarumo/core/common/assert"
 	"github.com/guidomantilla/yarumo/core/common/lifecycle"
+	"github.com/guidomantilla/yarumo/messaging"
 )
 
 // inMemoryMetadataStore is the canonical MetadataStore backed by a
@@ -19,6 +20,7 @@ import (
 type inMemoryMetadataStore struct {
 	name          string
 	sweepInterval time.Duration
+	clock         messaging.Clock
 
 	mu     sync.RWMutex
 	expiry map[string]time.Time
@@ -49,6 +51,7 @@ func NewInMemoryMetadataStore(name string, opts ...Option) MetadataStore {
 	return &inMemoryMetadataStore{
 		name:          name,
 		sweepInterval: options.sweepInterval,
+		clock:         options.clock,
 		expiry:        map[string]time.Time{},
 		stopCh:        make(chan struct{}),
 		done:          make(chan struct{}),
@@ -136,7 +139,7 @@ func (s *inMemoryMetadataStore) Has(_ context.Context, key string) (bool, error)
 		return false, nil
 	}
 
-	if time.Now().After(expiresAt) {
+	if s.clock.Now().After(expiresAt) {
 		return false, nil
 	}
 
@@ -161,11 +164,41 @@ func (s *inMemoryMetadataStore) Add(_ context.Context, key string, ttl time.Dura
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	s.expiry[key] = time.Now().Add(ttl)
+	s.expiry[key] = s.clock.Now().Add(ttl)
 
 	return nil
 }
 
+// AddIfAbsent records key with the given TTL when it is absent or
+// expired and reports whether it did, under a single write lock. It
+// returns ErrStore(ErrInvalidTTL) when ttl is non-positive and
+// ErrStore(ErrStoreClosed) when invoked after Stop.
+func (s *inMemoryMetadataStore) AddIfAbsent(_ context.Context, key string, ttl time.Duration) (bool, error) {
+	cassert.NotNil(s, "in-memory metadata store is nil")
+
+	if ttl <= 0 {
+		return false, ErrStore(ErrInvalidTTL)
+	}
+
+	if s.closed.Load() {
+		return false, ErrStore(ErrStoreClosed)
+	}
+
+	now := s.clock.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	expiresAt, ok := s.expiry[key]
+	if ok && !now.After(expiresAt) {
+		return false, nil
+	}
+
+	s.expiry[key] = now.Add(ttl)
+
+	return true, nil
+}
+
 // awaitDrain closes done exactly once after the sweeper goroutine
 // has exited.
 func (s *inMemoryMetadataStore) awaitDrain() {
@@ -176,14 +209,14 @@ func (s *inMemoryMetadataStore) awaitDrain() {
 // sweepLoop runs in its own goroutine and evicts expired entries on
 // each tick of the sweep interval. It exits when stopCh is closed.
 func (s *inMemoryMetadataStore) sweepLoop() {
-	ticker := time.NewTicker(s.sweepInterval)
+	ticker := s.clock.NewTicker(s.sweepInterval)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-s.stopCh:
 			return
-		case <-ticker.C:
+		case <-ticker.C():
 			s.sweep()
 		}
 	}
@@ -194,7 +227,7 @@ func (s *inMemoryMetadataStore) sweepLoop() {
 // store with many entries this is O(n) per sweep — pick the sweep
 // interval accordingly.
 func (s *inMemoryMetadataStore) sweep() {
-	now := time.Now()
+	now := s.clock.Now()
 
 	s.mu.Lock()
 	defer s.mu.Unlock()
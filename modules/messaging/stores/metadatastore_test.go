@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/guidomantilla/yarumo/core/common/lifecycle"
+	"github.com/guidomantilla/yarumo/messaging/messagingtest"
 )
 
 func TestNewInMemoryMetadataStore(t *testing.T) {
@@ -58,6 +59,17 @@ func TestNewInMemoryMetadataStore(t *testing.T) {
 		}
 	})
 
+	t.Run("honors WithClock", func(t *testing.T) {
+		t.Parallel()
+
+		clock := messagingtest.NewFakeClock(time.Time{})
+
+		s := NewInMemoryMetadataStore("test", WithClock(clock), WithClock(nil)).(*inMemoryMetadataStore)
+		if s.clock != clock {
+			t.Fatalf("expected the fake clock, got %T", s.clock)
+		}
+	})
+
 	t.Run("WithSweepInterval(0) preserves default", func(t *testing.T) {
 		t.Parallel()
 
@@ -110,14 +122,15 @@ func TestInMemoryMetadataStore_Add(t *testing.T) {
 	t.Run("refreshes TTL when key already exists", func(t *testing.T) {
 		t.Parallel()
 
-		s := startedStore(t, WithSweepInterval(10*time.Millisecond))
+		clock := messagingtest.NewFakeClock(time.Time{})
+		s := startedStore(t, WithSweepInterval(10*time.Millisecond), WithClock(clock))
 
 		err := s.Add(context.Background(), "k", 30*time.Millisecond)
 		if err != nil {
 			t.Fatalf("first add: %v", err)
 		}
 
-		time.Sleep(20 * time.Millisecond)
+		clock.Advance(20 * time.Millisecond)
 
 		err = s.Add(context.Background(), "k", 100*time.Millisecond)
 		if err != nil {
@@ -125,7 +138,7 @@ func TestInMemoryMetadataStore_Add(t *testing.T) {
 		}
 
 		// Past the original TTL deadline; still present because of refresh.
-		time.Sleep(25 * time.Millisecond)
+		clock.Advance(25 * time.Millisecond)
 
 		ok, err := s.Has(context.Background(), "k")
 		if err != nil {
@@ -161,14 +174,15 @@ func TestInMemoryMetadataStore_Has(t *testing.T) {
 
 		// Long sweep interval so the per-call freshness check is what
 		// is being exercised, not the background sweeper.
-		s := startedStore(t, WithSweepInterval(time.Hour))
+		clock := messagingtest.NewFakeClock(time.Time{})
+		s := startedStore(t, WithSweepInterval(time.Hour), WithClock(clock))
 
 		err := s.Add(context.Background(), "k", 10*time.Millisecond)
 		if err != nil {
 			t.Fatalf("add: %v", err)
 		}
 
-		time.Sleep(30 * time.Millisecond)
+		clock.Advance(30 * time.Millisecond)
 
 		ok, err := s.Has(context.Background(), "k")
 		if err != nil {
@@ -183,7 +197,9 @@ func TestInMemoryMetadataStore_Has(t *testing.T) {
 	t.Run("sweeper reclaims expired entries", func(t *testing.T) {
 		t.Parallel()
 
-		raw := NewInMemoryMetadataStore("test", WithSweepInterval(10*time.Millisecond)).(*inMemoryMetadataStore)
+		clock := messagingtest.NewFakeClock(time.Time{})
+
+		raw := NewInMemoryMetadataStore("test", WithSweepInterval(10*time.Millisecond), WithClock(clock)).(*inMemoryMetadataStore)
 		err := raw.Start(context.Background())
 		if err != nil {
 			t.Fatalf("start: %v", err)
@@ -201,22 +217,131 @@ func TestInMemoryMetadataStore_Has(t *testing.T) {
 			t.Fatalf("add: %v", err)
 		}
 
-		// Wait long enough for the sweeper to run at least one tick
-		// past the TTL.
-		deadline := time.Now().Add(time.Second)
-		for time.Now().Before(deadline) {
+		// One tick past the TTL lets the sweeper evict the entry.
+		clock.WaitForTimers(t, 1)
+		clock.Advance(30 * time.Millisecond)
+
+		messagingtest.WaitUntil(t, func() bool {
 			raw.mu.RLock()
-			n := len(raw.expiry)
-			raw.mu.RUnlock()
+			defer raw.mu.RUnlock()
+
+			return len(raw.expiry) == 0
+		}, "the sweeper to evict the expired entry")
+	})
+}
+
+func TestInMemoryMetadataStore_AddIfAbsent(t *testing.T) {
+	t.Parallel()
+
+	atomicStore := func(t *testing.T, opts ...Option) AtomicMetadataStore {
+		t.Helper()
+
+		s, ok := startedStore(t, opts...).(AtomicMetadataStore)
+		if !ok {
+			t.Fatal("expected AtomicMetadataStore implementation")
+		}
+
+		return s
+	}
+
+	t.Run("records an absent key exactly once", func(t *testing.T) {
+		t.Parallel()
+
+		s := atomicStore(t)
+
+		added, err := s.AddIfAbsent(context.Background(), "k", time.Minute)
+		if err != nil || !added {
+			t.Fatalf("first AddIfAbsent = %v, %v; want true, nil", added, err)
+		}
+
+		added, err = s.AddIfAbsent(context.Background(), "k", time.Minute)
+		if err != nil || added {
+			t.Fatalf("second AddIfAbsent = %v, %v; want false, nil", added, err)
+		}
+
+		ok, err := s.Has(context.Background(), "k")
+		if err != nil || !ok {
+			t.Fatalf("Has = %v, %v; want true, nil", ok, err)
+		}
+	})
+
+	t.Run("records again once the TTL expired", func(t *testing.T) {
+		t.Parallel()
+
+		clock := messagingtest.NewFakeClock(time.Time{})
+		s := atomicStore(t, WithSweepInterval(time.Hour), WithClock(clock))
+
+		_, err := s.AddIfAbsent(context.Background(), "k", 10*time.Millisecond)
+		if err != nil {
+			t.Fatalf("add: %v", err)
+		}
+
+		clock.Advance(30 * time.Millisecond)
+
+		added, err := s.AddIfAbsent(context.Background(), "k", time.Minute)
+		if err != nil || !added {
+			t.Fatalf("AddIfAbsent after expiry = %v, %v; want true, nil", added, err)
+		}
+	})
+
+	t.Run("rejects non-positive TTL with ErrInvalidTTL", func(t *testing.T) {
+		t.Parallel()
 
-			if n == 0 {
-				return
-			}
+		s := atomicStore(t)
 
-			time.Sleep(10 * time.Millisecond)
+		_, err := s.AddIfAbsent(context.Background(), "k", 0)
+		if !errors.Is(err, ErrInvalidTTL) {
+			t.Fatalf("expected ErrInvalidTTL, got %v", err)
 		}
+	})
+
+	t.Run("concurrent callers record a key once", func(t *testing.T) {
+		t.Parallel()
+
+		s := atomicStore(t)
+
+		var (
+			wg    sync.WaitGroup
+			mu    sync.Mutex
+			added int
+		)
+
+		for range 32 {
+			wg.Go(func() {
+				ok, err := s.AddIfAbsent(context.Background(), "k", time.Minute)
+				if err != nil {
+					t.Errorf("AddIfAbsent: %v", err)
+				}
 
-		t.Fatal("sweeper did not evict expired entry in time")
+				if ok {
+					mu.Lock()
+					added++
+					mu.Unlock()
+				}
+			})
+		}
+
+		wg.Wait()
+
+		if added != 1 {
+			t.Fatalf("added = %d, want 1", added)
+		}
+	})
+
+	t.Run("after Stop returns ErrStoreClosed", func(t *testing.T) {
+		t.Parallel()
+
+		s, _ := NewInMemoryMetadataStore("test").(AtomicMetadataStore)
+
+		err := s.(lifecycle.Component).Stop(context.Background())
+		if err != nil {
+			t.Fatalf("stop: %v", err)
+		}
+
+		_, err = s.AddIfAbsent(context.Background(), "k", time.Minute)
+		if !errors.Is(err, ErrStoreClosed) {
+			t.Fatalf("expected ErrStoreClosed, got %v", err)
+		}
 	})
 }
 
@@ -2,6 +2,8 @@ package stores
 
 import (
 	"time"
+
+	"github.com/guidomantilla/yarumo/messaging"
 )
 
 // defaultSweepInterval is the cadence at which the in-memory metadata
@@ -18,16 +20,19 @@ type Option func(opts *Options)
 // package.
 type Options struct {
 	sweepInterval time.Duration
+	clock         messaging.Clock
 }
 
 // NewOptions creates a new Options with sensible defaults and applies
 // the given options. The default sweep interval is one minute — a
 // compromise between memory pressure on a busy dedup store and
 // goroutine wakeups on an idle one. Consumers with short TTLs (sub-
-// second dedup windows) should pass WithSweepInterval to match.
+// second dedup windows) should pass WithSweepInterval to match. The
+// clock is messaging.SystemClock.
 func NewOptions(opts ...Option) *Options {
 	options := &Options{
 		sweepInterval: defaultSweepInterval,
+		clock:         messaging.SystemClock(),
 	}
 
 	for _, opt := range opts {
@@ -54,3 +59,15 @@ func WithSweepInterval(interval time.Duration) Option {
 		}
 	}
 }
+
+// WithClock sets the time source the in-memory MetadataStore computes
+// expirations and drives its sweeper with. Tests pass a
+// messagingtest.FakeClock to expire keys without sleeping. Nil values
+// are ignored (the system clock is preserved).
+func WithClock(clock messaging.Clock) Option {
+	return func(opts *Options) {
+		if clock != nil {
+			opts.clock = clock
+		}
+	}
+}
@@ -2,7 +2,7 @@
 // messaging EIP patterns and a canonical in-memory backend for each
 // contract.
 //
-// Two interfaces live here:
+// Three interfaces live here:
 //
 //   - MessageStore[T] persists full Message[T] envelopes keyed by an
 //     opaque string identifier. It is the storage primitive the Claim
@@ -15,6 +15,9 @@
 //     (YA-0228) uses for MessageID dedup history: the receiver checks
 //     whether it has seen a message before, and if not, records it
 //     with a TTL so the dedup window is bounded.
+//   - DeadLetterStore[T] persists the messaging.DeadLetter[T] envelopes
+//     a channel publishes through messaging.WithDLQChannel, so they can
+//     be browsed, filtered, deleted and replayed after the fact.
 //
 // # In-memory canonical implementations
 //
@@ -29,6 +32,21 @@
 //     sweeper, the in-memory metadata store implements
 //     common/lifecycle.Component (worker-style): Start spawns the
 //     sweeper; Stop drains it.
+//   - NewInMemoryDeadLetterStore[T] returns a DeadLetterStore[T] backed
+//     by a mutex-guarded map. Like the message store it is passive.
+//
+// # Dead letters
+//
+// A DLQ channel carries DeadLetter[T] but not the name of the channel
+// the letter came from, so the store is fed per origin channel:
+// RecordDeadLetters(store, "orders") is the Handler to subscribe on the
+// DLQ channel passed to the "orders" channel's WithDLQChannel.
+// ReplayDeadLetter sends a stored letter back to its origin channel,
+// resolved by name through a messaging.ChannelRegistry, as a new
+// message (fresh Headers.MessageID, the original MessageID as
+// Headers.CausationID, DeliveryCount reset) and deletes the entry once
+// the send succeeded. The control bus exposes the same operations as
+// verbs (see patterns/sysmgmt/controlbus.DeadLetterHandlers).
 //
 // Heavy-dep backends (Redis, Postgres, S3, …) belong in
 // extension/messaging/stores/<backend>/ — they get their own go-module
@@ -55,11 +73,16 @@ import (
 // error factories match their declared Fn aliases.
 var (
 	_ MessageStore[any]   = (*inMemoryMessageStore[any])(nil)
+	_ KeyLister           = (*inMemoryMessageStore[any])(nil)
 	_ MetadataStore       = (*inMemoryMetadataStore)(nil)
+	_ AtomicMetadataStore = (*inMemoryMetadataStore)(nil)
 	_ lifecycle.Component = (*inMemoryMetadataStore)(nil)
 
+	_ DeadLetterStore[any] = (*inMemoryDeadLetterStore[any])(nil)
+
 	_ ErrStoreFn    = ErrStore
 	_ ErrNotFoundFn = ErrNotFound
+	_ ErrReplayFn   = ErrReplay
 )
 
 // MessageStore persists full Message[T] envelopes keyed by an opaque
@@ -93,6 +116,17 @@ type MessageStore[T any] interface {
 	Delete(ctx context.Context, key string) error
 }
 
+// KeyLister is an optional MessageStore capability: a store that can
+// enumerate the keys it currently holds. Consumers detect it with a
+// type assertion; the Saga uses it on Start to rebuild the deadlines
+// of the instances a previous process left running. The in-memory
+// backend implements it.
+type KeyLister interface {
+	// Keys returns every key currently stored, in no particular
+	// order.
+	Keys(ctx context.Context) ([]string, error)
+}
+
 // MetadataStore records boolean presence of a key with TTL.
 // Implementations must be safe for concurrent use by multiple
 // goroutines.
@@ -121,8 +155,79 @@ type MetadataStore interface {
 	Add(ctx context.Context, key string, ttl time.Duration) error
 }
 
+// AtomicMetadataStore is a MetadataStore that can check and record a
+// key in a single atomic step. Has followed by Add leaves a window in
+// which two replicas both observe a key as absent; AddIfAbsent closes
+// it. Consumers such as the Idempotent Receiver detect the capability
+// with a type assertion and fall back to Has + Add otherwise.
+type AtomicMetadataStore interface {
+	MetadataStore
+	// AddIfAbsent records key with the given TTL only when it is not
+	// currently present, and reports whether it did. A present key
+	// keeps its TTL. ttl must be positive; implementations are free to
+	// reject non-positive values via ErrStore(ErrInvalidTTL).
+	AddIfAbsent(ctx context.Context, key string, ttl time.Duration) (bool, error)
+}
+
+// DeadLetterStore persists dead letters together with the name of the
+// channel that dead-lettered them. Implementations must be safe for
+// concurrent use by multiple goroutines.
+//
+// Entries are addressed by an ID assigned on Put. Backends that cannot
+// keep error identity across serialization (anything out of process)
+// store LastError by its message; DeadLetterFilter.ErrorContains works
+// against every backend, DeadLetterFilter.Error only where identity
+// survives.
+type DeadLetterStore[T any] interface {
+	// Put persists letter as dead-lettered by channel and returns the
+	// ID of the new entry.
+	Put(ctx context.Context, channel string, letter messaging.DeadLetter[T]) (string, error)
+	// Get returns the entry stored under id, or an error wrapping
+	// ErrStoreNotFound.
+	Get(ctx context.Context, id string) (DeadLetterEntry[T], error)
+	// List returns the entries matching filter, oldest failure first.
+	List(ctx context.Context, filter DeadLetterFilter) ([]DeadLetterEntry[T], error)
+	// Delete removes the entry stored under id. Delete is idempotent.
+	Delete(ctx context.Context, id string) error
+}
+
+// DeadLetterEntry is a stored dead letter.
+type DeadLetterEntry[T any] struct {
+	// ID identifies the entry within its store.
+	ID string
+	// Channel is the name of the channel that dead-lettered the
+	// message; ReplayDeadLetter sends the message back to it.
+	Channel string
+	// Letter is the dead letter as published on the DLQ channel.
+	Letter messaging.DeadLetter[T]
+	// StoredAt is when the store recorded the entry.
+	StoredAt time.Time
+}
+
+// DeadLetterFilter selects entries in DeadLetterStore.List. Zero fields
+// do not filter, so the zero value lists everything.
+type DeadLetterFilter struct {
+	// Channel keeps the entries dead-lettered by this channel.
+	Channel string
+	// Error keeps the entries whose LastError matches it under
+	// errors.Is (e.g. messaging.ErrExpired).
+	Error error
+	// ErrorContains keeps the entries whose LastError message contains
+	// this text.
+	ErrorContains string
+	// MinAge keeps the entries that failed at least this long ago.
+	MinAge time.Duration
+	// MaxAge keeps the entries that failed at most this long ago.
+	MaxAge time.Duration
+	// Limit caps the number of entries returned.
+	Limit int
+}
+
 // ErrStoreFn is the function type for ErrStore.
 type ErrStoreFn func(causes ...error) error
 
 // ErrNotFoundFn is the function type for ErrNotFound.
 type ErrNotFoundFn func(causes ...error) error
+
+// ErrReplayFn is the function type for ErrReplay.
+type ErrReplayFn func(causes ...error) error
@@ -24,13 +24,20 @@
 //     robin among the registered handlers. Buffered with a worker
 //     pool (WithWorkerCount). Implements lifecycle.Component.
 //     Use for work distribution among equivalent workers.
+//   - DurableQueueChannel[T] (via NewDurableQueueChannel): QueueChannel
+//     semantics backed by a segmented write-ahead log on local disk.
+//     Every accepted message is persisted before Send returns and
+//     acknowledged after dispatch; unacknowledged messages are
+//     replayed on the next Start (at-least-once). Durability knobs:
+//     WithSegmentSize, WithSegmentRetention, WithFsyncPolicy.
 //   - NullChannel[T]: sink that drops every message and fires the
 //     ErrorHandler hook with ErrDropped. Useful as a test double or
 //     to wire a disabled flow explicitly.
 //   - PollableChannel[T] (via NewPollableChannel): pull-based channel
 //     where consumers call Receive instead of subscribing. Bounded
 //     buffer; Send blocks on full / Receive blocks on empty until ctx
-//     or Close. No lifecycle.
+//     or Close. ReceiveWithLease adds at-least-once consumption (see
+//     Leases). No lifecycle.
 //   - ScheduledChannel[T] (via NewScheduledChannel): delayed delivery
 //     primitive. Send delivers ASAP; SendAt / SendAfter defer to a
 //     wall-clock deadline or relative delay via an internal min-heap
@@ -48,7 +55,8 @@
 //     handlers in the caller's goroutine and pass the ctx given to
 //     Send straight through. Deadline, cancellation and values from
 //     the publisher all reach the handler.
-//   - Asynchronous channels (TopicChannel, QueueChannel) decouple
+//   - Asynchronous channels (TopicChannel, QueueChannel,
+//     DurableQueueChannel) decouple
 //     publisher and consumer lifetimes. The handler ctx merges the
 //     worker's lifecycle ctx (the one passed to Start) with the
 //     publisher's Send ctx: Done / Deadline / Err follow the worker
@@ -59,9 +67,16 @@
 //     request-to-handler correlation when ctx-based cancellation
 //     propagation is undesirable for the async pattern.
 //
+// The merged ctx only carries trace spans in-process. To keep a trace
+// across a serializing driver (codec, outbox, Redis Streams), decorate
+// the channel with extension/telemetry/otel/messaging, which injects
+// the trace context into Headers.Custom on Send and extracts it before
+// each handler.
+//
 // # Overflow policy (async channels)
 //
-// TopicChannel and QueueChannel honor a configurable OverflowPolicy
+// TopicChannel, QueueChannel and DurableQueueChannel honor a
+// configurable OverflowPolicy
 // (see WithOverflowPolicy) selecting what happens when Send finds the
 // internal buffer at capacity:
 //
@@ -75,9 +90,84 @@
 //     ErrorHandler hook fires with ErrOverflow joined with ErrDropped;
 //     useful for telemetry / metrics where eviction is acceptable.
 //
-// Scope: in-process only. Broker drivers and outbox patterns will be
-// added later under the same Channel[T] shape; this module owns no
-// external transport dependencies beyond the standard library.
+// # Dispatch order (async channels)
+//
+// TopicChannel and QueueChannel workers take buffered messages in
+// arrival order by default (DispatchFIFO). WithDispatchOrder(
+// DispatchPriority) switches the buffer to a priority queue keyed by
+// Headers.Priority: the highest-priority pending message is dispatched
+// next, waiting messages gain one level per WithPriorityAging interval
+// so low priorities are never starved, and OverflowDropOldest evicts
+// the lowest-priority message first.
+//
+// # Expiration
+//
+// A message whose Headers.ExpirationTime has passed when it is
+// dequeued is never dispatched. TopicChannel, QueueChannel,
+// ScheduledChannel and DurableQueueChannel check at dispatch time;
+// PollableChannel.Receive skips expired messages. The message goes to
+// the WithExpiredHandler hook (the ErrorHandler when none is installed)
+// with ErrExpired joined with ErrDropped, and to the WithDLQChannel DLQ
+// as a DeadLetter whose LastError is ErrExpired. The buffering patterns
+// (aggregator, resequencer, barrier, delayer) apply the same check when
+// they release messages. IsExpired exposes the predicate.
+//
+// # Partitioning (QueueChannel)
+//
+// QueueChannel workers compete for messages, so two messages of the
+// same aggregate can be handled concurrently and out of order.
+// WithPartitioning (or WithPartitionKey) gives each worker its own
+// lane and routes every message to the lane its key hashes to: the
+// messages of one key are handled one at a time in arrival order while
+// different keys still run in parallel. The key defaults to
+// Headers.CorrelationID (PartitionByCorrelationID); PartitionByHeader
+// keys on a Headers.Custom entry. Lanes are spread over the live
+// subscribers and rebalance as subscribers join or cancel. A message
+// rescheduled by WithRedeliveryPolicy rejoins its lane behind the
+// messages sent meanwhile, so redelivery trades ordering for retries.
+//
+// # Load balancing (QueueChannel)
+//
+// Outside partitioning, every message goes to the subscriber a
+// LoadBalancer picks (WithLoadBalancer): RoundRobin by default,
+// Weighted, LeastInFlight, Random or StickyByKey. Subscribers
+// registered with SubscribeWith may declare a weight
+// (WithSubscriptionWeight) and a capacity (WithSubscriptionCapacity);
+// a subscriber at its capacity is skipped, so a pool mixing fast and
+// slow handlers keeps the fast ones busy instead of queueing work
+// behind the slow ones.
+//
+// # Leases (PollableChannel)
+//
+// Receive hands the message over for good: a consumer that crashes
+// before finishing loses it. ReceiveWithLease instead returns a Lease
+// that keeps the message invisible to other consumers for the
+// visibility timeout (WithVisibilityTimeout). Ack settles it; Nack
+// puts it back after a requeue delay; Extend pushes the deadline out
+// for long work. A lease that runs out is requeued automatically.
+// Every leased delivery stamps Headers.DeliveryCount, and with
+// WithMaxDeliveries a message that would be delivered once more goes
+// to the WithDLQChannel DLQ with ErrMaxDeliveries instead. A message
+// that cannot be requeued (the buffer is full or the channel is
+// closed) is dead-lettered with ErrRedeliveryFailed.
+//
+// # Redelivery
+//
+// By default a failing TopicChannel or QueueChannel handler goes
+// straight to the ErrorHandler and the DLQ. WithRedeliveryPolicy
+// retries it first: the message is rescheduled on an internal delay
+// heap with the configured backoff (so the worker moves on), handed
+// back to the queue or to the failing topic subscriber when due, and
+// stamped with Headers.DeliveryCount on every delivery. The DeadLetter
+// is only published once the attempts are exhausted, RetryIf rejects
+// the error, or the redelivery cannot happen (ErrRedeliveryFailed).
+//
+// Scope: in-process only; DurableQueueChannel adds crash-safety through
+// the local filesystem but no cross-process transport. Cross-process
+// channels live in extension/messaging/redis (Redis Streams, under the
+// same Channel[T] and PollableChannel[T] shapes) and the transactional
+// outbox in extension/messaging/outbox. This module owns no external
+// transport dependencies beyond the standard library.
 package messaging
 
 import (
@@ -91,12 +181,33 @@ var (
 	_ Channel[any] = (*topic[any])(nil)
 	_ Channel[any] = (*queue[any])(nil)
 	_ Channel[any] = (*null[any])(nil)
+	_ Channel[any] = (*durable[any])(nil)
 
 	_ PollableChannel[any]  = (*pollable[any])(nil)
+	_ Lease[any]            = (*lease[any])(nil)
 	_ ScheduledChannel[any] = (*scheduled[any])(nil)
 
+	_ Inspectable = (*pipeline[any])(nil)
+	_ Inspectable = (*broadcast[any])(nil)
+	_ Inspectable = (*topic[any])(nil)
+	_ Inspectable = (*queue[any])(nil)
+	_ Inspectable = (*null[any])(nil)
+	_ Inspectable = (*durable[any])(nil)
+	_ Inspectable = (*pollable[any])(nil)
+	_ Inspectable = (*scheduled[any])(nil)
+
+	_ ChannelRegistry = (*registry)(nil)
+
+	_ Clock  = systemClock{}
+	_ Timer  = (*systemTimer)(nil)
+	_ Ticker = (*systemTicker)(nil)
+
 	_ ErrorHandler = DefaultErrorHandler
 	_ ErrorHandler = SilentErrorHandler
+
+	_ PartitionKeyFn = PartitionByCorrelationID
+
+	_ ChannelInterceptor[any] = InterceptorFuncs[any]{}
 )
 
 // Handler is the function type for a message handler. The Handler
@@ -128,6 +239,87 @@ type Cancel func()
 // must opt out by installing SilentErrorHandler explicitly.
 type ErrorHandler func(ctx context.Context, msg any, err error)
 
+// Clock is the time source of the time-based components:
+// ScheduledChannel, PollableChannel leases and, through their own
+// WithClock options, the delayer, aggregator and resequencer patterns
+// and the in-memory MetadataStore. SystemClock, the default, reads the
+// wall clock; messagingtest.FakeClock only moves when a test advances
+// it, so time-based flows can be tested without sleeping.
+//
+// Implementations must be safe for concurrent use.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// NewTimer returns a Timer that delivers on its C channel once d
+	// has elapsed.
+	NewTimer(d time.Duration) Timer
+	// NewTicker returns a Ticker that delivers on its C channel every
+	// d. d must be positive.
+	NewTicker(d time.Duration) Ticker
+	// AfterFunc returns a Timer that calls f in its own goroutine once
+	// d has elapsed. Its C channel is nil.
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Timer is the Clock counterpart of *time.Timer.
+type Timer interface {
+	// C returns the channel the timer delivers on.
+	C() <-chan time.Time
+	// Stop prevents the timer from firing and reports whether it was
+	// still pending.
+	Stop() bool
+	// Reset re-arms the timer to fire after d and reports whether it
+	// was still pending.
+	Reset(d time.Duration) bool
+}
+
+// Ticker is the Clock counterpart of *time.Ticker.
+type Ticker interface {
+	// C returns the channel the ticks are delivered on.
+	C() <-chan time.Time
+	// Stop turns the ticker off.
+	Stop()
+}
+
+// PartitionKeyFn extracts the partition key of a message from its
+// headers. A partitioned QueueChannel (see WithPartitionKey) delivers
+// the messages that share a key in order, one at a time. An empty key
+// opts the message out of ordering: it goes to any lane.
+type PartitionKeyFn func(headers Headers) string
+
+// LoadBalancer selects the subscriber of a QueueChannel that receives
+// the next message (see WithLoadBalancer). The channel calls Select
+// with the message headers and the subscribers that can take a message
+// right now, in subscription order — subscribers at their declared
+// capacity (see WithSubscriptionCapacity) are left out — and dispatches
+// to the one at the returned index. An out-of-range index selects the
+// first candidate, and so does a panic, which is recovered and reported
+// to the ErrorHandler with ErrLoadBalancerPanic. candidates is never
+// empty and must not be retained.
+//
+// The stock strategies are RoundRobin (the default), Weighted,
+// LeastInFlight, Random and StickyByKey. Implementations may keep
+// state but must be safe for concurrent use.
+type LoadBalancer interface {
+	// Select returns the index in candidates of the subscriber that
+	// receives the message carrying headers.
+	Select(headers Headers, candidates []SubscriberState) int
+}
+
+// SubscriberState describes one subscriber to a LoadBalancer.
+type SubscriberState struct {
+	// ID identifies the subscription for as long as it is registered.
+	ID uint64
+	// Weight is the declared weight (see WithSubscriptionWeight); 1
+	// unless declared otherwise.
+	Weight int
+	// Capacity is the declared maximum of concurrent messages (see
+	// WithSubscriptionCapacity); 0 means unbounded.
+	Capacity int
+	// InFlight is the number of messages the subscriber is handling.
+	InFlight int
+}
+
 // Channel defines the contract for an in-process typed message channel.
 //
 // Implementations dispatch published Message[T] envelopes to all
@@ -160,6 +352,53 @@ type Channel[T any] interface {
 	Subscribe(handler Handler[T]) (Cancel, error)
 }
 
+// OptionSubscriber is implemented by the channels that honor
+// per-subscription options (QueueChannel). Call SubscribeWith instead
+// of asserting it directly: it falls back to Subscribe for the other
+// channels.
+type OptionSubscriber[T any] interface {
+	// SubscribeWith is Subscribe with the given SubscriptionOptions
+	// applied to the new subscription.
+	SubscribeWith(handler Handler[T], opts ...SubscriptionOption) (Cancel, error)
+}
+
+// ChannelInterceptor applies cross-cutting behavior to every message a
+// channel sends and delivers, installed through WithInterceptors. The
+// channels of this package run the hooks in this order:
+//
+//   - PreSend before the channel accepts a message. It may return a
+//     modified message, which is what the channel then sends, or an
+//     error, which vetoes the Send: Send returns
+//     ErrSend(ErrIntercepted, err) and the message goes nowhere.
+//   - PostSend once the Send outcome is known, with the error Send is
+//     about to return (nil on success). For asynchronous channels that
+//     is the enqueue outcome, not the handling one.
+//   - PreHandle before each handler invocation, redeliveries included.
+//     It may return a modified message for that handler, or an error,
+//     which skips the handler and counts as its failure (joined with
+//     ErrIntercepted) for the ErrorHandler, the DLQ and redelivery.
+//   - AfterHandle once the handler returns, with its error; a panic is
+//     reported as ErrHandlerPanic.
+//
+// Pre hooks run in registration order and post hooks in reverse
+// order, so the first interceptor wraps all the others. A post hook
+// only runs for the interceptors whose pre hook ran. Hooks run on the
+// Send caller's goroutine (PreSend, PostSend) or the dispatching one
+// (PreHandle, AfterHandle) and must be safe for concurrent use. Embed
+// or use InterceptorFuncs to implement only some of the hooks; the
+// interceptors sub-package provides stock ones.
+type ChannelInterceptor[T any] interface {
+	// PreSend inspects, rewrites or vetoes msg before it is sent.
+	PreSend(ctx context.Context, msg Message[T]) (Message[T], error)
+	// PostSend observes the Send outcome.
+	PostSend(ctx context.Context, msg Message[T], err error)
+	// PreHandle inspects, rewrites or vetoes msg before a handler
+	// receives it.
+	PreHandle(ctx context.Context, msg Message[T]) (Message[T], error)
+	// AfterHandle observes the handler outcome.
+	AfterHandle(ctx context.Context, msg Message[T], err error)
+}
+
 // PollableChannel defines a consumer-driven message channel: producers
 // publish via Send and consumers explicitly pull the next message via
 // Receive instead of subscribing a Handler. The shape mirrors Spring
@@ -189,11 +428,39 @@ type PollableChannel[T any] interface {
 	// any remaining buffered messages and then returns the zero
 	// Message[T] with ErrReceive(ErrChannelClosed).
 	Receive(ctx context.Context) (Message[T], error)
+	// ReceiveWithLease is Receive with at-least-once semantics: the
+	// message stays owned by the channel until the returned Lease is
+	// acknowledged, and goes back on the queue when the lease is
+	// nacked or expires. It blocks and fails like Receive.
+	ReceiveWithLease(ctx context.Context) (Lease[T], error)
 	// Close marks the channel as closed for further sends. Buffered
 	// messages remain receivable until drained. Close is idempotent.
 	Close() error
 }
 
+// Lease is a message received through PollableChannel.ReceiveWithLease
+// and the consumer's claim on it until its deadline. Exactly one of
+// Ack, Nack or the deadline settles a lease; once settled, Ack, Nack
+// and Extend return ErrLease(ErrLeaseLost). Implementations are safe
+// for concurrent use.
+type Lease[T any] interface {
+	// Message returns the leased message, with Headers.DeliveryCount
+	// set to the number of times it has been leased.
+	Message() Message[T]
+	// Deadline returns the instant the lease expires unless it is
+	// settled or extended first.
+	Deadline() time.Time
+	// Ack settles the lease: the message is done and is removed for
+	// good.
+	Ack(ctx context.Context) error
+	// Nack settles the lease and puts the message back on the queue
+	// after requeueDelay (immediately when it is not positive).
+	Nack(ctx context.Context, requeueDelay time.Duration) error
+	// Extend moves the deadline to extension from now. A non-positive
+	// extension returns ErrLease(ErrLeaseExtension).
+	Extend(ctx context.Context, extension time.Duration) error
+}
+
 // ScheduledChannel defines a Channel[T] variant with delayed delivery.
 // Messages sent via Send are dispatched as soon as possible (same
 // semantics as a plain async channel); SendAt and SendAfter defer
@@ -218,3 +485,39 @@ type ScheduledChannel[T any] interface {
 	// set as SendAt.
 	SendAfter(ctx context.Context, delay time.Duration, msg Message[T]) error
 }
+
+// ChannelRegistry resolves channel names to channels. The names are
+// the values carried in Headers.ReplyTo, so a responder can publish a
+// reply to whichever channel the requester named (see Reply) and
+// multi-hop request/reply flows can be assembled from configuration
+// instead of wiring every requester/responder pair by hand.
+//
+// Channels are stored type-erased because a registry holds channels
+// of different payload types; ResolveChannel restores the static type
+// at lookup. Implementations are safe for concurrent use.
+type ChannelRegistry interface {
+	// Register binds name to channel, which must be a Channel[T] for
+	// some T. Returns ErrRegistry(ErrChannelNameEmpty) on an empty
+	// name, ErrRegistry(ErrChannelNil) on a nil value,
+	// ErrRegistry(ErrChannelType) on a non-channel value and
+	// ErrRegistry(ErrChannelRegistered) when name is taken.
+	Register(name string, channel any) error
+	// Unregister removes name. Unknown names are a no-op.
+	Unregister(name string)
+	// Resolve returns the channel bound to name, type-erased. Returns
+	// ErrRegistry(ErrChannelNotFound) for unknown names.
+	Resolve(name string) (any, error)
+	// Names returns the registered names in ascending order.
+	Names() []string
+}
+
+// Inspectable is implemented by every channel of this package and by
+// every lifecycle-bearing pattern under patterns/. Stats returns a
+// point-in-time snapshot that is cheap enough to poll (atomic loads
+// plus a short lock for buffer and subscriber sizes). Channel[T] does
+// not embed it so third-party channels stay valid; use StatsOf to
+// query any value.
+type Inspectable interface {
+	// Stats returns the current runtime statistics.
+	Stats() Stats
+}
@@ -0,0 +1,687 @@
+package messaging
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	cerrs "github.com/guidomantilla/yarumo/core/common/errs"
+)
+
+// On-disk layout of a durable log directory. Segments are named after
+// the offset of their first record (zero-padded so lexical order is
+// offset order); the ack index is an append-only list of acknowledged
+// offsets; the checkpoint holds the low watermark below which every
+// offset is acknowledged.
+const (
+	walSegmentExt     = ".seg"
+	walAckFile        = "acks.idx"
+	walCheckpointFile = "checkpoint"
+	walTempSuffix     = ".tmp"
+
+	walFrameHeader = 4 + 4 + 8
+	walMaxRecord   = 1 << 30
+	walFileMode    = 0o600
+	walDirMode     = 0o750
+)
+
+// walSegment describes one on-disk segment file. base is the offset of
+// the first record stored in it; size is the number of bytes written so
+// far (used to decide when to roll the active segment).
+type walSegment struct {
+	base uint64
+	path string
+	size int64
+}
+
+// walRecord is one decoded frame read back during recovery.
+type walRecord struct {
+	offset uint64
+	data   []byte
+}
+
+// wal is the segmented append-only log behind the durable queue
+// channel. Every appended record receives a monotonically increasing
+// offset; consumers acknowledge offsets individually (out of order is
+// fine) and the log tracks the low watermark below which everything is
+// acknowledged. Segments entirely below the watermark are deleted,
+// keeping the configured number of acknowledged segments around for
+// inspection.
+//
+// Frame format (big endian):
+//
+//	[4 length][4 crc32(offset+data)][8 offset][data]
+//
+// A torn or corrupted frame at the tail of the active segment (the
+// signature of a crash mid-write) is truncated on recovery; corruption
+// anywhere else fails recovery with ErrLogCorrupted.
+//
+// wal is safe for concurrent use.
+type wal struct {
+	dir         string
+	segmentSize int64
+	retention   int
+	syncEvery   bool
+
+	mu        sync.Mutex
+	segments  []walSegment
+	active    *os.File
+	acks      *os.File
+	acked     map[uint64]struct{}
+	watermark uint64
+	next      uint64
+	closed    bool
+	failed    error
+}
+
+// openWAL opens (or creates) the log stored in dir, replays every
+// segment and returns the records that were appended but never
+// acknowledged, in offset order. The returned wal is ready to accept
+// appends after the last recovered offset.
+func openWAL(dir string, segmentSize int64, retention int, syncEvery bool) (*wal, []walRecord, error) {
+	err := os.MkdirAll(dir, walDirMode)
+	if err != nil {
+		return nil, nil, cerrs.Wrap(ErrLogIO, err)
+	}
+
+	w := &wal{
+		dir:         dir,
+		segmentSize: segmentSize,
+		retention:   retention,
+		syncEvery:   syncEvery,
+		acked:       map[uint64]struct{}{},
+	}
+
+	records, err := w.recover()
+	if err != nil {
+		w.closeFiles()
+
+		return nil, nil, err
+	}
+
+	return w, records, nil
+}
+
+// recover rebuilds the in-memory index from disk: checkpoint, ack
+// index and every segment. It opens the active segment and the ack
+// index for appending and returns the unacknowledged records.
+func (w *wal) recover() ([]walRecord, error) {
+	checkpoint, err := w.readCheckpoint()
+	if err != nil {
+		return nil, err
+	}
+
+	acked, err := w.readAcks()
+	if err != nil {
+		return nil, err
+	}
+
+	w.segments, err = w.listSegments()
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		pending []walRecord
+		last    = checkpoint
+	)
+
+	for i := range w.segments {
+		records, readErr := w.readSegment(&w.segments[i], i == len(w.segments)-1)
+		if readErr != nil {
+			return nil, readErr
+		}
+
+		last = max(last, w.segments[i].base)
+
+		for _, record := range records {
+			last = max(last, record.offset+1)
+
+			_, ok := acked[record.offset]
+			if record.offset < checkpoint || ok {
+				continue
+			}
+
+			pending = append(pending, record)
+		}
+	}
+
+	w.next = last
+	w.watermark = w.next
+
+	if len(pending) > 0 {
+		w.watermark = max(checkpoint, pending[0].offset)
+	}
+
+	for offset := range acked {
+		if offset >= w.watermark {
+			w.acked[offset] = struct{}{}
+		}
+	}
+
+	err = w.openActive()
+	if err != nil {
+		return nil, err
+	}
+
+	err = w.compact(true)
+	if err != nil {
+		return nil, err
+	}
+
+	return pending, nil
+}
+
+// readCheckpoint returns the persisted low watermark, or zero when no
+// checkpoint has been written yet.
+func (w *wal) readCheckpoint() (uint64, error) {
+	data, err := os.ReadFile(filepath.Join(w.dir, walCheckpointFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+
+	if err != nil {
+		return 0, cerrs.Wrap(ErrLogIO, err)
+	}
+
+	if len(data) != 8 {
+		return 0, cerrs.Wrap(ErrLogCorrupted, fmt.Errorf("checkpoint has %d bytes", len(data)))
+	}
+
+	return binary.BigEndian.Uint64(data), nil
+}
+
+// readAcks loads the ack index. A trailing partial entry (crash while
+// appending) is ignored; it is dropped when the index is rewritten.
+func (w *wal) readAcks() (map[uint64]struct{}, error) {
+	data, err := os.ReadFile(filepath.Join(w.dir, walAckFile))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, cerrs.Wrap(ErrLogIO, err)
+	}
+
+	acked := make(map[uint64]struct{}, len(data)/8)
+	for i := 0; i+8 <= len(data); i += 8 {
+		acked[binary.BigEndian.Uint64(data[i:i+8])] = struct{}{}
+	}
+
+	return acked, nil
+}
+
+// listSegments returns the segment files in dir ordered by base offset.
+func (w *wal) listSegments() ([]walSegment, error) {
+	entries, err := os.ReadDir(w.dir)
+	if err != nil {
+		return nil, cerrs.Wrap(ErrLogIO, err)
+	}
+
+	var segments []walSegment
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, walSegmentExt) {
+			continue
+		}
+
+		base, parseErr := strconv.ParseUint(strings.TrimSuffix(name, walSegmentExt), 10, 64)
+		if parseErr != nil {
+			continue
+		}
+
+		segments = append(segments, walSegment{base: base, path: filepath.Join(w.dir, name)})
+	}
+
+	slices.SortFunc(segments, func(a, b walSegment) int {
+		switch {
+		case a.base < b.base:
+			return -1
+		case a.base > b.base:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	return segments, nil
+}
+
+// readSegment decodes every frame of seg and records its valid size.
+// A bad frame in the tail segment is treated as a torn write and the
+// file is truncated at the last good frame; in any other segment it is
+// reported as ErrLogCorrupted.
+func (w *wal) readSegment(seg *walSegment, tail bool) ([]walRecord, error) {
+	file, err := os.Open(seg.path)
+	if err != nil {
+		return nil, cerrs.Wrap(ErrLogIO, err)
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+
+	var (
+		records []walRecord
+		valid   int64
+	)
+
+	for {
+		record, n, readErr := readFrame(reader)
+		if errors.Is(readErr, io.EOF) {
+			break
+		}
+
+		if readErr != nil {
+			if !tail {
+				return nil, cerrs.Wrap(ErrLogCorrupted, fmt.Errorf("%s at byte %d: %w", seg.path, valid, readErr))
+			}
+
+			truncErr := os.Truncate(seg.path, valid)
+			if truncErr != nil {
+				return nil, cerrs.Wrap(ErrLogIO, truncErr)
+			}
+
+			break
+		}
+
+		records = append(records, record)
+		valid += n
+	}
+
+	seg.size = valid
+
+	return records, nil
+}
+
+// readFrame decodes the next frame from r. It returns io.EOF on a clean
+// end of segment and a descriptive error on a short or corrupted frame.
+func readFrame(r io.Reader) (walRecord, int64, error) {
+	var header [walFrameHeader]byte
+
+	n, err := io.ReadFull(r, header[:])
+	if err != nil {
+		if errors.Is(err, io.EOF) && n == 0 {
+			return walRecord{}, 0, io.EOF
+		}
+
+		return walRecord{}, 0, err
+	}
+
+	length := binary.BigEndian.Uint32(header[0:4])
+	if length < 8 || length > walMaxRecord {
+		return walRecord{}, 0, fmt.Errorf("invalid frame length %d", length)
+	}
+
+	body := make([]byte, length)
+	copy(body, header[8:16])
+
+	_, err = io.ReadFull(r, body[8:])
+	if err != nil {
+		return walRecord{}, 0, err
+	}
+
+	if crc32.ChecksumIEEE(body) != binary.BigEndian.Uint32(header[4:8]) {
+		return walRecord{}, 0, errors.New("checksum mismatch")
+	}
+
+	return walRecord{offset: binary.BigEndian.Uint64(body[:8]), data: body[8:]}, int64(4 + 4 + length), nil
+}
+
+// openActive opens the newest segment for appending (creating the first
+// segment when the directory is empty) and the ack index.
+func (w *wal) openActive() error {
+	if len(w.segments) == 0 {
+		w.segments = append(w.segments, walSegment{base: w.next, path: w.segmentPath(w.next)})
+	}
+
+	tail := w.segments[len(w.segments)-1]
+
+	active, err := os.OpenFile(tail.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, walFileMode)
+	if err != nil {
+		return cerrs.Wrap(ErrLogIO, err)
+	}
+
+	w.active = active
+
+	acks, err := os.OpenFile(filepath.Join(w.dir, walAckFile), os.O_CREATE|os.O_WRONLY|os.O_APPEND, walFileMode)
+	if err != nil {
+		return cerrs.Wrap(ErrLogIO, err)
+	}
+
+	w.acks = acks
+
+	return nil
+}
+
+// segmentPath returns the file path of the segment starting at base.
+func (w *wal) segmentPath(base uint64) string {
+	return filepath.Join(w.dir, fmt.Sprintf("%020d%s", base, walSegmentExt))
+}
+
+// append writes data as a new record and returns its offset. The active
+// segment is rolled first when it has reached the configured size. A
+// record larger than walMaxRecord is refused with ErrRecordTooLarge. A
+// failed write or sync truncates the segment back to its last complete
+// frame so no torn bytes precede later records; when even that fails
+// the log refuses every further append with the original failure.
+func (w *wal) append(data []byte) (uint64, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return 0, ErrClosed
+	}
+
+	if w.failed != nil {
+		return 0, w.failed
+	}
+
+	if 8+len(data) > walMaxRecord {
+		return 0, cerrs.Wrap(ErrRecordTooLarge, fmt.Errorf("%d bytes", len(data)))
+	}
+
+	tail := &w.segments[len(w.segments)-1]
+	if tail.size >= w.segmentSize {
+		err := w.roll()
+		if err != nil {
+			return 0, err
+		}
+
+		tail = &w.segments[len(w.segments)-1]
+	}
+
+	offset := w.next
+
+	frame := make([]byte, walFrameHeader+len(data))
+	binary.BigEndian.PutUint32(frame[0:4], uint32(8+len(data))) //nolint:gosec // bounded by walMaxRecord
+	binary.BigEndian.PutUint64(frame[8:16], offset)
+	copy(frame[16:], data)
+	binary.BigEndian.PutUint32(frame[4:8], crc32.ChecksumIEEE(frame[8:]))
+
+	_, err := w.active.Write(frame)
+	if err == nil && w.syncEvery {
+		err = w.active.Sync()
+	}
+
+	if err != nil {
+		err = cerrs.Wrap(ErrLogIO, err)
+
+		truncErr := w.active.Truncate(tail.size)
+		if truncErr != nil {
+			w.failed = err
+		}
+
+		return 0, err
+	}
+
+	tail.size += int64(len(frame))
+	w.next++
+
+	return offset, nil
+}
+
+// roll seals the active segment and starts a new one at the next
+// offset. Caller must hold w.mu.
+func (w *wal) roll() error {
+	err := w.active.Sync()
+	if err != nil {
+		return cerrs.Wrap(ErrLogIO, err)
+	}
+
+	err = w.active.Close()
+	if err != nil {
+		return cerrs.Wrap(ErrLogIO, err)
+	}
+
+	seg := walSegment{base: w.next, path: w.segmentPath(w.next)}
+
+	active, err := os.OpenFile(seg.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, walFileMode)
+	if err != nil {
+		return cerrs.Wrap(ErrLogIO, err)
+	}
+
+	w.active = active
+	w.segments = append(w.segments, seg)
+
+	return nil
+}
+
+// ack records offset as processed. Offsets may be acknowledged in any
+// order; the watermark advances over the contiguous acknowledged
+// prefix and segments that fall entirely below it are reclaimed.
+func (w *wal) ack(offset uint64) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return ErrClosed
+	}
+
+	if offset < w.watermark {
+		return nil
+	}
+
+	var entry [8]byte
+	binary.BigEndian.PutUint64(entry[:], offset)
+
+	_, err := w.acks.Write(entry[:])
+	if err != nil {
+		return cerrs.Wrap(ErrLogIO, err)
+	}
+
+	if w.syncEvery {
+		err = w.acks.Sync()
+		if err != nil {
+			return cerrs.Wrap(ErrLogIO, err)
+		}
+	}
+
+	w.acked[offset] = struct{}{}
+
+	advanced := false
+
+	for {
+		_, ok := w.acked[w.watermark]
+		if !ok || w.watermark >= w.next {
+			break
+		}
+
+		delete(w.acked, w.watermark)
+		w.watermark++
+		advanced = true
+	}
+
+	if !advanced {
+		return nil
+	}
+
+	return w.compact(false)
+}
+
+// compact deletes the fully acknowledged segments that exceed the
+// retention budget and rewrites the checkpoint + ack index so the index
+// only carries offsets above the watermark. force rewrites the index
+// even when no segment is reclaimed (used on recovery to drop stale
+// entries). Caller must hold w.mu (or own w exclusively).
+func (w *wal) compact(force bool) error {
+	sealed := 0
+
+	for i := 0; i+1 < len(w.segments); i++ {
+		if w.segments[i+1].base > w.watermark {
+			break
+		}
+
+		sealed++
+	}
+
+	reclaim := sealed - w.retention
+	if reclaim <= 0 && !force {
+		return nil
+	}
+
+	err := w.writeCheckpoint()
+	if err != nil {
+		return err
+	}
+
+	for range max(reclaim, 0) {
+		removeErr := os.Remove(w.segments[0].path)
+		if removeErr != nil && !errors.Is(removeErr, os.ErrNotExist) {
+			return cerrs.Wrap(ErrLogIO, removeErr)
+		}
+
+		w.segments = w.segments[1:]
+	}
+
+	return w.rewriteAcks()
+}
+
+// writeCheckpoint atomically persists the watermark (temp file +
+// rename) so recovery can skip every offset below it without consulting
+// the ack index.
+func (w *wal) writeCheckpoint() error {
+	var data [8]byte
+	binary.BigEndian.PutUint64(data[:], w.watermark)
+
+	return writeFileAtomic(filepath.Join(w.dir, walCheckpointFile), data[:])
+}
+
+// rewriteAcks replaces the ack index with the offsets still above the
+// watermark and swaps the append handle over to the new file. The old
+// handle stays usable until the replacement is in place.
+func (w *wal) rewriteAcks() error {
+	offsets := make([]uint64, 0, len(w.acked))
+	for offset := range w.acked {
+		offsets = append(offsets, offset)
+	}
+
+	slices.Sort(offsets)
+
+	data := make([]byte, 8*len(offsets))
+	for i, offset := range offsets {
+		binary.BigEndian.PutUint64(data[i*8:], offset)
+	}
+
+	path := filepath.Join(w.dir, walAckFile)
+
+	err := writeFileAtomic(path, data)
+	if err != nil {
+		return err
+	}
+
+	acks, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, walFileMode)
+	if err != nil {
+		return cerrs.Wrap(ErrLogIO, err)
+	}
+
+	if w.acks != nil {
+		_ = w.acks.Close()
+	}
+
+	w.acks = acks
+
+	return nil
+}
+
+// sync flushes the active segment and the ack index to stable storage.
+func (w *wal) sync() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return nil
+	}
+
+	return w.syncFiles()
+}
+
+// syncFiles flushes both open files. Caller must hold w.mu.
+func (w *wal) syncFiles() error {
+	err := w.active.Sync()
+	if err != nil {
+		return cerrs.Wrap(ErrLogIO, err)
+	}
+
+	err = w.acks.Sync()
+	if err != nil {
+		return cerrs.Wrap(ErrLogIO, err)
+	}
+
+	return nil
+}
+
+// close flushes the log, persists the current watermark and releases
+// the file handles. close is idempotent.
+func (w *wal) close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return nil
+	}
+
+	w.closed = true
+
+	err := w.syncFiles()
+	if err == nil {
+		err = w.writeCheckpoint()
+	}
+
+	w.closeFiles()
+
+	return err
+}
+
+// closeFiles releases the open file handles without syncing. Used on
+// the close path and to clean up after a failed recovery.
+func (w *wal) closeFiles() {
+	if w.active != nil {
+		_ = w.active.Close()
+		w.active = nil
+	}
+
+	if w.acks != nil {
+		_ = w.acks.Close()
+		w.acks = nil
+	}
+}
+
+// writeFileAtomic writes data to path via a synced temp file and a
+// rename, so readers observe either the old or the new content.
+func writeFileAtomic(path string, data []byte) error {
+	tmp := path + walTempSuffix
+
+	file, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, walFileMode)
+	if err != nil {
+		return cerrs.Wrap(ErrLogIO, err)
+	}
+
+	_, err = file.Write(data)
+	if err == nil {
+		err = file.Sync()
+	}
+
+	closeErr := file.Close()
+	if err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		_ = os.Remove(tmp)
+
+		return cerrs.Wrap(ErrLogIO, err)
+	}
+
+	err = os.Rename(tmp, path)
+	if err != nil {
+		return cerrs.Wrap(ErrLogIO, err)
+	}
+
+	return nil
+}
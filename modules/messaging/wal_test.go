@@ -0,0 +1,477 @@
+package messaging
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func walSegmentFiles(t *testing.T, dir string) []string {
+	t.Helper()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir returned %v", err)
+	}
+
+	var names []string
+
+	for _, entry := range entries {
+		if strings.HasSuffix(entry.Name(), walSegmentExt) {
+			names = append(names, entry.Name())
+		}
+	}
+
+	return names
+}
+
+func TestOpenWAL(t *testing.T) {
+	t.Parallel()
+
+	t.Run("creates directory and first segment", func(t *testing.T) {
+		t.Parallel()
+
+		dir := filepath.Join(t.TempDir(), "nested", "log")
+
+		w, records, err := openWAL(dir, 1024, 0, true)
+		if err != nil {
+			t.Fatalf("openWAL returned %v", err)
+		}
+		defer w.close()
+
+		if len(records) != 0 {
+			t.Fatalf("expected no records, got %d", len(records))
+		}
+
+		segments := walSegmentFiles(t, dir)
+		if len(segments) != 1 {
+			t.Fatalf("expected 1 segment, got %v", segments)
+		}
+	})
+
+	t.Run("fails when dir is a file", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "file")
+
+		err := os.WriteFile(path, []byte("x"), 0o600)
+		if err != nil {
+			t.Fatalf("WriteFile returned %v", err)
+		}
+
+		_, _, err = openWAL(path, 1024, 0, true)
+		if !errors.Is(err, ErrLogIO) {
+			t.Fatalf("expected ErrLogIO, got %v", err)
+		}
+	})
+
+	t.Run("rejects malformed checkpoint", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+
+		err := os.WriteFile(filepath.Join(dir, walCheckpointFile), []byte{1, 2, 3}, 0o600)
+		if err != nil {
+			t.Fatalf("WriteFile returned %v", err)
+		}
+
+		_, _, err = openWAL(dir, 1024, 0, true)
+		if !errors.Is(err, ErrLogCorrupted) {
+			t.Fatalf("expected ErrLogCorrupted, got %v", err)
+		}
+	})
+}
+
+func TestWAL_Recovery(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns unacknowledged records in order", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+
+		w, _, err := openWAL(dir, 1024, 0, true)
+		if err != nil {
+			t.Fatalf("openWAL returned %v", err)
+		}
+
+		for _, payload := range []string{"a", "b", "c", "d"} {
+			_, appendErr := w.append([]byte(payload))
+			if appendErr != nil {
+				t.Fatalf("append returned %v", appendErr)
+			}
+		}
+
+		err = w.ack(0)
+		if err != nil {
+			t.Fatalf("ack returned %v", err)
+		}
+
+		err = w.ack(2)
+		if err != nil {
+			t.Fatalf("ack returned %v", err)
+		}
+
+		err = w.close()
+		if err != nil {
+			t.Fatalf("close returned %v", err)
+		}
+
+		reopened, records, err := openWAL(dir, 1024, 0, true)
+		if err != nil {
+			t.Fatalf("openWAL returned %v", err)
+		}
+		defer reopened.close()
+
+		if len(records) != 2 {
+			t.Fatalf("expected 2 records, got %d", len(records))
+		}
+
+		if records[0].offset != 1 || string(records[0].data) != "b" {
+			t.Fatalf("unexpected first record %d %q", records[0].offset, records[0].data)
+		}
+
+		if records[1].offset != 3 || string(records[1].data) != "d" {
+			t.Fatalf("unexpected second record %d %q", records[1].offset, records[1].data)
+		}
+
+		offset, err := reopened.append([]byte("e"))
+		if err != nil {
+			t.Fatalf("append returned %v", err)
+		}
+
+		if offset != 4 {
+			t.Fatalf("expected next offset 4, got %d", offset)
+		}
+	})
+
+	t.Run("truncates torn tail", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+
+		w, _, err := openWAL(dir, 1024, 0, true)
+		if err != nil {
+			t.Fatalf("openWAL returned %v", err)
+		}
+
+		_, err = w.append([]byte("intact"))
+		if err != nil {
+			t.Fatalf("append returned %v", err)
+		}
+
+		_ = w.close()
+
+		path := filepath.Join(dir, walSegmentFiles(t, dir)[0])
+
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o600)
+		if err != nil {
+			t.Fatalf("OpenFile returned %v", err)
+		}
+
+		_, _ = file.Write([]byte{0, 0, 0, 20, 1, 2})
+		_ = file.Close()
+
+		reopened, records, err := openWAL(dir, 1024, 0, true)
+		if err != nil {
+			t.Fatalf("openWAL returned %v", err)
+		}
+		defer reopened.close()
+
+		if len(records) != 1 || string(records[0].data) != "intact" {
+			t.Fatalf("expected the intact record only, got %v", records)
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatalf("Stat returned %v", err)
+		}
+
+		if info.Size() != int64(walFrameHeader+len("intact")) {
+			t.Fatalf("expected truncated size %d, got %d", walFrameHeader+len("intact"), info.Size())
+		}
+	})
+
+	t.Run("fails on corruption in sealed segment", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+
+		w, _, err := openWAL(dir, 1, 0, true)
+		if err != nil {
+			t.Fatalf("openWAL returned %v", err)
+		}
+
+		_, _ = w.append([]byte("first"))
+		_, _ = w.append([]byte("second"))
+		_ = w.close()
+
+		segments := walSegmentFiles(t, dir)
+		if len(segments) != 2 {
+			t.Fatalf("expected 2 segments, got %v", segments)
+		}
+
+		path := filepath.Join(dir, segments[0])
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("ReadFile returned %v", err)
+		}
+
+		data[len(data)-1] ^= 0xff
+
+		err = os.WriteFile(path, data, 0o600)
+		if err != nil {
+			t.Fatalf("WriteFile returned %v", err)
+		}
+
+		_, _, err = openWAL(dir, 1, 0, true)
+		if !errors.Is(err, ErrLogCorrupted) {
+			t.Fatalf("expected ErrLogCorrupted, got %v", err)
+		}
+	})
+
+	t.Run("ignores partial ack entry", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+
+		w, _, err := openWAL(dir, 1024, 0, true)
+		if err != nil {
+			t.Fatalf("openWAL returned %v", err)
+		}
+
+		_, _ = w.append([]byte("a"))
+		_, _ = w.append([]byte("b"))
+		_ = w.ack(1)
+		_ = w.close()
+
+		file, err := os.OpenFile(filepath.Join(dir, walAckFile), os.O_WRONLY|os.O_APPEND, 0o600)
+		if err != nil {
+			t.Fatalf("OpenFile returned %v", err)
+		}
+
+		_, _ = file.Write([]byte{0, 0, 0})
+		_ = file.Close()
+
+		reopened, records, err := openWAL(dir, 1024, 0, true)
+		if err != nil {
+			t.Fatalf("openWAL returned %v", err)
+		}
+		defer reopened.close()
+
+		if len(records) != 1 || records[0].offset != 0 {
+			t.Fatalf("expected offset 0 only, got %v", records)
+		}
+	})
+}
+
+func TestWAL_Compaction(t *testing.T) {
+	t.Parallel()
+
+	t.Run("reclaims acknowledged segments", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+
+		w, _, err := openWAL(dir, 1, 0, true)
+		if err != nil {
+			t.Fatalf("openWAL returned %v", err)
+		}
+		defer w.close()
+
+		for range 4 {
+			_, _ = w.append([]byte("x"))
+		}
+
+		got := len(walSegmentFiles(t, dir))
+		if got != 4 {
+			t.Fatalf("expected 4 segments, got %d", got)
+		}
+
+		for offset := range uint64(3) {
+			err = w.ack(offset)
+			if err != nil {
+				t.Fatalf("ack returned %v", err)
+			}
+		}
+
+		got = len(walSegmentFiles(t, dir))
+		if got != 1 {
+			t.Fatalf("expected 1 segment after compaction, got %d", got)
+		}
+	})
+
+	t.Run("keeps retained segments", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+
+		w, _, err := openWAL(dir, 1, 2, true)
+		if err != nil {
+			t.Fatalf("openWAL returned %v", err)
+		}
+		defer w.close()
+
+		for range 5 {
+			_, _ = w.append([]byte("x"))
+		}
+
+		for offset := range uint64(4) {
+			_ = w.ack(offset)
+		}
+
+		got := len(walSegmentFiles(t, dir))
+		if got != 3 {
+			t.Fatalf("expected 3 segments (2 retained + active), got %d", got)
+		}
+	})
+
+	t.Run("checkpoint skips acknowledged prefix on recovery", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+
+		w, _, err := openWAL(dir, 1024, 0, true)
+		if err != nil {
+			t.Fatalf("openWAL returned %v", err)
+		}
+
+		_, _ = w.append([]byte("a"))
+		_, _ = w.append([]byte("b"))
+		_ = w.ack(0)
+		_ = w.close()
+
+		err = os.Remove(filepath.Join(dir, walAckFile))
+		if err != nil {
+			t.Fatalf("Remove returned %v", err)
+		}
+
+		reopened, records, err := openWAL(dir, 1024, 0, true)
+		if err != nil {
+			t.Fatalf("openWAL returned %v", err)
+		}
+		defer reopened.close()
+
+		if len(records) != 1 || string(records[0].data) != "b" {
+			t.Fatalf("expected record b only, got %v", records)
+		}
+	})
+}
+
+func TestWAL_Append(t *testing.T) {
+	t.Parallel()
+
+	t.Run("rejects oversized record", func(t *testing.T) {
+		t.Parallel()
+
+		w, _, err := openWAL(t.TempDir(), 1024, 0, false)
+		if err != nil {
+			t.Fatalf("openWAL returned %v", err)
+		}
+		defer w.close()
+
+		_, err = w.append(make([]byte, walMaxRecord))
+		if !errors.Is(err, ErrRecordTooLarge) {
+			t.Fatalf("expected ErrRecordTooLarge, got %v", err)
+		}
+
+		offset, err := w.append([]byte("a"))
+		if err != nil || offset != 0 {
+			t.Fatalf("append after rejection returned %d, %v; want 0, nil", offset, err)
+		}
+	})
+
+	t.Run("failed write keeps earlier records recoverable", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+
+		w, _, err := openWAL(dir, 1024, 0, false)
+		if err != nil {
+			t.Fatalf("openWAL returned %v", err)
+		}
+
+		_, err = w.append([]byte("a"))
+		if err != nil {
+			t.Fatalf("append returned %v", err)
+		}
+
+		// A read-only handle fails both the write and the truncation
+		// that would roll it back, so the log must refuse to go on.
+		writable := w.active
+
+		w.active, err = os.Open(w.segments[len(w.segments)-1].path)
+		if err != nil {
+			t.Fatalf("Open returned %v", err)
+		}
+
+		_, err = w.append([]byte("b"))
+		if !errors.Is(err, ErrLogIO) {
+			t.Fatalf("expected ErrLogIO, got %v", err)
+		}
+
+		_ = w.active.Close()
+		w.active = writable
+
+		_, err = w.append([]byte("c"))
+		if !errors.Is(err, ErrLogIO) {
+			t.Fatalf("expected the failed log to refuse appends, got %v", err)
+		}
+
+		_ = w.close()
+
+		reopened, records, err := openWAL(dir, 1024, 0, false)
+		if err != nil {
+			t.Fatalf("openWAL returned %v", err)
+		}
+		defer reopened.close()
+
+		if len(records) != 1 || string(records[0].data) != "a" {
+			t.Fatalf("expected only record a, got %d records", len(records))
+		}
+	})
+}
+
+func TestWAL_Closed(t *testing.T) {
+	t.Parallel()
+
+	w, _, err := openWAL(t.TempDir(), 1024, 0, false)
+	if err != nil {
+		t.Fatalf("openWAL returned %v", err)
+	}
+
+	_, _ = w.append([]byte("a"))
+
+	err = w.sync()
+	if err != nil {
+		t.Fatalf("sync returned %v", err)
+	}
+
+	err = w.close()
+	if err != nil {
+		t.Fatalf("close returned %v", err)
+	}
+
+	err = w.close()
+	if err != nil {
+		t.Fatalf("second close returned %v", err)
+	}
+
+	_, err = w.append([]byte("b"))
+	if !errors.Is(err, ErrClosed) {
+		t.Fatalf("expected ErrClosed from append, got %v", err)
+	}
+
+	err = w.ack(0)
+	if !errors.Is(err, ErrClosed) {
+		t.Fatalf("expected ErrClosed from ack, got %v", err)
+	}
+
+	err = w.sync()
+	if err != nil {
+		t.Fatalf("sync after close returned %v", err)
+	}
+}